@@ -19,10 +19,10 @@ package gitea
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 )
 
 const (
@@ -52,11 +52,7 @@ func NewClient(token string, optFns ...gitprovider.ClientOption) (gitprovider.Cl
 	if opts.Domain != nil {
 		domain = *opts.Domain
 	}
-	baseURL := domain
-	if !strings.Contains(domain, "://") {
-		baseURL = fmt.Sprintf("https://%s/", domain)
-	}
-
+	baseURL := baseURL(domain)
 	gt, err := gitea.NewClient(baseURL, gitea.SetHTTPClient(httpClient), gitea.SetToken(token))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gitea client for %s: %w", baseURL, err)
@@ -67,11 +63,18 @@ func NewClient(token string, optFns ...gitprovider.ClientOption) (gitprovider.Cl
 		destructiveActions = *opts.EnableDestructiveAPICalls
 	}
 
-	return newClient(gt, domain, destructiveActions), nil
+	c := newClient(gt, domain, destructiveActions)
+	c.defaultOwner = gitprovider.NewDefaultOwner(opts.DefaultOwner)
+	c.gitTransport = gitprovider.GitTransportOptions{HTTPClient: httpClient, CABundle: opts.CABundle}
+	if token != "" {
+		// Gitea accepts the token as username in basic authentication
+		c.gitTransport.Auth = &githttp.BasicAuth{Username: token, Password: "x-oauth-basic"}
+	}
+	return c, nil
 }
 
 func newClient(c *gitea.Client, domain string, destructiveActions bool) *Client {
-	ctx := &clientContext{c, domain, destructiveActions}
+	ctx := &clientContext{c: c, domain: domain, destructiveActions: destructiveActions}
 	return &Client{
 		clientContext: ctx,
 		orgs: &OrganizationsClient{
@@ -90,6 +93,8 @@ type clientContext struct {
 	c                  *gitea.Client
 	domain             string
 	destructiveActions bool
+	// gitTransport is used for talking to the Git endpoints, e.g. when creating backups.
+	gitTransport gitprovider.GitTransportOptions
 }
 
 // Client implements the gitprovider.Client interface.
@@ -102,6 +107,9 @@ type Client struct {
 	orgs      *OrganizationsClient
 	orgRepos  *OrgRepositoriesClient
 	userRepos *UserRepositoriesClient
+
+	// defaultOwner resolves the repository names given to Repo.
+	defaultOwner *gitprovider.DefaultOwner
 }
 
 // SupportedDomain returns the domain endpoint for this client, e.g. "gitea.com", "gitea.dev.com" or
@@ -143,3 +151,34 @@ func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
 func (c *Client) HasTokenPermission(ctx context.Context, permission gitprovider.TokenPermission) (bool, error) {
 	return false, gitprovider.ErrNoProviderSupport
 }
+
+// TokenScopes is not supported, as Gitea only lists the scopes of tokens to basic authenticated
+// users, and can't tell which token is in use. ErrNoProviderSupport is returned.
+func (c *Client) TokenScopes(_ context.Context) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// webhookEventNames maps the supported WebhookEvents to Gitea webhook event names.
+//
+//nolint:gochecknoglobals
+var webhookEventNames = map[gitprovider.WebhookEvent]string{
+	gitprovider.WebhookEventPush:              "push",
+	gitprovider.WebhookEventTagPush:           "create",
+	gitprovider.WebhookEventPullRequest:       "pull_request",
+	gitprovider.WebhookEventPullRequestReview: "pull_request_review",
+	gitprovider.WebhookEventIssues:            "issues",
+	gitprovider.WebhookEventComment:           "issue_comment",
+	gitprovider.WebhookEventRelease:           "release",
+	gitprovider.WebhookEventWiki:              "wiki",
+	gitprovider.WebhookEventRepository:        "repository",
+}
+
+// Repo gets the repository with the given name, owned by the owner given through WithDefaultOwner.
+func (c *Client) Repo(ctx context.Context, name string) (gitprovider.UserRepository, error) {
+	return c.defaultOwner.Repository(ctx, c, name)
+}
+
+// SupportedWebhookEvents returns the webhook events Gitea can trigger webhooks for.
+func (c *Client) SupportedWebhookEvents() []gitprovider.WebhookEvent {
+	return gitprovider.SortedWebhookEvents(webhookEventNames)
+}
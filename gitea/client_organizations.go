@@ -18,6 +18,7 @@ package gitea
 
 import (
 	"context"
+	"fmt"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -55,7 +56,7 @@ func (c *OrganizationsClient) Get(ctx context.Context, ref gitprovider.Organizat
 // List returns all available organizations, using multiple paginated requests if needed.
 func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organization, error) {
 	// GET /user/orgs
-	apiObjs, err := c.listOrgs()
+	apiObjs, err := c.listOrgs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +73,56 @@ func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organizat
 	return orgs, nil
 }
 
+// ListWithRole lists the organizations where the authenticated user has at least the given role.
+// Gitea doesn't filter organizations by role, hence the permissions of the user are read for each
+// organization, costing an API call per organization.
+func (c *OrganizationsClient) ListWithRole(ctx context.Context, role gitprovider.OrganizationRole) ([]gitprovider.Organization, error) {
+	if err := gitprovider.ValidateOrganizationRole(role); err != nil {
+		return nil, fmt.Errorf("invalid role %q: %w", role, gitprovider.ErrInvalidArgument)
+	}
+
+	// GET /user
+	user, res, err := c.c.GetMyUserInfo()
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	// GET /user/orgs
+	apiObjs, err := c.listOrgs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := []gitprovider.Organization{}
+	for _, apiObj := range apiObjs {
+		// GET /users/{username}/orgs/{org}/permissions
+		perms, res, err := c.c.GetOrgPermissions(apiObj.UserName, user.UserName)
+		if err != nil {
+			return nil, handleHTTPError(res, err)
+		}
+		if !organizationRoleFromAPI(perms).AtLeast(role) {
+			continue
+		}
+		orgs = append(orgs, newOrganization(c.clientContext, apiObj, gitprovider.OrganizationRef{
+			Domain:       c.domain,
+			Organization: apiObj.UserName,
+		}))
+	}
+
+	return orgs, nil
+}
+
+// organizationRoleFromAPI returns the OrganizationRole granted by the given permissions.
+func organizationRoleFromAPI(perms *gitea.OrgPermissions) gitprovider.OrganizationRole {
+	switch {
+	case perms.IsOwner:
+		return gitprovider.OrganizationRoleOwner
+	case perms.IsAdmin:
+		return gitprovider.OrganizationRoleAdmin
+	default:
+		return gitprovider.OrganizationRoleMember
+	}
+}
+
 // getOrg returns a specific organization the user has access to.
 func (c *OrganizationsClient) getOrg(orgName string) (*gitea.Organization, error) {
 	apiObj, res, err := c.c.GetOrg(orgName)
@@ -86,11 +137,11 @@ func (c *OrganizationsClient) getOrg(orgName string) (*gitea.Organization, error
 }
 
 // listOrgs returns all of current user's organizations.
-func (c *OrganizationsClient) listOrgs() ([]*gitea.Organization, error) {
+func (c *OrganizationsClient) listOrgs(ctx context.Context) ([]*gitea.Organization, error) {
 	opts := gitea.ListOrgsOptions{}
 	apiObjs := []*gitea.Organization{}
 
-	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
 		// GET /user/orgs"
 		pageObjs, resp, listErr := c.c.ListMyOrgs(opts)
 		if len(pageObjs) > 0 {
@@ -66,7 +66,7 @@ func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.Organi
 	}
 
 	// GET /orgs/{org}/repos
-	apiObjs, err := c.listOrgRepos(ref.Organization)
+	apiObjs, err := c.listOrgRepos(ctx, ref.Organization)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +83,23 @@ func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.Organi
 	return repos, nil
 }
 
+// Iterate calls fn for each repository in the given organization, fetching the pages lazily.
+func (c *OrgRepositoriesClient) Iterate(ctx context.Context, ref gitprovider.OrganizationRef, fn func(gitprovider.OrgRepository) error) error {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return err
+	}
+
+	// GET /orgs/{org}/repos
+	return c.iterateOrgRepos(ctx, ref.Organization, func(apiObj *gitea.Repository) error {
+		// apiObj is already validated at iterateOrgRepos
+		return fn(newOrgRepository(c.clientContext, apiObj, gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  apiObj.Name,
+		}))
+	})
+}
+
 // Create creates a repository for the given organization, with the data and options.
 //
 // ErrAlreadyExists will be returned if the resource already exists.
@@ -92,11 +109,38 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgR
 		return nil, err
 	}
 
-	apiObj, err := createRepository(ctx, c.c, ref, ref.Organization, req, opts...)
-	if err != nil {
+	apiObj, err := createRepository(ctx, c.c, c.gitTransport, ref, ref.Organization, req, opts...)
+	if apiObj == nil {
+		// Gitea doesn't find the organization if it's a user
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			if isOrg, lookupErr := isOrganization(c.c, ref.Organization); lookupErr == nil && !isOrg {
+				return nil, gitprovider.NewErrIdentityTypeMismatch(ref.Organization, false)
+			}
+		}
 		return nil, err
 	}
-	return newOrgRepository(c.clientContext, apiObj, ref), nil
+	// The repository is returned along with the error if it was created, but the steps after the
+	// creation failed, see RepositoryCreateOptions
+	return newOrgRepository(c.clientContext, apiObj, ref), err
+}
+
+// isOrganization returns whether the given login belongs to an organization rather than a user.
+// ErrNotFound is returned if it belongs to neither.
+func isOrganization(c *gitea.Client, login string) (bool, error) {
+	// GET /orgs/{org}
+	_, res, err := c.GetOrg(login)
+	if err == nil {
+		return true, nil
+	}
+	if err = handleHTTPError(res, err); !errors.Is(err, gitprovider.ErrNotFound) {
+		return false, err
+	}
+	// GET /users/{username}
+	_, res, err = c.GetUserInfo(login)
+	if err != nil {
+		return false, handleHTTPError(res, err)
+	}
+	return false, nil
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -111,10 +155,17 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 		return nil, false, err
 	}
 
+	o := gitprovider.MakeRepositoryReconcileOptions(opts...)
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, false, err
+	}
 	actual, err := c.Get(ctx, ref)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
+			if err := o.CheckPrecondition(nil); err != nil {
+				return nil, false, err
+			}
 			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
 			return resp, true, err
 		}
@@ -122,6 +173,11 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 		// Unexpected path, Get should succeed or return NotFound
 		return nil, false, err
 	}
+	actualInfo := actual.Get()
+	if err := o.CheckPrecondition(&actualInfo); err != nil {
+		return nil, false, err
+	}
+
 	// Run generic reconciliation
 	actionTaken, err := reconcileRepository(ctx, actual, req)
 	return actual, actionTaken, err
@@ -134,11 +190,11 @@ func getRepo(c *gitea.Client, owner, repo string) (*gitea.Repository, error) {
 }
 
 // listOrgRepos returns all repositories of the given organization the user has access to.
-func (c *OrgRepositoriesClient) listOrgRepos(org string) ([]*gitea.Repository, error) {
+func (c *OrgRepositoriesClient) listOrgRepos(ctx context.Context, org string) ([]*gitea.Repository, error) {
 	opts := gitea.ListOrgReposOptions{}
 	apiObjs := []*gitea.Repository{}
 
-	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
 		// GET /orgs/{org}/repos
 		pageObjs, resp, listErr := c.c.ListOrgRepos(org, opts)
 		if len(pageObjs) > 0 {
@@ -153,18 +209,60 @@ func (c *OrgRepositoriesClient) listOrgRepos(org string) ([]*gitea.Repository, e
 	return validateRepositoryObjects(apiObjs)
 }
 
-func createRepository(ctx context.Context, c *gitea.Client, ref gitprovider.RepositoryRef, orgName string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*gitea.Repository, error) {
+// iterateOrgRepos calls fn for each repository of the given organization the user has access to,
+// fetching one page at a time.
+func (c *OrgRepositoriesClient) iterateOrgRepos(ctx context.Context, org string, fn func(*gitea.Repository) error) error {
+	opts := gitea.ListOrgReposOptions{}
+
+	return allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /orgs/{org}/repos
+		pageObjs, resp, listErr := c.c.ListOrgRepos(org, opts)
+		if listErr != nil {
+			return resp, listErr
+		}
+		if len(pageObjs) == 0 {
+			return nil, nil
+		}
+		if _, err := validateRepositoryObjects(pageObjs); err != nil {
+			return nil, err
+		}
+		for _, apiObj := range pageObjs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := fn(apiObj); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	})
+}
+
+// maxDescriptionLength is the maximum length, in characters, of the repository descriptions
+// accepted by Gitea.
+const maxDescriptionLength = 2048
+
+// createRepository creates the repository, then applies the options needing further steps. If
+// these fail, the created repository is returned along with the error.
+func createRepository(ctx context.Context, c *gitea.Client, gitTransport gitprovider.GitTransportOptions, ref gitprovider.RepositoryRef, orgName string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*gitea.Repository, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
 	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
 		return nil, err
 	}
+	// Gitea always requires the private flag at creation time, hence the visibility can't be omitted
+	if *req.Visibility == gitprovider.RepositoryVisibilityDefault {
+		return nil, fmt.Errorf("gitea requires an explicit visibility when creating a repository: %w", gitprovider.ErrNoProviderSupport)
+	}
 
 	// Assemble the options struct based on the given options
 	o, err := gitprovider.MakeRepositoryCreateOptions(opts...)
 	if err != nil {
 		return nil, err
 	}
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, err
+	}
 
 	// Convert to the API object and apply the options
 	apiOpts := repositoryToAPI(&req, ref)
@@ -175,7 +273,36 @@ func createRepository(ctx context.Context, c *gitea.Client, ref gitprovider.Repo
 		apiOpts.License = knownLicenseTemplateMap[string(*o.LicenseTemplate)]
 	}
 
-	return createRepo(c, orgName, apiOpts)
+	apiObj, err := createRepo(c, orgName, apiOpts)
+	if err != nil {
+		return nil, err
+	}
+	// Gitea doesn't allow setting the message of the commit made by AutoInit, hence reword it
+	if apiOpts.AutoInit && o.InitialCommitMessage != nil {
+		if err := gitprovider.RewordInitialCommit(ctx, apiObj.CloneURL, *o.InitialCommitMessage, gitTransport); err != nil {
+			return apiObj, fmt.Errorf("failed to set the initial commit message: %w", err)
+		}
+	}
+	for _, label := range o.Labels {
+		label.Default()
+		// POST /repos/{owner}/{repo}/labels
+		_, res, err := c.CreateLabel(ref.GetIdentity(), ref.GetRepository(), labelToAPI(&label))
+		if err != nil {
+			return apiObj, fmt.Errorf("failed to create label %q: %w", label.Name, handleHTTPError(res, err))
+		}
+	}
+	return apiObj, nil
+}
+
+func labelToAPI(label *gitprovider.LabelInfo) gitea.CreateLabelOption {
+	opts := gitea.CreateLabelOption{
+		Name:  label.Name,
+		Color: "#" + label.Color,
+	}
+	if label.Description != nil {
+		opts.Description = *label.Description
+	}
+	return opts
 }
 
 func createRepo(c *gitea.Client, orgName string, apiOpts gitea.CreateRepoOption) (*gitea.Repository, error) {
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrgRepositoriesClient_Create_Labels(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v1/org/fluxcd/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		opts := gitea.CreateRepoOption{}
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		json.NewEncoder(w).Encode(&gitea.Repository{
+			ID:            1,
+			Name:          opts.Name,
+			Owner:         &gitea.User{UserName: "fluxcd"},
+			Private:       opts.Private,
+			DefaultBranch: opts.DefaultBranch,
+		})
+	})
+	labels := []gitea.CreateLabelOption{}
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		opts := gitea.CreateLabelOption{}
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		labels = append(labels, opts)
+		json.NewEncoder(w).Encode(&gitea.Label{ID: int64(len(labels)), Name: opts.Name, Color: opts.Color})
+	})
+
+	oc := &OrgRepositoriesClient{clientContext: c}
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	_, err := oc.Create(context.Background(), ref, gitprovider.RepositoryInfo{
+		Visibility: gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibilityPrivate),
+	}, &gitprovider.RepositoryCreateOptions{
+		Labels: []gitprovider.LabelInfo{
+			{Name: "bug", Color: "#D73A4A"},
+			{Name: "enhancement", Color: "a2eeef", Description: gitprovider.StringVar("New feature or request")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	want := []gitea.CreateLabelOption{
+		{Name: "bug", Color: "#d73a4a"},
+		{Name: "enhancement", Color: "#a2eeef", Description: "New feature or request"},
+	}
+	if diff := cmp.Diff(want, labels); diff != "" {
+		t.Errorf("created labels (-want +got):\n%s", diff)
+	}
+}
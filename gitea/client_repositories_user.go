@@ -59,7 +59,7 @@ func (c *UserRepositoriesClient) List(ctx context.Context, ref gitprovider.UserR
 	}
 
 	// GET /users/{username}/repos
-	apiObjs, err := c.listUserRepos(ref.UserLogin)
+	apiObjs, err := c.listUserRepos(ctx, ref.UserLogin)
 	if err != nil {
 		return nil, err
 	}
@@ -76,11 +76,11 @@ func (c *UserRepositoriesClient) List(ctx context.Context, ref gitprovider.UserR
 	return repos, nil
 }
 
-func (c *UserRepositoriesClient) listUserRepos(username string) ([]*gitea.Repository, error) {
+func (c *UserRepositoriesClient) listUserRepos(ctx context.Context, username string) ([]*gitea.Repository, error) {
 	opts := gitea.ListReposOptions{}
 	apiObjs := []*gitea.Repository{}
 
-	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
 		// GET /users/{username}/repos
 		pageObjs, resp, listErr := c.c.ListUserRepos(username, opts)
 		if len(pageObjs) > 0 {
@@ -129,11 +129,15 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 	}
 
 	if ref.GetIdentity() != idRef.GetIdentity() {
+		// Tell an organization passed as a user apart from another user
+		if isOrg, err := isOrganization(c.c, ref.GetIdentity()); err == nil && isOrg {
+			return nil, gitprovider.NewErrIdentityTypeMismatch(ref.GetIdentity(), true)
+		}
 		return nil, gitprovider.NewErrIncorrectUser(ref.GetIdentity())
 	}
 
-	apiObj, err := createRepository(ctx, c.c, ref, "", req, opts...)
-	if err != nil {
+	apiObj, err := createRepository(ctx, c.c, c.gitTransport, ref, "", req, opts...)
+	if apiObj == nil {
 		return nil, err
 	}
 
@@ -142,7 +146,9 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 	}
 	ref.UserLogin = apiObj.Owner.UserName
 
-	return newUserRepository(c.clientContext, apiObj, ref), nil
+	// The repository is returned along with the error if it was created, but the steps after the
+	// creation failed, see RepositoryCreateOptions
+	return newUserRepository(c.clientContext, apiObj, ref), err
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -157,10 +163,17 @@ func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.
 		return nil, false, err
 	}
 
+	o := gitprovider.MakeRepositoryReconcileOptions(opts...)
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, false, err
+	}
 	actual, err := c.Get(ctx, ref)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
+			if err := o.CheckPrecondition(nil); err != nil {
+				return nil, false, err
+			}
 			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
 			return resp, true, err
 		}
@@ -169,6 +182,11 @@ func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.
 		return nil, false, err
 	}
 
+	actualInfo := actual.Get()
+	if err := o.CheckPrecondition(&actualInfo); err != nil {
+		return nil, false, err
+	}
+
 	// Run generic reconciliation
 	actionTaken, err := reconcileRepository(ctx, actual, req)
 	return actual, actionTaken, err
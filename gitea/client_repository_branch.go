@@ -18,6 +18,9 @@ package gitea
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -32,23 +35,62 @@ type BranchClient struct {
 	ref gitprovider.RepositoryRef
 }
 
-// Create creates a branch with the given specifications.
-// Creating a branch from a commit is noy supported by Gitea, the sha refers to the branch to create from.
-// see: https://github.com/go-gitea/gitea/issues/22139
+// Create creates a branch with the given specifications. sha is the commit to create the branch
+// from, abbreviated or not, or the name of a branch or tag. Creating a branch from a commit
+// requires Gitea 1.21 or later.
 func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
-
-	// Doesn't seem to support specific sha?
-	opts := gitea.CreateBranchOption{
-		BranchName:    branch,
-		OldBranchName: sha,
+	if err := (gitea.CreateBranchOption{BranchName: branch}).Validate(); err != nil {
+		return fmt.Errorf("%v: %w", err, gitprovider.ErrInvalidArgument)
 	}
-	err := opts.Validate()
+	sha, err := gitprovider.ExpandShortSHA(ctx, &CommitClient{clientContext: c.clientContext, ref: c.ref}, sha)
 	if err != nil {
 		return err
 	}
-	if _, _, err := c.c.CreateBranch(c.ref.GetIdentity(), c.ref.GetRepository(), opts); err != nil {
-		return err
+
+	// The Gitea SDK only supports creating a branch from another branch
+	opts := createBranchOption{BranchName: branch, OldRefName: sha}
+	// POST /repos/{owner}/{repo}/branches
+	return c.doAPIRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/branches", c.ref.GetIdentity(), c.ref.GetRepository()), opts, nil)
+}
+
+// createBranchOption is the request body of the branch creation endpoint, with the reference to
+// create the branch from, which the Gitea SDK doesn't cover.
+type createBranchOption struct {
+	// BranchName is the name of the branch to create.
+	BranchName string `json:"new_branch_name"`
+	// OldRefName is the commit SHA, branch or tag to create the branch from.
+	OldRefName string `json:"old_ref_name"`
+}
+
+// RequiredChecks returns the names of the status checks required by the protection of the branch.
+// The protection is looked up by the branch name, so protections matching branches by pattern
+// aren't found.
+func (c *BranchClient) RequiredChecks(_ context.Context, branch string) ([]string, error) {
+	protection, res, err := c.c.GetBranchProtection(c.ref.GetIdentity(), c.ref.GetRepository(), branch)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	if !protection.EnableStatusCheck {
+		return []string{}, nil
 	}
+	return append([]string{}, protection.StatusCheckContexts...), nil
+}
+
+// RequiresSignedCommits returns whether the protection of the branch requires signed commits.
+// Like RequiredChecks, protections matching branches by pattern aren't found.
+func (c *BranchClient) RequiresSignedCommits(_ context.Context, branch string) (bool, error) {
+	protection, res, err := c.c.GetBranchProtection(c.ref.GetIdentity(), c.ref.GetRepository(), branch)
+	if err != nil {
+		err = handleHTTPError(res, err)
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return protection.RequireSignedCommits, nil
+}
 
-	return nil
+// DefaultReviewers is not supported by Gitea, ErrNoProviderSupport is returned.
+func (c *BranchClient) DefaultReviewers(_ context.Context, _ string) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
 }
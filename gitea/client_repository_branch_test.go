@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchClient_Create(t *testing.T) {
+	const fullSHA = "5f2c7a1e9b3d4c6f8a0b1c2d3e4f5a6b7c8d9e0f"
+	tests := []struct {
+		name        string
+		sha         string
+		wantRefName string
+	}{
+		{
+			name:        "from a branch",
+			sha:         "main",
+			wantRefName: "main",
+		},
+		{
+			name:        "from an abbreviated commit SHA",
+			sha:         "5f2c7a1",
+			wantRefName: fullSHA,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/git/commits/5f2c7a1", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"sha": %q}`, fullSHA)
+			})
+			var payload map[string]string
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"name": "feature"}`)
+			})
+
+			branches := &BranchClient{
+				clientContext: c,
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			if err := branches.Create(context.Background(), "feature", tt.sha); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			want := map[string]string{"new_branch_name": "feature", "old_ref_name": tt.wantRefName}
+			if diff := cmp.Diff(want, payload); diff != "" {
+				t.Errorf("payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,14 +18,30 @@ package gitea
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
-// CommitClient implements the gitprovider.CommitClient interface.
-var _ gitprovider.CommitClient = &CommitClient{}
+const (
+	// commitFilterPageSize is the number of commits listed per request when filtering them
+	// client-side, the default maximum of Gitea.
+	commitFilterPageSize = 50
+	// commitDefaultPageSize is the default number of commits per page of Gitea.
+	commitDefaultPageSize = 30
+)
+
+// CommitClient implements the gitprovider.CommitClient and gitprovider.CommitContentEncoder interfaces.
+var (
+	_ gitprovider.CommitClient         = &CommitClient{}
+	_ gitprovider.CommitContentEncoder = &CommitClient{}
+)
 
 // CommitClient operates on the commits for a specific repository.
 type CommitClient struct {
@@ -34,10 +50,39 @@ type CommitClient struct {
 }
 
 // ListPage lists all repository commits of the given page and page size.
-// ListPage returns all available repository commits
-// using multiple paginated requests if needed.
 func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
-	dks, err := c.listPage(ctx, branch, perPage, page)
+	if err := gitprovider.ValidatePage(perPage, page); err != nil {
+		return nil, err
+	}
+	return c.List(ctx, gitprovider.CommitListOptions{
+		Branch:  branch,
+		PerPage: perPage,
+		Page:    page,
+	})
+}
+
+// Get returns the commit with the given SHA.
+//
+// ErrNotFound is returned if the commit does not exist.
+func (c *CommitClient) Get(_ context.Context, sha string) (gitprovider.Commit, error) {
+	// GET /repos/{owner}/{repo}/git/commits/{sha}
+	apiObj, err := c.getCommit(c.ref.GetIdentity(), c.ref.GetRepository(), sha)
+	if err != nil {
+		return nil, err
+	}
+	return newCommit(c, apiObj), nil
+}
+
+// List lists the repository commits matching the given options.
+// The branch and path filters are applied server-side. Gitea doesn't support the author and time
+// filters, so when any of them is set, all commits are listed up to the first one preceding
+// Since, or the whole history without Since, and the requested page is cut from the matching
+// commits. This costs a request per commitFilterPageSize commits.
+func (c *CommitClient) List(ctx context.Context, opts gitprovider.CommitListOptions) ([]gitprovider.Commit, error) {
+	if err := opts.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	dks, err := c.list(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -49,9 +94,15 @@ func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, pag
 	return commits, nil
 }
 
-func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, page int) ([]*commitType, error) {
-	// GET /repos/{owner}/{repo}/commits
-	apiObjs, err := c.listCommits(c.ref.GetIdentity(), c.ref.GetRepository(), branch, perPage, page)
+func (c *CommitClient) list(ctx context.Context, opts gitprovider.CommitListOptions) ([]*commitType, error) {
+	var apiObjs []*gitea.Commit
+	var err error
+	if opts.HasFilters() {
+		apiObjs, err = c.listMatchingCommits(ctx, opts)
+	} else {
+		// GET /repos/{owner}/{repo}/commits
+		apiObjs, err = c.listCommits(c.ref.GetIdentity(), c.ref.GetRepository(), opts)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -65,70 +116,240 @@ func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, pag
 	return keys, nil
 }
 
+// listMatchingCommits lists all commits passing the author and time filters of opts, and returns
+// the page of them selected by opts.
+func (c *CommitClient) listMatchingCommits(ctx context.Context, opts gitprovider.CommitListOptions) ([]*gitea.Commit, error) {
+	matching := []*gitea.Commit{}
+	pageOpts := opts
+	pageOpts.PerPage = commitFilterPageSize
+	for pageOpts.Page = 1; ; pageOpts.Page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// GET /repos/{owner}/{repo}/commits
+		apiObjs, err := c.listCommits(c.ref.GetIdentity(), c.ref.GetRepository(), pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiObj := range apiObjs {
+			if opts.Before(commitCreatedAt(apiObj)) {
+				start, end := opts.PageBounds(len(matching), commitDefaultPageSize)
+				return matching[start:end], nil
+			}
+			if commitMatchesOptions(apiObj, opts) {
+				matching = append(matching, apiObj)
+			}
+		}
+		if len(apiObjs) < commitFilterPageSize {
+			start, end := opts.PageBounds(len(matching), commitDefaultPageSize)
+			return matching[start:end], nil
+		}
+	}
+}
+
+// listCommitDates returns the creation dates of the commits of branch created at or after since.
+// As Gitea lists the commits in topological order, the older commits are filtered out across all
+// pages, listing at most gitprovider.CommitActivityMaxCommits commits.
+func (c *CommitClient) listCommitDates(ctx context.Context, branch string, since time.Time) ([]time.Time, error) {
+	dates := []time.Time{}
+	pageOpts := gitprovider.CommitListOptions{Branch: branch, PerPage: commitFilterPageSize}
+	maxPages := gitprovider.CommitActivityMaxCommits / commitFilterPageSize
+	for pageOpts.Page = 1; pageOpts.Page <= maxPages; pageOpts.Page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// GET /repos/{owner}/{repo}/commits
+		apiObjs, err := c.listCommits(c.ref.GetIdentity(), c.ref.GetRepository(), pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiObj := range apiObjs {
+			if createdAt := commitCreatedAt(apiObj); !createdAt.Before(since) {
+				dates = append(dates, createdAt)
+			}
+		}
+		if len(apiObjs) < commitFilterPageSize {
+			break
+		}
+	}
+	return dates, nil
+}
+
+func commitCreatedAt(apiObj *gitea.Commit) time.Time {
+	if apiObj.CommitMeta == nil {
+		return time.Time{}
+	}
+	return apiObj.Created
+}
+
+// commitMatchesOptions returns true if the commit passes the author and time filters of opts.
+func commitMatchesOptions(apiObj *gitea.Commit, opts gitprovider.CommitListOptions) bool {
+	authors := []string{}
+	if apiObj.Author != nil {
+		authors = append(authors, apiObj.Author.UserName)
+	}
+	if apiObj.RepoCommit != nil && apiObj.RepoCommit.Author != nil {
+		authors = append(authors, apiObj.RepoCommit.Author.Name, apiObj.RepoCommit.Author.Email)
+	}
+	return opts.Matches(commitCreatedAt(apiObj), authors...)
+}
+
 // Create creates a commit with the given specifications.
-// This method creates a commit with a single file.
-// TODO: fix when gitea supports creating commits with multiple files
-func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+// The content of the files must be base64-encoded, see EncodeCommitContent.
+// Commits with several files, or deleting files, require Gitea 1.20 or later.
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile, opts ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files added")
 	}
+	if err := gitprovider.ValidateCommitFiles(files); err != nil {
+		return nil, err
+	}
 
-	if len(files) > 1 {
-		return nil, fmt.Errorf("creating commits with multiple files is not supported")
+	// Gitea can't create commits conditionally, hence check the head of the branch first
+	o := gitprovider.MakeCommitCreateOptions(opts...)
+	if err := o.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	if len(o.Parents) > 0 {
+		// Gitea always commits on top of the branch head, hence push the commit through Git
+		return c.commitOnParents(ctx, branch, message, files, o)
+	}
+	if o.ExpectedHeadSHA != "" {
+		head, err := c.ResolveRef(ctx, branch)
+		if err != nil {
+			return nil, err
+		}
+		if err := o.CheckHead(head); err != nil {
+			return nil, err
+		}
 	}
 
-	resp, err := c.createCommits(c.ref.GetIdentity(), c.ref.GetRepository(), *files[0].Path, &gitea.CreateFileOptions{
-		Content: *files[0].Content,
-		FileOptions: gitea.FileOptions{
-			Message:    message,
-			BranchName: branch,
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create commit: %w", err)
+	owner, repo := c.ref.GetIdentity(), c.ref.GetRepository()
+	fileOpts := gitea.FileOptions{
+		Message:    message,
+		BranchName: branch,
+	}
+	// Gitea uses the author as committer, and the authenticated user if no author is given
+	if o.AuthorName != "" {
+		fileOpts.Author = gitea.Identity{Name: o.AuthorName, Email: o.AuthorEmail}
+	}
+	// Gitea uses the current time for the dates left zero
+	if o.AuthorDate != nil {
+		fileOpts.Dates.Author = *o.AuthorDate
+		fileOpts.Dates.Committer = *o.AuthorDate
+	}
+	if o.CommitterDate != nil {
+		fileOpts.Dates.Committer = *o.CommitterDate
+	}
+	var apiObj *gitea.FileCommitResponse
+	if len(files) == 1 && files[0].GetAction() == gitprovider.CommitFileActionWrite {
+		resp, err := c.createCommits(owner, repo, *files[0].Path, &gitea.CreateFileOptions{
+			Content:     *files[0].Content,
+			FileOptions: fileOpts,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create commit: %w", err)
+		}
+		apiObj = resp.Commit
+	} else {
+		var err error
+		if apiObj, err = c.changeFiles(ctx, owner, repo, fileOpts, files); err != nil {
+			return nil, fmt.Errorf("failed to create commit: %w", err)
+		}
 	}
 
 	commit := &gitea.Commit{
-		HTMLURL: resp.Commit.HTMLURL,
+		HTMLURL: apiObj.HTMLURL,
 		Author: &gitea.User{
-			UserName: resp.Commit.Author.Name,
-			Email:    resp.Commit.Author.Email,
+			UserName: apiObj.Author.Name,
+			Email:    apiObj.Author.Email,
 		},
 		Committer: &gitea.User{
-			UserName: resp.Commit.Committer.Name,
-			Email:    resp.Commit.Committer.Email,
+			UserName: apiObj.Committer.Name,
+			Email:    apiObj.Committer.Email,
 		},
-		Parents: resp.Commit.Parents,
+		Parents: apiObj.Parents,
 	}
-	commit.CommitMeta = &resp.Commit.CommitMeta
+	commit.CommitMeta = &apiObj.CommitMeta
 
 	return newCommit(c, commit), nil
 }
 
-// listCommits lists all repository commits of the given branch.
-// It accepts a page size and page number to support pagination.
-func (c *CommitClient) listCommits(owner, repo, branch string, perPage int, page int) ([]*gitea.Commit, error) {
+// EncodeCommitContent base64-encodes content, as taken by Create.
+func (c *CommitClient) EncodeCommitContent(content string) string {
+	return base64.StdEncoding.EncodeToString([]byte(content))
+}
+
+// commitOnParents creates a commit with the parents given through o using the Git protocol.
+// The base64-encoded content of the files is decoded first, as Git takes the raw content.
+func (c *CommitClient) commitOnParents(ctx context.Context, branch, message string, files []gitprovider.CommitFile, o gitprovider.CommitCreateOptions) (gitprovider.Commit, error) {
+	decoded := make([]gitprovider.CommitFile, 0, len(files))
+	for _, file := range files {
+		if file.GetAction() == gitprovider.CommitFileActionWrite {
+			content, err := base64.StdEncoding.DecodeString(*file.Content)
+			if err != nil {
+				return nil, fmt.Errorf("content of %q isn't base64-encoded: %w", *file.Path, gitprovider.ErrInvalidArgument)
+			}
+			file.Content = gitprovider.StringVar(string(content))
+		}
+		decoded = append(decoded, file)
+	}
+	return gitprovider.CommitOnParents(ctx, c.ref.GetCloneURL(gitprovider.TransportTypeHTTPS), branch, message, decoded, o, c.gitTransport)
+}
+
+// SignatureKey returns the ID of the key that signed the commit with the given SHA.
+// ErrNotFound is returned if the commit is not signed.
+func (c *CommitClient) SignatureKey(_ context.Context, sha string) (string, error) {
+	// GET /repos/{owner}/{repo}/git/commits/{sha}
+	apiObj, err := c.getCommit(c.ref.GetIdentity(), c.ref.GetRepository(), sha)
+	if err != nil {
+		return "", err
+	}
+	if apiObj.RepoCommit == nil || apiObj.RepoCommit.Verification == nil || apiObj.RepoCommit.Verification.Signature == "" {
+		return "", gitprovider.ErrNotFound
+	}
+	return gitprovider.SignatureKeyID(apiObj.RepoCommit.Verification.Signature)
+}
+
+// ResolveRef resolves a branch, a tag or an abbreviated commit SHA to the full SHA of its commit.
+// Gitea reports abbreviated SHAs matching several commits as not found. To tell these apart,
+// at the cost of fetching the whole repository, use gitprovider.ResolveShortSHA.
+func (c *CommitClient) ResolveRef(_ context.Context, ref string) (string, error) {
+	// GET /repos/{owner}/{repo}/git/commits/{sha}
+	apiObj, err := c.getCommit(c.ref.GetIdentity(), c.ref.GetRepository(), ref)
+	if err != nil {
+		return "", err
+	}
+	if apiObj.SHA == "" {
+		return "", fmt.Errorf("didn't expect the commit SHA to be empty: %w", gitprovider.ErrInvalidServerData)
+	}
+	return apiObj.SHA, nil
+}
+
+// getCommit returns the commit with the given SHA.
+func (c *CommitClient) getCommit(owner, repo, sha string) (*gitea.Commit, error) {
+	apiObj, res, err := c.c.GetSingleCommit(owner, repo, sha)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return apiObj, nil
+}
+
+// listCommits lists a single page of the repository commits of the branch and path given in lcOpts.
+// The page size and 1-based page number are passed through to the server.
+func (c *CommitClient) listCommits(owner, repo string, lcOpts gitprovider.CommitListOptions) ([]*gitea.Commit, error) {
 	opts := gitea.ListCommitOptions{
 		ListOptions: gitea.ListOptions{
-			PageSize: perPage,
-			Page:     page,
+			PageSize: lcOpts.PerPage,
+			Page:     lcOpts.Page,
 		},
-		SHA: branch,
+		SHA:  lcOpts.Branch,
+		Path: lcOpts.Path,
 	}
-	apiObjs := []*gitea.Commit{}
-	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
-		pageObjs, resp, listErr := c.c.ListRepoCommits(owner, repo, opts)
-		if len(pageObjs) > 0 {
-			apiObjs = append(apiObjs, pageObjs...)
-			return resp, listErr
-		}
-		return nil, nil
-	})
-
+	apiObjs, res, err := c.c.ListRepoCommits(owner, repo, opts)
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(res, err)
 	}
-
 	return apiObjs, nil
 }
 
@@ -140,3 +361,100 @@ func (c *CommitClient) createCommits(owner, repo string, path string, req *gitea
 	}
 	return apiObj, nil
 }
+
+// changeFileOperation is a file operation of a changeFilesOptions request.
+type changeFileOperation struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Content   string `json:"content,omitempty"`
+	SHA       string `json:"sha,omitempty"`
+}
+
+// changeFilesOptions is the request for changing several files in a single commit, which the Gitea
+// SDK doesn't cover yet.
+type changeFilesOptions struct {
+	gitea.FileOptions
+	Files []changeFileOperation `json:"files"`
+}
+
+// changeFiles creates a commit with the given files as described by fileOpts, writing or deleting
+// each as its action says. Gitea creates the missing parent directories of the files.
+func (c *CommitClient) changeFiles(ctx context.Context, owner, repo string, fileOpts gitea.FileOptions, files []gitprovider.CommitFile) (*gitea.FileCommitResponse, error) {
+	req := changeFilesOptions{
+		FileOptions: fileOpts,
+		Files:       make([]changeFileOperation, 0, len(files)),
+	}
+	for _, file := range files {
+		if file.GetAction() == gitprovider.CommitFileActionWrite {
+			req.Files = append(req.Files, changeFileOperation{Operation: "create", Path: *file.Path, Content: *file.Content})
+			continue
+		}
+		// Deleting a file requires its current SHA
+		contents, res, err := c.c.GetContents(owner, repo, fileOpts.BranchName, *file.Path)
+		if err != nil {
+			err = handleHTTPError(res, err)
+			if errors.Is(err, gitprovider.ErrNotFound) {
+				return nil, fmt.Errorf("%q: %w", *file.Path, gitprovider.ErrFileNotFound)
+			}
+			return nil, err
+		}
+		req.Files = append(req.Files, changeFileOperation{Operation: "delete", Path: *file.Path, SHA: contents.SHA})
+	}
+
+	apiObj := &gitea.FileResponse{}
+	// POST /repos/{owner}/{repo}/contents
+	if err := c.doAPIRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/contents", owner, repo), req, apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj.Commit, nil
+}
+
+// ContainedInBranches returns the names of the branches containing the commit with the given SHA.
+// Gitea can't list the branches containing a commit, so the head of each branch is compared with
+// the commit instead: the branch contains it if the commit has no commits the branch is missing.
+// Comparing commits requires Gitea 1.22 or later.
+func (c *CommitClient) ContainedInBranches(ctx context.Context, sha string) ([]string, error) {
+	owner, repo := c.ref.GetIdentity(), c.ref.GetRepository()
+	// Make sure the commit exists, as comparing with a missing commit fails for every branch
+	sha, err := c.ResolveRef(ctx, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []string{}
+	opts := gitea.ListRepoBranchesOptions{}
+	err = allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/branches
+		apiObjs, res, err := c.c.ListRepoBranches(owner, repo, opts)
+		if err != nil {
+			return res, err
+		}
+		// Stop on the first empty page
+		if len(apiObjs) == 0 {
+			return nil, nil
+		}
+		for _, apiObj := range apiObjs {
+			branches = append(branches, apiObj.Name)
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(branches)
+
+	return gitprovider.BranchesContaining(ctx, branches, func(_ context.Context, branch string) (bool, error) {
+		// GET /repos/{owner}/{repo}/compare/{base}...{head}
+		apiObj, res, err := c.c.CompareCommits(owner, repo, branch, sha)
+		if err != nil {
+			return false, handleHTTPError(res, err)
+		}
+		return apiObj.TotalCommits == 0, nil
+	})
+}
+
+// CheckAnnotations lists the check annotations of the commit with the given SHA.
+// Gitea doesn't have check annotations, so this returns ErrNoProviderSupport.
+func (c *CommitClient) CheckAnnotations(_ context.Context, _ string) ([]gitprovider.Annotation, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestCommitClient(t *testing.T) (*http.ServeMux, *CommitClient) {
+	mux, c := setup(t)
+	return mux, &CommitClient{
+		clientContext: c,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestCommitClient_List(t *testing.T) {
+	since := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	// Gitea lists the commits newest first
+	commits := `[
+		{"sha": "b1", "created": "2023-01-16T12:00:00Z", "author": {"login": "bob"}, "commit": {"author": {"name": "Bob", "email": "bob@example.com"}}},
+		{"sha": "a2", "created": "2023-01-15T12:00:00Z", "author": {"login": "alice"}, "commit": {"author": {"name": "Alice", "email": "alice@example.com"}}},
+		{"sha": "a1", "created": "2022-12-31T12:00:00Z", "author": {"login": "alice"}, "commit": {"author": {"name": "Alice", "email": "alice@example.com"}}}
+	]`
+
+	tests := []struct {
+		name      string
+		opts      gitprovider.CommitListOptions
+		response  string
+		wantQuery url.Values
+		wantShas  []string
+	}{
+		{
+			name: "filters",
+			opts: gitprovider.CommitListOptions{
+				Branch:  "main",
+				Author:  "alice@example.com",
+				Since:   &since,
+				Until:   &until,
+				Path:    "docs",
+				PerPage: 10,
+			},
+			response: commits,
+			wantQuery: url.Values{
+				"sha":   []string{"main"},
+				"path":  []string{"docs"},
+				"page":  []string{"1"},
+				"limit": []string{"50"},
+			},
+			wantShas: []string{"a2"},
+		},
+		{
+			name:     "empty result",
+			opts:     gitprovider.CommitListOptions{Branch: "main"},
+			response: `[]`,
+			wantQuery: url.Values{
+				"sha":   []string{"main"},
+				"page":  []string{"1"},
+				"limit": []string{"0"},
+			},
+			wantShas: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(tt.wantQuery, r.URL.Query()); diff != "" {
+					t.Errorf("query (-want +got):\n%s", diff)
+				}
+				fmt.Fprint(w, tt.response)
+			})
+
+			got, err := c.List(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			gotShas := []string{}
+			for _, commit := range got {
+				gotShas = append(gotShas, commit.Get().Sha)
+			}
+			if diff := cmp.Diff(tt.wantShas, gotShas); diff != "" {
+				t.Errorf("List() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommitClient_List_FilterAcrossPages(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	pages := []string{}
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		// Alternate the authors, the first page being full, and the second one the last
+		apiObjs := []map[string]interface{}{}
+		count := commitFilterPageSize
+		if page == "2" {
+			count = 10
+		}
+		for i := 0; i < count; i++ {
+			login := "alice"
+			if i%2 == 1 {
+				login = "bob"
+			}
+			apiObjs = append(apiObjs, map[string]interface{}{
+				"sha":    fmt.Sprintf("p%s-%d", page, i),
+				"author": map[string]string{"login": login},
+			})
+		}
+		if err := json.NewEncoder(w).Encode(apiObjs); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// The matching commits are p1-0, p1-2, ..., p1-48, p2-0, ..., p2-8, the third page of 10 of
+	// them starts at p1-40
+	got, err := c.List(context.Background(), gitprovider.CommitListOptions{Author: "alice", PerPage: 10, Page: 3})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	gotShas := []string{}
+	for _, commit := range got {
+		gotShas = append(gotShas, commit.Get().Sha)
+	}
+	want := []string{"p1-40", "p1-42", "p1-44", "p1-46", "p1-48", "p2-0", "p2-2", "p2-4", "p2-6", "p2-8"}
+	if diff := cmp.Diff(want, gotShas); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, pages); diff != "" {
+		t.Errorf("listed pages (-want +got):\n%s", diff)
+	}
+}
+
+// pagedCommitShas are the commits served page by page by the ListPage fake, newest first.
+var pagedCommitShas = []string{"c5", "c4", "c3", "c2", "c1"}
+
+func TestCommitClient_ListPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		perPage  int
+		page     int
+		wantShas []string
+		wantErr  error
+	}{
+		{name: "first page", perPage: 2, page: 1, wantShas: []string{"c5", "c4"}},
+		{name: "second page", perPage: 2, page: 2, wantShas: []string{"c3", "c2"}},
+		{name: "last page", perPage: 2, page: 3, wantShas: []string{"c1"}},
+		{name: "past the end", perPage: 2, page: 4, wantShas: []string{}},
+		{name: "zero page", perPage: 2, page: 0, wantErr: gitprovider.ErrInvalidArgument},
+		{name: "zero page size", perPage: 0, page: 1, wantErr: gitprovider.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				perPage, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+				start := min((page-1)*perPage, len(pagedCommitShas))
+				end := min(start+perPage, len(pagedCommitShas))
+				items := []string{}
+				for _, sha := range pagedCommitShas[start:end] {
+					items = append(items, fmt.Sprintf(`{"sha": %q, "created": "2023-01-15T12:00:00Z", "author": {"login": "alice"}, "commit": {"author": {"name": "Alice", "email": "alice@example.com"}}}`, sha))
+				}
+				fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+			})
+
+			got, err := c.ListPage(context.Background(), "main", tt.perPage, tt.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListPage() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			gotShas := []string{}
+			for _, commit := range got {
+				gotShas = append(gotShas, commit.Get().Sha)
+			}
+			if diff := cmp.Diff(tt.wantShas, gotShas); diff != "" {
+				t.Errorf("ListPage() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommitClient_Create_NestedPaths(t *testing.T) {
+	const response = `{"commit": {"sha": "c3", "html_url": "https://gitea.example.com/fluxcd/repo/commit/c3", "author": {"name": "alice"}, "committer": {"name": "alice"}}}`
+	tests := []struct {
+		name      string
+		files     []gitprovider.CommitFile
+		wantPaths []string
+	}{
+		{
+			name: "single file",
+			files: []gitprovider.CommitFile{
+				{Path: gitprovider.StringVar("a/b/c/file.txt"), Content: gitprovider.StringVar("aGVsbG8=")},
+			},
+			wantPaths: []string{"a/b/c/file.txt"},
+		},
+		{
+			name: "multiple files",
+			files: []gitprovider.CommitFile{
+				{Path: gitprovider.StringVar("a/b/c/file.txt"), Content: gitprovider.StringVar("aGVsbG8=")},
+				{Path: gitprovider.StringVar("a/b/.gitkeep"), Content: gitprovider.StringVar("")},
+			},
+			wantPaths: []string{"a/b/c/file.txt", "a/b/.gitkeep"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			gotPaths := []string{}
+			// Gitea creates the missing directories of the created files
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/contents/", func(w http.ResponseWriter, r *http.Request) {
+				gotPaths = append(gotPaths, strings.TrimPrefix(r.URL.Path, "/api/v1/repos/fluxcd/repo/contents/"))
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, response)
+			})
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/contents", func(w http.ResponseWriter, r *http.Request) {
+				var payload struct {
+					Branch string `json:"branch"`
+					Files  []struct {
+						Operation string `json:"operation"`
+						Path      string `json:"path"`
+					} `json:"files"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				if payload.Branch != "main" {
+					t.Errorf("branch = %q, want main", payload.Branch)
+				}
+				for _, f := range payload.Files {
+					if f.Operation != "create" {
+						t.Errorf("operation on %q = %q, want create", f.Path, f.Operation)
+					}
+					gotPaths = append(gotPaths, f.Path)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, response)
+			})
+
+			commit, err := c.Create(context.Background(), "main", "scaffold", tt.files)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.wantPaths, gotPaths); diff != "" {
+				t.Errorf("committed paths (-want +got):\n%s", diff)
+			}
+			if got := commit.Get().Sha; got != "c3" {
+				t.Errorf("Create() sha = %q, want c3", got)
+			}
+		})
+	}
+}
+
+func TestCommitClient_Create_DeleteFiles(t *testing.T) {
+	deletions := []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("a.txt"), Action: gitprovider.CommitFileActionDelete},
+		{Path: gitprovider.StringVar("dir/b.txt"), Action: gitprovider.CommitFileActionDelete},
+		{Path: gitprovider.StringVar("dir/sub/c.txt"), Action: gitprovider.CommitFileActionDelete},
+	}
+	tests := []struct {
+		name        string
+		existing    map[string]string
+		wantErr     error
+		wantCommits int
+	}{
+		{
+			name:        "all files exist",
+			existing:    map[string]string{"a.txt": "s1", "dir/b.txt": "s2", "dir/sub/c.txt": "s3"},
+			wantCommits: 1,
+		},
+		{
+			name:     "a file doesn't exist",
+			existing: map[string]string{"a.txt": "s1", "dir/sub/c.txt": "s3"},
+			wantErr:  gitprovider.ErrFileNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/contents/", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected %s on %s", r.Method, r.URL.Path)
+				}
+				p := strings.TrimPrefix(r.URL.Path, "/api/v1/repos/fluxcd/repo/contents/")
+				sha, ok := tt.existing[p]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				fmt.Fprintf(w, `{"type": "file", "path": %q, "sha": %q}`, p, sha)
+			})
+			commits := 0
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/contents", func(w http.ResponseWriter, r *http.Request) {
+				commits++
+				var payload struct {
+					Files []struct {
+						Operation string `json:"operation"`
+						Path      string `json:"path"`
+						SHA       string `json:"sha"`
+					} `json:"files"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				if len(payload.Files) != len(deletions) {
+					t.Errorf("committed %d files, want %d", len(payload.Files), len(deletions))
+				}
+				for _, f := range payload.Files {
+					if f.Operation != "delete" || f.SHA != tt.existing[f.Path] {
+						t.Errorf("operation on %q = %q with sha %q, want delete with sha %q", f.Path, f.Operation, f.SHA, tt.existing[f.Path])
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprint(w, `{"commit": {"sha": "c4", "author": {"name": "alice"}, "committer": {"name": "alice"}}}`)
+			})
+
+			_, err := c.Create(context.Background(), "main", "remove files", deletions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if commits != tt.wantCommits {
+				t.Errorf("Create() made %d commits, want %d", commits, tt.wantCommits)
+			}
+		})
+	}
+}
+
+func TestCommitClient_ResolveRef_NotFound(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/git/commits/5f2c7a1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "not found"}`)
+	})
+
+	// An unknown abbreviated SHA isn't looked up again by fetching the repository
+	if _, err := c.ResolveRef(context.Background(), "5f2c7a1"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("ResolveRef() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
@@ -74,7 +74,7 @@ func (c *DeployKeyClient) List(ctx context.Context) ([]gitprovider.DeployKey, er
 
 func (c *DeployKeyClient) list(ctx context.Context) ([]*deployKey, error) {
 	// GET /repos/{owner}/{repo}/keys
-	apiObjs, err := c.listKeys(c.ref.GetIdentity(), c.ref.GetRepository())
+	apiObjs, err := c.listKeys(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
 	if err != nil {
 		return nil, err
 	}
@@ -138,12 +138,19 @@ func (c *DeployKeyClient) Reconcile(ctx context.Context, req gitprovider.DeployK
 	return actual, true, actual.Update(ctx)
 }
 
+// ReconcileBatch makes sure all the given desired deploy keys (reqs) become the actual state in the
+// backing Git provider, listing the existing deploy keys only once.
+// See gitprovider.ReconcileDeployKeys for details.
+func (c *DeployKeyClient) ReconcileBatch(ctx context.Context, reqs []gitprovider.DeployKeyInfo) ([]gitprovider.DeployKeyBatchResult, error) {
+	return gitprovider.ReconcileDeployKeys(ctx, c, reqs)
+}
+
 // listKeys returns all deploy keys of the given repository.
-func (c *DeployKeyClient) listKeys(owner, repo string) ([]*gitea.DeployKey, error) {
+func (c *DeployKeyClient) listKeys(ctx context.Context, owner, repo string) ([]*gitea.DeployKey, error) {
 	opts := gitea.ListDeployKeysOptions{}
 	apiObjs := []*gitea.DeployKey{}
 
-	err := allPages(&opts.ListOptions, func() (*gitea.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
 		// GET /repos/{owner}/{repo}/keys"
 		pageObjs, resp, listErr := c.c.ListDeployKeys(owner, repo, opts)
 		if len(pageObjs) > 0 {
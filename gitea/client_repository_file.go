@@ -41,30 +41,31 @@ func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...git
 		opt.ApplyFilesGetOptions(&fileOpts)
 	}
 
-	listFiles, _, err := c.c.ListContents(c.ref.GetIdentity(), c.ref.GetRepository(), branch, path)
+	listFiles, res, err := c.c.ListContents(c.ref.GetIdentity(), c.ref.GetRepository(), branch, path)
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(res, err)
 	}
 
 	if len(listFiles) == 0 {
 		return nil, fmt.Errorf("no files found on this path[%s]", path)
 	}
 
-	files := make([]*gitprovider.CommitFile, 0)
+	paths := make([]string, 0, len(listFiles))
 	for _, file := range listFiles {
 		if file.Type != "file" {
 			continue
 		}
-		filePath := file.Path
-		fileBytes, _, err := c.c.GetFile(c.ref.GetIdentity(), c.ref.GetRepository(), branch, filePath)
+		paths = append(paths, file.Path)
+	}
+	return gitprovider.FetchFiles(ctx, paths, fileOpts.Concurrency, func(_ context.Context, filePath string) (*gitprovider.CommitFile, error) {
+		fileBytes, res, err := c.c.GetFile(c.ref.GetIdentity(), c.ref.GetRepository(), branch, filePath)
 		if err != nil {
-			return nil, err
+			return nil, handleHTTPError(res, err)
 		}
 		fileStr := string(fileBytes)
-		files = append(files, &gitprovider.CommitFile{
+		return &gitprovider.CommitFile{
 			Path:    &filePath,
 			Content: &fileStr,
-		})
-	}
-	return files, nil
+		}, nil
+	})
 }
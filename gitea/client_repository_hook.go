@@ -0,0 +1,230 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// HookClient implements the gitprovider.HookClient interface.
+var _ gitprovider.HookClient = &HookClient{}
+
+// HookClient operates on the webhooks of a specific repository.
+type HookClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the webhook with the given URL.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *HookClient) Get(ctx context.Context, url string) (gitprovider.DeployHook, error) {
+	return c.get(ctx, url)
+}
+
+func (c *HookClient) get(ctx context.Context, url string) (*deployHook, error) {
+	hooks, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Loop through the webhooks until we find one with the right URL
+	for _, hook := range hooks {
+		if hook.h.Config[hookConfigURL] == url {
+			return hook, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all webhooks of the repository.
+//
+// List returns all available webhooks, using multiple paginated requests if needed.
+func (c *HookClient) List(ctx context.Context) ([]gitprovider.DeployHook, error) {
+	hooks, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.DeployHook
+	result := make([]gitprovider.DeployHook, 0, len(hooks))
+	for _, hook := range hooks {
+		result = append(result, hook)
+	}
+	return result, nil
+}
+
+func (c *HookClient) list(ctx context.Context) ([]*deployHook, error) {
+	// GET /repos/{owner}/{repo}/hooks
+	apiObjs, err := c.listHooks(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := make([]*deployHook, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at listHooks
+		hooks = append(hooks, newDeployHook(c, apiObj))
+	}
+	return hooks, nil
+}
+
+// Create creates a webhook with the given specifications.
+//
+// ErrAlreadyExists will be returned if a webhook with the same URL already exists.
+func (c *HookClient) Create(ctx context.Context, req gitprovider.DeployHookInfo) (gitprovider.DeployHook, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.get(ctx, req.URL); err == nil {
+		return nil, gitprovider.ErrAlreadyExists
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	data, err := deployHookToAPI(&req)
+	if err != nil {
+		return nil, err
+	}
+	// POST /repos/{owner}/{repo}/hooks
+	apiObj, err := c.createHook(c.ref.GetIdentity(), c.ref.GetRepository(), data)
+	if err != nil {
+		return nil, err
+	}
+	return newDeployHook(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *HookClient) Reconcile(ctx context.Context, req gitprovider.DeployHookInfo) (gitprovider.DeployHook, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	// Webhooks are identified by their URL
+	actual, err := c.get(ctx, req.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
+
+// listHooks returns all webhooks of the given repository.
+func (c *HookClient) listHooks(ctx context.Context, owner, repo string) ([]*gitea.Hook, error) {
+	opts := gitea.ListHooksOptions{}
+	apiObjs := []*gitea.Hook{}
+
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/hooks
+		pageObjs, resp, listErr := c.c.ListRepoHooks(owner, repo, opts)
+		if listErr != nil {
+			return resp, listErr
+		}
+		if len(pageObjs) == 0 {
+			return nil, nil
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateHookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+// createHook creates a new webhook for the given repository.
+func (c *HookClient) createHook(owner, repo string, req *gitea.Hook) (*gitea.Hook, error) {
+	opts := gitea.CreateHookOption{
+		Type:   gitea.HookTypeGitea,
+		Config: req.Config,
+		Events: req.Events,
+		Active: req.Active,
+	}
+	apiObj, res, err := c.c.CreateRepoHook(owner, repo, opts)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	if err := validateHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+// editHook applies the given webhook to the webhook with the same ID, and returns the result.
+func (c *HookClient) editHook(owner, repo string, req *gitea.Hook) (*gitea.Hook, error) {
+	opts := gitea.EditHookOption{
+		Config: req.Config,
+		Events: req.Events,
+		Active: &req.Active,
+	}
+	// PATCH /repos/{owner}/{repo}/hooks/{id}
+	res, err := c.c.EditRepoHook(owner, repo, req.ID, opts)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	// Gitea doesn't return the edited webhook, fetch it again
+	// GET /repos/{owner}/{repo}/hooks/{id}
+	apiObj, res, err := c.c.GetRepoHook(owner, repo, req.ID)
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	if err := validateHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+// deleteHook deletes the given webhook from the given repository.
+func (c *HookClient) deleteHook(owner, repo string, id int64) error {
+	res, err := c.c.DeleteRepoHook(owner, repo, id)
+	return handleHTTPError(res, err)
+}
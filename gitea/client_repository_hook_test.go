@@ -0,0 +1,274 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// hookServer serves the webhooks of the fluxcd/repo repository from memory.
+type hookServer struct {
+	hooks   map[int64]*gitea.Hook
+	nextID  int64
+	created int
+	edited  int
+}
+
+func newHookServer(t *testing.T, mux *http.ServeMux, hooks ...*gitea.Hook) *hookServer {
+	s := &hookServer{hooks: map[int64]*gitea.Hook{}, nextID: 1}
+	for _, h := range hooks {
+		s.hooks[h.ID] = h
+		if h.ID >= s.nextID {
+			s.nextID = h.ID + 1
+		}
+	}
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/hooks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			list := []*gitea.Hook{}
+			for _, h := range s.hooks {
+				list = append(list, h)
+			}
+			sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+			json.NewEncoder(w).Encode(list)
+		case http.MethodPost:
+			opts := gitea.CreateHookOption{}
+			if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			h := &gitea.Hook{ID: s.nextID, Type: string(opts.Type), Config: opts.Config, Events: opts.Events, Active: opts.Active}
+			s.hooks[h.ID] = h
+			s.nextID++
+			s.created++
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(h)
+		}
+	})
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/hooks/", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/v1/repos/fluxcd/repo/hooks/"), 10, 64)
+		h, ok := s.hooks[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(h)
+		case http.MethodPatch:
+			opts := gitea.EditHookOption{}
+			if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			h.Config, h.Events = opts.Config, opts.Events
+			if opts.Active != nil {
+				h.Active = *opts.Active
+			}
+			s.edited++
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			delete(s.hooks, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	return s
+}
+
+func newTestHookClient(c *clientContext) *HookClient {
+	return &HookClient{
+		clientContext: c,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestHookClient_Reconcile(t *testing.T) {
+	existing := func() *gitea.Hook {
+		return &gitea.Hook{
+			ID:     3,
+			Type:   "gitea",
+			Config: map[string]string{"url": "https://example.com/hook", "content_type": "json"},
+			Events: []string{"push", "pull_request", "fork"},
+			Active: true,
+		}
+	}
+	tests := []struct {
+		name            string
+		hooks           []*gitea.Hook
+		req             gitprovider.DeployHookInfo
+		wantActionTaken bool
+		wantCreated     int
+		wantEdited      int
+		want            gitprovider.DeployHookInfo
+		wantAPIEvents   []string
+	}{
+		{
+			name: "create",
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://example.com/hook",
+				Secret: gitprovider.StringVar("s3cr3t"),
+			},
+			wantActionTaken: true,
+			wantCreated:     1,
+			want: gitprovider.DeployHookInfo{
+				URL:         "https://example.com/hook",
+				Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+				ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+				Active:      gitprovider.BoolVar(true),
+			},
+			wantAPIEvents: []string{"push"},
+		},
+		{
+			name:  "up to date, with the events in another order",
+			hooks: []*gitea.Hook{existing()},
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+			},
+			want: gitprovider.DeployHookInfo{
+				URL:         "https://example.com/hook",
+				Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush},
+				ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+				Active:      gitprovider.BoolVar(true),
+			},
+			wantAPIEvents: []string{"push", "pull_request", "fork"},
+		},
+		{
+			name:  "rotated secret",
+			hooks: []*gitea.Hook{existing()},
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+				Secret: gitprovider.StringVar("r0tated"),
+			},
+			wantActionTaken: true,
+			wantEdited:      1,
+			want: gitprovider.DeployHookInfo{
+				URL:         "https://example.com/hook",
+				Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush},
+				ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+				Active:      gitprovider.BoolVar(true),
+			},
+			wantAPIEvents: []string{"fork", "push", "pull_request"},
+		},
+		{
+			name:  "update the events",
+			hooks: []*gitea.Hook{existing()},
+			req: gitprovider.DeployHookInfo{
+				URL:         "https://example.com/hook",
+				Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventTagPush},
+				ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeForm),
+			},
+			wantActionTaken: true,
+			wantEdited:      1,
+			want: gitprovider.DeployHookInfo{
+				URL:         "https://example.com/hook",
+				Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventTagPush},
+				ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeForm),
+				Active:      gitprovider.BoolVar(true),
+			},
+			wantAPIEvents: []string{"fork", "push", "create"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			s := newHookServer(t, mux, tt.hooks...)
+
+			hook, actionTaken, err := newTestHookClient(c).Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if s.created != tt.wantCreated || s.edited != tt.wantEdited {
+				t.Errorf("Reconcile() created %d and edited %d webhooks, want %d and %d", s.created, s.edited, tt.wantCreated, tt.wantEdited)
+			}
+			if diff := cmp.Diff(tt.want, hook.Get()); diff != "" {
+				t.Errorf("Reconcile() mismatch (-want +got):\n%s", diff)
+			}
+			for _, h := range s.hooks {
+				if diff := cmp.Diff(tt.wantAPIEvents, h.Events); diff != "" {
+					t.Errorf("webhook events (-want +got):\n%s", diff)
+				}
+			}
+			if tt.req.Secret != nil {
+				for _, h := range s.hooks {
+					if h.Config["secret"] != *tt.req.Secret {
+						t.Errorf("webhook secret = %q, want %q", h.Config["secret"], *tt.req.Secret)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDeployHook_Delete(t *testing.T) {
+	tests := []struct {
+		name               string
+		destructiveActions bool
+		wantErr            error
+		wantHooks          int
+	}{
+		{
+			name:               "destructive actions enabled",
+			destructiveActions: true,
+		},
+		{
+			name:      "destructive actions disabled",
+			wantErr:   gitprovider.ErrDestructiveCallDisallowed,
+			wantHooks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			c.destructiveActions = tt.destructiveActions
+			s := newHookServer(t, mux, &gitea.Hook{
+				ID:     1,
+				Config: map[string]string{"url": "https://example.com/hook"},
+				Events: []string{"push"},
+				Active: true,
+			})
+
+			hook, err := newTestHookClient(c).Get(context.Background(), "https://example.com/hook")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if err := hook.Delete(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(s.hooks) != tt.wantHooks {
+				t.Errorf("repository has %d webhooks, want %d", len(s.hooks), tt.wantHooks)
+			}
+		})
+	}
+}
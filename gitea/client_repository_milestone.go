@@ -0,0 +1,189 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// MilestoneClient implements the gitprovider.MilestoneClient interface.
+var _ gitprovider.MilestoneClient = &MilestoneClient{}
+
+// MilestoneClient operates on the milestones of a specific repository.
+type MilestoneClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the milestone with the given title.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *MilestoneClient) Get(ctx context.Context, title string) (gitprovider.Milestone, error) {
+	return c.get(ctx, title)
+}
+
+func (c *MilestoneClient) get(ctx context.Context, title string) (*milestone, error) {
+	milestones, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Loop through milestones once we find one with the right title
+	for _, m := range milestones {
+		if m.m.Title == title {
+			return m, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all milestones of the repository, open and closed.
+//
+// List returns all available milestones, using multiple paginated requests if needed.
+func (c *MilestoneClient) List(ctx context.Context) ([]gitprovider.Milestone, error) {
+	ms, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.Milestone
+	milestones := make([]gitprovider.Milestone, 0, len(ms))
+	for _, m := range ms {
+		milestones = append(milestones, m)
+	}
+	return milestones, nil
+}
+
+func (c *MilestoneClient) list(ctx context.Context) ([]*milestone, error) {
+	// GET /repos/{owner}/{repo}/milestones
+	apiObjs, err := c.listMilestones(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our Milestone type
+	milestones := make([]*milestone, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at listMilestones
+		milestones = append(milestones, newMilestone(c, apiObj))
+	}
+
+	return milestones, nil
+}
+
+// Create creates a milestone with the given specifications.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *MilestoneClient) Create(_ context.Context, req gitprovider.MilestoneInfo) (gitprovider.Milestone, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	// POST /repos/{owner}/{repo}/milestones
+	apiObj, res, err := c.c.CreateMilestone(c.ref.GetIdentity(), c.ref.GetRepository(), milestoneToCreateOption(&req))
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	if err := validateMilestoneAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return newMilestone(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *MilestoneClient) Reconcile(ctx context.Context, req gitprovider.MilestoneInfo) (gitprovider.Milestone, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	// Get the milestone with the desired title
+	actual, err := c.Get(ctx, req.Title)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
+
+// Close closes the milestone with the given title.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *MilestoneClient) Close(ctx context.Context, title string) (gitprovider.Milestone, error) {
+	m, err := c.get(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	if m.m.State == gitea.StateClosed {
+		return m, nil
+	}
+	m.m.State = gitea.StateClosed
+	return m, m.Update(ctx)
+}
+
+// listMilestones returns all milestones of the given repository, open and closed.
+func (c *MilestoneClient) listMilestones(ctx context.Context, owner, repo string) ([]*gitea.Milestone, error) {
+	opts := gitea.ListMilestoneOption{State: gitea.StateAll}
+	apiObjs := []*gitea.Milestone{}
+
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/milestones
+		pageObjs, resp, listErr := c.c.ListRepoMilestones(owner, repo, opts)
+		if len(pageObjs) > 0 {
+			apiObjs = append(apiObjs, pageObjs...)
+			return resp, listErr
+		}
+		return nil, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateMilestoneAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
@@ -18,12 +18,16 @@ package gitea
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
+// wipTitlePrefix marks a pull request as a work in progress when prefixing its title.
+const wipTitlePrefix = "WIP: "
+
 // PullRequestClient implements the gitprovider.PullRequestClient interface.
 var _ gitprovider.PullRequestClient = &PullRequestClient{}
 
@@ -34,9 +38,11 @@ type PullRequestClient struct {
 }
 
 // List lists all pull requests in the repository
-func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest, error) {
-	opts := gitea.ListPullRequestsOptions{}
-	prs, _, err := c.c.ListRepoPullRequests(c.ref.GetIdentity(), c.ref.GetRepository(), opts)
+// The Gitea SDK can't filter pull requests by label, so they're filtered after listing.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestListOptions(opts...)
+	listOpts := gitea.ListPullRequestsOptions{}
+	prs, _, err := c.c.ListRepoPullRequests(c.ref.GetIdentity(), c.ref.GetRepository(), listOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -47,11 +53,16 @@ func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest
 		requests[idx] = newPullRequest(c.clientContext, pr)
 	}
 
-	return requests, nil
+	return o.FilterByLabels(requests), nil
 }
 
 // Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// Draft pull requests are created by prefixing their title with "WIP:", which Gitea recognizes
+// as a work in progress by default.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	if o := gitprovider.MakePullRequestCreateOptions(opts...); o.Draft {
+		title = wipTitlePrefix + title
+	}
 	prOpts := gitea.CreatePullRequestOption{
 		Base:  baseBranch,
 		Title: title,
@@ -110,3 +121,274 @@ func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod g
 
 	return nil
 }
+
+// MergeabilityStatus returns whether the pull request can be merged, and which of the status checks
+// required by the protection of its base branch failed.
+// The Gitea API doesn't report conflicts, nor whether the mergeable state is still being computed.
+func (c *PullRequestClient) MergeabilityStatus(ctx context.Context, number int) (gitprovider.MergeableInfo, error) {
+	owner, repo := c.ref.GetIdentity(), c.ref.GetRepository()
+	pr, res, err := c.c.GetPullRequest(owner, repo, int64(number))
+	if err != nil {
+		return gitprovider.MergeableInfo{}, handleHTTPError(res, err)
+	}
+	info := gitprovider.MergeableInfo{
+		Mergeable: gitprovider.BoolVar(pr.Mergeable),
+	}
+	if pr.Base == nil || pr.Head == nil {
+		return info, nil
+	}
+
+	protection, res, err := c.c.GetBranchProtection(owner, repo, pr.Base.Ref)
+	if err != nil {
+		err = handleHTTPError(res, err)
+		// The base branch isn't protected
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return info, nil
+		}
+		return gitprovider.MergeableInfo{}, err
+	}
+	if !protection.EnableStatusCheck || len(protection.StatusCheckContexts) == 0 {
+		return info, nil
+	}
+
+	combined, res, err := c.c.GetCombinedStatus(owner, repo, pr.Head.Sha)
+	if err != nil {
+		return gitprovider.MergeableInfo{}, handleHTTPError(res, err)
+	}
+	failing := map[string]struct{}{}
+	for _, status := range combined.Statuses {
+		if status.State == gitea.StatusFailure || status.State == gitea.StatusError {
+			failing[status.Context] = struct{}{}
+		}
+	}
+	for _, name := range protection.StatusCheckContexts {
+		if _, ok := failing[name]; ok {
+			info.FailingChecks = append(info.FailingChecks, name)
+		}
+	}
+	return info, nil
+}
+
+// PreviewRebase is not supported, as the Gitea API doesn't report conflicts. ErrNoProviderSupport
+// is returned.
+func (c *PullRequestClient) PreviewRebase(_ context.Context, _ int) (gitprovider.RebasePreview, error) {
+	return gitprovider.RebasePreview{}, gitprovider.ErrNoProviderSupport
+}
+
+// ListCommits lists the commits of the pull request.
+func (c *PullRequestClient) ListCommits(ctx context.Context, number int) ([]gitprovider.Commit, error) {
+	apiObjs := []*gitea.Commit{}
+	opts := gitea.ListPullRequestCommitsOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls/{index}/commits
+		pageObjs, res, err := c.c.ListPullRequestCommits(c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), opts)
+		if err != nil {
+			return res, err
+		}
+		// Stop on the first empty page
+		if len(pageObjs) == 0 {
+			return nil, nil
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	commitClient := &CommitClient{clientContext: c.clientContext, ref: c.ref}
+	commits := make([]gitprovider.Commit, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		commits = append(commits, newCommit(commitClient, apiObj))
+	}
+	return commits, nil
+}
+
+// ReviewSummary returns the current verdicts of the reviewers of the pull request.
+// Pending reviews aren't submitted yet, hence ignored, and dismissed reviews withdraw the verdict
+// of their reviewer.
+func (c *PullRequestClient) ReviewSummary(ctx context.Context, number int) (gitprovider.ReviewSummary, error) {
+	apiObjs := []*gitea.PullReview{}
+	opts := gitea.ListPullReviewsOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls/{index}/reviews
+		pageObjs, res, err := c.c.ListPullReviews(c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), opts)
+		if err != nil {
+			return res, err
+		}
+		// Stop on the first empty page
+		if len(pageObjs) == 0 {
+			return nil, nil
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, nil
+	})
+	if err != nil {
+		return gitprovider.ReviewSummary{}, err
+	}
+
+	reviews := make([]gitprovider.ReviewInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		if apiObj.Reviewer == nil {
+			continue
+		}
+		review := gitprovider.ReviewInfo{Reviewer: apiObj.Reviewer.UserName}
+		switch {
+		case apiObj.Dismissed:
+			review.State = gitprovider.ReviewStateDismissed
+		case apiObj.State == gitea.ReviewStateApproved:
+			review.State = gitprovider.ReviewStateApproved
+		case apiObj.State == gitea.ReviewStateRequestChanges:
+			review.State = gitprovider.ReviewStateChangesRequested
+		case apiObj.State == gitea.ReviewStateComment:
+			review.State = gitprovider.ReviewStateCommented
+		default:
+			continue
+		}
+		reviews = append(reviews, review)
+	}
+	return gitprovider.SummarizeReviews(reviews), nil
+}
+
+// Review submits a review of the pull request.
+func (c *PullRequestClient) Review(_ context.Context, number int, action gitprovider.ReviewAction, body string) (gitprovider.PullRequestReview, error) {
+	if err := gitprovider.ValidateReview(action, body); err != nil {
+		return nil, err
+	}
+
+	// POST /repos/{owner}/{repo}/pulls/{index}/reviews
+	apiObj, res, err := c.c.CreatePullReview(c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), gitea.CreatePullReviewOptions{
+		State: reviewStateTypes[action],
+		Body:  body,
+	})
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return newPullRequestReview(apiObj), nil
+}
+
+// reviewStateTypes maps the provider-neutral review actions to the states of the Gitea reviews
+// they submit.
+//
+//nolint:gochecknoglobals
+var reviewStateTypes = map[gitprovider.ReviewAction]gitea.ReviewStateType{
+	gitprovider.ReviewApprove:        gitea.ReviewStateApproved,
+	gitprovider.ReviewRequestChanges: gitea.ReviewStateRequestChanges,
+	gitprovider.ReviewComment:        gitea.ReviewStateComment,
+}
+
+// Diff returns the changes of the pull request as a unified diff, including binary changes.
+func (c *PullRequestClient) Diff(_ context.Context, number int) ([]byte, error) {
+	diff, res, err := c.c.GetPullRequestDiff(c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), gitea.PullRequestDiffOptions{
+		Binary: true,
+	})
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return diff, nil
+}
+
+// CreateComment posts a comment on the conversation of the pull request. Gitea handles the
+// conversation of a pull request like the one of an issue.
+func (c *PullRequestClient) CreateComment(_ context.Context, number int, body string) (gitprovider.PullRequestComment, error) {
+	// POST /repos/{owner}/{repo}/issues/{index}/comments
+	apiObj, res, err := c.c.CreateIssueComment(c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), gitea.CreateIssueCommentOption{
+		Body: body,
+	})
+	if err != nil {
+		return gitprovider.PullRequestComment{}, handleHTTPError(res, err)
+	}
+	return pullRequestCommentFromAPI(apiObj), nil
+}
+
+// ListComments lists the comments on the conversation of the pull request.
+func (c *PullRequestClient) ListComments(ctx context.Context, number int) ([]gitprovider.PullRequestComment, error) {
+	apiObjs := []*gitea.Comment{}
+	opts := gitea.ListIssueCommentOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/issues/{index}/comments
+		pageObjs, res, err := c.c.ListIssueComments(c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), opts)
+		if err != nil {
+			return res, err
+		}
+		// Stop on the first empty page
+		if len(pageObjs) == 0 {
+			return nil, nil
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]gitprovider.PullRequestComment, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		comments = append(comments, pullRequestCommentFromAPI(apiObj))
+	}
+	return comments, nil
+}
+
+// AddLabels adds labels to the pull request. Gitea doesn't create labels on the fly, so the
+// labels are looked up by name in the repository, and ErrNotFound is returned if one is missing.
+func (c *PullRequestClient) AddLabels(ctx context.Context, number int, labels []string) error {
+	owner, repo := c.ref.GetIdentity(), c.ref.GetRepository()
+	ids := map[string]int64{}
+	opts := gitea.ListLabelsOptions{}
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/labels
+		pageObjs, res, err := c.c.ListRepoLabels(owner, repo, opts)
+		if err != nil {
+			return res, err
+		}
+		// Stop on the first empty page
+		if len(pageObjs) == 0 {
+			return nil, nil
+		}
+		for _, apiObj := range pageObjs {
+			ids[apiObj.Name] = apiObj.ID
+		}
+		return res, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	labelOpts := gitea.IssueLabelsOption{Labels: make([]int64, 0, len(labels))}
+	for _, label := range labels {
+		id, ok := ids[label]
+		if !ok {
+			return fmt.Errorf("label %q doesn't exist in the repository: %w", label, gitprovider.ErrNotFound)
+		}
+		labelOpts.Labels = append(labelOpts.Labels, id)
+	}
+	// POST /repos/{owner}/{repo}/issues/{index}/labels
+	_, res, err := c.c.AddIssueLabels(owner, repo, int64(number), labelOpts)
+	return handleHTTPError(res, err)
+}
+
+// ListLabels lists the names of the labels of the pull request.
+func (c *PullRequestClient) ListLabels(_ context.Context, number int) ([]string, error) {
+	// GET /repos/{owner}/{repo}/issues/{index}/labels
+	apiObjs, res, err := c.c.GetIssueLabels(c.ref.GetIdentity(), c.ref.GetRepository(), int64(number), gitea.ListLabelsOptions{})
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	labels := make([]string, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		labels = append(labels, apiObj.Name)
+	}
+	return labels, nil
+}
+
+func pullRequestCommentFromAPI(apiObj *gitea.Comment) gitprovider.PullRequestComment {
+	comment := gitprovider.PullRequestComment{
+		ID:        apiObj.ID,
+		Body:      apiObj.Body,
+		CreatedAt: apiObj.Created,
+	}
+	if apiObj.Poster != nil {
+		comment.Author = apiObj.Poster.UserName
+	}
+	return comment
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific repository.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all the tags of the repository.
+// The tagger and date of annotated tags are only exposed by the tag object, which is fetched
+// for each annotated tag.
+func (c *TagClient) List(ctx context.Context) ([]gitprovider.TagInfo, error) {
+	opts := gitea.ListRepoTagsOptions{}
+	apiObjs := []*gitea.Tag{}
+	err := allPages(ctx, &opts.ListOptions, func() (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/tags
+		pageObjs, resp, listErr := c.c.ListRepoTags(c.ref.GetIdentity(), c.ref.GetRepository(), opts)
+		if len(pageObjs) > 0 {
+			apiObjs = append(apiObjs, pageObjs...)
+			return resp, listErr
+		}
+		return nil, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]gitprovider.TagInfo, 0, len(apiObjs))
+	tagObjectSHAs := map[string]string{}
+	for _, apiObj := range apiObjs {
+		tag := tagFromAPI(apiObj)
+		if tag.Annotated {
+			tagObjectSHAs[tag.Name] = apiObj.ID
+		}
+		tags = append(tags, tag)
+	}
+
+	err = gitprovider.ResolveTags(ctx, tags, func(_ context.Context, tag *gitprovider.TagInfo) error {
+		if !tag.Annotated {
+			return nil
+		}
+		return c.resolveTagger(tag, tagObjectSHAs[tag.Name])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Get returns the tag with the given name.
+func (c *TagClient) Get(_ context.Context, name string) (gitprovider.TagInfo, error) {
+	// GET /repos/{owner}/{repo}/tags/{tag}
+	apiObj, res, err := c.c.GetTag(c.ref.GetIdentity(), c.ref.GetRepository(), name)
+	if err != nil {
+		return gitprovider.TagInfo{}, handleHTTPError(res, err)
+	}
+	return c.tagWithTagger(apiObj)
+}
+
+// Create creates a tag pointing to commitSHA. Gitea creates an annotated tag if a message is
+// given, and a lightweight tag otherwise.
+func (c *TagClient) Create(_ context.Context, name, commitSHA, message string) (gitprovider.TagInfo, error) {
+	// POST /repos/{owner}/{repo}/tags
+	apiObj, res, err := c.c.CreateTag(c.ref.GetIdentity(), c.ref.GetRepository(), gitea.CreateTagOption{
+		TagName: name,
+		Message: message,
+		Target:  commitSHA,
+	})
+	if err != nil {
+		return gitprovider.TagInfo{}, handleHTTPError(res, err)
+	}
+	return c.tagWithTagger(apiObj)
+}
+
+// tagWithTagger converts apiObj, fetching the tag object of annotated tags for their tagger.
+func (c *TagClient) tagWithTagger(apiObj *gitea.Tag) (gitprovider.TagInfo, error) {
+	tag := tagFromAPI(apiObj)
+	if tag.Annotated {
+		if err := c.resolveTagger(&tag, apiObj.ID); err != nil {
+			return gitprovider.TagInfo{}, err
+		}
+	}
+	return tag, nil
+}
+
+// resolveTagger fills in the tagger and date of an annotated tag from its tag object.
+func (c *TagClient) resolveTagger(tag *gitprovider.TagInfo, tagObjectSHA string) error {
+	// GET /repos/{owner}/{repo}/git/tags/{sha}
+	apiObj, res, err := c.c.GetAnnotatedTag(c.ref.GetIdentity(), c.ref.GetRepository(), tagObjectSHA)
+	if err != nil {
+		return handleHTTPError(res, err)
+	}
+	if apiObj.Tagger != nil {
+		tag.Tagger = apiObj.Tagger.Name
+		if createdAt, err := time.Parse(time.RFC3339, apiObj.Tagger.Date); err == nil {
+			tag.CreatedAt = createdAt
+		}
+	}
+	return nil
+}
+
+// tagFromAPI converts a Gitea tag. The ID of an annotated tag is the SHA of the tag object,
+// and the commit SHA otherwise.
+func tagFromAPI(apiObj *gitea.Tag) gitprovider.TagInfo {
+	tag := gitprovider.TagInfo{
+		Name: apiObj.Name,
+	}
+	if apiObj.Commit != nil {
+		tag.SHA = apiObj.Commit.SHA
+		tag.Annotated = apiObj.ID != apiObj.Commit.SHA
+		tag.CreatedAt = apiObj.Commit.Created
+	}
+	if tag.Annotated {
+		tag.Message = apiObj.Message
+	}
+	return tag
+}
@@ -99,8 +99,9 @@ func (c *TeamAccessClient) Create(ctx context.Context, req gitprovider.TeamAcces
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
 //
 // If req doesn't exist under the hood, it is created (actionTaken == true).
-// If req doesn't equal the actual state, the resource will be deleted and recreated (actionTaken == true).
+// If req doesn't equal the actual state, the access mode of the team is updated (actionTaken == true).
 // If req is already the actual state, this is a no-op (actionTaken == false).
+// ErrPermissionNotSupported is returned if Gitea can't express the requested permission.
 func (c *TeamAccessClient) Reconcile(ctx context.Context,
 	req gitprovider.TeamAccessInfo,
 ) (gitprovider.TeamAccess, bool, error) {
@@ -109,6 +110,10 @@ func (c *TeamAccessClient) Reconcile(ctx context.Context,
 	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
 		return nil, false, err
 	}
+	// Gitea can't express all the permission levels, nothing is done if req can't be applied
+	if _, err := getGiteaAccessMode(*req.Permission); err != nil {
+		return nil, false, err
+	}
 
 	actual, err := c.Get(ctx, req.Name)
 	if err != nil {
@@ -141,7 +146,7 @@ func (c *TeamAccessClient) getTeamPermissions(_ context.Context, orgName, repo,
 		return nil, handleHTTPError(resp, err)
 	}
 	if apiObj == nil {
-		return nil, fmt.Errorf("team %s not found in repository %s/%s", teamName, orgName, repo)
+		return nil, fmt.Errorf("team %s not found in repository %s/%s: %w", teamName, orgName, repo, gitprovider.ErrNotFound)
 	}
 
 	return &apiObj.Permission, nil
@@ -156,11 +161,41 @@ func (c *TeamAccessClient) listRepoTeams(ctx context.Context, orgName, repo stri
 	return teamObjs, nil
 }
 
-// addTeam adds the given team to the given repository.
-// We don't support setting permissions for Gitea, so we ignore the permission parameter.
+// addTeam adds the given team to the given repository, and sets the permission of the team.
+// Gitea doesn't have per-repository team permissions, the access mode belongs to the team:
+// changing it applies to all the repositories of the team.
 // see https://github.com/go-gitea/gitea/issues/14717
 func (c *TeamAccessClient) addTeam(_ context.Context, orgName, repo, teamName string, permission gitprovider.RepositoryPermission) error {
+	// Fail before adding the team if the permission can't be set afterwards
+	accessMode, err := getGiteaAccessMode(permission)
+	if err != nil {
+		return err
+	}
+
 	res, err := c.c.AddRepoTeam(orgName, repo, teamName)
+	if err != nil {
+		return handleHTTPError(res, err)
+	}
+
+	team, res, err := c.c.CheckRepoTeam(orgName, repo, teamName)
+	if err != nil {
+		return handleHTTPError(res, err)
+	}
+	if team == nil {
+		return fmt.Errorf("team %s not found in repository %s/%s", teamName, orgName, repo)
+	}
+	if *getProviderPermission(team.Permission) == permission {
+		return nil
+	}
+
+	res, err = c.c.EditTeam(team.ID, gitea.EditTeamOption{
+		Name:                    team.Name,
+		Description:             &team.Description,
+		Permission:              accessMode,
+		CanCreateOrgRepo:        &team.CanCreateOrgRepo,
+		IncludesAllRepositories: &team.IncludesAllRepositories,
+		Units:                   team.Units,
+	})
 	return handleHTTPError(res, err)
 }
 
@@ -169,3 +204,11 @@ func (c *TeamAccessClient) removeTeam(_ context.Context, orgName, repo, teamName
 	res, err := c.c.RemoveRepoTeam(orgName, repo, teamName)
 	return handleHTTPError(res, err)
 }
+
+// ReconcileAll makes sure exactly the given teams (reqs) have access to the repository, with the
+// given permissions. The access of the other teams is removed if prune is true, which requires
+// the client to allow destructive API calls.
+// See gitprovider.ReconcileAllTeamAccess for details.
+func (c *TeamAccessClient) ReconcileAll(ctx context.Context, reqs []gitprovider.TeamAccessInfo, prune bool) ([]gitprovider.TeamAccessBatchResult, error) {
+	return gitprovider.ReconcileAllTeamAccess(ctx, c, reqs, prune, c.destructiveActions)
+}
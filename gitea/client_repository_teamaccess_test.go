@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestTeamAccessClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		assigned        bool
+		teamPermission  gitea.AccessMode
+		req             gitprovider.RepositoryPermission
+		wantActionTaken bool
+		wantPermission  gitea.AccessMode
+		wantErr         error
+	}{
+		{
+			name:            "add team",
+			teamPermission:  gitea.AccessModeRead,
+			req:             gitprovider.RepositoryPermissionPush,
+			wantActionTaken: true,
+			wantPermission:  gitea.AccessModeWrite,
+		},
+		{
+			name:           "up to date",
+			assigned:       true,
+			teamPermission: gitea.AccessModeRead,
+			req:            gitprovider.RepositoryPermissionPull,
+			wantPermission: gitea.AccessModeRead,
+		},
+		{
+			name:            "change permission",
+			assigned:        true,
+			teamPermission:  gitea.AccessModeRead,
+			req:             gitprovider.RepositoryPermissionAdmin,
+			wantActionTaken: true,
+			wantPermission:  gitea.AccessModeAdmin,
+		},
+		{
+			name:           "unsupported permission",
+			assigned:       true,
+			teamPermission: gitea.AccessModeRead,
+			req:            gitprovider.RepositoryPermissionTriage,
+			wantPermission: gitea.AccessModeRead,
+			wantErr:        gitprovider.ErrPermissionNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			assigned := tt.assigned
+			team := &gitea.Team{ID: 1, Name: "team", Permission: tt.teamPermission}
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/teams/team", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodPut:
+					assigned = true
+					w.WriteHeader(http.StatusNoContent)
+				case http.MethodGet:
+					if !assigned {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					json.NewEncoder(w).Encode(team)
+				}
+			})
+			mux.HandleFunc("/api/v1/teams/1", func(w http.ResponseWriter, r *http.Request) {
+				opts := gitea.EditTeamOption{}
+				if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				team.Permission = opts.Permission
+				json.NewEncoder(w).Encode(team)
+			})
+
+			tc := &TeamAccessClient{
+				clientContext: c,
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			_, actionTaken, err := tc.Reconcile(context.Background(), gitprovider.TeamAccessInfo{
+				Name:       "team",
+				Permission: &tt.req,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reconcile() error = %v, want %v", err, tt.wantErr)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if team.Permission != tt.wantPermission {
+				t.Errorf("team permission = %s, want %s", team.Permission, tt.wantPermission)
+			}
+			if tt.wantErr == nil && !assigned {
+				t.Error("team wasn't added to the repository")
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TopicsClient implements the gitprovider.TopicsClient interface.
+var _ gitprovider.TopicsClient = &TopicsClient{}
+
+// TopicsClient operates on the topics of a specific repository.
+type TopicsClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the topics of the repository.
+func (c *TopicsClient) Get(_ context.Context) ([]string, error) {
+	// GET /repos/{owner}/{repo}/topics
+	// Gitea allows at most 25 topics per repository, which fit in the first page
+	topics, res, err := c.c.ListRepoTopics(c.ref.GetIdentity(), c.ref.GetRepository(), gitea.ListRepoTopicsOptions{})
+	if err != nil {
+		return nil, handleHTTPError(res, err)
+	}
+	return topics, nil
+}
+
+// Reconcile makes sure the repository has exactly the given topics, in any order.
+func (c *TopicsClient) Reconcile(ctx context.Context, topics []string, opts ...gitprovider.TopicsReconcileOption) (bool, error) {
+	return gitprovider.ReconcileTopics(ctx, topics, c.Get, func(_ context.Context, topics []string) error {
+		// PUT /repos/{owner}/{repo}/topics
+		res, err := c.c.SetRepoTopics(c.ref.GetIdentity(), c.ref.GetRepository(), topics)
+		return handleHTTPError(res, err)
+	}, opts...)
+}
@@ -60,5 +60,8 @@ func commitFromAPI(apiObj *gitea.Commit) gitprovider.CommitInfo {
 		}
 		info.Message = apiObj.RepoCommit.Message
 	}
+	for _, parent := range apiObj.Parents {
+		info.Parents = append(info.Parents, parent.SHA)
+	}
 	return info
 }
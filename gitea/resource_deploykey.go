@@ -150,11 +150,13 @@ func validateDeployKeyAPI(apiObj *gitea.DeployKey) error {
 }
 
 func deployKeyFromAPI(apiObj *gitea.DeployKey) gitprovider.DeployKeyInfo {
-	return gitprovider.DeployKeyInfo{
+	info := gitprovider.DeployKeyInfo{
 		Name:     apiObj.Title,
 		Key:      []byte(apiObj.Key),
 		ReadOnly: &apiObj.ReadOnly,
 	}
+	info.ComputeFingerprint()
+	return info
 }
 
 func deployKeyToAPI(info *gitprovider.DeployKeyInfo) *gitea.DeployKey {
@@ -0,0 +1,249 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// hookConfigURL is the key of the webhook URL in the config of Gitea webhooks.
+	hookConfigURL = "url"
+	// hookConfigContentType is the key of the delivery format in the config of Gitea webhooks.
+	hookConfigContentType = "content_type"
+	// hookConfigSecret is the key of the write-only secret in the config of Gitea webhooks.
+	hookConfigSecret = "secret"
+)
+
+func newDeployHook(c *HookClient, hook *gitea.Hook) *deployHook {
+	h := &deployHook{
+		h: *hook,
+		c: c,
+	}
+	// Copy the config so that Set doesn't modify the map of the given hook, and don't keep any
+	// secret returned by Gitea: it must not be sent back when updating
+	h.h.Config = make(map[string]string, len(hook.Config))
+	for k, v := range hook.Config {
+		if k != hookConfigSecret {
+			h.h.Config[k] = v
+		}
+	}
+	return h
+}
+
+var _ gitprovider.DeployHook = &deployHook{}
+
+type deployHook struct {
+	h gitea.Hook
+	c *HookClient
+}
+
+// Get returns the webhook information.
+func (dh *deployHook) Get() gitprovider.DeployHookInfo {
+	return deployHookFromAPI(&dh.h)
+}
+
+// Set sets the webhook information.
+func (dh *deployHook) Set(info gitprovider.DeployHookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return deployHookInfoToAPIObj(&info, &dh.h)
+}
+
+// APIObject returns the underlying API object.
+func (dh *deployHook) APIObject() interface{} {
+	return &dh.h
+}
+
+// Repository returns the repository that this webhook belongs to.
+func (dh *deployHook) Repository() gitprovider.RepositoryRef {
+	return dh.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (dh *deployHook) Update(ctx context.Context) error {
+	apiObj, err := dh.c.editHook(dh.c.ref.GetIdentity(), dh.c.ref.GetRepository(), &dh.h)
+	if err != nil {
+		return err
+	}
+	*dh = *newDeployHook(dh.c, apiObj)
+	return nil
+}
+
+// Delete deletes the webhook from the repository. Deleting a webhook requires destructive
+// API calls to be enabled, ErrDestructiveCallDisallowed is returned otherwise.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dh *deployHook) Delete(ctx context.Context) error {
+	// Don't allow deleting webhooks if the user didn't explicitly allow dangerous API calls.
+	if !dh.c.destructiveActions {
+		return fmt.Errorf("cannot delete webhook: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /repos/{owner}/{repo}/hooks/{id}
+	return dh.c.deleteHook(dh.c.ref.GetIdentity(), dh.c.ref.GetRepository(), dh.h.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (dh *deployHook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := dh.c.get(ctx, dh.h.Config[hookConfigURL])
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			// POST /repos/{owner}/{repo}/hooks
+			apiObj, err := dh.c.createHook(dh.c.ref.GetIdentity(), dh.c.ref.GetRepository(), &dh.h)
+			if err != nil {
+				return true, err
+			}
+			*dh = *newDeployHook(dh.c, apiObj)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing. Gitea doesn't return the secret, so
+	// a desired one is always sent.
+	desired := dh.Get()
+	if secret, ok := dh.h.Config[hookConfigSecret]; ok {
+		desired.Secret = &secret
+	}
+	if desired.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Otherwise, update the actual webhook with the desired state
+	dh.h.ID = actual.h.ID
+	return true, dh.Update(ctx)
+}
+
+func validateHookAPI(apiObj *gitea.Hook) error {
+	return validateAPIObject("Gitea.Hook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Config[hookConfigURL] == "" {
+			validator.Required("Config.URL")
+		}
+	})
+}
+
+func deployHookFromAPI(apiObj *gitea.Hook) gitprovider.DeployHookInfo {
+	info := gitprovider.DeployHookInfo{
+		URL:    apiObj.Config[hookConfigURL],
+		Events: webhookEventsFromAPI(apiObj.Events),
+		Active: gitprovider.BoolVar(apiObj.Active),
+	}
+	if contentType := apiObj.Config[hookConfigContentType]; contentType != "" {
+		info.ContentType = gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentType(contentType))
+	}
+	return info
+}
+
+func deployHookToAPI(info *gitprovider.DeployHookInfo) (*gitea.Hook, error) {
+	h := &gitea.Hook{}
+	if err := deployHookInfoToAPIObj(info, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func deployHookInfoToAPIObj(info *gitprovider.DeployHookInfo, apiObj *gitea.Hook) error {
+	events, err := webhookEventsToAPI(info.Events, apiObj.Events)
+	if err != nil {
+		return err
+	}
+	if apiObj.Config == nil {
+		apiObj.Config = map[string]string{}
+	}
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Config[hookConfigURL] = info.URL
+	apiObj.Events = events
+	// optional fields
+	if info.ContentType != nil {
+		apiObj.Config[hookConfigContentType] = string(*info.ContentType)
+	}
+	if info.Secret != nil {
+		apiObj.Config[hookConfigSecret] = *info.Secret
+	} else {
+		delete(apiObj.Config, hookConfigSecret)
+	}
+	if info.Active != nil {
+		apiObj.Active = *info.Active
+	}
+	return nil
+}
+
+// webhookEventsFromAPI maps Gitea event names to WebhookEvents, dropping the names which can't
+// be expressed as a WebhookEvent.
+func webhookEventsFromAPI(names []string) []gitprovider.WebhookEvent {
+	var events []gitprovider.WebhookEvent
+	for _, event := range gitprovider.SortedWebhookEvents(webhookEventNames) {
+		for _, name := range names {
+			if name == webhookEventNames[event] {
+				events = append(events, event)
+				break
+			}
+		}
+	}
+	return events
+}
+
+// webhookEventsToAPI maps events to Gitea event names, keeping the names in current which can't
+// be expressed as a WebhookEvent, as these can't be desired.
+func webhookEventsToAPI(events []gitprovider.WebhookEvent, current []string) ([]string, error) {
+	known := make(map[string]struct{}, len(webhookEventNames))
+	for _, name := range webhookEventNames {
+		known[name] = struct{}{}
+	}
+	names := make([]string, 0, len(events))
+	for _, name := range current {
+		if _, ok := known[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for _, event := range events {
+		name, ok := webhookEventNames[event]
+		if !ok {
+			return nil, fmt.Errorf("Gitea webhooks can't be triggered by %q events: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
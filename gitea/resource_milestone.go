@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newMilestone(c *MilestoneClient, apiObj *gitea.Milestone) *milestone {
+	return &milestone{
+		m: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Milestone = &milestone{}
+
+type milestone struct {
+	m gitea.Milestone
+	c *MilestoneClient
+}
+
+// Get returns the milestone information.
+func (m *milestone) Get() gitprovider.MilestoneInfo {
+	return milestoneFromAPI(&m.m)
+}
+
+// Set sets the milestone information.
+func (m *milestone) Set(info gitprovider.MilestoneInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	milestoneInfoToAPIObj(&info, &m.m)
+	return nil
+}
+
+// APIObject returns the underlying API object.
+func (m *milestone) APIObject() interface{} {
+	return &m.m
+}
+
+// Repository returns the repository that this milestone belongs to.
+func (m *milestone) Repository() gitprovider.RepositoryRef {
+	return m.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (m *milestone) Update(_ context.Context) error {
+	opts := gitea.EditMilestoneOption{
+		Title:       m.m.Title,
+		Description: &m.m.Description,
+		State:       &m.m.State,
+		Deadline:    m.m.Deadline,
+	}
+	// PATCH /repos/{owner}/{repo}/milestones/{id}
+	apiObj, res, err := m.c.c.EditMilestone(m.c.ref.GetIdentity(), m.c.ref.GetRepository(), m.m.ID, opts)
+	if err != nil {
+		return handleHTTPError(res, err)
+	}
+	if err := validateMilestoneAPI(apiObj); err != nil {
+		return err
+	}
+	m.m = *apiObj
+	return nil
+}
+
+func validateMilestoneAPI(apiObj *gitea.Milestone) error {
+	return validateAPIObject("Gitea.Milestone", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Title == "" {
+			validator.Required("Title")
+		}
+	})
+}
+
+func milestoneFromAPI(apiObj *gitea.Milestone) gitprovider.MilestoneInfo {
+	info := gitprovider.MilestoneInfo{
+		Title:       apiObj.Title,
+		Description: gitprovider.StringVar(apiObj.Description),
+		State:       gitprovider.MilestoneStateVar(gitprovider.MilestoneState(apiObj.State)),
+	}
+	if apiObj.Deadline != nil {
+		info.DueDate = gitprovider.MilestoneDueDate(*apiObj.Deadline)
+	}
+	return info
+}
+
+func milestoneToCreateOption(info *gitprovider.MilestoneInfo) gitea.CreateMilestoneOption {
+	opts := gitea.CreateMilestoneOption{
+		Title:    info.Title,
+		Deadline: info.DueDate,
+	}
+	if info.Description != nil {
+		opts.Description = *info.Description
+	}
+	if info.State != nil {
+		opts.State = gitea.StateType(*info.State)
+	}
+	return opts
+}
+
+func milestoneInfoToAPIObj(info *gitprovider.MilestoneInfo, apiObj *gitea.Milestone) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Title = info.Title
+	// optional fields
+	if info.Description != nil {
+		apiObj.Description = *info.Description
+	}
+	if info.DueDate != nil {
+		apiObj.Deadline = info.DueDate
+	}
+	if info.State != nil {
+		apiObj.State = gitea.StateType(*info.State)
+	}
+}
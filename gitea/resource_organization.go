@@ -18,6 +18,12 @@ package gitea
 
 import (
 	"code.gitea.io/sdk/gitea"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
@@ -32,6 +38,10 @@ func newOrganization(ctx *clientContext, apiObj *gitea.Organization, ref gitprov
 			clientContext: ctx,
 			ref:           ref,
 		},
+		settings: &OrganizationSettingsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 	}
 }
 
@@ -43,7 +53,8 @@ type organization struct {
 	o   gitea.Organization
 	ref gitprovider.OrganizationRef
 
-	teams *TeamsClient
+	teams    *TeamsClient
+	settings *OrganizationSettingsClient
 }
 
 // Get returns the organization information.
@@ -66,6 +77,40 @@ func (o *organization) Teams() gitprovider.TeamsClient {
 	return o.teams
 }
 
+// Settings returns the org-wide settings client.
+func (o *organization) Settings() gitprovider.OrganizationSettingsClient {
+	return o.settings
+}
+
+// Webhooks isn't implemented for Gitea organizations, ErrNoProviderSupport is returned.
+func (o *organization) Webhooks() (gitprovider.OrganizationWebhookClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// AuditLog is not supported, as Gitea has no audit log. ErrNoProviderSupport is returned.
+func (o *organization) AuditLog() (gitprovider.AuditLogClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetAvatar uploads the given image as the avatar of the organization.
+func (o *organization) SetAvatar(ctx context.Context, avatar io.Reader) error {
+	data, _, err := gitprovider.ReadAvatar(avatar)
+	if err != nil {
+		return err
+	}
+	// POST /orgs/{org}/avatar
+	path := fmt.Sprintf("/orgs/%s/avatar", url.PathEscape(o.ref.Organization))
+	return o.doAPIRequest(ctx, http.MethodPost, path, avatarOption{Image: base64.StdEncoding.EncodeToString(data)}, nil)
+}
+
+// avatarOption is the request body of the avatar endpoints, which the Gitea SDK doesn't cover.
+type avatarOption struct {
+	// Image is the base64 encoded image.
+	Image string `json:"image"`
+}
+
+// organizationFromAPI leaves TwoFactorRequired unset, as Gitea can't require two-factor
+// authentication per organization.
 func organizationFromAPI(apiObj *gitea.Organization) gitprovider.OrganizationInfo {
 	return gitprovider.OrganizationInfo{
 		Name:        &apiObj.UserName,
@@ -47,9 +47,48 @@ func (pr *pullrequest) APIObject() interface{} {
 }
 
 func pullrequestFromAPI(apiObj *gitea.PullRequest) gitprovider.PullRequestInfo {
+	var labels []string
+	for _, label := range apiObj.Labels {
+		labels = append(labels, label.Name)
+	}
 	return gitprovider.PullRequestInfo{
 		Merged: apiObj.HasMerged,
 		Number: int(apiObj.Index),
 		WebURL: apiObj.HTMLURL,
+		Labels: labels,
+	}
+}
+
+func newPullRequestReview(apiObj *gitea.PullReview) *pullRequestReview {
+	return &pullRequestReview{
+		r: *apiObj,
+	}
+}
+
+var _ gitprovider.PullRequestReview = &pullRequestReview{}
+
+type pullRequestReview struct {
+	r gitea.PullReview
+}
+
+// Get returns the review information.
+func (r *pullRequestReview) Get() gitprovider.ReviewInfo {
+	info := gitprovider.ReviewInfo{}
+	if r.r.Reviewer != nil {
+		info.Reviewer = r.r.Reviewer.UserName
+	}
+	switch r.r.State {
+	case gitea.ReviewStateApproved:
+		info.State = gitprovider.ReviewStateApproved
+	case gitea.ReviewStateRequestChanges:
+		info.State = gitprovider.ReviewStateChangesRequested
+	case gitea.ReviewStateComment:
+		info.State = gitprovider.ReviewStateCommented
 	}
+	return info
+}
+
+// APIObject returns the underlying API object.
+func (r *pullRequestReview) APIObject() interface{} {
+	return &r.r
 }
@@ -18,8 +18,16 @@ package gitea
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
 	"reflect"
+	"strings"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 
@@ -36,6 +44,10 @@ func newUserRepository(ctx *clientContext, apiObj *gitea.Repository, ref gitprov
 			clientContext: ctx,
 			ref:           ref,
 		},
+		milestones: &MilestoneClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		commits: &CommitClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -44,6 +56,10 @@ func newUserRepository(ctx *clientContext, apiObj *gitea.Repository, ref gitprov
 			clientContext: ctx,
 			ref:           ref,
 		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		pullRequests: &PullRequestClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -64,8 +80,10 @@ type userRepository struct {
 	ref gitprovider.RepositoryRef
 
 	deployKeys   *DeployKeyClient
+	milestones   *MilestoneClient
 	commits      *CommitClient
 	branches     *BranchClient
+	tags         *TagClient
 	pullRequests *PullRequestClient
 	files        *FileClient
 	trees        *TreeClient
@@ -73,7 +91,7 @@ type userRepository struct {
 
 // Get returns the repository information.
 func (r *userRepository) Get() gitprovider.RepositoryInfo {
-	return repositoryFromAPI(&r.r)
+	return repositoryFromAPI(&r.r, r.domain)
 }
 
 // Set sets the repository information.
@@ -100,12 +118,65 @@ func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
 	return r.deployKeys
 }
 
+// Hooks returns the webhook client.
+func (r *userRepository) Hooks() (gitprovider.HookClient, error) {
+	return &HookClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
 // DeployTokens returns the deploy token client.
 // ErrNoProviderSupport is returned as the provider does not support deploy tokens.
 func (r *userRepository) DeployTokens() (gitprovider.DeployTokenClient, error) {
 	return nil, gitprovider.ErrNoProviderSupport
 }
 
+// ProtectedTags returns the protected tag client.
+// ErrNoProviderSupport is returned as the provider does not support protected tags.
+func (r *userRepository) ProtectedTags() (gitprovider.ProtectedTagClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Milestones returns the milestone client.
+func (r *userRepository) Milestones() (gitprovider.MilestoneClient, error) {
+	return r.milestones, nil
+}
+
+// Releases is not implemented for Gitea yet, ErrNoProviderSupport is returned.
+func (r *userRepository) Releases() (gitprovider.ReleaseClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Pipelines is not supported, as Gitea has no API to trigger CI pipelines. ErrNoProviderSupport
+// is returned.
+func (r *userRepository) Pipelines() (gitprovider.PipelineClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Workflows is not supported, as the Gitea SDK has no API to dispatch Actions workflows.
+// ErrNoProviderSupport is returned.
+func (r *userRepository) Workflows() (gitprovider.WorkflowClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Traffic is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *userRepository) Traffic() (gitprovider.TrafficClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Environments is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *userRepository) Environments() (gitprovider.EnvironmentClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Collaborators is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *userRepository) Collaborators() (gitprovider.CollaboratorClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Topics returns a TopicsClient operating on the topics of the repository.
+func (r *userRepository) Topics() (gitprovider.TopicsClient, error) {
+	return &TopicsClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
 // Commits returns the commit client.
 func (r *userRepository) Commits() gitprovider.CommitClient {
 	return r.commits
@@ -116,6 +187,10 @@ func (r *userRepository) Branches() gitprovider.BranchClient {
 	return r.branches
 }
 
+func (r *userRepository) Tags() gitprovider.TagClient {
+	return r.tags
+}
+
 // PullRequests returns the pull request client.
 func (r *userRepository) PullRequests() gitprovider.PullRequestClient {
 	return r.pullRequests
@@ -252,6 +327,306 @@ func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
 	return r.teamAccess
 }
 
+// License returns the license file found at the root of the repository's default branch.
+// Gitea doesn't expose license detection through its API, hence only the path of the
+// license file is reported, and SPDXID and Name are left empty.
+//
+// ErrNotFound is returned if no license file is found.
+func (r *orgRepository) License(_ context.Context) (gitprovider.LicenseInfo, error) {
+	contents, res, err := r.c.ListContents(r.ref.GetIdentity(), r.ref.GetRepository(), r.r.DefaultBranch, "")
+	if err != nil {
+		return gitprovider.LicenseInfo{}, handleHTTPError(res, err)
+	}
+	for _, content := range contents {
+		if content.Type == "file" && isLicenseFile(content.Name) {
+			return gitprovider.LicenseInfo{Path: content.Path}, nil
+		}
+	}
+	return gitprovider.LicenseInfo{}, fmt.Errorf("no license file found in repository %q: %w", r.ref.GetRepository(), gitprovider.ErrNotFound)
+}
+
+// Language returns the language making up the most bytes of the repository, as detected by Gitea.
+//
+// ErrNotFound is returned if no language is detected.
+func (r *orgRepository) Language(_ context.Context) (string, error) {
+	// GET /repos/{owner}/{repo}/languages
+	languages, res, err := r.c.GetRepoLanguages(r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		return "", handleHTTPError(res, err)
+	}
+	primary := ""
+	for language, size := range languages {
+		// Break ties by name, to be deterministic
+		if primary == "" || size > languages[primary] || (size == languages[primary] && language < primary) {
+			primary = language
+		}
+	}
+	if primary == "" {
+		return "", fmt.Errorf("no language detected in repository %q: %w", r.ref.GetRepository(), gitprovider.ErrNotFound)
+	}
+	return primary, nil
+}
+
+// isLicenseFile returns true if the given file name is a conventional license file name,
+// e.g. "LICENSE", "LICENSE.md" or "COPYING".
+func isLicenseFile(name string) bool {
+	base := strings.ToUpper(strings.TrimSuffix(name, path.Ext(name)))
+	switch base {
+	case "LICENSE", "LICENCE", "COPYING", "UNLICENSE":
+		return true
+	}
+	return false
+}
+
+// Permissions returns the effective permission level of the authenticated user on the repository.
+func (r *orgRepository) Permissions(_ context.Context) (gitprovider.PermissionLevel, error) {
+	apiObj, err := getRepo(r.c, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		return "", err
+	}
+	return permissionLevelFromAPI(apiObj.Permissions), nil
+}
+
+// CreateBackup writes a git bundle of all refs of the repository to w.
+func (r *orgRepository) CreateBackup(ctx context.Context, w io.Writer) error {
+	return gitprovider.WriteBackupBundle(ctx, w, r.r.CloneURL, r.gitTransport)
+}
+
+// Wiki returns a WikiClient operating on the wiki repository through the Git protocol.
+func (r *orgRepository) Wiki() (gitprovider.WikiClient, error) {
+	return gitprovider.NewGitWikiClient(gitprovider.WikiCloneURL(r.r.CloneURL), r.gitTransport)
+}
+
+// CustomProperties is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *orgRepository) CustomProperties() (gitprovider.CustomPropertiesClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// InteractionLimits is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *orgRepository) InteractionLimits() (gitprovider.InteractionLimitsClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ApprovalSettings is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *orgRepository) ApprovalSettings() (gitprovider.ApprovalSettingsClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Pages is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *orgRepository) Pages() (gitprovider.PagesClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription watches or unwatches the repository for the authenticated user.
+// Gitea has no ignored state, so ignoring a repository unwatches it.
+func (r *orgRepository) SetSubscription(_ context.Context, subscription gitprovider.SubscriptionInfo) error {
+	if err := subscription.ValidateInfo(); err != nil {
+		return err
+	}
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	if subscription.Subscribed {
+		// PUT /repos/{owner}/{repo}/subscription
+		res, err := r.c.WatchRepo(owner, repo)
+		return handleHTTPError(res, err)
+	}
+	// DELETE /repos/{owner}/{repo}/subscription
+	res, err := r.c.UnWatchRepo(owner, repo)
+	return handleHTTPError(res, err)
+}
+
+// subscribersPageSize is the number of subscribers listed per page, the default maximum of Gitea.
+const subscribersPageSize = 50
+
+// Subscribers lists the users watching the repository.
+func (r *orgRepository) Subscribers(ctx context.Context) ([]gitprovider.UserRef, error) {
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	subscribers := []gitprovider.UserRef{}
+	for page := 1; ; page++ {
+		// GET /repos/{owner}/{repo}/subscribers, which isn't covered by the Gitea SDK
+		apiObjs := []*gitea.User{}
+		path := fmt.Sprintf("/repos/%s/%s/subscribers?page=%d&limit=%d", owner, repo, page, subscribersPageSize)
+		if err := r.doAPIRequest(ctx, http.MethodGet, path, nil, &apiObjs); err != nil {
+			return nil, err
+		}
+		for _, apiObj := range apiObjs {
+			subscribers = append(subscribers, gitprovider.UserRef{Domain: r.domain, UserLogin: apiObj.UserName})
+		}
+		if len(apiObjs) < subscribersPageSize {
+			return subscribers, nil
+		}
+	}
+}
+
+// SetAvatar uploads the given image as the avatar of the repository.
+func (r *orgRepository) SetAvatar(ctx context.Context, avatar io.Reader) error {
+	data, _, err := gitprovider.ReadAvatar(avatar)
+	if err != nil {
+		return err
+	}
+	// POST /repos/{owner}/{repo}/avatar
+	path := fmt.Sprintf("/repos/%s/%s/avatar", url.PathEscape(r.ref.GetIdentity()), url.PathEscape(r.ref.GetRepository()))
+	return r.doAPIRequest(ctx, http.MethodPost, path, avatarOption{Image: base64.StdEncoding.EncodeToString(data)}, nil)
+}
+
+// Counts returns the number of open pull requests, open issues, branches and tags of the
+// repository. The open pull requests and issues are read from the repository object, while the
+// branches and tags are read from the X-Total-Count header of single-item list requests.
+func (r *orgRepository) Counts(_ context.Context) (gitprovider.RepoCounts, error) {
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	apiObj, err := getRepo(r.c, owner, repo)
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	branches, err := countItems(func(opts gitea.ListOptions) (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/branches
+		_, res, err := r.c.ListRepoBranches(owner, repo, gitea.ListRepoBranchesOptions{ListOptions: opts})
+		return res, err
+	})
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	tags, err := countItems(func(opts gitea.ListOptions) (*gitea.Response, error) {
+		// GET /repos/{owner}/{repo}/tags
+		_, res, err := r.c.ListRepoTags(owner, repo, gitea.ListRepoTagsOptions{ListOptions: opts})
+		return res, err
+	})
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	return gitprovider.RepoCounts{
+		OpenPullRequests: gitprovider.ItemCount{Count: gitprovider.IntVar(apiObj.OpenPulls), Exact: true},
+		OpenIssues:       gitprovider.ItemCount{Count: gitprovider.IntVar(apiObj.OpenIssues), Exact: true},
+		Branches:         gitprovider.ItemCount{Count: branches, Exact: branches != nil},
+		Tags:             gitprovider.ItemCount{Count: tags, Exact: tags != nil},
+	}, nil
+}
+
+// ReconcileMergeQueue is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileMergeQueue(_ context.Context, _ string, _ gitprovider.MergeQueueInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileBranchMergeMethods is not supported, as the merge methods of Gitea are configured for
+// the whole repository. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileBranchMergeMethods(_ context.Context, _ string, _ gitprovider.BranchMergeMethodsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileMergeMethods is not supported by Gitea yet, ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileMergeMethods(_ context.Context, _ gitprovider.MergeMethodsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileEnvironmentProtection is not supported, as Gitea has no deployment environments.
+// ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileEnvironmentProtection(_ context.Context, _ string, _ gitprovider.EnvironmentProtectionInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// IsDefaultBranchProtected returns whether the default branch of the repository is protected, from
+// the protected flag of the branch object.
+func (r *orgRepository) IsDefaultBranchProtected(_ context.Context) (bool, error) {
+	apiObj, res, err := r.c.GetRepoBranch(r.ref.GetIdentity(), r.ref.GetRepository(), r.r.DefaultBranch)
+	if err != nil {
+		return false, handleHTTPError(res, err)
+	}
+	return apiObj.Protected, nil
+}
+
+// ReconcileWorkflowPermissions is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileWorkflowPermissions(_ context.Context, _ gitprovider.WorkflowPermissionsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileCodeScanningDefaultSetup is not supported by Gitea, ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileCodeScanningDefaultSetup(_ context.Context, _ gitprovider.CodeScanningDefaultSetupInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// CommitActivity returns the weekly commit activity of the default branch. Gitea has no weekly
+// commit statistics, so the activity is computed from the commits of the last year.
+func (r *orgRepository) CommitActivity(ctx context.Context) ([]gitprovider.WeeklyActivity, error) {
+	now := time.Now()
+	dates, err := r.commits.listCommitDates(ctx, r.r.DefaultBranch, gitprovider.CommitActivitySince(now))
+	if err != nil {
+		return nil, err
+	}
+	return gitprovider.WeeklyActivityFromCommits(now, dates), nil
+}
+
+// SetTemplates commits the given pull request and issue templates to their conventional
+// locations on the default branch, in a single commit.
+func (r *orgRepository) SetTemplates(ctx context.Context, templates gitprovider.TemplatesInfo) (gitprovider.Commit, error) {
+	return gitprovider.CommitTemplates(ctx, r.commits, r.r.DefaultBranch, giteaTemplateLayout, templates)
+}
+
+// GetTemplates reads the pull request and issue templates from their conventional
+// locations on the default branch.
+func (r *orgRepository) GetTemplates(ctx context.Context) (gitprovider.TemplatesInfo, error) {
+	return gitprovider.ReadTemplates(ctx, r.files, r.r.DefaultBranch, giteaTemplateLayout)
+}
+
+// DiscoverTemplates returns the paths of the pull request and issue templates found at the
+// locations Gitea recognizes, at the given ref or on the default branch if ref is empty.
+func (r *orgRepository) DiscoverTemplates(ctx context.Context, ref string) (gitprovider.TemplateLocations, error) {
+	if ref == "" {
+		ref = r.r.DefaultBranch
+	}
+	return gitprovider.DiscoverTemplates(ctx, r.trees, ref, giteaTemplateConventions)
+}
+
+// SetDependabotConfig is not supported, as Gitea has no Dependabot. ErrNoProviderSupport is returned.
+func (r *orgRepository) SetDependabotConfig(_ context.Context, _ []byte) (gitprovider.Commit, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetDependabotConfig is not supported, as Gitea has no Dependabot. ErrNoProviderSupport is returned.
+func (r *orgRepository) GetDependabotConfig(_ context.Context) ([]byte, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetSecurityPolicy is not supported, as Gitea has no security policy convention.
+// ErrNoProviderSupport is returned.
+func (r *orgRepository) SetSecurityPolicy(_ context.Context, _ []byte) (gitprovider.Commit, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetSecurityPolicy is not supported, as Gitea has no security policy convention.
+// ErrNoProviderSupport is returned.
+func (r *orgRepository) GetSecurityPolicy(_ context.Context) ([]byte, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// giteaTemplateLayout describes where Gitea expects the templates, see https://docs.gitea.com/usage/issue-pull-request-templates
+//
+//nolint:gochecknoglobals
+var giteaTemplateLayout = gitprovider.TemplateLayout{
+	PullRequestTemplate: ".gitea/PULL_REQUEST_TEMPLATE.md",
+	IssueTemplatesDir:   ".gitea/ISSUE_TEMPLATE",
+}
+
+// giteaTemplateConventions describes where Gitea looks for the templates. Besides its own .gitea
+// directory, Gitea recognizes the locations used by GitHub and GitLab.
+//
+//nolint:gochecknoglobals
+var giteaTemplateConventions = gitprovider.TemplateConventions{
+	PullRequestTemplates: []string{
+		"pull_request_template.md",
+		".gitea/pull_request_template.md",
+		".github/pull_request_template.md",
+	},
+	IssueTemplates: []string{
+		"issue_template.md",
+		".gitea/issue_template.md",
+		".github/issue_template.md",
+	},
+	IssueTemplateDirs: []string{
+		".gitea/ISSUE_TEMPLATE",
+		".github/ISSUE_TEMPLATE",
+		".gitlab/issue_templates",
+	},
+}
+
 // validateRepositoryAPI validates the apiObj received from the server, to make sure that it is
 // valid for our use.
 func validateRepositoryAPI(apiObj *gitea.Repository) error {
@@ -271,7 +646,7 @@ func validateRepositoryAPI(apiObj *gitea.Repository) error {
 	})
 }
 
-func repositoryFromAPI(apiObj *gitea.Repository) gitprovider.RepositoryInfo {
+func repositoryFromAPI(apiObj *gitea.Repository, domain string) gitprovider.RepositoryInfo {
 	repo := gitprovider.RepositoryInfo{
 		Description:   &apiObj.Description,
 		DefaultBranch: &apiObj.DefaultBranch,
@@ -281,9 +656,32 @@ func repositoryFromAPI(apiObj *gitea.Repository) gitprovider.RepositoryInfo {
 	} else {
 		repo.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibility("private"))
 	}
+	if apiObj.Parent != nil && apiObj.Parent.Owner != nil {
+		// Gitea doesn't tell whether the parent owner is a user or an organization; both
+		// kinds of references resolve to the same URLs
+		repo.Parent = gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: apiObj.Parent.Owner.UserName},
+			RepositoryName:  apiObj.Parent.Name,
+		}
+	}
 	return repo
 }
 
+// permissionLevelFromAPI maps the permissions of the authenticated user on a repository to a PermissionLevel.
+func permissionLevelFromAPI(permissions *gitea.Permission) gitprovider.PermissionLevel {
+	switch {
+	case permissions == nil:
+		return gitprovider.PermissionLevelNone
+	case permissions.Admin:
+		return gitprovider.PermissionLevelAdmin
+	case permissions.Push:
+		return gitprovider.PermissionLevelWrite
+	case permissions.Pull:
+		return gitprovider.PermissionLevelRead
+	}
+	return gitprovider.PermissionLevelNone
+}
+
 func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) gitea.CreateRepoOption {
 	apiObj := gitea.CreateRepoOption{
 		Name: *gitprovider.StringVar(ref.GetRepository()),
@@ -299,7 +697,7 @@ func repositoryInfoToCreateOption(repo *gitprovider.RepositoryInfo, apiObj *gite
 	if repo.DefaultBranch != nil {
 		apiObj.DefaultBranch = *repo.DefaultBranch
 	}
-	if repo.Visibility != nil {
+	if repo.Visibility != nil && *repo.Visibility != gitprovider.RepositoryVisibilityDefault {
 		apiObj.Private = *gitprovider.BoolVar(string(*repo.Visibility) == "private")
 	}
 }
@@ -311,7 +709,7 @@ func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *gitea.Repo
 	if repo.DefaultBranch != nil {
 		apiObj.DefaultBranch = *repo.DefaultBranch
 	}
-	if repo.Visibility != nil {
+	if repo.Visibility != nil && *repo.Visibility != gitprovider.RepositoryVisibilityDefault {
 		apiObj.Private = *gitprovider.BoolVar(string(*repo.Visibility) == "private")
 	}
 }
@@ -353,3 +751,16 @@ type giteaRepositorySpec struct {
 func (s *giteaRepositorySpec) Equals(other *giteaRepositorySpec) bool {
 	return reflect.DeepEqual(s, other)
 }
+
+// Rename renames the repository by editing its name.
+func (r *orgRepository) Rename(_ context.Context, newName string) (gitprovider.OrgRepository, error) {
+	apiObj, err := updateRepo(r.c, r.ref.GetIdentity(), r.ref.GetRepository(), &gitea.EditRepoOption{
+		Name: &newName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ref := r.ref.(gitprovider.OrgRepositoryRef)
+	ref.RepositoryName = apiObj.Name
+	return newOrgRepository(r.clientContext, apiObj, ref), nil
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_isLicenseFile(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "LICENSE", want: true},
+		{name: "LICENSE.md", want: true},
+		{name: "license.txt", want: true},
+		{name: "LICENCE", want: true},
+		{name: "COPYING", want: true},
+		{name: "README.md", want: false},
+		{name: "LICENSES", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLicenseFile(tt.name); got != tt.want {
+				t.Errorf("isLicenseFile(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_SetAvatar(t *testing.T) {
+	var avatar bytes.Buffer
+	if err := png.Encode(&avatar, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+
+	mux, c := setup(t)
+	c.gitTransport.Auth = &githttp.BasicAuth{Username: "token", Password: "x-oauth-basic"}
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/avatar", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want %s", r.Method, http.MethodPost)
+		}
+		if user, _, _ := r.BasicAuth(); user != "token" {
+			t.Errorf("basic auth user = %q, want %q", user, "token")
+		}
+		var body avatarOption
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		got, err := base64.StdEncoding.DecodeString(body.Image)
+		if err != nil {
+			t.Fatalf("image isn't base64 encoded: %v", err)
+		}
+		if !bytes.Equal(got, avatar.Bytes()) {
+			t.Error("uploaded avatar doesn't match the image")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(c, &gitea.Repository{Name: "repo"}, ref)
+	if err := repo.SetAvatar(context.Background(), bytes.NewReader(avatar.Bytes())); err != nil {
+		t.Fatalf("SetAvatar() error = %v", err)
+	}
+}
+
+func TestOrgRepository_Subscribers(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/subscribers", func(w http.ResponseWriter, r *http.Request) {
+		users := []*gitea.User{{UserName: "carol"}}
+		if r.URL.Query().Get("page") == "1" {
+			users = make([]*gitea.User, subscribersPageSize)
+			for i := range users {
+				users[i] = &gitea.User{UserName: fmt.Sprintf("user%d", i)}
+			}
+		}
+		if err := json.NewEncoder(w).Encode(users); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(c, &gitea.Repository{Name: "repo"}, ref)
+	got, err := repo.Subscribers(context.Background())
+	if err != nil {
+		t.Fatalf("Subscribers() error = %v", err)
+	}
+	if len(got) != subscribersPageSize+1 {
+		t.Fatalf("Subscribers() returned %d users, want %d", len(got), subscribersPageSize+1)
+	}
+	want := gitprovider.UserRef{Domain: c.domain, UserLogin: "carol"}
+	if got[subscribersPageSize] != want {
+		t.Errorf("Subscribers() last user = %+v, want %+v", got[subscribersPageSize], want)
+	}
+}
+
+func TestOrgRepository_CommitActivity(t *testing.T) {
+	mux, c := setup(t)
+	created := time.Now().UTC().Format(time.RFC3339)
+	old := time.Now().AddDate(-2, 0, 0).UTC().Format(time.RFC3339)
+	var pages []string
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("sha"); got != "main" {
+			t.Errorf("sha = %q, want %q", got, "main")
+		}
+		pages = append(pages, r.URL.Query().Get("page"))
+		// Gitea lists the commits in topological order, the recent commits of a merged branch
+		// may come after commits older than a year
+		apiObjs := []map[string]string{{"sha": "c50", "created": created}}
+		if r.URL.Query().Get("page") == "1" {
+			apiObjs = make([]map[string]string, 0, commitFilterPageSize)
+			for i := 0; i < commitFilterPageSize; i++ {
+				date := created
+				if i >= 2 {
+					date = old
+				}
+				apiObjs = append(apiObjs, map[string]string{"sha": fmt.Sprintf("c%d", i), "created": date})
+			}
+		}
+		if err := json.NewEncoder(w).Encode(apiObjs); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(c, &gitea.Repository{Name: "repo", DefaultBranch: "main"}, ref)
+	got, err := repo.CommitActivity(context.Background())
+	if err != nil {
+		t.Fatalf("CommitActivity() error = %v", err)
+	}
+	if len(got) != gitprovider.CommitActivityWeeks {
+		t.Fatalf("CommitActivity() returned %d weeks, want %d", len(got), gitprovider.CommitActivityWeeks)
+	}
+	if total := got[len(got)-1].Total; total != 3 {
+		t.Errorf("CommitActivity() current week total = %d, want 3", total)
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, pages); diff != "" {
+		t.Errorf("listed pages (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrgRepository_CommitActivity_MaxCommits(t *testing.T) {
+	mux, c := setup(t)
+	old := time.Now().AddDate(-2, 0, 0).UTC().Format(time.RFC3339)
+	requests := 0
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		apiObjs := make([]map[string]string, 0, commitFilterPageSize)
+		for i := 0; i < commitFilterPageSize; i++ {
+			apiObjs = append(apiObjs, map[string]string{"sha": fmt.Sprintf("c%d", i), "created": old})
+		}
+		if err := json.NewEncoder(w).Encode(apiObjs); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(c, &gitea.Repository{Name: "repo", DefaultBranch: "main"}, ref)
+	if _, err := repo.CommitActivity(context.Background()); err != nil {
+		t.Fatalf("CommitActivity() error = %v", err)
+	}
+	if want := gitprovider.CommitActivityMaxCommits / commitFilterPageSize; requests != want {
+		t.Errorf("CommitActivity() listed %d pages, want %d", requests, want)
+	}
+}
+
+func TestOrgRepository_Language(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages map[string]int64
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "largest language",
+			languages: map[string]int64{"Go": 2048, "Shell": 512, "Makefile": 128},
+			want:      "Go",
+		},
+		{
+			name:      "tie",
+			languages: map[string]int64{"Shell": 512, "Go": 512},
+			want:      "Go",
+		},
+		{
+			name:      "no language",
+			languages: map[string]int64{},
+			wantErr:   gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			mux.HandleFunc("/api/v1/repos/fluxcd/repo/languages", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(tt.languages)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newOrgRepository(c, &gitea.Repository{Name: "repo"}, ref)
+			got, err := repo.Language(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Language() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Language() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_SetTemplates(t *testing.T) {
+	const template = "## Description\n"
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v1/repos/fluxcd/repo/contents/.gitea/PULL_REQUEST_TEMPLATE.md", func(w http.ResponseWriter, r *http.Request) {
+		var body gitea.CreateFileOptions
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		// Gitea takes the content base64-encoded, the rendered template must be encoded
+		got, err := base64.StdEncoding.DecodeString(body.Content)
+		if err != nil {
+			t.Fatalf("content isn't base64 encoded: %v", err)
+		}
+		if string(got) != template {
+			t.Errorf("committed content = %q, want %q", got, template)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"commit": {"sha": "c1", "author": {"name": "alice"}, "committer": {"name": "alice"}}}`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: c.domain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(c, &gitea.Repository{Name: "repo", DefaultBranch: "main"}, ref)
+	commit, err := repo.SetTemplates(context.Background(), gitprovider.TemplatesInfo{PullRequest: gitprovider.StringVar(template)})
+	if err != nil {
+		t.Fatalf("SetTemplates() error = %v", err)
+	}
+	if got := commit.Get().Sha; got != "c1" {
+		t.Errorf("SetTemplates() sha = %q, want c1", got)
+	}
+}
@@ -19,6 +19,7 @@ package gitea
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -79,10 +80,16 @@ func (ta *teamAccess) Update(ctx context.Context) error {
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
 //
 // If req doesn't exist under the hood, it is created (actionTaken == true).
-// If req doesn't equal the actual state, the resource will be deleted and recreated (actionTaken == true).
+// If req doesn't equal the actual state, the access mode of the team is updated (actionTaken == true).
 // If req is already the actual state, this is a no-op (actionTaken == false).
+// ErrPermissionNotSupported is returned if Gitea can't express the requested permission.
 func (ta *teamAccess) Reconcile(ctx context.Context) (bool, error) {
 	req := ta.Get()
+	if req.Permission != nil {
+		if _, err := getGiteaAccessMode(*req.Permission); err != nil {
+			return false, err
+		}
+	}
 	actual, err := ta.c.Get(ctx, req.Name)
 	if err != nil {
 		// Create if not found
@@ -106,6 +113,20 @@ func (ta *teamAccess) Reconcile(ctx context.Context) (bool, error) {
 	return true, ta.Update(ctx)
 }
 
+// getGiteaAccessMode returns the access mode matching the given permission.
+// Gitea has no triage or maintain access modes, ErrPermissionNotSupported is returned for these.
+func getGiteaAccessMode(permission gitprovider.RepositoryPermission) (gitea.AccessMode, error) {
+	switch permission {
+	case gitprovider.RepositoryPermissionPull:
+		return gitea.AccessModeRead, nil
+	case gitprovider.RepositoryPermissionPush:
+		return gitea.AccessModeWrite, nil
+	case gitprovider.RepositoryPermissionAdmin:
+		return gitea.AccessModeAdmin, nil
+	}
+	return gitea.AccessModeNone, fmt.Errorf("gitea teams can't have the %s permission: %w", permission, gitprovider.ErrPermissionNotSupported)
+}
+
 func getProviderPermission(accessMode gitea.AccessMode) (permission *gitprovider.RepositoryPermission) {
 	switch accessMode {
 	case gitea.AccessModeOwner, gitea.AccessModeAdmin:
@@ -17,15 +17,68 @@ limitations under the License.
 package gitea
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"code.gitea.io/sdk/gitea"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
 )
 
+// baseURL returns the base URL of the Gitea server at domain, which may include the scheme.
+func baseURL(domain string) string {
+	if !strings.Contains(domain, "://") {
+		return fmt.Sprintf("https://%s/", domain)
+	}
+	return domain
+}
+
+// doAPIRequest sends body as JSON to an API endpoint the Gitea SDK doesn't cover, using the
+// HTTP client and credentials of the Git transport. path is relative to "/api/v1". The JSON
+// response is decoded into out, unless it's nil.
+// This function handles HTTP error wrapping.
+func (c *clientContext) doAPIRequest(ctx context.Context, method, path string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(baseURL(c.domain), "/") + "/api/v1" + path
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if auth, ok := c.gitTransport.Auth.(githttp.AuthMethod); ok {
+		auth.SetAuth(req)
+	}
+	httpClient := c.gitTransport.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(res.Body)
+		return handleHTTPError(&gitea.Response{Response: res}, fmt.Errorf("%s %s: %d %s", method, path, res.StatusCode, bytes.TrimSpace(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
 // validateUserRepositoryRef makes sure the UserRepositoryRef is valid for Gitea's usage.
 func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
 	// Make sure the RepositoryRef fields are valid
@@ -127,10 +180,15 @@ func handleHTTPError(res *gitea.Response, err error) error {
 // // allPages runs fn for each page, expecting a HTTP request to be made and returned during that call.
 // // allPages expects that the data is saved in fn to an outer variable.
 // // allPages calls fn as many times as needed to get all pages, and modifies opts for each call.
+// // The Gitea SDK doesn't take a context per request, hence pages aren't bounded by
+// // the page timeout of the client; the error of ctx is still returned once ctx is done, between pages.
 // // There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
-func allPages(opts *gitea.ListOptions, fn func() (*gitea.Response, error)) error {
+func allPages(ctx context.Context, opts *gitea.ListOptions, fn func() (*gitea.Response, error)) error {
 	opts.Page = 1
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		resp, err := fn()
 		if err != nil {
 			return handleHTTPError(resp, err)
@@ -142,6 +200,20 @@ func allPages(opts *gitea.ListOptions, fn func() (*gitea.Response, error)) error
 	}
 }
 
+// countItems counts the items of a paginated list without fetching them all, by reading the
+// X-Total-Count header of a single-item page. nil is returned if Gitea doesn't set the header.
+func countItems(fn func(opts gitea.ListOptions) (*gitea.Response, error)) (*int, error) {
+	resp, err := fn(gitea.ListOptions{Page: 1, PageSize: 1})
+	if err != nil {
+		return nil, handleHTTPError(resp, err)
+	}
+	total, err := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	if err != nil {
+		return nil, nil
+	}
+	return &total, nil
+}
+
 // validateAPIObject creates a Validatior with the specified name, gives it to fn, and
 // depending on if any error was registered with it; either returns nil, or a MultiError
 // with both the validation error and ErrInvalidServerData, to mark that the server data
@@ -17,12 +17,30 @@ limitations under the License.
 package gitea
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"code.gitea.io/sdk/gitea"
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
 )
 
+// setup returns a clientContext talking to a local HTTP server, and the mux of that
+// server for registering the handlers of the endpoints under test.
+func setup(t *testing.T) (*http.ServeMux, *clientContext) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	// An empty version skips looking up the version of the server
+	c, err := gitea.NewClient(server.URL, gitea.SetGiteaVersion(""))
+	if err != nil {
+		t.Fatalf("unexpected error while creating the client: %v", err)
+	}
+	return mux, &clientContext{c: c, domain: server.URL, destructiveActions: true}
+}
+
 func Test_validateAPIObject(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -44,9 +44,11 @@ const (
 //
 // You can customize low-level HTTP Transport functionality by using the With{Pre,Post}ChainTransportHook options.
 // You can also use conditional requests (and an in-memory cache) using WithConditionalRequests.
+// Failed requests are retried using WithRetryPolicy.
+// The interactions with the API can be recorded and replayed using WithRecorder and WithReplayer.
 //
 // The chain of transports looks like this:
-// github.com API <-> "Post Chain" <-> Authentication <-> Cache <-> "Pre Chain" <-> *github.Client.
+// github.com API <-> "Post Chain" <-> Record/Replay <-> Retries <-> Authentication <-> Cache <-> "Pre Chain" <-> *github.Client.
 func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
 	// Complete the options struct
 	opts, err := gitprovider.MakeClientOptions(optFns...)
@@ -59,6 +61,11 @@ func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Downloads from pre-signed URLs go through the same transports, without the credentials
+	downloadClient, err := gitprovider.BuildClientFromTransportChain(opts.GetUnauthenticatedTransportChain())
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the GitHub client either for the default github.com domain, or
 	// a custom enterprise domain if opts.Domain is set to something other than
@@ -86,5 +93,7 @@ func NewClient(optFns ...gitprovider.ClientOption) (gitprovider.Client, error) {
 		destructiveActions = *opts.EnableDestructiveAPICalls
 	}
 
-	return newClient(gh, domain, destructiveActions), nil
+	c := newClient(gh, domain, destructiveActions, downloadClient)
+	c.defaultOwner = gitprovider.NewDefaultOwner(opts.DefaultOwner)
+	return c, nil
 }
@@ -18,6 +18,7 @@ package github
 
 import (
 	"context"
+	"net/http"
 	"strings"
 
 	"github.com/google/go-github/v66/github"
@@ -28,8 +29,8 @@ import (
 // ProviderID is the provider ID for GitHub.
 const ProviderID = gitprovider.ProviderID("github")
 
-func newClient(c *github.Client, domain string, destructiveActions bool) *Client {
-	ghClient := &githubClientImpl{c, destructiveActions}
+func newClient(c *github.Client, domain string, destructiveActions bool, downloadClient *http.Client) *Client {
+	ghClient := &githubClientImpl{c, destructiveActions, downloadClient}
 	ctx := &clientContext{ghClient, domain, destructiveActions}
 	return &Client{
 		clientContext: ctx,
@@ -61,6 +62,9 @@ type Client struct {
 	orgs      *OrganizationsClient
 	orgRepos  *OrgRepositoriesClient
 	userRepos *UserRepositoriesClient
+
+	// defaultOwner resolves the repository names given to Repo.
+	defaultOwner *gitprovider.DefaultOwner
 }
 
 // SupportedDomain returns the domain endpoint for this client, e.g. "github.com", "enterprise.github.com" or
@@ -110,19 +114,12 @@ func (c *Client) HasTokenPermission(ctx context.Context, permission gitprovider.
 		return false, gitprovider.ErrNoProviderSupport
 	}
 
-	// The X-OAuth-Scopes header is returned for any API calls, using Meta here to keep things simple.
-	_, res, err := c.c.Client().Meta.Get(ctx)
+	scopes, err := c.TokenScopes(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	scopes := res.Header.Get("X-OAuth-Scopes")
-	if scopes == "" {
-		return false, gitprovider.ErrMissingHeader
-	}
-
-	for _, s := range strings.Split(scopes, ",") {
-		scope := strings.TrimSpace(s)
+	for _, scope := range scopes {
 		if scope == requestedScope {
 			return true, nil
 		}
@@ -130,3 +127,59 @@ func (c *Client) HasTokenPermission(ctx context.Context, permission gitprovider.
 
 	return false, nil
 }
+
+// TokenScopes returns the OAuth scopes granted to the token, as reported by the X-OAuth-Scopes
+// header. Fine-grained personal access tokens don't have scopes, ErrMissingHeader is returned
+// for them.
+func (c *Client) TokenScopes(ctx context.Context) ([]string, error) {
+	// The X-OAuth-Scopes header is returned for any API calls, using Meta here to keep things simple.
+	_, res, err := c.c.Client().Meta.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header := res.Header.Get("X-OAuth-Scopes")
+	if header == "" {
+		return nil, gitprovider.ErrMissingHeader
+	}
+	return parseOAuthScopes(header), nil
+}
+
+// parseOAuthScopes splits the comma-separated scopes of the X-OAuth-Scopes header.
+func parseOAuthScopes(header string) []string {
+	scopes := []string{}
+	for _, s := range strings.Split(header, ",") {
+		if scope := strings.TrimSpace(s); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// webhookEventNames maps the supported WebhookEvents to GitHub webhook event names, see
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads
+//
+//nolint:gochecknoglobals
+var webhookEventNames = map[gitprovider.WebhookEvent]string{
+	gitprovider.WebhookEventPush:              "push",
+	gitprovider.WebhookEventTagPush:           "create",
+	gitprovider.WebhookEventPullRequest:       "pull_request",
+	gitprovider.WebhookEventPullRequestReview: "pull_request_review",
+	gitprovider.WebhookEventIssues:            "issues",
+	gitprovider.WebhookEventComment:           "issue_comment",
+	gitprovider.WebhookEventRelease:           "release",
+	gitprovider.WebhookEventDeployment:        "deployment",
+	gitprovider.WebhookEventPipeline:          "workflow_run",
+	gitprovider.WebhookEventWiki:              "gollum",
+	gitprovider.WebhookEventRepository:        "repository",
+}
+
+// Repo gets the repository with the given name, owned by the owner given through WithDefaultOwner.
+func (c *Client) Repo(ctx context.Context, name string) (gitprovider.UserRepository, error) {
+	return c.defaultOwner.Repository(ctx, c, name)
+}
+
+// SupportedWebhookEvents returns the webhook events GitHub can trigger webhooks for.
+func (c *Client) SupportedWebhookEvents() []gitprovider.WebhookEvent {
+	return gitprovider.SortedWebhookEvents(webhookEventNames)
+}
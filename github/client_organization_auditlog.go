@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// AuditLogClient implements the gitprovider.AuditLogClient interface.
+var _ gitprovider.AuditLogClient = &AuditLogClient{}
+
+// AuditLogClient reads the audit log of a specific organization. The audit log is only
+// available to organizations on GitHub Enterprise Cloud, ErrNotFound is returned otherwise.
+type AuditLogClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// List lists the entries of the audit log passing the filters of opts, newest first.
+// The date filters are applied server-side through the "created" search qualifier.
+func (c *AuditLogClient) List(ctx context.Context, opts gitprovider.AuditLogOptions) ([]gitprovider.AuditLogEntry, error) {
+	if err := opts.ValidateOptions(); err != nil {
+		return nil, err
+	}
+
+	qualifiers := []string{}
+	if opts.Since != nil {
+		qualifiers = append(qualifiers, "created:>="+opts.Since.UTC().Format(time.RFC3339))
+	}
+	if opts.Until != nil {
+		qualifiers = append(qualifiers, "created:<="+opts.Until.UTC().Format(time.RFC3339))
+	}
+	apiObjs, err := c.c.ListOrgAuditLog(ctx, c.ref.Organization, strings.Join(qualifiers, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]gitprovider.AuditLogEntry, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		entries = append(entries, auditLogEntryFromAPI(apiObj))
+	}
+	return entries, nil
+}
+
+// auditLogEntryFromAPI converts a GitHub audit log event. The target of the event is the
+// repository it's about if any, and the user it affected otherwise.
+func auditLogEntryFromAPI(apiObj *github.AuditEntry) gitprovider.AuditLogEntry {
+	entry := gitprovider.AuditLogEntry{
+		Actor:  apiObj.GetActor(),
+		Action: apiObj.GetAction(),
+		Target: apiObj.GetUser(),
+	}
+	if repo, ok := apiObj.AdditionalFields["repo"].(string); ok && repo != "" {
+		entry.Target = repo
+	}
+	// @timestamp is when the event happened, created_at when it was recorded
+	if apiObj.Timestamp != nil {
+		entry.CreatedAt = apiObj.Timestamp.Time
+	} else {
+		entry.CreatedAt = apiObj.GetCreatedAt().Time
+	}
+	return entry
+}
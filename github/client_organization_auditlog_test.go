@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestAuditLogClient_List(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/orgs/fluxcd/audit-log", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("phrase"), "created:>=2026-01-01T00:00:00Z created:<=2026-02-01T00:00:00Z"; got != want {
+			t.Errorf("phrase = %q, want %q", got, want)
+		}
+		// Serve each entry on its own page to exercise the cursor pagination
+		if r.URL.Query().Get("after") == "" {
+			w.Header().Set("Link", `<https://api.github.com/orgs/fluxcd/audit-log?after=c2>; rel="next"`)
+			fmt.Fprint(w, `[{"action": "repo.create", "actor": "alice", "repo": "fluxcd/flux2", "@timestamp": 1769904000000}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"action": "org.add_member", "actor": "bob", "user": "carol", "created_at": 1767225600000}]`)
+	})
+
+	c := &AuditLogClient{
+		clientContext: client.clientContext,
+		ref:           gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+	}
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	got, err := c.List(context.Background(), gitprovider.AuditLogOptions{Since: &since, Until: &until})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []gitprovider.AuditLogEntry{
+		{
+			Actor:     "alice",
+			Action:    "repo.create",
+			CreatedAt: until,
+			Target:    "fluxcd/flux2",
+		},
+		{
+			Actor:     "bob",
+			Action:    "org.add_member",
+			CreatedAt: since,
+			Target:    "carol",
+		},
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.List(context.Background(), gitprovider.AuditLogOptions{Since: &until, Until: &since}); err == nil {
+		t.Error("List() expected an error for Since after Until")
+	}
+}
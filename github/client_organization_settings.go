@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationSettingsClient implements the gitprovider.OrganizationSettingsClient interface.
+var _ gitprovider.OrganizationSettingsClient = &OrganizationSettingsClient{}
+
+// OrganizationSettingsClient operates on the org-wide defaults of a specific organization.
+// GitHub has no org-wide merge settings, these are configured per repository.
+type OrganizationSettingsClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get returns the org-wide defaults of the organization.
+// GitHub only returns these to the owners of the organization.
+func (c *OrganizationSettingsClient) Get(ctx context.Context) (gitprovider.OrganizationSettingsInfo, error) {
+	// GET /orgs/{org}
+	apiObj, err := c.c.GetOrg(ctx, c.ref.Organization)
+	if err != nil {
+		return gitprovider.OrganizationSettingsInfo{}, err
+	}
+	return organizationSettingsFromAPI(apiObj), nil
+}
+
+// Reconcile makes sure the fields set in req become the actual org-wide defaults of the organization.
+func (c *OrganizationSettingsClient) Reconcile(ctx context.Context, req gitprovider.OrganizationSettingsInfo) (bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+	if req.AllowAutoMerge != nil || req.AllowSquashMerge != nil {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+
+	actual, err := c.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// Only send the fields which differ from the actual state
+	patch := &github.Organization{}
+	actionTaken := false
+	if req.DefaultRepositoryPermission != nil && (actual.DefaultRepositoryPermission == nil || *req.DefaultRepositoryPermission != *actual.DefaultRepositoryPermission) {
+		patch.DefaultRepoPermission = github.String(string(*req.DefaultRepositoryPermission))
+		actionTaken = true
+	}
+	if req.MembersCanCreateRepositories != nil && (actual.MembersCanCreateRepositories == nil || *req.MembersCanCreateRepositories != *actual.MembersCanCreateRepositories) {
+		patch.MembersCanCreateRepos = req.MembersCanCreateRepositories
+		actionTaken = true
+	}
+	if !actionTaken {
+		return false, nil
+	}
+
+	// PATCH /orgs/{org}
+	_, err = c.c.UpdateOrg(ctx, c.ref.Organization, patch)
+	return true, err
+}
+
+func organizationSettingsFromAPI(apiObj *github.Organization) gitprovider.OrganizationSettingsInfo {
+	info := gitprovider.OrganizationSettingsInfo{
+		MembersCanCreateRepositories: apiObj.MembersCanCreateRepos,
+	}
+	if apiObj.DefaultRepoPermission != nil {
+		info.DefaultRepositoryPermission = gitprovider.PermissionLevelVar(gitprovider.PermissionLevel(*apiObj.DefaultRepoPermission))
+	}
+	return info
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrganizationSettingsClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             gitprovider.OrganizationSettingsInfo
+		wantActionTaken bool
+		wantPayload     map[string]interface{}
+		wantErr         error
+	}{
+		{
+			name: "no-op",
+			req: gitprovider.OrganizationSettingsInfo{
+				DefaultRepositoryPermission:  gitprovider.PermissionLevelVar(gitprovider.PermissionLevelRead),
+				MembersCanCreateRepositories: gitprovider.BoolVar(false),
+			},
+		},
+		{
+			name: "only changed fields",
+			req: gitprovider.OrganizationSettingsInfo{
+				DefaultRepositoryPermission:  gitprovider.PermissionLevelVar(gitprovider.PermissionLevelNone),
+				MembersCanCreateRepositories: gitprovider.BoolVar(false),
+			},
+			wantActionTaken: true,
+			wantPayload:     map[string]interface{}{"default_repository_permission": "none"},
+		},
+		{
+			name: "merge settings",
+			req: gitprovider.OrganizationSettingsInfo{
+				AllowSquashMerge: gitprovider.BoolVar(true),
+			},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var payload map[string]interface{}
+			mux.HandleFunc("/orgs/fluxcd", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+						t.Fatalf("failed to decode the request body: %v", err)
+					}
+				}
+				fmt.Fprint(w, `{"login": "fluxcd", "default_repository_permission": "read", "members_can_create_repositories": false}`)
+			})
+
+			c := &OrganizationSettingsClient{
+				clientContext: client.clientContext,
+				ref:           gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			}
+			actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reconcile() error = %v, want %v", err, tt.wantErr)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestOrganizationWebhookClient(t *testing.T) (*http.ServeMux, *OrganizationWebhookClient) {
+	mux, client := setup(t)
+	return mux, &OrganizationWebhookClient{
+		clientContext: client.clientContext,
+		ref:           gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+	}
+}
+
+func TestOrganizationWebhookClient_Create(t *testing.T) {
+	mux, c := newTestOrganizationWebhookClient(t)
+	var payload map[string]interface{}
+	mux.HandleFunc("/orgs/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1, "name": "web", "events": ["push", "pull_request"], "config": {"url": "https://audit.example.com/hook", "content_type": "json", "insecure_ssl": "0", "secret": "********"}}`)
+	})
+
+	hook, err := c.Create(context.Background(), gitprovider.OrganizationWebhookInfo{
+		URL:    "https://audit.example.com/hook",
+		Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+		Secret: gitprovider.StringVar("s3cr3t"),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	wantPayload := map[string]interface{}{
+		"name":   "web",
+		"events": []interface{}{"push", "pull_request"},
+		"config": map[string]interface{}{
+			"url":          "https://audit.example.com/hook",
+			"content_type": "json",
+			"insecure_ssl": "0",
+			"secret":       "s3cr3t",
+		},
+	}
+	if diff := cmp.Diff(wantPayload, payload); diff != "" {
+		t.Errorf("payload (-want +got):\n%s", diff)
+	}
+	wantInfo := gitprovider.OrganizationWebhookInfo{
+		URL:                "https://audit.example.com/hook",
+		Events:             []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush},
+		InsecureSkipVerify: gitprovider.BoolVar(false),
+		ContentType:        gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+	}
+	if diff := cmp.Diff(wantInfo, hook.Get()); diff != "" {
+		t.Errorf("Get() (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrganizationWebhookClient_Reconcile(t *testing.T) {
+	const existing = `[
+		{"id": 1, "name": "web", "events": ["push"], "config": {"url": "https://other.example.com/hook", "insecure_ssl": "0"}},
+		{"id": 2, "name": "web", "events": ["push", "star"], "config": {"url": "https://audit.example.com/hook", "content_type": "json", "insecure_ssl": "0", "secret": "********"}}
+	]`
+	tests := []struct {
+		name        string
+		req         gitprovider.OrganizationWebhookInfo
+		wantAction  bool
+		wantPayload map[string]interface{}
+	}{
+		{
+			name:       "up to date",
+			req:        gitprovider.OrganizationWebhookInfo{URL: "https://audit.example.com/hook"},
+			wantAction: false,
+		},
+		{
+			name: "events changed",
+			req: gitprovider.OrganizationWebhookInfo{
+				URL:    "https://audit.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventRelease},
+			},
+			wantAction: true,
+			wantPayload: map[string]interface{}{
+				"name":   "web",
+				"events": []interface{}{"star", "push", "release"},
+				"config": map[string]interface{}{
+					"url":          "https://audit.example.com/hook",
+					"content_type": "json",
+					"insecure_ssl": "0",
+				},
+			},
+		},
+		{
+			name: "secret rotated",
+			req: gitprovider.OrganizationWebhookInfo{
+				URL:    "https://audit.example.com/hook",
+				Secret: gitprovider.StringVar("r0tated"),
+			},
+			wantAction: true,
+			wantPayload: map[string]interface{}{
+				"name":   "web",
+				"events": []interface{}{"star", "push"},
+				"config": map[string]interface{}{
+					"url":          "https://audit.example.com/hook",
+					"content_type": "json",
+					"insecure_ssl": "0",
+					"secret":       "r0tated",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestOrganizationWebhookClient(t)
+			mux.HandleFunc("/orgs/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, existing)
+			})
+			var payload map[string]interface{}
+			mux.HandleFunc("/orgs/fluxcd/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				fmt.Fprint(w, `{"id": 2, "name": "web", "events": ["star", "push", "release"], "config": {"url": "https://audit.example.com/hook", "insecure_ssl": "0"}}`)
+			})
+
+			_, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if actionTaken != tt.wantAction {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantAction)
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrganizationWebhookClient_Reconcile_SSLAndContentType(t *testing.T) {
+	mux, c := newTestOrganizationWebhookClient(t)
+	mux.HandleFunc("/orgs/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("didn't expect the webhook to be recreated, got %s request", r.Method)
+		}
+		fmt.Fprint(w, `[{"id": 2, "name": "web", "events": ["push"], "config": {"url": "https://audit.example.com/hook", "content_type": "form", "insecure_ssl": "1"}}]`)
+	})
+	var payload map[string]interface{}
+	mux.HandleFunc("/orgs/fluxcd/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		fmt.Fprint(w, `{"id": 2, "name": "web", "events": ["push"], "config": {"url": "https://audit.example.com/hook", "content_type": "json", "insecure_ssl": "0"}}`)
+	})
+
+	hook, actionTaken, err := c.Reconcile(context.Background(), gitprovider.OrganizationWebhookInfo{
+		URL:                "https://audit.example.com/hook",
+		InsecureSkipVerify: gitprovider.BoolVar(false),
+		ContentType:        gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if !actionTaken {
+		t.Error("Reconcile() actionTaken = false, want true")
+	}
+	wantPayload := map[string]interface{}{
+		"name":   "web",
+		"events": []interface{}{"push"},
+		"config": map[string]interface{}{
+			"url":          "https://audit.example.com/hook",
+			"content_type": "json",
+			"insecure_ssl": "0",
+		},
+	}
+	if diff := cmp.Diff(wantPayload, payload); diff != "" {
+		t.Errorf("payload (-want +got):\n%s", diff)
+	}
+	if got := hook.Get(); *got.InsecureSkipVerify || *got.ContentType != gitprovider.WebhookContentTypeJSON {
+		t.Errorf("Reconcile() = %+v, want SSL verification on and JSON content type", got)
+	}
+}
+
+func TestOrganizationWebhookClient_Delete(t *testing.T) {
+	mux, c := newTestOrganizationWebhookClient(t)
+	mux.HandleFunc("/orgs/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 2, "name": "web", "events": ["push"], "config": {"url": "https://audit.example.com/hook"}}]`)
+	})
+	deleted := false
+	mux.HandleFunc("/orgs/fluxcd/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.Method == http.MethodDelete
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	hook, err := c.Get(context.Background(), "https://audit.example.com/hook")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	c.destructiveActions = false
+	if err := hook.Delete(context.Background()); !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+		t.Fatalf("Delete() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if deleted {
+		t.Fatal("the webhook was deleted without destructive API calls enabled")
+	}
+	c.destructiveActions = true
+	if err := hook.Delete(context.Background()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if !deleted {
+		t.Error("the webhook wasn't deleted")
+	}
+	if _, err := c.Get(context.Background(), "https://unknown.example.com/hook"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestOrganizationWebhookClient_SetActive(t *testing.T) {
+	mux, c := newTestOrganizationWebhookClient(t)
+	active := true
+	mux.HandleFunc("/orgs/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"id": 2, "name": "web", "active": %t, "events": ["push"], "config": {"url": "https://audit.example.com/hook"}}]`, active)
+	})
+	edits := 0
+	mux.HandleFunc("/orgs/fluxcd/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		if diff := cmp.Diff(map[string]interface{}{"active": !active}, payload); diff != "" {
+			t.Errorf("webhook payload (-want +got):\n%s", diff)
+		}
+		edits++
+		active = payload["active"].(bool)
+		fmt.Fprintf(w, `{"id": 2, "name": "web", "active": %t, "events": ["push"], "config": {"url": "https://audit.example.com/hook"}}`, active)
+	})
+
+	for _, want := range []bool{false, false, true} {
+		if err := c.SetActive(context.Background(), "https://audit.example.com/hook", want); err != nil {
+			t.Fatalf("SetActive(%t) error = %v", want, err)
+		}
+		if active != want {
+			t.Errorf("active = %t, want %t", active, want)
+		}
+	}
+	// Setting the current state again is a no-op
+	if edits != 2 {
+		t.Errorf("the webhook was edited %d times, want 2", edits)
+	}
+	if err := c.SetActive(context.Background(), "https://unknown.example.com/hook", false); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("SetActive() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
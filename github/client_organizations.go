@@ -18,6 +18,7 @@ package github
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
@@ -71,6 +72,45 @@ func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organizat
 	return orgs, nil
 }
 
+// ListWithRole lists the organizations where the authenticated user has at least the given role.
+// GitHub organizations have owners and members only, hence the owners match OrganizationRoleAdmin.
+//
+// ListWithRole returns all matching organizations, using multiple paginated requests if needed.
+func (c *OrganizationsClient) ListWithRole(ctx context.Context, role gitprovider.OrganizationRole) ([]gitprovider.Organization, error) {
+	if err := gitprovider.ValidateOrganizationRole(role); err != nil {
+		return nil, fmt.Errorf("invalid role %q: %w", role, gitprovider.ErrInvalidArgument)
+	}
+
+	// GET /user/memberships/orgs
+	memberships, err := c.c.ListOrgMemberships(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := []gitprovider.Organization{}
+	for _, membership := range memberships {
+		if !organizationRoleFromAPI(membership.GetRole()).AtLeast(role) {
+			continue
+		}
+		apiObj := membership.GetOrganization()
+		orgs = append(orgs, newOrganization(c.clientContext, apiObj, gitprovider.OrganizationRef{
+			Domain:       c.domain,
+			Organization: apiObj.GetLogin(),
+		}))
+	}
+
+	return orgs, nil
+}
+
+// organizationRoleFromAPI maps the role of a GitHub organization membership to an
+// OrganizationRole. GitHub's "admin" role is the owner of the organization.
+func organizationRoleFromAPI(role string) gitprovider.OrganizationRole {
+	if role == "admin" {
+		return gitprovider.OrganizationRoleOwner
+	}
+	return gitprovider.OrganizationRoleMember
+}
+
 // Children returns the immediate child-organizations for the specific OrganizationRef o.
 // The OrganizationRef may point to any existing sub-organization.
 //
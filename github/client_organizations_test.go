@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrganizationsClient_ListWithRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     gitprovider.OrganizationRole
+		wantOrgs []string
+		wantErr  error
+	}{
+		{
+			name:     "admin",
+			role:     gitprovider.OrganizationRoleAdmin,
+			wantOrgs: []string{"fluxcd"},
+		},
+		{
+			name:     "member",
+			role:     gitprovider.OrganizationRoleMember,
+			wantOrgs: []string{"fluxcd", "weaveworks"},
+		},
+		{
+			name:    "invalid role",
+			role:    gitprovider.OrganizationRole("maintainer"),
+			wantErr: gitprovider.ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/user/memberships/orgs", func(w http.ResponseWriter, r *http.Request) {
+				if state := r.URL.Query().Get("state"); state != "active" {
+					t.Errorf("unexpected state query: %q", state)
+				}
+				fmt.Fprint(w, `[
+					{"state": "active", "role": "admin", "organization": {"login": "fluxcd"}},
+					{"state": "active", "role": "member", "organization": {"login": "weaveworks"}}
+				]`)
+			})
+
+			orgs, err := client.Organizations().ListWithRole(context.Background(), tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListWithRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got := []string{}
+			for _, org := range orgs {
+				got = append(got, org.Organization().Organization)
+			}
+			if diff := cmp.Diff(tt.wantOrgs, got); diff != "" {
+				t.Errorf("ListWithRole() organizations mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrganizationsClient_Get_TwoFactorRequired(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *bool
+	}{
+		{
+			name:     "enforced",
+			response: `{"login": "fluxcd", "name": "Flux", "two_factor_requirement_enabled": true}`,
+			want:     gitprovider.BoolVar(true),
+		},
+		{
+			name:     "not enforced",
+			response: `{"login": "fluxcd", "name": "Flux", "two_factor_requirement_enabled": false}`,
+			want:     gitprovider.BoolVar(false),
+		},
+		{
+			// GitHub only reports the requirement to the owners of the organization
+			name:     "not reported",
+			response: `{"login": "fluxcd", "name": "Flux"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/orgs/fluxcd", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.response)
+			})
+
+			org, err := client.Organizations().Get(context.Background(), gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"})
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, org.Get().TwoFactorRequired); diff != "" {
+				t.Errorf("TwoFactorRequired mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ package github
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/go-github/v66/github"
 
@@ -76,6 +78,23 @@ func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.Organi
 	return repos, nil
 }
 
+// Iterate calls fn for each repository in the given organization, fetching the pages lazily.
+func (c *OrgRepositoriesClient) Iterate(ctx context.Context, ref gitprovider.OrganizationRef, fn func(gitprovider.OrgRepository) error) error {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return err
+	}
+
+	// GET /orgs/{org}/repos
+	return c.c.IterateOrgRepos(ctx, ref.Organization, func(apiObj *github.Repository) error {
+		// apiObj is already validated at IterateOrgRepos
+		return fn(newOrgRepository(c.clientContext, apiObj, gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  *apiObj.Name,
+		}))
+	})
+}
+
 // Create creates a repository for the given organization, with the data and options.
 //
 // ErrAlreadyExists will be returned if the resource already exists.
@@ -86,10 +105,29 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgR
 	}
 
 	apiObj, err := createRepository(ctx, c.c, ref, ref.Organization, req, opts...)
-	if err != nil {
+	if apiObj == nil {
+		// GitHub doesn't find the organization if it's a user
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			if isOrg, lookupErr := isOrganization(ctx, c.c, ref.Organization); lookupErr == nil && !isOrg {
+				return nil, gitprovider.NewErrIdentityTypeMismatch(ref.Organization, false)
+			}
+		}
 		return nil, err
 	}
-	return newOrgRepository(c.clientContext, apiObj, ref), nil
+	// The repository is returned along with the error if it was created, but the steps after the
+	// creation failed, see RepositoryCreateOptions
+	return newOrgRepository(c.clientContext, apiObj, ref), err
+}
+
+// isOrganization returns whether the given login belongs to an organization rather than a user.
+// ErrNotFound is returned if it belongs to neither.
+func isOrganization(ctx context.Context, c githubClient, login string) (bool, error) {
+	// GET /users/{username}
+	apiObj, err := c.GetUserByLogin(ctx, login)
+	if err != nil {
+		return false, err
+	}
+	return apiObj.GetType() == "Organization", nil
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -104,10 +142,17 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 		return nil, false, err
 	}
 
+	o := gitprovider.MakeRepositoryReconcileOptions(opts...)
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, false, err
+	}
 	actual, err := c.Get(ctx, ref)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
+			if err := o.CheckPrecondition(nil); err != nil {
+				return nil, false, err
+			}
 			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
 			return resp, true, err
 		}
@@ -115,11 +160,22 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 		// Unexpected path, Get should succeed or return NotFound
 		return nil, false, err
 	}
+	actualInfo := actual.Get()
+	if err := o.CheckPrecondition(&actualInfo); err != nil {
+		return nil, false, err
+	}
+
 	// Run generic reconciliation
 	actionTaken, err := reconcileRepository(ctx, actual, req)
 	return actual, actionTaken, err
 }
 
+// maxDescriptionLength is the maximum length, in characters, of the repository descriptions
+// accepted by GitHub.
+const maxDescriptionLength = 350
+
+// createRepository creates the repository, then applies the options needing further steps. If
+// these fail, the created repository is returned along with the error.
 func createRepository(ctx context.Context, c githubClient, ref gitprovider.RepositoryRef, orgName string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*github.Repository, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
@@ -132,12 +188,67 @@ func createRepository(ctx context.Context, c githubClient, ref gitprovider.Repos
 	if err != nil {
 		return nil, err
 	}
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, err
+	}
 
 	// Convert to the API object and apply the options
 	data := repositoryToAPI(&req, ref)
 	applyRepoCreateOptions(&data, o)
 
-	return c.CreateRepo(ctx, orgName, &data)
+	apiObj, err := c.CreateRepo(ctx, orgName, &data)
+	if err != nil {
+		return nil, err
+	}
+	// GitHub doesn't allow setting the message of the commit made by AutoInit, hence reword it
+	if o.AutoInit != nil && *o.AutoInit && o.InitialCommitMessage != nil {
+		err := gitprovider.RewordInitialCommit(ctx, apiObj.GetCloneURL(), *o.InitialCommitMessage, gitprovider.GitTransportOptions{
+			HTTPClient: c.Client().Client(),
+		})
+		if err != nil {
+			return apiObj, fmt.Errorf("failed to set the initial commit message: %w", err)
+		}
+	}
+	if len(o.Labels) != 0 {
+		if err := createLabels(ctx, c, apiObj.GetOwner().GetLogin(), apiObj.GetName(), o.Labels); err != nil {
+			return apiObj, fmt.Errorf("failed to create the labels: %w", err)
+		}
+	}
+	return apiObj, nil
+}
+
+// createLabels provisions the given labels in a newly created repository. GitHub creates a set
+// of default labels along with the repository, labels named like one of them are updated instead.
+func createLabels(ctx context.Context, c githubClient, owner, repo string, labels []gitprovider.LabelInfo) error {
+	existing, err := c.ListLabels(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]string, len(existing))
+	for _, apiObj := range existing {
+		names[strings.ToLower(apiObj.GetName())] = apiObj.GetName()
+	}
+	for _, label := range labels {
+		label.Default()
+		data := labelToAPI(&label)
+		if name, ok := names[strings.ToLower(label.Name)]; ok {
+			err = c.EditLabel(ctx, owner, repo, name, data)
+		} else {
+			err = c.CreateLabel(ctx, owner, repo, data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func labelToAPI(label *gitprovider.LabelInfo) *github.Label {
+	return &github.Label{
+		Name:        &label.Name,
+		Color:       &label.Color,
+		Description: label.Description,
+	}
 }
 
 func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
@@ -0,0 +1,268 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrgRepositoriesClient_Iterate(t *testing.T) {
+	errStop := errors.New("stop")
+	tests := []struct {
+		name      string
+		stopAfter int
+		wantErr   error
+		wantRepos int
+		wantPages int
+	}{
+		{
+			name:      "all pages",
+			wantRepos: 6,
+			wantPages: 3,
+		},
+		{
+			name:      "callback stops in the second page",
+			stopAfter: 3,
+			wantErr:   errStop,
+			wantRepos: 3,
+			wantPages: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			pages := 0
+			mux.HandleFunc("/orgs/fluxcd/repos", func(w http.ResponseWriter, r *http.Request) {
+				pages++
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				if page == 0 {
+					page = 1
+				}
+				if page < 3 {
+					w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/orgs/fluxcd/repos?page=%d>; rel="next"`, page+1))
+				}
+				fmt.Fprintf(w, `[{"name": "repo-%d-1"}, {"name": "repo-%d-2"}]`, page, page)
+			})
+
+			repos := 0
+			err := client.OrgRepositories().Iterate(context.Background(), gitprovider.OrganizationRef{
+				Domain:       DefaultDomain,
+				Organization: "fluxcd",
+			}, func(repo gitprovider.OrgRepository) error {
+				repos++
+				if repos == tt.stopAfter {
+					return errStop
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Iterate() error = %v, want %v", err, tt.wantErr)
+			}
+			if repos != tt.wantRepos {
+				t.Errorf("Iterate() called fn %d times, want %d", repos, tt.wantRepos)
+			}
+			if pages != tt.wantPages {
+				t.Errorf("Iterate() fetched %d pages, want %d", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Iterate_Canceled(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/orgs/fluxcd/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://api.github.com/orgs/fluxcd/repos?page=2>; rel="next"`)
+		fmt.Fprint(w, `[{"name": "repo-1"}, {"name": "repo-2"}]`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repos := 0
+	err := client.OrgRepositories().Iterate(ctx, gitprovider.OrganizationRef{
+		Domain:       DefaultDomain,
+		Organization: "fluxcd",
+	}, func(repo gitprovider.OrgRepository) error {
+		repos++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Iterate() error = %v, want %v", err, context.Canceled)
+	}
+	if repos != 1 {
+		t.Errorf("Iterate() called fn %d times after cancellation, want 1", repos)
+	}
+}
+
+func TestOrgRepositoriesClient_Create_Labels(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/orgs/fluxcd/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "repo", "owner": {"login": "fluxcd"}, "visibility": "private", "default_branch": "main"}`)
+	})
+	created := map[string]string{}
+	mux.HandleFunc("/repos/fluxcd/repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[{"name": "bug", "color": "d73a4a"}, {"name": "documentation", "color": "0075ca"}]`)
+		case http.MethodPost:
+			label := github.Label{}
+			if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			created[label.GetName()] = label.GetColor()
+			fmt.Fprint(w, `{}`)
+		}
+	})
+	updated := map[string]string{}
+	mux.HandleFunc("/repos/fluxcd/repo/labels/bug", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		label := github.Label{}
+		if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		updated[label.GetName()] = label.GetColor()
+		fmt.Fprint(w, `{}`)
+	})
+
+	_, err := client.OrgRepositories().Create(context.Background(), gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}, gitprovider.RepositoryInfo{}, &gitprovider.RepositoryCreateOptions{
+		Labels: []gitprovider.LabelInfo{
+			{Name: "Bug", Color: "#EE0701"},
+			{Name: "kind/flake", Color: "fbca04"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"kind/flake": "fbca04"}, created); diff != "" {
+		t.Errorf("created labels (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"Bug": "ee0701"}, updated); diff != "" {
+		t.Errorf("updated labels (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrgRepositoriesClient_Create_IdentityTypeMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		userType string
+		wantErr  error
+	}{
+		{
+			name:     "user given as organization",
+			userType: "User",
+			wantErr:  gitprovider.ErrIdentityTypeMismatch,
+		},
+		{
+			name:     "missing organization",
+			userType: "",
+			wantErr:  gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/orgs/stefanprodan/repos", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message": "Not Found"}`)
+			})
+			mux.HandleFunc("/users/stefanprodan", func(w http.ResponseWriter, r *http.Request) {
+				if tt.userType == "" {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message": "Not Found"}`)
+					return
+				}
+				fmt.Fprintf(w, `{"login": "stefanprodan", "type": %q}`, tt.userType)
+			})
+
+			_, err := client.OrgRepositories().Create(context.Background(), gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "stefanprodan"},
+				RepositoryName:  "repo",
+			}, gitprovider.RepositoryInfo{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Create_DescriptionTooLong(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		opts        []gitprovider.RepositoryCreateOption
+		wantErr     error
+	}{
+		{
+			name:        "within the limit",
+			description: strings.Repeat("a", maxDescriptionLength),
+		},
+		{
+			name:        "over the limit",
+			description: strings.Repeat("a", maxDescriptionLength+1),
+			wantErr:     gitprovider.ErrInvalidArgument,
+		},
+		{
+			name:        "over a lowered limit",
+			description: "a description",
+			opts:        []gitprovider.RepositoryCreateOption{&gitprovider.RepositoryCreateOptions{MaxDescriptionLength: gitprovider.IntVar(5)}},
+			wantErr:     gitprovider.ErrInvalidArgument,
+		},
+		{
+			name:        "limit disabled",
+			description: strings.Repeat("a", maxDescriptionLength+1),
+			opts:        []gitprovider.RepositoryCreateOption{&gitprovider.RepositoryCreateOptions{MaxDescriptionLength: gitprovider.IntVar(0)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			created := false
+			mux.HandleFunc("/orgs/fluxcd/repos", func(w http.ResponseWriter, r *http.Request) {
+				created = true
+				fmt.Fprint(w, `{"name": "repo", "owner": {"login": "fluxcd"}, "visibility": "private", "default_branch": "main"}`)
+			})
+
+			_, err := client.OrgRepositories().Create(context.Background(), gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}, gitprovider.RepositoryInfo{Description: gitprovider.StringVar(tt.description)}, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if created != (tt.wantErr == nil) {
+				t.Errorf("repository created = %v, want %v", created, tt.wantErr == nil)
+			}
+		})
+	}
+}
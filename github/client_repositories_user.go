@@ -109,11 +109,15 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 	}
 
 	if ref.GetIdentity() != idRef.GetIdentity() {
+		// Tell an organization passed as a user apart from another user
+		if isOrg, err := isOrganization(ctx, c.c, ref.GetIdentity()); err == nil && isOrg {
+			return nil, gitprovider.NewErrIdentityTypeMismatch(ref.GetIdentity(), true)
+		}
 		return nil, gitprovider.NewErrIncorrectUser(ref.GetIdentity())
 	}
 
 	apiObj, err := createRepository(ctx, c.c, ref, "", req, opts...)
-	if err != nil {
+	if apiObj == nil {
 		return nil, err
 	}
 
@@ -123,7 +127,9 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 	}
 	ref.UserLogin = *owner.Login
 
-	return newUserRepository(c.clientContext, apiObj, ref), nil
+	// The repository is returned along with the error if it was created, but the steps after the
+	// creation failed, see RepositoryCreateOptions
+	return newUserRepository(c.clientContext, apiObj, ref), err
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -138,10 +144,17 @@ func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.
 		return nil, false, err
 	}
 
+	o := gitprovider.MakeRepositoryReconcileOptions(opts...)
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, false, err
+	}
 	actual, err := c.Get(ctx, ref)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
+			if err := o.CheckPrecondition(nil); err != nil {
+				return nil, false, err
+			}
 			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
 			return resp, true, err
 		}
@@ -150,6 +163,11 @@ func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.
 		return nil, false, err
 	}
 
+	actualInfo := actual.Get()
+	if err := o.CheckPrecondition(&actualInfo); err != nil {
+		return nil, false, err
+	}
+
 	// Run generic reconciliation
 	actionTaken, err := reconcileRepository(ctx, actual, req)
 	return actual, actionTaken, err
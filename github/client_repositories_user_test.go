@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestUserRepositoriesClient_Create_IdentityTypeMismatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		userType     string
+		wantMismatch bool
+	}{
+		{
+			name:         "organization given as user",
+			userType:     "Organization",
+			wantMismatch: true,
+		},
+		{
+			name:     "another user",
+			userType: "User",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"login": "stefanprodan", "type": "User"}`)
+			})
+			mux.HandleFunc("/users/fluxcd", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"login": "fluxcd", "type": %q}`, tt.userType)
+			})
+			mux.HandleFunc("/user/repos", func(w http.ResponseWriter, r *http.Request) {
+				t.Error("the repository shouldn't be created")
+			})
+
+			_, err := client.UserRepositories().Create(context.Background(), gitprovider.UserRepositoryRef{
+				UserRef:        gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "fluxcd"},
+				RepositoryName: "repo",
+			}, gitprovider.RepositoryInfo{})
+			if got := errors.Is(err, gitprovider.ErrIdentityTypeMismatch); got != tt.wantMismatch {
+				t.Errorf("Create() error = %v, want ErrIdentityTypeMismatch: %v", err, tt.wantMismatch)
+			}
+			var incorrectUser *gitprovider.ErrIncorrectUser
+			if got := errors.As(err, &incorrectUser); got == tt.wantMismatch {
+				t.Errorf("Create() error = %v, want ErrIncorrectUser: %v", err, !tt.wantMismatch)
+			}
+		})
+	}
+}
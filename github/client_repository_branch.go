@@ -18,6 +18,8 @@ package github
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
 	"github.com/google/go-github/v66/github"
 
@@ -35,6 +37,10 @@ type BranchClient struct {
 
 // Create creates a branch with the given specifications.
 func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
+	sha, err := gitprovider.ExpandShortSHA(ctx, &CommitClient{clientContext: c.clientContext, ref: c.ref}, sha)
+	if err != nil {
+		return err
+	}
 
 	ref := "refs/heads/" + branch
 
@@ -51,3 +57,58 @@ func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
 
 	return nil
 }
+
+// RequiredChecks returns the names of the status checks required by the protection of the branch.
+// GitHub also reports ErrNotFound for protected branches which don't require status checks.
+func (c *BranchClient) RequiredChecks(ctx context.Context, branch string) ([]string, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}/protection/required_status_checks
+	required, _, err := c.c.Client().Repositories.GetRequiredStatusChecks(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch)
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		return nil, gitprovider.ErrNotFound
+	}
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// The legacy contexts mirror the checks, so only report each check once
+	names := []string{}
+	seen := map[string]struct{}{}
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	for _, name := range required.GetContexts() {
+		add(name)
+	}
+	for _, check := range required.GetChecks() {
+		add(check.Context)
+	}
+	return names, nil
+}
+
+// RequiresSignedCommits returns whether the protection of the branch requires signed commits.
+func (c *BranchClient) RequiresSignedCommits(ctx context.Context, branch string) (bool, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}/protection/required_signatures
+	signatures, _, err := c.c.Client().Repositories.GetSignaturesProtectedBranch(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch)
+	if isBranchNotProtected(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, handleHTTPError(err)
+	}
+	return signatures.GetEnabled(), nil
+}
+
+// isBranchNotProtected returns whether err is the "404 Not Found" response GitHub returns when
+// reading the protection of an unprotected branch, as opposed to a missing repository or branch.
+func isBranchNotProtected(err error) bool {
+	ghErrorResponse := &github.ErrorResponse{}
+	return errors.As(err, &ghErrorResponse) && ghErrorResponse.Response.StatusCode == http.StatusNotFound &&
+		ghErrorResponse.Message == "Branch not protected"
+}
+
+// DefaultReviewers is not supported by GitHub, ErrNoProviderSupport is returned.
+func (c *BranchClient) DefaultReviewers(_ context.Context, _ string) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
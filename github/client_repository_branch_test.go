@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchClient_Create_ShortSHA(t *testing.T) {
+	const fullSHA = "4a5b6c7d8e9f00112233445566778899aabbccdd"
+	tests := []struct {
+		name    string
+		sha     string
+		wantSHA string
+		wantErr error
+	}{
+		{
+			name:    "full SHA",
+			sha:     fullSHA,
+			wantSHA: fullSHA,
+		},
+		{
+			name:    "unique short SHA",
+			sha:     "4a5b6c7",
+			wantSHA: fullSHA,
+		},
+		{
+			name:    "ambiguous short SHA",
+			sha:     "abcdef1",
+			wantErr: gitprovider.ErrAmbiguousReference,
+		},
+		{
+			name:    "unknown short SHA",
+			sha:     "0000000",
+			wantErr: gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/repos/fluxcd/repo/commits/4a5b6c7", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, fullSHA)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/commits/abcdef1", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				fmt.Fprint(w, `{"message": "short SHA abcdef1 is ambiguous"}`)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/commits/0000000", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				fmt.Fprint(w, `{"message": "No commit found for SHA: 0000000"}`)
+			})
+			var gotSHA string
+			mux.HandleFunc("/repos/fluxcd/repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+				var ref struct {
+					Ref string `json:"ref"`
+					SHA string `json:"sha"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&ref); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				gotSHA = ref.SHA
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"ref": %q, "object": {"sha": %q}}`, ref.Ref, ref.SHA)
+			})
+
+			c := &BranchClient{
+				clientContext: client.clientContext,
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			err := c.Create(context.Background(), "feature", tt.sha)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotSHA != tt.wantSHA {
+				t.Errorf("Create() created the branch at %q, want %q", gotSHA, tt.wantSHA)
+			}
+		})
+	}
+}
+
+func TestBranchClient_RequiredChecks(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/branches/main/protection/required_status_checks", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"strict": true, "contexts": ["build", "ci/lint"], "checks": [{"context": "build"}, {"context": "ci/lint"}, {"context": "test", "app_id": 15368}]}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/branches/feature/protection/required_status_checks", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Branch not protected"}`)
+	})
+	c := &BranchClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	got, err := c.RequiredChecks(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("RequiredChecks() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"build", "ci/lint", "test"}, got); diff != "" {
+		t.Errorf("RequiredChecks() (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.RequiredChecks(context.Background(), "feature"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("RequiredChecks() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestBranchClient_RequiresSignedCommits(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/branches/main/protection/required_signatures", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"url": "https://api.github.com/repos/fluxcd/repo/branches/main/protection/required_signatures", "enabled": true}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/branches/release/protection/required_signatures", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"url": "https://api.github.com/repos/fluxcd/repo/branches/release/protection/required_signatures", "enabled": false}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/branches/feature/protection/required_signatures", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Branch not protected"}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/branches/missing/protection/required_signatures", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Branch not found"}`)
+	})
+	c := &BranchClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	tests := []struct {
+		branch  string
+		want    bool
+		wantErr error
+	}{
+		{branch: "main", want: true},
+		{branch: "release", want: false},
+		{branch: "feature", want: false},
+		{branch: "missing", wantErr: gitprovider.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			got, err := c.RequiresSignedCommits(context.Background(), tt.branch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RequiresSignedCommits() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RequiresSignedCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
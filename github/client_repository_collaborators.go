@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"sort"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// CollaboratorClient implements the gitprovider.CollaboratorClient interface.
+var _ gitprovider.CollaboratorClient = &CollaboratorClient{}
+
+// CollaboratorClient operates on the users having access to a specific repository.
+type CollaboratorClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists the users having access to the repository.
+// With CollaboratorListOptions.Effective, the organization members and the members of the
+// teams having access to the repository are listed too. GitHub then reports the highest of
+// the permissions granted to each user.
+func (c *CollaboratorClient) List(ctx context.Context, opts ...gitprovider.CollaboratorListOption) ([]gitprovider.CollaboratorInfo, error) {
+	o := gitprovider.MakeCollaboratorListOptions(opts...)
+	affiliation := "direct"
+	if o.Effective {
+		affiliation = "all"
+	}
+
+	apiObjs, err := c.c.ListRepoCollaborators(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), affiliation)
+	if err != nil {
+		return nil, err
+	}
+
+	collaborators := make([]gitprovider.CollaboratorInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		permission := getPermissionFromMap(apiObj.GetPermissions())
+		if permission == nil {
+			continue
+		}
+		collaborators = append(collaborators, gitprovider.CollaboratorInfo{
+			Login:      apiObj.GetLogin(),
+			Permission: *permission,
+		})
+	}
+	sort.Slice(collaborators, func(i, j int) bool {
+		return collaborators[i].Login < collaborators[j].Login
+	})
+	return collaborators, nil
+}
@@ -18,7 +18,9 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-github/v66/github"
@@ -37,10 +39,36 @@ type CommitClient struct {
 }
 
 // ListPage lists all repository commits of the given page and page size.
-// ListPage returns all available repository commits
-// using multiple paginated requests if needed.
 func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
-	dks, err := c.listPage(ctx, branch, perPage, page)
+	if err := gitprovider.ValidatePage(perPage, page); err != nil {
+		return nil, err
+	}
+	return c.List(ctx, gitprovider.CommitListOptions{
+		Branch:  branch,
+		PerPage: perPage,
+		Page:    page,
+	})
+}
+
+// Get returns the commit with the given SHA.
+//
+// ErrNotFound is returned if the commit does not exist.
+func (c *CommitClient) Get(ctx context.Context, sha string) (gitprovider.Commit, error) {
+	// GET /repos/{owner}/{repo}/git/commits/{commit_sha}
+	apiObj, err := c.c.GetGitCommit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), sha)
+	if err != nil {
+		return nil, err
+	}
+	return newCommit(c, apiObj), nil
+}
+
+// List lists the repository commits matching the given options.
+// All filters are applied server-side.
+func (c *CommitClient) List(ctx context.Context, opts gitprovider.CommitListOptions) ([]gitprovider.Commit, error) {
+	if err := opts.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	dks, err := c.list(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -52,9 +80,25 @@ func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, pag
 	return commits, nil
 }
 
-func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, page int) ([]*commitType, error) {
+func (c *CommitClient) list(ctx context.Context, opts gitprovider.CommitListOptions) ([]*commitType, error) {
+	lcOpts := &github.CommitsListOptions{
+		ListOptions: github.ListOptions{
+			PerPage: opts.PerPage,
+			Page:    opts.Page,
+		},
+		SHA:    opts.Branch,
+		Path:   opts.Path,
+		Author: opts.Author,
+	}
+	if opts.Since != nil {
+		lcOpts.Since = *opts.Since
+	}
+	if opts.Until != nil {
+		lcOpts.Until = *opts.Until
+	}
+
 	// GET /repos/{owner}/{repo}/commits
-	apiObjs, err := c.c.ListCommitsPage(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), branch, perPage, page)
+	apiObjs, err := c.c.ListCommitsPage(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), lcOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -69,11 +113,22 @@ func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, pag
 }
 
 // Create creates a commit with the given specifications.
-func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile, opts ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
+	o := gitprovider.MakeCommitCreateOptions(opts...)
+	if err := o.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	author, committer, err := signatures(o)
+	if err != nil {
+		return nil, err
+	}
 
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files added")
 	}
+	if err := gitprovider.ValidateCommitFiles(files); err != nil {
+		return nil, err
+	}
 
 	treeEntries := make([]*github.TreeEntry, 0)
 	for _, file := range files {
@@ -85,27 +140,31 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 		})
 	}
 
-	commits, err := c.ListPage(ctx, branch, 1, 0)
+	parents, baseTreeSHA, err := c.parents(ctx, branch, o)
 	if err != nil {
 		return nil, err
 	}
+	// GitHub fails obscurely when deleting a file missing from the base tree
+	for _, file := range files {
+		if file.GetAction() != gitprovider.CommitFileActionDelete {
+			continue
+		}
+		if err := c.checkFileExists(ctx, *file.Path, parents[0].GetSHA()); err != nil {
+			return nil, err
+		}
+	}
 
-	latestCommitTreeSHA := commits[0].Get().TreeSha
-
-	tree, _, err := c.c.Client().Git.CreateTree(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), latestCommitTreeSHA, treeEntries)
+	tree, _, err := c.c.Client().Git.CreateTree(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), baseTreeSHA, treeEntries)
 	if err != nil {
 		return nil, err
 	}
 
-	latestCommitSHA := commits[0].Get().Sha
 	nCommit, _, err := c.c.Client().Git.CreateCommit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Commit{
-		Message: &message,
-		Tree:    tree,
-		Parents: []*github.Commit{
-			{
-				SHA: &latestCommitSHA,
-			},
-		},
+		Message:   &message,
+		Tree:      tree,
+		Parents:   parents,
+		Author:    author,
+		Committer: committer,
 	}, nil)
 	if err != nil {
 		return nil, err
@@ -119,9 +178,181 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 		},
 	}
 
-	if _, _, err := c.c.Client().Git.UpdateRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), ghRef, true); err != nil {
+	// When the head is expected, only fast-forward the branch so that it fails if the branch moved
+	// after the check above. Commits with explicit parents may not descend from the head, hence
+	// the branch is forced to them, which their required expected head makes conditional.
+	force := o.ExpectedHeadSHA == "" || len(o.Parents) > 0
+	if _, _, err := c.c.Client().Git.UpdateRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), ghRef, force); err != nil {
+		if !force {
+			return nil, handleFastForwardHTTPError(err)
+		}
 		return nil, err
 	}
 
 	return newCommit(c, nCommit), nil
 }
+
+// signatures returns the author and committer of a commit created with the given options, nil
+// when GitHub fills them in, i.e. with the authenticated user or app and the current time. GitHub
+// requires the name and email along with a date, hence ErrInvalidArgument is returned if dates
+// are given without AuthorName and AuthorEmail.
+func signatures(o gitprovider.CommitCreateOptions) (*github.CommitAuthor, *github.CommitAuthor, error) {
+	if o.AuthorName == "" {
+		if o.AuthorDate != nil || o.CommitterDate != nil {
+			return nil, nil, fmt.Errorf("GitHub requires AuthorName and AuthorEmail along with the commit dates: %w", gitprovider.ErrInvalidArgument)
+		}
+		return nil, nil, nil
+	}
+
+	// The committer defaults to the author, date included
+	author := &github.CommitAuthor{Name: &o.AuthorName, Email: &o.AuthorEmail}
+	if o.AuthorDate != nil {
+		author.Date = &github.Timestamp{Time: *o.AuthorDate}
+	}
+	var committer *github.CommitAuthor
+	if o.CommitterDate != nil {
+		committer = &github.CommitAuthor{Name: &o.AuthorName, Email: &o.AuthorEmail, Date: &github.Timestamp{Time: *o.CommitterDate}}
+	}
+	return author, committer, nil
+}
+
+// checkFileExists returns ErrFileNotFound if there is no file at path in the given commit.
+func (c *CommitClient) checkFileExists(ctx context.Context, path, sha string) error {
+	// GET /repos/{owner}/{repo}/contents/{path}
+	fileContent, _, _, err := c.c.Client().Repositories.GetContents(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), path, &github.RepositoryContentGetOptions{Ref: sha})
+	if err != nil {
+		err = handleHTTPError(err)
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return fmt.Errorf("%q: %w", path, gitprovider.ErrFileNotFound)
+		}
+		return err
+	}
+	// The path is a directory
+	if fileContent == nil {
+		return fmt.Errorf("%q: %w", path, gitprovider.ErrFileNotFound)
+	}
+	return nil
+}
+
+// parents returns the parents of a commit created on branch with the given options, and the SHA
+// of the tree the files of the commit are applied on. The head of the branch is checked against
+// the options, and explicit parents are checked to exist.
+func (c *CommitClient) parents(ctx context.Context, branch string, o gitprovider.CommitCreateOptions) ([]*github.Commit, string, error) {
+	if len(o.Parents) == 0 {
+		commits, err := c.ListPage(ctx, branch, 1, 1)
+		if err != nil {
+			return nil, "", err
+		}
+		head := commits[0].Get()
+		if err := o.CheckHead(head.Sha); err != nil {
+			return nil, "", err
+		}
+		return []*github.Commit{{SHA: &head.Sha}}, head.TreeSha, nil
+	}
+
+	if o.ExpectedHeadSHA != "" {
+		head, err := c.ResolveRef(ctx, branch)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := o.CheckHead(head); err != nil {
+			return nil, "", err
+		}
+	}
+	parents := make([]*github.Commit, 0, len(o.Parents))
+	for _, sha := range o.Parents {
+		parent, err := c.c.GetGitCommit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), sha)
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return nil, "", fmt.Errorf("parent commit %s doesn't exist: %w", sha, gitprovider.ErrInvalidArgument)
+		} else if err != nil {
+			return nil, "", err
+		}
+		parents = append(parents, parent)
+	}
+	return parents, parents[0].GetTree().GetSHA(), nil
+}
+
+// SignatureKey returns the ID of the key that signed the commit with the given SHA.
+// ErrNotFound is returned if the commit is not signed.
+func (c *CommitClient) SignatureKey(ctx context.Context, sha string) (string, error) {
+	signature, err := c.c.GetCommitSignature(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), sha)
+	if err != nil {
+		return "", err
+	}
+	if signature == "" {
+		return "", gitprovider.ErrNotFound
+	}
+	return gitprovider.SignatureKeyID(signature)
+}
+
+// CheckAnnotations lists the annotations of the check runs of the commit with the given SHA.
+// Check runs without annotations are skipped without listing their annotations.
+func (c *CommitClient) CheckAnnotations(ctx context.Context, sha string) ([]gitprovider.Annotation, error) {
+	checkRuns, err := c.c.ListCheckRunsForRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), sha)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := []gitprovider.Annotation{}
+	for _, checkRun := range checkRuns {
+		if checkRun.GetOutput().GetAnnotationsCount() == 0 {
+			continue
+		}
+		apiObjs, err := c.c.ListCheckRunAnnotations(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), checkRun.GetID())
+		if err != nil {
+			return nil, err
+		}
+		for _, apiObj := range apiObjs {
+			annotations = append(annotations, gitprovider.Annotation{
+				CheckName: checkRun.GetName(),
+				Path:      apiObj.GetPath(),
+				StartLine: apiObj.GetStartLine(),
+				EndLine:   apiObj.GetEndLine(),
+				Level:     gitprovider.AnnotationLevel(apiObj.GetAnnotationLevel()),
+				Title:     apiObj.GetTitle(),
+				Message:   apiObj.GetMessage(),
+			})
+		}
+	}
+	return annotations, nil
+}
+
+// ContainedInBranches returns the names of the branches containing the commit with the given SHA.
+// GitHub can only list the branches a commit is the head of, so the commit is compared with the
+// head of each branch instead: the branch contains it if it's ahead of or identical to the commit.
+func (c *CommitClient) ContainedInBranches(ctx context.Context, sha string) ([]string, error) {
+	// Make sure the commit exists, as comparing with a missing commit fails for every branch
+	sha, err := c.ResolveRef(ctx, sha)
+	if err != nil {
+		return nil, err
+	}
+	apiObjs, err := c.c.ListBranches(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	heads := make(map[string]string, len(apiObjs))
+	branches := make([]string, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		heads[apiObj.GetName()] = apiObj.GetCommit().GetSHA()
+		branches = append(branches, apiObj.GetName())
+	}
+	sort.Strings(branches)
+
+	return gitprovider.BranchesContaining(ctx, branches, func(ctx context.Context, branch string) (bool, error) {
+		if heads[branch] == sha {
+			return true, nil
+		}
+		status, err := c.c.GetCompareStatus(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), sha, heads[branch])
+		if err != nil {
+			return false, err
+		}
+		return status == "ahead" || status == "identical", nil
+	})
+}
+
+// ResolveRef resolves a branch, a tag or an abbreviated commit SHA to the full SHA of its commit.
+func (c *CommitClient) ResolveRef(ctx context.Context, ref string) (string, error) {
+	// GET /repos/{owner}/{repo}/commits/{ref}
+	return c.c.GetCommitSHA(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), ref)
+}
@@ -0,0 +1,607 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newTestCommitClient(t *testing.T) (*http.ServeMux, *CommitClient) {
+	mux, client := setup(t)
+	return mux, &CommitClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestCommitClient_List(t *testing.T) {
+	since := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		opts      gitprovider.CommitListOptions
+		response  string
+		wantQuery url.Values
+		wantShas  []string
+	}{
+		{
+			name: "filters",
+			opts: gitprovider.CommitListOptions{
+				Branch:  "main",
+				Author:  "alice",
+				Since:   &since,
+				Until:   &until,
+				Path:    "docs",
+				PerPage: 10,
+				Page:    2,
+			},
+			response: `[{
+				"sha": "a2",
+				"html_url": "https://github.com/fluxcd/repo/commit/a2",
+				"commit": {"message": "docs", "tree": {"sha": "t2"}, "author": {"name": "alice", "date": "2023-01-15T12:00:00Z"}}
+			}]`,
+			wantQuery: url.Values{
+				"sha":      []string{"main"},
+				"author":   []string{"alice"},
+				"since":    []string{"2023-01-01T00:00:00Z"},
+				"until":    []string{"2023-02-01T00:00:00Z"},
+				"path":     []string{"docs"},
+				"per_page": []string{"10"},
+				"page":     []string{"2"},
+			},
+			wantShas: []string{"a2"},
+		},
+		{
+			name:      "empty result",
+			opts:      gitprovider.CommitListOptions{Branch: "main"},
+			response:  `[]`,
+			wantQuery: url.Values{"sha": []string{"main"}},
+			wantShas:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(tt.wantQuery, r.URL.Query()); diff != "" {
+					t.Errorf("query (-want +got):\n%s", diff)
+				}
+				fmt.Fprint(w, tt.response)
+			})
+
+			got, err := c.List(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			gotShas := []string{}
+			for _, commit := range got {
+				gotShas = append(gotShas, commit.Get().Sha)
+			}
+			if diff := cmp.Diff(tt.wantShas, gotShas); diff != "" {
+				t.Errorf("List() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// pagedCommitShas are the commits served page by page by the ListPage fake, newest first.
+var pagedCommitShas = []string{"c5", "c4", "c3", "c2", "c1"}
+
+func TestCommitClient_ListPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		perPage  int
+		page     int
+		wantShas []string
+		wantErr  error
+	}{
+		{name: "first page", perPage: 2, page: 1, wantShas: []string{"c5", "c4"}},
+		{name: "second page", perPage: 2, page: 2, wantShas: []string{"c3", "c2"}},
+		{name: "last page", perPage: 2, page: 3, wantShas: []string{"c1"}},
+		{name: "past the end", perPage: 2, page: 4, wantShas: []string{}},
+		{name: "zero page", perPage: 2, page: 0, wantErr: gitprovider.ErrInvalidArgument},
+		{name: "zero page size", perPage: 0, page: 1, wantErr: gitprovider.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+				start := min((page-1)*perPage, len(pagedCommitShas))
+				end := min(start+perPage, len(pagedCommitShas))
+				items := []string{}
+				for _, sha := range pagedCommitShas[start:end] {
+					items = append(items, fmt.Sprintf(`{"sha": %q, "html_url": "https://github.com/fluxcd/repo/commit", "commit": {"message": "m", "tree": {"sha": "t"}, "author": {"name": "alice", "date": "2023-01-15T12:00:00Z"}}}`, sha))
+				}
+				fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+			})
+
+			got, err := c.ListPage(context.Background(), "main", tt.perPage, tt.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListPage() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			gotShas := []string{}
+			for _, commit := range got {
+				gotShas = append(gotShas, commit.Get().Sha)
+			}
+			if diff := cmp.Diff(tt.wantShas, gotShas); diff != "" {
+				t.Errorf("ListPage() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommitClient_Get_MergeCommit(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	mux.HandleFunc("/repos/fluxcd/repo/git/commits/m1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"sha": "m1", "url": "https://api.github.com/repos/fluxcd/repo/git/commits/m1", "message": "Merge", "tree": {"sha": "t"}, "author": {"name": "alice", "date": "2023-01-15T12:00:00Z"}, "parents": [{"sha": "p1"}, {"sha": "p2"}]}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/commits", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"sha": "m1", "html_url": "https://github.com/fluxcd/repo/commit", "commit": {"message": "Merge", "tree": {"sha": "t"}, "author": {"name": "alice", "date": "2023-01-15T12:00:00Z"}}, "parents": [{"sha": "p1"}, {"sha": "p2"}]}]`)
+	})
+	wantParents := []string{"p1", "p2"}
+
+	commit, err := c.Get(context.Background(), "m1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if diff := cmp.Diff(wantParents, commit.Get().Parents); diff != "" {
+		t.Errorf("Get() parents (-want +got):\n%s", diff)
+	}
+
+	commits, err := c.ListPage(context.Background(), "main", 1, 1)
+	if err != nil {
+		t.Fatalf("ListPage() error = %v", err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("ListPage() returned %d commits, want 1", len(commits))
+	}
+	if diff := cmp.Diff(wantParents, commits[0].Get().Parents); diff != "" {
+		t.Errorf("ListPage() parents (-want +got):\n%s", diff)
+	}
+}
+
+// testSSHCommitSignature is a signature created by "ssh-keygen -Y sign -n git".
+const testSSHCommitSignature = "-----BEGIN SSH SIGNATURE-----\nU1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgzucSErncjCI0Nf5gf33gnBAOg7\nuy2aqH3mgIqLuFJSMAAAADZ2l0AAAAAAAAAAZzaGE1MTIAAABTAAAAC3NzaC1lZDI1NTE5\nAAAAQBZ/oYWgVkDVMhNAjBh/x9GBjjY43dDadL5m5a1YODIdBwRH1yW+FTUrQjhzNrkGsW\nqmd7jMNoKTt1FLc920MQA=\n-----END SSH SIGNATURE-----\n"
+
+func TestCommitClient_SignatureKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		verification string
+		want         string
+		wantErr      error
+	}{
+		{
+			name:         "ssh signed commit",
+			verification: fmt.Sprintf(`{"verified": true, "reason": "valid", "signature": %q}`, testSSHCommitSignature),
+			want:         "SHA256:PPLDQaRJtLJKsbiZJ95S2y64yWHANQHTR5CRbOaABDw",
+		},
+		{
+			name:         "unsigned commit",
+			verification: `{"verified": false, "reason": "unsigned", "signature": null}`,
+			wantErr:      gitprovider.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/repos/fluxcd/repo/commits/abc123", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"sha": "abc123", "commit": {"message": "test", "verification": %s}}`, tt.verification)
+			})
+
+			got, err := c.SignatureKey(context.Background(), "abc123")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SignatureKey() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SignatureKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitClient_Create_ExpectedHead(t *testing.T) {
+	tests := []struct {
+		name         string
+		head         string
+		refStatus    int
+		expectedHead string
+		wantErr      error
+		wantForce    bool
+	}{
+		{
+			name:      "unconditional",
+			head:      "a1",
+			refStatus: http.StatusOK,
+			wantForce: true,
+		},
+		{
+			name:         "head as expected",
+			head:         "a1",
+			refStatus:    http.StatusOK,
+			expectedHead: "a1",
+		},
+		{
+			name:         "head moved before the commit",
+			head:         "b2",
+			expectedHead: "a1",
+			wantErr:      gitprovider.ErrPreconditionFailed,
+		},
+		{
+			name:         "head moved while committing",
+			head:         "a1",
+			refStatus:    http.StatusUnprocessableEntity,
+			expectedHead: "a1",
+			wantErr:      gitprovider.ErrPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `[{"sha": %q, "html_url": "https://github.com/fluxcd/repo/commit", "commit": {"message": "m", "tree": {"sha": "t1"}, "author": {"name": "alice", "date": "2023-01-15T12:00:00Z"}}}]`, tt.head)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/trees", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"sha": "t2"}`)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"sha": "c3", "message": "update", "tree": {"sha": "t2"}}`)
+			})
+			refUpdated := false
+			mux.HandleFunc("/repos/fluxcd/repo/git/refs/heads/main", func(w http.ResponseWriter, r *http.Request) {
+				refUpdated = true
+				var payload struct {
+					Force bool `json:"force"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				if payload.Force != tt.wantForce {
+					t.Errorf("force = %v, want %v", payload.Force, tt.wantForce)
+				}
+				w.WriteHeader(tt.refStatus)
+				if tt.refStatus != http.StatusOK {
+					fmt.Fprint(w, `{"message": "Update is not a fast forward"}`)
+					return
+				}
+				fmt.Fprint(w, `{"ref": "refs/heads/main", "object": {"sha": "c3"}}`)
+			})
+
+			files := []gitprovider.CommitFile{{Path: gitprovider.StringVar("README.md"), Content: gitprovider.StringVar("hello")}}
+			_, err := c.Create(context.Background(), "main", "update", files, &gitprovider.CommitCreateOptions{
+				ExpectedHeadSHA: tt.expectedHead,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if wantUpdate := tt.refStatus != 0; refUpdated != wantUpdate {
+				t.Errorf("branch updated = %v, want %v", refUpdated, wantUpdate)
+			}
+		})
+	}
+}
+
+func TestCommitClient_Create_Parents(t *testing.T) {
+	tests := []struct {
+		name         string
+		parents      []string
+		expectedHead string
+		wantErr      error
+		wantParents  []string
+	}{
+		{
+			name:         "graft onto a historical commit",
+			parents:      []string{"p1"},
+			expectedHead: "c2",
+			wantParents:  []string{"p1"},
+		},
+		{
+			name:         "unknown parent",
+			parents:      []string{"p1", "missing"},
+			expectedHead: "c2",
+			wantErr:      gitprovider.ErrInvalidArgument,
+		},
+		{
+			name:         "branch moved",
+			parents:      []string{"p1"},
+			expectedHead: "c1",
+			wantErr:      gitprovider.ErrPreconditionFailed,
+		},
+		{
+			name:    "no expected head",
+			parents: []string{"p1"},
+			wantErr: validation.ErrFieldRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/repos/fluxcd/repo/commits/main", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "c2")
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/commits/p1", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"sha": "p1", "message": "historical", "tree": {"sha": "tp1"}}`)
+			})
+			treeCreated := false
+			mux.HandleFunc("/repos/fluxcd/repo/git/trees", func(w http.ResponseWriter, r *http.Request) {
+				treeCreated = true
+				var payload struct {
+					BaseTree string `json:"base_tree"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				if payload.BaseTree != "tp1" {
+					t.Errorf("base tree = %q, want the tree of the first parent", payload.BaseTree)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"sha": "t2"}`)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+				var payload struct {
+					Parents []string `json:"parents"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				if diff := cmp.Diff(tt.wantParents, payload.Parents); diff != "" {
+					t.Errorf("commit parents (-want +got):\n%s", diff)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"sha": "c3", "message": "graft", "tree": {"sha": "t2"}, "parents": [{"sha": "p1"}]}`)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/refs/heads/main", func(w http.ResponseWriter, r *http.Request) {
+				var payload struct {
+					Force bool `json:"force"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				if !payload.Force {
+					t.Error("the branch should be force-moved to the grafted commit")
+				}
+				fmt.Fprint(w, `{"ref": "refs/heads/main", "object": {"sha": "c3"}}`)
+			})
+
+			files := []gitprovider.CommitFile{{Path: gitprovider.StringVar("README.md"), Content: gitprovider.StringVar("hello")}}
+			_, err := c.Create(context.Background(), "main", "graft", files, &gitprovider.CommitCreateOptions{
+				Parents:         tt.parents,
+				ExpectedHeadSHA: tt.expectedHead,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && treeCreated {
+				t.Error("Create() created a tree despite the error")
+			}
+		})
+	}
+}
+
+func TestCommitClient_CheckAnnotations(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	mux.HandleFunc("/repos/fluxcd/repo/commits/abc/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_count": 2, "check_runs": [
+			{"id": 1, "name": "build", "output": {"annotations_count": 0}},
+			{"id": 2, "name": "lint", "output": {"annotations_count": 3}}
+		]}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/check-runs/1/annotations", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("CheckAnnotations() listed the annotations of a check run without annotations")
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/check-runs/2/annotations", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			fmt.Fprint(w, `[
+				{"path": "main.go", "start_line": 10, "end_line": 10, "annotation_level": "failure", "title": "errcheck", "message": "Error return value is not checked"},
+				{"path": "main.go", "start_line": 20, "end_line": 24, "annotation_level": "warning", "message": "Function is too long"}
+			]`)
+			return
+		}
+		fmt.Fprint(w, `[{"path": "README.md", "start_line": 1, "end_line": 1, "annotation_level": "notice", "message": "Consider adding a badge"}]`)
+	})
+
+	got, err := c.CheckAnnotations(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("CheckAnnotations() error = %v", err)
+	}
+	want := []gitprovider.Annotation{
+		{CheckName: "lint", Path: "main.go", StartLine: 10, EndLine: 10, Level: gitprovider.AnnotationLevelFailure, Title: "errcheck", Message: "Error return value is not checked"},
+		{CheckName: "lint", Path: "main.go", StartLine: 20, EndLine: 24, Level: gitprovider.AnnotationLevelWarning, Message: "Function is too long"},
+		{CheckName: "lint", Path: "README.md", StartLine: 1, EndLine: 1, Level: gitprovider.AnnotationLevelNotice, Message: "Consider adding a badge"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CheckAnnotations() (-want +got):\n%s", diff)
+	}
+}
+
+func TestCommitClient_ContainedInBranches(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	mux.HandleFunc("/repos/fluxcd/repo/commits/c1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "c1")
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/branches", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[
+			{"name": "release", "commit": {"sha": "c1"}},
+			{"name": "main", "commit": {"sha": "c3"}},
+			{"name": "feature", "commit": {"sha": "f1"}}
+		]`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/compare/c1...c3", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"status": "ahead"}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/compare/c1...f1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"status": "diverged"}`)
+	})
+
+	got, err := c.ContainedInBranches(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("ContainedInBranches() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"main", "release"}, got); diff != "" {
+		t.Errorf("ContainedInBranches() (-want +got):\n%s", diff)
+	}
+}
+
+func TestCommitClient_Create_NestedPaths(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	mux.HandleFunc("/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"sha": "a1", "html_url": "https://github.com/fluxcd/repo/commit", "commit": {"message": "m", "tree": {"sha": "t1"}, "author": {"name": "alice", "date": "2023-01-15T12:00:00Z"}}}]`)
+	})
+	var tree struct {
+		BaseTree string `json:"base_tree"`
+		Tree     []struct {
+			Path string `json:"path"`
+		} `json:"tree"`
+	}
+	mux.HandleFunc("/repos/fluxcd/repo/git/trees", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&tree); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha": "t2"}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha": "c3", "message": "scaffold", "tree": {"sha": "t2"}}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/git/refs/heads/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref": "refs/heads/main", "object": {"sha": "c3"}}`)
+	})
+
+	// GitHub creates the trees of the missing directories from the full paths
+	files := []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("a/b/c/file.txt"), Content: gitprovider.StringVar("hello")},
+		{Path: gitprovider.StringVar("a/b/.gitkeep"), Content: gitprovider.StringVar("")},
+	}
+	if _, err := c.Create(context.Background(), "main", "scaffold", files); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if tree.BaseTree != "t1" {
+		t.Errorf("base tree = %q, want t1", tree.BaseTree)
+	}
+	gotPaths := []string{}
+	for _, entry := range tree.Tree {
+		gotPaths = append(gotPaths, entry.Path)
+	}
+	if diff := cmp.Diff([]string{"a/b/c/file.txt", "a/b/.gitkeep"}, gotPaths); diff != "" {
+		t.Errorf("tree paths (-want +got):\n%s", diff)
+	}
+
+	// Directories can't be committed without files
+	files = []gitprovider.CommitFile{{Path: gitprovider.StringVar("a/b/"), Content: gitprovider.StringVar("")}}
+	if _, err := c.Create(context.Background(), "main", "scaffold", files); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Create() error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestCommitClient_Create_Dates(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	// App installation tokens can't get the authenticated user
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Create() requested the authenticated user")
+		http.Error(w, `{"message": "Resource not accessible by integration"}`, http.StatusForbidden)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"sha": "a1", "html_url": "https://github.com/fluxcd/repo/commit", "commit": {"message": "m", "tree": {"sha": "t1"}, "author": {"name": "alice", "date": "2023-01-15T12:00:00Z"}}}]`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/git/trees", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha": "t2"}`)
+	})
+	var payload struct {
+		Author    *github.CommitAuthor `json:"author"`
+		Committer *github.CommitAuthor `json:"committer"`
+	}
+	mux.HandleFunc("/repos/fluxcd/repo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha": "c3", "url": "https://api.github.com/repos/fluxcd/repo/git/commits/c3", "message": "import", "tree": {"sha": "t2"}, "author": {"name": "alice", "date": "2015-03-01T00:00:00Z"}}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/git/refs/heads/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref": "refs/heads/main", "object": {"sha": "c3"}}`)
+	})
+
+	date := time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC)
+	files := []gitprovider.CommitFile{{Path: gitprovider.StringVar("README.md"), Content: gitprovider.StringVar("hello")}}
+	commit, err := c.Create(context.Background(), "main", "import", files, &gitprovider.CommitCreateOptions{
+		AuthorName:  "alice",
+		AuthorEmail: "alice@example.com",
+		AuthorDate:  &date,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	// The committer defaults to the author, date included
+	if payload.Committer != nil {
+		t.Errorf("committer = %v, want none", payload.Committer)
+	}
+	want := &github.CommitAuthor{
+		Name:  github.String("alice"),
+		Email: github.String("alice@example.com"),
+		Date:  &github.Timestamp{Time: date},
+	}
+	if diff := cmp.Diff(want, payload.Author); diff != "" {
+		t.Errorf("commit author (-want +got):\n%s", diff)
+	}
+	if got := commit.Get().CreatedAt; !got.Equal(date) {
+		t.Errorf("CreatedAt = %v, want %v", got, date)
+	}
+
+	// GitHub doesn't take dates without the identity
+	if _, err := c.Create(context.Background(), "main", "import", files, &gitprovider.CommitCreateOptions{
+		AuthorDate: &date,
+	}); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Create() error = %v, want ErrInvalidArgument", err)
+	}
+
+	// Dates in the future are rejected before committing
+	future := time.Now().AddDate(1, 0, 0)
+	if _, err := c.Create(context.Background(), "main", "import", files, &gitprovider.CommitCreateOptions{
+		AuthorDate: &future,
+	}); !errors.Is(err, validation.ErrFieldInvalid) {
+		t.Errorf("Create() error = %v, want ErrFieldInvalid", err)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// customPropertyMultiSelect is the value type of custom properties having a list of values.
+	customPropertyMultiSelect = "multi_select"
+	// customPropertyTrueFalse is the value type of boolean custom properties.
+	customPropertyTrueFalse = "true_false"
+)
+
+// CustomPropertiesClient implements the gitprovider.CustomPropertiesClient interface.
+var _ gitprovider.CustomPropertiesClient = &CustomPropertiesClient{}
+
+// CustomPropertiesClient operates on the custom properties of a specific repository.
+type CustomPropertiesClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the values of the custom properties set on the repository, by property name.
+// The values of multi-select properties are comma-separated.
+func (c *CustomPropertiesClient) Get(ctx context.Context) (map[string]string, error) {
+	apiObjs, err := c.c.GetRepoCustomPropertyValues(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+	return customPropertiesFromAPI(apiObjs), nil
+}
+
+// Reconcile makes sure the properties in req have the given values, an empty value unsetting
+// the property. Properties not in req are left untouched.
+//
+// req is validated against the custom properties defined by the organization, if the
+// authenticated user is allowed to read them.
+func (c *CustomPropertiesClient) Reconcile(ctx context.Context, req map[string]string) (bool, error) {
+	schema, err := c.schema(ctx)
+	if err != nil {
+		return false, err
+	}
+	if err := validateCustomProperties(req, schema); err != nil {
+		return false, err
+	}
+
+	actual, err := c.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	// Sort the names to send a deterministic request
+	names := make([]string, 0, len(req))
+	for name := range req {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	changed := []*github.CustomPropertyValue{}
+	for _, name := range names {
+		if actual[name] != req[name] {
+			changed = append(changed, customPropertyValueToAPI(name, req[name], schema[name]))
+		}
+	}
+	if len(changed) == 0 {
+		return false, nil
+	}
+	return true, c.c.UpdateRepoCustomPropertyValues(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), changed)
+}
+
+// schema returns the custom properties defined by the organization owning the repository by name,
+// or nil if they can't be read, e.g. as the repository is owned by a user or the authenticated
+// user isn't an organization member.
+func (c *CustomPropertiesClient) schema(ctx context.Context) (map[string]*github.CustomProperty, error) {
+	apiObjs, err := c.c.ListOrgCustomProperties(ctx, c.ref.GetIdentity())
+	var credErr *gitprovider.InvalidCredentialsError
+	if errors.Is(err, gitprovider.ErrNotFound) || errors.As(err, &credErr) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	schema := make(map[string]*github.CustomProperty, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		schema[apiObj.GetPropertyName()] = apiObj
+	}
+	return schema, nil
+}
+
+// validateCustomProperties validates req against schema, unless schema is nil.
+func validateCustomProperties(req map[string]string, schema map[string]*github.CustomProperty) error {
+	if schema == nil {
+		return nil
+	}
+	for name, value := range req {
+		prop, ok := schema[name]
+		if !ok {
+			return fmt.Errorf("custom property %q isn't defined by the organization: %w", name, gitprovider.ErrInvalidArgument)
+		}
+		if value == "" {
+			if prop.GetRequired() {
+				return fmt.Errorf("required custom property %q can't be unset: %w", name, gitprovider.ErrInvalidArgument)
+			}
+			continue
+		}
+		if prop.ValueType == customPropertyTrueFalse && value != "true" && value != "false" {
+			return fmt.Errorf("custom property %q must be true or false, got %q: %w", name, value, gitprovider.ErrInvalidArgument)
+		}
+		if len(prop.AllowedValues) == 0 {
+			continue
+		}
+		allowed := make(map[string]struct{}, len(prop.AllowedValues))
+		for _, v := range prop.AllowedValues {
+			allowed[v] = struct{}{}
+		}
+		values := []string{value}
+		if prop.ValueType == customPropertyMultiSelect {
+			values = strings.Split(value, ",")
+		}
+		for _, v := range values {
+			if _, ok := allowed[v]; !ok {
+				return fmt.Errorf("value %q isn't allowed for custom property %q: %w", v, name, gitprovider.ErrInvalidArgument)
+			}
+		}
+	}
+	return nil
+}
+
+func customPropertiesFromAPI(apiObjs []*github.CustomPropertyValue) map[string]string {
+	props := make(map[string]string, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		switch v := apiObj.Value.(type) {
+		case string:
+			props[apiObj.PropertyName] = v
+		case []string:
+			props[apiObj.PropertyName] = strings.Join(v, ",")
+		}
+	}
+	return props
+}
+
+// customPropertyValueToAPI converts the value of the named property to its API object, prop being
+// the definition of the property if known.
+func customPropertyValueToAPI(name, value string, prop *github.CustomProperty) *github.CustomPropertyValue {
+	apiObj := &github.CustomPropertyValue{PropertyName: name}
+	switch {
+	case value == "":
+		// A null value unsets the property
+	case prop != nil && prop.ValueType == customPropertyMultiSelect:
+		apiObj.Value = strings.Split(value, ",")
+	default:
+		apiObj.Value = value
+	}
+	return apiObj
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const customPropertiesSchema = `[
+	{"property_name": "team", "value_type": "single_select", "allowed_values": ["platform", "web"]},
+	{"property_name": "tier", "value_type": "string", "required": true}
+]`
+
+func TestCustomPropertiesClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             map[string]string
+		wantActionTaken bool
+		wantPayload     map[string]interface{}
+		wantErr         error
+	}{
+		{
+			name: "no-op",
+			req:  map[string]string{"team": "platform", "tier": "1"},
+		},
+		{
+			name:            "change a value",
+			req:             map[string]string{"team": "web", "tier": "1"},
+			wantActionTaken: true,
+			wantPayload: map[string]interface{}{
+				"properties": []interface{}{
+					map[string]interface{}{"property_name": "team", "value": "web"},
+				},
+			},
+		},
+		{
+			name:    "value not allowed",
+			req:     map[string]string{"team": "mobile"},
+			wantErr: gitprovider.ErrInvalidArgument,
+		},
+		{
+			name:    "undefined property",
+			req:     map[string]string{"owner": "flux"},
+			wantErr: gitprovider.ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/orgs/fluxcd/properties/schema", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, customPropertiesSchema)
+			})
+			var gotPayload map[string]interface{}
+			mux.HandleFunc("/repos/fluxcd/repo/properties/values", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, `[{"property_name": "team", "value": "platform"}, {"property_name": "tier", "value": "1"}]`)
+				case http.MethodPatch:
+					if err := json.NewDecoder(r.Body).Decode(&gotPayload); err != nil {
+						t.Fatalf("failed to decode the request body: %v", err)
+					}
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected %s request", r.Method)
+				}
+			})
+
+			c := &CustomPropertiesClient{
+				clientContext: client.clientContext,
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reconcile() error = %v, want %v", err, tt.wantErr)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(tt.wantPayload, gotPayload); diff != "" {
+				t.Errorf("custom properties payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
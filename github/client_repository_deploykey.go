@@ -138,6 +138,13 @@ func (c *DeployKeyClient) Reconcile(ctx context.Context, req gitprovider.DeployK
 	return actual, true, actual.Update(ctx)
 }
 
+// ReconcileBatch makes sure all the given desired deploy keys (reqs) become the actual state in the
+// backing Git provider, listing the existing deploy keys only once.
+// See gitprovider.ReconcileDeployKeys for details.
+func (c *DeployKeyClient) ReconcileBatch(ctx context.Context, reqs []gitprovider.DeployKeyInfo) ([]gitprovider.DeployKeyBatchResult, error) {
+	return gitprovider.ReconcileDeployKeys(ctx, c, reqs)
+}
+
 func createDeployKey(ctx context.Context, c githubClient, ref gitprovider.RepositoryRef, req gitprovider.DeployKeyInfo) (*github.Key, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestDeployKeyClient_LastUsed(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request, the keys shouldn't be updated", r.Method)
+		}
+		fmt.Fprint(w, `[
+			{"id": 1, "title": "used", "key": "ssh-ed25519 AAAAused", "read_only": true, "last_used": "2023-01-15T12:00:00Z"},
+			{"id": 2, "title": "unused", "key": "ssh-ed25519 AAAAunused", "read_only": true}
+		]`)
+	})
+	c := &DeployKeyClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	used, err := c.Get(context.Background(), "used")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	want := time.Date(2023, time.January, 15, 12, 0, 0, 0, time.UTC)
+	if got := used.Get().LastUsed; got == nil || !got.Equal(want) {
+		t.Errorf("LastUsed = %v, want %v", got, want)
+	}
+	if got := used.Get().LastUsedFrom; got != nil {
+		t.Errorf("LastUsedFrom = %v, want nil", *got)
+	}
+
+	keys, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, key := range keys {
+		if key.Get().Name == "unused" && key.Get().LastUsed != nil {
+			t.Errorf("LastUsed of the unused key = %v, want nil", *key.Get().LastUsed)
+		}
+	}
+
+	// The usage of the key mustn't be seen as a difference with the desired state
+	_, actionTaken, err := c.Reconcile(context.Background(), gitprovider.DeployKeyInfo{
+		Name: "used",
+		Key:  []byte("ssh-ed25519 AAAAused"),
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if actionTaken {
+		t.Error("Reconcile() took action, want no-op")
+	}
+}
+
+func TestDeployKeyClient_List_Fingerprint(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/keys", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "title": "flux", "key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHjOPER7YZeZ4xWXO3stnnb0HFDmD/vk+IiVhEQGiA6Y", "read_only": true}]`)
+	})
+	c := &DeployKeyClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	keys, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("List() returned %d keys, want 1", len(keys))
+	}
+	want := "SHA256:KMfTVsNiAiJWpmRtthaD2VhAOv1H7u/9uER9xBnI+j8"
+	if got := keys[0].Get().Fingerprint; got == nil || *got != want {
+		t.Errorf("Fingerprint = %v, want %q", got, want)
+	}
+}
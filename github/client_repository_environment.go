@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"sort"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// EnvironmentClient implements the gitprovider.EnvironmentClient interface.
+var _ gitprovider.EnvironmentClient = &EnvironmentClient{}
+
+// EnvironmentClient operates on the deployment environments of a specific repository.
+type EnvironmentClient struct {
+	*clientContext
+	// repoID is the ID of the repository, as the environment secrets are only addressable by it.
+	repoID int64
+}
+
+// Secrets returns a client reading the secrets of the given environment.
+func (c *EnvironmentClient) Secrets(environment string) gitprovider.EnvironmentSecretClient {
+	return &EnvironmentSecretClient{clientContext: c.clientContext, repoID: c.repoID, environment: environment}
+}
+
+// EnvironmentSecretClient implements the gitprovider.EnvironmentSecretClient interface.
+var _ gitprovider.EnvironmentSecretClient = &EnvironmentSecretClient{}
+
+// EnvironmentSecretClient reads the GitHub Actions secrets of a deployment environment. GitHub
+// never returns the values of the secrets, and requires admin access to the repository to list them.
+type EnvironmentSecretClient struct {
+	*clientContext
+	repoID      int64
+	environment string
+}
+
+// List returns the names of the secrets along with when they were last updated.
+func (c *EnvironmentSecretClient) List(ctx context.Context) ([]gitprovider.SecretMetadataInfo, error) {
+	// GET /repositories/{repository_id}/environments/{environment_name}/secrets
+	apiObjs, err := c.c.ListEnvSecrets(ctx, c.repoID, c.environment)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]gitprovider.SecretMetadataInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		secrets = append(secrets, gitprovider.SecretMetadataInfo{
+			Name:      apiObj.Name,
+			UpdatedAt: apiObj.UpdatedAt.Time,
+		})
+	}
+	return secrets, nil
+}
+
+// ListNames returns the names of the secrets, sorted.
+func (c *EnvironmentSecretClient) ListNames(ctx context.Context) ([]string, error) {
+	secrets, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		names = append(names, secret.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
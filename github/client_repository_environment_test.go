@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestEnvironmentSecretClient_List(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repositories/42/environments/production/secrets", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"total_count": 2, "secrets": [
+				{"name": "API_TOKEN", "created_at": "2026-01-01T00:00:00Z", "updated_at": "2026-09-01T00:00:00Z"}
+			]}`)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/repositories/42/environments/production/secrets?page=2>; rel="next"`)
+		fmt.Fprint(w, `{"total_count": 2, "secrets": [
+			{"name": "DEPLOY_KEY", "created_at": "2026-01-01T00:00:00Z", "updated_at": "2026-10-01T00:00:00Z"}
+		]}`)
+	})
+	mux.HandleFunc("/repositories/42/environments/missing/secrets", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	})
+
+	envs := &EnvironmentClient{clientContext: client.clientContext, repoID: 42}
+	c := envs.Secrets("production")
+
+	got, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []gitprovider.SecretMetadataInfo{
+		{Name: "DEPLOY_KEY", UpdatedAt: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "API_TOKEN", UpdatedAt: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+
+	names, err := c.ListNames(context.Background())
+	if err != nil {
+		t.Fatalf("ListNames() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"API_TOKEN", "DEPLOY_KEY"}, names); diff != "" {
+		t.Errorf("ListNames() (-want +got):\n%s", diff)
+	}
+
+	if _, err := envs.Secrets("missing").ListNames(context.Background()); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("ListNames() error = %v, want ErrNotFound", err)
+	}
+}
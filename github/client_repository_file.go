@@ -48,41 +48,46 @@ func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...git
 		opt.ApplyFilesGetOptions(&fileOpts)
 	}
 
-	_, directoryContent, _, err := c.c.Client().Repositories.GetContents(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), path, opts)
+	fileContent, directoryContent, _, err := c.c.Client().Repositories.GetContents(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), path, opts)
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(err)
 	}
 
-	if len(directoryContent) == 0 {
-		return nil, fmt.Errorf("no files found on this path[%s]", path)
+	paths := make([]string, 0, len(directoryContent))
+	if fileContent != nil {
+		// A file path was given, its content is returned along with it unless it's too large
+		if fileContent.GetEncoding() == "base64" {
+			content, err := fileContent.GetContent()
+			if err != nil {
+				return nil, err
+			}
+			return []*gitprovider.CommitFile{{Path: gitprovider.StringVar(fileContent.GetPath()), Content: &content}}, nil
+		}
+		paths = append(paths, fileContent.GetPath())
 	}
-
-	files := make([]*gitprovider.CommitFile, 0)
-
-	// For handling to close the output [io.ReadCloser] errors.
-	var errs error
 	for _, file := range directoryContent {
-		filePath := file.Path
-		output, _, err := c.c.Client().Repositories.DownloadContents(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), *filePath, opts)
+		paths = append(paths, file.GetPath())
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files found on this path[%s]", path)
+	}
+	return gitprovider.FetchFiles(ctx, paths, fileOpts.Concurrency, func(ctx context.Context, filePath string) (*gitprovider.CommitFile, error) {
+		output, _, err := c.c.Client().Repositories.DownloadContents(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), filePath, opts)
 		if err != nil {
-			return nil, err
+			return nil, handleHTTPError(err)
 		}
 		content, err := io.ReadAll(output)
 		if err != nil {
+			return nil, errors.Join(err, output.Close())
+		}
+		if err := output.Close(); err != nil {
 			return nil, err
 		}
-		// Don't use defer in the for loop. Checks errors lazily.
-		errs = errors.Join(errs, output.Close())
 
 		contentStr := string(content)
-		files = append(files, &gitprovider.CommitFile{
-			Path:    filePath,
+		return &gitprovider.CommitFile{
+			Path:    &filePath,
 			Content: &contentStr,
-		})
-	}
-	if errs != nil {
-		return nil, errs
-	}
-
-	return files, nil
+		}, nil
+	})
 }
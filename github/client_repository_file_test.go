@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// newTestFileClient serves a "dir" directory of n small files. Downloads take delay, and the
+// highest number of downloads in flight is recorded in maxInFlight.
+func newTestFileClient(t testing.TB, n int, delay time.Duration, maxInFlight *int32) *FileClient {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/contents/dir", func(w http.ResponseWriter, r *http.Request) {
+		entries := make([]map[string]string, 0, n)
+		for i := 0; i < n; i++ {
+			name := fmt.Sprintf("file-%03d", i)
+			entries = append(entries, map[string]string{
+				"type":         "file",
+				"name":         name,
+				"path":         "dir/" + name,
+				"download_url": "http://" + r.Host + "/download/dir/" + name,
+			})
+		}
+		_ = json.NewEncoder(w).Encode(entries)
+	})
+	var inFlight int32
+	mux.HandleFunc("/download/dir/", func(w http.ResponseWriter, r *http.Request) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			old := atomic.LoadInt32(maxInFlight)
+			if cur <= old || atomic.CompareAndSwapInt32(maxInFlight, old, cur) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		fmt.Fprintf(w, "content of %s", r.URL.Path)
+	})
+	return &FileClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestFileClient_Get_Concurrency(t *testing.T) {
+	var maxInFlight int32
+	c := newTestFileClient(t, 20, 10*time.Millisecond, &maxInFlight)
+
+	files, err := c.Get(context.Background(), "dir", "main", &gitprovider.FilesGetOptions{Concurrency: 3})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(files) != 20 {
+		t.Fatalf("Get() returned %d files, want 20", len(files))
+	}
+	for i, f := range files {
+		wantPath := fmt.Sprintf("dir/file-%03d", i)
+		if *f.Path != wantPath {
+			t.Errorf("files[%d].Path = %q, want %q", i, *f.Path, wantPath)
+		}
+		if want := "content of /download/" + wantPath; *f.Content != want {
+			t.Errorf("files[%d].Content = %q, want %q", i, *f.Content, want)
+		}
+	}
+	if maxInFlight > 3 {
+		t.Errorf("%d downloads were in flight at once, want at most 3", maxInFlight)
+	}
+}
+
+func BenchmarkFileClient_Get(b *testing.B) {
+	for _, concurrency := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			var maxInFlight int32
+			c := newTestFileClient(b, 100, time.Millisecond, &maxInFlight)
+			opts := &gitprovider.FilesGetOptions{Concurrency: concurrency}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := c.Get(context.Background(), "dir", "main", opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestFileClient_Get_File(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/contents/SECURITY.md", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"type": "file", "name": "SECURITY.md", "path": "SECURITY.md", "encoding": "base64", "content": "IyBTZWN1cml0eSBQb2xpY3kK"}`)
+	})
+	c := &FileClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	files, err := c.Get(context.Background(), "SECURITY.md", "main")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(files) != 1 || *files[0].Path != "SECURITY.md" || *files[0].Content != "# Security Policy\n" {
+		t.Errorf("Get() = %+v, want the content of SECURITY.md", files)
+	}
+}
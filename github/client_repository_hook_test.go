@@ -0,0 +1,223 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestHookClient(t *testing.T) (*http.ServeMux, *HookClient) {
+	mux, client := setup(t)
+	return mux, &HookClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestHookClient_Create(t *testing.T) {
+	mux, c := newTestHookClient(t)
+	var payload map[string]interface{}
+	mux.HandleFunc("/repos/fluxcd/repo/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1, "name": "web", "active": true, "events": ["push"], "config": {"url": "https://ci.example.com/hook", "content_type": "json", "secret": "********"}}`)
+	})
+
+	hook, err := c.Create(context.Background(), gitprovider.DeployHookInfo{
+		URL:    "https://ci.example.com/hook",
+		Secret: gitprovider.StringVar("s3cr3t"),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	wantPayload := map[string]interface{}{
+		"name":   "web",
+		"active": true,
+		"events": []interface{}{"push"},
+		"config": map[string]interface{}{
+			"url":          "https://ci.example.com/hook",
+			"content_type": "json",
+			"secret":       "s3cr3t",
+		},
+	}
+	if diff := cmp.Diff(wantPayload, payload); diff != "" {
+		t.Errorf("payload (-want +got):\n%s", diff)
+	}
+	// The secret is write-only, and never returned
+	wantInfo := gitprovider.DeployHookInfo{
+		URL:         "https://ci.example.com/hook",
+		Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPush},
+		ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+		Active:      gitprovider.BoolVar(true),
+	}
+	if diff := cmp.Diff(wantInfo, hook.Get()); diff != "" {
+		t.Errorf("Get() (-want +got):\n%s", diff)
+	}
+}
+
+func TestHookClient_Reconcile(t *testing.T) {
+	const existing = `[
+		{"id": 1, "name": "web", "active": true, "events": ["push"], "config": {"url": "https://other.example.com/hook"}},
+		{"id": 2, "name": "web", "active": true, "events": ["push", "pull_request", "star"], "config": {"url": "https://ci.example.com/hook", "content_type": "json", "secret": "********"}}
+	]`
+	tests := []struct {
+		name        string
+		req         gitprovider.DeployHookInfo
+		wantAction  bool
+		wantPayload map[string]interface{}
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush},
+			},
+			wantAction: false,
+		},
+		{
+			// The actual secret can't be compared, hence it's always sent
+			name: "rotated secret",
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush},
+				Secret: gitprovider.StringVar("r0t4t3d"),
+			},
+			wantAction: true,
+			wantPayload: map[string]interface{}{
+				"name":   "web",
+				"active": true,
+				"events": []interface{}{"star", "pull_request", "push"},
+				"config": map[string]interface{}{
+					"url":          "https://ci.example.com/hook",
+					"content_type": "json",
+					"secret":       "r0t4t3d",
+				},
+			},
+		},
+		{
+			// Events GitHub names differently are mapped, and unknown ones kept
+			name: "deactivated",
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventComment},
+				Active: gitprovider.BoolVar(false),
+			},
+			wantAction: true,
+			wantPayload: map[string]interface{}{
+				"name":   "web",
+				"active": false,
+				"events": []interface{}{"star", "push", "issue_comment"},
+				"config": map[string]interface{}{
+					"url":          "https://ci.example.com/hook",
+					"content_type": "json",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestHookClient(t)
+			mux.HandleFunc("/repos/fluxcd/repo/hooks", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				fmt.Fprint(w, existing)
+			})
+			var payload map[string]interface{}
+			mux.HandleFunc("/repos/fluxcd/repo/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				fmt.Fprint(w, `{"id": 2, "name": "web", "active": false, "events": ["push", "pull_request"], "config": {"url": "https://ci.example.com/hook", "content_type": "json"}}`)
+			})
+
+			_, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if actionTaken != tt.wantAction {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantAction)
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeployHook_Delete(t *testing.T) {
+	tests := []struct {
+		name               string
+		destructiveActions bool
+		wantErr            error
+		wantDeleted        bool
+	}{
+		{
+			name:               "destructive actions enabled",
+			destructiveActions: true,
+			wantDeleted:        true,
+		},
+		{
+			name:    "destructive actions disabled",
+			wantErr: gitprovider.ErrDestructiveCallDisallowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestHookClient(t)
+			c.destructiveActions = tt.destructiveActions
+			deleted := false
+			mux.HandleFunc("/repos/fluxcd/repo/hooks/1", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			hook := newDeployHook(c, &github.Hook{ID: github.Int64(1), Config: &github.HookConfig{URL: github.String("https://example.com/hook")}})
+			if err := hook.Delete(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("Delete() deleted the webhook = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// InteractionLimitsClient implements the gitprovider.InteractionLimitsClient interface.
+var _ gitprovider.InteractionLimitsClient = &InteractionLimitsClient{}
+
+// InteractionLimitsClient operates on the interaction limits of a specific repository.
+type InteractionLimitsClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the interaction limits in effect.
+//
+// ErrNotFound is returned if interactions aren't limited.
+func (c *InteractionLimitsClient) Get(ctx context.Context) (gitprovider.InteractionLimitsInfo, error) {
+	apiObj, err := c.c.GetRepoInteractionLimits(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return gitprovider.InteractionLimitsInfo{}, err
+	}
+	// GitHub returns an empty object if interactions aren't limited
+	if apiObj.Limit == nil {
+		return gitprovider.InteractionLimitsInfo{}, gitprovider.ErrNotFound
+	}
+	return interactionLimitsFromAPI(apiObj), nil
+}
+
+// Set limits the interactions with the repository, replacing the limits in effect.
+func (c *InteractionLimitsClient) Set(ctx context.Context, req gitprovider.InteractionLimitsInfo) (gitprovider.InteractionLimitsInfo, error) {
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return gitprovider.InteractionLimitsInfo{}, err
+	}
+	apiObj, err := c.c.SetRepoInteractionLimits(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), string(req.Limit), string(*req.Expiry))
+	if err != nil {
+		return gitprovider.InteractionLimitsInfo{}, err
+	}
+	return interactionLimitsFromAPI(apiObj), nil
+}
+
+// Remove lifts the interaction limits.
+func (c *InteractionLimitsClient) Remove(ctx context.Context) error {
+	return c.c.RemoveRepoInteractionLimits(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+}
+
+func interactionLimitsFromAPI(apiObj *github.InteractionRestriction) gitprovider.InteractionLimitsInfo {
+	info := gitprovider.InteractionLimitsInfo{
+		Limit: gitprovider.InteractionLimit(apiObj.GetLimit()),
+	}
+	if apiObj.ExpiresAt != nil {
+		expiresAt := apiObj.ExpiresAt.Time
+		info.ExpiresAt = &expiresAt
+	}
+	return info
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestInteractionLimitsClient_SetAndRemove(t *testing.T) {
+	mux, client := setup(t)
+	limits := `{}`
+	mux.HandleFunc("/repos/fluxcd/repo/interaction-limits", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, limits)
+		case http.MethodPut:
+			var payload map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			want := map[string]interface{}{"limit": "collaborators_only", "expiry": "one_week"}
+			if diff := cmp.Diff(want, payload); diff != "" {
+				t.Errorf("interaction limits payload (-want +got):\n%s", diff)
+			}
+			limits = `{"limit": "collaborators_only", "origin": "repository", "expires_at": "2026-01-08T00:00:00Z"}`
+			fmt.Fprint(w, limits)
+		case http.MethodDelete:
+			limits = `{}`
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	})
+
+	c := &InteractionLimitsClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	ctx := context.Background()
+	if _, err := c.Get(ctx); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Fatalf("Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+
+	got, err := c.Set(ctx, gitprovider.InteractionLimitsInfo{
+		Limit:  gitprovider.InteractionLimitCollaboratorsOnly,
+		Expiry: gitprovider.InteractionLimitExpiryVar(gitprovider.InteractionLimitExpiryOneWeek),
+	})
+	if err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	expiresAt := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+	want := gitprovider.InteractionLimitsInfo{
+		Limit:     gitprovider.InteractionLimitCollaboratorsOnly,
+		ExpiresAt: &expiresAt,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Set() (-want +got):\n%s", diff)
+	}
+	if got, err := c.Get(ctx); err != nil || got.Limit != gitprovider.InteractionLimitCollaboratorsOnly {
+		t.Errorf("Get() = %+v, %v, want the collaborators only limit", got, err)
+	}
+
+	if err := c.Remove(ctx); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := c.Get(ctx); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() after Remove() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestMilestoneClient(t *testing.T) (*http.ServeMux, *MilestoneClient) {
+	mux, client := setup(t)
+	return mux, &MilestoneClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestMilestoneClient_List(t *testing.T) {
+	mux, c := newTestMilestoneClient(t)
+	mux.HandleFunc("/repos/fluxcd/repo/milestones", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != "all" {
+			t.Errorf("state = %q, want %q", got, "all")
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"number": 2, "title": "v2.0", "state": "open"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2&state=all>; rel="next"`, r.URL.Path))
+		fmt.Fprint(w, `[{"number": 1, "title": "v1.0", "state": "closed", "due_on": "2023-04-01T07:00:00Z"}]`)
+	})
+
+	milestones, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	got := []gitprovider.MilestoneInfo{}
+	for _, m := range milestones {
+		got = append(got, m.Get())
+	}
+	want := []gitprovider.MilestoneInfo{
+		{
+			Title:       "v1.0",
+			Description: gitprovider.StringVar(""),
+			DueDate:     gitprovider.MilestoneDueDate(time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)),
+			State:       gitprovider.MilestoneStateVar(gitprovider.MilestoneStateClosed),
+		},
+		{
+			Title:       "v2.0",
+			Description: gitprovider.StringVar(""),
+			State:       gitprovider.MilestoneStateVar(gitprovider.MilestoneStateOpen),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+}
+
+func TestMilestoneClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             gitprovider.MilestoneInfo
+		wantActionTaken bool
+		wantUpdate      map[string]interface{}
+	}{
+		{
+			name:            "no-op when unset fields differ",
+			req:             gitprovider.MilestoneInfo{Title: "v1.0"},
+			wantActionTaken: false,
+		},
+		{
+			name: "update when the description differs",
+			req: gitprovider.MilestoneInfo{
+				Title:       "v1.0",
+				Description: gitprovider.StringVar("First stable release"),
+			},
+			wantActionTaken: true,
+			wantUpdate: map[string]interface{}{
+				"title":       "v1.0",
+				"description": "First stable release",
+				"state":       "open",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestMilestoneClient(t)
+			mux.HandleFunc("/repos/fluxcd/repo/milestones", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `[{"number": 1, "title": "v1.0", "description": "TBD", "state": "open"}]`)
+			})
+			var update map[string]interface{}
+			mux.HandleFunc("/repos/fluxcd/repo/milestones/1", func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				fmt.Fprint(w, `{"number": 1, "title": "v1.0", "description": "First stable release", "state": "open"}`)
+			})
+
+			_, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(tt.wantUpdate, update); diff != "" {
+				t.Errorf("Reconcile() update (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
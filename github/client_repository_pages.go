@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PagesClient implements the gitprovider.PagesClient interface.
+var _ gitprovider.PagesClient = &PagesClient{}
+
+// PagesClient operates on the GitHub Pages site of a specific repository.
+type PagesClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the source and custom domain of the GitHub Pages site of the repository.
+//
+// ErrNotFound is returned if GitHub Pages isn't enabled for the repository.
+func (c *PagesClient) Get(ctx context.Context) (gitprovider.PagesInfo, error) {
+	// GET /repos/{owner}/{repo}/pages
+	apiObj, err := c.c.GetPages(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return gitprovider.PagesInfo{}, err
+	}
+	return pagesFromAPI(apiObj), nil
+}
+
+// Reconcile makes sure the GitHub Pages site of the repository is published from the source
+// branch and path in req, at the custom domain in req if set. GitHub Pages is enabled first if
+// needed.
+func (c *PagesClient) Reconcile(ctx context.Context, req gitprovider.PagesInfo) (bool, error) {
+	req.Default()
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+
+	enabled := false
+	actual, err := c.Get(ctx)
+	if errors.Is(err, gitprovider.ErrNotFound) {
+		// POST /repos/{owner}/{repo}/pages
+		if err := c.c.EnablePages(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Pages{
+			Source: pagesSourceToAPI(req),
+		}); err != nil {
+			return false, err
+		}
+		enabled = true
+		// The custom domain can only be set once the site is enabled, by the update below
+		actual = gitprovider.PagesInfo{SourceBranch: req.SourceBranch, SourcePath: req.SourcePath}
+	} else if err != nil {
+		return false, err
+	}
+
+	desired := actual
+	desired.SourceBranch = req.SourceBranch
+	desired.SourcePath = req.SourcePath
+	if req.CustomDomain != nil {
+		desired.CustomDomain = nil
+		if *req.CustomDomain != "" {
+			desired.CustomDomain = req.CustomDomain
+		}
+	}
+	if desired.Equals(actual) {
+		return enabled, nil
+	}
+
+	// PUT /repos/{owner}/{repo}/pages
+	// A nil CNAME removes the custom domain
+	return true, c.c.UpdatePages(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.PagesUpdate{
+		CNAME:  desired.CustomDomain,
+		Source: pagesSourceToAPI(desired),
+	})
+}
+
+func pagesFromAPI(apiObj *github.Pages) gitprovider.PagesInfo {
+	info := gitprovider.PagesInfo{
+		SourceBranch: apiObj.GetSource().GetBranch(),
+		SourcePath:   apiObj.GetSource().Path,
+		URL:          apiObj.HTMLURL,
+	}
+	if apiObj.GetCNAME() != "" {
+		info.CustomDomain = apiObj.CNAME
+	}
+	return info
+}
+
+func pagesSourceToAPI(info gitprovider.PagesInfo) *github.PagesSource {
+	return &github.PagesSource{
+		Branch: &info.SourceBranch,
+		Path:   info.SourcePath,
+	}
+}
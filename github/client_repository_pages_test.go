@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestPagesClient_Reconcile(t *testing.T) {
+	const actualPages = `{"html_url": "https://docs.fluxcd.io/", "cname": "docs.fluxcd.io", "source": {"branch": "gh-pages", "path": "/"}}`
+	tests := []struct {
+		name        string
+		req         gitprovider.PagesInfo
+		wantPayload map[string]interface{}
+	}{
+		{
+			name: "in sync",
+			req:  gitprovider.PagesInfo{SourceBranch: "gh-pages"},
+		},
+		{
+			name: "source branch changed",
+			req: gitprovider.PagesInfo{
+				SourceBranch: "main",
+				SourcePath:   gitprovider.StringVar("/docs"),
+			},
+			wantPayload: map[string]interface{}{
+				"cname":  "docs.fluxcd.io",
+				"source": map[string]interface{}{"branch": "main", "path": "/docs"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var gotPayload map[string]interface{}
+			mux.HandleFunc("/repos/fluxcd/repo/pages", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, actualPages)
+				case http.MethodPut:
+					if err := json.NewDecoder(r.Body).Decode(&gotPayload); err != nil {
+						t.Fatalf("failed to decode the request body: %v", err)
+					}
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected %s request", r.Method)
+				}
+			})
+
+			c := &PagesClient{
+				clientContext: client.clientContext,
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if want := tt.wantPayload != nil; actionTaken != want {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, want)
+			}
+			if diff := cmp.Diff(tt.wantPayload, gotPayload); diff != "" {
+				t.Errorf("Reconcile() payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
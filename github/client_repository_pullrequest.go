@@ -17,12 +17,27 @@ limitations under the License.
 package github
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-github/v66/github"
 )
 
+// mergeabilityPollInterval is the interval at which the mergeable state of a pull request is polled
+// while GitHub computes it.
+//
+//nolint:gochecknoglobals
+var mergeabilityPollInterval = 2 * time.Second
+
+// diffMediaType is the media type requesting the unified diff of a pull request.
+const diffMediaType = "application/vnd.github.diff"
+
 // PullRequestClient implements the gitprovider.PullRequestClient interface.
 var _ gitprovider.PullRequestClient = &PullRequestClient{}
 
@@ -33,7 +48,9 @@ type PullRequestClient struct {
 }
 
 // List lists all pull requests in the repository
-func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest, error) {
+// GitHub can't filter pull requests by label server-side, so they're filtered after listing.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestListOptions(opts...)
 	prs, _, err := c.c.Client().PullRequests.List(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), nil)
 	if err != nil {
 		return nil, err
@@ -45,17 +62,19 @@ func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest
 		requests[idx] = newPullRequest(c.clientContext, pr)
 	}
 
-	return requests, nil
+	return o.FilterByLabels(requests), nil
 }
 
 // Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestCreateOptions(opts...)
 
 	prOpts := &github.NewPullRequest{
 		Title: &title,
 		Head:  &branch,
 		Base:  &baseBranch,
 		Body:  &description,
+		Draft: &o.Draft,
 	}
 
 	pr, _, err := c.c.Client().PullRequests.Create(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), prOpts)
@@ -104,3 +123,284 @@ func (c *PullRequestClient) Merge(ctx context.Context, number int, mergeMethod g
 
 	return nil
 }
+
+// MergeabilityStatus returns whether the pull request can be merged, and why not.
+// GitHub computes the mergeable state in the background, so the pull request is polled until it's known.
+func (c *PullRequestClient) MergeabilityStatus(ctx context.Context, number int) (gitprovider.MergeableInfo, error) {
+	return gitprovider.PollMergeability(ctx, mergeabilityPollInterval, func(ctx context.Context) (gitprovider.MergeableInfo, error) {
+		pr, _, err := c.c.Client().PullRequests.Get(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+		if err != nil {
+			return gitprovider.MergeableInfo{}, handleHTTPError(err)
+		}
+		if pr.Mergeable == nil {
+			return gitprovider.MergeableInfo{}, nil
+		}
+
+		failingChecks, err := c.failingRequiredChecks(ctx, pr.GetBase().GetRef(), pr.GetHead().GetSHA())
+		if err != nil {
+			return gitprovider.MergeableInfo{}, err
+		}
+		return gitprovider.MergeableInfo{
+			Mergeable:     pr.Mergeable,
+			Conflicts:     pr.GetMergeableState() == "dirty",
+			FailingChecks: failingChecks,
+		}, nil
+	})
+}
+
+// PreviewRebase returns whether rebasing the pull request would conflict. GitHub computes the
+// rebaseable state along with the mergeable one, so the pull request is polled until the latter is
+// known. GitHub doesn't report the conflicting files, so the files changed both by the pull request
+// and on the base branch since the merge base are reported instead.
+func (c *PullRequestClient) PreviewRebase(ctx context.Context, number int) (gitprovider.RebasePreview, error) {
+	owner, repo := c.ref.GetIdentity(), c.ref.GetRepository()
+	var pr *github.PullRequest
+	_, err := gitprovider.PollMergeability(ctx, mergeabilityPollInterval, func(ctx context.Context) (gitprovider.MergeableInfo, error) {
+		apiObj, _, err := c.c.Client().PullRequests.Get(ctx, owner, repo, number)
+		if err != nil {
+			return gitprovider.MergeableInfo{}, handleHTTPError(err)
+		}
+		pr = apiObj
+		return gitprovider.MergeableInfo{Mergeable: apiObj.Mergeable}, nil
+	})
+	if err != nil {
+		return gitprovider.RebasePreview{}, err
+	}
+	if pr.GetRebaseable() {
+		return gitprovider.RebasePreview{}, nil
+	}
+
+	// GET /repos/{owner}/{repo}/compare/{base}...{head}
+	prChanges, err := c.c.CompareCommits(ctx, owner, repo, pr.GetBase().GetRef(), pr.GetHead().GetSHA())
+	if err != nil {
+		return gitprovider.RebasePreview{}, err
+	}
+	baseChanges, err := c.c.CompareCommits(ctx, owner, repo, prChanges.GetMergeBaseCommit().GetSHA(), pr.GetBase().GetRef())
+	if err != nil {
+		return gitprovider.RebasePreview{}, err
+	}
+	return gitprovider.RebasePreview{
+		Conflicts:        true,
+		ConflictingFiles: changedOnBothSides(prChanges.Files, baseChanges.Files),
+	}, nil
+}
+
+// changedOnBothSides returns the sorted paths of the files found in both lists of changed files.
+func changedOnBothSides(ours, theirs []*github.CommitFile) []string {
+	changed := map[string]struct{}{}
+	for _, f := range ours {
+		changed[f.GetFilename()] = struct{}{}
+	}
+	files := []string{}
+	for _, f := range theirs {
+		if _, ok := changed[f.GetFilename()]; ok {
+			files = append(files, f.GetFilename())
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Diff returns the changes of the pull request as a unified diff.
+// The diff is streamed into the returned buffer, as it may be large.
+func (c *PullRequestClient) Diff(ctx context.Context, number int) ([]byte, error) {
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}
+	req, err := c.c.Client().NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/pulls/%d", c.ref.GetIdentity(), c.ref.GetRepository(), number), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", diffMediaType)
+
+	var buf bytes.Buffer
+	if _, err := c.c.Client().Do(ctx, req, &buf); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ListCommits lists the commits of the pull request.
+// GitHub lists at most 250 commits for a pull request.
+func (c *PullRequestClient) ListCommits(ctx context.Context, number int) ([]gitprovider.Commit, error) {
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}/commits
+	apiObjs, err := c.c.ListPullRequestCommits(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return nil, err
+	}
+
+	commitClient := &CommitClient{clientContext: c.clientContext, ref: c.ref}
+	commits := make([]gitprovider.Commit, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		commits = append(commits, newCommit(commitClient, apiObj))
+	}
+	return commits, nil
+}
+
+// ReviewSummary returns the current verdicts of the reviewers of the pull request.
+// GitHub lists the reviews from the oldest to the newest. Pending reviews aren't submitted yet,
+// hence ignored.
+func (c *PullRequestClient) ReviewSummary(ctx context.Context, number int) (gitprovider.ReviewSummary, error) {
+	// GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+	apiObjs, err := c.c.ListPullRequestReviews(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return gitprovider.ReviewSummary{}, err
+	}
+
+	reviews := make([]gitprovider.ReviewInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		state, ok := reviewStates[apiObj.GetState()]
+		if !ok {
+			continue
+		}
+		reviews = append(reviews, gitprovider.ReviewInfo{
+			Reviewer: apiObj.GetUser().GetLogin(),
+			State:    state,
+		})
+	}
+	return gitprovider.SummarizeReviews(reviews), nil
+}
+
+// CreateComment posts a comment on the conversation of the pull request. GitHub handles the
+// conversation of a pull request like the one of an issue.
+func (c *PullRequestClient) CreateComment(ctx context.Context, number int, body string) (gitprovider.PullRequestComment, error) {
+	// POST /repos/{owner}/{repo}/issues/{issue_number}/comments
+	apiObj, err := c.c.CreateIssueComment(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, body)
+	if err != nil {
+		return gitprovider.PullRequestComment{}, err
+	}
+	return pullRequestCommentFromAPI(apiObj), nil
+}
+
+// ListComments lists the comments on the conversation of the pull request.
+func (c *PullRequestClient) ListComments(ctx context.Context, number int) ([]gitprovider.PullRequestComment, error) {
+	// GET /repos/{owner}/{repo}/issues/{issue_number}/comments
+	apiObjs, err := c.c.ListIssueComments(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]gitprovider.PullRequestComment, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		comments = append(comments, pullRequestCommentFromAPI(apiObj))
+	}
+	return comments, nil
+}
+
+// AddLabels adds labels to the pull request. GitHub creates the labels which don't exist yet.
+func (c *PullRequestClient) AddLabels(ctx context.Context, number int, labels []string) error {
+	return c.c.AddLabelsToIssue(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, labels)
+}
+
+// ListLabels lists the names of the labels of the pull request.
+func (c *PullRequestClient) ListLabels(ctx context.Context, number int) ([]string, error) {
+	apiObjs, err := c.c.ListLabelsByIssue(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number)
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]string, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		labels = append(labels, apiObj.GetName())
+	}
+	return labels, nil
+}
+
+func pullRequestCommentFromAPI(apiObj *github.IssueComment) gitprovider.PullRequestComment {
+	return gitprovider.PullRequestComment{
+		ID:        apiObj.GetID(),
+		Author:    apiObj.GetUser().GetLogin(),
+		Body:      apiObj.GetBody(),
+		CreatedAt: apiObj.GetCreatedAt().Time,
+	}
+}
+
+// Review submits a review of the pull request. The review is submitted right away, rather than
+// left pending.
+func (c *PullRequestClient) Review(ctx context.Context, number int, action gitprovider.ReviewAction, body string) (gitprovider.PullRequestReview, error) {
+	if err := gitprovider.ValidateReview(action, body); err != nil {
+		return nil, err
+	}
+
+	req := &github.PullRequestReviewRequest{Event: github.String(reviewEvents[action])}
+	if body != "" {
+		req.Body = &body
+	}
+	// POST /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+	apiObj, err := c.c.CreatePullRequestReview(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), number, req)
+	if err != nil {
+		return nil, err
+	}
+	return newPullRequestReview(apiObj), nil
+}
+
+// reviewEvents maps the provider-neutral review actions to the events submitting GitHub reviews.
+//
+//nolint:gochecknoglobals
+var reviewEvents = map[gitprovider.ReviewAction]string{
+	gitprovider.ReviewApprove:        "APPROVE",
+	gitprovider.ReviewRequestChanges: "REQUEST_CHANGES",
+	gitprovider.ReviewComment:        "COMMENT",
+}
+
+// reviewStates maps the states of the submitted GitHub reviews to the provider-neutral ones.
+//
+//nolint:gochecknoglobals
+var reviewStates = map[string]gitprovider.ReviewState{
+	"APPROVED":          gitprovider.ReviewStateApproved,
+	"CHANGES_REQUESTED": gitprovider.ReviewStateChangesRequested,
+	"COMMENTED":         gitprovider.ReviewStateCommented,
+	"DISMISSED":         gitprovider.ReviewStateDismissed,
+}
+
+// failingRequiredChecks returns the names of the status checks required by the protection of the
+// base branch, which failed for the given commit.
+func (c *PullRequestClient) failingRequiredChecks(ctx context.Context, base, sha string) ([]string, error) {
+	owner, repo := c.ref.GetIdentity(), c.ref.GetRepository()
+	required, err := (&BranchClient{clientContext: c.clientContext, ref: c.ref}).RequiredChecks(ctx, base)
+	if err != nil {
+		// The base branch isn't protected, or doesn't require status checks
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	requiredNames := map[string]struct{}{}
+	for _, name := range required {
+		requiredNames[name] = struct{}{}
+	}
+	if len(requiredNames) == 0 {
+		return nil, nil
+	}
+
+	failing := []string{}
+	addFailing := func(name string) {
+		if _, ok := requiredNames[name]; ok {
+			failing = append(failing, name)
+			// Only report each check once
+			delete(requiredNames, name)
+		}
+	}
+
+	// Checks are reported either as commit statuses or as check runs
+	combined, _, err := c.c.Client().Repositories.GetCombinedStatus(ctx, owner, repo, sha, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	for _, status := range combined.Statuses {
+		switch status.GetState() {
+		case "failure", "error":
+			addFailing(status.GetContext())
+		}
+	}
+	checkRuns, _, err := c.c.Client().Checks.ListCheckRunsForRef(ctx, owner, repo, sha, &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	for _, run := range checkRuns.CheckRuns {
+		switch run.GetConclusion() {
+		case "failure", "cancelled", "timed_out", "action_required", "startup_failure":
+			addFailing(run.GetName())
+		}
+	}
+	return failing, nil
+}
@@ -0,0 +1,482 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestPullRequestClient_MergeabilityStatus(t *testing.T) {
+	mux, client := setup(t)
+	c := &PullRequestClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	oldInterval := mergeabilityPollInterval
+	mergeabilityPollInterval = time.Millisecond
+	t.Cleanup(func() { mergeabilityPollInterval = oldInterval })
+
+	// GitHub reports an unknown mergeable state until it computed it in the background
+	gets := 0
+	mux.HandleFunc("/repos/fluxcd/repo/pulls/1", func(w http.ResponseWriter, _ *http.Request) {
+		gets++
+		if gets < 3 {
+			fmt.Fprint(w, `{"number": 1, "mergeable": null, "mergeable_state": "unknown", "base": {"ref": "main"}, "head": {"sha": "abc"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"number": 1, "mergeable": false, "mergeable_state": "dirty", "base": {"ref": "main"}, "head": {"sha": "abc"}}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/branches/main/protection/required_status_checks", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"strict": true, "checks": [{"context": "build"}, {"context": "ci/lint"}, {"context": "test"}]}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/commits/abc/status", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"state": "failure", "statuses": [{"context": "ci/lint", "state": "error"}, {"context": "other", "state": "failure"}]}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/commits/abc/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"total_count": 2, "check_runs": [{"name": "build", "status": "completed", "conclusion": "failure"}, {"name": "test", "status": "completed", "conclusion": "success"}]}`)
+	})
+
+	got, err := c.MergeabilityStatus(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("MergeabilityStatus() error = %v", err)
+	}
+	if gets != 3 {
+		t.Errorf("MergeabilityStatus() got the pull request %d times, want 3", gets)
+	}
+	want := gitprovider.MergeableInfo{
+		Mergeable:     gitprovider.BoolVar(false),
+		Conflicts:     true,
+		FailingChecks: []string{"ci/lint", "build"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MergeabilityStatus() (-want +got):\n%s", diff)
+	}
+}
+
+func TestPullRequestClient_List_Labels(t *testing.T) {
+	mux, client := setup(t)
+	c := &PullRequestClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	// GitHub can't filter by label, so all pull requests are listed and filtered afterwards
+	mux.HandleFunc("/repos/fluxcd/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("labels"); got != "" {
+			t.Errorf("labels query = %q, want none", got)
+		}
+		fmt.Fprint(w, `[
+			{"number": 1, "labels": [{"name": "bug"}]},
+			{"number": 2, "labels": [{"name": "bug"}, {"name": "help"}]},
+			{"number": 3}
+		]`)
+	})
+
+	prs, err := c.List(context.Background(), &gitprovider.PullRequestListOptions{Labels: []string{"help", "bug"}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	got := []int{}
+	for _, pr := range prs {
+		got = append(got, pr.Get().Number)
+	}
+	if diff := cmp.Diff([]int{2}, got); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+
+	prs, err = c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(prs) != 3 {
+		t.Errorf("List() without labels returned %d pull requests, want 3", len(prs))
+	}
+}
+
+func TestPullRequestClient_Create_Draft(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []gitprovider.PullRequestCreateOption
+		wantDraft bool
+	}{
+		{
+			name: "ready for review",
+		},
+		{
+			name:      "draft",
+			opts:      []gitprovider.PullRequestCreateOption{&gitprovider.PullRequestCreateOptions{Draft: true}},
+			wantDraft: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			c := &PullRequestClient{
+				clientContext: client.clientContext,
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			mux.HandleFunc("/repos/fluxcd/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+				var payload github.NewPullRequest
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				if payload.GetDraft() != tt.wantDraft {
+					t.Errorf("draft = %v, want %v", payload.GetDraft(), tt.wantDraft)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"number": 1, "title": %q, "draft": %v}`, payload.GetTitle(), payload.GetDraft())
+			})
+
+			pr, err := c.Create(context.Background(), "Update manifests", "feature", "main", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if got := pr.Get().Title; got != "Update manifests" {
+				t.Errorf("Create() title = %q, want %q", got, "Update manifests")
+			}
+		})
+	}
+}
+
+func TestPullRequestClient_Labels(t *testing.T) {
+	mux, client := setup(t)
+	c := &PullRequestClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	mux.HandleFunc("/repos/fluxcd/repo/issues/1/labels", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var payload []string
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			if diff := cmp.Diff([]string{"automated"}, payload); diff != "" {
+				t.Errorf("labels (-want +got):\n%s", diff)
+			}
+			fmt.Fprint(w, `[{"name": "flux"}, {"name": "automated"}]`)
+		case http.MethodGet:
+			fmt.Fprint(w, `[{"name": "flux"}, {"name": "automated"}]`)
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	})
+
+	if err := c.AddLabels(context.Background(), 1, []string{"automated"}); err != nil {
+		t.Fatalf("AddLabels() error = %v", err)
+	}
+	got, err := c.ListLabels(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListLabels() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"flux", "automated"}, got); diff != "" {
+		t.Errorf("ListLabels() (-want +got):\n%s", diff)
+	}
+}
+
+func TestPullRequestClient_Diff(t *testing.T) {
+	mux, client := setup(t)
+	c := &PullRequestClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	const patch = "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1 @@\n-# Old\n+# New\n"
+	mux.HandleFunc("/repos/fluxcd/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/vnd.github.diff" {
+			t.Errorf("Accept header = %q, want the diff media type", got)
+		}
+		fmt.Fprint(w, patch)
+	})
+
+	got, err := c.Diff(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if diff := cmp.Diff(patch, string(got)); diff != "" {
+		t.Errorf("Diff() (-want +got):\n%s", diff)
+	}
+}
+
+func TestPullRequestClient_ListCommits(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     []gitprovider.CommitInfo
+	}{
+		{
+			name: "commits",
+			response: `[
+				{"sha": "abc", "html_url": "https://github.com/fluxcd/repo/commit/abc", "commit": {"message": "first", "tree": {"sha": "t1"}, "author": {"name": "alice", "date": "2024-01-01T00:00:00Z"}}},
+				{"sha": "def", "html_url": "https://github.com/fluxcd/repo/commit/def", "commit": {"message": "second", "tree": {"sha": "t2"}, "author": {"name": "bob", "date": "2024-01-02T00:00:00Z"}}}
+			]`,
+			want: []gitprovider.CommitInfo{
+				{Sha: "abc", TreeSha: "t1", Author: "alice", Message: "first", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), URL: "https://github.com/fluxcd/repo/commit/abc"},
+				{Sha: "def", TreeSha: "t2", Author: "bob", Message: "second", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), URL: "https://github.com/fluxcd/repo/commit/def"},
+			},
+		},
+		{
+			name:     "no commits",
+			response: `[]`,
+			want:     []gitprovider.CommitInfo{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			c := &PullRequestClient{
+				clientContext: client.clientContext,
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			mux.HandleFunc("/repos/fluxcd/repo/pulls/1/commits", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.response)
+			})
+
+			commits, err := c.ListCommits(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("ListCommits() error = %v", err)
+			}
+			got := make([]gitprovider.CommitInfo, 0, len(commits))
+			for _, commit := range commits {
+				got = append(got, commit.Get())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ListCommits() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPullRequestClient_PreviewRebase(t *testing.T) {
+	tests := []struct {
+		name       string
+		rebaseable bool
+		want       gitprovider.RebasePreview
+	}{
+		{
+			name:       "no conflict",
+			rebaseable: true,
+			want:       gitprovider.RebasePreview{},
+		},
+		{
+			name:       "conflict",
+			rebaseable: false,
+			want: gitprovider.RebasePreview{
+				Conflicts:        true,
+				ConflictingFiles: []string{"README.md", "go.mod"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			c := &PullRequestClient{
+				clientContext: client.clientContext,
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			mux.HandleFunc("/repos/fluxcd/repo/pulls/1", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprintf(w, `{"number": 1, "mergeable": %t, "rebaseable": %t, "base": {"ref": "main"}, "head": {"sha": "abc"}}`, tt.rebaseable, tt.rebaseable)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/compare/main...abc", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"merge_base_commit": {"sha": "def"}, "files": [{"filename": "go.mod"}, {"filename": "main.go"}, {"filename": "README.md"}]}`)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/compare/def...main", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"merge_base_commit": {"sha": "def"}, "files": [{"filename": "README.md"}, {"filename": "go.mod"}, {"filename": "go.sum"}]}`)
+			})
+
+			got, err := c.PreviewRebase(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("PreviewRebase() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("PreviewRebase() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPullRequestClient_ReviewSummary(t *testing.T) {
+	mux, client := setup(t)
+	c := &PullRequestClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	mux.HandleFunc("/repos/fluxcd/repo/pulls/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", `<https://api.github.com/repos/fluxcd/repo/pulls/1/reviews?page=2>; rel="next"`)
+			fmt.Fprint(w, `[
+				{"user": {"login": "stefan"}, "state": "CHANGES_REQUESTED"},
+				{"user": {"login": "hidde"}, "state": "APPROVED"},
+				{"user": {"login": "max"}, "state": "COMMENTED"}
+			]`)
+			return
+		}
+		fmt.Fprint(w, `[
+			{"user": {"login": "stefan"}, "state": "APPROVED"},
+			{"user": {"login": "hidde"}, "state": "COMMENTED"},
+			{"user": {"login": "sanskar"}, "state": "CHANGES_REQUESTED"},
+			{"user": {"login": "somtochi"}, "state": "APPROVED"},
+			{"user": {"login": "somtochi"}, "state": "DISMISSED"},
+			{"user": {"login": "max"}, "state": "PENDING"}
+		]`)
+	})
+
+	got, err := c.ReviewSummary(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ReviewSummary() error = %v", err)
+	}
+	want := gitprovider.ReviewSummary{
+		Approvals:        2,
+		ChangesRequested: 1,
+		Approvers:        []string{"hidde", "stefan"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReviewSummary() (-want +got):\n%s", diff)
+	}
+}
+
+func TestPullRequestClient_Comments(t *testing.T) {
+	mux, client := setup(t)
+	c := &PullRequestClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	mux.HandleFunc("/repos/fluxcd/repo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var payload map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			if payload["body"] != "Reconciliation succeeded" {
+				t.Errorf("comment body = %v, want %q", payload["body"], "Reconciliation succeeded")
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 2, "user": {"login": "flux-bot"}, "body": "Reconciliation succeeded", "created_at": "2023-06-02T10:00:00Z"}`)
+		case http.MethodGet:
+			fmt.Fprint(w, `[
+				{"id": 1, "user": {"login": "stefan"}, "body": "Please reconcile", "created_at": "2023-06-01T10:00:00Z"},
+				{"id": 2, "user": {"login": "flux-bot"}, "body": "Reconciliation succeeded", "created_at": "2023-06-02T10:00:00Z"}
+			]`)
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	})
+
+	created, err := c.CreateComment(context.Background(), 1, "Reconciliation succeeded")
+	if err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+	want := gitprovider.PullRequestComment{
+		ID:        2,
+		Author:    "flux-bot",
+		Body:      "Reconciliation succeeded",
+		CreatedAt: time.Date(2023, 6, 2, 10, 0, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(want, created); diff != "" {
+		t.Errorf("CreateComment() (-want +got):\n%s", diff)
+	}
+
+	got, err := c.ListComments(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListComments() error = %v", err)
+	}
+	wantList := []gitprovider.PullRequestComment{
+		{ID: 1, Author: "stefan", Body: "Please reconcile", CreatedAt: time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)},
+		want,
+	}
+	if diff := cmp.Diff(wantList, got); diff != "" {
+		t.Errorf("ListComments() (-want +got):\n%s", diff)
+	}
+}
+
+func TestPullRequestClient_Review(t *testing.T) {
+	mux, client := setup(t)
+	c := &PullRequestClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	var gotPayload map[string]interface{}
+	mux.HandleFunc("/repos/fluxcd/repo/pulls/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotPayload); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		fmt.Fprint(w, `{"id": 80, "user": {"login": "flux-bot"}, "state": "CHANGES_REQUESTED", "body": "Please add a test"}`)
+	})
+
+	review, err := c.Review(context.Background(), 1, gitprovider.ReviewRequestChanges, "Please add a test")
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	wantPayload := map[string]interface{}{"event": "REQUEST_CHANGES", "body": "Please add a test"}
+	if diff := cmp.Diff(wantPayload, gotPayload); diff != "" {
+		t.Errorf("review payload (-want +got):\n%s", diff)
+	}
+	want := gitprovider.ReviewInfo{Reviewer: "flux-bot", State: gitprovider.ReviewStateChangesRequested}
+	if diff := cmp.Diff(want, review.Get()); diff != "" {
+		t.Errorf("Review() (-want +got):\n%s", diff)
+	}
+	if apiObj, ok := review.APIObject().(*github.PullRequestReview); !ok || apiObj.GetID() != 80 {
+		t.Errorf("APIObject() = %v, want the created review", review.APIObject())
+	}
+
+	if _, err := c.Review(context.Background(), 1, gitprovider.ReviewComment, ""); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Review() without body error = %v, want ErrInvalidArgument", err)
+	}
+}
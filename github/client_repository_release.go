@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient operates on the releases of a specific repository.
+type ReleaseClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all the releases of the repository, newest first.
+func (c *ReleaseClient) List(ctx context.Context) ([]gitprovider.ReleaseInfo, error) {
+	apiObjs, err := c.c.ListReleases(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]gitprovider.ReleaseInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		releases = append(releases, releaseFromAPI(apiObj))
+	}
+	return releases, nil
+}
+
+// Get returns the release of the given tag.
+func (c *ReleaseClient) Get(ctx context.Context, tagName string) (gitprovider.ReleaseInfo, error) {
+	apiObj, err := c.get(ctx, tagName)
+	if err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+	return releaseFromAPI(apiObj), nil
+}
+
+// Create creates a release with the given specifications, creating its tag first if needed.
+// GitHub would otherwise tag the default branch when publishing the release.
+func (c *ReleaseClient) Create(ctx context.Context, req gitprovider.ReleaseInfo) (gitprovider.ReleaseInfo, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+	tags := &TagClient{clientContext: c.clientContext, ref: c.ref}
+	commits := &CommitClient{clientContext: c.clientContext, ref: c.ref}
+	if err := gitprovider.EnsureReleaseTag(ctx, tags, commits, req); err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+
+	apiObj, err := c.c.CreateRelease(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), releaseToAPI(&req))
+	if err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+	return releaseFromAPI(apiObj), nil
+}
+
+// Publish creates a commit (or resolves an existing ref), an annotated tag pointing to it, and
+// the release of that tag. Rolling back the tag requires destructive API calls to be enabled.
+func (c *ReleaseClient) Publish(ctx context.Context, opts gitprovider.PublishOptions) (gitprovider.PublishResult, error) {
+	return gitprovider.PublishRelease(ctx, &releasePublisher{c}, opts, c.destructiveActions)
+}
+
+// Edit updates the release of req.TagName to match req.
+func (c *ReleaseClient) Edit(ctx context.Context, req gitprovider.ReleaseInfo) (gitprovider.ReleaseInfo, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+	actual, err := c.get(ctx, req.TagName)
+	if err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+
+	// The target commitish only matters when the tag is created
+	release := releaseToAPI(&req)
+	release.TargetCommitish = nil
+	apiObj, err := c.c.EditRelease(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), actual.GetID(), release)
+	if err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+	return releaseFromAPI(apiObj), nil
+}
+
+// Delete deletes the release of the given tag.
+func (c *ReleaseClient) Delete(ctx context.Context, tagName string) error {
+	apiObj, err := c.get(ctx, tagName)
+	if err != nil {
+		return err
+	}
+	return c.c.DeleteRelease(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), apiObj.GetID())
+}
+
+// get returns the release of the given tag. Draft releases aren't found by tag, so the
+// releases are listed to look for a draft if no published release is found.
+func (c *ReleaseClient) get(ctx context.Context, tagName string) (*github.RepositoryRelease, error) {
+	apiObj, err := c.c.GetReleaseByTag(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), tagName)
+	if !errors.Is(err, gitprovider.ErrNotFound) {
+		return apiObj, err
+	}
+
+	apiObjs, err := c.c.ListReleases(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		if apiObj.GetDraft() && apiObj.GetTagName() == tagName {
+			return apiObj, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// releasePublisher implements gitprovider.ReleasePublisher.
+var _ gitprovider.ReleasePublisher = &releasePublisher{}
+
+// releasePublisher publishes the releases of the repository of a ReleaseClient.
+type releasePublisher struct {
+	c *ReleaseClient
+}
+
+func (p *releasePublisher) Commits() gitprovider.CommitClient {
+	return &CommitClient{clientContext: p.c.clientContext, ref: p.c.ref}
+}
+
+func (p *releasePublisher) CreateTag(ctx context.Context, name, sha, message string) error {
+	tags := &TagClient{clientContext: p.c.clientContext, ref: p.c.ref}
+	_, err := tags.Create(ctx, name, sha, message)
+	return err
+}
+
+func (p *releasePublisher) DeleteTag(ctx context.Context, name string) error {
+	// DELETE /repos/{owner}/{repo}/git/refs/tags/{name}
+	return p.c.c.DeleteTagRef(ctx, p.c.ref.GetIdentity(), p.c.ref.GetRepository(), name)
+}
+
+func (p *releasePublisher) CreateRelease(ctx context.Context, req gitprovider.ReleaseInfo) error {
+	// POST /repos/{owner}/{repo}/releases
+	_, err := p.c.c.CreateRelease(ctx, p.c.ref.GetIdentity(), p.c.ref.GetRepository(), releaseToAPI(&req))
+	return err
+}
+
+func releaseFromAPI(apiObj *github.RepositoryRelease) gitprovider.ReleaseInfo {
+	return gitprovider.ReleaseInfo{
+		TagName:         apiObj.GetTagName(),
+		Name:            apiObj.GetName(),
+		Body:            apiObj.GetBody(),
+		Draft:           apiObj.GetDraft(),
+		Prerelease:      apiObj.GetPrerelease(),
+		TargetCommitish: apiObj.GetTargetCommitish(),
+	}
+}
+
+func releaseToAPI(req *gitprovider.ReleaseInfo) *github.RepositoryRelease {
+	apiObj := &github.RepositoryRelease{
+		TagName:    &req.TagName,
+		Name:       &req.Name,
+		Body:       &req.Body,
+		Draft:      &req.Draft,
+		Prerelease: &req.Prerelease,
+	}
+	if req.TargetCommitish != "" {
+		apiObj.TargetCommitish = &req.TargetCommitish
+	}
+	return apiObj
+}
+
+func validateReleaseAPI(apiObj *github.RepositoryRelease) error {
+	return validateAPIObject("GitHub.RepositoryRelease", func(validator validation.Validator) {
+		// Make sure the ID and tag name are populated, as per
+		// https://docs.github.com/en/rest/releases/releases#get-a-release
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.TagName == nil {
+			validator.Required("TagName")
+		}
+	})
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const releaseTestSHA = "8b5e6a6ab5c2b5e2e0a7cd6b9b1fd7a7c1e4a2d0"
+
+func TestReleaseClient_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            gitprovider.ReleaseInfo
+		tagExists      bool
+		releaseExists  bool
+		wantTagCreated bool
+		wantErr        error
+	}{
+		{
+			name:      "existing tag",
+			req:       gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", Body: "First release"},
+			tagExists: true,
+		},
+		{
+			name:           "missing tag created from the target commitish",
+			req:            gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", TargetCommitish: "main"},
+			wantTagCreated: true,
+		},
+		{
+			name:    "missing tag without target commitish",
+			req:     gitprovider.ReleaseInfo{TagName: "v1.0.0"},
+			wantErr: gitprovider.ErrNotFound,
+		},
+		{
+			name:          "release already exists",
+			req:           gitprovider.ReleaseInfo{TagName: "v1.0.0"},
+			tagExists:     true,
+			releaseExists: true,
+			wantErr:       gitprovider.ErrAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			tagCreated := false
+			mux.HandleFunc("/repos/fluxcd/repo/git/ref/tags/v1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+				if !tt.tagExists && !tagCreated {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message": "Not Found"}`)
+					return
+				}
+				fmt.Fprintf(w, `{"ref": "refs/tags/v1.0.0", "object": {"type": "commit", "sha": %q}}`, releaseTestSHA)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/commits/"+releaseTestSHA, func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprintf(w, `{"sha": %q, "committer": {"date": "2026-01-01T00:00:00Z"}}`, releaseTestSHA)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/commits/main", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, releaseTestSHA)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+				req := map[string]string{}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatal(err)
+				}
+				if req["ref"] != "refs/tags/v1.0.0" || req["sha"] != releaseTestSHA {
+					t.Errorf("unexpected ref request %v", req)
+				}
+				tagCreated = true
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"ref": "refs/tags/v1.0.0", "object": {"type": "commit", "sha": %q}}`, releaseTestSHA)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+				if tt.releaseExists {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					fmt.Fprint(w, `{"message": "Validation Failed", "errors": [{"resource": "Release", "code": "already_exists", "field": "tag_name"}]}`)
+					return
+				}
+				req := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatal(err)
+				}
+				req["id"] = 1
+				w.WriteHeader(http.StatusCreated)
+				if err := json.NewEncoder(w).Encode(req); err != nil {
+					t.Fatal(err)
+				}
+			})
+
+			c := &ReleaseClient{clientContext: client.clientContext, ref: newTestTagClient(client).ref}
+			got, err := c.Create(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if tagCreated != tt.wantTagCreated {
+				t.Errorf("Create() created the tag: %v, want %v", tagCreated, tt.wantTagCreated)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.req, got); diff != "" {
+				t.Errorf("Create() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReleaseClient_Edit(t *testing.T) {
+	mux, client := setup(t)
+	// Draft releases can't be found by tag
+	mux.HandleFunc("/repos/fluxcd/repo/releases/tags/v1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/releases", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "tag_name": "v0.1.0"}, {"id": 2, "tag_name": "v1.0.0", "draft": true}]`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/releases/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		req := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		req["id"] = 2
+		if err := json.NewEncoder(w).Encode(req); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	c := &ReleaseClient{clientContext: client.clientContext, ref: newTestTagClient(client).ref}
+	req := gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", Body: "First release"}
+	got, err := c.Edit(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Edit() error = %v", err)
+	}
+	if diff := cmp.Diff(req, got); diff != "" {
+		t.Errorf("Edit() (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.Edit(context.Background(), gitprovider.ReleaseInfo{TagName: "v2.0.0"}); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Edit() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestReleaseClient_Publish(t *testing.T) {
+	release := gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", Body: "First release"}
+	tests := []struct {
+		name               string
+		opts               gitprovider.PublishOptions
+		destructiveActions bool
+		releaseFails       bool
+		wantResult         gitprovider.PublishResult
+		wantErr            error
+		wantStep           gitprovider.PublishStep
+		wantTagDeleted     bool
+	}{
+		{
+			name:       "tag and release",
+			opts:       gitprovider.PublishOptions{Ref: "main", Release: release},
+			wantResult: gitprovider.PublishResult{CommitSHA: releaseTestSHA, TagCreated: true, ReleaseCreated: true},
+		},
+		{
+			name:               "release fails, tag is rolled back",
+			opts:               gitprovider.PublishOptions{Ref: "main", Release: release, Rollback: true},
+			destructiveActions: true,
+			releaseFails:       true,
+			wantResult:         gitprovider.PublishResult{CommitSHA: releaseTestSHA},
+			wantStep:           gitprovider.PublishStepRelease,
+			wantTagDeleted:     true,
+		},
+		{
+			name:    "rollback without destructive actions",
+			opts:    gitprovider.PublishOptions{Ref: "main", Release: release, Rollback: true},
+			wantErr: gitprovider.ErrDestructiveCallDisallowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			client.destructiveActions = tt.destructiveActions
+			mux.HandleFunc("/repos/fluxcd/repo/commits/main", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, releaseTestSHA)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/tags", func(w http.ResponseWriter, r *http.Request) {
+				req := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatal(err)
+				}
+				if req["tag"] != "v1.0.0" || req["message"] != "v1.0.0" {
+					t.Errorf("unexpected tag request %v", req)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"sha": "4c1e", "tag": "v1.0.0", "message": "v1.0.0"}`)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/refs", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"ref": "refs/tags/v1.0.0", "object": {"type": "tag", "sha": "4c1e"}}`)
+			})
+			tagDeleted := false
+			mux.HandleFunc("/repos/fluxcd/repo/git/refs/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				tagDeleted = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/releases", func(w http.ResponseWriter, _ *http.Request) {
+				if tt.releaseFails {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprint(w, `{"message": "Server Error"}`)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id": 1, "tag_name": "v1.0.0"}`)
+			})
+
+			c := &ReleaseClient{clientContext: client.clientContext, ref: newTestTagClient(client).ref}
+			got, err := c.Publish(context.Background(), tt.opts)
+			var publishErr *gitprovider.PublishError
+			if tt.wantStep != "" {
+				if !errors.As(err, &publishErr) || publishErr.Step != tt.wantStep {
+					t.Fatalf("Publish() error = %v, want a failure at step %s", err, tt.wantStep)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Publish() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.wantResult {
+				t.Errorf("Publish() = %+v, want %+v", got, tt.wantResult)
+			}
+			if tagDeleted != tt.wantTagDeleted {
+				t.Errorf("Publish() deleted the tag: %v, want %v", tagDeleted, tt.wantTagDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific repository.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all the tags of the repository.
+// The tag refs only tell the type and SHA of the object they point to, so the tag object of
+// annotated tags, or the commit of lightweight tags, is fetched for each tag to get its date.
+func (c *TagClient) List(ctx context.Context) ([]gitprovider.TagInfo, error) {
+	refs, err := c.c.ListTagRefs(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]gitprovider.TagInfo, 0, len(refs))
+	for _, ref := range refs {
+		tags = append(tags, tagFromRef(ref))
+	}
+
+	if err := gitprovider.ResolveTags(ctx, tags, c.resolveTag); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Get returns the tag with the given name.
+func (c *TagClient) Get(ctx context.Context, name string) (gitprovider.TagInfo, error) {
+	ref, err := c.c.GetTagRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), name)
+	if err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	tag := tagFromRef(ref)
+	if err := c.resolveTag(ctx, &tag); err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	return tag, nil
+}
+
+// Create creates a tag pointing to commitSHA. The tag object of an annotated tag is created
+// first, and the tag ref then points to it.
+func (c *TagClient) Create(ctx context.Context, name, commitSHA, message string) (gitprovider.TagInfo, error) {
+	commitSHA, err := gitprovider.ExpandShortSHA(ctx, &CommitClient{clientContext: c.clientContext, ref: c.ref}, commitSHA)
+	if err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+
+	if message == "" {
+		ref, err := c.c.CreateTagRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), name, commitSHA)
+		if err != nil {
+			return gitprovider.TagInfo{}, err
+		}
+		tag := tagFromRef(ref)
+		if err := c.resolveTag(ctx, &tag); err != nil {
+			return gitprovider.TagInfo{}, err
+		}
+		return tag, nil
+	}
+
+	apiObj, err := c.c.CreateTag(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), &github.Tag{
+		Tag:     &name,
+		Message: &message,
+		Object: &github.GitObject{
+			Type: github.String("commit"),
+			SHA:  &commitSHA,
+		},
+	})
+	if err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	if _, err := c.c.CreateTagRef(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), name, apiObj.GetSHA()); err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	return gitprovider.TagInfo{
+		Name:      name,
+		SHA:       commitSHA,
+		Annotated: true,
+		Message:   apiObj.GetMessage(),
+		Tagger:    apiObj.GetTagger().GetName(),
+		CreatedAt: apiObj.GetTagger().GetDate().Time,
+	}, nil
+}
+
+// tagFromRef converts a tag ref. The SHA of an annotated tag is the one of its tag object
+// until the tag is resolved.
+func tagFromRef(ref *github.Reference) gitprovider.TagInfo {
+	return gitprovider.TagInfo{
+		Name:      strings.TrimPrefix(ref.GetRef(), "refs/tags/"),
+		SHA:       ref.GetObject().GetSHA(),
+		Annotated: ref.GetObject().GetType() == "tag",
+	}
+}
+
+// resolveTag fills in the commit, message, tagger and date of the given tag, from the tag object
+// of annotated tags or the commit of lightweight tags.
+func (c *TagClient) resolveTag(ctx context.Context, tag *gitprovider.TagInfo) error {
+	if !tag.Annotated {
+		commit, err := c.c.GetGitCommit(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), tag.SHA)
+		if err != nil {
+			return err
+		}
+		tag.CreatedAt = commit.GetCommitter().GetDate().Time
+		return nil
+	}
+	// The ref of an annotated tag points to the tag object, which points to the commit
+	apiObj, err := c.c.GetTag(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), tag.SHA)
+	if err != nil {
+		return err
+	}
+	tag.SHA = apiObj.GetObject().GetSHA()
+	tag.Message = apiObj.GetMessage()
+	tag.Tagger = apiObj.GetTagger().GetName()
+	tag.CreatedAt = apiObj.GetTagger().GetDate().Time
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestTagClient_List(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/git/matching-refs/tags", func(w http.ResponseWriter, r *http.Request) {
+		// Serve each tag on its own page to exercise pagination
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", `<https://api.github.com/repos/fluxcd/repo/git/matching-refs/tags?page=2>; rel="next"`)
+			fmt.Fprint(w, `[{"ref": "refs/tags/v1.0.0", "object": {"type": "commit", "sha": "c1"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"ref": "refs/tags/v2.0.0", "object": {"type": "tag", "sha": "t2"}}]`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/git/commits/c1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"sha": "c1", "committer": {"name": "Committer", "date": "2026-01-01T00:00:00Z"}}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/git/tags/t2", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"sha": "t2", "tag": "v2.0.0", "message": "Release v2.0.0", "tagger": {"name": "Tagger", "date": "2026-02-01T00:00:00Z"}, "object": {"type": "commit", "sha": "c2"}}`)
+	})
+
+	c := &TagClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	got, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []gitprovider.TagInfo{
+		{
+			Name:      "v1.0.0",
+			SHA:       "c1",
+			CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:      "v2.0.0",
+			SHA:       "c2",
+			Annotated: true,
+			Message:   "Release v2.0.0",
+			Tagger:    "Tagger",
+			CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+}
+
+func TestTagClient_Get(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/git/ref/tags/v1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"ref": "refs/tags/v1.0.0", "object": {"type": "tag", "sha": "t1"}}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/git/tags/t1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"sha": "t1", "tag": "v1.0.0", "message": "Release v1.0.0", "tagger": {"name": "Tagger", "date": "2026-01-01T00:00:00Z"}, "object": {"type": "commit", "sha": "c1"}}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/git/ref/tags/v2.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	})
+
+	c := newTestTagClient(client)
+	got, err := c.Get(context.Background(), "v1.0.0")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	want := gitprovider.TagInfo{
+		Name:      "v1.0.0",
+		SHA:       "c1",
+		Annotated: true,
+		Message:   "Release v1.0.0",
+		Tagger:    "Tagger",
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get() (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.Get(context.Background(), "v2.0.0"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestTagClient_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		refExists bool
+		wantRef   string
+		want      gitprovider.TagInfo
+		wantErr   error
+	}{
+		{
+			name:    "lightweight tag",
+			wantRef: "c1",
+			want: gitprovider.TagInfo{
+				Name:      "v1.0.0",
+				SHA:       "c1",
+				CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "annotated tag",
+			message: "Release v1.0.0",
+			wantRef: "t1",
+			want: gitprovider.TagInfo{
+				Name:      "v1.0.0",
+				SHA:       "c1",
+				Annotated: true,
+				Message:   "Release v1.0.0",
+				Tagger:    "Tagger",
+				CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "tag already exists",
+			refExists: true,
+			wantErr:   gitprovider.ErrAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/repos/fluxcd/repo/git/tags", func(w http.ResponseWriter, r *http.Request) {
+				req := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatal(err)
+				}
+				if req["tag"] != "v1.0.0" || req["message"] != tt.message || req["object"] != "c1" || req["type"] != "commit" {
+					t.Errorf("unexpected tag object request %v", req)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"sha": "t1", "tag": "v1.0.0", "message": %q, "tagger": {"name": "Tagger", "date": "2026-02-01T00:00:00Z"}, "object": {"type": "commit", "sha": "c1"}}`, tt.message)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+				if tt.refExists {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					fmt.Fprint(w, `{"message": "Reference already exists"}`)
+					return
+				}
+				req := map[string]string{}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatal(err)
+				}
+				if req["ref"] != "refs/tags/v1.0.0" || req["sha"] != tt.wantRef {
+					t.Errorf("unexpected ref request %v", req)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"ref": "refs/tags/v1.0.0", "object": {"type": "commit", "sha": %q}}`, req["sha"])
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/git/commits/c1", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"sha": "c1", "committer": {"name": "Committer", "date": "2026-01-01T00:00:00Z"}}`)
+			})
+
+			got, err := newTestTagClient(client).Create(context.Background(), "v1.0.0", "c1", tt.message)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Create() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func newTestTagClient(client *Client) *TagClient {
+	return &TagClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
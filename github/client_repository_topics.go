@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TopicsClient implements the gitprovider.TopicsClient interface.
+var _ gitprovider.TopicsClient = &TopicsClient{}
+
+// TopicsClient operates on the topics of a specific repository.
+type TopicsClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the topics of the repository.
+func (c *TopicsClient) Get(ctx context.Context) ([]string, error) {
+	return c.c.ListRepoTopics(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+}
+
+// Reconcile makes sure the repository has exactly the given topics, in any order.
+// GitHub lowercases the topics, so they should be given in lowercase to be reconciled once.
+func (c *TopicsClient) Reconcile(ctx context.Context, topics []string, opts ...gitprovider.TopicsReconcileOption) (bool, error) {
+	return gitprovider.ReconcileTopics(ctx, topics, c.Get, func(ctx context.Context, topics []string) error {
+		return c.c.ReplaceRepoTopics(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), topics)
+	}, opts...)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TrafficClient implements the gitprovider.TrafficClient interface.
+var _ gitprovider.TrafficClient = &TrafficClient{}
+
+// TrafficClient reads the traffic statistics of a specific repository. GitHub reports the last
+// 14 days, the current day included, and requires push access to the repository.
+type TrafficClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Clones returns the number of clones of the repository over the last 14 days, in total and per day.
+func (c *TrafficClient) Clones(ctx context.Context) (gitprovider.TrafficInfo, error) {
+	// GET /repos/{owner}/{repo}/traffic/clones
+	apiObj, err := c.c.ListTrafficClones(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return gitprovider.TrafficInfo{}, err
+	}
+	return trafficFromAPI(apiObj.GetCount(), apiObj.GetUniques(), apiObj.Clones), nil
+}
+
+// Views returns the number of views of the repository over the last 14 days, in total and per day.
+func (c *TrafficClient) Views(ctx context.Context) (gitprovider.TrafficInfo, error) {
+	// GET /repos/{owner}/{repo}/traffic/views
+	apiObj, err := c.c.ListTrafficViews(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return gitprovider.TrafficInfo{}, err
+	}
+	return trafficFromAPI(apiObj.GetCount(), apiObj.GetUniques(), apiObj.Views), nil
+}
+
+// PopularPaths returns the 10 most viewed paths of the repository over the last 14 days, most
+// viewed first.
+func (c *TrafficClient) PopularPaths(ctx context.Context) ([]gitprovider.PopularPathInfo, error) {
+	// GET /repos/{owner}/{repo}/traffic/popular/paths
+	apiObjs, err := c.c.ListTrafficPaths(ctx, c.ref.GetIdentity(), c.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]gitprovider.PopularPathInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		paths = append(paths, gitprovider.PopularPathInfo{
+			Path:    apiObj.GetPath(),
+			Title:   apiObj.GetTitle(),
+			Count:   apiObj.GetCount(),
+			Uniques: apiObj.GetUniques(),
+		})
+	}
+	return paths, nil
+}
+
+// trafficFromAPI converts the traffic of a repository. GitHub omits the days without traffic.
+func trafficFromAPI(count, uniques int, apiObjs []*github.TrafficData) gitprovider.TrafficInfo {
+	info := gitprovider.TrafficInfo{
+		Count:   count,
+		Uniques: uniques,
+		Daily:   make([]gitprovider.TrafficCountInfo, 0, len(apiObjs)),
+	}
+	for _, apiObj := range apiObjs {
+		info.Daily = append(info.Daily, gitprovider.TrafficCountInfo{
+			Timestamp: apiObj.GetTimestamp().Time,
+			Count:     apiObj.GetCount(),
+			Uniques:   apiObj.GetUniques(),
+		})
+	}
+	return info
+}
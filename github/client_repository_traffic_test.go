@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestTrafficClient_Clones(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/traffic/clones", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per"); got != "day" {
+			t.Errorf("per = %q, want %q", got, "day")
+		}
+		fmt.Fprint(w, `{
+			"count": 173,
+			"uniques": 128,
+			"clones": [
+				{"timestamp": "2026-10-01T00:00:00Z", "count": 2, "uniques": 1},
+				{"timestamp": "2026-10-02T00:00:00Z", "count": 171, "uniques": 127}
+			]
+		}`)
+	})
+
+	c := &TrafficClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+	got, err := c.Clones(context.Background())
+	if err != nil {
+		t.Fatalf("Clones() error = %v", err)
+	}
+	want := gitprovider.TrafficInfo{
+		Count:   173,
+		Uniques: 128,
+		Daily: []gitprovider.TrafficCountInfo{
+			{Timestamp: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), Count: 2, Uniques: 1},
+			{Timestamp: time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC), Count: 171, Uniques: 127},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Clones() (-want +got):\n%s", diff)
+	}
+}
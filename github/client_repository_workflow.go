@@ -0,0 +1,221 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"gopkg.in/yaml.v3"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// WorkflowClient implements the gitprovider.WorkflowClient interface.
+var _ gitprovider.WorkflowClient = &WorkflowClient{}
+
+// WorkflowClient operates on the GitHub Actions workflows of a specific repository.
+type WorkflowClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Dispatch runs the given workflow, identified by its file name or ID, on the given branch or
+// tag with the given inputs.
+//
+// When the workflow file can be read at ref, the inputs are validated against the inputs the
+// workflow declares, and ErrInvalidArgument is returned for unknown or missing required inputs.
+//
+// ErrNotFound is returned if the workflow does not exist.
+func (c *WorkflowClient) Dispatch(ctx context.Context, workflow, ref string, inputs map[string]string) error {
+	if workflow == "" || ref == "" {
+		return fmt.Errorf("workflow and ref are required: %w", gitprovider.ErrInvalidArgument)
+	}
+	// GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}
+	apiObj, err := c.c.GetWorkflow(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), workflow)
+	if err != nil {
+		return err
+	}
+	if err := c.validateInputs(ctx, apiObj.GetPath(), ref, inputs); err != nil {
+		return err
+	}
+
+	req := github.CreateWorkflowDispatchEventRequest{Ref: ref}
+	if len(inputs) > 0 {
+		req.Inputs = make(map[string]interface{}, len(inputs))
+		for k, v := range inputs {
+			req.Inputs[k] = v
+		}
+	}
+	// POST /repos/{owner}/{repo}/actions/workflows/{workflow_id}/dispatches
+	return c.c.DispatchWorkflow(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), workflow, req)
+}
+
+// ListRuns lists the most recent runs of the given workflow, identified by its file name or ID,
+// most recent first.
+//
+// ErrNotFound is returned if the workflow does not exist.
+func (c *WorkflowClient) ListRuns(ctx context.Context, workflow string) ([]gitprovider.WorkflowRun, error) {
+	// GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}/runs
+	apiObjs, err := c.c.ListWorkflowRunsPage(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), workflow, &github.ListWorkflowRunsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]gitprovider.WorkflowRun, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		runs = append(runs, newWorkflowRun(c, apiObj))
+	}
+	return runs, nil
+}
+
+// GetRunLogs returns the logs of the jobs of the workflow run with the given ID, one job after
+// the other. The logs of each job are downloaded as the returned reader is read, which must be
+// closed by the caller.
+//
+// ErrNotFound is returned if the workflow run does not exist.
+func (c *WorkflowClient) GetRunLogs(ctx context.Context, runID int64) (io.ReadCloser, error) {
+	// GET /repos/{owner}/{repo}/actions/runs/{run_id}/jobs
+	jobs, err := c.c.ListWorkflowJobs(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), runID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Download the logs of each job rather than the archive of the logs of the run, so that they
+	// can be streamed
+	pr, pw := io.Pipe()
+	go func() {
+		for _, job := range jobs {
+			// GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs
+			if err := c.c.DownloadWorkflowJobLogs(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), job.GetID(), pw); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.Close()
+	}()
+	return pr, nil
+}
+
+// validateInputs validates inputs against the inputs declared by the workflow file at path on ref.
+// Validation is skipped if the workflow file can't be read.
+func (c *WorkflowClient) validateInputs(ctx context.Context, path, ref string, inputs map[string]string) error {
+	if path == "" {
+		return nil
+	}
+	// GET /repos/{owner}/{repo}/contents/{path}
+	fileContent, _, _, err := c.c.Client().Repositories.GetContents(ctx, c.ref.GetIdentity(), c.ref.GetRepository(), path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		err = handleHTTPError(err)
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if fileContent == nil {
+		return nil
+	}
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return nil
+	}
+
+	declared, dispatchable, err := workflowDispatchInputs([]byte(content))
+	if err != nil {
+		// Leave rejecting malformed workflows to GitHub
+		return nil
+	}
+	if !dispatchable {
+		return fmt.Errorf("workflow %q has no workflow_dispatch trigger: %w", path, gitprovider.ErrInvalidArgument)
+	}
+
+	unknown := []string{}
+	for name := range inputs {
+		if _, ok := declared[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("workflow %q doesn't declare the inputs %v: %w", path, unknown, gitprovider.ErrInvalidArgument)
+	}
+
+	missing := []string{}
+	for name, input := range declared {
+		if _, ok := inputs[name]; !ok && input.Required && input.Default == nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("workflow %q requires the inputs %v: %w", path, missing, gitprovider.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// workflowInput is an input declared by the workflow_dispatch trigger of a workflow.
+type workflowInput struct {
+	Required bool        `yaml:"required"`
+	Default  interface{} `yaml:"default"`
+}
+
+// workflowDispatchInputs parses a workflow file, and returns the inputs declared by its
+// workflow_dispatch trigger, and whether the workflow has such a trigger at all.
+func workflowDispatchInputs(content []byte) (map[string]workflowInput, bool, error) {
+	var wf struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(content, &wf); err != nil {
+		return nil, false, err
+	}
+
+	// "on" is either a single event, a list of events, or a map of events to their configuration
+	switch wf.On.Kind {
+	case yaml.ScalarNode:
+		return nil, wf.On.Value == "workflow_dispatch", nil
+	case yaml.SequenceNode:
+		for _, n := range wf.On.Content {
+			if n.Value == "workflow_dispatch" {
+				return nil, true, nil
+			}
+		}
+		return nil, false, nil
+	case yaml.MappingNode:
+		var events map[string]struct {
+			Inputs map[string]workflowInput `yaml:"inputs"`
+		}
+		if err := wf.On.Decode(&events); err != nil {
+			return nil, false, err
+		}
+		dispatch, ok := events["workflow_dispatch"]
+		return dispatch.Inputs, ok, nil
+	}
+	return nil, false, nil
+}
+
+// workflowID returns the numeric ID in workflow, if workflow isn't a file name.
+func workflowID(workflow string) (int64, bool) {
+	id, err := strconv.ParseInt(workflow, 10, 64)
+	return id, err == nil
+}
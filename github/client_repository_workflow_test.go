@@ -0,0 +1,226 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const testWorkflowFile = `name: release
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      version:
+        required: true
+      dry-run:
+        required: true
+        default: "false"
+`
+
+func newTestWorkflowClient(t *testing.T) (*http.ServeMux, *WorkflowClient) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/actions/workflows/release.yaml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 42, "name": "release", "path": ".github/workflows/release.yaml"}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/contents/.github/workflows/release.yaml", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ref"); got != "main" {
+			t.Errorf("ref = %q, want %q", got, "main")
+		}
+		fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "path": ".github/workflows/release.yaml", "content": %q}`,
+			base64.StdEncoding.EncodeToString([]byte(testWorkflowFile)))
+	})
+	return mux, &WorkflowClient{
+		clientContext: client.clientContext,
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestWorkflowClient_Dispatch(t *testing.T) {
+	mux, c := newTestWorkflowClient(t)
+	var got map[string]interface{}
+	mux.HandleFunc("/repos/fluxcd/repo/actions/workflows/release.yaml/dispatches", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.Dispatch(context.Background(), "release.yaml", "main", map[string]string{"version": "v1.2.0"}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"ref":    "main",
+		"inputs": map[string]interface{}{"version": "v1.2.0"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Dispatch() payload (-want +got):\n%s", diff)
+	}
+}
+
+func TestWorkflowClient_Dispatch_InvalidInputs(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs map[string]string
+	}{
+		{
+			name:   "unknown input",
+			inputs: map[string]string{"version": "v1.2.0", "environment": "prod"},
+		},
+		{
+			name:   "missing required input",
+			inputs: map[string]string{"dry-run": "true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestWorkflowClient(t)
+			mux.HandleFunc("/repos/fluxcd/repo/actions/workflows/release.yaml/dispatches", func(w http.ResponseWriter, r *http.Request) {
+				t.Error("didn't expect the workflow to be dispatched")
+			})
+
+			err := c.Dispatch(context.Background(), "release.yaml", "main", tt.inputs)
+			if !errors.Is(err, gitprovider.ErrInvalidArgument) {
+				t.Errorf("Dispatch() error = %v, want %v", err, gitprovider.ErrInvalidArgument)
+			}
+		})
+	}
+}
+
+func TestWorkflowClient_GetRunLogs(t *testing.T) {
+	mux, c := newTestWorkflowClient(t)
+	mux.HandleFunc("/repos/fluxcd/repo/actions/runs/7/jobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_count": 2, "jobs": [{"id": 1, "name": "build"}, {"id": 2, "name": "test"}]}`)
+	})
+	for _, id := range []string{"1", "2"} {
+		id := id
+		mux.HandleFunc("/repos/fluxcd/repo/actions/jobs/"+id+"/logs", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://"+r.Host+"/logs/job-"+id+".txt", http.StatusFound)
+		})
+		mux.HandleFunc("/logs/job-"+id+".txt", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" {
+				t.Error("didn't expect credentials to be sent with the logs request")
+			}
+			fmt.Fprintf(w, "logs of job %s\n", id)
+		})
+	}
+
+	// The logs must be downloaded through the configured download client.
+	downloads := 0
+	c.c.(*githubClientImpl).downloadClient = &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			downloads++
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+
+	logs, err := c.GetRunLogs(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetRunLogs() error = %v", err)
+	}
+	defer logs.Close()
+	got, err := io.ReadAll(logs)
+	if err != nil {
+		t.Fatalf("reading the logs failed: %v", err)
+	}
+	if want := "logs of job 1\nlogs of job 2\n"; string(got) != want {
+		t.Errorf("GetRunLogs() = %q, want %q", got, want)
+	}
+	if downloads != 2 {
+		t.Errorf("downloads through the download client = %d, want 2", downloads)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestWorkflowClient_GetRunLogs_NotFound(t *testing.T) {
+	mux, c := newTestWorkflowClient(t)
+	mux.HandleFunc("/repos/fluxcd/repo/actions/runs/7/jobs", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+
+	if _, err := c.GetRunLogs(context.Background(), 7); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("GetRunLogs() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestWorkflowDispatchInputs(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		wantInputs       map[string]workflowInput
+		wantDispatchable bool
+	}{
+		{
+			name:             "single event",
+			content:          "on: workflow_dispatch\n",
+			wantDispatchable: true,
+		},
+		{
+			name:             "list of events",
+			content:          "on: [push, pull_request]\n",
+			wantDispatchable: false,
+		},
+		{
+			name:             "trigger without inputs",
+			content:          "on:\n  workflow_dispatch:\n",
+			wantDispatchable: true,
+		},
+		{
+			name:    "trigger with inputs",
+			content: "on:\n  workflow_dispatch:\n    inputs:\n      tag:\n        required: true\n",
+			wantInputs: map[string]workflowInput{
+				"tag": {Required: true},
+			},
+			wantDispatchable: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs, dispatchable, err := workflowDispatchInputs([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("workflowDispatchInputs() error = %v", err)
+			}
+			if dispatchable != tt.wantDispatchable {
+				t.Errorf("workflowDispatchInputs() dispatchable = %v, want %v", dispatchable, tt.wantDispatchable)
+			}
+			if diff := cmp.Diff(tt.wantInputs, inputs); diff != "" {
+				t.Errorf("workflowDispatchInputs() inputs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestClient_TokenScopes(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         string
+		want           []string
+		wantErr        error
+		wantPermission bool
+	}{
+		{
+			name:           "classic token",
+			header:         "repo, admin:org,  workflow",
+			want:           []string{"repo", "admin:org", "workflow"},
+			wantPermission: true,
+		},
+		{
+			name:   "without the repo scope",
+			header: "read:org",
+			want:   []string{"read:org"},
+		},
+		{
+			name:    "fine-grained token",
+			wantErr: gitprovider.ErrMissingHeader,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/meta", func(w http.ResponseWriter, _ *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("X-OAuth-Scopes", tt.header)
+				}
+				fmt.Fprint(w, `{}`)
+			})
+
+			got, err := client.TokenScopes(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TokenScopes() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("TokenScopes() (-want +got):\n%s", diff)
+			}
+
+			hasPermission, err := client.HasTokenPermission(context.Background(), gitprovider.TokenPermissionRWRepository)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HasTokenPermission() error = %v, want %v", err, tt.wantErr)
+			}
+			if hasPermission != tt.wantPermission {
+				t.Errorf("HasTokenPermission() = %v, want %v", hasPermission, tt.wantPermission)
+			}
+		})
+	}
+}
+
+func TestClient_Repo(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultOwner gitprovider.IdentityRef
+		orgExists    bool
+		wantErr      error
+		wantOrgGets  int
+	}{
+		{
+			name:         "organization",
+			defaultOwner: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			orgExists:    true,
+			wantOrgGets:  1,
+		},
+		{
+			name:         "user",
+			defaultOwner: gitprovider.UserRef{Domain: DefaultDomain, UserLogin: "fluxcd"},
+		},
+		{
+			name:         "missing organization",
+			defaultOwner: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+			wantErr:      gitprovider.ErrNotFound,
+			wantOrgGets:  2,
+		},
+		{
+			name:    "no default owner",
+			wantErr: gitprovider.ErrNoDefaultOwner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			client.defaultOwner = gitprovider.NewDefaultOwner(tt.defaultOwner)
+			orgGets := 0
+			mux.HandleFunc("/orgs/fluxcd", func(w http.ResponseWriter, _ *http.Request) {
+				orgGets++
+				if !tt.orgExists {
+					http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `{"login": "fluxcd"}`)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"name": "repo"}`)
+			})
+
+			// The organization is only looked up until it's found to exist
+			for i := 0; i < 2; i++ {
+				repo, err := client.Repo(context.Background(), "repo")
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Repo() error = %v, want %v", err, tt.wantErr)
+				}
+				if err != nil {
+					continue
+				}
+				if diff := cmp.Diff(tt.defaultOwner.GetIdentity(), repo.Repository().GetIdentity()); diff != "" {
+					t.Errorf("Repo() owner (-want +got):\n%s", diff)
+				}
+			}
+			if orgGets != tt.wantOrgGets {
+				t.Errorf("Repo() got the organization %d times, want %d", orgGets, tt.wantOrgGets)
+			}
+		})
+	}
+}
@@ -18,7 +18,11 @@ package github
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/google/go-github/v66/github"
@@ -35,9 +39,45 @@ type githubClient interface {
 	// GetOrg is a wrapper for "GET /orgs/{org}".
 	// This function HTTP error wrapping, and validates the server result.
 	GetOrg(ctx context.Context, orgName string) (*github.Organization, error)
+	// UpdateOrg is a wrapper for "PATCH /orgs/{org}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateOrg(ctx context.Context, orgName string, req *github.Organization) (*github.Organization, error)
 	// ListOrgs is a wrapper for "GET /user/orgs".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListOrgs(ctx context.Context) ([]*github.Organization, error)
+	// ListOrgMemberships is a wrapper for "GET /user/memberships/orgs?state=active".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgMemberships(ctx context.Context) ([]*github.Membership, error)
+
+	// ListOrgHooks is a wrapper for "GET /orgs/{org}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListOrgHooks(ctx context.Context, orgName string) ([]*github.Hook, error)
+	// CreateOrgHook is a wrapper for "POST /orgs/{org}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateOrgHook(ctx context.Context, orgName string, req *github.Hook) (*github.Hook, error)
+	// EditOrgHook is a wrapper for "PATCH /orgs/{org}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditOrgHook(ctx context.Context, orgName string, id int64, req *github.Hook) (*github.Hook, error)
+	// DeleteOrgHook is a wrapper for "DELETE /orgs/{org}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteOrgHook(ctx context.Context, orgName string, id int64) error
+	// ListOrgAuditLog is a wrapper for "GET /orgs/{org}/audit-log", only listing the events
+	// matching phrase. The audit log is only available to organizations on GitHub Enterprise Cloud.
+	// This function handles pagination and HTTP error wrapping.
+	ListOrgAuditLog(ctx context.Context, orgName, phrase string) ([]*github.AuditEntry, error)
+
+	// ListRepoHooks is a wrapper for "GET /repos/{owner}/{repo}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListRepoHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error)
+	// CreateRepoHook is a wrapper for "POST /repos/{owner}/{repo}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepoHook(ctx context.Context, owner, repo string, req *github.Hook) (*github.Hook, error)
+	// EditRepoHook is a wrapper for "PATCH /repos/{owner}/{repo}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditRepoHook(ctx context.Context, owner, repo string, id int64, req *github.Hook) (*github.Hook, error)
+	// DeleteRepoHook is a wrapper for "DELETE /repos/{owner}/{repo}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteRepoHook(ctx context.Context, owner, repo string, id int64) error
 
 	// ListOrgTeamMembers is a wrapper for "GET /orgs/{org}/teams/{team_slug}/members".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
@@ -52,6 +92,10 @@ type githubClient interface {
 	// ListOrgRepos is a wrapper for "GET /orgs/{org}/repos".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListOrgRepos(ctx context.Context, org string) ([]*github.Repository, error)
+	// IterateOrgRepos is a wrapper for "GET /orgs/{org}/repos", calling fn for each repository
+	// while fetching one page at a time.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	IterateOrgRepos(ctx context.Context, org string, fn func(*github.Repository) error) error
 	// ListUserRepos is a wrapper for "GET /users/{username}/repos".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListUserRepos(ctx context.Context, username string) ([]*github.Repository, error)
@@ -66,16 +110,246 @@ type githubClient interface {
 	// This function handles HTTP error wrapping.
 	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
 	DeleteRepo(ctx context.Context, owner, repo string) error
+	// GetRepoLicense is a wrapper for "GET /repos/{owner}/{repo}/license".
+	// This function handles HTTP error wrapping.
+	GetRepoLicense(ctx context.Context, owner, repo string) (*github.RepositoryLicense, error)
+	// GetBranch is a wrapper for "GET /repos/{owner}/{repo}/branches/{branch}".
+	// This function handles HTTP error wrapping.
+	GetBranch(ctx context.Context, owner, repo, branch string) (*github.Branch, error)
+	// SetRepoSubscription is a wrapper for "PUT /repos/{owner}/{repo}/subscription".
+	// This function handles HTTP error wrapping.
+	SetRepoSubscription(ctx context.Context, owner, repo string, req *github.Subscription) error
+	// DeleteRepoSubscription is a wrapper for "DELETE /repos/{owner}/{repo}/subscription".
+	// This function handles HTTP error wrapping.
+	DeleteRepoSubscription(ctx context.Context, owner, repo string) error
+	// ListRepoSubscribers is a wrapper for "GET /repos/{owner}/{repo}/subscribers".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListRepoSubscribers(ctx context.Context, owner, repo string) ([]*github.User, error)
+	// ListRepoRulesets is a wrapper for "GET /repos/{owner}/{repo}/rulesets".
+	// This function handles HTTP error wrapping, and validates the server result.
+	// The listed rulesets don't contain their rules, use GetRepoRuleset for these.
+	ListRepoRulesets(ctx context.Context, owner, repo string) ([]*github.Ruleset, error)
+	// GetRepoRuleset is a wrapper for "GET /repos/{owner}/{repo}/rulesets/{ruleset_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetRepoRuleset(ctx context.Context, owner, repo string, id int64) (*github.Ruleset, error)
+	// GetRepoRulesetRuleParameters is a wrapper for "GET /repos/{owner}/{repo}/rulesets/{ruleset_id}".
+	// This function handles HTTP error wrapping, and returns the raw parameters of the rule of the
+	// given type, as go-github drops the parameters it doesn't know. nil is returned if the ruleset
+	// has no such rule.
+	GetRepoRulesetRuleParameters(ctx context.Context, owner, repo string, id int64, ruleType string) (json.RawMessage, error)
+	// CreateRepoRuleset is a wrapper for "POST /repos/{owner}/{repo}/rulesets".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRepoRuleset(ctx context.Context, owner, repo string, req *github.Ruleset) (*github.Ruleset, error)
+	// UpdateRepoRuleset is a wrapper for "PUT /repos/{owner}/{repo}/rulesets/{ruleset_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateRepoRuleset(ctx context.Context, owner, repo string, id int64, req *github.Ruleset) (*github.Ruleset, error)
+	// DeleteRepoRuleset is a wrapper for "DELETE /repos/{owner}/{repo}/rulesets/{ruleset_id}".
+	// This function handles HTTP error wrapping.
+	DeleteRepoRuleset(ctx context.Context, owner, repo string, id int64) error
+	// GetEnvironment is a wrapper for "GET /repos/{owner}/{repo}/environments/{environment_name}".
+	// This function handles HTTP error wrapping.
+	GetEnvironment(ctx context.Context, owner, repo, name string) (*github.Environment, error)
+	// UpdateEnvironment is a wrapper for "PUT /repos/{owner}/{repo}/environments/{environment_name}".
+	// This function handles HTTP error wrapping.
+	UpdateEnvironment(ctx context.Context, owner, repo, name string, req *github.CreateUpdateEnvironment) error
+	// ListEnvSecrets is a wrapper for "GET /repositories/{repository_id}/environments/{environment_name}/secrets".
+	// This function handles pagination and HTTP error wrapping.
+	ListEnvSecrets(ctx context.Context, repoID int64, environment string) ([]*github.Secret, error)
+	// ListDeploymentBranchPolicies is a wrapper for "GET /repos/{owner}/{repo}/environments/{environment_name}/deployment-branch-policies".
+	// This function handles HTTP error wrapping.
+	ListDeploymentBranchPolicies(ctx context.Context, owner, repo, environment string) ([]*github.DeploymentBranchPolicy, error)
+	// CreateDeploymentBranchPolicy is a wrapper for "POST /repos/{owner}/{repo}/environments/{environment_name}/deployment-branch-policies".
+	// This function handles HTTP error wrapping.
+	CreateDeploymentBranchPolicy(ctx context.Context, owner, repo, environment string, req *github.DeploymentBranchPolicyRequest) error
+	// DeleteDeploymentBranchPolicy is a wrapper for "DELETE /repos/{owner}/{repo}/environments/{environment_name}/deployment-branch-policies/{branch_policy_id}".
+	// This function handles HTTP error wrapping.
+	DeleteDeploymentBranchPolicy(ctx context.Context, owner, repo, environment string, id int64) error
+	// ListDeploymentProtectionRules is a wrapper for "GET /repos/{owner}/{repo}/environments/{environment_name}/deployment_protection_rules".
+	// This function handles HTTP error wrapping.
+	ListDeploymentProtectionRules(ctx context.Context, owner, repo, environment string) ([]*github.CustomDeploymentProtectionRule, error)
+	// CreateDeploymentProtectionRule is a wrapper for "POST /repos/{owner}/{repo}/environments/{environment_name}/deployment_protection_rules".
+	// This function handles HTTP error wrapping.
+	CreateDeploymentProtectionRule(ctx context.Context, owner, repo, environment string, appID int64) error
+	// DisableDeploymentProtectionRule is a wrapper for "DELETE /repos/{owner}/{repo}/environments/{environment_name}/deployment_protection_rules/{protection_rule_id}".
+	// This function handles HTTP error wrapping.
+	DisableDeploymentProtectionRule(ctx context.Context, owner, repo, environment string, id int64) error
+	// GetWorkflow is a wrapper for "GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}",
+	// where workflow is either the ID or the file name of the workflow.
+	// This function handles HTTP error wrapping.
+	GetWorkflow(ctx context.Context, owner, repo, workflow string) (*github.Workflow, error)
+	// DispatchWorkflow is a wrapper for "POST /repos/{owner}/{repo}/actions/workflows/{workflow_id}/dispatches",
+	// where workflow is either the ID or the file name of the workflow.
+	// This function handles HTTP error wrapping.
+	DispatchWorkflow(ctx context.Context, owner, repo, workflow string, req github.CreateWorkflowDispatchEventRequest) error
+	// ListWorkflowRunsPage is a wrapper for "GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}/runs",
+	// where workflow is either the ID or the file name of the workflow.
+	// This function handles HTTP error wrapping, and returns a single page of the most recent runs.
+	ListWorkflowRunsPage(ctx context.Context, owner, repo, workflow string, opts *github.ListWorkflowRunsOptions) ([]*github.WorkflowRun, error)
+	// ListWorkflowJobs is a wrapper for "GET /repos/{owner}/{repo}/actions/runs/{run_id}/jobs".
+	// This function handles pagination and HTTP error wrapping.
+	ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64) ([]*github.WorkflowJob, error)
+	// DownloadWorkflowJobLogs is a wrapper for "GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs",
+	// following the redirect to the logs without credentials, and writing them to w.
+	// This function handles HTTP error wrapping.
+	DownloadWorkflowJobLogs(ctx context.Context, owner, repo string, jobID int64, w io.Writer) error
+	// GetRepoDefaultWorkflowPermissions is a wrapper for "GET /repos/{owner}/{repo}/actions/permissions/workflow".
+	// This function handles HTTP error wrapping.
+	GetRepoDefaultWorkflowPermissions(ctx context.Context, owner, repo string) (*github.DefaultWorkflowPermissionRepository, error)
+	// EditRepoDefaultWorkflowPermissions is a wrapper for "PUT /repos/{owner}/{repo}/actions/permissions/workflow".
+	// This function handles HTTP error wrapping.
+	EditRepoDefaultWorkflowPermissions(ctx context.Context, owner, repo string, req *github.DefaultWorkflowPermissionRepository) error
+	// GetRepoCodeScanningDefaultSetup is a wrapper for "GET /repos/{owner}/{repo}/code-scanning/default-setup".
+	// This function handles HTTP error wrapping.
+	GetRepoCodeScanningDefaultSetup(ctx context.Context, owner, repo string) (*github.DefaultSetupConfiguration, error)
+	// UpdateRepoCodeScanningDefaultSetup is a wrapper for "PATCH /repos/{owner}/{repo}/code-scanning/default-setup".
+	// This function handles HTTP error wrapping, and treats the update being scheduled in the
+	// background (202 Accepted) as a success.
+	UpdateRepoCodeScanningDefaultSetup(ctx context.Context, owner, repo string, req *github.UpdateDefaultSetupConfigurationOptions) error
+	// ListRepoCommitActivity is a wrapper for "GET /repos/{owner}/{repo}/stats/commit_activity".
+	// This function handles HTTP error wrapping, and returns nil statistics while GitHub
+	// computes them in the background (202 Accepted).
+	ListRepoCommitActivity(ctx context.Context, owner, repo string) ([]*github.WeeklyCommitActivity, error)
+	// ListOrgCustomProperties is a wrapper for "GET /orgs/{org}/properties/schema".
+	// This function handles HTTP error wrapping.
+	ListOrgCustomProperties(ctx context.Context, org string) ([]*github.CustomProperty, error)
+	// GetRepoCustomPropertyValues is a wrapper for "GET /repos/{owner}/{repo}/properties/values".
+	// This function handles HTTP error wrapping.
+	GetRepoCustomPropertyValues(ctx context.Context, owner, repo string) ([]*github.CustomPropertyValue, error)
+	// UpdateRepoCustomPropertyValues is a wrapper for "PATCH /repos/{owner}/{repo}/properties/values".
+	// This function handles HTTP error wrapping.
+	UpdateRepoCustomPropertyValues(ctx context.Context, owner, repo string, values []*github.CustomPropertyValue) error
+	// GetRepoInteractionLimits is a wrapper for "GET /repos/{owner}/{repo}/interaction-limits".
+	// This function handles HTTP error wrapping.
+	GetRepoInteractionLimits(ctx context.Context, owner, repo string) (*github.InteractionRestriction, error)
+	// SetRepoInteractionLimits is a wrapper for "PUT /repos/{owner}/{repo}/interaction-limits".
+	// This function handles HTTP error wrapping.
+	SetRepoInteractionLimits(ctx context.Context, owner, repo, limit, expiry string) (*github.InteractionRestriction, error)
+	// RemoveRepoInteractionLimits is a wrapper for "DELETE /repos/{owner}/{repo}/interaction-limits".
+	// This function handles HTTP error wrapping.
+	RemoveRepoInteractionLimits(ctx context.Context, owner, repo string) error
+	// GetPages is a wrapper for "GET /repos/{owner}/{repo}/pages".
+	// This function handles HTTP error wrapping.
+	GetPages(ctx context.Context, owner, repo string) (*github.Pages, error)
+	// EnablePages is a wrapper for "POST /repos/{owner}/{repo}/pages".
+	// This function handles HTTP error wrapping.
+	EnablePages(ctx context.Context, owner, repo string, req *github.Pages) error
+	// UpdatePages is a wrapper for "PUT /repos/{owner}/{repo}/pages".
+	// This function handles HTTP error wrapping.
+	UpdatePages(ctx context.Context, owner, repo string, req *github.PagesUpdate) error
+	// ListTrafficClones is a wrapper for "GET /repos/{owner}/{repo}/traffic/clones".
+	// This function handles HTTP error wrapping.
+	ListTrafficClones(ctx context.Context, owner, repo string) (*github.TrafficClones, error)
+	// ListTrafficViews is a wrapper for "GET /repos/{owner}/{repo}/traffic/views".
+	// This function handles HTTP error wrapping.
+	ListTrafficViews(ctx context.Context, owner, repo string) (*github.TrafficViews, error)
+	// ListTrafficPaths is a wrapper for "GET /repos/{owner}/{repo}/traffic/popular/paths".
+	// This function handles HTTP error wrapping.
+	ListTrafficPaths(ctx context.Context, owner, repo string) ([]*github.TrafficPath, error)
+	// ListRepoTopics is a wrapper for "GET /repos/{owner}/{repo}/topics".
+	// This function handles HTTP error wrapping.
+	ListRepoTopics(ctx context.Context, owner, repo string) ([]string, error)
+	// ReplaceRepoTopics is a wrapper for "PUT /repos/{owner}/{repo}/topics".
+	// This function handles HTTP error wrapping.
+	ReplaceRepoTopics(ctx context.Context, owner, repo string, topics []string) error
+	// ListRepoCollaborators is a wrapper for "GET /repos/{owner}/{repo}/collaborators",
+	// with affiliation being "direct" or "all".
+	// This function handles pagination and HTTP error wrapping.
+	ListRepoCollaborators(ctx context.Context, owner, repo, affiliation string) ([]*github.User, error)
+	// CountRepoPullRequests is a wrapper for "GET /repos/{owner}/{repo}/pulls", counting the
+	// pull requests in the given state without listing them all.
+	// This function handles HTTP error wrapping.
+	CountRepoPullRequests(ctx context.Context, owner, repo, state string) (int, error)
+	// CountRepoBranches is a wrapper for "GET /repos/{owner}/{repo}/branches", counting the
+	// branches without listing them all.
+	// This function handles HTTP error wrapping.
+	CountRepoBranches(ctx context.Context, owner, repo string) (int, error)
+	// CountRepoTags is a wrapper for "GET /repos/{owner}/{repo}/tags", counting the tags
+	// without listing them all.
+	// This function handles HTTP error wrapping.
+	CountRepoTags(ctx context.Context, owner, repo string) (int, error)
 
 	// GetUser is a wrapper for "GET /user"
 	GetUser(ctx context.Context) (*github.User, error)
+	// GetUserByLogin is a wrapper for "GET /users/{username}", which also describes organizations.
+	// This function handles HTTP error wrapping.
+	GetUserByLogin(ctx context.Context, login string) (*github.User, error)
 
 	// ListKeys is a wrapper for "GET /repos/{owner}/{repo}/keys".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListKeys(ctx context.Context, owner, repo string) ([]*github.Key, error)
 	// ListCommitsPage is a wrapper for "GET /repos/{owner}/{repo}/commits".
-	// This function handles pagination, HTTP error wrapping.
-	ListCommitsPage(ctx context.Context, owner, repo, branch string, perPage int, page int) ([]*github.Commit, error)
+	// This function lists the single page given in opts, and handles HTTP error wrapping.
+	ListCommitsPage(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.Commit, error)
+	// ListPullRequestCommits is a wrapper for "GET /repos/{owner}/{repo}/pulls/{pull_number}/commits".
+	// This function handles pagination, HTTP error wrapping, and maps the repository commits
+	// to git commits, like ListCommitsPage.
+	ListPullRequestCommits(ctx context.Context, owner, repo string, number int) ([]*github.Commit, error)
+	// ListPullRequestReviews is a wrapper for "GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews".
+	// This function handles pagination and HTTP error wrapping.
+	ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error)
+	// CreatePullRequestReview is a wrapper for "POST /repos/{owner}/{repo}/pulls/{pull_number}/reviews".
+	// This function handles HTTP error wrapping.
+	CreatePullRequestReview(ctx context.Context, owner, repo string, number int, req *github.PullRequestReviewRequest) (*github.PullRequestReview, error)
+	// ListIssueComments is a wrapper for "GET /repos/{owner}/{repo}/issues/{issue_number}/comments".
+	// This function handles pagination and HTTP error wrapping.
+	ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error)
+	// CreateIssueComment is a wrapper for "POST /repos/{owner}/{repo}/issues/{issue_number}/comments".
+	// This function handles HTTP error wrapping.
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*github.IssueComment, error)
+	// AddLabelsToIssue is a wrapper for "POST /repos/{owner}/{repo}/issues/{issue_number}/labels".
+	// This function handles HTTP error wrapping.
+	AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) error
+	// ListLabelsByIssue is a wrapper for "GET /repos/{owner}/{repo}/issues/{issue_number}/labels".
+	// This function handles pagination and HTTP error wrapping.
+	ListLabelsByIssue(ctx context.Context, owner, repo string, number int) ([]*github.Label, error)
+	// GetCommitSignature is a wrapper for "GET /repos/{owner}/{repo}/commits/{ref}".
+	// This function handles HTTP error wrapping, and returns the commit's verification signature,
+	// which is empty if the commit isn't signed.
+	GetCommitSignature(ctx context.Context, owner, repo, sha string) (string, error)
+	// ListCheckRunsForRef is a wrapper for "GET /repos/{owner}/{repo}/commits/{ref}/check-runs".
+	// This function handles pagination and HTTP error wrapping.
+	ListCheckRunsForRef(ctx context.Context, owner, repo, ref string) ([]*github.CheckRun, error)
+	// ListCheckRunAnnotations is a wrapper for "GET /repos/{owner}/{repo}/check-runs/{check_run_id}/annotations".
+	// This function handles pagination and HTTP error wrapping.
+	ListCheckRunAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]*github.CheckRunAnnotation, error)
+	// ListBranches is a wrapper for "GET /repos/{owner}/{repo}/branches".
+	// This function handles pagination and HTTP error wrapping.
+	ListBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error)
+	// GetCompareStatus is a wrapper for "GET /repos/{owner}/{repo}/compare/{base}...{head}".
+	// This function handles HTTP error wrapping, and returns the status of head relative to base,
+	// one of "ahead", "behind", "diverged" or "identical".
+	GetCompareStatus(ctx context.Context, owner, repo, base, head string) (string, error)
+	// CompareCommits is a wrapper for "GET /repos/{owner}/{repo}/compare/{base}...{head}".
+	// This function handles HTTP error wrapping, and returns the comparison including the merge
+	// base and the changed files.
+	CompareCommits(ctx context.Context, owner, repo, base, head string) (*github.CommitsComparison, error)
+	// GetCommitSHA is a wrapper for "GET /repos/{owner}/{repo}/commits/{ref}".
+	// This function handles HTTP error wrapping, and returns the full SHA of the commit ref points to.
+	GetCommitSHA(ctx context.Context, owner, repo, ref string) (string, error)
+	// ListTagRefs is a wrapper for "GET /repos/{owner}/{repo}/git/matching-refs/tags".
+	// This function handles pagination and HTTP error wrapping.
+	ListTagRefs(ctx context.Context, owner, repo string) ([]*github.Reference, error)
+	// GetTag is a wrapper for "GET /repos/{owner}/{repo}/git/tags/{tag_sha}", returning the
+	// annotated tag object with the given SHA.
+	// This function handles HTTP error wrapping.
+	GetTag(ctx context.Context, owner, repo, sha string) (*github.Tag, error)
+	// GetTagRef is a wrapper for "GET /repos/{owner}/{repo}/git/ref/tags/{tag}".
+	// This function handles HTTP error wrapping.
+	GetTagRef(ctx context.Context, owner, repo, name string) (*github.Reference, error)
+	// CreateTag is a wrapper for "POST /repos/{owner}/{repo}/git/tags", creating the tag object
+	// of an annotated tag. The tag ref must be created separately.
+	// This function handles HTTP error wrapping.
+	CreateTag(ctx context.Context, owner, repo string, req *github.Tag) (*github.Tag, error)
+	// CreateTagRef is a wrapper for "POST /repos/{owner}/{repo}/git/refs", creating the
+	// "refs/tags/{name}" ref pointing to the given SHA.
+	// This function handles HTTP error wrapping, and returns ErrAlreadyExists if the tag exists.
+	CreateTagRef(ctx context.Context, owner, repo, name, sha string) (*github.Reference, error)
+	// DeleteTagRef is a wrapper for "DELETE /repos/{owner}/{repo}/git/refs/tags/{name}".
+	// This function handles HTTP error wrapping.
+	DeleteTagRef(ctx context.Context, owner, repo, name string) error
+	// GetGitCommit is a wrapper for "GET /repos/{owner}/{repo}/git/commits/{commit_sha}".
+	// This function handles HTTP error wrapping.
+	GetGitCommit(ctx context.Context, owner, repo, sha string) (*github.Commit, error)
 	// CreateKey is a wrapper for "POST /repos/{owner}/{repo}/keys".
 	// This function handles HTTP error wrapping, and validates the server result.
 	CreateKey(ctx context.Context, owner, repo string, req *github.Key) (*github.Key, error)
@@ -83,6 +357,43 @@ type githubClient interface {
 	// This function handles HTTP error wrapping.
 	DeleteKey(ctx context.Context, owner, repo string, id int64) error
 
+	// ListMilestones is a wrapper for "GET /repos/{owner}/{repo}/milestones?state=all".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListMilestones(ctx context.Context, owner, repo string) ([]*github.Milestone, error)
+	// CreateMilestone is a wrapper for "POST /repos/{owner}/{repo}/milestones".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateMilestone(ctx context.Context, owner, repo string, req *github.Milestone) (*github.Milestone, error)
+	// UpdateMilestone is a wrapper for "PATCH /repos/{owner}/{repo}/milestones/{milestone_number}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateMilestone(ctx context.Context, owner, repo string, number int, req *github.Milestone) (*github.Milestone, error)
+
+	// ListReleases is a wrapper for "GET /repos/{owner}/{repo}/releases".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListReleases(ctx context.Context, owner, repo string) ([]*github.RepositoryRelease, error)
+	// GetReleaseByTag is a wrapper for "GET /repos/{owner}/{repo}/releases/tags/{tag}".
+	// Draft releases can't be found by tag, but only listed.
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, error)
+	// CreateRelease is a wrapper for "POST /repos/{owner}/{repo}/releases".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateRelease(ctx context.Context, owner, repo string, req *github.RepositoryRelease) (*github.RepositoryRelease, error)
+	// EditRelease is a wrapper for "PATCH /repos/{owner}/{repo}/releases/{release_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditRelease(ctx context.Context, owner, repo string, id int64, req *github.RepositoryRelease) (*github.RepositoryRelease, error)
+	// DeleteRelease is a wrapper for "DELETE /repos/{owner}/{repo}/releases/{release_id}".
+	// This function handles HTTP error wrapping.
+	DeleteRelease(ctx context.Context, owner, repo string, id int64) error
+
+	// ListLabels is a wrapper for "GET /repos/{owner}/{repo}/labels".
+	// This function handles pagination and HTTP error wrapping.
+	ListLabels(ctx context.Context, owner, repo string) ([]*github.Label, error)
+	// CreateLabel is a wrapper for "POST /repos/{owner}/{repo}/labels".
+	// This function handles HTTP error wrapping.
+	CreateLabel(ctx context.Context, owner, repo string, req *github.Label) error
+	// EditLabel is a wrapper for "PATCH /repos/{owner}/{repo}/labels/{name}".
+	// This function handles HTTP error wrapping.
+	EditLabel(ctx context.Context, owner, repo, name string, req *github.Label) error
+
 	// GetTeamPermissions is a wrapper for "GET /orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}".
 	// This function handles HTTP error wrapping, and validates the server result.
 	GetTeamPermissions(ctx context.Context, orgName, repo, teamName string) (map[string]bool, error)
@@ -104,6 +415,8 @@ type githubClient interface {
 type githubClientImpl struct {
 	c                  *github.Client
 	destructiveActions bool
+	// downloadClient is used for downloading from pre-signed URLs, without the credentials of c.
+	downloadClient *http.Client
 }
 
 // githubClientImpl implements githubClient.
@@ -126,10 +439,142 @@ func (c *githubClientImpl) GetOrg(ctx context.Context, orgName string) (*github.
 	return apiObj, nil
 }
 
+func (c *githubClientImpl) UpdateOrg(ctx context.Context, orgName string, req *github.Organization) (*github.Organization, error) {
+	// PATCH /orgs/{org}
+	apiObj, _, err := c.c.Organizations.Edit(ctx, orgName, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateOrganizationAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListOrgHooks(ctx context.Context, orgName string) ([]*github.Hook, error) {
+	apiObjs := []*github.Hook{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /orgs/{org}/hooks
+		pageObjs, resp, listErr := c.c.Organizations.ListHooks(ctx, orgName, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateHookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateOrgHook(ctx context.Context, orgName string, req *github.Hook) (*github.Hook, error) {
+	// POST /orgs/{org}/hooks
+	apiObj, _, err := c.c.Organizations.CreateHook(ctx, orgName, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditOrgHook(ctx context.Context, orgName string, id int64, req *github.Hook) (*github.Hook, error) {
+	// PATCH /orgs/{org}/hooks/{hook_id}
+	apiObj, _, err := c.c.Organizations.EditHook(ctx, orgName, id, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteOrgHook(ctx context.Context, orgName string, id int64) error {
+	// DELETE /orgs/{org}/hooks/{hook_id}
+	_, err := c.c.Organizations.DeleteHook(ctx, orgName, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListOrgAuditLog(ctx context.Context, orgName, phrase string) ([]*github.AuditEntry, error) {
+	apiObjs := []*github.AuditEntry{}
+	opts := &github.GetAuditLogOptions{}
+	if phrase != "" {
+		opts.Phrase = &phrase
+	}
+	err := allCursorPages(ctx, &opts.ListCursorOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /orgs/{org}/audit-log
+		pageObjs, resp, listErr := c.c.Organizations.GetAuditLog(ctx, orgName, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListRepoHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error) {
+	apiObjs := []*github.Hook{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/hooks
+		pageObjs, resp, listErr := c.c.Repositories.ListHooks(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateHookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateRepoHook(ctx context.Context, owner, repo string, req *github.Hook) (*github.Hook, error) {
+	// POST /repos/{owner}/{repo}/hooks
+	apiObj, _, err := c.c.Repositories.CreateHook(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditRepoHook(ctx context.Context, owner, repo string, id int64, req *github.Hook) (*github.Hook, error) {
+	// PATCH /repos/{owner}/{repo}/hooks/{hook_id}
+	apiObj, _, err := c.c.Repositories.EditHook(ctx, owner, repo, id, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteRepoHook(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/hooks/{hook_id}
+	_, err := c.c.Repositories.DeleteHook(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
 func (c *githubClientImpl) ListOrgs(ctx context.Context) ([]*github.Organization, error) {
 	apiObjs := []*github.Organization{}
 	opts := &github.ListOptions{}
-	err := allPages(opts, func() (*github.Response, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
 		// GET /user/orgs
 		pageObjs, resp, listErr := c.c.Organizations.List(ctx, "", opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -148,10 +593,32 @@ func (c *githubClientImpl) ListOrgs(ctx context.Context) ([]*github.Organization
 	return apiObjs, nil
 }
 
+func (c *githubClientImpl) ListOrgMemberships(ctx context.Context) ([]*github.Membership, error) {
+	apiObjs := []*github.Membership{}
+	opts := &github.ListOrgMembershipsOptions{State: "active"}
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /user/memberships/orgs
+		pageObjs, resp, listErr := c.c.Organizations.ListOrgMemberships(ctx, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the API objects
+	for _, apiObj := range apiObjs {
+		if err := validateOrganizationAPI(apiObj.GetOrganization()); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
 func (c *githubClientImpl) ListOrgTeamMembers(ctx context.Context, orgName, teamName string) ([]*github.User, error) {
 	apiObjs := []*github.User{}
 	opts := &github.TeamListTeamMembersOptions{}
-	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
 		// GET /orgs/{org}/teams/{team_slug}/members
 		pageObjs, resp, listErr := c.c.Teams.ListTeamMembersBySlug(ctx, orgName, teamName, opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -175,7 +642,7 @@ func (c *githubClientImpl) ListOrgTeams(ctx context.Context, orgName string) ([]
 	// List all teams, using pagination. This does not contain information about the members
 	apiObjs := []*github.Team{}
 	opts := &github.ListOptions{}
-	err := allPages(opts, func() (*github.Response, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
 		// GET /orgs/{org}/teams
 		pageObjs, resp, listErr := c.c.Teams.ListTeams(ctx, orgName, opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -215,7 +682,7 @@ func validateRepositoryAPIResp(apiObj *github.Repository, err error) (*github.Re
 func (c *githubClientImpl) ListOrgRepos(ctx context.Context, org string) ([]*github.Repository, error) {
 	var apiObjs []*github.Repository
 	opts := &github.RepositoryListByOrgOptions{}
-	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
 		// GET /orgs/{org}/repos
 		pageObjs, resp, listErr := c.c.Repositories.ListByOrg(ctx, org, opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -227,6 +694,29 @@ func (c *githubClientImpl) ListOrgRepos(ctx context.Context, org string) ([]*git
 	return validateRepositoryObjects(apiObjs)
 }
 
+func (c *githubClientImpl) IterateOrgRepos(ctx context.Context, org string, fn func(*github.Repository) error) error {
+	opts := &github.RepositoryListByOrgOptions{}
+	return allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /orgs/{org}/repos
+		pageObjs, resp, listErr := c.c.Repositories.ListByOrg(ctx, org, opts)
+		if listErr != nil {
+			return resp, listErr
+		}
+		if _, err := validateRepositoryObjects(pageObjs); err != nil {
+			return nil, err
+		}
+		for _, apiObj := range pageObjs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := fn(apiObj); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	})
+}
+
 func validateRepositoryObjects(apiObjs []*github.Repository) ([]*github.Repository, error) {
 	for _, apiObj := range apiObjs {
 		// Make sure apiObj is valid
@@ -240,7 +730,7 @@ func validateRepositoryObjects(apiObjs []*github.Repository) ([]*github.Reposito
 func (c *githubClientImpl) ListUserRepos(ctx context.Context, username string) ([]*github.Repository, error) {
 	var apiObjs []*github.Repository
 	opts := &github.RepositoryListOptions{}
-	err := allPages(&opts.ListOptions, func() (*github.Response, error) {
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
 		// GET /users/{username}/repos
 		pageObjs, resp, listErr := c.c.Repositories.List(ctx, username, opts)
 		apiObjs = append(apiObjs, pageObjs...)
@@ -256,7 +746,7 @@ func (c *githubClientImpl) CreateRepo(ctx context.Context, orgName string, req *
 	// POST /user/repos (if orgName == "")
 	// POST /orgs/{org}/repos (if orgName != "")
 	setPrivate := true
-	if *req.Visibility == "private" {
+	if req.GetVisibility() == "private" {
 		req.Private = &setPrivate
 	}
 	apiObj, _, err := c.c.Repositories.Create(ctx, orgName, req)
@@ -279,12 +769,42 @@ func (c *githubClientImpl) DeleteRepo(ctx context.Context, owner, repo string) e
 	return handleHTTPError(err)
 }
 
-func (c *githubClientImpl) ListKeys(ctx context.Context, owner, repo string) ([]*github.Key, error) {
-	apiObjs := []*github.Key{}
+func (c *githubClientImpl) GetRepoLicense(ctx context.Context, owner, repo string) (*github.RepositoryLicense, error) {
+	// GET /repos/{owner}/{repo}/license
+	apiObj, _, err := c.c.Repositories.License(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) GetBranch(ctx context.Context, owner, repo, branch string) (*github.Branch, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}
+	apiObj, _, err := c.c.Repositories.GetBranch(ctx, owner, repo, branch, 1)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) SetRepoSubscription(ctx context.Context, owner, repo string, req *github.Subscription) error {
+	// PUT /repos/{owner}/{repo}/subscription
+	_, _, err := c.c.Activity.SetRepositorySubscription(ctx, owner, repo, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) DeleteRepoSubscription(ctx context.Context, owner, repo string) error {
+	// DELETE /repos/{owner}/{repo}/subscription
+	_, err := c.c.Activity.DeleteRepositorySubscription(ctx, owner, repo)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListRepoSubscribers(ctx context.Context, owner, repo string) ([]*github.User, error) {
+	apiObjs := []*github.User{}
 	opts := &github.ListOptions{}
-	err := allPages(opts, func() (*github.Response, error) {
-		// GET /repos/{owner}/{repo}/keys
-		pageObjs, resp, listErr := c.c.Repositories.ListKeys(ctx, owner, repo, opts)
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/subscribers
+		pageObjs, resp, listErr := c.c.Activity.ListWatchers(ctx, owner, repo, opts)
 		apiObjs = append(apiObjs, pageObjs...)
 		return resp, listErr
 	})
@@ -292,48 +812,907 @@ func (c *githubClientImpl) ListKeys(ctx context.Context, owner, repo string) ([]
 		return nil, err
 	}
 
+	// Make sure the Login field is set.
 	for _, apiObj := range apiObjs {
-		if err := validateDeployKeyAPI(apiObj); err != nil {
-			return nil, err
+		if apiObj.Login == nil {
+			return nil, fmt.Errorf("didn't expect login to be nil for user: %+v: %w", apiObj, gitprovider.ErrInvalidServerData)
 		}
 	}
 	return apiObjs, nil
 }
 
-func (c *githubClientImpl) GetUser(ctx context.Context) (*github.User, error) {
-	// GET /user
-	user, _, err := c.c.Users.Get(ctx, "")
-	return user, err
+func (c *githubClientImpl) ListRepoRulesets(ctx context.Context, owner, repo string) ([]*github.Ruleset, error) {
+	// GET /repos/{owner}/{repo}/rulesets
+	apiObjs, _, err := c.c.Repositories.GetAllRulesets(ctx, owner, repo, false)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	for _, apiObj := range apiObjs {
+		if err := validateRulesetAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
 }
 
-func (c *githubClientImpl) ListCommitsPage(ctx context.Context, owner, repo, branch string, perPage int, page int) ([]*github.Commit, error) {
-	apiObjs := make([]*github.Commit, 0)
-	lcOpts := &github.CommitsListOptions{
-		ListOptions: github.ListOptions{
-			PerPage: perPage,
-			Page:    page,
-		},
-		SHA: branch,
+func (c *githubClientImpl) GetRepoRuleset(ctx context.Context, owner, repo string, id int64) (*github.Ruleset, error) {
+	// GET /repos/{owner}/{repo}/rulesets/{ruleset_id}
+	apiObj, _, err := c.c.Repositories.GetRuleset(ctx, owner, repo, id, false)
+	if err != nil {
+		return nil, handleHTTPError(err)
 	}
-
-	// GET /repos/{owner}/{repo}/commits
-	pageObjs, _, listErr := c.c.Repositories.ListCommits(ctx, owner, repo, lcOpts)
-	for _, c := range pageObjs {
-		apiObjs = append(apiObjs, &github.Commit{
-			SHA: c.SHA,
-			Tree: &github.Tree{
-				SHA: c.Commit.Tree.SHA,
-			},
-			Author:  c.Commit.Author,
-			Message: c.Commit.Message,
-			URL:     c.HTMLURL,
-		})
+	if err := validateRulesetAPI(apiObj); err != nil {
+		return nil, err
 	}
+	return apiObj, nil
+}
 
-	if listErr != nil {
-		return nil, listErr
+func (c *githubClientImpl) GetRepoRulesetRuleParameters(ctx context.Context, owner, repo string, id int64, ruleType string) (json.RawMessage, error) {
+	// GET /repos/{owner}/{repo}/rulesets/{ruleset_id}
+	req, err := c.c.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/rulesets/%d", owner, repo, id), nil)
+	if err != nil {
+		return nil, err
 	}
-	return apiObjs, nil
+	apiObj := struct {
+		Rules []struct {
+			Type       string          `json:"type"`
+			Parameters json.RawMessage `json:"parameters"`
+		} `json:"rules"`
+	}{}
+	if _, err := c.c.Do(ctx, req, &apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	for _, rule := range apiObj.Rules {
+		if rule.Type == ruleType {
+			return rule.Parameters, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *githubClientImpl) CreateRepoRuleset(ctx context.Context, owner, repo string, req *github.Ruleset) (*github.Ruleset, error) {
+	// POST /repos/{owner}/{repo}/rulesets
+	apiObj, _, err := c.c.Repositories.CreateRuleset(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateRulesetAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) UpdateRepoRuleset(ctx context.Context, owner, repo string, id int64, req *github.Ruleset) (*github.Ruleset, error) {
+	// PUT /repos/{owner}/{repo}/rulesets/{ruleset_id}
+	apiObj, _, err := c.c.Repositories.UpdateRuleset(ctx, owner, repo, id, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateRulesetAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteRepoRuleset(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/rulesets/{ruleset_id}
+	_, err := c.c.Repositories.DeleteRuleset(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetEnvironment(ctx context.Context, owner, repo, name string) (*github.Environment, error) {
+	// GET /repos/{owner}/{repo}/environments/{environment_name}
+	apiObj, _, err := c.c.Repositories.GetEnvironment(ctx, owner, repo, name)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) UpdateEnvironment(ctx context.Context, owner, repo, name string, req *github.CreateUpdateEnvironment) error {
+	// PUT /repos/{owner}/{repo}/environments/{environment_name}
+	_, _, err := c.c.Repositories.CreateUpdateEnvironment(ctx, owner, repo, name, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListEnvSecrets(ctx context.Context, repoID int64, environment string) ([]*github.Secret, error) {
+	apiObjs := []*github.Secret{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repositories/{repository_id}/environments/{environment_name}/secrets
+		pageObj, resp, listErr := c.c.Actions.ListEnvSecrets(ctx, int(repoID), environment, opts)
+		if pageObj != nil {
+			apiObjs = append(apiObjs, pageObj.Secrets...)
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListDeploymentBranchPolicies(ctx context.Context, owner, repo, environment string) ([]*github.DeploymentBranchPolicy, error) {
+	// GET /repos/{owner}/{repo}/environments/{environment_name}/deployment-branch-policies
+	apiObj, _, err := c.c.Repositories.ListDeploymentBranchPolicies(ctx, owner, repo, environment)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj.BranchPolicies, nil
+}
+
+func (c *githubClientImpl) CreateDeploymentBranchPolicy(ctx context.Context, owner, repo, environment string, req *github.DeploymentBranchPolicyRequest) error {
+	// POST /repos/{owner}/{repo}/environments/{environment_name}/deployment-branch-policies
+	_, _, err := c.c.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repo, environment, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) DeleteDeploymentBranchPolicy(ctx context.Context, owner, repo, environment string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/environments/{environment_name}/deployment-branch-policies/{branch_policy_id}
+	_, err := c.c.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repo, environment, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListDeploymentProtectionRules(ctx context.Context, owner, repo, environment string) ([]*github.CustomDeploymentProtectionRule, error) {
+	// GET /repos/{owner}/{repo}/environments/{environment_name}/deployment_protection_rules
+	apiObj, _, err := c.c.Repositories.GetAllDeploymentProtectionRules(ctx, owner, repo, environment)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj.ProtectionRules, nil
+}
+
+func (c *githubClientImpl) CreateDeploymentProtectionRule(ctx context.Context, owner, repo, environment string, appID int64) error {
+	// POST /repos/{owner}/{repo}/environments/{environment_name}/deployment_protection_rules
+	_, _, err := c.c.Repositories.CreateCustomDeploymentProtectionRule(ctx, owner, repo, environment, &github.CustomDeploymentProtectionRuleRequest{
+		IntegrationID: &appID,
+	})
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) DisableDeploymentProtectionRule(ctx context.Context, owner, repo, environment string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/environments/{environment_name}/deployment_protection_rules/{protection_rule_id}
+	_, err := c.c.Repositories.DisableCustomDeploymentProtectionRule(ctx, owner, repo, environment, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetWorkflow(ctx context.Context, owner, repo, workflow string) (*github.Workflow, error) {
+	// GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}
+	var apiObj *github.Workflow
+	var err error
+	if id, ok := workflowID(workflow); ok {
+		apiObj, _, err = c.c.Actions.GetWorkflowByID(ctx, owner, repo, id)
+	} else {
+		apiObj, _, err = c.c.Actions.GetWorkflowByFileName(ctx, owner, repo, workflow)
+	}
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DispatchWorkflow(ctx context.Context, owner, repo, workflow string, req github.CreateWorkflowDispatchEventRequest) error {
+	// POST /repos/{owner}/{repo}/actions/workflows/{workflow_id}/dispatches
+	var err error
+	if id, ok := workflowID(workflow); ok {
+		_, err = c.c.Actions.CreateWorkflowDispatchEventByID(ctx, owner, repo, id, req)
+	} else {
+		_, err = c.c.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflow, req)
+	}
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListWorkflowRunsPage(ctx context.Context, owner, repo, workflow string, opts *github.ListWorkflowRunsOptions) ([]*github.WorkflowRun, error) {
+	// GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}/runs
+	var runs *github.WorkflowRuns
+	var err error
+	if id, ok := workflowID(workflow); ok {
+		runs, _, err = c.c.Actions.ListWorkflowRunsByID(ctx, owner, repo, id, opts)
+	} else {
+		runs, _, err = c.c.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflow, opts)
+	}
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return runs.WorkflowRuns, nil
+}
+
+func (c *githubClientImpl) ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64) ([]*github.WorkflowJob, error) {
+	apiObjs := []*github.WorkflowJob{}
+	opts := &github.ListWorkflowJobsOptions{}
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/actions/runs/{run_id}/jobs
+		jobs, resp, listErr := c.c.Actions.ListWorkflowJobs(ctx, owner, repo, runID, opts)
+		if jobs != nil {
+			apiObjs = append(apiObjs, jobs.Jobs...)
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) DownloadWorkflowJobLogs(ctx context.Context, owner, repo string, jobID int64, w io.Writer) error {
+	// GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs
+	logsURL, _, err := c.c.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 1)
+	if err != nil {
+		return handleHTTPError(err)
+	}
+	// The logs are served from a pre-signed URL, which rejects requests carrying the credentials
+	// of the client
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logsURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.downloadClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s downloading the logs of job %d", resp.Status, jobID)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (c *githubClientImpl) GetRepoDefaultWorkflowPermissions(ctx context.Context, owner, repo string) (*github.DefaultWorkflowPermissionRepository, error) {
+	// GET /repos/{owner}/{repo}/actions/permissions/workflow
+	apiObj, _, err := c.c.Repositories.GetDefaultWorkflowPermissions(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditRepoDefaultWorkflowPermissions(ctx context.Context, owner, repo string, req *github.DefaultWorkflowPermissionRepository) error {
+	// PUT /repos/{owner}/{repo}/actions/permissions/workflow
+	_, _, err := c.c.Repositories.EditDefaultWorkflowPermissions(ctx, owner, repo, *req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetRepoCodeScanningDefaultSetup(ctx context.Context, owner, repo string) (*github.DefaultSetupConfiguration, error) {
+	// GET /repos/{owner}/{repo}/code-scanning/default-setup
+	apiObj, _, err := c.c.CodeScanning.GetDefaultSetupConfiguration(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) UpdateRepoCodeScanningDefaultSetup(ctx context.Context, owner, repo string, req *github.UpdateDefaultSetupConfigurationOptions) error {
+	// PATCH /repos/{owner}/{repo}/code-scanning/default-setup
+	_, _, err := c.c.CodeScanning.UpdateDefaultSetupConfiguration(ctx, owner, repo, req)
+	var acceptedErr *github.AcceptedError
+	if errors.As(err, &acceptedErr) {
+		return nil
+	}
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListRepoCommitActivity(ctx context.Context, owner, repo string) ([]*github.WeeklyCommitActivity, error) {
+	// GET /repos/{owner}/{repo}/stats/commit_activity
+	apiObjs, _, err := c.c.Repositories.ListCommitActivity(ctx, owner, repo)
+	var acceptedErr *github.AcceptedError
+	if errors.As(err, &acceptedErr) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// An empty repository has no statistics, which mustn't be mistaken for pending ones
+	if apiObjs == nil {
+		apiObjs = []*github.WeeklyCommitActivity{}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListOrgCustomProperties(ctx context.Context, org string) ([]*github.CustomProperty, error) {
+	// GET /orgs/{org}/properties/schema
+	apiObjs, _, err := c.c.Organizations.GetAllCustomProperties(ctx, org)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetRepoCustomPropertyValues(ctx context.Context, owner, repo string) ([]*github.CustomPropertyValue, error) {
+	// GET /repos/{owner}/{repo}/properties/values
+	apiObjs, _, err := c.c.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) UpdateRepoCustomPropertyValues(ctx context.Context, owner, repo string, values []*github.CustomPropertyValue) error {
+	// PATCH /repos/{owner}/{repo}/properties/values
+	_, err := c.c.Repositories.CreateOrUpdateCustomProperties(ctx, owner, repo, values)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetRepoInteractionLimits(ctx context.Context, owner, repo string) (*github.InteractionRestriction, error) {
+	// GET /repos/{owner}/{repo}/interaction-limits
+	apiObj, _, err := c.c.Interactions.GetRestrictionsForRepo(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) SetRepoInteractionLimits(ctx context.Context, owner, repo, limit, expiry string) (*github.InteractionRestriction, error) {
+	// PUT /repos/{owner}/{repo}/interaction-limits
+	// go-github doesn't send the expiry, so the request is built here
+	req, err := c.c.NewRequest(http.MethodPut, fmt.Sprintf("repos/%s/%s/interaction-limits", owner, repo), map[string]string{
+		"limit":  limit,
+		"expiry": expiry,
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiObj := &github.InteractionRestriction{}
+	if _, err := c.c.Do(ctx, req, apiObj); err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) RemoveRepoInteractionLimits(ctx context.Context, owner, repo string) error {
+	// DELETE /repos/{owner}/{repo}/interaction-limits
+	_, err := c.c.Interactions.RemoveRestrictionsFromRepo(ctx, owner, repo)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetPages(ctx context.Context, owner, repo string) (*github.Pages, error) {
+	// GET /repos/{owner}/{repo}/pages
+	apiObj, _, err := c.c.Repositories.GetPagesInfo(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EnablePages(ctx context.Context, owner, repo string, req *github.Pages) error {
+	// POST /repos/{owner}/{repo}/pages
+	_, _, err := c.c.Repositories.EnablePages(ctx, owner, repo, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) UpdatePages(ctx context.Context, owner, repo string, req *github.PagesUpdate) error {
+	// PUT /repos/{owner}/{repo}/pages
+	_, err := c.c.Repositories.UpdatePages(ctx, owner, repo, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListTrafficClones(ctx context.Context, owner, repo string) (*github.TrafficClones, error) {
+	// GET /repos/{owner}/{repo}/traffic/clones
+	apiObj, _, err := c.c.Repositories.ListTrafficClones(ctx, owner, repo, &github.TrafficBreakdownOptions{Per: "day"})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListTrafficViews(ctx context.Context, owner, repo string) (*github.TrafficViews, error) {
+	// GET /repos/{owner}/{repo}/traffic/views
+	apiObj, _, err := c.c.Repositories.ListTrafficViews(ctx, owner, repo, &github.TrafficBreakdownOptions{Per: "day"})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListTrafficPaths(ctx context.Context, owner, repo string) ([]*github.TrafficPath, error) {
+	// GET /repos/{owner}/{repo}/traffic/popular/paths
+	apiObjs, _, err := c.c.Repositories.ListTrafficPaths(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListRepoTopics(ctx context.Context, owner, repo string) ([]string, error) {
+	// GET /repos/{owner}/{repo}/topics
+	topics, _, err := c.c.Repositories.ListAllTopics(ctx, owner, repo)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return topics, nil
+}
+
+func (c *githubClientImpl) ReplaceRepoTopics(ctx context.Context, owner, repo string, topics []string) error {
+	// PUT /repos/{owner}/{repo}/topics
+	_, _, err := c.c.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListRepoCollaborators(ctx context.Context, owner, repo, affiliation string) ([]*github.User, error) {
+	apiObjs := []*github.User{}
+	opts := &github.ListCollaboratorsOptions{Affiliation: affiliation}
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/collaborators
+		pageObjs, resp, listErr := c.c.Repositories.ListCollaborators(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CountRepoPullRequests(ctx context.Context, owner, repo, state string) (int, error) {
+	// GET /repos/{owner}/{repo}/pulls
+	return countItems(func(opts *github.ListOptions) (int, *github.Response, error) {
+		apiObjs, resp, err := c.c.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State:       state,
+			ListOptions: *opts,
+		})
+		return len(apiObjs), resp, err
+	})
+}
+
+func (c *githubClientImpl) CountRepoBranches(ctx context.Context, owner, repo string) (int, error) {
+	// GET /repos/{owner}/{repo}/branches
+	return countItems(func(opts *github.ListOptions) (int, *github.Response, error) {
+		apiObjs, resp, err := c.c.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{
+			ListOptions: *opts,
+		})
+		return len(apiObjs), resp, err
+	})
+}
+
+func (c *githubClientImpl) CountRepoTags(ctx context.Context, owner, repo string) (int, error) {
+	// GET /repos/{owner}/{repo}/tags
+	return countItems(func(opts *github.ListOptions) (int, *github.Response, error) {
+		apiObjs, resp, err := c.c.Repositories.ListTags(ctx, owner, repo, opts)
+		return len(apiObjs), resp, err
+	})
+}
+
+func (c *githubClientImpl) ListKeys(ctx context.Context, owner, repo string) ([]*github.Key, error) {
+	apiObjs := []*github.Key{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/keys
+		pageObjs, resp, listErr := c.c.Repositories.ListKeys(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateDeployKeyAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListMilestones(ctx context.Context, owner, repo string) ([]*github.Milestone, error) {
+	apiObjs := []*github.Milestone{}
+	opts := &github.MilestoneListOptions{State: "all"}
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/milestones
+		pageObjs, resp, listErr := c.c.Issues.ListMilestones(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateMilestoneAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateMilestone(ctx context.Context, owner, repo string, req *github.Milestone) (*github.Milestone, error) {
+	// POST /repos/{owner}/{repo}/milestones
+	apiObj, _, err := c.c.Issues.CreateMilestone(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateMilestoneAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) UpdateMilestone(ctx context.Context, owner, repo string, number int, req *github.Milestone) (*github.Milestone, error) {
+	// PATCH /repos/{owner}/{repo}/milestones/{milestone_number}
+	apiObj, _, err := c.c.Issues.EditMilestone(ctx, owner, repo, number, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateMilestoneAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListReleases(ctx context.Context, owner, repo string) ([]*github.RepositoryRelease, error) {
+	apiObjs := []*github.RepositoryRelease{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/releases
+		pageObjs, resp, listErr := c.c.Repositories.ListReleases(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateReleaseAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, error) {
+	// GET /repos/{owner}/{repo}/releases/tags/{tag}
+	apiObj, _, err := c.c.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) CreateRelease(ctx context.Context, owner, repo string, req *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	// POST /repos/{owner}/{repo}/releases
+	apiObj, _, err := c.c.Repositories.CreateRelease(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleCreateReleaseHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) EditRelease(ctx context.Context, owner, repo string, id int64, req *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+	// PATCH /repos/{owner}/{repo}/releases/{release_id}
+	apiObj, _, err := c.c.Repositories.EditRelease(ctx, owner, repo, id, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateReleaseAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteRelease(ctx context.Context, owner, repo string, id int64) error {
+	// DELETE /repos/{owner}/{repo}/releases/{release_id}
+	_, err := c.c.Repositories.DeleteRelease(ctx, owner, repo, id)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListLabels(ctx context.Context, owner, repo string) ([]*github.Label, error) {
+	apiObjs := []*github.Label{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/labels
+		pageObjs, resp, listErr := c.c.Issues.ListLabels(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateLabel(ctx context.Context, owner, repo string, req *github.Label) error {
+	// POST /repos/{owner}/{repo}/labels
+	_, _, err := c.c.Issues.CreateLabel(ctx, owner, repo, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) EditLabel(ctx context.Context, owner, repo, name string, req *github.Label) error {
+	// PATCH /repos/{owner}/{repo}/labels/{name}
+	_, _, err := c.c.Issues.EditLabel(ctx, owner, repo, name, req)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetUser(ctx context.Context) (*github.User, error) {
+	// GET /user
+	user, _, err := c.c.Users.Get(ctx, "")
+	return user, err
+}
+
+func (c *githubClientImpl) GetUserByLogin(ctx context.Context, login string) (*github.User, error) {
+	// GET /users/{username}
+	user, _, err := c.c.Users.Get(ctx, login)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return user, nil
+}
+
+func (c *githubClientImpl) ListCommitsPage(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.Commit, error) {
+	apiObjs := make([]*github.Commit, 0)
+
+	// GET /repos/{owner}/{repo}/commits
+	pageObjs, _, listErr := c.c.Repositories.ListCommits(ctx, owner, repo, opts)
+	for _, c := range pageObjs {
+		apiObjs = append(apiObjs, &github.Commit{
+			SHA: c.SHA,
+			Tree: &github.Tree{
+				SHA: c.Commit.Tree.SHA,
+			},
+			Author:  c.Commit.Author,
+			Message: c.Commit.Message,
+			URL:     c.HTMLURL,
+			Parents: c.Parents,
+		})
+	}
+
+	if listErr != nil {
+		return nil, listErr
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListPullRequestCommits(ctx context.Context, owner, repo string, number int) ([]*github.Commit, error) {
+	apiObjs := []*github.Commit{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls/{pull_number}/commits
+		pageObjs, resp, listErr := c.c.PullRequests.ListCommits(ctx, owner, repo, number, opts)
+		for _, c := range pageObjs {
+			apiObjs = append(apiObjs, &github.Commit{
+				SHA: c.SHA,
+				Tree: &github.Tree{
+					SHA: c.Commit.Tree.SHA,
+				},
+				Author:  c.Commit.Author,
+				Message: c.Commit.Message,
+				URL:     c.HTMLURL,
+				Parents: c.Parents,
+			})
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	apiObjs := []*github.PullRequestReview{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+		pageObjs, resp, listErr := c.c.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreatePullRequestReview(ctx context.Context, owner, repo string, number int, req *github.PullRequestReviewRequest) (*github.PullRequestReview, error) {
+	// POST /repos/{owner}/{repo}/pulls/{pull_number}/reviews
+	apiObj, _, err := c.c.PullRequests.CreateReview(ctx, owner, repo, number, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error) {
+	apiObjs := []*github.IssueComment{}
+	opts := &github.IssueListCommentsOptions{}
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/issues/{issue_number}/comments
+		pageObjs, resp, listErr := c.c.Issues.ListComments(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*github.IssueComment, error) {
+	// POST /repos/{owner}/{repo}/issues/{issue_number}/comments
+	apiObj, _, err := c.c.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: &body})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) error {
+	// POST /repos/{owner}/{repo}/issues/{issue_number}/labels
+	_, _, err := c.c.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) ListLabelsByIssue(ctx context.Context, owner, repo string, number int) ([]*github.Label, error) {
+	apiObjs := []*github.Label{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/issues/{issue_number}/labels
+		pageObjs, resp, listErr := c.c.Issues.ListLabelsByIssue(ctx, owner, repo, number, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string) ([]*github.CheckRun, error) {
+	apiObjs := []*github.CheckRun{}
+	opts := &github.ListCheckRunsOptions{}
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/commits/{ref}/check-runs
+		result, resp, listErr := c.c.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+		if result != nil {
+			apiObjs = append(apiObjs, result.CheckRuns...)
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListCheckRunAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]*github.CheckRunAnnotation, error) {
+	apiObjs := []*github.CheckRunAnnotation{}
+	opts := &github.ListOptions{}
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/check-runs/{check_run_id}/annotations
+		pageObjs, resp, listErr := c.c.Checks.ListCheckRunAnnotations(ctx, owner, repo, checkRunID, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) ListBranches(ctx context.Context, owner, repo string) ([]*github.Branch, error) {
+	apiObjs := []*github.Branch{}
+	opts := &github.BranchListOptions{}
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/branches
+		pageObjs, resp, listErr := c.c.Repositories.ListBranches(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetCompareStatus(ctx context.Context, owner, repo, base, head string) (string, error) {
+	// GET /repos/{owner}/{repo}/compare/{base}...{head}
+	// Only the status is needed, so don't list more than one commit
+	apiObj, _, err := c.c.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return "", handleHTTPError(err)
+	}
+	return apiObj.GetStatus(), nil
+}
+
+func (c *githubClientImpl) CompareCommits(ctx context.Context, owner, repo, base, head string) (*github.CommitsComparison, error) {
+	// GET /repos/{owner}/{repo}/compare/{base}...{head}
+	apiObj, _, err := c.c.Repositories.CompareCommits(ctx, owner, repo, base, head, nil)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) GetCommitSignature(ctx context.Context, owner, repo, sha string) (string, error) {
+	// GET /repos/{owner}/{repo}/commits/{ref}
+	apiObj, _, err := c.c.Repositories.GetCommit(ctx, owner, repo, sha, nil)
+	if err != nil {
+		return "", handleRefHTTPError(err)
+	}
+	return apiObj.GetCommit().GetVerification().GetSignature(), nil
+}
+
+func (c *githubClientImpl) GetCommitSHA(ctx context.Context, owner, repo, ref string) (string, error) {
+	// GET /repos/{owner}/{repo}/commits/{ref}
+	sha, _, err := c.c.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+	if err != nil {
+		return "", handleRefHTTPError(err)
+	}
+	return sha, nil
+}
+
+func (c *githubClientImpl) ListTagRefs(ctx context.Context, owner, repo string) ([]*github.Reference, error) {
+	apiObjs := []*github.Reference{}
+	opts := &github.ReferenceListOptions{Ref: "tags"}
+	err := allPages(ctx, &opts.ListOptions, func(ctx context.Context) (*github.Response, error) {
+		// GET /repos/{owner}/{repo}/git/matching-refs/tags
+		pageObjs, resp, listErr := c.c.Git.ListMatchingRefs(ctx, owner, repo, opts)
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *githubClientImpl) GetTag(ctx context.Context, owner, repo, sha string) (*github.Tag, error) {
+	// GET /repos/{owner}/{repo}/git/tags/{tag_sha}
+	apiObj, _, err := c.c.Git.GetTag(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) GetTagRef(ctx context.Context, owner, repo, name string) (*github.Reference, error) {
+	// GET /repos/{owner}/{repo}/git/ref/tags/{tag}
+	apiObj, _, err := c.c.Git.GetRef(ctx, owner, repo, "tags/"+name)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) CreateTag(ctx context.Context, owner, repo string, req *github.Tag) (*github.Tag, error) {
+	// POST /repos/{owner}/{repo}/git/tags
+	apiObj, _, err := c.c.Git.CreateTag(ctx, owner, repo, req)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) CreateTagRef(ctx context.Context, owner, repo, name, sha string) (*github.Reference, error) {
+	// POST /repos/{owner}/{repo}/git/refs
+	apiObj, _, err := c.c.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/tags/" + name),
+		Object: &github.GitObject{SHA: &sha},
+	})
+	if err != nil {
+		return nil, handleCreateRefHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *githubClientImpl) DeleteTagRef(ctx context.Context, owner, repo, name string) error {
+	// DELETE /repos/{owner}/{repo}/git/refs/tags/{name}
+	_, err := c.c.Git.DeleteRef(ctx, owner, repo, "tags/"+name)
+	return handleHTTPError(err)
+}
+
+func (c *githubClientImpl) GetGitCommit(ctx context.Context, owner, repo, sha string) (*github.Commit, error) {
+	// GET /repos/{owner}/{repo}/git/commits/{commit_sha}
+	apiObj, _, err := c.c.Git.GetCommit(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
 }
 
 func (c *githubClientImpl) CreateKey(ctx context.Context, owner, repo string, req *github.Key) (*github.Key, error) {
@@ -371,7 +1750,7 @@ func (c *githubClientImpl) GetTeamPermissions(ctx context.Context, orgName, repo
 func (c *githubClientImpl) ListRepoTeams(ctx context.Context, orgName, repo string) ([]*github.Team, error) {
 	apiObjs := []*github.Team{}
 	opts := &github.ListOptions{}
-	err := allPages(opts, func() (*github.Response, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*github.Response, error) {
 		// GET /repos/{owner}/{repo}/teams
 		pageObjs, resp, listErr := c.c.Repositories.ListTeams(ctx, orgName, repo, opts)
 		apiObjs = append(apiObjs, pageObjs...)
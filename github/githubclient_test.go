@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// setup returns a Client talking to a local HTTP server, and the mux of that
+// server for registering the handlers of the endpoints under test.
+func setup(t testing.TB) (*http.ServeMux, *Client) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("unexpected error while parsing the server URL: %v", err)
+	}
+	ghClient := github.NewClient(nil)
+	ghClient.BaseURL = baseURL
+	ghClient.UploadURL = baseURL
+
+	return mux, newClient(ghClient, DefaultDomain, true, &http.Client{})
+}
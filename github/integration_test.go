@@ -368,6 +368,26 @@ var _ = Describe("GitHub Provider", func() {
 		Expect(errors.Is(err, gitprovider.ErrAlreadyExists)).To(BeTrue())
 	})
 
+	It("should be possible to rename an org repository", func() {
+		repoRef := newOrgRepoRef(testOrgName, testOrgRepoName)
+		repo, err := c.OrgRepositories().Get(ctx, repoRef)
+		Expect(err).ToNot(HaveOccurred())
+
+		renamedRef := newOrgRepoRef(testOrgName, testOrgRepoName+"-renamed")
+		renamed, err := repo.Rename(ctx, renamedRef.RepositoryName)
+		Expect(err).ToNot(HaveOccurred())
+		validateRepo(renamed, renamedRef)
+
+		getRepo, err := c.OrgRepositories().Get(ctx, renamedRef)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getRepo.Get().Description).To(Equal(repo.Get().Description))
+
+		// Rename it back, so the following tests find the repository under its original name
+		renamed, err = renamed.Rename(ctx, testOrgRepoName)
+		Expect(err).ToNot(HaveOccurred())
+		validateRepo(renamed, repoRef)
+	})
+
 	It("should update if the repository already exists when reconciling", func() {
 		repoRef := newOrgRepoRef(testOrgName, testOrgRepoName)
 		// No-op reconcile
@@ -437,6 +457,42 @@ var _ = Describe("GitHub Provider", func() {
 		Expect(actionTaken).To(BeTrue())
 	})
 
+	It("should be possible to create an org repository with settings", func() {
+		repoRef := newOrgRepoRef(testOrgName, testOrgRepoName+"-settings")
+		settings := gitprovider.RepositorySettings{
+			Topics: []string{"flux", "gitops"},
+			WorkflowPermissions: &gitprovider.WorkflowPermissionsInfo{
+				DefaultPermission:            gitprovider.WorkflowPermissionVar(gitprovider.WorkflowPermissionRead),
+				CanApprovePullRequestReviews: gitprovider.BoolVar(false),
+			},
+			MergeQueues: map[string]gitprovider.MergeQueueInfo{
+				defaultBranch: {Enabled: true},
+			},
+		}
+		repo, err := gitprovider.CreateWithSettings(ctx, c.OrgRepositories(), repoRef, gitprovider.RepositoryInfo{
+			Description: gitprovider.StringVar(defaultDescription),
+		}, settings, &gitprovider.RepositoryCreateOptions{
+			AutoInit: gitprovider.BoolVar(true),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		validateRepo(repo, repoRef)
+		defer func() { Expect(repo.Delete(ctx)).ToNot(HaveOccurred()) }()
+
+		topicsClient, err := repo.Topics()
+		Expect(err).ToNot(HaveOccurred())
+		topics, err := topicsClient.Get(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(topics).To(ConsistOf(settings.Topics))
+
+		// Reconciling the same settings again must be a no-op
+		actionTaken, err := repo.ReconcileWorkflowPermissions(ctx, *settings.WorkflowPermissions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actionTaken).To(BeFalse())
+		actionTaken, err = repo.ReconcileMergeQueue(ctx, defaultBranch, settings.MergeQueues[defaultBranch])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actionTaken).To(BeFalse())
+	})
+
 	It("should validate that the token has the correct permissions", func() {
 		hasPermission, err := c.HasTokenPermission(ctx, 0)
 		Expect(err).To(Equal(gitprovider.ErrNoProviderSupport))
@@ -465,7 +521,7 @@ var _ = Describe("GitHub Provider", func() {
 		retryOp = testutils.NewRetry()
 		Eventually(func() bool {
 			var err error
-			commits, err = userRepo.Commits().ListPage(ctx, *defaultBranch, 1, 0)
+			commits, err = userRepo.Commits().ListPage(ctx, *defaultBranch, 1, 1)
 			if err == nil && len(commits) == 0 {
 				err = errors.New("empty commits list")
 			}
@@ -553,6 +609,46 @@ var _ = Describe("GitHub Provider", func() {
 		Expect(*githubPR.Title).To(Equal("a new title"))
 	})
 
+	It("should be possible to publish a release", func() {
+		userRepoRef := newUserRepoRef(testUser, testUserRepoName)
+
+		userRepo, err := c.UserRepositories().Get(ctx, userRepoRef)
+		Expect(err).ToNot(HaveOccurred())
+		releases, err := userRepo.Releases()
+		Expect(err).ToNot(HaveOccurred())
+
+		version := "1.0.0\n"
+		release := gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", Body: "First release"}
+		result, err := releases.Publish(ctx, gitprovider.PublishOptions{
+			Files:         []gitprovider.CommitFile{{Path: gitprovider.StringVar("VERSION"), Content: &version}},
+			Branch:        *userRepo.Get().DefaultBranch,
+			CommitMessage: "Release v1.0.0",
+			Release:       release,
+			Rollback:      true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.CommitCreated).To(BeTrue())
+		Expect(result.TagCreated).To(BeTrue())
+		Expect(result.ReleaseCreated).To(BeTrue())
+
+		tag, err := userRepo.Tags().Get(ctx, "v1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tag.SHA).To(Equal(result.CommitSHA))
+		Expect(tag.Annotated).To(BeTrue())
+
+		got, err := releases.Get(ctx, "v1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(got.Name).To(Equal(release.Name))
+		Expect(got.Body).To(Equal(release.Body))
+
+		// Publishing the same tag again fails before creating the release
+		_, err = releases.Publish(ctx, gitprovider.PublishOptions{Ref: result.CommitSHA, Release: release})
+		var publishErr *gitprovider.PublishError
+		Expect(errors.As(err, &publishErr)).To(BeTrue())
+		Expect(publishErr.Step).To(Equal(gitprovider.PublishStepTag))
+		Expect(errors.Is(err, gitprovider.ErrAlreadyExists)).To(BeTrue())
+	})
+
 	It("should be possible to download files from path and branch specified", func() {
 
 		userRepoRef := newUserRepoRef(testUser, testUserRepoName)
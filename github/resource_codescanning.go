@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// codeScanningStateConfigured is the state of an enabled code scanning default setup.
+	codeScanningStateConfigured = "configured"
+	// codeScanningStateNotConfigured is the state of a disabled code scanning default setup.
+	codeScanningStateNotConfigured = "not-configured"
+)
+
+func codeScanningDefaultSetupFromAPI(apiObj *github.DefaultSetupConfiguration) gitprovider.CodeScanningDefaultSetupInfo {
+	info := gitprovider.CodeScanningDefaultSetupInfo{
+		Enabled:   apiObj.GetState() == codeScanningStateConfigured,
+		Languages: apiObj.Languages,
+	}
+	if apiObj.QuerySuite != nil {
+		info.QuerySuite = gitprovider.CodeScanningQuerySuiteVar(gitprovider.CodeScanningQuerySuite(*apiObj.QuerySuite))
+	}
+	return info
+}
+
+// codeScanningDefaultSetupToAPI converts the defaulted req to its API object.
+func codeScanningDefaultSetupToAPI(req gitprovider.CodeScanningDefaultSetupInfo) *github.UpdateDefaultSetupConfigurationOptions {
+	if !req.Enabled {
+		return &github.UpdateDefaultSetupConfigurationOptions{State: codeScanningStateNotConfigured}
+	}
+	return &github.UpdateDefaultSetupConfigurationOptions{
+		State:      codeScanningStateConfigured,
+		QuerySuite: github.String(string(*req.QuerySuite)),
+		Languages:  req.Languages,
+	}
+}
@@ -45,7 +45,7 @@ func (c *commitType) APIObject() interface{} {
 }
 
 func commitFromAPI(apiObj *github.Commit) gitprovider.CommitInfo {
-	return gitprovider.CommitInfo{
+	info := gitprovider.CommitInfo{
 		Sha:       *apiObj.SHA,
 		TreeSha:   *apiObj.Tree.SHA,
 		Author:    *apiObj.Author.Name,
@@ -53,4 +53,8 @@ func commitFromAPI(apiObj *github.Commit) gitprovider.CommitInfo {
 		CreatedAt: *apiObj.Author.Date.GetTime(),
 		URL:       *apiObj.URL,
 	}
+	for _, parent := range apiObj.Parents {
+		info.Parents = append(info.Parents, parent.GetSHA())
+	}
+	return info
 }
@@ -155,11 +155,17 @@ func validateDeployKeyAPI(apiObj *github.Key) error {
 }
 
 func deployKeyFromAPI(apiObj *github.Key) gitprovider.DeployKeyInfo {
-	return gitprovider.DeployKeyInfo{
+	info := gitprovider.DeployKeyInfo{
 		Name:     *apiObj.Title,
 		Key:      []byte(*apiObj.Key),
 		ReadOnly: apiObj.ReadOnly,
 	}
+	// GitHub reports when the key was last used, but not from where
+	if apiObj.LastUsed != nil {
+		info.LastUsed = &apiObj.LastUsed.Time
+	}
+	info.ComputeFingerprint()
+	return info
 }
 
 func deployKeyToAPI(info *gitprovider.DeployKeyInfo) *github.Key {
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// deploymentBranchPolicyTypeBranch is the type of the deployment branch policies matching branches.
+	deploymentBranchPolicyTypeBranch = "branch"
+	// deploymentBranchPolicyTypeTag is the type of the deployment branch policies matching tags.
+	deploymentBranchPolicyTypeTag = "tag"
+
+	// environmentRuleTypeWaitTimer is the type of the protection rule delaying deployments.
+	environmentRuleTypeWaitTimer = "wait_timer"
+	// environmentRuleTypeRequiredReviewers is the type of the protection rule requiring reviews of deployments.
+	environmentRuleTypeRequiredReviewers = "required_reviewers"
+)
+
+// deploymentBranchPolicyKey identifies a deployment branch policy by its type and name pattern.
+type deploymentBranchPolicyKey struct {
+	policyType string
+	pattern    string
+}
+
+// deploymentBranchPolicyKeyFromAPI returns the key of the policy. Older GitHub Enterprise servers
+// don't report the type of the policies, which only match branches there.
+func deploymentBranchPolicyKeyFromAPI(apiObj *github.DeploymentBranchPolicy) deploymentBranchPolicyKey {
+	policyType := apiObj.GetType()
+	if policyType == "" {
+		policyType = deploymentBranchPolicyTypeBranch
+	}
+	return deploymentBranchPolicyKey{policyType: policyType, pattern: apiObj.GetName()}
+}
+
+// deploymentBranchPolicyKeysOf returns the keys of the deployment branch policies described by
+// req, in order.
+func deploymentBranchPolicyKeysOf(req gitprovider.EnvironmentProtectionInfo) []deploymentBranchPolicyKey {
+	keys := make([]deploymentBranchPolicyKey, 0, len(req.BranchPatterns)+len(req.TagPatterns))
+	for _, pattern := range req.BranchPatterns {
+		keys = append(keys, deploymentBranchPolicyKey{policyType: deploymentBranchPolicyTypeBranch, pattern: pattern})
+	}
+	for _, pattern := range req.TagPatterns {
+		keys = append(keys, deploymentBranchPolicyKey{policyType: deploymentBranchPolicyTypeTag, pattern: pattern})
+	}
+	return keys
+}
+
+// deploymentBranchPolicyKeys returns the set of the keys of the deployment branch policies
+// described by req.
+func deploymentBranchPolicyKeys(req gitprovider.EnvironmentProtectionInfo) map[deploymentBranchPolicyKey]bool {
+	keys := map[deploymentBranchPolicyKey]bool{}
+	for _, key := range deploymentBranchPolicyKeysOf(req) {
+		keys[key] = true
+	}
+	return keys
+}
+
+func environmentProtectionFromAPI(policies []*github.DeploymentBranchPolicy, rules []*github.CustomDeploymentProtectionRule) gitprovider.EnvironmentProtectionInfo {
+	info := gitprovider.EnvironmentProtectionInfo{}
+	for _, policy := range policies {
+		key := deploymentBranchPolicyKeyFromAPI(policy)
+		if key.policyType == deploymentBranchPolicyTypeTag {
+			info.TagPatterns = append(info.TagPatterns, key.pattern)
+		} else {
+			info.BranchPatterns = append(info.BranchPatterns, key.pattern)
+		}
+	}
+	for _, rule := range rules {
+		info.CustomRuleAppIDs = append(info.CustomRuleAppIDs, rule.GetApp().GetID())
+	}
+	return info
+}
+
+// environmentToAPI returns the request updating the deployment branch policy of the environment
+// to branchPolicy. As the environment is replaced as a whole, its other settings are carried over
+// from its protection rules.
+func environmentToAPI(apiObj *github.Environment, branchPolicy *github.BranchPolicy) *github.CreateUpdateEnvironment {
+	req := &github.CreateUpdateEnvironment{
+		CanAdminsBypass:        apiObj.CanAdminsBypass,
+		DeploymentBranchPolicy: branchPolicy,
+	}
+	for _, rule := range apiObj.ProtectionRules {
+		switch rule.GetType() {
+		case environmentRuleTypeWaitTimer:
+			req.WaitTimer = rule.WaitTimer
+		case environmentRuleTypeRequiredReviewers:
+			req.PreventSelfReview = rule.PreventSelfReview
+			for _, reviewer := range rule.Reviewers {
+				req.Reviewers = append(req.Reviewers, &github.EnvReviewers{
+					Type: reviewer.Type,
+					ID:   reviewerID(reviewer),
+				})
+			}
+		}
+	}
+	return req
+}
+
+// reviewerID returns the ID of the user or team required to review the deployments.
+func reviewerID(reviewer *github.RequiredReviewer) *int64 {
+	switch r := reviewer.Reviewer.(type) {
+	case *github.User:
+		return r.ID
+	case *github.Team:
+		return r.ID
+	}
+	return nil
+}
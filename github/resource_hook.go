@@ -0,0 +1,187 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newDeployHook(c *HookClient, hook *github.Hook) *deployHook {
+	h := &deployHook{
+		h: *hook,
+		c: c,
+	}
+	// GitHub returns the secret obfuscated, don't send that back when updating
+	if hook.Config != nil {
+		config := *hook.Config
+		config.Secret = nil
+		h.h.Config = &config
+	}
+	return h
+}
+
+var _ gitprovider.DeployHook = &deployHook{}
+
+type deployHook struct {
+	h github.Hook
+	c *HookClient
+}
+
+func (dh *deployHook) Get() gitprovider.DeployHookInfo {
+	return deployHookFromAPI(&dh.h)
+}
+
+func (dh *deployHook) Set(info gitprovider.DeployHookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return deployHookInfoToAPIObj(&info, &dh.h)
+}
+
+func (dh *deployHook) APIObject() interface{} {
+	return &dh.h
+}
+
+func (dh *deployHook) Repository() gitprovider.RepositoryRef {
+	return dh.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (dh *deployHook) Update(ctx context.Context) error {
+	if dh.h.ID == nil {
+		return fmt.Errorf("didn't expect ID to be nil: %w", gitprovider.ErrUnexpectedEvent)
+	}
+	// PATCH /repos/{owner}/{repo}/hooks/{hook_id}
+	apiObj, err := dh.c.c.EditRepoHook(ctx, dh.c.ref.GetIdentity(), dh.c.ref.GetRepository(), *dh.h.ID, orgWebhookSpec(&dh.h))
+	if err != nil {
+		return err
+	}
+	*dh = *newDeployHook(dh.c, apiObj)
+	return nil
+}
+
+// Delete deletes the webhook from the repository. Deleting a webhook requires destructive
+// API calls to be enabled, ErrDestructiveCallDisallowed is returned otherwise.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dh *deployHook) Delete(ctx context.Context) error {
+	// Don't allow deleting webhooks if the user didn't explicitly allow dangerous API calls.
+	if !dh.c.destructiveActions {
+		return fmt.Errorf("cannot delete webhook: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if dh.h.ID == nil {
+		return fmt.Errorf("didn't expect ID to be nil: %w", gitprovider.ErrUnexpectedEvent)
+	}
+	// DELETE /repos/{owner}/{repo}/hooks/{hook_id}
+	return dh.c.c.DeleteRepoHook(ctx, dh.c.ref.GetIdentity(), dh.c.ref.GetRepository(), *dh.h.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (dh *deployHook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := dh.c.get(ctx, dh.h.Config.GetURL())
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			// POST /repos/{owner}/{repo}/hooks
+			apiObj, err := dh.c.c.CreateRepoHook(ctx, dh.c.ref.GetIdentity(), dh.c.ref.GetRepository(), orgWebhookSpec(&dh.h))
+			if err != nil {
+				return true, err
+			}
+			*dh = *newDeployHook(dh.c, apiObj)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing. The secret is only known when set
+	// through Set, as newDeployHook drops the obfuscated one
+	desired := dh.Get()
+	if dh.h.Config != nil {
+		desired.Secret = dh.h.Config.Secret
+	}
+	if desired.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Otherwise, update the actual webhook with the desired state
+	dh.h.ID = actual.h.ID
+	return true, dh.Update(ctx)
+}
+
+func deployHookFromAPI(apiObj *github.Hook) gitprovider.DeployHookInfo {
+	info := gitprovider.DeployHookInfo{
+		URL:         apiObj.Config.GetURL(),
+		Events:      webhookEventsFromAPI(apiObj.Events),
+		ContentType: gitprovider.WebhookContentTypeVar(webhookDefaultContentType),
+		Active:      gitprovider.BoolVar(apiObj.GetActive()),
+	}
+	if contentType := apiObj.Config.GetContentType(); contentType != "" {
+		info.ContentType = gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentType(contentType))
+	}
+	return info
+}
+
+func deployHookToAPI(info *gitprovider.DeployHookInfo) (*github.Hook, error) {
+	h := &github.Hook{}
+	if err := deployHookInfoToAPIObj(info, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func deployHookInfoToAPIObj(info *gitprovider.DeployHookInfo, apiObj *github.Hook) error {
+	events, err := webhookEventsToAPI(info.Events, apiObj.Events)
+	if err != nil {
+		return err
+	}
+	apiObj.Name = github.String(webhookName)
+	apiObj.Events = events
+	if apiObj.Config == nil {
+		apiObj.Config = &github.HookConfig{}
+	}
+	apiObj.Config.URL = github.String(info.URL)
+	if info.ContentType != nil {
+		apiObj.Config.ContentType = github.String(string(*info.ContentType))
+	}
+	apiObj.Config.Secret = info.Secret
+	if info.Active != nil {
+		apiObj.Active = info.Active
+	}
+	return nil
+}
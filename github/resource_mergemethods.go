@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+const (
+	// mergeMethodsRulesetPrefix prefixes the name of the rulesets managed by ReconcileBranchMergeMethods.
+	mergeMethodsRulesetPrefix = "merge-methods-"
+	// pullRequestRuleType is the type of the ruleset rule requiring pull requests, which
+	// restricts their merge methods.
+	pullRequestRuleType = "pull_request"
+)
+
+// pullRequestRuleParameters are the parameters of a pull request rule. go-github doesn't know
+// the allowed merge methods yet.
+type pullRequestRuleParameters struct {
+	github.PullRequestRuleParameters
+	AllowedMergeMethods []string `json:"allowed_merge_methods,omitempty"`
+}
+
+// mergeMethodsRulesetName returns the name of the ruleset holding the merge methods of branch.
+func mergeMethodsRulesetName(branch string) string {
+	return mergeMethodsRulesetPrefix + branch
+}
+
+// pullRequestParamsFromAPI decodes the raw parameters of a pull request rule. nil is returned if
+// there are none, i.e. the ruleset has no pull request rule.
+func pullRequestParamsFromAPI(rawParams json.RawMessage) (*pullRequestRuleParameters, error) {
+	if rawParams == nil {
+		return nil, nil
+	}
+	params := &pullRequestRuleParameters{}
+	if err := json.Unmarshal(rawParams, params); err != nil {
+		return nil, fmt.Errorf("invalid pull request rule parameters: %v: %w", err, gitprovider.ErrInvalidServerData)
+	}
+	return params, nil
+}
+
+func branchMergeMethodsFromAPI(params *pullRequestRuleParameters) gitprovider.BranchMergeMethodsInfo {
+	methods := make([]gitprovider.MergeMethod, 0, len(params.AllowedMergeMethods))
+	for _, method := range params.AllowedMergeMethods {
+		methods = append(methods, gitprovider.MergeMethod(method))
+	}
+	return gitprovider.BranchMergeMethodsInfo{AllowedMethods: methods}
+}
+
+// mergeMethodsRulesetToAPI returns the ruleset restricting the merge methods of branch to those of
+// req. The other rules and settings of the existing ruleset, if any, are kept, as are the review
+// requirements of its pull request rule, given by existingParams.
+func mergeMethodsRulesetToAPI(branch string, req gitprovider.BranchMergeMethodsInfo, existing *github.Ruleset, existingParams *pullRequestRuleParameters) (*github.Ruleset, error) {
+	params := &pullRequestRuleParameters{}
+	if existingParams != nil {
+		params = existingParams
+	}
+	rules := []*github.RepositoryRule{}
+	var bypassActors []*github.BypassActor
+	if existing != nil {
+		for _, rule := range existing.Rules {
+			if rule.Type != pullRequestRuleType {
+				rules = append(rules, rule)
+			}
+		}
+		bypassActors = existing.BypassActors
+	}
+
+	params.AllowedMergeMethods = make([]string, 0, len(req.AllowedMethods))
+	for _, method := range req.AllowedMethods {
+		params.AllowedMergeMethods = append(params.AllowedMergeMethods, string(method))
+	}
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	rawMessage := json.RawMessage(rawParams)
+
+	return &github.Ruleset{
+		Name:         mergeMethodsRulesetName(branch),
+		Target:       github.String("branch"),
+		Enforcement:  rulesetEnforcementActive,
+		BypassActors: bypassActors,
+		Conditions: &github.RulesetConditions{
+			RefName: &github.RulesetRefConditionParameters{
+				Include: []string{"refs/heads/" + branch},
+				Exclude: []string{},
+			},
+		},
+		Rules: append(rules, &github.RepositoryRule{
+			Type:       pullRequestRuleType,
+			Parameters: &rawMessage,
+		}),
+	}, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// mergeQueueRulesetPrefix prefixes the name of the rulesets managed by ReconcileMergeQueue.
+	mergeQueueRulesetPrefix = "merge-queue-"
+	// mergeQueueRuleType is the type of the ruleset rule configuring a merge queue.
+	mergeQueueRuleType = "merge_queue"
+	// rulesetEnforcementActive is the enforcement of rulesets which are in effect.
+	rulesetEnforcementActive = "active"
+	// defaultMergeQueueBuildConcurrency is the number of queued pull requests GitHub builds
+	// at once by default. It's only set when creating a merge queue.
+	defaultMergeQueueBuildConcurrency = 5
+)
+
+// mergeQueueRulesetName returns the name of the ruleset holding the merge queue of branch.
+func mergeQueueRulesetName(branch string) string {
+	return mergeQueueRulesetPrefix + branch
+}
+
+func validateRulesetAPI(apiObj *github.Ruleset) error {
+	return validateAPIObject("GitHub.Ruleset", func(validator validation.Validator) {
+		// Make sure ID and name are populated, as per
+		// https://docs.github.com/en/rest/repos/rules#get-a-repository-ruleset
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+// mergeQueueParamsFromAPI returns the parameters of the merge queue rule of the ruleset, or nil
+// if the ruleset has no merge queue rule.
+func mergeQueueParamsFromAPI(apiObj *github.Ruleset) (*github.MergeQueueRuleParameters, error) {
+	for _, rule := range apiObj.Rules {
+		if rule.Type != mergeQueueRuleType {
+			continue
+		}
+		params := &github.MergeQueueRuleParameters{}
+		if rule.Parameters != nil {
+			if err := json.Unmarshal(*rule.Parameters, params); err != nil {
+				return nil, fmt.Errorf("invalid merge queue rule parameters: %v: %w", err, gitprovider.ErrInvalidServerData)
+			}
+		}
+		return params, nil
+	}
+	return nil, nil
+}
+
+func mergeQueueFromAPI(params *github.MergeQueueRuleParameters) gitprovider.MergeQueueInfo {
+	maxEntries := params.MaxEntriesToMerge
+	minWait := time.Duration(params.MinEntriesToMergeWaitMinutes) * time.Minute
+	maxWait := time.Duration(params.CheckResponseTimeoutMinutes) * time.Minute
+	return gitprovider.MergeQueueInfo{
+		Enabled:     true,
+		MergeMethod: gitprovider.MergeMethodVar(gitprovider.MergeMethod(strings.ToLower(params.MergeMethod))),
+		MaxEntries:  &maxEntries,
+		MinWait:     &minWait,
+		MaxWait:     &maxWait,
+	}
+}
+
+// mergeQueueRulesetToAPI returns the ruleset enabling the merge queue described by req on branch.
+// The other rules and settings of the existing ruleset, if any, are kept.
+func mergeQueueRulesetToAPI(branch string, req gitprovider.MergeQueueInfo, existing *github.Ruleset) (*github.Ruleset, error) {
+	params := &github.MergeQueueRuleParameters{
+		GroupingStrategy:  "ALLGREEN",
+		MaxEntriesToBuild: defaultMergeQueueBuildConcurrency,
+		MinEntriesToMerge: 1,
+	}
+	rules := []*github.RepositoryRule{}
+	var bypassActors []*github.BypassActor
+	if existing != nil {
+		existingParams, err := mergeQueueParamsFromAPI(existing)
+		if err != nil {
+			return nil, err
+		}
+		if existingParams != nil {
+			params = existingParams
+		}
+		for _, rule := range existing.Rules {
+			if rule.Type != mergeQueueRuleType {
+				rules = append(rules, rule)
+			}
+		}
+		bypassActors = existing.BypassActors
+	}
+
+	params.MergeMethod = strings.ToUpper(string(*req.MergeMethod))
+	params.MaxEntriesToMerge = *req.MaxEntries
+	params.MinEntriesToMergeWaitMinutes = int(*req.MinWait / time.Minute)
+	params.CheckResponseTimeoutMinutes = int(*req.MaxWait / time.Minute)
+
+	return &github.Ruleset{
+		Name:         mergeQueueRulesetName(branch),
+		Target:       github.String("branch"),
+		Enforcement:  rulesetEnforcementActive,
+		BypassActors: bypassActors,
+		Conditions: &github.RulesetConditions{
+			RefName: &github.RulesetRefConditionParameters{
+				Include: []string{"refs/heads/" + branch},
+				Exclude: []string{},
+			},
+		},
+		Rules: append(rules, github.NewMergeQueueRule(params)),
+	}, nil
+}
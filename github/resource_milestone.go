@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newMilestone(c *MilestoneClient, apiObj *github.Milestone) *milestone {
+	return &milestone{
+		m: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Milestone = &milestone{}
+
+type milestone struct {
+	m github.Milestone
+	c *MilestoneClient
+}
+
+func (m *milestone) Get() gitprovider.MilestoneInfo {
+	return milestoneFromAPI(&m.m)
+}
+
+func (m *milestone) Set(info gitprovider.MilestoneInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	milestoneInfoToAPIObj(&info, &m.m)
+	return nil
+}
+
+func (m *milestone) APIObject() interface{} {
+	return &m.m
+}
+
+func (m *milestone) Repository() gitprovider.RepositoryRef {
+	return m.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (m *milestone) Update(ctx context.Context) error {
+	// PATCH /repos/{owner}/{repo}/milestones/{milestone_number}
+	apiObj, err := m.c.c.UpdateMilestone(ctx, m.c.ref.GetIdentity(), m.c.ref.GetRepository(), m.m.GetNumber(), &github.Milestone{
+		Title:       m.m.Title,
+		Description: m.m.Description,
+		DueOn:       m.m.DueOn,
+		State:       m.m.State,
+	})
+	if err != nil {
+		return err
+	}
+	m.m = *apiObj
+	return nil
+}
+
+func validateMilestoneAPI(apiObj *github.Milestone) error {
+	return validateAPIObject("GitHub.Milestone", func(validator validation.Validator) {
+		// Make sure number and title are populated, as per
+		// https://docs.github.com/en/rest/issues/milestones#get-a-milestone
+		if apiObj.Number == nil {
+			validator.Required("Number")
+		}
+		if apiObj.Title == nil {
+			validator.Required("Title")
+		}
+	})
+}
+
+func milestoneFromAPI(apiObj *github.Milestone) gitprovider.MilestoneInfo {
+	info := gitprovider.MilestoneInfo{
+		Title:       apiObj.GetTitle(),
+		Description: gitprovider.StringVar(apiObj.GetDescription()),
+		State:       gitprovider.MilestoneStateVar(gitprovider.MilestoneState(apiObj.GetState())),
+	}
+	if apiObj.DueOn != nil {
+		info.DueDate = gitprovider.MilestoneDueDate(apiObj.DueOn.Time)
+	}
+	return info
+}
+
+func milestoneToAPI(info *gitprovider.MilestoneInfo) *github.Milestone {
+	m := &github.Milestone{}
+	milestoneInfoToAPIObj(info, m)
+	return m
+}
+
+func milestoneInfoToAPIObj(info *gitprovider.MilestoneInfo, apiObj *github.Milestone) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Title = gitprovider.StringVar(info.Title)
+	// optional fields
+	if info.Description != nil {
+		apiObj.Description = info.Description
+	}
+	if info.DueDate != nil {
+		apiObj.DueOn = &github.Timestamp{Time: *info.DueDate}
+	}
+	if info.State != nil {
+		apiObj.State = gitprovider.StringVar(string(*info.State))
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package github
 
 import (
+	"context"
 	"github.com/google/go-github/v66/github"
+	"io"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
@@ -32,6 +34,14 @@ func newOrganization(ctx *clientContext, apiObj *github.Organization, ref gitpro
 			clientContext: ctx,
 			ref:           ref,
 		},
+		settings: &OrganizationSettingsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &OrganizationWebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 	}
 }
 
@@ -43,7 +53,9 @@ type organization struct {
 	o   github.Organization
 	ref gitprovider.OrganizationRef
 
-	teams *TeamsClient
+	teams    *TeamsClient
+	settings *OrganizationSettingsClient
+	webhooks *OrganizationWebhookClient
 }
 
 func (o *organization) Get() gitprovider.OrganizationInfo {
@@ -62,10 +74,29 @@ func (o *organization) Teams() gitprovider.TeamsClient {
 	return o.teams
 }
 
+func (o *organization) Settings() gitprovider.OrganizationSettingsClient {
+	return o.settings
+}
+
+func (o *organization) Webhooks() (gitprovider.OrganizationWebhookClient, error) {
+	return o.webhooks, nil
+}
+
+func (o *organization) AuditLog() (gitprovider.AuditLogClient, error) {
+	return &AuditLogClient{clientContext: o.clientContext, ref: o.ref}, nil
+}
+
+// SetAvatar is not supported by the GitHub API, ErrNoProviderSupport is returned.
+func (o *organization) SetAvatar(_ context.Context, _ io.Reader) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
 func organizationFromAPI(apiObj *github.Organization) gitprovider.OrganizationInfo {
 	return gitprovider.OrganizationInfo{
 		Name:        apiObj.Name,
 		Description: apiObj.Description,
+		// Only reported to the owners of the organization
+		TwoFactorRequired: apiObj.TwoFactorRequirementEnabled,
 	}
 }
 
@@ -0,0 +1,259 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// webhookName is the name of all webhooks delivering to a URL, as opposed to GitHub services.
+	webhookName = "web"
+	// webhookDefaultContentType is the format GitHub delivers the events in if unset.
+	webhookDefaultContentType = gitprovider.WebhookContentTypeForm
+)
+
+func newOrgWebhook(c *OrganizationWebhookClient, hook *github.Hook) *orgWebhook {
+	w := &orgWebhook{
+		h: *hook,
+		c: c,
+	}
+	// GitHub returns the secret obfuscated, don't send that back when updating
+	if hook.Config != nil {
+		config := *hook.Config
+		config.Secret = nil
+		w.h.Config = &config
+	}
+	return w
+}
+
+var _ gitprovider.OrganizationWebhook = &orgWebhook{}
+
+type orgWebhook struct {
+	h github.Hook
+	c *OrganizationWebhookClient
+}
+
+func (w *orgWebhook) Get() gitprovider.OrganizationWebhookInfo {
+	return orgWebhookFromAPI(&w.h)
+}
+
+func (w *orgWebhook) Set(info gitprovider.OrganizationWebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return orgWebhookInfoToAPIObj(&info, &w.h)
+}
+
+func (w *orgWebhook) APIObject() interface{} {
+	return &w.h
+}
+
+func (w *orgWebhook) Organization() gitprovider.OrganizationRef {
+	return w.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (w *orgWebhook) Update(ctx context.Context) error {
+	if w.h.ID == nil {
+		return fmt.Errorf("didn't expect ID to be nil: %w", gitprovider.ErrUnexpectedEvent)
+	}
+	// PATCH /orgs/{org}/hooks/{hook_id}
+	apiObj, err := w.c.c.EditOrgHook(ctx, w.c.ref.Organization, *w.h.ID, orgWebhookSpec(&w.h))
+	if err != nil {
+		return err
+	}
+	*w = *newOrgWebhook(w.c, apiObj)
+	return nil
+}
+
+// Delete deletes the webhook from the organization. Deleting a webhook requires destructive
+// API calls to be enabled, ErrDestructiveCallDisallowed is returned otherwise.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (w *orgWebhook) Delete(ctx context.Context) error {
+	// Don't allow deleting webhooks if the user didn't explicitly allow dangerous API calls.
+	if !w.c.destructiveActions {
+		return fmt.Errorf("cannot delete webhook: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if w.h.ID == nil {
+		return fmt.Errorf("didn't expect ID to be nil: %w", gitprovider.ErrUnexpectedEvent)
+	}
+	// DELETE /orgs/{org}/hooks/{hook_id}
+	return w.c.c.DeleteOrgHook(ctx, w.c.ref.Organization, *w.h.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (w *orgWebhook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := w.c.get(ctx, w.h.Config.GetURL())
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			// POST /orgs/{org}/hooks
+			apiObj, err := w.c.c.CreateOrgHook(ctx, w.c.ref.Organization, orgWebhookSpec(&w.h))
+			if err != nil {
+				return true, err
+			}
+			*w = *newOrgWebhook(w.c, apiObj)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing. The secret is only known when set
+	// through Set, as newOrgWebhook drops the obfuscated one
+	desired := w.Get()
+	if w.h.Config != nil {
+		desired.Secret = w.h.Config.Secret
+	}
+	if desired.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Otherwise, update the actual webhook with the desired state
+	w.h.ID = actual.h.ID
+	return true, w.Update(ctx)
+}
+
+func validateHookAPI(apiObj *github.Hook) error {
+	return validateAPIObject("GitHub.Hook", func(validator validation.Validator) {
+		if apiObj.ID == nil {
+			validator.Required("ID")
+		}
+		if apiObj.Config == nil || apiObj.Config.URL == nil {
+			validator.Required("Config.URL")
+		}
+	})
+}
+
+func orgWebhookFromAPI(apiObj *github.Hook) gitprovider.OrganizationWebhookInfo {
+	info := gitprovider.OrganizationWebhookInfo{
+		URL:                apiObj.Config.GetURL(),
+		InsecureSkipVerify: gitprovider.BoolVar(apiObj.Config.GetInsecureSSL() == "1"),
+		ContentType:        gitprovider.WebhookContentTypeVar(webhookDefaultContentType),
+	}
+	if contentType := apiObj.Config.GetContentType(); contentType != "" {
+		info.ContentType = gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentType(contentType))
+	}
+	info.Events = webhookEventsFromAPI(apiObj.Events)
+	return info
+}
+
+func orgWebhookToAPI(info *gitprovider.OrganizationWebhookInfo) (*github.Hook, error) {
+	h := &github.Hook{}
+	if err := orgWebhookInfoToAPIObj(info, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func orgWebhookInfoToAPIObj(info *gitprovider.OrganizationWebhookInfo, apiObj *github.Hook) error {
+	events, err := webhookEventsToAPI(info.Events, apiObj.Events)
+	if err != nil {
+		return err
+	}
+	apiObj.Name = github.String(webhookName)
+	apiObj.Events = events
+	if apiObj.Config == nil {
+		apiObj.Config = &github.HookConfig{}
+	}
+	apiObj.Config.URL = github.String(info.URL)
+	if info.ContentType != nil {
+		apiObj.Config.ContentType = github.String(string(*info.ContentType))
+	}
+	apiObj.Config.Secret = info.Secret
+	if info.InsecureSkipVerify != nil {
+		insecureSSL := "0"
+		if *info.InsecureSkipVerify {
+			insecureSSL = "1"
+		}
+		apiObj.Config.InsecureSSL = github.String(insecureSSL)
+	}
+	return nil
+}
+
+// webhookEventsFromAPI maps GitHub event names to WebhookEvents, leaving out the events which
+// can't be expressed as a WebhookEvent.
+func webhookEventsFromAPI(names []string) []gitprovider.WebhookEvent {
+	var events []gitprovider.WebhookEvent
+	for _, event := range gitprovider.SortedWebhookEvents(webhookEventNames) {
+		for _, name := range names {
+			if name == webhookEventNames[event] {
+				events = append(events, event)
+				break
+			}
+		}
+	}
+	return events
+}
+
+// webhookEventsToAPI maps events to GitHub event names, keeping the names in current which can't
+// be expressed as a WebhookEvent, as these can't be desired.
+func webhookEventsToAPI(events []gitprovider.WebhookEvent, current []string) ([]string, error) {
+	known := make(map[string]struct{}, len(webhookEventNames))
+	for _, name := range webhookEventNames {
+		known[name] = struct{}{}
+	}
+	names := make([]string, 0, len(events))
+	for _, name := range current {
+		if _, ok := known[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for _, event := range events {
+		name, ok := webhookEventNames[event]
+		if !ok {
+			return nil, fmt.Errorf("GitHub webhooks can't be triggered by %q events: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// orgWebhookSpec copies over the fields of a webhook which can be sent to the API, leaving out the
+// "status" fields like the delivery URLs and last response.
+func orgWebhookSpec(hook *github.Hook) *github.Hook {
+	return &github.Hook{
+		Name:   hook.Name,
+		Config: hook.Config,
+		Events: hook.Events,
+		Active: hook.Active,
+	}
+}
@@ -52,6 +52,10 @@ func pullrequestFromAPI(apiObj *github.PullRequest) gitprovider.PullRequestInfo
 			sourceBranch = *head.Ref
 		}
 	}
+	var labels []string
+	for _, label := range apiObj.Labels {
+		labels = append(labels, label.GetName())
+	}
 	return gitprovider.PullRequestInfo{
 		Title:        apiObj.GetTitle(),
 		Description:  apiObj.GetBody(),
@@ -59,5 +63,29 @@ func pullrequestFromAPI(apiObj *github.PullRequest) gitprovider.PullRequestInfo
 		Number:       apiObj.GetNumber(),
 		WebURL:       apiObj.GetHTMLURL(),
 		SourceBranch: sourceBranch,
+		Labels:       labels,
+	}
+}
+
+func newPullRequestReview(apiObj *github.PullRequestReview) *pullRequestReview {
+	return &pullRequestReview{
+		r: *apiObj,
+	}
+}
+
+var _ gitprovider.PullRequestReview = &pullRequestReview{}
+
+type pullRequestReview struct {
+	r github.PullRequestReview
+}
+
+func (r *pullRequestReview) Get() gitprovider.ReviewInfo {
+	return gitprovider.ReviewInfo{
+		Reviewer: r.r.GetUser().GetLogin(),
+		State:    reviewStates[r.r.GetState()],
 	}
 }
+
+func (r *pullRequestReview) APIObject() interface{} {
+	return &r.r
+}
@@ -19,7 +19,10 @@ package github
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"reflect"
+	"time"
 
 	"github.com/google/go-github/v66/github"
 
@@ -27,6 +30,27 @@ import (
 	"github.com/fluxcd/go-git-providers/validation"
 )
 
+const (
+	// dependabotConfigPath is where GitHub expects the Dependabot configuration.
+	dependabotConfigPath = ".github/dependabot.yml"
+	// dependabotConfigCommitMessage is the commit message used when committing the Dependabot configuration.
+	dependabotConfigCommitMessage = "Set Dependabot configuration"
+	// securityPolicyPath is the conventional location of the security policy.
+	securityPolicyPath = "SECURITY.md"
+	// securityPolicyCommitMessage is the commit message used when committing the security policy.
+	securityPolicyCommitMessage = "Set security policy"
+)
+
+// statsPollInterval is the interval at which the statistics of a repository are polled while
+// GitHub computes them.
+//
+//nolint:gochecknoglobals
+var statsPollInterval = 2 * time.Second
+
+// statsMaxPolls is the maximum number of times the statistics of a repository are polled before
+// giving up.
+const statsMaxPolls = 15
+
 var githubRepositoryKnownFields = map[string]struct{}{
 	"Name":        {},
 	"Description": {},
@@ -62,6 +86,14 @@ func newUserRepository(ctx *clientContext, apiObj *github.Repository, ref gitpro
 			clientContext: ctx,
 			ref:           ref,
 		},
+		milestones: &MilestoneClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		releases: &ReleaseClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		commits: &CommitClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -70,6 +102,10 @@ func newUserRepository(ctx *clientContext, apiObj *github.Repository, ref gitpro
 			clientContext: ctx,
 			ref:           ref,
 		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		pullRequests: &PullRequestClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -95,15 +131,18 @@ type userRepository struct {
 	ref       gitprovider.RepositoryRef
 
 	deployKeys   *DeployKeyClient
+	milestones   *MilestoneClient
+	releases     *ReleaseClient
 	commits      *CommitClient
 	branches     *BranchClient
+	tags         *TagClient
 	pullRequests *PullRequestClient
 	files        *FileClient
 	trees        *TreeClient
 }
 
 func (r *userRepository) Get() gitprovider.RepositoryInfo {
-	return repositoryFromAPI(&r.r)
+	return repositoryFromAPI(&r.r, r.domain)
 }
 
 // Set sets the desired state of this object.
@@ -129,10 +168,59 @@ func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
 	return r.deployKeys
 }
 
+// Hooks returns a HookClient operating on the webhooks of the repository.
+func (r *userRepository) Hooks() (gitprovider.HookClient, error) {
+	return &HookClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
 func (r *userRepository) DeployTokens() (gitprovider.DeployTokenClient, error) {
 	return nil, gitprovider.ErrNoProviderSupport
 }
 
+func (r *userRepository) ProtectedTags() (gitprovider.ProtectedTagClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+func (r *userRepository) Milestones() (gitprovider.MilestoneClient, error) {
+	return r.milestones, nil
+}
+
+func (r *userRepository) Releases() (gitprovider.ReleaseClient, error) {
+	return r.releases, nil
+}
+
+// Pipelines is not supported, as GitHub Actions workflows are dispatched rather than triggered
+// as pipelines. ErrNoProviderSupport is returned.
+func (r *userRepository) Pipelines() (gitprovider.PipelineClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Workflows returns a WorkflowClient operating on the GitHub Actions workflows of the repository.
+func (r *userRepository) Workflows() (gitprovider.WorkflowClient, error) {
+	return &WorkflowClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
+// Traffic returns a TrafficClient reading the traffic statistics of the repository. Reading them
+// requires push access to the repository.
+func (r *userRepository) Traffic() (gitprovider.TrafficClient, error) {
+	return &TrafficClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
+// Environments returns an EnvironmentClient operating on the deployment environments of the repository.
+func (r *userRepository) Environments() (gitprovider.EnvironmentClient, error) {
+	return &EnvironmentClient{clientContext: r.clientContext, repoID: r.r.GetID()}, nil
+}
+
+// Collaborators returns a CollaboratorClient operating on the users having access to the repository.
+func (r *userRepository) Collaborators() (gitprovider.CollaboratorClient, error) {
+	return &CollaboratorClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
+// Topics returns a TopicsClient operating on the topics of the repository.
+func (r *userRepository) Topics() (gitprovider.TopicsClient, error) {
+	return &TopicsClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
 func (r *userRepository) Commits() gitprovider.CommitClient {
 	return r.commits
 }
@@ -141,6 +229,10 @@ func (r *userRepository) Branches() gitprovider.BranchClient {
 	return r.branches
 }
 
+func (r *userRepository) Tags() gitprovider.TagClient {
+	return r.tags
+}
+
 func (r *userRepository) PullRequests() gitprovider.PullRequestClient {
 	return r.pullRequests
 }
@@ -244,6 +336,518 @@ func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
 	return r.teamAccess
 }
 
+// License returns the license GitHub detected in the repository.
+//
+// ErrNotFound is returned if no license is detected.
+func (r *orgRepository) License(ctx context.Context) (gitprovider.LicenseInfo, error) {
+	// GET /repos/{owner}/{repo}/license
+	apiObj, err := r.c.GetRepoLicense(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		return gitprovider.LicenseInfo{}, err
+	}
+	return licenseFromAPI(apiObj), nil
+}
+
+// Language returns the primary language of the repository, as detected by GitHub. It's read from
+// the repository object, hence no API call is made.
+//
+// ErrNotFound is returned if no language is detected.
+func (r *orgRepository) Language(_ context.Context) (string, error) {
+	if r.r.GetLanguage() == "" {
+		return "", fmt.Errorf("no language detected in repository %q: %w", r.ref.GetRepository(), gitprovider.ErrNotFound)
+	}
+	return r.r.GetLanguage(), nil
+}
+
+// Permissions returns the effective permission level of the authenticated user on the repository.
+func (r *orgRepository) Permissions(ctx context.Context) (gitprovider.PermissionLevel, error) {
+	// GET /repos/{owner}/{repo}
+	apiObj, err := r.c.GetRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		return "", err
+	}
+	return permissionLevelFromAPI(apiObj.GetPermissions()), nil
+}
+
+// CreateBackup writes a git bundle of all refs of the repository to w.
+// The repository is fetched with the authenticated HTTP client of the provider.
+func (r *orgRepository) CreateBackup(ctx context.Context, w io.Writer) error {
+	return gitprovider.WriteBackupBundle(ctx, w, r.r.GetCloneURL(), gitprovider.GitTransportOptions{
+		HTTPClient: r.c.Client().Client(),
+	})
+}
+
+// Wiki returns a WikiClient operating on the wiki repository through the Git protocol.
+func (r *orgRepository) Wiki() (gitprovider.WikiClient, error) {
+	return gitprovider.NewGitWikiClient(gitprovider.WikiCloneURL(r.r.GetCloneURL()), gitprovider.GitTransportOptions{
+		HTTPClient: r.c.Client().Client(),
+	})
+}
+
+// CustomProperties returns a CustomPropertiesClient operating on the custom properties of the repository.
+func (r *orgRepository) CustomProperties() (gitprovider.CustomPropertiesClient, error) {
+	return &CustomPropertiesClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
+// InteractionLimits returns an InteractionLimitsClient operating on the interaction limits of the repository.
+func (r *orgRepository) InteractionLimits() (gitprovider.InteractionLimitsClient, error) {
+	return &InteractionLimitsClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
+// ApprovalSettings is not supported by GitHub, ErrNoProviderSupport is returned.
+func (r *orgRepository) ApprovalSettings() (gitprovider.ApprovalSettingsClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Pages returns a PagesClient operating on the GitHub Pages site of the repository.
+func (r *orgRepository) Pages() (gitprovider.PagesClient, error) {
+	return &PagesClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
+// SetSubscription sets whether the authenticated user watches or ignores the repository.
+// The subscription is deleted when neither is set, resetting it to the user's defaults.
+func (r *orgRepository) SetSubscription(ctx context.Context, subscription gitprovider.SubscriptionInfo) error {
+	if err := subscription.ValidateInfo(); err != nil {
+		return err
+	}
+	if !subscription.Subscribed && !subscription.Ignored {
+		return r.c.DeleteRepoSubscription(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	}
+	return r.c.SetRepoSubscription(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), &github.Subscription{
+		Subscribed: &subscription.Subscribed,
+		Ignored:    &subscription.Ignored,
+	})
+}
+
+// Subscribers lists the users watching the repository.
+func (r *orgRepository) Subscribers(ctx context.Context) ([]gitprovider.UserRef, error) {
+	apiObjs, err := r.c.ListRepoSubscribers(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		return nil, err
+	}
+	subscribers := make([]gitprovider.UserRef, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		subscribers = append(subscribers, gitprovider.UserRef{Domain: r.domain, UserLogin: apiObj.GetLogin()})
+	}
+	return subscribers, nil
+}
+
+// SetAvatar is not supported, as GitHub repositories have no avatar. ErrNoProviderSupport is returned.
+func (r *orgRepository) SetAvatar(_ context.Context, _ io.Reader) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileMergeQueue makes sure the merge queue of the given branch matches req. The merge queue
+// is configured through a repository ruleset named after the branch, which is created, updated or
+// deleted as needed.
+func (r *orgRepository) ReconcileMergeQueue(ctx context.Context, branch string, req gitprovider.MergeQueueInfo) (bool, error) {
+	if branch == "" {
+		return false, fmt.Errorf("branch is required: %w", gitprovider.ErrInvalidArgument)
+	}
+	req.Default()
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	apiObj, err := r.getRuleset(ctx, mergeQueueRulesetName(branch))
+	if err != nil {
+		return false, err
+	}
+
+	if !req.Enabled {
+		if apiObj == nil {
+			return false, nil
+		}
+		return true, r.c.DeleteRepoRuleset(ctx, owner, repo, apiObj.GetID())
+	}
+
+	if apiObj != nil && apiObj.Enforcement == rulesetEnforcementActive {
+		params, err := mergeQueueParamsFromAPI(apiObj)
+		if err != nil {
+			return false, err
+		}
+		if params != nil && req.Equals(mergeQueueFromAPI(params)) {
+			return false, nil
+		}
+	}
+
+	desired, err := mergeQueueRulesetToAPI(branch, req, apiObj)
+	if err != nil {
+		return false, err
+	}
+	if apiObj == nil {
+		_, err = r.c.CreateRepoRuleset(ctx, owner, repo, desired)
+	} else {
+		_, err = r.c.UpdateRepoRuleset(ctx, owner, repo, apiObj.GetID(), desired)
+	}
+	return true, err
+}
+
+// ReconcileBranchMergeMethods makes sure the merge methods allowed for the pull requests targeting
+// branch match req. The merge methods are restricted through the pull request rule of a repository
+// ruleset named after the branch, which is created, updated or deleted as needed. Note that the
+// pull request rule also requires changes to the branch to go through pull requests.
+func (r *orgRepository) ReconcileBranchMergeMethods(ctx context.Context, branch string, req gitprovider.BranchMergeMethodsInfo) (bool, error) {
+	if branch == "" {
+		return false, fmt.Errorf("branch is required: %w", gitprovider.ErrInvalidArgument)
+	}
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	apiObj, err := r.getRuleset(ctx, mergeMethodsRulesetName(branch))
+	if err != nil {
+		return false, err
+	}
+
+	if len(req.AllowedMethods) == 0 {
+		if apiObj == nil {
+			return false, nil
+		}
+		return true, r.c.DeleteRepoRuleset(ctx, owner, repo, apiObj.GetID())
+	}
+
+	var params *pullRequestRuleParameters
+	if apiObj != nil {
+		// The pull request rule is read separately, as go-github drops its merge methods
+		rawParams, err := r.c.GetRepoRulesetRuleParameters(ctx, owner, repo, apiObj.GetID(), pullRequestRuleType)
+		if err != nil {
+			return false, err
+		}
+		if params, err = pullRequestParamsFromAPI(rawParams); err != nil {
+			return false, err
+		}
+		if params != nil && apiObj.Enforcement == rulesetEnforcementActive && req.Equals(branchMergeMethodsFromAPI(params)) {
+			return false, nil
+		}
+	}
+
+	desired, err := mergeMethodsRulesetToAPI(branch, req, apiObj, params)
+	if err != nil {
+		return false, err
+	}
+	if apiObj == nil {
+		_, err = r.c.CreateRepoRuleset(ctx, owner, repo, desired)
+	} else {
+		_, err = r.c.UpdateRepoRuleset(ctx, owner, repo, apiObj.GetID(), desired)
+	}
+	return true, err
+}
+
+// ReconcileMergeMethods is not supported, as the repository-wide merge methods of GitHub are
+// fields of the repository object, without a preselected method. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileMergeMethods(_ context.Context, _ gitprovider.MergeMethodsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileEnvironmentProtection makes sure the refs allowed to deploy to the given environment,
+// and its custom protection rules, match req. The allowed refs are given by the deployment branch
+// policies of the environment, which is switched to custom branch policies as needed, or back to
+// allowing any ref if req has no patterns. The other protection rules of the environment are kept.
+func (r *orgRepository) ReconcileEnvironmentProtection(ctx context.Context, environment string, req gitprovider.EnvironmentProtectionInfo) (bool, error) {
+	if environment == "" {
+		return false, fmt.Errorf("environment is required: %w", gitprovider.ErrInvalidArgument)
+	}
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	env, err := r.c.GetEnvironment(ctx, owner, repo, environment)
+	if err != nil {
+		return false, err
+	}
+	// The deployment branch policies only apply when the environment uses custom branch policies
+	customPolicies := env.GetDeploymentBranchPolicy().GetCustomBranchPolicies()
+	wantCustomPolicies := len(req.BranchPatterns)+len(req.TagPatterns) > 0
+	var policies []*github.DeploymentBranchPolicy
+	if customPolicies {
+		if policies, err = r.c.ListDeploymentBranchPolicies(ctx, owner, repo, environment); err != nil {
+			return false, err
+		}
+	}
+	rules, err := r.c.ListDeploymentProtectionRules(ctx, owner, repo, environment)
+	if err != nil {
+		return false, err
+	}
+	if customPolicies == wantCustomPolicies && req.Equals(environmentProtectionFromAPI(policies, rules)) {
+		return false, nil
+	}
+
+	if wantCustomPolicies && !customPolicies {
+		branchPolicy := &github.BranchPolicy{ProtectedBranches: github.Bool(false), CustomBranchPolicies: github.Bool(true)}
+		if err := r.c.UpdateEnvironment(ctx, owner, repo, environment, environmentToAPI(env, branchPolicy)); err != nil {
+			return true, err
+		}
+	}
+
+	desiredPolicies := deploymentBranchPolicyKeys(req)
+	existingPolicies := map[deploymentBranchPolicyKey]struct{}{}
+	for _, policy := range policies {
+		key := deploymentBranchPolicyKeyFromAPI(policy)
+		if desiredPolicies[key] {
+			existingPolicies[key] = struct{}{}
+			continue
+		}
+		if err := r.c.DeleteDeploymentBranchPolicy(ctx, owner, repo, environment, policy.GetID()); err != nil {
+			return true, err
+		}
+	}
+	for _, key := range deploymentBranchPolicyKeysOf(req) {
+		if _, ok := existingPolicies[key]; ok {
+			continue
+		}
+		if err := r.c.CreateDeploymentBranchPolicy(ctx, owner, repo, environment, &github.DeploymentBranchPolicyRequest{
+			Name: github.String(key.pattern),
+			Type: github.String(key.policyType),
+		}); err != nil {
+			return true, err
+		}
+		existingPolicies[key] = struct{}{}
+	}
+
+	if customPolicies && !wantCustomPolicies {
+		// Let any ref deploy to the environment again
+		if err := r.c.UpdateEnvironment(ctx, owner, repo, environment, environmentToAPI(env, nil)); err != nil {
+			return true, err
+		}
+	}
+
+	desiredApps := map[int64]bool{}
+	for _, id := range req.CustomRuleAppIDs {
+		desiredApps[id] = true
+	}
+	existingApps := map[int64]struct{}{}
+	for _, rule := range rules {
+		id := rule.GetApp().GetID()
+		if desiredApps[id] {
+			existingApps[id] = struct{}{}
+			continue
+		}
+		if err := r.c.DisableDeploymentProtectionRule(ctx, owner, repo, environment, rule.GetID()); err != nil {
+			return true, err
+		}
+	}
+	for _, id := range req.CustomRuleAppIDs {
+		if _, ok := existingApps[id]; ok {
+			continue
+		}
+		if err := r.c.CreateDeploymentProtectionRule(ctx, owner, repo, environment, id); err != nil {
+			return true, err
+		}
+		existingApps[id] = struct{}{}
+	}
+	return true, nil
+}
+
+// IsDefaultBranchProtected returns whether the default branch of the repository is protected, from
+// the protected flag of the branch object.
+func (r *orgRepository) IsDefaultBranchProtected(ctx context.Context) (bool, error) {
+	// GET /repos/{owner}/{repo}/branches/{branch}
+	apiObj, err := r.c.GetBranch(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), r.r.GetDefaultBranch())
+	if err != nil {
+		return false, err
+	}
+	return apiObj.GetProtected(), nil
+}
+
+// ReconcileWorkflowPermissions makes sure the default permissions of the GITHUB_TOKEN handed to the
+// GitHub Actions workflows of the repository match req.
+func (r *orgRepository) ReconcileWorkflowPermissions(ctx context.Context, req gitprovider.WorkflowPermissionsInfo) (bool, error) {
+	req.Default()
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	apiObj, err := r.c.GetRepoDefaultWorkflowPermissions(ctx, owner, repo)
+	if err != nil {
+		return false, err
+	}
+	if req.Equals(workflowPermissionsFromAPI(apiObj)) {
+		return false, nil
+	}
+	return true, r.c.EditRepoDefaultWorkflowPermissions(ctx, owner, repo, workflowPermissionsToAPI(req))
+}
+
+// ReconcileCodeScanningDefaultSetup makes sure the CodeQL default setup of the repository matches
+// req. Disabling it only sends the new state, as the other settings are kept by GitHub.
+func (r *orgRepository) ReconcileCodeScanningDefaultSetup(ctx context.Context, req gitprovider.CodeScanningDefaultSetupInfo) (bool, error) {
+	req.Default()
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	apiObj, err := r.c.GetRepoCodeScanningDefaultSetup(ctx, owner, repo)
+	if err != nil {
+		return false, err
+	}
+	if req.Equals(codeScanningDefaultSetupFromAPI(apiObj)) {
+		return false, nil
+	}
+	return true, r.c.UpdateRepoCodeScanningDefaultSetup(ctx, owner, repo, codeScanningDefaultSetupToAPI(req))
+}
+
+// getRuleset returns the ruleset with the given name including its rules, or nil if there is none.
+func (r *orgRepository) getRuleset(ctx context.Context, name string) (*github.Ruleset, error) {
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	apiObjs, err := r.c.ListRepoRulesets(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, apiObj := range apiObjs {
+		if apiObj.Name == name {
+			// The listed rulesets don't contain their rules
+			return r.c.GetRepoRuleset(ctx, owner, repo, apiObj.GetID())
+		}
+	}
+	return nil, nil
+}
+
+// Counts returns the number of open pull requests, open issues, branches and tags of the
+// repository. The open issues are read from the repository object, while the other counts are
+// read from the pagination of single-item list requests.
+func (r *orgRepository) Counts(ctx context.Context) (gitprovider.RepoCounts, error) {
+	owner, repo := r.ref.GetIdentity(), r.ref.GetRepository()
+	apiObj, err := r.c.GetRepo(ctx, owner, repo)
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	pulls, err := r.c.CountRepoPullRequests(ctx, owner, repo, "open")
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	branches, err := r.c.CountRepoBranches(ctx, owner, repo)
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	tags, err := r.c.CountRepoTags(ctx, owner, repo)
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	return repoCountsFromAPI(apiObj, pulls, branches, tags), nil
+}
+
+// CommitActivity returns the weekly commit activity of the default branch. GitHub computes the
+// statistics in the background when they aren't cached, so they're polled until computed. If
+// they're still being computed after statsMaxPolls polls, gitprovider.ErrNotReady is returned
+// and the call can be retried later.
+func (r *orgRepository) CommitActivity(ctx context.Context) ([]gitprovider.WeeklyActivity, error) {
+	for poll := 1; ; poll++ {
+		apiObjs, err := r.c.ListRepoCommitActivity(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+		if err != nil {
+			return nil, err
+		}
+		if apiObjs != nil {
+			return weeklyActivityFromAPI(apiObjs), nil
+		}
+		if poll == statsMaxPolls {
+			return nil, fmt.Errorf("commit activity is still being computed after %d polls: %w", statsMaxPolls, gitprovider.ErrNotReady)
+		}
+
+		timer := time.NewTimer(statsPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("commit activity is still being computed: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func weeklyActivityFromAPI(apiObjs []*github.WeeklyCommitActivity) []gitprovider.WeeklyActivity {
+	activity := make([]gitprovider.WeeklyActivity, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		week := gitprovider.WeeklyActivity{
+			Week:  apiObj.GetWeek().UTC(),
+			Total: apiObj.GetTotal(),
+		}
+		copy(week.Days[:], apiObj.Days)
+		activity = append(activity, week)
+	}
+	return activity
+}
+
+// SetTemplates commits the given pull request and issue templates to their conventional
+// locations on the default branch, in a single commit.
+func (r *orgRepository) SetTemplates(ctx context.Context, templates gitprovider.TemplatesInfo) (gitprovider.Commit, error) {
+	return gitprovider.CommitTemplates(ctx, r.commits, r.r.GetDefaultBranch(), githubTemplateLayout, templates)
+}
+
+// GetTemplates reads the pull request and issue templates from their conventional
+// locations on the default branch.
+func (r *orgRepository) GetTemplates(ctx context.Context) (gitprovider.TemplatesInfo, error) {
+	return gitprovider.ReadTemplates(ctx, r.files, r.r.GetDefaultBranch(), githubTemplateLayout)
+}
+
+// DiscoverTemplates returns the paths of the pull request and issue templates found at the
+// locations GitHub recognizes, at the given ref or on the default branch if ref is empty.
+func (r *orgRepository) DiscoverTemplates(ctx context.Context, ref string) (gitprovider.TemplateLocations, error) {
+	if ref == "" {
+		ref = r.r.GetDefaultBranch()
+	}
+	return gitprovider.DiscoverTemplates(ctx, r.trees, ref, githubTemplateConventions)
+}
+
+// SetDependabotConfig commits the given Dependabot configuration to .github/dependabot.yml on the
+// default branch.
+func (r *orgRepository) SetDependabotConfig(ctx context.Context, config []byte) (gitprovider.Commit, error) {
+	return gitprovider.CommitRepoFile(ctx, r.commits, r.r.GetDefaultBranch(), dependabotConfigPath, dependabotConfigCommitMessage, config)
+}
+
+// GetDependabotConfig reads the Dependabot configuration from .github/dependabot.yml on the
+// default branch.
+func (r *orgRepository) GetDependabotConfig(ctx context.Context) ([]byte, error) {
+	return gitprovider.ReadRepoFile(ctx, r.files, r.r.GetDefaultBranch(), dependabotConfigPath)
+}
+
+// SetSecurityPolicy commits the given security policy to SECURITY.md on the default branch.
+func (r *orgRepository) SetSecurityPolicy(ctx context.Context, policy []byte) (gitprovider.Commit, error) {
+	return gitprovider.CommitRepoFile(ctx, r.commits, r.r.GetDefaultBranch(), securityPolicyPath, securityPolicyCommitMessage, policy)
+}
+
+// GetSecurityPolicy reads the security policy from SECURITY.md on the default branch.
+// GitHub also recognizes the policy in the docs and .github directories, which isn't read.
+func (r *orgRepository) GetSecurityPolicy(ctx context.Context) ([]byte, error) {
+	return gitprovider.ReadRepoFile(ctx, r.files, r.r.GetDefaultBranch(), securityPolicyPath)
+}
+
+// githubTemplateLayout describes where GitHub expects the templates, see https://docs.github.com/en/communities/using-templates-to-encourage-useful-issues-and-pull-requests
+//
+//nolint:gochecknoglobals
+var githubTemplateLayout = gitprovider.TemplateLayout{
+	PullRequestTemplate: ".github/PULL_REQUEST_TEMPLATE.md",
+	IssueTemplatesDir:   ".github/ISSUE_TEMPLATE",
+}
+
+// githubTemplateConventions describes where GitHub looks for the templates. The pull request
+// templates may be in the root, docs or .github directory.
+//
+//nolint:gochecknoglobals
+var githubTemplateConventions = gitprovider.TemplateConventions{
+	PullRequestTemplates: []string{
+		"pull_request_template.md",
+		"docs/pull_request_template.md",
+		".github/pull_request_template.md",
+	},
+	PullRequestTemplateDirs: []string{
+		"PULL_REQUEST_TEMPLATE",
+		"docs/PULL_REQUEST_TEMPLATE",
+		".github/PULL_REQUEST_TEMPLATE",
+	},
+	IssueTemplates: []string{
+		"issue_template.md",
+		"docs/issue_template.md",
+		".github/issue_template.md",
+	},
+	IssueTemplateDirs: []string{
+		".github/ISSUE_TEMPLATE",
+	},
+}
+
 // validateRepositoryAPI validates the apiObj received from the server, to make sure that it is
 // valid for our use.
 func validateRepositoryAPI(apiObj *github.Repository) error {
@@ -260,7 +864,7 @@ func validateRepositoryAPI(apiObj *github.Repository) error {
 	})
 }
 
-func repositoryFromAPI(apiObj *github.Repository) gitprovider.RepositoryInfo {
+func repositoryFromAPI(apiObj *github.Repository, domain string) gitprovider.RepositoryInfo {
 	repo := gitprovider.RepositoryInfo{
 		Description:   apiObj.Description,
 		DefaultBranch: apiObj.DefaultBranch,
@@ -268,9 +872,71 @@ func repositoryFromAPI(apiObj *github.Repository) gitprovider.RepositoryInfo {
 	if apiObj.Visibility != nil {
 		repo.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibility(*apiObj.Visibility))
 	}
+	if apiObj.Parent != nil {
+		repo.Parent = repositoryRefFromAPI(apiObj.Parent, domain)
+	}
+	if apiObj.Source != nil {
+		repo.Source = repositoryRefFromAPI(apiObj.Source, domain)
+	}
 	return repo
 }
 
+// repositoryRefFromAPI returns a reference to the given repository, owned by either a user or
+// an organization.
+func repositoryRefFromAPI(apiObj *github.Repository, domain string) gitprovider.RepositoryRef {
+	owner := apiObj.GetOwner()
+	if owner.GetType() == "Organization" {
+		return gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: owner.GetLogin()},
+			RepositoryName:  apiObj.GetName(),
+		}
+	}
+	return gitprovider.UserRepositoryRef{
+		UserRef:        gitprovider.UserRef{Domain: domain, UserLogin: owner.GetLogin()},
+		RepositoryName: apiObj.GetName(),
+	}
+}
+
+// repoCountsFromAPI maps the counts of a repository. GitHub counts open pull requests as open
+// issues, so the open issues are derived by subtracting the pull requests, which makes them
+// approximate as both counts are read at different times.
+func repoCountsFromAPI(apiObj *github.Repository, pulls, branches, tags int) gitprovider.RepoCounts {
+	counts := gitprovider.RepoCounts{
+		OpenPullRequests: gitprovider.ItemCount{Count: gitprovider.IntVar(pulls), Exact: true},
+		Branches:         gitprovider.ItemCount{Count: gitprovider.IntVar(branches), Exact: true},
+		Tags:             gitprovider.ItemCount{Count: gitprovider.IntVar(tags), Exact: true},
+	}
+	if apiObj.OpenIssuesCount != nil {
+		counts.OpenIssues.Count = gitprovider.IntVar(max(apiObj.GetOpenIssuesCount()-pulls, 0))
+	}
+	return counts
+}
+
+func licenseFromAPI(apiObj *github.RepositoryLicense) gitprovider.LicenseInfo {
+	license := gitprovider.LicenseInfo{
+		Path: apiObj.GetPath(),
+	}
+	if apiObj.License != nil {
+		license.SPDXID = apiObj.License.GetSPDXID()
+		license.Name = apiObj.License.GetName()
+	}
+	return license
+}
+
+// permissionLevelFromAPI maps the permissions of the authenticated user on a repository,
+// e.g. {"admin": false, "push": true, "pull": true}, to a PermissionLevel.
+func permissionLevelFromAPI(permissions map[string]bool) gitprovider.PermissionLevel {
+	switch {
+	case permissions["admin"]:
+		return gitprovider.PermissionLevelAdmin
+	case permissions["maintain"], permissions["push"]:
+		return gitprovider.PermissionLevelWrite
+	case permissions["triage"], permissions["pull"]:
+		return gitprovider.PermissionLevelRead
+	}
+	return gitprovider.PermissionLevelNone
+}
+
 func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) github.Repository {
 	apiObj := github.Repository{
 		Name: gitprovider.StringVar(ref.GetRepository()),
@@ -286,7 +952,7 @@ func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *github.Rep
 	if repo.DefaultBranch != nil {
 		apiObj.DefaultBranch = repo.DefaultBranch
 	}
-	if repo.Visibility != nil {
+	if repo.Visibility != nil && *repo.Visibility != gitprovider.RepositoryVisibilityDefault {
 		apiObj.Visibility = gitprovider.StringVar(string(*repo.Visibility))
 	}
 }
@@ -301,7 +967,7 @@ func updateApiObjWithRepositoryInfo(repo *gitprovider.RepositoryInfo, apiObj *gi
 	if repo.DefaultBranch != nil {
 		desired.DefaultBranch = repo.DefaultBranch
 	}
-	if repo.Visibility != nil {
+	if repo.Visibility != nil && *repo.Visibility != gitprovider.RepositoryVisibilityDefault {
 		desired.Visibility = gitprovider.StringVar(string(*repo.Visibility))
 	}
 
@@ -372,3 +1038,18 @@ func updateGithubRepository(desired, actual *github.Repository) *github.Reposito
 
 	return u
 }
+
+// Rename renames the repository by editing its name. GitHub redirects the old name to the
+// renamed repository.
+func (r *orgRepository) Rename(ctx context.Context, newName string) (gitprovider.OrgRepository, error) {
+	// PATCH /repos/{owner}/{repo}
+	apiObj, err := r.c.UpdateRepo(ctx, r.ref.GetIdentity(), r.ref.GetRepository(), &github.Repository{
+		Name: &newName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ref := r.ref.(gitprovider.OrgRepositoryRef)
+	ref.RepositoryName = apiObj.GetName()
+	return newOrgRepository(r.clientContext, apiObj, ref), nil
+}
@@ -0,0 +1,1084 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_permissionLevelFromAPI(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions map[string]bool
+		want        gitprovider.PermissionLevel
+	}{
+		{
+			name: "no permissions",
+			want: gitprovider.PermissionLevelNone,
+		},
+		{
+			name:        "pull",
+			permissions: map[string]bool{"admin": false, "maintain": false, "push": false, "triage": false, "pull": true},
+			want:        gitprovider.PermissionLevelRead,
+		},
+		{
+			name:        "maintain",
+			permissions: map[string]bool{"admin": false, "maintain": true, "push": true, "triage": true, "pull": true},
+			want:        gitprovider.PermissionLevelWrite,
+		},
+		{
+			name:        "admin",
+			permissions: map[string]bool{"admin": true, "maintain": true, "push": true, "triage": true, "pull": true},
+			want:        gitprovider.PermissionLevelAdmin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := permissionLevelFromAPI(tt.permissions); got != tt.want {
+				t.Errorf("permissionLevelFromAPI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateRepository_DefaultVisibility(t *testing.T) {
+	tests := []struct {
+		name           string
+		visibility     gitprovider.RepositoryVisibility
+		wantVisibility interface{}
+	}{
+		{
+			name:           "visibility is omitted for the default sentinel",
+			visibility:     gitprovider.RepositoryVisibilityDefault,
+			wantVisibility: nil,
+		},
+		{
+			name:           "visibility is sent otherwise",
+			visibility:     gitprovider.RepositoryVisibilityInternal,
+			wantVisibility: "internal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var payload map[string]interface{}
+			mux.HandleFunc("/orgs/fluxcd/repos", func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"name": "repo", "visibility": "private"}`)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			_, err := client.OrgRepositories().Create(context.Background(), ref, gitprovider.RepositoryInfo{
+				Visibility: gitprovider.RepositoryVisibilityVar(tt.visibility),
+			})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if got := payload["visibility"]; got != tt.wantVisibility {
+				t.Errorf("visibility = %v, want %v", got, tt.wantVisibility)
+			}
+			if _, ok := payload["private"]; ok && tt.wantVisibility == nil {
+				t.Errorf("private shouldn't be set, got %v", payload["private"])
+			}
+		})
+	}
+}
+
+func TestOrgRepository_SetSubscription(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription gitprovider.SubscriptionInfo
+		wantMethod   string
+		wantPayload  map[string]interface{}
+		wantErr      bool
+	}{
+		{
+			name:         "watch",
+			subscription: gitprovider.SubscriptionInfo{Subscribed: true},
+			wantMethod:   http.MethodPut,
+			wantPayload:  map[string]interface{}{"subscribed": true, "ignored": false},
+		},
+		{
+			name:         "ignore",
+			subscription: gitprovider.SubscriptionInfo{Ignored: true},
+			wantMethod:   http.MethodPut,
+			wantPayload:  map[string]interface{}{"subscribed": false, "ignored": true},
+		},
+		{
+			name:         "reset",
+			subscription: gitprovider.SubscriptionInfo{},
+			wantMethod:   http.MethodDelete,
+		},
+		{
+			name:         "watch and ignore",
+			subscription: gitprovider.SubscriptionInfo{Subscribed: true, Ignored: true},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var payload map[string]interface{}
+			mux.HandleFunc("/repos/fluxcd/repo/subscription", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tt.wantMethod {
+					t.Errorf("method = %s, want %s", r.Method, tt.wantMethod)
+				}
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				fmt.Fprint(w, `{"subscribed": true, "ignored": false}`)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+			err := repo.SetSubscription(context.Background(), tt.subscription)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_Subscribers(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo/subscribers", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", `<https://api.github.com/repos/fluxcd/repo/subscribers?page=2>; rel="next"`)
+			fmt.Fprint(w, `[{"login": "alice"}, {"login": "bob"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"login": "carol"}]`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+	got, err := repo.Subscribers(context.Background())
+	if err != nil {
+		t.Fatalf("Subscribers() error = %v", err)
+	}
+	want := []gitprovider.UserRef{
+		{Domain: DefaultDomain, UserLogin: "alice"},
+		{Domain: DefaultDomain, UserLogin: "bob"},
+		{Domain: DefaultDomain, UserLogin: "carol"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Subscribers() (-want +got):\n%s", diff)
+	}
+}
+
+func Test_repoCountsFromAPI(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiObj *github.Repository
+		pulls  int
+		want   gitprovider.RepoCounts
+	}{
+		{
+			name:   "open issues include pull requests",
+			apiObj: &github.Repository{OpenIssuesCount: github.Int(7)},
+			pulls:  3,
+			want: gitprovider.RepoCounts{
+				OpenPullRequests: gitprovider.ItemCount{Count: gitprovider.IntVar(3), Exact: true},
+				OpenIssues:       gitprovider.ItemCount{Count: gitprovider.IntVar(4)},
+				Branches:         gitprovider.ItemCount{Count: gitprovider.IntVar(2), Exact: true},
+				Tags:             gitprovider.ItemCount{Count: gitprovider.IntVar(1), Exact: true},
+			},
+		},
+		{
+			name:   "pull requests opened since the repository was read",
+			apiObj: &github.Repository{OpenIssuesCount: github.Int(2)},
+			pulls:  3,
+			want: gitprovider.RepoCounts{
+				OpenPullRequests: gitprovider.ItemCount{Count: gitprovider.IntVar(3), Exact: true},
+				OpenIssues:       gitprovider.ItemCount{Count: gitprovider.IntVar(0)},
+				Branches:         gitprovider.ItemCount{Count: gitprovider.IntVar(2), Exact: true},
+				Tags:             gitprovider.ItemCount{Count: gitprovider.IntVar(1), Exact: true},
+			},
+		},
+		{
+			name:   "no open issues count",
+			apiObj: &github.Repository{},
+			want: gitprovider.RepoCounts{
+				OpenPullRequests: gitprovider.ItemCount{Count: gitprovider.IntVar(0), Exact: true},
+				Branches:         gitprovider.ItemCount{Count: gitprovider.IntVar(2), Exact: true},
+				Tags:             gitprovider.ItemCount{Count: gitprovider.IntVar(1), Exact: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := repoCountsFromAPI(tt.apiObj, tt.pulls, 2, 1)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("repoCountsFromAPI() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_repositoryFromAPI_Fork(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		wantParent gitprovider.RepositoryRef
+		wantSource gitprovider.RepositoryRef
+	}{
+		{
+			name:     "not a fork",
+			response: `{"name": "flux2", "fork": false}`,
+		},
+		{
+			name: "fork of a fork",
+			response: `{
+				"name": "flux2",
+				"fork": true,
+				"parent": {"name": "flux2", "owner": {"login": "stefanprodan", "type": "User"}},
+				"source": {"name": "flux2", "owner": {"login": "fluxcd", "type": "Organization"}}
+			}`,
+			wantParent: gitprovider.UserRepositoryRef{
+				UserRef:        gitprovider.UserRef{Domain: "github.com", UserLogin: "stefanprodan"},
+				RepositoryName: "flux2",
+			},
+			wantSource: gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "github.com", Organization: "fluxcd"},
+				RepositoryName:  "flux2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiObj := &github.Repository{}
+			if err := json.Unmarshal([]byte(tt.response), apiObj); err != nil {
+				t.Fatal(err)
+			}
+			got := repositoryFromAPI(apiObj, "github.com")
+			if !reflect.DeepEqual(got.Parent, tt.wantParent) {
+				t.Errorf("repositoryFromAPI() Parent = %v, want %v", got.Parent, tt.wantParent)
+			}
+			if !reflect.DeepEqual(got.Source, tt.wantSource) {
+				t.Errorf("repositoryFromAPI() Source = %v, want %v", got.Source, tt.wantSource)
+			}
+			// The fork network is ignored when reconciling
+			if !(gitprovider.RepositoryInfo{}).Equals(gitprovider.RepositoryInfo{Parent: got.Parent, Source: got.Source}) {
+				t.Error("RepositoryInfo.Equals() compared the fork network")
+			}
+		})
+	}
+}
+
+func TestOrgRepository_Counts(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/repos/fluxcd/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "repo", "open_issues_count": 12}`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per_page"); got != "1" {
+			t.Errorf("per_page = %q, want %q", got, "1")
+		}
+		if got := r.URL.Query().Get("state"); got != "open" {
+			t.Errorf("state = %q, want %q", got, "open")
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/fluxcd/repo/pulls?per_page=1&page=2>; rel="next", <https://api.github.com/repos/fluxcd/repo/pulls?per_page=1&page=5>; rel="last"`)
+		fmt.Fprint(w, `[{"number": 1}]`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name": "main"}]`)
+	})
+	mux.HandleFunc("/repos/fluxcd/repo/tags", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+	got, err := repo.Counts(context.Background())
+	if err != nil {
+		t.Fatalf("Counts() error = %v", err)
+	}
+	want := gitprovider.RepoCounts{
+		OpenPullRequests: gitprovider.ItemCount{Count: gitprovider.IntVar(5), Exact: true},
+		OpenIssues:       gitprovider.ItemCount{Count: gitprovider.IntVar(7)},
+		Branches:         gitprovider.ItemCount{Count: gitprovider.IntVar(1), Exact: true},
+		Tags:             gitprovider.ItemCount{Count: gitprovider.IntVar(0), Exact: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Counts() (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrgRepository_CommitActivity(t *testing.T) {
+	oldInterval := statsPollInterval
+	statsPollInterval = time.Millisecond
+	t.Cleanup(func() { statsPollInterval = oldInterval })
+
+	mux, client := setup(t)
+	requests := 0
+	mux.HandleFunc("/repos/fluxcd/repo/stats/commit_activity", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// GitHub computes the statistics in the background on the first request
+		if requests == 1 {
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprint(w, `[
+			{"days": [0, 3, 26, 20, 39, 1, 0], "total": 89, "week": 1336280400},
+			{"days": [0, 1, 0, 0, 0, 0, 0], "total": 1, "week": 1336885200}
+		]`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+	got, err := repo.CommitActivity(context.Background())
+	if err != nil {
+		t.Fatalf("CommitActivity() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("CommitActivity() made %d requests, want 2", requests)
+	}
+	want := []gitprovider.WeeklyActivity{
+		{Week: time.Unix(1336280400, 0).UTC(), Total: 89, Days: [7]int{0, 3, 26, 20, 39, 1, 0}},
+		{Week: time.Unix(1336885200, 0).UTC(), Total: 1, Days: [7]int{0, 1, 0, 0, 0, 0, 0}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CommitActivity() (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrgRepository_CommitActivity_NotReady(t *testing.T) {
+	oldInterval := statsPollInterval
+	statsPollInterval = time.Millisecond
+	t.Cleanup(func() { statsPollInterval = oldInterval })
+
+	mux, client := setup(t)
+	requests := 0
+	mux.HandleFunc("/repos/fluxcd/repo/stats/commit_activity", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{}`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+	_, err := repo.CommitActivity(context.Background())
+	if !errors.Is(err, gitprovider.ErrNotReady) {
+		t.Fatalf("CommitActivity() error = %v, want %v", err, gitprovider.ErrNotReady)
+	}
+	if requests != statsMaxPolls {
+		t.Errorf("CommitActivity() made %d requests, want %d", requests, statsMaxPolls)
+	}
+}
+
+func TestOrgRepository_ReconcileMergeQueue(t *testing.T) {
+	const existingRuleset = `{
+		"id": 42,
+		"name": "merge-queue-main",
+		"target": "branch",
+		"enforcement": "active",
+		"conditions": {"ref_name": {"include": ["refs/heads/main"], "exclude": []}},
+		"rules": [{
+			"type": "merge_queue",
+			"parameters": {
+				"check_response_timeout_minutes": 60,
+				"grouping_strategy": "ALLGREEN",
+				"max_entries_to_build": 10,
+				"max_entries_to_merge": 5,
+				"merge_method": "MERGE",
+				"min_entries_to_merge": 1,
+				"min_entries_to_merge_wait_minutes": 5
+			}
+		}]
+	}`
+	tests := []struct {
+		name            string
+		existing        bool
+		req             gitprovider.MergeQueueInfo
+		wantActionTaken bool
+		wantMethod      string
+		wantParams      map[string]interface{}
+	}{
+		{
+			name:     "no-op",
+			existing: true,
+			req:      gitprovider.MergeQueueInfo{Enabled: true},
+		},
+		{
+			name:            "update",
+			existing:        true,
+			req:             gitprovider.MergeQueueInfo{Enabled: true, MergeMethod: gitprovider.MergeMethodVar(gitprovider.MergeMethodSquash)},
+			wantActionTaken: true,
+			wantMethod:      http.MethodPut,
+			wantParams: map[string]interface{}{
+				"check_response_timeout_minutes":    float64(60),
+				"grouping_strategy":                 "ALLGREEN",
+				"max_entries_to_build":              float64(10),
+				"max_entries_to_merge":              float64(5),
+				"merge_method":                      "SQUASH",
+				"min_entries_to_merge":              float64(1),
+				"min_entries_to_merge_wait_minutes": float64(5),
+			},
+		},
+		{
+			name:            "create",
+			req:             gitprovider.MergeQueueInfo{Enabled: true},
+			wantActionTaken: true,
+			wantMethod:      http.MethodPost,
+			wantParams: map[string]interface{}{
+				"check_response_timeout_minutes":    float64(60),
+				"grouping_strategy":                 "ALLGREEN",
+				"max_entries_to_build":              float64(5),
+				"max_entries_to_merge":              float64(5),
+				"merge_method":                      "MERGE",
+				"min_entries_to_merge":              float64(1),
+				"min_entries_to_merge_wait_minutes": float64(5),
+			},
+		},
+		{
+			name:            "disable",
+			existing:        true,
+			req:             gitprovider.MergeQueueInfo{},
+			wantActionTaken: true,
+			wantMethod:      http.MethodDelete,
+		},
+		{
+			name: "disabled no-op",
+			req:  gitprovider.MergeQueueInfo{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var gotMethod string
+			var payload struct {
+				Rules []struct {
+					Type       string                 `json:"type"`
+					Parameters map[string]interface{} `json:"parameters"`
+				} `json:"rules"`
+			}
+			record := func(r *http.Request) {
+				gotMethod = r.Method
+				if r.Method == http.MethodDelete {
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+			}
+			mux.HandleFunc("/repos/fluxcd/repo/rulesets", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					if tt.existing {
+						fmt.Fprint(w, `[{"id": 42, "name": "merge-queue-main", "enforcement": "active"}]`)
+					} else {
+						fmt.Fprint(w, `[{"id": 7, "name": "other", "enforcement": "active"}]`)
+					}
+					return
+				}
+				record(r)
+				fmt.Fprint(w, existingRuleset)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/rulesets/42", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					fmt.Fprint(w, existingRuleset)
+					return
+				}
+				record(r)
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				fmt.Fprint(w, existingRuleset)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+			actionTaken, err := repo.ReconcileMergeQueue(context.Background(), "main", tt.req)
+			if err != nil {
+				t.Fatalf("ReconcileMergeQueue() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("ReconcileMergeQueue() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if gotMethod != tt.wantMethod {
+				t.Errorf("ReconcileMergeQueue() sent %q, want %q", gotMethod, tt.wantMethod)
+			}
+			if tt.wantParams == nil {
+				return
+			}
+			if len(payload.Rules) != 1 || payload.Rules[0].Type != "merge_queue" {
+				t.Fatalf("ReconcileMergeQueue() rules = %+v, want a single merge_queue rule", payload.Rules)
+			}
+			if diff := cmp.Diff(tt.wantParams, payload.Rules[0].Parameters); diff != "" {
+				t.Errorf("merge queue parameters (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_ReconcileBranchMergeMethods(t *testing.T) {
+	const existingRuleset = `{
+		"id": 42,
+		"name": "merge-methods-main",
+		"target": "branch",
+		"enforcement": "active",
+		"conditions": {"ref_name": {"include": ["refs/heads/main"], "exclude": []}},
+		"rules": [{
+			"type": "pull_request",
+			"parameters": {
+				"allowed_merge_methods": ["squash"],
+				"dismiss_stale_reviews_on_push": false,
+				"require_code_owner_review": false,
+				"require_last_push_approval": false,
+				"required_approving_review_count": 2,
+				"required_review_thread_resolution": false
+			}
+		}]
+	}`
+	tests := []struct {
+		name            string
+		existing        bool
+		req             gitprovider.BranchMergeMethodsInfo
+		wantActionTaken bool
+		wantMethod      string
+		wantParams      map[string]interface{}
+	}{
+		{
+			name:     "no-op",
+			existing: true,
+			req:      gitprovider.BranchMergeMethodsInfo{AllowedMethods: []gitprovider.MergeMethod{gitprovider.MergeMethodSquash}},
+		},
+		{
+			name:            "update",
+			existing:        true,
+			req:             gitprovider.BranchMergeMethodsInfo{AllowedMethods: []gitprovider.MergeMethod{gitprovider.MergeMethodSquash, gitprovider.MergeMethodMerge}},
+			wantActionTaken: true,
+			wantMethod:      http.MethodPut,
+			wantParams: map[string]interface{}{
+				"allowed_merge_methods":             []interface{}{"squash", "merge"},
+				"dismiss_stale_reviews_on_push":     false,
+				"require_code_owner_review":         false,
+				"require_last_push_approval":        false,
+				"required_approving_review_count":   float64(2),
+				"required_review_thread_resolution": false,
+			},
+		},
+		{
+			name:            "create",
+			req:             gitprovider.BranchMergeMethodsInfo{AllowedMethods: []gitprovider.MergeMethod{gitprovider.MergeMethodSquash}},
+			wantActionTaken: true,
+			wantMethod:      http.MethodPost,
+			wantParams: map[string]interface{}{
+				"allowed_merge_methods":             []interface{}{"squash"},
+				"dismiss_stale_reviews_on_push":     false,
+				"require_code_owner_review":         false,
+				"require_last_push_approval":        false,
+				"required_approving_review_count":   float64(0),
+				"required_review_thread_resolution": false,
+			},
+		},
+		{
+			name:            "remove",
+			existing:        true,
+			req:             gitprovider.BranchMergeMethodsInfo{},
+			wantActionTaken: true,
+			wantMethod:      http.MethodDelete,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var gotMethod string
+			var payload struct {
+				Rules []struct {
+					Type       string                 `json:"type"`
+					Parameters map[string]interface{} `json:"parameters"`
+				} `json:"rules"`
+			}
+			record := func(r *http.Request) {
+				gotMethod = r.Method
+				if r.Method == http.MethodDelete {
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+			}
+			mux.HandleFunc("/repos/fluxcd/repo/rulesets", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					if tt.existing {
+						fmt.Fprint(w, `[{"id": 42, "name": "merge-methods-main", "enforcement": "active"}]`)
+					} else {
+						fmt.Fprint(w, `[{"id": 7, "name": "merge-queue-main", "enforcement": "active"}]`)
+					}
+					return
+				}
+				record(r)
+				fmt.Fprint(w, existingRuleset)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/rulesets/42", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					fmt.Fprint(w, existingRuleset)
+					return
+				}
+				record(r)
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				fmt.Fprint(w, existingRuleset)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+			actionTaken, err := repo.ReconcileBranchMergeMethods(context.Background(), "main", tt.req)
+			if err != nil {
+				t.Fatalf("ReconcileBranchMergeMethods() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("ReconcileBranchMergeMethods() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if gotMethod != tt.wantMethod {
+				t.Errorf("ReconcileBranchMergeMethods() sent %q, want %q", gotMethod, tt.wantMethod)
+			}
+			if tt.wantParams == nil {
+				return
+			}
+			if len(payload.Rules) != 1 || payload.Rules[0].Type != "pull_request" {
+				t.Fatalf("ReconcileBranchMergeMethods() rules = %+v, want a single pull_request rule", payload.Rules)
+			}
+			if diff := cmp.Diff(tt.wantParams, payload.Rules[0].Parameters); diff != "" {
+				t.Errorf("pull request rule parameters (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_ReconcileWorkflowPermissions(t *testing.T) {
+	tests := []struct {
+		name            string
+		actual          string
+		req             gitprovider.WorkflowPermissionsInfo
+		wantActionTaken bool
+		wantPayload     map[string]interface{}
+	}{
+		{
+			name:   "no-op",
+			actual: `{"default_workflow_permissions": "read", "can_approve_pull_request_reviews": false}`,
+			req:    gitprovider.WorkflowPermissionsInfo{},
+		},
+		{
+			name:   "tighten write to read",
+			actual: `{"default_workflow_permissions": "write", "can_approve_pull_request_reviews": true}`,
+			req: gitprovider.WorkflowPermissionsInfo{
+				DefaultPermission: gitprovider.WorkflowPermissionVar(gitprovider.WorkflowPermissionRead),
+			},
+			wantActionTaken: true,
+			wantPayload: map[string]interface{}{
+				"default_workflow_permissions":     "read",
+				"can_approve_pull_request_reviews": false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var gotPayload map[string]interface{}
+			mux.HandleFunc("/repos/fluxcd/repo/actions/permissions/workflow", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, tt.actual)
+				case http.MethodPut:
+					if err := json.NewDecoder(r.Body).Decode(&gotPayload); err != nil {
+						t.Fatalf("failed to decode the request body: %v", err)
+					}
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected %s request", r.Method)
+				}
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+			actionTaken, err := repo.ReconcileWorkflowPermissions(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("ReconcileWorkflowPermissions() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("ReconcileWorkflowPermissions() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(tt.wantPayload, gotPayload); diff != "" {
+				t.Errorf("workflow permissions payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_ReconcileCodeScanningDefaultSetup(t *testing.T) {
+	tests := []struct {
+		name            string
+		actual          string
+		req             gitprovider.CodeScanningDefaultSetupInfo
+		wantActionTaken bool
+		wantPayload     map[string]interface{}
+	}{
+		{
+			name:   "enable default setup",
+			actual: `{"state": "not-configured", "languages": [], "query_suite": "default"}`,
+			req: gitprovider.CodeScanningDefaultSetupInfo{
+				Enabled: true,
+			},
+			wantActionTaken: true,
+			wantPayload: map[string]interface{}{
+				"state":       "configured",
+				"query_suite": "default",
+			},
+		},
+		{
+			name:   "change the query suite",
+			actual: `{"state": "configured", "languages": ["go", "python"], "query_suite": "default"}`,
+			req: gitprovider.CodeScanningDefaultSetupInfo{
+				Enabled:    true,
+				Languages:  []string{"python", "go"},
+				QuerySuite: gitprovider.CodeScanningQuerySuiteVar(gitprovider.CodeScanningQuerySuiteExtended),
+			},
+			wantActionTaken: true,
+			wantPayload: map[string]interface{}{
+				"state":       "configured",
+				"query_suite": "extended",
+				"languages":   []interface{}{"python", "go"},
+			},
+		},
+		{
+			name:   "no-op with the detected languages",
+			actual: `{"state": "configured", "languages": ["go"], "query_suite": "extended"}`,
+			req: gitprovider.CodeScanningDefaultSetupInfo{
+				Enabled:    true,
+				QuerySuite: gitprovider.CodeScanningQuerySuiteVar(gitprovider.CodeScanningQuerySuiteExtended),
+			},
+		},
+		{
+			name:            "disable default setup",
+			actual:          `{"state": "configured", "languages": ["go"], "query_suite": "extended"}`,
+			req:             gitprovider.CodeScanningDefaultSetupInfo{},
+			wantActionTaken: true,
+			wantPayload: map[string]interface{}{
+				"state": "not-configured",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var gotPayload map[string]interface{}
+			mux.HandleFunc("/repos/fluxcd/repo/code-scanning/default-setup", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, tt.actual)
+				case http.MethodPatch:
+					if err := json.NewDecoder(r.Body).Decode(&gotPayload); err != nil {
+						t.Fatalf("failed to decode the request body: %v", err)
+					}
+					// GitHub schedules the update in the background
+					w.WriteHeader(http.StatusAccepted)
+					fmt.Fprint(w, `{"run_id": 42, "run_url": "https://api.github.com/repos/fluxcd/repo/actions/runs/42"}`)
+				default:
+					t.Errorf("unexpected %s request", r.Method)
+				}
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+			actionTaken, err := repo.ReconcileCodeScanningDefaultSetup(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("ReconcileCodeScanningDefaultSetup() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("ReconcileCodeScanningDefaultSetup() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(tt.wantPayload, gotPayload); diff != "" {
+				t.Errorf("code scanning default setup payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Reconcile_Precondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		concurrent func(repo *github.Repository) *github.Repository
+		wantErr    error
+		wantWrite  bool
+	}{
+		{
+			name:       "unchanged since observed",
+			concurrent: func(repo *github.Repository) *github.Repository { return repo },
+			wantWrite:  true,
+		},
+		{
+			name: "changed since observed",
+			concurrent: func(repo *github.Repository) *github.Repository {
+				repo.Description = github.String("changed concurrently")
+				return repo
+			},
+			wantErr: gitprovider.ErrPreconditionFailed,
+		},
+		{
+			name:       "deleted since observed",
+			concurrent: func(*github.Repository) *github.Repository { return nil },
+			wantErr:    gitprovider.ErrPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			repo := &github.Repository{
+				Name:          github.String("repo"),
+				Description:   github.String("observed"),
+				DefaultBranch: github.String("main"),
+				Visibility:    github.String("private"),
+			}
+			wrote := false
+			mux.HandleFunc("/repos/fluxcd/repo", func(w http.ResponseWriter, r *http.Request) {
+				if repo == nil {
+					http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+					return
+				}
+				if r.Method == http.MethodPatch {
+					wrote = true
+					if err := json.NewDecoder(r.Body).Decode(repo); err != nil {
+						t.Fatalf("failed to decode the request body: %v", err)
+					}
+				}
+				_ = json.NewEncoder(w).Encode(repo)
+			})
+			mux.HandleFunc("/orgs/fluxcd/repos", func(w http.ResponseWriter, r *http.Request) {
+				wrote = true
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"name": "repo"}`)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			observed, err := client.OrgRepositories().Get(context.Background(), ref)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			expected := observed.Get()
+			repo = tt.concurrent(repo)
+
+			req := expected
+			req.Description = github.String("desired")
+			_, actionTaken, err := client.OrgRepositories().Reconcile(context.Background(), ref, req, &gitprovider.RepositoryReconcileOptions{
+				Expected: &expected,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reconcile() error = %v, want %v", err, tt.wantErr)
+			}
+			if actionTaken != tt.wantWrite || wrote != tt.wantWrite {
+				t.Errorf("Reconcile() actionTaken = %v, wrote = %v, want %v", actionTaken, wrote, tt.wantWrite)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_IsDefaultBranchProtected(t *testing.T) {
+	for _, protected := range []bool{true, false} {
+		t.Run(fmt.Sprintf("protected=%t", protected), func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/repos/fluxcd/repo/branches/main", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"name": "main", "protected": %t}`, protected)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo"), DefaultBranch: github.String("main")}, ref)
+			got, err := repo.IsDefaultBranchProtected(context.Background())
+			if err != nil {
+				t.Fatalf("IsDefaultBranchProtected() error = %v", err)
+			}
+			if got != protected {
+				t.Errorf("IsDefaultBranchProtected() = %t, want %t", got, protected)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_ReconcileEnvironmentProtection(t *testing.T) {
+	const customEnvironment = `{
+		"name": "production",
+		"deployment_branch_policy": {"protected_branches": false, "custom_branch_policies": true},
+		"protection_rules": [{"id": 1, "type": "branch_policy"}]
+	}`
+	const openEnvironment = `{
+		"name": "production",
+		"can_admins_bypass": false,
+		"protection_rules": [
+			{"id": 2, "type": "wait_timer", "wait_timer": 30},
+			{"id": 3, "type": "required_reviewers", "prevent_self_review": true, "reviewers": [{"type": "Team", "reviewer": {"id": 7, "slug": "ops"}}]}
+		]
+	}`
+	tests := []struct {
+		name            string
+		environment     string
+		req             gitprovider.EnvironmentProtectionInfo
+		wantActionTaken bool
+		wantWrites      []string
+	}{
+		{
+			name:        "no-op",
+			environment: customEnvironment,
+			req: gitprovider.EnvironmentProtectionInfo{
+				BranchPatterns:   []string{"release/*", "main"},
+				TagPatterns:      []string{"v*"},
+				CustomRuleAppIDs: []int64{99},
+			},
+		},
+		{
+			name:        "add a pattern",
+			environment: customEnvironment,
+			req: gitprovider.EnvironmentProtectionInfo{
+				BranchPatterns:   []string{"main", "release/*", "hotfix/*"},
+				TagPatterns:      []string{"v*"},
+				CustomRuleAppIDs: []int64{99},
+			},
+			wantActionTaken: true,
+			wantWrites: []string{
+				`POST /repos/fluxcd/repo/environments/production/deployment-branch-policies {"name":"hotfix/*","type":"branch"}`,
+			},
+		},
+		{
+			name:        "restrict an environment open to any ref",
+			environment: openEnvironment,
+			req: gitprovider.EnvironmentProtectionInfo{
+				BranchPatterns:   []string{"main"},
+				CustomRuleAppIDs: []int64{99},
+			},
+			wantActionTaken: true,
+			wantWrites: []string{
+				`PUT /repos/fluxcd/repo/environments/production {"can_admins_bypass":false,"deployment_branch_policy":{"custom_branch_policies":true,"protected_branches":false},"prevent_self_review":true,"reviewers":[{"id":7,"type":"Team"}],"wait_timer":30}`,
+				`POST /repos/fluxcd/repo/environments/production/deployment-branch-policies {"name":"main","type":"branch"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			gotWrites := []string{}
+			recordWrite := func(r *http.Request) {
+				body := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				encoded, _ := json.Marshal(body)
+				gotWrites = append(gotWrites, r.Method+" "+r.URL.Path+" "+string(encoded))
+			}
+			mux.HandleFunc("/repos/fluxcd/repo/environments/production", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					recordWrite(r)
+				}
+				fmt.Fprint(w, tt.environment)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/environments/production/deployment-branch-policies", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					recordWrite(r)
+					fmt.Fprint(w, `{"id": 10}`)
+					return
+				}
+				fmt.Fprint(w, `{"total_count": 3, "branch_policies": [
+					{"id": 1, "name": "main", "type": "branch"},
+					{"id": 2, "name": "release/*", "type": "branch"},
+					{"id": 3, "name": "v*", "type": "tag"}
+				]}`)
+			})
+			mux.HandleFunc("/repos/fluxcd/repo/environments/production/deployment_protection_rules", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected %s on %s", r.Method, r.URL.Path)
+				}
+				fmt.Fprint(w, `{"total_count": 1, "custom_deployment_protection_rules": [{"id": 5, "enabled": true, "app": {"id": 99, "slug": "checks"}}]}`)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newOrgRepository(client.clientContext, &github.Repository{Name: github.String("repo")}, ref)
+			actionTaken, err := repo.ReconcileEnvironmentProtection(context.Background(), "production", tt.req)
+			if err != nil {
+				t.Fatalf("ReconcileEnvironmentProtection() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("ReconcileEnvironmentProtection() actionTaken = %t, want %t", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(append([]string{}, tt.wantWrites...), gotWrites); diff != "" {
+				t.Errorf("ReconcileEnvironmentProtection() writes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
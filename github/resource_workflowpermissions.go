@@ -0,0 +1,38 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func workflowPermissionsFromAPI(apiObj *github.DefaultWorkflowPermissionRepository) gitprovider.WorkflowPermissionsInfo {
+	return gitprovider.WorkflowPermissionsInfo{
+		DefaultPermission:            gitprovider.WorkflowPermissionVar(gitprovider.WorkflowPermission(apiObj.GetDefaultWorkflowPermissions())),
+		CanApprovePullRequestReviews: gitprovider.BoolVar(apiObj.GetCanApprovePullRequestReviews()),
+	}
+}
+
+// workflowPermissionsToAPI converts the defaulted req to its API object.
+func workflowPermissionsToAPI(req gitprovider.WorkflowPermissionsInfo) *github.DefaultWorkflowPermissionRepository {
+	return &github.DefaultWorkflowPermissionRepository{
+		DefaultWorkflowPermissions:   github.String(string(*req.DefaultPermission)),
+		CanApprovePullRequestReviews: req.CanApprovePullRequestReviews,
+	}
+}
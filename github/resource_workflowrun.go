@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"github.com/google/go-github/v66/github"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newWorkflowRun(c *WorkflowClient, apiObj *github.WorkflowRun) *workflowRun {
+	return &workflowRun{
+		r: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.WorkflowRun = &workflowRun{}
+
+type workflowRun struct {
+	r github.WorkflowRun
+	c *WorkflowClient
+}
+
+func (r *workflowRun) Get() gitprovider.WorkflowRunInfo {
+	return workflowRunFromAPI(&r.r)
+}
+
+func (r *workflowRun) APIObject() interface{} {
+	return &r.r
+}
+
+func (r *workflowRun) Repository() gitprovider.RepositoryRef {
+	return r.c.ref
+}
+
+func workflowRunFromAPI(apiObj *github.WorkflowRun) gitprovider.WorkflowRunInfo {
+	return gitprovider.WorkflowRunInfo{
+		ID:         apiObj.GetID(),
+		Ref:        apiObj.GetHeadBranch(),
+		Sha:        apiObj.GetHeadSHA(),
+		Event:      apiObj.GetEvent(),
+		Status:     apiObj.GetStatus(),
+		Conclusion: apiObj.GetConclusion(),
+		URL:        apiObj.GetHTMLURL(),
+		CreatedAt:  apiObj.GetCreatedAt().Time,
+	}
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package github
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-github/v66/github"
 
@@ -29,7 +31,11 @@ import (
 
 const (
 	alreadyExistsMagicString = "name already exists on this account"
+	refExistsMagicString     = "Reference already exists"
+	alreadyExistsErrorCode   = "already_exists"
+	ambiguousRefMagicString  = "ambiguous"
 	rateLimitDocURL          = "https://developer.github.com/v3/#rate-limiting"
+	secondaryRateLimitDocURL = "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"
 )
 
 // TODO: Guard better against nil pointer dereference panics in this package, also
@@ -100,8 +106,20 @@ func handleHTTPError(err error) error {
 		return nil
 	}
 	ghRateLimitError := &github.RateLimitError{}
+	ghAbuseRateLimitError := &github.AbuseRateLimitError{}
 	ghErrorResponse := &github.ErrorResponse{}
-	if errors.As(err, &ghRateLimitError) {
+	if errors.As(err, &ghAbuseRateLimitError) {
+		// Convert go-github's AbuseRateLimitError to our typed secondary rate limit error
+		return validation.NewMultiError(err, &gitprovider.SecondaryRateLimitError{
+			HTTPError: gitprovider.HTTPError{
+				Response:         ghAbuseRateLimitError.Response,
+				ErrorMessage:     ghAbuseRateLimitError.Error(),
+				Message:          ghAbuseRateLimitError.Message,
+				DocumentationURL: secondaryRateLimitDocURL,
+			},
+			RetryAfter: ghAbuseRateLimitError.GetRetryAfter(),
+		})
+	} else if errors.As(err, &ghRateLimitError) {
 		// Convert go-github's RateLimitError to our similar error type
 		return validation.NewMultiError(err, &gitprovider.RateLimitError{
 			HTTPError: gitprovider.HTTPError{
@@ -145,14 +163,44 @@ func handleHTTPError(err error) error {
 	return err
 }
 
+// handleRefHTTPError wraps err like handleHTTPError, but also handles the "422 Unprocessable Entity"
+// responses GitHub returns for references which don't resolve to a single commit.
+func handleRefHTTPError(err error) error {
+	ghErrorResponse := &github.ErrorResponse{}
+	if errors.As(err, &ghErrorResponse) && ghErrorResponse.Response.StatusCode == http.StatusUnprocessableEntity {
+		if strings.Contains(ghErrorResponse.Message, ambiguousRefMagicString) {
+			return validation.NewMultiError(err, gitprovider.ErrAmbiguousReference)
+		}
+		return validation.NewMultiError(err, gitprovider.ErrNotFound)
+	}
+	return handleHTTPError(err)
+}
+
+// handleFastForwardHTTPError wraps err like handleHTTPError, but also handles the "422 Unprocessable
+// Entity" responses GitHub returns when a non-forced reference update isn't a fast-forward.
+func handleFastForwardHTTPError(err error) error {
+	ghErrorResponse := &github.ErrorResponse{}
+	if errors.As(err, &ghErrorResponse) && ghErrorResponse.Response.StatusCode == http.StatusUnprocessableEntity {
+		return validation.NewMultiError(err, gitprovider.ErrPreconditionFailed)
+	}
+	return handleHTTPError(err)
+}
+
 // allPages runs fn for each page, expecting a HTTP request to be made and returned during that call.
 // allPages expects that the data is saved in fn to an outer variable.
 // allPages calls fn as many times as needed to get all pages, and modifies opts for each call.
+// Each call gets a context bounded by the page timeout of the client, if any, and the error of ctx
+// is returned without fetching further pages once ctx is done; callers then discard the pages
+// fetched so far.
 // There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
-func allPages(opts *github.ListOptions, fn func() (*github.Response, error)) error {
+func allPages(ctx context.Context, opts *github.ListOptions, fn func(ctx context.Context) (*github.Response, error)) error {
 	for {
-
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
 		if err != nil {
 			return handleHTTPError(err)
 		}
@@ -163,6 +211,39 @@ func allPages(opts *github.ListOptions, fn func() (*github.Response, error)) err
 	}
 }
 
+// allCursorPages is like allPages, for the lists paginated with cursors rather than page numbers.
+func allCursorPages(ctx context.Context, opts *github.ListCursorOptions, fn func(ctx context.Context) (*github.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.After == "" {
+			return nil
+		}
+		opts.After = resp.After
+	}
+}
+
+// countItems counts the items of a paginated list without fetching them all. fn is called once
+// with a page size of one, so the number of the last page reported in the Link header is the
+// number of items. Without a last page, the list fits in the single page fetched.
+func countItems(fn func(opts *github.ListOptions) (int, *github.Response, error)) (int, error) {
+	n, resp, err := fn(&github.ListOptions{PerPage: 1})
+	if err != nil {
+		return 0, handleHTTPError(err)
+	}
+	if resp.LastPage != 0 {
+		return resp.LastPage, nil
+	}
+	return n, nil
+}
+
 // validateAPIObject creates a Validatior with the specified name, gives it to fn, and
 // depending on if any error was registered with it; either returns nil, or a MultiError
 // with both the validation error and ErrInvalidServerData, to mark that the server data
@@ -176,3 +257,28 @@ func validateAPIObject(name string, fn func(validation.Validator)) error {
 	}
 	return nil
 }
+
+// handleCreateRefHTTPError wraps err like handleHTTPError, but also handles the "422 Unprocessable
+// Entity" response GitHub returns when the reference to create already exists.
+func handleCreateRefHTTPError(err error) error {
+	ghErrorResponse := &github.ErrorResponse{}
+	if errors.As(err, &ghErrorResponse) && ghErrorResponse.Response.StatusCode == http.StatusUnprocessableEntity &&
+		strings.Contains(ghErrorResponse.Message, refExistsMagicString) {
+		return validation.NewMultiError(err, gitprovider.ErrAlreadyExists)
+	}
+	return handleHTTPError(err)
+}
+
+// handleCreateReleaseHTTPError wraps err like handleHTTPError, but also handles the "422
+// Unprocessable Entity" response GitHub returns when the tag already has a release.
+func handleCreateReleaseHTTPError(err error) error {
+	ghErrorResponse := &github.ErrorResponse{}
+	if errors.As(err, &ghErrorResponse) && ghErrorResponse.Response.StatusCode == http.StatusUnprocessableEntity {
+		for _, validationErr := range ghErrorResponse.Errors {
+			if validationErr.Code == alreadyExistsErrorCode {
+				return validation.NewMultiError(err, gitprovider.ErrAlreadyExists)
+			}
+		}
+	}
+	return handleHTTPError(err)
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package github
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
@@ -119,7 +122,7 @@ func Test_allPages(t *testing.T) {
 			// the page index are 1-based, and omitting page is the same as page=1
 			// set page=1 here just to be able to test more easily
 			tt.opts.Page = 1
-			err := allPages(tt.opts, func() (*github.Response, error) {
+			err := allPages(context.Background(), tt.opts, func(_ context.Context) (*github.Response, error) {
 				i++
 				if tt.opts.Page != i {
 					t.Fatalf("page number is unexpected: got = %d want = %d", tt.opts.Page, i)
@@ -133,3 +136,46 @@ func Test_allPages(t *testing.T) {
 		})
 	}
 }
+
+func Test_allPages_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	err := allPages(ctx, &github.ListOptions{}, func(_ context.Context) (*github.Response, error) {
+		calls++
+		// Cancel the listing while the first page is being fetched
+		cancel()
+		return &github.Response{NextPage: calls + 1}, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("allPages() error = %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("allPages() calls = %d, want 1", calls)
+	}
+}
+
+func Test_handleHTTPError_SecondaryRateLimit(t *testing.T) {
+	mux, client := setup(t)
+	mux.HandleFunc("/orgs/fluxcd", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{
+			"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",
+			"documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"
+		}`))
+	})
+
+	_, err := client.Organizations().Get(context.Background(), gitprovider.OrganizationRef{Domain: DefaultDomain, Organization: "fluxcd"})
+	secondaryErr := &gitprovider.SecondaryRateLimitError{}
+	if !errors.As(err, &secondaryErr) {
+		t.Fatalf("Get() error = %v, want a *gitprovider.SecondaryRateLimitError", err)
+	}
+	if secondaryErr.RetryAfter != 60*time.Second {
+		t.Errorf("RetryAfter = %v, want %v", secondaryErr.RetryAfter, 60*time.Second)
+	}
+	// A secondary rate limit must not be mistaken for the primary one or for invalid credentials
+	if errors.As(err, new(*gitprovider.RateLimitError)) || errors.As(err, new(*gitprovider.InvalidCredentialsError)) {
+		t.Errorf("Get() error = %v, want only a secondary rate limit error", err)
+	}
+}
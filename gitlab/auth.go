@@ -18,6 +18,7 @@ package gitlab
 
 import (
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	gogitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
@@ -43,17 +44,23 @@ func NewClient(token string, tokenType string, optFns ...gitprovider.ClientOptio
 		return nil, err
 	}
 
+	glOpts := []gogitlab.ClientOptionFunc{gogitlab.WithHTTPClient(httpClient)}
+	if opts.RetryPolicy != nil {
+		// The retry policy is applied by the transport chain, don't retry twice
+		glOpts = append(glOpts, gogitlab.WithoutRetries())
+	}
+
 	if tokenType == "oauth2" {
 		if opts.Domain == nil || *opts.Domain == DefaultDomain {
 			// No domain set or the default gitlab.com used
 			domain = DefaultDomain
-			gl, err = gogitlab.NewOAuthClient(token, gogitlab.WithHTTPClient(httpClient))
+			gl, err = gogitlab.NewOAuthClient(token, glOpts...)
 			if err != nil {
 				return nil, err
 			}
 		} else {
 			domain = *opts.Domain
-			gl, err = gogitlab.NewOAuthClient(token, gogitlab.WithHTTPClient(httpClient), gogitlab.WithBaseURL(domain))
+			gl, err = gogitlab.NewOAuthClient(token, append(glOpts, gogitlab.WithBaseURL(domain))...)
 			if err != nil {
 				return nil, err
 			}
@@ -62,13 +69,13 @@ func NewClient(token string, tokenType string, optFns ...gitprovider.ClientOptio
 		if opts.Domain == nil || *opts.Domain == DefaultDomain {
 			// No domain set or the default gitlab.com used
 			domain = DefaultDomain
-			gl, err = gogitlab.NewClient(token, gogitlab.WithHTTPClient(httpClient))
+			gl, err = gogitlab.NewClient(token, glOpts...)
 			if err != nil {
 				return nil, err
 			}
 		} else {
 			domain = *opts.Domain
-			gl, err = gogitlab.NewClient(token, gogitlab.WithHTTPClient(httpClient), gogitlab.WithBaseURL(domain))
+			gl, err = gogitlab.NewClient(token, append(glOpts, gogitlab.WithBaseURL(domain))...)
 			if err != nil {
 				return nil, err
 			}
@@ -81,5 +88,13 @@ func NewClient(token string, tokenType string, optFns ...gitprovider.ClientOptio
 		destructiveActions = *opts.EnableDestructiveAPICalls
 	}
 
-	return newClient(gl, domain, sshDomain, destructiveActions), nil
+	c := newClient(gl, domain, sshDomain, destructiveActions)
+	c.defaultOwner = gitprovider.NewDefaultOwner(opts.DefaultOwner)
+	c.gitTransport = gitprovider.GitTransportOptions{HTTPClient: httpClient, CABundle: opts.CABundle}
+	if token != "" {
+		// Git over HTTPS only supports basic authentication, which takes the token as
+		// password, and requires "oauth2" as username for OAuth2 tokens
+		c.gitTransport.Auth = &githttp.BasicAuth{Username: "oauth2", Password: token}
+	}
+	return c, nil
 }
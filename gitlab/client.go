@@ -18,6 +18,7 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 
@@ -30,7 +31,7 @@ const ProviderID = gitprovider.ProviderID("gitlab")
 
 func newClient(c *gitlab.Client, domain string, sshDomain string, destructiveActions bool) *Client {
 	glClient := &gitlabClientImpl{c, destructiveActions}
-	ctx := &clientContext{glClient, domain, sshDomain, destructiveActions}
+	ctx := &clientContext{c: glClient, domain: domain, sshDomain: sshDomain, destructiveActions: destructiveActions}
 	return &Client{
 		clientContext: ctx,
 		orgs: &OrganizationsClient{
@@ -50,6 +51,8 @@ type clientContext struct {
 	domain             string
 	sshDomain          string
 	destructiveActions bool
+	// gitTransport is used for talking to the Git endpoints, e.g. when creating backups.
+	gitTransport gitprovider.GitTransportOptions
 }
 
 // Client implements the gitprovider.Client interface.
@@ -62,6 +65,9 @@ type Client struct {
 	orgs      *OrganizationsClient
 	orgRepos  *OrgRepositoriesClient
 	userRepos *UserRepositoriesClient
+
+	// defaultOwner resolves the repository names given to Repo.
+	defaultOwner *gitprovider.DefaultOwner
 }
 
 // SupportedDomain returns the domain endpoint for this client, e.g. "gitlab.com" or
@@ -115,3 +121,42 @@ func (c *Client) UserRepositories() gitprovider.UserRepositoriesClient {
 func (c *Client) HasTokenPermission(_ context.Context, _ gitprovider.TokenPermission) (bool, error) {
 	return false, gitprovider.ErrNoProviderSupport
 }
+
+// TokenScopes returns the scopes of the personal, project or group access token in use.
+// Other tokens, e.g. OAuth tokens, can't be introspected and ErrNoProviderSupport is returned.
+func (c *Client) TokenScopes(ctx context.Context) ([]string, error) {
+	apiObj, err := c.c.GetCurrentPersonalAccessToken(ctx)
+	if errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, gitprovider.ErrNoProviderSupport
+	}
+	if err != nil {
+		return nil, err
+	}
+	return apiObj.Scopes, nil
+}
+
+// webhookEventNames maps the supported WebhookEvents to the triggers of GitLab project hooks, see
+// https://docs.gitlab.com/ee/api/projects.html#add-project-hook
+//
+//nolint:gochecknoglobals
+var webhookEventNames = map[gitprovider.WebhookEvent]string{
+	gitprovider.WebhookEventPush:        "push_events",
+	gitprovider.WebhookEventTagPush:     "tag_push_events",
+	gitprovider.WebhookEventPullRequest: "merge_requests_events",
+	gitprovider.WebhookEventIssues:      "issues_events",
+	gitprovider.WebhookEventComment:     "note_events",
+	gitprovider.WebhookEventRelease:     "releases_events",
+	gitprovider.WebhookEventDeployment:  "deployment_events",
+	gitprovider.WebhookEventPipeline:    "pipeline_events",
+	gitprovider.WebhookEventWiki:        "wiki_page_events",
+}
+
+// Repo gets the repository with the given name, owned by the owner given through WithDefaultOwner.
+func (c *Client) Repo(ctx context.Context, name string) (gitprovider.UserRepository, error) {
+	return c.defaultOwner.Repository(ctx, c, name)
+}
+
+// SupportedWebhookEvents returns the webhook events GitLab can trigger project hooks for.
+func (c *Client) SupportedWebhookEvents() []gitprovider.WebhookEvent {
+	return gitprovider.SortedWebhookEvents(webhookEventNames)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// AuditLogClient implements the gitprovider.AuditLogClient interface.
+var _ gitprovider.AuditLogClient = &AuditLogClient{}
+
+// AuditLogClient reads the audit events of a specific group. Group audit events require a
+// GitLab Premium subscription.
+type AuditLogClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// List lists the audit events of the group passing the filters of opts, newest first.
+func (c *AuditLogClient) List(ctx context.Context, opts gitprovider.AuditLogOptions) ([]gitprovider.AuditLogEntry, error) {
+	if err := opts.ValidateOptions(); err != nil {
+		return nil, err
+	}
+
+	apiObjs := []*gitlab.AuditEvent{}
+	listOpts := &gitlab.ListAuditEventsOptions{
+		CreatedAfter:  opts.Since,
+		CreatedBefore: opts.Until,
+	}
+	err := allAuditEventPages(ctx, listOpts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /groups/{id}/audit_events
+		pageObjs, resp, listErr := c.c.Client().AuditEvents.ListGroupAuditEvents(c.ref.Organization, listOpts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]gitprovider.AuditLogEntry, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		entries = append(entries, auditLogEntryFromAPI(apiObj))
+	}
+	return entries, nil
+}
+
+// auditLogEntryFromAPI converts a GitLab audit event.
+func auditLogEntryFromAPI(apiObj *gitlab.AuditEvent) gitprovider.AuditLogEntry {
+	entry := gitprovider.AuditLogEntry{
+		Actor:  apiObj.Details.AuthorName,
+		Action: apiObj.EventName,
+		Target: apiObj.Details.TargetDetails,
+	}
+	if entry.Action == "" {
+		entry.Action = auditEventAction(apiObj.Details)
+	}
+	if apiObj.CreatedAt != nil {
+		entry.CreatedAt = *apiObj.CreatedAt
+	}
+	return entry
+}
+
+// auditEventAction describes the action of the older audit events, which have no name, by what
+// their details say was added, changed or removed.
+func auditEventAction(details gitlab.AuditEventDetails) string {
+	switch {
+	case details.EventName != "":
+		return details.EventName
+	case details.Add != "":
+		return "add " + details.Add
+	case details.Change != "":
+		return "change " + details.Change
+	case details.Remove != "":
+		return "remove " + details.Remove
+	}
+	return details.CustomMessage
+}
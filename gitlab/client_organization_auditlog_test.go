@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestAuditLogClient_List(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/groups/fluxcd/audit_events", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("created_after") != "2026-01-01T00:00:00Z" || query.Get("created_before") != "2026-02-01T00:00:00Z" {
+			t.Errorf("unexpected date filters: %v", query)
+		}
+		// Serve each event on its own page to exercise pagination
+		if query.Get("page") != "2" {
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id": 2, "event_name": "group_variable_created", "created_at": "2026-01-20T00:00:00Z",
+				"details": {"author_name": "Alice", "target_details": "TOKEN"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 1, "created_at": "2026-01-10T00:00:00Z",
+			"details": {"author_name": "Bob", "add": "user_access", "as": "Developer", "target_details": "Carol"}}]`)
+	})
+
+	client := &AuditLogClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref:           gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+	}
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	got, err := client.List(context.Background(), gitprovider.AuditLogOptions{Since: &since, Until: &until})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []gitprovider.AuditLogEntry{
+		{
+			Actor:     "Alice",
+			Action:    "group_variable_created",
+			CreatedAt: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			Target:    "TOKEN",
+		},
+		{
+			Actor:     "Bob",
+			Action:    "add user_access",
+			CreatedAt: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+			Target:    "Carol",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+}
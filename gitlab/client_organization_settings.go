@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationSettingsClient implements the gitprovider.OrganizationSettingsClient interface.
+var _ gitprovider.OrganizationSettingsClient = &OrganizationSettingsClient{}
+
+// OrganizationSettingsClient operates on the org-wide defaults of a specific group.
+// GitLab groups have no default project permission nor merge settings, only
+// MembersCanCreateRepositories is supported. It maps to the project creation level of the group,
+// which allows either developers or only maintainers to create projects.
+type OrganizationSettingsClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get returns the org-wide defaults of the group.
+func (c *OrganizationSettingsClient) Get(ctx context.Context) (gitprovider.OrganizationSettingsInfo, error) {
+	// GET /groups/{group}
+	apiObj, err := c.c.GetGroup(ctx, c.ref.Organization)
+	if err != nil {
+		return gitprovider.OrganizationSettingsInfo{}, err
+	}
+	return organizationSettingsFromAPI(apiObj), nil
+}
+
+// Reconcile makes sure the fields set in req become the actual org-wide defaults of the group.
+func (c *OrganizationSettingsClient) Reconcile(ctx context.Context, req gitprovider.OrganizationSettingsInfo) (bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+	if req.DefaultRepositoryPermission != nil || req.AllowAutoMerge != nil || req.AllowSquashMerge != nil {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+	if req.MembersCanCreateRepositories == nil {
+		return false, nil
+	}
+
+	actual, err := c.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	if req.Equals(actual) {
+		return false, nil
+	}
+
+	level := gitlab.MaintainerProjectCreation
+	if *req.MembersCanCreateRepositories {
+		level = gitlab.DeveloperProjectCreation
+	}
+	// PUT /groups/{group}
+	_, err = c.c.UpdateGroup(ctx, c.ref.Organization, &gitlab.UpdateGroupOptions{
+		ProjectCreationLevel: &level,
+	})
+	return true, err
+}
+
+func organizationSettingsFromAPI(apiObj *gitlab.Group) gitprovider.OrganizationSettingsInfo {
+	return gitprovider.OrganizationSettingsInfo{
+		MembersCanCreateRepositories: gitprovider.BoolVar(apiObj.ProjectCreationLevel == gitlab.DeveloperProjectCreation),
+	}
+}
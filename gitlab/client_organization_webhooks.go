@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationWebhookClient implements the gitprovider.OrganizationWebhookClient interface.
+var _ gitprovider.OrganizationWebhookClient = &OrganizationWebhookClient{}
+
+// OrganizationWebhookClient operates on the webhooks of a specific group, which GitLab only
+// offers in its paid tiers.
+type OrganizationWebhookClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get returns the webhook with the given URL.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *OrganizationWebhookClient) Get(ctx context.Context, url string) (gitprovider.OrganizationWebhook, error) {
+	return c.get(ctx, url)
+}
+
+func (c *OrganizationWebhookClient) get(ctx context.Context, url string) (*orgWebhook, error) {
+	hooks, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range hooks {
+		if hook.h.URL == url {
+			return hook, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all webhooks of the organization.
+//
+// List returns all available webhooks, using multiple paginated requests if needed.
+func (c *OrganizationWebhookClient) List(ctx context.Context) ([]gitprovider.OrganizationWebhook, error) {
+	hooks, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.OrganizationWebhook
+	result := make([]gitprovider.OrganizationWebhook, 0, len(hooks))
+	for _, hook := range hooks {
+		result = append(result, hook)
+	}
+	return result, nil
+}
+
+func (c *OrganizationWebhookClient) list(ctx context.Context) ([]*orgWebhook, error) {
+	// GET /groups/{group}/hooks
+	apiObjs, err := c.c.ListGroupHooks(ctx, c.ref.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := make([]*orgWebhook, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListGroupHooks
+		hooks = append(hooks, newOrgWebhook(c, apiObj, nil))
+	}
+	return hooks, nil
+}
+
+// Create creates a webhook with the given specifications.
+//
+// ErrAlreadyExists will be returned if a webhook with the same URL already exists.
+func (c *OrganizationWebhookClient) Create(ctx context.Context, req gitprovider.OrganizationWebhookInfo) (gitprovider.OrganizationWebhook, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+	data, err := orgWebhookToAPI(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.get(ctx, req.URL); err == nil {
+		return nil, gitprovider.ErrAlreadyExists
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, err
+	}
+
+	// POST /groups/{group}/hooks
+	apiObj, err := c.c.AddGroupHook(ctx, c.ref.Organization, addGroupHookOptions(data, req.Secret))
+	if err != nil {
+		return nil, err
+	}
+	return newOrgWebhook(c, apiObj, req.Secret), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *OrganizationWebhookClient) Reconcile(ctx context.Context, req gitprovider.OrganizationWebhookInfo) (gitprovider.OrganizationWebhook, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.get(ctx, req.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
+
+// SetActive is not supported, as GitLab group webhooks can't be paused. ErrNoProviderSupport is returned.
+func (c *OrganizationWebhookClient) SetActive(_ context.Context, _ string, _ bool) error {
+	return gitprovider.ErrNoProviderSupport
+}
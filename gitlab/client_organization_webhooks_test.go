@@ -0,0 +1,240 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestOrganizationWebhookClient(t *testing.T) (*http.ServeMux, *OrganizationWebhookClient) {
+	mux, c := setup(t)
+	return mux, &OrganizationWebhookClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref:           gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+	}
+}
+
+func TestOrganizationWebhookClient_Create(t *testing.T) {
+	mux, c := newTestOrganizationWebhookClient(t)
+	var payload map[string]interface{}
+	mux.HandleFunc("/api/v4/groups/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1, "url": "https://audit.example.com/hook", "push_events": true, "merge_requests_events": true, "enable_ssl_verification": true}`)
+	})
+
+	hook, err := c.Create(context.Background(), gitprovider.OrganizationWebhookInfo{
+		URL:    "https://audit.example.com/hook",
+		Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+		Secret: gitprovider.StringVar("s3cr3t"),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	wantPayload := map[string]interface{}{
+		"url":                     "https://audit.example.com/hook",
+		"push_events":             true,
+		"tag_push_events":         false,
+		"merge_requests_events":   true,
+		"issues_events":           false,
+		"note_events":             false,
+		"releases_events":         false,
+		"deployment_events":       false,
+		"pipeline_events":         false,
+		"wiki_page_events":        false,
+		"enable_ssl_verification": true,
+		"token":                   "s3cr3t",
+	}
+	if diff := cmp.Diff(wantPayload, payload); diff != "" {
+		t.Errorf("payload (-want +got):\n%s", diff)
+	}
+	wantInfo := gitprovider.OrganizationWebhookInfo{
+		URL:                "https://audit.example.com/hook",
+		Events:             []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest, gitprovider.WebhookEventPush},
+		Secret:             gitprovider.StringVar("s3cr3t"),
+		InsecureSkipVerify: gitprovider.BoolVar(false),
+		ContentType:        gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+	}
+	if diff := cmp.Diff(wantInfo, hook.Get()); diff != "" {
+		t.Errorf("Get() (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrganizationWebhookClient_Create_UnsupportedEvent(t *testing.T) {
+	_, c := newTestOrganizationWebhookClient(t)
+	_, err := c.Create(context.Background(), gitprovider.OrganizationWebhookInfo{
+		URL:    "https://audit.example.com/hook",
+		Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventRepository},
+	})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
+
+func TestOrganizationWebhookClient_Reconcile(t *testing.T) {
+	mux, c := newTestOrganizationWebhookClient(t)
+	mux.HandleFunc("/api/v4/groups/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 2, "url": "https://audit.example.com/hook", "push_events": true, "job_events": true, "enable_ssl_verification": true}]`)
+	})
+	var payload map[string]interface{}
+	mux.HandleFunc("/api/v4/groups/fluxcd/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		fmt.Fprint(w, `{"id": 2, "url": "https://audit.example.com/hook", "push_events": true, "pipeline_events": true, "job_events": true}`)
+	})
+
+	_, actionTaken, err := c.Reconcile(context.Background(), gitprovider.OrganizationWebhookInfo{
+		URL:                "https://audit.example.com/hook",
+		Events:             []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPipeline},
+		InsecureSkipVerify: gitprovider.BoolVar(true),
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if !actionTaken {
+		t.Error("Reconcile() actionTaken = false, want true")
+	}
+	// The job events can't be expressed as a WebhookEvent, and aren't sent
+	wantPayload := map[string]interface{}{
+		"url":                     "https://audit.example.com/hook",
+		"push_events":             true,
+		"tag_push_events":         false,
+		"merge_requests_events":   false,
+		"issues_events":           false,
+		"note_events":             false,
+		"releases_events":         false,
+		"deployment_events":       false,
+		"pipeline_events":         true,
+		"wiki_page_events":        false,
+		"enable_ssl_verification": false,
+	}
+	if diff := cmp.Diff(wantPayload, payload); diff != "" {
+		t.Errorf("payload (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrganizationWebhookClient_Reconcile_UpToDate(t *testing.T) {
+	mux, c := newTestOrganizationWebhookClient(t)
+	mux.HandleFunc("/api/v4/groups/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 2, "url": "https://audit.example.com/hook", "push_events": true, "enable_ssl_verification": true}]`)
+	})
+	mux.HandleFunc("/api/v4/groups/fluxcd/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("didn't expect a %s request", r.Method)
+	})
+
+	_, actionTaken, err := c.Reconcile(context.Background(), gitprovider.OrganizationWebhookInfo{
+		URL:                "https://audit.example.com/hook",
+		InsecureSkipVerify: gitprovider.BoolVar(false),
+		ContentType:        gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if actionTaken {
+		t.Error("Reconcile() actionTaken = true, want false")
+	}
+}
+
+func TestOrganizationWebhookClient_Create_FormContentType(t *testing.T) {
+	_, c := newTestOrganizationWebhookClient(t)
+	_, err := c.Create(context.Background(), gitprovider.OrganizationWebhookInfo{
+		URL:         "https://audit.example.com/hook",
+		ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeForm),
+	})
+	if !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Create() error = %v, want %v", err, gitprovider.ErrNoProviderSupport)
+	}
+}
+
+func TestOrganizationWebhookClient_Reconcile_RotatedSecret(t *testing.T) {
+	mux, c := newTestOrganizationWebhookClient(t)
+	mux.HandleFunc("/api/v4/groups/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 2, "url": "https://audit.example.com/hook", "push_events": true, "enable_ssl_verification": true}]`)
+	})
+	var payload map[string]interface{}
+	mux.HandleFunc("/api/v4/groups/fluxcd/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		fmt.Fprint(w, `{"id": 2, "url": "https://audit.example.com/hook", "push_events": true, "enable_ssl_verification": true}`)
+	})
+
+	_, actionTaken, err := c.Reconcile(context.Background(), gitprovider.OrganizationWebhookInfo{
+		URL:    "https://audit.example.com/hook",
+		Secret: gitprovider.StringVar("r0tated"),
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if !actionTaken {
+		t.Error("Reconcile() actionTaken = false, want true")
+	}
+	if got := payload["token"]; got != "r0tated" {
+		t.Errorf("token = %v, want %q", got, "r0tated")
+	}
+}
+
+func TestOrganizationWebhookClient_Delete(t *testing.T) {
+	for _, destructiveActions := range []bool{false, true} {
+		t.Run(fmt.Sprintf("destructiveActions=%t", destructiveActions), func(t *testing.T) {
+			mux, c := newTestOrganizationWebhookClient(t)
+			c.destructiveActions = destructiveActions
+			mux.HandleFunc("/api/v4/groups/fluxcd/hooks", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `[{"id": 2, "url": "https://audit.example.com/hook", "push_events": true}]`)
+			})
+			deleted := false
+			mux.HandleFunc("/api/v4/groups/fluxcd/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+				deleted = r.Method == http.MethodDelete
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			hook, err := c.Get(context.Background(), "https://audit.example.com/hook")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			err = hook.Delete(context.Background())
+			if destructiveActions && err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if !destructiveActions && !errors.Is(err, gitprovider.ErrDestructiveCallDisallowed) {
+				t.Fatalf("Delete() error = %v, want %v", err, gitprovider.ErrDestructiveCallDisallowed)
+			}
+			if deleted != destructiveActions {
+				t.Errorf("deleted = %t, want %t", deleted, destructiveActions)
+			}
+		})
+	}
+}
@@ -18,6 +18,9 @@ package gitlab
 
 import (
 	"context"
+	"fmt"
+
+	"gitlab.com/gitlab-org/api/client-go"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
@@ -66,6 +69,45 @@ func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organizat
 	return groups, nil
 }
 
+// ListWithRole lists the groups where the authenticated user has at least the given role. Members
+// have at least the guest access level, admins the maintainer one, and owners the owner one. The
+// groups are filtered server-side.
+//
+// ListWithRole returns all matching organizations, using multiple paginated requests if needed.
+func (c *OrganizationsClient) ListWithRole(ctx context.Context, role gitprovider.OrganizationRole) ([]gitprovider.Organization, error) {
+	level, ok := organizationRoleAccessLevels[role]
+	if !ok {
+		return nil, fmt.Errorf("invalid role %q: %w", role, gitprovider.ErrInvalidArgument)
+	}
+
+	// GET /groups?min_access_level={level}
+	apiObjs, err := c.c.ListGroupsWithMinAccessLevel(ctx, level)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]gitprovider.Organization, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		ref := gitprovider.OrganizationRef{
+			Domain:       apiObj.WebURL,
+			Organization: apiObj.FullName,
+		}
+		groups = append(groups, newOrganization(c.clientContext, apiObj, ref))
+	}
+
+	return groups, nil
+}
+
+// organizationRoleAccessLevels maps each OrganizationRole to the minimum group access level
+// granting it.
+//
+//nolint:gochecknoglobals
+var organizationRoleAccessLevels = map[gitprovider.OrganizationRole]gitlab.AccessLevelValue{
+	gitprovider.OrganizationRoleMember: gitlab.GuestPermissions,
+	gitprovider.OrganizationRoleAdmin:  gitlab.MaintainerPermissions,
+	gitprovider.OrganizationRoleOwner:  gitlab.OwnerPermissions,
+}
+
 // Children returns the immediate child-organizations for the specific OrganizationRef o.
 // The OrganizationRef may point to any existing sub-organization.
 //
@@ -19,6 +19,7 @@ package gitlab
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"gitlab.com/gitlab-org/api/client-go"
@@ -75,6 +76,23 @@ func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.Organi
 	return repos, nil
 }
 
+// Iterate calls fn for each repository in the given organization, fetching the pages lazily.
+func (c *OrgRepositoriesClient) Iterate(ctx context.Context, ref gitprovider.OrganizationRef, fn func(gitprovider.OrgRepository) error) error {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.domain); err != nil {
+		return err
+	}
+
+	// GET /groups/{group}/projects
+	return c.c.IterateGroupProjects(ctx, ref.Organization, func(apiObj *gitlab.Project) error {
+		// apiObj is already validated at IterateGroupProjects
+		return fn(newGroupProject(c.clientContext, apiObj, gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  apiObj.Name,
+		}))
+	})
+}
+
 // Create creates a repository for the given organization, with the data and options.
 //
 // ErrAlreadyExists will be returned if the resource already exists.
@@ -84,11 +102,28 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context, ref gitprovider.OrgR
 		return nil, err
 	}
 
-	apiObj, err := createProject(ctx, c.c, ref, ref.Organization, req, opts...)
-	if err != nil {
+	apiObj, err := createProject(ctx, c.c, c.gitTransport, ref, ref.Organization, req, opts...)
+	if apiObj == nil {
+		// The group of the project can't be found if it's a user
+		if isGroup, lookupErr := isGroupNamespace(ctx, c.c, ref.Organization); lookupErr == nil && !isGroup {
+			return nil, gitprovider.NewErrIdentityTypeMismatch(ref.Organization, false)
+		}
 		return nil, err
 	}
-	return newGroupProject(c.clientContext, apiObj, ref), nil
+	// The project is returned along with the error if it was created, but the steps after the
+	// creation failed, see RepositoryCreateOptions
+	return newGroupProject(c.clientContext, apiObj, ref), err
+}
+
+// isGroupNamespace returns whether the namespace at the given path belongs to a group rather than
+// a user. ErrNotFound is returned if the namespace doesn't exist, or isn't visible.
+func isGroupNamespace(ctx context.Context, c gitlabClient, path string) (bool, error) {
+	// GET /namespaces/{namespace}
+	apiObj, err := c.GetNamespace(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	return apiObj.Kind == "group", nil
 }
 
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
@@ -103,10 +138,17 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 		return nil, false, err
 	}
 
+	o := gitprovider.MakeRepositoryReconcileOptions(opts...)
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, false, err
+	}
 	actual, err := c.Get(ctx, ref)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
+			if err := o.CheckPrecondition(nil); err != nil {
+				return nil, false, err
+			}
 			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
 			return resp, true, err
 		}
@@ -114,12 +156,24 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 		// Unexpected path, Get should succeed or return NotFound
 		return nil, false, err
 	}
+	actualInfo := actual.Get()
+	if err := o.CheckPrecondition(&actualInfo); err != nil {
+		return nil, false, err
+	}
+
 	actionTaken, err := reconcileRepository(ctx, actual, req)
 	return actual, actionTaken, err
 }
 
+// maxDescriptionLength is the maximum length, in characters, of the project descriptions
+// accepted by GitLab.
+const maxDescriptionLength = 2000
+
+// createProject creates the project, then applies the options needing further steps. If these
+// fail, the created project is returned along with the error.
+//
 // nolint
-func createProject(ctx context.Context, c gitlabClient, ref gitprovider.RepositoryRef, groupName string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*gitlab.Project, error) {
+func createProject(ctx context.Context, c gitlabClient, gitTransport gitprovider.GitTransportOptions, ref gitprovider.RepositoryRef, groupName string, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*gitlab.Project, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
 	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
@@ -139,11 +193,49 @@ func createProject(ctx context.Context, c gitlabClient, ref gitprovider.Reposito
 	if err != nil {
 		return nil, err
 	}
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, err
+	}
 	apiOpts := gitlab.CreateProjectOptions{
 		InitializeWithReadme: o.AutoInit,
 	}
+	// GitLab doesn't allow setting the message of the commit adding the README, and protects
+	// the default branch against force pushes, hence push our own README instead
+	initWithMessage := o.AutoInit != nil && *o.AutoInit && o.InitialCommitMessage != nil
+	if initWithMessage {
+		apiOpts.InitializeWithReadme = gitlab.Ptr(false)
+	}
 
-	return c.CreateProject(ctx, &data, &apiOpts)
+	apiObj, err := c.CreateProject(ctx, &data, &apiOpts)
+	if err != nil {
+		return nil, err
+	}
+	if initWithMessage {
+		readme := fmt.Sprintf("# %s\n", apiObj.Name)
+		if apiObj.Description != "" {
+			readme += "\n" + apiObj.Description + "\n"
+		}
+		files := []gitprovider.CommitFile{{Path: gitprovider.StringVar("README.md"), Content: &readme}}
+		if _, err := gitprovider.PushInitialCommit(ctx, apiObj.HTTPURLToRepo, *req.DefaultBranch, *o.InitialCommitMessage, files, gitTransport); err != nil {
+			return apiObj, fmt.Errorf("failed to push the initial commit: %w", err)
+		}
+	}
+	for _, label := range o.Labels {
+		label.Default()
+		if err := c.CreateLabel(ctx, apiObj.PathWithNamespace, labelToAPI(&label)); err != nil {
+			return apiObj, fmt.Errorf("failed to create label %q: %w", label.Name, err)
+		}
+	}
+	return apiObj, nil
+}
+
+func labelToAPI(label *gitprovider.LabelInfo) *gitlab.CreateLabelOptions {
+	return &gitlab.CreateLabelOptions{
+		Name: &label.Name,
+		// GitLab expects the color as a CSS color, hence with a leading "#"
+		Color:       gitlab.Ptr("#" + label.Color),
+		Description: label.Description,
+	}
 }
 
 func reconcileRepository(ctx context.Context, actual gitprovider.UserRepository, req gitprovider.RepositoryInfo) (bool, error) {
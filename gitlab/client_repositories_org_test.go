@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrgRepositoriesClient_Create_IdentityTypeMismatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespaceKind string
+		wantMismatch  bool
+	}{
+		{
+			name:          "user given as group",
+			namespaceKind: "user",
+			wantMismatch:  true,
+		},
+		{
+			name:          "group",
+			namespaceKind: "group",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			mux.HandleFunc("/api/v4/groups/stefanprodan", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message": "404 Group Not Found"}`)
+			})
+			mux.HandleFunc("/api/v4/namespaces/stefanprodan", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"id": 1, "path": "stefanprodan", "kind": %q}`, tt.namespaceKind)
+			})
+
+			oc := &OrgRepositoriesClient{clientContext: &clientContext{c: c, domain: "gitlab.com"}}
+			_, err := oc.Create(context.Background(), gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "stefanprodan"},
+				RepositoryName:  "repo",
+			}, gitprovider.RepositoryInfo{})
+			if err == nil {
+				t.Fatal("Create() expected an error")
+			}
+			if got := errors.Is(err, gitprovider.ErrIdentityTypeMismatch); got != tt.wantMismatch {
+				t.Errorf("Create() error = %v, want ErrIdentityTypeMismatch: %v", err, tt.wantMismatch)
+			}
+		})
+	}
+}
+
+func TestOrgRepositoriesClient_Create_InitialCommitFailure(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/groups/fluxcd", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "path": "fluxcd"}`)
+	})
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": 2, "name": "repo", "path_with_namespace": "fluxcd/repo", "http_url_to_repo": "http://%s/fluxcd/repo.git"}`, r.Host)
+	})
+	// The push of the initial commit fails once the project has been created
+	mux.HandleFunc("/fluxcd/repo.git/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	oc := &OrgRepositoriesClient{clientContext: &clientContext{c: c, domain: "gitlab.com"}}
+	repo, err := oc.Create(context.Background(), gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}, gitprovider.RepositoryInfo{}, &gitprovider.RepositoryCreateOptions{
+		AutoInit:             gitprovider.BoolVar(true),
+		InitialCommitMessage: gitprovider.StringVar("Initial commit"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "initial commit") {
+		t.Fatalf("Create() error = %v, want an initial commit failure", err)
+	}
+	if repo == nil {
+		t.Fatal("Create() didn't return the created project along with the error")
+	}
+	if got := repo.Repository().GetRepository(); got != "repo" {
+		t.Errorf("Create() repository = %q, want %q", got, "repo")
+	}
+}
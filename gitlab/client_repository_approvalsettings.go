@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ApprovalSettingsClient implements the gitprovider.ApprovalSettingsClient interface.
+var _ gitprovider.ApprovalSettingsClient = &ApprovalSettingsClient{}
+
+// ApprovalSettingsClient operates on the merge request approval settings of a specific project.
+type ApprovalSettingsClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the merge request approval settings of the project.
+func (c *ApprovalSettingsClient) Get(ctx context.Context) (gitprovider.ApprovalSettingsInfo, error) {
+	apiObj, err := c.c.GetProjectApprovalConfiguration(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return gitprovider.ApprovalSettingsInfo{}, err
+	}
+	return approvalSettingsFromAPI(apiObj), nil
+}
+
+// Reconcile makes sure the fields set in req become the actual approval settings of the project.
+// Only the differing settings are sent to GitLab.
+func (c *ApprovalSettingsClient) Reconcile(ctx context.Context, req gitprovider.ApprovalSettingsInfo) (bool, error) {
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+	actual, err := c.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	opts := approvalSettingsToAPI(changedApprovalSettings(req, actual))
+	if *opts == (gitlab.ChangeApprovalConfigurationOptions{}) {
+		return false, nil
+	}
+	_, err = c.c.ChangeProjectApprovalConfiguration(ctx, getRepoPath(c.ref), opts)
+	return true, err
+}
+
+// changedApprovalSettings returns the fields set in req which differ from actual.
+func changedApprovalSettings(req, actual gitprovider.ApprovalSettingsInfo) gitprovider.ApprovalSettingsInfo {
+	changed := func(desired, actual *bool) *bool {
+		if desired == nil || (actual != nil && *desired == *actual) {
+			return nil
+		}
+		return desired
+	}
+	return gitprovider.ApprovalSettingsInfo{
+		ResetApprovalsOnPush:     changed(req.ResetApprovalsOnPush, actual.ResetApprovalsOnPush),
+		PreventAuthorApproval:    changed(req.PreventAuthorApproval, actual.PreventAuthorApproval),
+		PreventCommitterApproval: changed(req.PreventCommitterApproval, actual.PreventCommitterApproval),
+		RequirePasswordToApprove: changed(req.RequirePasswordToApprove, actual.RequirePasswordToApprove),
+	}
+}
+
+// approvalSettingsFromAPI converts the approval configuration of a project. GitLab stores whether
+// authors may approve their merge requests, the inverse of PreventAuthorApproval.
+func approvalSettingsFromAPI(apiObj *gitlab.ProjectApprovals) gitprovider.ApprovalSettingsInfo {
+	return gitprovider.ApprovalSettingsInfo{
+		ResetApprovalsOnPush:     gitprovider.BoolVar(apiObj.ResetApprovalsOnPush),
+		PreventAuthorApproval:    gitprovider.BoolVar(!apiObj.MergeRequestsAuthorApproval),
+		PreventCommitterApproval: gitprovider.BoolVar(apiObj.MergeRequestsDisableCommittersApproval),
+		RequirePasswordToApprove: gitprovider.BoolVar(apiObj.RequirePasswordToApprove),
+	}
+}
+
+func approvalSettingsToAPI(info gitprovider.ApprovalSettingsInfo) *gitlab.ChangeApprovalConfigurationOptions {
+	opts := &gitlab.ChangeApprovalConfigurationOptions{
+		ResetApprovalsOnPush:                   info.ResetApprovalsOnPush,
+		MergeRequestsDisableCommittersApproval: info.PreventCommitterApproval,
+		RequirePasswordToApprove:               info.RequirePasswordToApprove,
+	}
+	if info.PreventAuthorApproval != nil {
+		opts.MergeRequestsAuthorApproval = gitlab.Ptr(!*info.PreventAuthorApproval)
+	}
+	return opts
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestApprovalSettingsClient_Reconcile(t *testing.T) {
+	const actual = `{"reset_approvals_on_push": true, "merge_requests_author_approval": true, "merge_requests_disable_committers_approval": false, "require_password_to_approve": false}`
+	tests := []struct {
+		name            string
+		req             gitprovider.ApprovalSettingsInfo
+		wantActionTaken bool
+		wantPayload     map[string]interface{}
+	}{
+		{
+			name: "no-op",
+			req: gitprovider.ApprovalSettingsInfo{
+				ResetApprovalsOnPush:  gitprovider.BoolVar(true),
+				PreventAuthorApproval: gitprovider.BoolVar(false),
+			},
+		},
+		{
+			name: "prevent author approval and require password",
+			req: gitprovider.ApprovalSettingsInfo{
+				ResetApprovalsOnPush:     gitprovider.BoolVar(true),
+				PreventAuthorApproval:    gitprovider.BoolVar(true),
+				RequirePasswordToApprove: gitprovider.BoolVar(true),
+			},
+			wantActionTaken: true,
+			wantPayload: map[string]interface{}{
+				"merge_requests_author_approval": false,
+				"require_password_to_approve":    true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			var gotPayload map[string]interface{}
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/approvals", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, actual)
+				case http.MethodPost:
+					if err := json.NewDecoder(r.Body).Decode(&gotPayload); err != nil {
+						t.Fatalf("failed to decode the request body: %v", err)
+					}
+					fmt.Fprint(w, actual)
+				default:
+					t.Errorf("unexpected %s request", r.Method)
+				}
+			})
+
+			client := &ApprovalSettingsClient{
+				clientContext: &clientContext{c: c, domain: "gitlab.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+			actionTaken, err := client.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(tt.wantPayload, gotPayload); diff != "" {
+				t.Errorf("approval settings payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
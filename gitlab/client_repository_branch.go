@@ -18,6 +18,7 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"gitlab.com/gitlab-org/api/client-go"
@@ -33,7 +34,11 @@ type BranchClient struct {
 }
 
 // Create creates a branch with the given specifications.
-func (c *BranchClient) Create(_ context.Context, branch, sha string) error {
+func (c *BranchClient) Create(ctx context.Context, branch, sha string) error {
+	sha, err := gitprovider.ExpandShortSHA(ctx, &CommitClient{clientContext: c.clientContext, ref: c.ref}, sha)
+	if err != nil {
+		return err
+	}
 
 	ref := &gitlab.CreateBranchOptions{
 		Ref:    &sha,
@@ -46,3 +51,71 @@ func (c *BranchClient) Create(_ context.Context, branch, sha string) error {
 
 	return nil
 }
+
+// RequiredChecks returns the names of the external status checks required by the protection of
+// the branch. Checks which aren't limited to any protected branch apply to all of them.
+// External status checks are a GitLab Ultimate feature, without it no checks are returned.
+func (c *BranchClient) RequiredChecks(ctx context.Context, branch string) ([]string, error) {
+	projectName := getRepoPath(c.ref)
+	protected, _, err := c.c.Client().ProtectedBranches.GetProtectedBranch(projectName, branch, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	names := []string{}
+	opts := &gitlab.ListOptions{PerPage: 100}
+	err = allStatusCheckPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		checks, resp, err := c.c.Client().ExternalStatusChecks.ListProjectStatusChecks(projectName, opts, gitlab.WithContext(ctx))
+		for _, check := range checks {
+			if statusCheckAppliesTo(check, protected.ID) {
+				names = append(names, check.Name)
+			}
+		}
+		return resp, err
+	})
+	if err != nil {
+		// The external status checks aren't available on this GitLab tier
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	return names, nil
+}
+
+// RequiresSignedCommits returns whether the push rules of the project reject unsigned commits.
+// GitLab's push rules apply to all the branches of the project, whether they're protected or not.
+// Push rules are a GitLab Premium feature, without it false is returned.
+func (c *BranchClient) RequiresSignedCommits(ctx context.Context, _ string) (bool, error) {
+	// GET /projects/{project}/push_rule
+	rules, _, err := c.c.Client().Projects.GetProjectPushRules(getRepoPath(c.ref), gitlab.WithContext(ctx))
+	if err != nil {
+		err = handleHTTPError(err)
+		// The push rules aren't available on this GitLab tier
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	// GitLab returns null if the project has no push rules
+	return rules != nil && rules.RejectUnsignedCommits, nil
+}
+
+// statusCheckAppliesTo returns whether the external status check applies to the protected branch
+// with the given ID.
+func statusCheckAppliesTo(check *gitlab.ProjectStatusCheck, protectedBranchID int) bool {
+	if len(check.ProtectedBranches) == 0 {
+		return true
+	}
+	for _, b := range check.ProtectedBranches {
+		if b.ID == protectedBranchID {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultReviewers is not supported by GitLab, ErrNoProviderSupport is returned.
+func (c *BranchClient) DefaultReviewers(_ context.Context, _ string) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestBranchClient_RequiresSignedCommits(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		want     bool
+	}{
+		{name: "unsigned commits rejected", status: http.StatusOK, response: `{"id": 1, "reject_unsigned_commits": true}`, want: true},
+		{name: "unsigned commits accepted", status: http.StatusOK, response: `{"id": 1, "reject_unsigned_commits": false}`, want: false},
+		{name: "no push rules", status: http.StatusOK, response: `null`, want: false},
+		{name: "push rules unavailable", status: http.StatusNotFound, response: `{"message": "404 Not Found"}`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/push_rule", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.response)
+			})
+			c := &BranchClient{
+				clientContext: &clientContext{c: client, domain: "gitlab.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			got, err := c.RequiresSignedCommits(context.Background(), "main")
+			if err != nil {
+				t.Fatalf("RequiresSignedCommits() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RequiresSignedCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"sort"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// CollaboratorClient implements the gitprovider.CollaboratorClient interface.
+var _ gitprovider.CollaboratorClient = &CollaboratorClient{}
+
+// CollaboratorClient operates on the members of a specific project.
+type CollaboratorClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists the members of the project.
+// With CollaboratorListOptions.Effective, the members inherited from the ancestor groups and
+// from the groups the project is shared with are listed too, with their highest access level.
+// Members with an access level that doesn't map to a repository permission, like minimal
+// access, are skipped.
+func (c *CollaboratorClient) List(ctx context.Context, opts ...gitprovider.CollaboratorListOption) ([]gitprovider.CollaboratorInfo, error) {
+	o := gitprovider.MakeCollaboratorListOptions(opts...)
+	apiObjs, err := c.c.ListProjectMembers(ctx, getRepoPath(c.ref), o.Effective)
+	if err != nil {
+		return nil, err
+	}
+
+	// A user can be listed once per membership, keep the highest access level
+	levels := make(map[string]int, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		if level := int(apiObj.AccessLevel); level > levels[apiObj.Username] {
+			levels[apiObj.Username] = level
+		}
+	}
+
+	collaborators := make([]gitprovider.CollaboratorInfo, 0, len(levels))
+	for login, level := range levels {
+		permission, err := getGitProviderPermission(level)
+		if err != nil {
+			continue
+		}
+		collaborators = append(collaborators, gitprovider.CollaboratorInfo{
+			Login:      login,
+			Permission: *permission,
+		})
+	}
+	sort.Slice(collaborators, func(i, j int) bool {
+		return collaborators[i].Login < collaborators[j].Login
+	})
+	return collaborators, nil
+}
@@ -18,7 +18,10 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"gitlab.com/gitlab-org/api/client-go"
@@ -34,8 +37,35 @@ type CommitClient struct {
 }
 
 // ListPage lists repository commits of the given page and page size.
-func (c *CommitClient) ListPage(_ context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
-	dks, err := c.listPage(branch, perPage, page)
+func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
+	if err := gitprovider.ValidatePage(perPage, page); err != nil {
+		return nil, err
+	}
+	return c.List(ctx, gitprovider.CommitListOptions{
+		Branch:  branch,
+		PerPage: perPage,
+		Page:    page,
+	})
+}
+
+// Get returns the commit with the given SHA.
+//
+// ErrNotFound is returned if the commit does not exist.
+func (c *CommitClient) Get(ctx context.Context, sha string) (gitprovider.Commit, error) {
+	apiObj, err := c.c.GetCommit(ctx, getRepoPath(c.ref), sha)
+	if err != nil {
+		return nil, err
+	}
+	return newCommit(c, apiObj), nil
+}
+
+// List lists the repository commits matching the given options.
+// All filters are applied server-side.
+func (c *CommitClient) List(ctx context.Context, opts gitprovider.CommitListOptions) ([]gitprovider.Commit, error) {
+	if err := opts.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	dks, err := c.list(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -47,9 +77,27 @@ func (c *CommitClient) ListPage(_ context.Context, branch string, perPage, page
 	return commits, nil
 }
 
-func (c *CommitClient) listPage(branch string, perPage, page int) ([]*commitType, error) {
-	// GET /repos/{owner}/{repo}/commits
-	apiObjs, err := c.c.ListCommitsPage(getRepoPath(c.ref), branch, perPage, page)
+func (c *CommitClient) list(ctx context.Context, opts gitprovider.CommitListOptions) ([]*commitType, error) {
+	lcOpts := &gitlab.ListCommitsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: opts.PerPage,
+			Page:    opts.Page,
+		},
+		Since: opts.Since,
+		Until: opts.Until,
+	}
+	if opts.Branch != "" {
+		lcOpts.RefName = &opts.Branch
+	}
+	if opts.Path != "" {
+		lcOpts.Path = &opts.Path
+	}
+	if opts.Author != "" {
+		lcOpts.Author = &opts.Author
+	}
+
+	// GET /projects/{project}/repository/commits
+	apiObjs, err := c.c.ListCommitsPage(ctx, getRepoPath(c.ref), lcOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -64,16 +112,19 @@ func (c *CommitClient) listPage(branch string, perPage, page int) ([]*commitType
 }
 
 // Create creates a commit with the given specifications.
-func (c *CommitClient) Create(_ context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile, opts ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
 
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files added")
 	}
+	if err := gitprovider.ValidateCommitFiles(files); err != nil {
+		return nil, err
+	}
 
 	commitActions := make([]*gitlab.CommitActionOptions, 0)
 	for _, file := range files {
 		fileAction := gitlab.FileCreate
-		if file.Content == nil {
+		if file.GetAction() == gitprovider.CommitFileActionDelete {
 			fileAction = gitlab.FileDelete
 		}
 
@@ -84,16 +135,98 @@ func (c *CommitClient) Create(_ context.Context, branch string, message string,
 		})
 	}
 
-	opts := &gitlab.CreateCommitOptions{
+	// GitLab can't create commits conditionally, hence check the head of the branch first
+	o := gitprovider.MakeCommitCreateOptions(opts...)
+	if err := o.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	if len(o.Parents) > 0 {
+		// GitLab always commits on top of the branch head, hence push the commit through Git
+		return gitprovider.CommitOnParents(ctx, c.ref.GetCloneURL(gitprovider.TransportTypeHTTPS), branch, message, files, o, c.gitTransport)
+	}
+	if o.AuthorDate != nil || o.CommitterDate != nil {
+		// GitLab always dates the commits it creates with the current time
+		return nil, gitprovider.ErrNoProviderSupport
+	}
+	if o.ExpectedHeadSHA != "" {
+		head, err := c.ResolveRef(ctx, branch)
+		if err != nil {
+			return nil, err
+		}
+		if err := o.CheckHead(head); err != nil {
+			return nil, err
+		}
+	}
+
+	// GitLab rejects deleting a missing file with a generic bad request
+	for _, file := range files {
+		if file.GetAction() != gitprovider.CommitFileActionDelete {
+			continue
+		}
+		// HEAD /projects/{project}/repository/files/{file_path}
+		_, _, err := c.c.Client().RepositoryFiles.GetFileMetaData(getRepoPath(c.ref), *file.Path, &gitlab.GetFileMetaDataOptions{Ref: &branch}, gitlab.WithContext(ctx))
+		if err != nil {
+			err = handleHTTPError(err)
+			if errors.Is(err, gitprovider.ErrNotFound) {
+				return nil, fmt.Errorf("%q: %w", *file.Path, gitprovider.ErrFileNotFound)
+			}
+			return nil, err
+		}
+	}
+
+	createOpts := &gitlab.CreateCommitOptions{
 		Branch:        &branch,
 		CommitMessage: &message,
 		Actions:       commitActions,
 	}
+	if o.AuthorName != "" {
+		createOpts.AuthorName = &o.AuthorName
+		createOpts.AuthorEmail = &o.AuthorEmail
+	}
 
-	commit, _, err := c.c.Client().Commits.CreateCommit(getRepoPath(c.ref), opts)
+	commit, _, err := c.c.Client().Commits.CreateCommit(getRepoPath(c.ref), createOpts)
 	if err != nil {
 		return nil, err
 	}
 
 	return newCommit(c, commit), nil
 }
+
+// SignatureKey returns the primary key ID of the GPG key that signed the commit with the given SHA.
+// ErrNotFound is returned if the commit is not signed, and ErrNoProviderSupport if it isn't signed
+// with a GPG key.
+func (c *CommitClient) SignatureKey(ctx context.Context, sha string) (string, error) {
+	// GET /projects/{project}/repository/commits/{sha}/signature
+	apiObj, err := c.c.GetCommitSignature(ctx, getRepoPath(c.ref), sha)
+	if err != nil {
+		return "", err
+	}
+	if apiObj.KeyPrimaryKeyID == "" {
+		return "", fmt.Errorf("commit %s isn't signed with a GPG key: %w", sha, gitprovider.ErrNoProviderSupport)
+	}
+	return strings.ToUpper(apiObj.KeyPrimaryKeyID), nil
+}
+
+// ResolveRef resolves a branch, a tag or an abbreviated commit SHA to the full SHA of its commit.
+// GitLab reports abbreviated SHAs matching several commits as not found. To tell these apart,
+// at the cost of fetching the whole repository, use gitprovider.ResolveShortSHA.
+func (c *CommitClient) ResolveRef(ctx context.Context, ref string) (string, error) {
+	// GET /projects/{project}/repository/commits/{sha}
+	return c.c.GetCommitSHA(ctx, getRepoPath(c.ref), ref)
+}
+
+// ContainedInBranches returns the names of the branches containing the commit with the given SHA.
+func (c *CommitClient) ContainedInBranches(ctx context.Context, sha string) ([]string, error) {
+	branches, err := c.c.ListCommitBranches(ctx, getRepoPath(c.ref), sha)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(branches)
+	return branches, nil
+}
+
+// CheckAnnotations lists the check annotations of the commit with the given SHA.
+// GitLab doesn't have check annotations, so this returns ErrNoProviderSupport.
+func (c *CommitClient) CheckAnnotations(_ context.Context, _ string) ([]gitprovider.Annotation, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
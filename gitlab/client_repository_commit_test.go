@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestCommitClient(t *testing.T) (*http.ServeMux, *CommitClient) {
+	mux, c := setup(t)
+	return mux, &CommitClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.UserRepositoryRef{
+			UserRef:        gitprovider.UserRef{Domain: "gitlab.com", UserLogin: "fluxcd"},
+			RepositoryName: "repo",
+		},
+	}
+}
+
+func TestCommitClient_List(t *testing.T) {
+	since := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		opts      gitprovider.CommitListOptions
+		response  string
+		wantQuery url.Values
+		wantShas  []string
+	}{
+		{
+			name: "filters",
+			opts: gitprovider.CommitListOptions{
+				Branch:  "main",
+				Author:  "Alice",
+				Since:   &since,
+				Until:   &until,
+				Path:    "docs",
+				PerPage: 10,
+				Page:    2,
+			},
+			response: `[{"id": "a2", "author_name": "Alice", "message": "docs", "created_at": "2023-01-15T12:00:00Z"}]`,
+			wantQuery: url.Values{
+				"ref_name": []string{"main"},
+				"author":   []string{"Alice"},
+				"since":    []string{"2023-01-01T00:00:00Z"},
+				"until":    []string{"2023-02-01T00:00:00Z"},
+				"path":     []string{"docs"},
+				"per_page": []string{"10"},
+				"page":     []string{"2"},
+			},
+			wantShas: []string{"a2"},
+		},
+		{
+			name:      "empty result",
+			opts:      gitprovider.CommitListOptions{Branch: "main"},
+			response:  `[]`,
+			wantQuery: url.Values{"ref_name": []string{"main"}},
+			wantShas:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(tt.wantQuery, r.URL.Query()); diff != "" {
+					t.Errorf("query (-want +got):\n%s", diff)
+				}
+				fmt.Fprint(w, tt.response)
+			})
+
+			got, err := c.List(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			gotShas := []string{}
+			for _, commit := range got {
+				gotShas = append(gotShas, commit.Get().Sha)
+			}
+			if diff := cmp.Diff(tt.wantShas, gotShas); diff != "" {
+				t.Errorf("List() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// pagedCommitShas are the commits served page by page by the ListPage fake, newest first.
+var pagedCommitShas = []string{"c5", "c4", "c3", "c2", "c1"}
+
+func TestCommitClient_ListPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		perPage  int
+		page     int
+		wantShas []string
+		wantErr  error
+	}{
+		{name: "first page", perPage: 2, page: 1, wantShas: []string{"c5", "c4"}},
+		{name: "second page", perPage: 2, page: 2, wantShas: []string{"c3", "c2"}},
+		{name: "last page", perPage: 2, page: 3, wantShas: []string{"c1"}},
+		{name: "past the end", perPage: 2, page: 4, wantShas: []string{}},
+		{name: "zero page", perPage: 2, page: 0, wantErr: gitprovider.ErrInvalidArgument},
+		{name: "zero page size", perPage: 0, page: 1, wantErr: gitprovider.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestCommitClient(t)
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+				start := min((page-1)*perPage, len(pagedCommitShas))
+				end := min(start+perPage, len(pagedCommitShas))
+				items := []string{}
+				for _, sha := range pagedCommitShas[start:end] {
+					items = append(items, fmt.Sprintf(`{"id": %q, "author_name": "Alice", "message": "m", "created_at": "2023-01-15T12:00:00Z"}`, sha))
+				}
+				fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+			})
+
+			got, err := c.ListPage(context.Background(), "main", tt.perPage, tt.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListPage() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			gotShas := []string{}
+			for _, commit := range got {
+				gotShas = append(gotShas, commit.Get().Sha)
+			}
+			if diff := cmp.Diff(tt.wantShas, gotShas); diff != "" {
+				t.Errorf("ListPage() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommitClient_Create_NestedPaths(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	var payload struct {
+		Actions []struct {
+			Action   string `json:"action"`
+			FilePath string `json:"file_path"`
+		} `json:"actions"`
+	}
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "c3", "author_name": "Alice", "message": "scaffold", "created_at": "2023-01-15T12:00:00Z"}`)
+	})
+
+	// GitLab creates the missing directories of the created files
+	files := []gitprovider.CommitFile{
+		{Path: gitprovider.StringVar("a/b/c/file.txt"), Content: gitprovider.StringVar("hello")},
+		{Path: gitprovider.StringVar("a/b/.gitkeep"), Content: gitprovider.StringVar("")},
+	}
+	if _, err := c.Create(context.Background(), "main", "scaffold", files); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	got := []string{}
+	for _, action := range payload.Actions {
+		got = append(got, action.Action+" "+action.FilePath)
+	}
+	if diff := cmp.Diff([]string{"create a/b/c/file.txt", "create a/b/.gitkeep"}, got); diff != "" {
+		t.Errorf("commit actions (-want +got):\n%s", diff)
+	}
+
+	// Directories can't be committed without files
+	files = []gitprovider.CommitFile{{Path: gitprovider.StringVar("a/b/"), Content: gitprovider.StringVar("")}}
+	if _, err := c.Create(context.Background(), "main", "scaffold", files); !errors.Is(err, gitprovider.ErrInvalidArgument) {
+		t.Errorf("Create() error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestCommitClient_ResolveRef_NotFound(t *testing.T) {
+	mux, c := newTestCommitClient(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/commits/5f2c7a1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "404 Commit Not Found"}`)
+	})
+
+	// An unknown abbreviated SHA isn't looked up again by fetching the repository
+	if _, err := c.ResolveRef(context.Background(), "5f2c7a1"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("ResolveRef() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
@@ -37,12 +37,12 @@ type DeployKeyClient struct {
 // Get returns the repository at the given path.
 //
 // ErrNotFound is returned if the resource does not exist.
-func (c *DeployKeyClient) Get(_ context.Context, deployKeyName string) (gitprovider.DeployKey, error) {
-	return c.get(deployKeyName)
+func (c *DeployKeyClient) Get(ctx context.Context, deployKeyName string) (gitprovider.DeployKey, error) {
+	return c.get(ctx, deployKeyName)
 }
 
-func (c *DeployKeyClient) get(deployKeyName string) (*deployKey, error) {
-	deployKeys, err := c.list()
+func (c *DeployKeyClient) get(ctx context.Context, deployKeyName string) (*deployKey, error) {
+	deployKeys, err := c.list(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +59,8 @@ func (c *DeployKeyClient) get(deployKeyName string) (*deployKey, error) {
 //
 // List returns all available repository deploy keys for the given type,
 // using multiple paginated requests if needed.
-func (c *DeployKeyClient) List(_ context.Context) ([]gitprovider.DeployKey, error) {
-	dks, err := c.list()
+func (c *DeployKeyClient) List(ctx context.Context) ([]gitprovider.DeployKey, error) {
+	dks, err := c.list(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -72,9 +72,9 @@ func (c *DeployKeyClient) List(_ context.Context) ([]gitprovider.DeployKey, erro
 	return keys, nil
 }
 
-func (c *DeployKeyClient) list() ([]*deployKey, error) {
+func (c *DeployKeyClient) list(ctx context.Context) ([]*deployKey, error) {
 	// GET /repos/{owner}/{repo}/keys
-	apiObjs, err := c.c.ListKeys(getRepoPath(c.ref))
+	apiObjs, err := c.c.ListKeys(ctx, getRepoPath(c.ref))
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +138,13 @@ func (c *DeployKeyClient) Reconcile(ctx context.Context, req gitprovider.DeployK
 	return actual, true, actual.Update(ctx)
 }
 
+// ReconcileBatch makes sure all the given desired deploy keys (reqs) become the actual state in the
+// backing Git provider, listing the existing deploy keys only once.
+// See gitprovider.ReconcileDeployKeys for details.
+func (c *DeployKeyClient) ReconcileBatch(ctx context.Context, reqs []gitprovider.DeployKeyInfo) ([]gitprovider.DeployKeyBatchResult, error) {
+	return gitprovider.ReconcileDeployKeys(ctx, c, reqs)
+}
+
 func createDeployKey(c gitlabClient, ref gitprovider.RepositoryRef, req gitprovider.DeployKeyInfo) (*gitlab.ProjectDeployKey, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
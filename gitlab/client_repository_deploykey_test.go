@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestDeployKeyClient_LastUsed(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/deploy_keys", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "title": "key", "key": "ssh-ed25519 AAAAkey", "can_push": false, "created_at": "2023-01-01T00:00:00Z"}]`)
+	})
+	client := &DeployKeyClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.UserRepositoryRef{
+			UserRef:        gitprovider.UserRef{Domain: "gitlab.com", UserLogin: "fluxcd"},
+			RepositoryName: "repo",
+		},
+	}
+
+	key, err := client.Get(context.Background(), "key")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	// GitLab doesn't report the usage of deploy keys
+	if got := key.Get().LastUsed; got != nil {
+		t.Errorf("LastUsed = %v, want nil", *got)
+	}
+	if got := key.Get().LastUsedFrom; got != nil {
+		t.Errorf("LastUsedFrom = %v, want nil", *got)
+	}
+}
@@ -37,12 +37,12 @@ type DeployTokenClient struct {
 // Get returns the repository at the given path.
 //
 // ErrNotFound is returned if the resource does not exist.
-func (c *DeployTokenClient) Get(_ context.Context, deployTokenName string) (gitprovider.DeployToken, error) {
-	return c.get(deployTokenName)
+func (c *DeployTokenClient) Get(ctx context.Context, deployTokenName string) (gitprovider.DeployToken, error) {
+	return c.get(ctx, deployTokenName)
 }
 
-func (c *DeployTokenClient) get(deployTokenName string) (*deployToken, error) {
-	deployTokens, err := c.list()
+func (c *DeployTokenClient) get(ctx context.Context, deployTokenName string) (*deployToken, error) {
+	deployTokens, err := c.list(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +59,8 @@ func (c *DeployTokenClient) get(deployTokenName string) (*deployToken, error) {
 //
 // List returns all available repository deploy tokens for the given type,
 // using multiple paginated requests if needed.
-func (c *DeployTokenClient) List(_ context.Context) ([]gitprovider.DeployToken, error) {
-	dks, err := c.list()
+func (c *DeployTokenClient) List(ctx context.Context) ([]gitprovider.DeployToken, error) {
+	dks, err := c.list(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -72,9 +72,9 @@ func (c *DeployTokenClient) List(_ context.Context) ([]gitprovider.DeployToken,
 	return tokens, nil
 }
 
-func (c *DeployTokenClient) list() ([]*deployToken, error) {
+func (c *DeployTokenClient) list(ctx context.Context) ([]*deployToken, error) {
 	// GET /repos/{owner}/{repo}/tokens
-	apiObjs, err := c.c.ListTokens(getRepoPath(c.ref))
+	apiObjs, err := c.c.ListTokens(ctx, getRepoPath(c.ref))
 	if err != nil {
 		return nil, err
 	}
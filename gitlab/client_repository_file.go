@@ -54,21 +54,24 @@ func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...git
 
 	listFiles, _, err := c.c.Client().Repositories.ListTree(getRepoPath(c.ref), opts)
 	if err != nil {
-		return nil, err
+		return nil, handleHTTPError(err)
 	}
 
 	fileOpts := &gitlab.GetFileOptions{
 		Ref: &branch,
 	}
 
-	files := make([]*gitprovider.CommitFile, 0)
+	paths := make([]string, 0, len(listFiles))
 	for _, file := range listFiles {
 		if file.Type == "tree" {
 			continue
 		}
-		fileDownloaded, _, err := c.c.Client().RepositoryFiles.GetFile(getRepoPath(c.ref), file.Path, fileOpts)
+		paths = append(paths, file.Path)
+	}
+	return gitprovider.FetchFiles(ctx, paths, filesGetOpts.Concurrency, func(ctx context.Context, path string) (*gitprovider.CommitFile, error) {
+		fileDownloaded, _, err := c.c.Client().RepositoryFiles.GetFile(getRepoPath(c.ref), path, fileOpts, gitlab.WithContext(ctx))
 		if err != nil {
-			return nil, err
+			return nil, handleHTTPError(err)
 		}
 		filePath := fileDownloaded.FilePath
 		fileContentDecoded := base64.NewDecoder(base64.RawStdEncoding, strings.NewReader(fileDownloaded.Content))
@@ -77,11 +80,9 @@ func (c *FileClient) Get(ctx context.Context, path, branch string, optFns ...git
 			return nil, err
 		}
 		fileStr := string(fileBytes)
-		files = append(files, &gitprovider.CommitFile{
+		return &gitprovider.CommitFile{
 			Path:    &filePath,
 			Content: &fileStr,
-		})
-	}
-
-	return files, nil
+		}, nil
+	})
 }
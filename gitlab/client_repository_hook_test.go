@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestHookClient(t *testing.T) (*http.ServeMux, *HookClient) {
+	mux, c := setup(t)
+	return mux, &HookClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+}
+
+func TestHookClient_List(t *testing.T) {
+	mux, c := newTestHookClient(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id": 2, "url": "https://ci.example.com/hook", "merge_requests_events": true}]`)
+			return
+		}
+		w.Header().Set("X-Next-Page", "2")
+		fmt.Fprint(w, `[{"id": 1, "url": "https://other.example.com/hook", "push_events": true, "tag_push_events": true}]`)
+	})
+
+	hooks, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	got := []gitprovider.DeployHookInfo{}
+	for _, hook := range hooks {
+		got = append(got, hook.Get())
+	}
+	want := []gitprovider.DeployHookInfo{
+		{
+			URL:         "https://other.example.com/hook",
+			Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventTagPush},
+			ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+			Active:      gitprovider.BoolVar(true),
+		},
+		{
+			URL:         "https://ci.example.com/hook",
+			Events:      []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest},
+			ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+			Active:      gitprovider.BoolVar(true),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+}
+
+func TestHookClient_Reconcile(t *testing.T) {
+	const existing = `[{"id": 2, "url": "https://ci.example.com/hook", "push_events": true, "tag_push_events": true, "job_events": true}]`
+	tests := []struct {
+		name        string
+		req         gitprovider.DeployHookInfo
+		wantAction  bool
+		wantPayload map[string]interface{}
+	}{
+		{
+			name: "up to date",
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventTagPush, gitprovider.WebhookEventPush},
+			},
+			wantAction: false,
+		},
+		{
+			name: "rotated secret",
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventTagPush, gitprovider.WebhookEventPush},
+				Secret: gitprovider.StringVar("r0tated"),
+			},
+			wantAction: true,
+			wantPayload: map[string]interface{}{
+				"url":   "https://ci.example.com/hook",
+				"token": "r0tated",
+			},
+		},
+		{
+			name: "events changed",
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+				Secret: gitprovider.StringVar("s3cr3t"),
+			},
+			wantAction: true,
+			wantPayload: map[string]interface{}{
+				"url":                   "https://ci.example.com/hook",
+				"tag_push_events":       false,
+				"merge_requests_events": true,
+				"token":                 "s3cr3t",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestHookClient(t)
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/hooks", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				fmt.Fprint(w, existing)
+			})
+			var payload map[string]interface{}
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/hooks/2", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				fmt.Fprint(w, `{"id": 2, "url": "https://ci.example.com/hook", "push_events": true, "merge_requests_events": true, "job_events": true}`)
+			})
+
+			hook, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if actionTaken != tt.wantAction {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantAction)
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("payload (-want +got):\n%s", diff)
+			}
+			// The token is write-only, and never returned
+			if secret := hook.Get().Secret; secret != nil {
+				t.Errorf("Get().Secret = %q, want nil", *secret)
+			}
+		})
+	}
+}
+
+func TestDeployHook_Delete(t *testing.T) {
+	tests := []struct {
+		name               string
+		destructiveActions bool
+		wantErr            error
+		wantDeleted        bool
+	}{
+		{
+			name:               "destructive actions enabled",
+			destructiveActions: true,
+			wantDeleted:        true,
+		},
+		{
+			name:    "destructive actions disabled",
+			wantErr: gitprovider.ErrDestructiveCallDisallowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestHookClient(t)
+			c.destructiveActions = tt.destructiveActions
+			deleted := false
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/hooks/1", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			hook := newDeployHook(c, &gitlab.ProjectHook{ID: 1, URL: "https://example.com/hook"}, nil)
+			if err := hook.Delete(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("Delete() deleted the webhook = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
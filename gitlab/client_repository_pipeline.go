@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// PipelineClient implements the gitprovider.PipelineClient interface.
+var _ gitprovider.PipelineClient = &PipelineClient{}
+
+// PipelineClient operates on the CI pipelines of a specific project.
+type PipelineClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Trigger creates a pipeline for the given branch or tag, passing it the given variables as
+// environment variables.
+func (c *PipelineClient) Trigger(ctx context.Context, ref string, variables map[string]string) (gitprovider.Pipeline, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("ref is required: %w", gitprovider.ErrInvalidArgument)
+	}
+	opts := &gitlab.CreatePipelineOptions{Ref: gitlab.Ptr(ref)}
+	if len(variables) != 0 {
+		// Sort the variables to get a deterministic request
+		keys := make([]string, 0, len(variables))
+		for key := range variables {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		vars := make([]*gitlab.PipelineVariableOptions, 0, len(keys))
+		for _, key := range keys {
+			vars = append(vars, &gitlab.PipelineVariableOptions{
+				Key:          gitlab.Ptr(key),
+				Value:        gitlab.Ptr(variables[key]),
+				VariableType: gitlab.Ptr(gitlab.EnvVariableType),
+			})
+		}
+		opts.Variables = &vars
+	}
+
+	// POST /projects/{project}/pipeline
+	apiObj, err := c.c.CreatePipeline(ctx, getRepoPath(c.ref), opts)
+	if err != nil {
+		return nil, err
+	}
+	return newPipeline(c, apiObj), nil
+}
+
+// Get returns the pipeline with the given ID.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *PipelineClient) Get(ctx context.Context, id int) (gitprovider.Pipeline, error) {
+	// GET /projects/{project}/pipelines/{pipeline_id}
+	apiObj, err := c.c.GetPipeline(ctx, getRepoPath(c.ref), id)
+	if err != nil {
+		return nil, err
+	}
+	return newPipeline(c, apiObj), nil
+}
+
+// List lists the page of the pipelines of the project given in opts, most recent first.
+func (c *PipelineClient) List(ctx context.Context, opts gitprovider.PipelineListOptions) ([]gitprovider.Pipeline, error) {
+	if err := opts.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	lpOpts := &gitlab.ListProjectPipelinesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: opts.PerPage,
+			Page:    opts.Page,
+		},
+	}
+	if opts.Ref != "" {
+		lpOpts.Ref = &opts.Ref
+	}
+	if opts.Status != "" {
+		lpOpts.Status = gitlab.Ptr(gitlab.BuildStateValue(opts.Status))
+	}
+
+	// GET /projects/{project}/pipelines
+	apiObjs, err := c.c.ListPipelinesPage(ctx, getRepoPath(c.ref), lpOpts)
+	if err != nil {
+		return nil, err
+	}
+	pipelines := make([]gitprovider.Pipeline, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		pipelines = append(pipelines, newPipeline(c, apiObj))
+	}
+	return pipelines, nil
+}
+
+// GetRunLogs returns the logs of the jobs of the pipeline with the given ID, one job after the
+// other in the order they were created. The log of each job is downloaded as the returned reader
+// is read, which must be closed by the caller.
+//
+// ErrNotFound is returned if the pipeline does not exist.
+func (c *PipelineClient) GetRunLogs(ctx context.Context, id int) (io.ReadCloser, error) {
+	// GET /projects/{project}/pipelines/{pipeline_id}/jobs
+	jobs, err := c.c.ListPipelineJobs(ctx, getRepoPath(c.ref), id)
+	if err != nil {
+		return nil, err
+	}
+	// The jobs are listed most recent first
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].ID < jobs[j].ID
+	})
+
+	pr, pw := io.Pipe()
+	go func() {
+		for _, job := range jobs {
+			// GET /projects/{project}/jobs/{job_id}/trace
+			if err := c.c.GetJobTrace(ctx, getRepoPath(c.ref), job.ID, pw); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.Close()
+	}()
+	return pr, nil
+}
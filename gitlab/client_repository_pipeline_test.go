@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestPipelineClient(t *testing.T) (*http.ServeMux, *PipelineClient) {
+	mux, c := setup(t)
+	return mux, &PipelineClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.UserRepositoryRef{
+			UserRef:        gitprovider.UserRef{Domain: "gitlab.com", UserLogin: "fluxcd"},
+			RepositoryName: "repo",
+		},
+	}
+}
+
+func TestPipelineClient_Trigger(t *testing.T) {
+	tests := []struct {
+		name        string
+		variables   map[string]string
+		wantPayload map[string]interface{}
+	}{
+		{
+			name: "no variables",
+			wantPayload: map[string]interface{}{
+				"ref": "main",
+			},
+		},
+		{
+			name:      "variables",
+			variables: map[string]string{"TARGET": "production", "DRY_RUN": "false"},
+			wantPayload: map[string]interface{}{
+				"ref": "main",
+				"variables": []interface{}{
+					map[string]interface{}{"key": "DRY_RUN", "value": "false", "variable_type": "env_var"},
+					map[string]interface{}{"key": "TARGET", "value": "production", "variable_type": "env_var"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestPipelineClient(t)
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/pipeline", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				payload := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("failed to decode the request body: %v", err)
+				}
+				if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+					t.Errorf("Trigger() payload (-want +got):\n%s", diff)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id": 42, "ref": "main", "sha": "abc123", "status": "created", "web_url": "https://gitlab.com/fluxcd/repo/-/pipelines/42"}`)
+			})
+
+			p, err := c.Trigger(context.Background(), "main", tt.variables)
+			if err != nil {
+				t.Fatalf("Trigger() error = %v", err)
+			}
+			want := gitprovider.PipelineInfo{
+				ID:     42,
+				Ref:    "main",
+				Sha:    "abc123",
+				Status: "created",
+				URL:    "https://gitlab.com/fluxcd/repo/-/pipelines/42",
+			}
+			if diff := cmp.Diff(want, p.Get()); diff != "" {
+				t.Errorf("Trigger() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPipelineClient_List(t *testing.T) {
+	mux, c := newTestPipelineClient(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/pipelines", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("ref") != "main" || q.Get("status") != "failed" || q.Get("per_page") != "2" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[{"id": 43, "ref": "main", "status": "failed"}, {"id": 41, "ref": "main", "status": "failed"}]`)
+	})
+
+	pipelines, err := c.List(context.Background(), gitprovider.PipelineListOptions{Ref: "main", Status: "failed", PerPage: 2})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	got := []int{}
+	for _, p := range pipelines {
+		got = append(got, p.Get().ID)
+	}
+	if diff := cmp.Diff([]int{43, 41}, got); diff != "" {
+		t.Errorf("List() IDs (-want +got):\n%s", diff)
+	}
+}
+
+func TestPipelineClient_GetRunLogs(t *testing.T) {
+	mux, c := newTestPipelineClient(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/pipelines/12/jobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 101, "name": "test"}, {"id": 100, "name": "build"}]`)
+	})
+	for _, id := range []int{100, 101} {
+		id := id
+		mux.HandleFunc(fmt.Sprintf("/api/v4/projects/fluxcd%%2Frepo/jobs/%d/trace", id), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "trace of job %d\n", id)
+		})
+	}
+
+	logs, err := c.GetRunLogs(context.Background(), 12)
+	if err != nil {
+		t.Fatalf("GetRunLogs() error = %v", err)
+	}
+	defer logs.Close()
+	got, err := io.ReadAll(logs)
+	if err != nil {
+		t.Fatalf("reading the logs failed: %v", err)
+	}
+	if want := "trace of job 100\ntrace of job 101\n"; string(got) != want {
+		t.Errorf("GetRunLogs() = %q, want %q", got, want)
+	}
+}
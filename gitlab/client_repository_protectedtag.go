@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// ProtectedTagClient implements the gitprovider.ProtectedTagClient interface.
+var _ gitprovider.ProtectedTagClient = &ProtectedTagClient{}
+
+// ProtectedTagClient operates on the protected tags of a specific repository.
+type ProtectedTagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the protected tag with the given name or pattern.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (c *ProtectedTagClient) Get(ctx context.Context, name string) (gitprovider.ProtectedTag, error) {
+	return c.get(ctx, name)
+}
+
+func (c *ProtectedTagClient) get(ctx context.Context, name string) (*protectedTag, error) {
+	protectedTags, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Loop through the protected tags until we find one with the right pattern
+	for _, pt := range protectedTags {
+		if pt.t.Name == name {
+			return pt, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all protected tags of the repository.
+//
+// List returns all available protected tags, using multiple paginated requests if needed.
+func (c *ProtectedTagClient) List(ctx context.Context) ([]gitprovider.ProtectedTag, error) {
+	pts, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Cast to the generic []gitprovider.ProtectedTag
+	tags := make([]gitprovider.ProtectedTag, 0, len(pts))
+	for _, pt := range pts {
+		tags = append(tags, pt)
+	}
+	return tags, nil
+}
+
+func (c *ProtectedTagClient) list(ctx context.Context) ([]*protectedTag, error) {
+	// GET /projects/{project}/protected_tags
+	apiObjs, err := c.c.ListProtectedTags(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the api object to our ProtectedTag type
+	tags := make([]*protectedTag, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		// apiObj is already validated at ListProtectedTags
+		tags = append(tags, newProtectedTag(c, apiObj))
+	}
+
+	return tags, nil
+}
+
+// Create protects the tags matching the given specifications.
+//
+// ErrAlreadyExists will be returned if the resource already exists.
+func (c *ProtectedTagClient) Create(ctx context.Context, req gitprovider.ProtectedTagInfo) (gitprovider.ProtectedTag, error) {
+	apiObj, err := createProtectedTag(ctx, c.c, c.ref, req)
+	if err != nil {
+		return nil, err
+	}
+	return newProtectedTag(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be deleted and recreated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *ProtectedTagClient) Reconcile(ctx context.Context, req gitprovider.ProtectedTagInfo) (gitprovider.ProtectedTag, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	// Get the protected tag with the desired pattern
+	actual, err := c.get(ctx, req.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if req.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object, keeping the actual state to
+	// restore it if applying the desired one fails
+	previous := actual.t
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	return actual, true, actual.update(ctx, &previous)
+}
+
+func createProtectedTag(ctx context.Context, c gitlabClient, ref gitprovider.RepositoryRef, req gitprovider.ProtectedTagInfo) (*gitlab.ProtectedTag, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	return c.ProtectTag(ctx, getRepoPath(ref), protectedTagToAPI(&req))
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestProtectedTagClient(t *testing.T) (*http.ServeMux, *ProtectedTagClient) {
+	mux, c := setup(t)
+	return mux, &ProtectedTagClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.UserRepositoryRef{
+			UserRef:        gitprovider.UserRef{Domain: "gitlab.com", UserLogin: "fluxcd"},
+			RepositoryName: "repo",
+		},
+	}
+}
+
+func TestProtectedTagClient_Create(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         gitprovider.ProtectedTagInfo
+		wantPayload map[string]interface{}
+	}{
+		{
+			name: "default access level",
+			req:  gitprovider.ProtectedTagInfo{Name: "v*"},
+			wantPayload: map[string]interface{}{
+				"name":                "v*",
+				"create_access_level": float64(40),
+			},
+		},
+		{
+			name: "developers can create",
+			req: gitprovider.ProtectedTagInfo{
+				Name:              "release-*",
+				CreateAccessLevel: gitprovider.PermissionLevelVar(gitprovider.PermissionLevelWrite),
+			},
+			wantPayload: map[string]interface{}{
+				"name":                "release-*",
+				"create_access_level": float64(30),
+			},
+		},
+		{
+			name: "no one can create",
+			req: gitprovider.ProtectedTagInfo{
+				Name:              "v1.0.0",
+				CreateAccessLevel: gitprovider.PermissionLevelVar(gitprovider.PermissionLevelNone),
+			},
+			wantPayload: map[string]interface{}{
+				"name":                "v1.0.0",
+				"create_access_level": float64(0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestProtectedTagClient(t)
+			var payload map[string]interface{}
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/protected_tags", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"name": %q, "create_access_levels": [{"access_level": %v}]}`, payload["name"], payload["create_access_level"])
+			})
+
+			got, err := c.Create(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("Create() payload (-want +got):\n%s", diff)
+			}
+			want := tt.req
+			want.Default()
+			if diff := cmp.Diff(want, got.Get()); diff != "" {
+				t.Errorf("Create() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProtectedTagClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             gitprovider.ProtectedTagInfo
+		wantActionTaken bool
+		wantRequests    []string
+	}{
+		{
+			name:            "no-op when the actual state matches",
+			req:             gitprovider.ProtectedTagInfo{Name: "v*"},
+			wantActionTaken: false,
+			wantRequests:    []string{"GET"},
+		},
+		{
+			name: "recreate when the access level differs",
+			req: gitprovider.ProtectedTagInfo{
+				Name:              "v*",
+				CreateAccessLevel: gitprovider.PermissionLevelVar(gitprovider.PermissionLevelWrite),
+			},
+			wantActionTaken: true,
+			wantRequests:    []string{"GET", "DELETE", "POST"},
+		},
+		{
+			name:            "create when the pattern isn't protected",
+			req:             gitprovider.ProtectedTagInfo{Name: "release-*"},
+			wantActionTaken: true,
+			wantRequests:    []string{"GET", "POST"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestProtectedTagClient(t)
+			requests := []string{}
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/protected_tags", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method)
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, `[{"name": "v*", "create_access_levels": [{"access_level": 40, "access_level_description": "Maintainers"}]}]`)
+				case http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"name": "v*", "create_access_levels": [{"access_level": 30}]}`)
+				}
+			})
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/protected_tags/{name}", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method)
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			_, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(tt.wantRequests, requests); diff != "" {
+				t.Errorf("Reconcile() requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProtectedTagClient_Reconcile_RestoresOnFailure(t *testing.T) {
+	mux, c := newTestProtectedTagClient(t)
+	var restored map[string]interface{}
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/protected_tags", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[{"name": "v*", "create_access_levels": [{"access_level": 40}, {"access_level": 30, "user_id": 7}]}]`)
+		case http.MethodPost:
+			var payload map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			// Fail to apply the desired state, but accept restoring the previous one
+			if _, ok := payload["allowed_to_create"]; !ok {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				fmt.Fprint(w, `{"message": "invalid access level"}`)
+				return
+			}
+			restored = payload
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"name": "v*", "create_access_levels": [{"access_level": 40}, {"access_level": 30, "user_id": 7}]}`)
+		}
+	})
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/protected_tags/{name}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, _, err := c.Reconcile(context.Background(), gitprovider.ProtectedTagInfo{
+		Name:              "v*",
+		CreateAccessLevel: gitprovider.PermissionLevelVar(gitprovider.PermissionLevelWrite),
+	})
+	if err == nil {
+		t.Fatal("Reconcile() expected an error")
+	}
+	want := map[string]interface{}{
+		"name": "v*",
+		"allowed_to_create": []interface{}{
+			map[string]interface{}{"access_level": float64(40)},
+			map[string]interface{}{"user_id": float64(7)},
+		},
+	}
+	if diff := cmp.Diff(want, restored); diff != "" {
+		t.Errorf("restored protection (-want +got):\n%s", diff)
+	}
+}
+
+func Test_createAccessLevelFromAPI(t *testing.T) {
+	maintainers := &gitlab.TagAccessDescription{AccessLevel: gitlab.MaintainerPermissions}
+	developers := &gitlab.TagAccessDescription{AccessLevel: gitlab.DeveloperPermissions}
+	user := &gitlab.TagAccessDescription{AccessLevel: gitlab.DeveloperPermissions, UserID: 7}
+	tests := []struct {
+		name   string
+		levels []*gitlab.TagAccessDescription
+		want   gitprovider.PermissionLevel
+	}{
+		{name: "maintainers", levels: []*gitlab.TagAccessDescription{maintainers}, want: gitprovider.PermissionLevelAdmin},
+		{name: "maintainers then developers", levels: []*gitlab.TagAccessDescription{maintainers, developers}, want: gitprovider.PermissionLevelWrite},
+		{name: "developers then maintainers", levels: []*gitlab.TagAccessDescription{developers, maintainers}, want: gitprovider.PermissionLevelWrite},
+		{name: "user then maintainers", levels: []*gitlab.TagAccessDescription{user, maintainers}, want: gitprovider.PermissionLevelAdmin},
+		{name: "no one", levels: []*gitlab.TagAccessDescription{{AccessLevel: gitlab.NoPermissions}}, want: gitprovider.PermissionLevelNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createAccessLevelFromAPI(tt.levels); got != tt.want {
+				t.Errorf("createAccessLevelFromAPI() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
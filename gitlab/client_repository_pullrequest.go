@@ -17,8 +17,10 @@ limitations under the License.
 package gitlab
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -28,6 +30,26 @@ import (
 // mergeStatusChecking indicates that gitlab has not yet asynchronously updated the merge status for a merge request
 const mergeStatusChecking = "checking"
 
+// draftTitlePrefix marks a merge request as a draft when prefixing its title.
+const draftTitlePrefix = "Draft: "
+
+// mergeabilityPollInterval is the interval at which the merge status of a merge request is polled
+// while GitLab computes it.
+//
+//nolint:gochecknoglobals
+var mergeabilityPollInterval = 2 * time.Second
+
+// unknownDetailedMergeStatuses are the detailed merge statuses GitLab reports while it's still
+// computing whether a merge request can be merged.
+//
+//nolint:gochecknoglobals
+var unknownDetailedMergeStatuses = map[string]struct{}{
+	"unchecked":         {},
+	"checking":          {},
+	"preparing":         {},
+	"approvals_syncing": {},
+}
+
 // PullRequestClient implements the gitprovider.PullRequestClient interface.
 var _ gitprovider.PullRequestClient = &PullRequestClient{}
 
@@ -38,8 +60,15 @@ type PullRequestClient struct {
 }
 
 // List lists all pull requests in the repository
-func (c *PullRequestClient) List(_ context.Context) ([]gitprovider.PullRequest, error) {
-	mrs, _, err := c.c.Client().MergeRequests.ListProjectMergeRequests(getRepoPath(c.ref), nil)
+// The labels filter is passed to GitLab.
+func (c *PullRequestClient) List(_ context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	o := gitprovider.MakePullRequestListOptions(opts...)
+	var listOpts *gitlab.ListProjectMergeRequestsOptions
+	if len(o.Labels) > 0 {
+		labels := gitlab.LabelOptions(o.Labels)
+		listOpts = &gitlab.ListProjectMergeRequestsOptions{Labels: &labels}
+	}
+	mrs, _, err := c.c.Client().MergeRequests.ListProjectMergeRequests(getRepoPath(c.ref), listOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +83,11 @@ func (c *PullRequestClient) List(_ context.Context) ([]gitprovider.PullRequest,
 }
 
 // Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(_ context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// Draft merge requests are created by prefixing their title with "Draft:".
+func (c *PullRequestClient) Create(_ context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	if o := gitprovider.MakePullRequestCreateOptions(opts...); o.Draft {
+		title = draftTitlePrefix + title
+	}
 
 	prOpts := &gitlab.CreateMergeRequestOptions{
 		Title:        &title,
@@ -146,3 +179,258 @@ func (c *PullRequestClient) waitForMergeRequestToBeMergeable(number int) error {
 
 	return fmt.Errorf("merge status unavailable for pull request number: %d", number)
 }
+
+// MergeabilityStatus returns whether the merge request can be merged, and why not.
+// GitLab computes the merge status in the background, so the merge request is polled until it's known.
+// A failed pipeline is reported as the "pipeline" check when the project requires pipelines to succeed.
+func (c *PullRequestClient) MergeabilityStatus(ctx context.Context, number int) (gitprovider.MergeableInfo, error) {
+	return gitprovider.PollMergeability(ctx, mergeabilityPollInterval, func(ctx context.Context) (gitprovider.MergeableInfo, error) {
+		mr, _, err := c.c.Client().MergeRequests.GetMergeRequest(getRepoPath(c.ref), number, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+		if err != nil {
+			return gitprovider.MergeableInfo{}, handleHTTPError(err)
+		}
+		if _, unknown := unknownDetailedMergeStatuses[mr.DetailedMergeStatus]; unknown {
+			return gitprovider.MergeableInfo{}, nil
+		}
+
+		info := gitprovider.MergeableInfo{
+			Mergeable: gitprovider.BoolVar(mr.DetailedMergeStatus == "mergeable"),
+			Conflicts: mr.HasConflicts || mr.DetailedMergeStatus == "conflict",
+		}
+		if mr.DetailedMergeStatus == "ci_must_pass" && mr.HeadPipeline != nil {
+			switch mr.HeadPipeline.Status {
+			case "failed", "canceled":
+				info.FailingChecks = []string{"pipeline"}
+			}
+		}
+		return info, nil
+	})
+}
+
+// PreviewRebase returns whether rebasing the merge request would conflict, from the conflicts
+// GitLab computes in the background, hence the merge request is polled until its merge status is
+// known. The rebase endpoint isn't used, as it rebases the merge request right away. GitLab's API
+// doesn't report the conflicting files.
+func (c *PullRequestClient) PreviewRebase(ctx context.Context, number int) (gitprovider.RebasePreview, error) {
+	info, err := c.MergeabilityStatus(ctx, number)
+	if err != nil {
+		return gitprovider.RebasePreview{}, err
+	}
+	return gitprovider.RebasePreview{Conflicts: info.Conflicts}, nil
+}
+
+// Diff returns the changes of the merge request as a unified diff.
+// GitLab returns the diff of every file without its headers, so these are reconstructed.
+func (c *PullRequestClient) Diff(ctx context.Context, number int) ([]byte, error) {
+	apiObjs := []*gitlab.MergeRequestDiff{}
+	opts := &gitlab.ListMergeRequestDiffsOptions{}
+	err := allMergeRequestDiffPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/merge_requests/{merge_request_iid}/diffs
+		pageObjs, resp, listErr := c.c.Client().MergeRequests.ListMergeRequestDiffs(getRepoPath(c.ref), number, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mergeRequestDiffsToPatch(apiObjs), nil
+}
+
+// ListCommits lists the commits of the merge request.
+func (c *PullRequestClient) ListCommits(ctx context.Context, number int) ([]gitprovider.Commit, error) {
+	apiObjs := []*gitlab.Commit{}
+	opts := &gitlab.GetMergeRequestCommitsOptions{}
+	err := allMergeRequestCommitPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/merge_requests/{merge_request_iid}/commits
+		pageObjs, resp, listErr := c.c.Client().MergeRequests.GetMergeRequestCommits(getRepoPath(c.ref), number, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	commitClient := &CommitClient{clientContext: c.clientContext, ref: c.ref}
+	commits := make([]gitprovider.Commit, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		commits = append(commits, newCommit(commitClient, apiObj))
+	}
+	return commits, nil
+}
+
+// ReviewSummary returns the current verdicts of the reviewers of the merge request. GitLab
+// reports who approved the merge request separately from the state of its reviewers, and
+// approvals may come from users who weren't asked for a review.
+func (c *PullRequestClient) ReviewSummary(ctx context.Context, number int) (gitprovider.ReviewSummary, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/reviewers
+	reviewers, _, err := c.c.Client().MergeRequests.GetMergeRequestReviewers(getRepoPath(c.ref), number, gitlab.WithContext(ctx))
+	if err != nil {
+		return gitprovider.ReviewSummary{}, handleHTTPError(err)
+	}
+	// GET /projects/{project}/merge_requests/{merge_request_iid}/approvals
+	approvals, _, err := c.c.Client().MergeRequestApprovals.GetConfiguration(getRepoPath(c.ref), number, gitlab.WithContext(ctx))
+	if err != nil {
+		return gitprovider.ReviewSummary{}, handleHTTPError(err)
+	}
+
+	reviews := []gitprovider.ReviewInfo{}
+	for _, reviewer := range reviewers {
+		if reviewer.User != nil && reviewer.State == "requested_changes" {
+			reviews = append(reviews, gitprovider.ReviewInfo{
+				Reviewer: reviewer.User.Username,
+				State:    gitprovider.ReviewStateChangesRequested,
+			})
+		}
+	}
+	// Approving a merge request replaces the change request of its reviewer, hence approvals come last
+	for _, approver := range approvals.ApprovedBy {
+		if approver.User != nil {
+			reviews = append(reviews, gitprovider.ReviewInfo{
+				Reviewer: approver.User.Username,
+				State:    gitprovider.ReviewStateApproved,
+			})
+		}
+	}
+	return gitprovider.SummarizeReviews(reviews), nil
+}
+
+// CreateComment posts a note on the merge request.
+func (c *PullRequestClient) CreateComment(ctx context.Context, number int, body string) (gitprovider.PullRequestComment, error) {
+	opts := &gitlab.CreateMergeRequestNoteOptions{Body: &body}
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/notes
+	apiObj, _, err := c.c.Client().Notes.CreateMergeRequestNote(getRepoPath(c.ref), number, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return gitprovider.PullRequestComment{}, handleHTTPError(err)
+	}
+	return pullRequestCommentFromAPI(apiObj), nil
+}
+
+// ListComments lists the notes on the merge request. The notes GitLab adds itself, e.g. when
+// commits are pushed, and the notes on the lines of the diff are skipped.
+func (c *PullRequestClient) ListComments(ctx context.Context, number int) ([]gitprovider.PullRequestComment, error) {
+	apiObjs := []*gitlab.Note{}
+	opts := &gitlab.ListMergeRequestNotesOptions{
+		OrderBy: gitlab.Ptr("created_at"),
+		Sort:    gitlab.Ptr("asc"),
+	}
+	err := allMergeRequestNotePages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/merge_requests/{merge_request_iid}/notes
+		pageObjs, resp, listErr := c.c.Client().Notes.ListMergeRequestNotes(getRepoPath(c.ref), number, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []gitprovider.PullRequestComment{}
+	for _, apiObj := range apiObjs {
+		if apiObj.System || apiObj.Type == gitlab.DiffNote || apiObj.Type == gitlab.LegacyDiffNote {
+			continue
+		}
+		comments = append(comments, pullRequestCommentFromAPI(apiObj))
+	}
+	return comments, nil
+}
+
+// AddLabels adds labels to the merge request. GitLab creates the labels which don't exist yet.
+func (c *PullRequestClient) AddLabels(ctx context.Context, number int, labels []string) error {
+	addLabels := gitlab.LabelOptions(labels)
+	opts := &gitlab.UpdateMergeRequestOptions{AddLabels: &addLabels}
+	// PUT /projects/{project}/merge_requests/{merge_request_iid}
+	_, _, err := c.c.Client().MergeRequests.UpdateMergeRequest(getRepoPath(c.ref), number, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+// ListLabels lists the names of the labels of the merge request.
+func (c *PullRequestClient) ListLabels(ctx context.Context, number int) ([]string, error) {
+	// GET /projects/{project}/merge_requests/{merge_request_iid}
+	apiObj, _, err := c.c.Client().MergeRequests.GetMergeRequest(getRepoPath(c.ref), number, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return append([]string{}, apiObj.Labels...), nil
+}
+
+func pullRequestCommentFromAPI(apiObj *gitlab.Note) gitprovider.PullRequestComment {
+	comment := gitprovider.PullRequestComment{
+		ID:     int64(apiObj.ID),
+		Author: apiObj.Author.Username,
+		Body:   apiObj.Body,
+	}
+	if apiObj.CreatedAt != nil {
+		comment.CreatedAt = *apiObj.CreatedAt
+	}
+	return comment
+}
+
+// Review submits a review of the merge request. GitLab has no reviews as such: approving approves
+// the merge request, and posts the body as a note, while commenting only posts the note. The API
+// object of the review is the approval state of the merge request, or the note.
+// GitLab can't request changes through its API, ErrNoProviderSupport is returned.
+func (c *PullRequestClient) Review(ctx context.Context, number int, action gitprovider.ReviewAction, body string) (gitprovider.PullRequestReview, error) {
+	if err := gitprovider.ValidateReview(action, body); err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case gitprovider.ReviewRequestChanges:
+		return nil, gitprovider.ErrNoProviderSupport
+	case gitprovider.ReviewComment:
+		opts := &gitlab.CreateMergeRequestNoteOptions{Body: &body}
+		// POST /projects/{project}/merge_requests/{merge_request_iid}/notes
+		note, _, err := c.c.Client().Notes.CreateMergeRequestNote(getRepoPath(c.ref), number, opts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, handleHTTPError(err)
+		}
+		return newPullRequestReview(note, note.Author.Username, gitprovider.ReviewStateCommented), nil
+	}
+
+	// POST /projects/{project}/merge_requests/{merge_request_iid}/approve
+	approvals, _, err := c.c.Client().MergeRequestApprovals.ApproveMergeRequest(getRepoPath(c.ref), number, &gitlab.ApproveMergeRequestOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if body != "" {
+		if _, err := c.CreateComment(ctx, number, body); err != nil {
+			return nil, err
+		}
+	}
+	// The approvers don't tell which one is the authenticated user
+	// GET /user
+	user, err := c.c.GetUser(ctx)
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return newPullRequestReview(approvals, user.Username, gitprovider.ReviewStateApproved), nil
+}
+
+// mergeRequestDiffsToPatch joins the diffs of the files of a merge request into a unified diff,
+// adding the headers "git diff" prints for every file.
+func mergeRequestDiffsToPatch(apiObjs []*gitlab.MergeRequestDiff) []byte {
+	var buf bytes.Buffer
+	for _, apiObj := range apiObjs {
+		oldPath, newPath := "a/"+apiObj.OldPath, "b/"+apiObj.NewPath
+		fmt.Fprintf(&buf, "diff --git %s %s\n", oldPath, newPath)
+		switch {
+		case apiObj.NewFile:
+			fmt.Fprintf(&buf, "new file mode %s\n", apiObj.BMode)
+			oldPath = "/dev/null"
+		case apiObj.DeletedFile:
+			fmt.Fprintf(&buf, "deleted file mode %s\n", apiObj.AMode)
+			newPath = "/dev/null"
+		case apiObj.RenamedFile:
+			fmt.Fprintf(&buf, "rename from %s\nrename to %s\n", apiObj.OldPath, apiObj.NewPath)
+		}
+		// Pure renames and mode changes have no hunks
+		if apiObj.Diff == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldPath, newPath)
+		buf.WriteString(apiObj.Diff)
+		if !strings.HasSuffix(apiObj.Diff, "\n") {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
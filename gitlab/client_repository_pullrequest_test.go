@@ -0,0 +1,273 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestPullRequestClient_List_Labels(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("labels"); got != "bug,help" {
+			t.Errorf("labels query = %q, want %q", got, "bug,help")
+		}
+		fmt.Fprint(w, `[{"iid": 1, "title": "fix", "labels": ["bug", "help"]}]`)
+	})
+	client := &PullRequestClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	prs, err := client.List(context.Background(), &gitprovider.PullRequestListOptions{Labels: []string{"bug", "help"}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("List() returned %d pull requests, want 1", len(prs))
+	}
+	if got := prs[0].Get().Labels; len(got) != 2 {
+		t.Errorf("Labels = %v, want [bug help]", got)
+	}
+}
+
+func TestPullRequestClient_Create_Draft(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []gitprovider.PullRequestCreateOption
+		wantTitle string
+	}{
+		{
+			name:      "ready for review",
+			wantTitle: "Update manifests",
+		},
+		{
+			name:      "draft",
+			opts:      []gitprovider.PullRequestCreateOption{&gitprovider.PullRequestCreateOptions{Draft: true}},
+			wantTitle: "Draft: Update manifests",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+				var payload gitlab.CreateMergeRequestOptions
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				if got := *payload.Title; got != tt.wantTitle {
+					t.Errorf("title = %q, want %q", got, tt.wantTitle)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"iid": 1, "title": %q}`, *payload.Title)
+			})
+			client := &PullRequestClient{
+				clientContext: &clientContext{c: c, domain: "gitlab.com"},
+				ref: gitprovider.OrgRepositoryRef{
+					OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+					RepositoryName:  "repo",
+				},
+			}
+
+			pr, err := client.Create(context.Background(), "Update manifests", "feature", "main", "", tt.opts...)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if got := pr.Get().Title; got != tt.wantTitle {
+				t.Errorf("Create() title = %q, want %q", got, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestPullRequestClient_Labels(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			var payload gitlab.UpdateMergeRequestOptions
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			if payload.AddLabels == nil || !reflect.DeepEqual(*payload.AddLabels, gitlab.LabelOptions{"automated"}) {
+				t.Errorf("add_labels = %v, want [automated]", payload.AddLabels)
+			}
+			if payload.Labels != nil {
+				t.Errorf("labels = %v, want the existing labels to be kept", *payload.Labels)
+			}
+			fmt.Fprint(w, `{"iid": 1, "labels": ["flux", "automated"]}`)
+		case http.MethodGet:
+			fmt.Fprint(w, `{"iid": 1, "labels": ["flux", "automated"]}`)
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	})
+	client := &PullRequestClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	if err := client.AddLabels(context.Background(), 1, []string{"automated"}); err != nil {
+		t.Fatalf("AddLabels() error = %v", err)
+	}
+	got, err := client.ListLabels(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListLabels() error = %v", err)
+	}
+	if want := []string{"flux", "automated"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListLabels() = %v, want %v", got, want)
+	}
+}
+
+func Test_mergeRequestDiffsToPatch(t *testing.T) {
+	apiObjs := []*gitlab.MergeRequestDiff{
+		{OldPath: "README.md", NewPath: "README.md", AMode: "100644", BMode: "100644", Diff: "@@ -1 +1 @@\n-# Old\n+# New\n"},
+		{OldPath: "new.txt", NewPath: "new.txt", AMode: "0", BMode: "100644", NewFile: true, Diff: "@@ -0,0 +1 @@\n+new"},
+		{OldPath: "a.txt", NewPath: "b.txt", AMode: "100644", BMode: "100644", RenamedFile: true},
+	}
+	want := `diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-# Old
++# New
+diff --git a/new.txt b/new.txt
+new file mode 100644
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++new
+diff --git a/a.txt b/b.txt
+rename from a.txt
+rename to b.txt
+`
+	if got := string(mergeRequestDiffsToPatch(apiObjs)); got != want {
+		t.Errorf("mergeRequestDiffsToPatch() = %q, want %q", got, want)
+	}
+}
+
+func TestPullRequestClient_Comments(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/merge_requests/1/notes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 3, "author": {"username": "flux-bot"}, "body": "Reconciliation succeeded", "created_at": "2023-06-02T10:00:00Z"}`)
+		case http.MethodGet:
+			if got := r.URL.Query().Get("sort"); got != "asc" {
+				t.Errorf("sort query = %q, want %q", got, "asc")
+			}
+			fmt.Fprint(w, `[
+				{"id": 1, "author": {"username": "stefan"}, "body": "Please reconcile", "created_at": "2023-06-01T10:00:00Z"},
+				{"id": 2, "author": {"username": "stefan"}, "body": "added 1 commit", "system": true, "created_at": "2023-06-01T11:00:00Z"},
+				{"id": 4, "type": "DiffNote", "author": {"username": "hidde"}, "body": "nit", "created_at": "2023-06-01T12:00:00Z"},
+				{"id": 3, "author": {"username": "flux-bot"}, "body": "Reconciliation succeeded", "created_at": "2023-06-02T10:00:00Z"}
+			]`)
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	})
+	client := &PullRequestClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	created, err := client.CreateComment(context.Background(), 1, "Reconciliation succeeded")
+	if err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+	if created.ID != 3 || created.Author != "flux-bot" {
+		t.Errorf("CreateComment() = %+v, want the comment 3 of flux-bot", created)
+	}
+
+	comments, err := client.ListComments(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListComments() error = %v", err)
+	}
+	want := []gitprovider.PullRequestComment{
+		{ID: 1, Author: "stefan", Body: "Please reconcile", CreatedAt: time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)},
+		{ID: 3, Author: "flux-bot", Body: "Reconciliation succeeded", CreatedAt: time.Date(2023, 6, 2, 10, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("ListComments() = %+v, want %+v", comments, want)
+	}
+}
+
+func TestPullRequestClient_Review(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/merge_requests/1/approve", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		fmt.Fprint(w, `{"iid": 1, "approved_by": [{"user": {"username": "stefan"}}, {"user": {"username": "flux-bot"}}]}`)
+	})
+	notes := 0
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/merge_requests/1/notes", func(w http.ResponseWriter, _ *http.Request) {
+		notes++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 3, "author": {"username": "flux-bot"}, "body": "LGTM"}`)
+	})
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id": 2, "username": "flux-bot"}`)
+	})
+	client := &PullRequestClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.OrgRepositoryRef{
+			OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+			RepositoryName:  "repo",
+		},
+	}
+
+	review, err := client.Review(context.Background(), 1, gitprovider.ReviewApprove, "LGTM")
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	want := gitprovider.ReviewInfo{Reviewer: "flux-bot", State: gitprovider.ReviewStateApproved}
+	if got := review.Get(); got != want {
+		t.Errorf("Review() = %+v, want %+v", got, want)
+	}
+	if _, ok := review.APIObject().(*gitlab.MergeRequestApprovals); !ok {
+		t.Errorf("APIObject() = %T, want *gitlab.MergeRequestApprovals", review.APIObject())
+	}
+	if notes != 1 {
+		t.Errorf("Review() posted %d notes, want 1", notes)
+	}
+
+	if _, err := client.Review(context.Background(), 1, gitprovider.ReviewRequestChanges, "Please add a test"); !errors.Is(err, gitprovider.ErrNoProviderSupport) {
+		t.Errorf("Review() requesting changes error = %v, want ErrNoProviderSupport", err)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// ReleaseClient implements the gitprovider.ReleaseClient interface.
+var _ gitprovider.ReleaseClient = &ReleaseClient{}
+
+// ReleaseClient operates on the releases of a specific project.
+// GitLab has no draft releases nor pre-releases, and doesn't report the ref a tag was created from.
+type ReleaseClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all the releases of the project.
+func (c *ReleaseClient) List(ctx context.Context) ([]gitprovider.ReleaseInfo, error) {
+	apiObjs := []*gitlab.Release{}
+	opts := &gitlab.ListReleasesOptions{}
+	err := allReleasePages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{id}/releases
+		pageObjs, resp, listErr := c.c.Client().Releases.ListReleases(getRepoPath(c.ref), opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]gitprovider.ReleaseInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		releases = append(releases, releaseFromAPI(apiObj))
+	}
+	return releases, nil
+}
+
+// Get returns the release of the given tag.
+func (c *ReleaseClient) Get(ctx context.Context, tagName string) (gitprovider.ReleaseInfo, error) {
+	// GET /projects/{id}/releases/{tag_name}
+	apiObj, _, err := c.c.Client().Releases.GetRelease(getRepoPath(c.ref), tagName, gitlab.WithContext(ctx))
+	if err != nil {
+		return gitprovider.ReleaseInfo{}, handleHTTPError(err)
+	}
+	return releaseFromAPI(apiObj), nil
+}
+
+// Create creates a release with the given specifications, creating its tag first if needed.
+func (c *ReleaseClient) Create(ctx context.Context, req gitprovider.ReleaseInfo) (gitprovider.ReleaseInfo, error) {
+	if err := validateReleaseInfo(req); err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+	tags := &TagClient{clientContext: c.clientContext, ref: c.ref}
+	commits := &CommitClient{clientContext: c.clientContext, ref: c.ref}
+	if err := gitprovider.EnsureReleaseTag(ctx, tags, commits, req); err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+
+	apiObj, err := c.create(ctx, req)
+	if err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+	return releaseFromAPI(apiObj), nil
+}
+
+// create creates the release of the existing tag req.TagName.
+func (c *ReleaseClient) create(ctx context.Context, req gitprovider.ReleaseInfo) (*gitlab.Release, error) {
+	// POST /projects/{id}/releases
+	apiObj, _, err := c.c.Client().Releases.CreateRelease(getRepoPath(c.ref), &gitlab.CreateReleaseOptions{
+		TagName:     &req.TagName,
+		Name:        &req.Name,
+		Description: &req.Body,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		// GitLab answers "409 Conflict" if the tag already has a release
+		glErrorResponse := &gitlab.ErrorResponse{}
+		if errors.As(err, &glErrorResponse) && glErrorResponse.Response.StatusCode == http.StatusConflict {
+			return nil, validation.NewMultiError(err, gitprovider.ErrAlreadyExists)
+		}
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+// Edit updates the name and description of the release of req.TagName.
+func (c *ReleaseClient) Edit(ctx context.Context, req gitprovider.ReleaseInfo) (gitprovider.ReleaseInfo, error) {
+	if err := validateReleaseInfo(req); err != nil {
+		return gitprovider.ReleaseInfo{}, err
+	}
+	// PUT /projects/{id}/releases/{tag_name}
+	apiObj, _, err := c.c.Client().Releases.UpdateRelease(getRepoPath(c.ref), req.TagName, &gitlab.UpdateReleaseOptions{
+		Name:        &req.Name,
+		Description: &req.Body,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return gitprovider.ReleaseInfo{}, handleHTTPError(err)
+	}
+	return releaseFromAPI(apiObj), nil
+}
+
+// Delete deletes the release of the given tag.
+func (c *ReleaseClient) Delete(ctx context.Context, tagName string) error {
+	// DELETE /projects/{id}/releases/{tag_name}
+	_, _, err := c.c.Client().Releases.DeleteRelease(getRepoPath(c.ref), tagName, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+// Publish creates a commit (or resolves an existing ref), an annotated tag pointing to it, and
+// the release of that tag. The release can't be a draft nor a pre-release. Rolling back the tag
+// requires destructive API calls to be enabled.
+func (c *ReleaseClient) Publish(ctx context.Context, opts gitprovider.PublishOptions) (gitprovider.PublishResult, error) {
+	if err := validateReleaseInfo(opts.Release); err != nil {
+		return gitprovider.PublishResult{}, err
+	}
+	return gitprovider.PublishRelease(ctx, &releasePublisher{c}, opts, c.destructiveActions)
+}
+
+// releasePublisher implements gitprovider.ReleasePublisher.
+var _ gitprovider.ReleasePublisher = &releasePublisher{}
+
+// releasePublisher publishes the releases of the project of a ReleaseClient.
+type releasePublisher struct {
+	c *ReleaseClient
+}
+
+func (p *releasePublisher) Commits() gitprovider.CommitClient {
+	return &CommitClient{clientContext: p.c.clientContext, ref: p.c.ref}
+}
+
+func (p *releasePublisher) CreateTag(ctx context.Context, name, sha, message string) error {
+	tags := &TagClient{clientContext: p.c.clientContext, ref: p.c.ref}
+	_, err := tags.Create(ctx, name, sha, message)
+	return err
+}
+
+func (p *releasePublisher) DeleteTag(ctx context.Context, name string) error {
+	// DELETE /projects/{id}/repository/tags/{tag_name}
+	_, err := p.c.c.Client().Tags.DeleteTag(getRepoPath(p.c.ref), name, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (p *releasePublisher) CreateRelease(ctx context.Context, req gitprovider.ReleaseInfo) error {
+	_, err := p.c.create(ctx, req)
+	return err
+}
+
+// validateReleaseInfo validates req, which can't be a draft nor a pre-release on GitLab.
+func validateReleaseInfo(req gitprovider.ReleaseInfo) error {
+	if err := req.ValidateInfo(); err != nil {
+		return err
+	}
+	if req.Draft || req.Prerelease {
+		return fmt.Errorf("gitlab has no draft releases nor pre-releases: %w", gitprovider.ErrNoProviderSupport)
+	}
+	return nil
+}
+
+func releaseFromAPI(apiObj *gitlab.Release) gitprovider.ReleaseInfo {
+	return gitprovider.ReleaseInfo{
+		TagName: apiObj.TagName,
+		Name:    apiObj.Name,
+		Body:    apiObj.Description,
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestReleaseClient_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            gitprovider.ReleaseInfo
+		tagExists      bool
+		wantTagCreated bool
+		wantPayload    map[string]interface{}
+		wantErr        error
+	}{
+		{
+			name:        "existing tag",
+			req:         gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", Body: "First release"},
+			tagExists:   true,
+			wantPayload: map[string]interface{}{"tag_name": "v1.0.0", "name": "v1.0.0", "description": "First release"},
+		},
+		{
+			name:           "missing tag created from the target commitish",
+			req:            gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", TargetCommitish: "main"},
+			wantTagCreated: true,
+			wantPayload:    map[string]interface{}{"tag_name": "v1.0.0", "name": "v1.0.0", "description": ""},
+		},
+		{
+			name:    "missing tag without target commitish",
+			req:     gitprovider.ReleaseInfo{TagName: "v1.0.0"},
+			wantErr: gitprovider.ErrNotFound,
+		},
+		{
+			name:      "draft release",
+			req:       gitprovider.ReleaseInfo{TagName: "v1.0.0", Draft: true},
+			tagExists: true,
+			wantErr:   gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, tags := newTestTagClient(t)
+			tagCreated := false
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/tags/v1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+				if !tt.tagExists {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message": "404 Tag Not Found"}`)
+					return
+				}
+				fmt.Fprint(w, `{"name": "v1.0.0", "target": "c1", "commit": {"id": "c1"}}`)
+			})
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/commits/main", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"id": "c1"}`)
+			})
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/tags", func(w http.ResponseWriter, r *http.Request) {
+				payload := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				if payload["tag_name"] != "v1.0.0" || payload["ref"] != "c1" {
+					t.Errorf("unexpected tag request %v", payload)
+				}
+				tagCreated = true
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"name": "v1.0.0", "target": "c1", "commit": {"id": "c1"}}`)
+			})
+			var payload map[string]interface{}
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/releases", func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"tag_name": %q, "name": %q, "description": %q}`, payload["tag_name"], payload["name"], payload["description"])
+			})
+
+			c := &ReleaseClient{clientContext: tags.clientContext, ref: tags.ref}
+			got, err := c.Create(context.Background(), tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if tagCreated != tt.wantTagCreated {
+				t.Errorf("Create() created the tag: %v, want %v", tagCreated, tt.wantTagCreated)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("Create() payload (-want +got):\n%s", diff)
+			}
+			want := tt.req
+			want.TargetCommitish = ""
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Create() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReleaseClient_Publish(t *testing.T) {
+	release := gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", Body: "First release"}
+	tests := []struct {
+		name               string
+		opts               gitprovider.PublishOptions
+		destructiveActions bool
+		releaseFails       bool
+		wantResult         gitprovider.PublishResult
+		wantErr            error
+		wantStep           gitprovider.PublishStep
+		wantTagDeleted     bool
+	}{
+		{
+			name:       "tag and release",
+			opts:       gitprovider.PublishOptions{Ref: "main", Release: release},
+			wantResult: gitprovider.PublishResult{CommitSHA: "c1", TagCreated: true, ReleaseCreated: true},
+		},
+		{
+			name:               "release fails, tag is rolled back",
+			opts:               gitprovider.PublishOptions{Ref: "main", Release: release, Rollback: true},
+			destructiveActions: true,
+			releaseFails:       true,
+			wantResult:         gitprovider.PublishResult{CommitSHA: "c1"},
+			wantStep:           gitprovider.PublishStepRelease,
+			wantTagDeleted:     true,
+		},
+		{
+			name:    "rollback without destructive actions",
+			opts:    gitprovider.PublishOptions{Ref: "main", Release: release, Rollback: true},
+			wantErr: gitprovider.ErrDestructiveCallDisallowed,
+		},
+		{
+			name:    "pre-release",
+			opts:    gitprovider.PublishOptions{Ref: "main", Release: gitprovider.ReleaseInfo{TagName: "v1.0.0-rc.1", Prerelease: true}},
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, tags := newTestTagClient(t)
+			tags.destructiveActions = tt.destructiveActions
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/commits/main", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"id": "c1"}`)
+			})
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/tags", func(w http.ResponseWriter, r *http.Request) {
+				payload := map[string]interface{}{}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				if payload["tag_name"] != "v1.0.0" || payload["ref"] != "c1" || payload["message"] != "v1.0.0" {
+					t.Errorf("unexpected tag request %v", payload)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"name": "v1.0.0", "target": "4c1e", "message": "v1.0.0", "commit": {"id": "c1"}}`)
+			})
+			tagDeleted := false
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("unexpected method %s", r.Method)
+				}
+				tagDeleted = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/releases", func(w http.ResponseWriter, _ *http.Request) {
+				if tt.releaseFails {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"message": "Release description is too long"}`)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"tag_name": "v1.0.0", "name": "v1.0.0"}`)
+			})
+
+			c := &ReleaseClient{clientContext: tags.clientContext, ref: tags.ref}
+			got, err := c.Publish(context.Background(), tt.opts)
+			var publishErr *gitprovider.PublishError
+			if tt.wantStep != "" {
+				if !errors.As(err, &publishErr) || publishErr.Step != tt.wantStep {
+					t.Fatalf("Publish() error = %v, want a failure at step %s", err, tt.wantStep)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Publish() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.wantResult {
+				t.Errorf("Publish() = %+v, want %+v", got, tt.wantResult)
+			}
+			if tagDeleted != tt.wantTagDeleted {
+				t.Errorf("Publish() deleted the tag: %v, want %v", tagDeleted, tt.wantTagDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific project.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all the tags of the project.
+// GitLab doesn't expose the tagger of annotated tags, and the creation date of all tags is the
+// date of the commit they point to.
+func (c *TagClient) List(ctx context.Context) ([]gitprovider.TagInfo, error) {
+	apiObjs := []*gitlab.Tag{}
+	opts := &gitlab.ListTagsOptions{}
+	err := allTagPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{id}/repository/tags
+		pageObjs, resp, listErr := c.c.Client().Tags.ListTags(getRepoPath(c.ref), opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]gitprovider.TagInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		tags = append(tags, tagFromAPI(apiObj))
+	}
+	return tags, nil
+}
+
+// Get returns the tag with the given name.
+func (c *TagClient) Get(ctx context.Context, name string) (gitprovider.TagInfo, error) {
+	// GET /projects/{id}/repository/tags/{tag_name}
+	apiObj, _, err := c.c.Client().Tags.GetTag(getRepoPath(c.ref), name, gitlab.WithContext(ctx))
+	if err != nil {
+		return gitprovider.TagInfo{}, handleHTTPError(err)
+	}
+	return tagFromAPI(apiObj), nil
+}
+
+// Create creates a tag pointing to commitSHA. GitLab creates an annotated tag if a message is
+// given, and a lightweight tag otherwise.
+func (c *TagClient) Create(ctx context.Context, name, commitSHA, message string) (gitprovider.TagInfo, error) {
+	opts := &gitlab.CreateTagOptions{
+		TagName: &name,
+		Ref:     &commitSHA,
+	}
+	if message != "" {
+		opts.Message = &message
+	}
+	// POST /projects/{id}/repository/tags
+	apiObj, _, err := c.c.Client().Tags.CreateTag(getRepoPath(c.ref), opts, gitlab.WithContext(ctx))
+	if err != nil {
+		// GitLab answers "400 Bad Request" with "Tag {name} already exists" for existing tags
+		glErrorResponse := &gitlab.ErrorResponse{}
+		if errors.As(err, &glErrorResponse) && glErrorResponse.Response.StatusCode == http.StatusBadRequest &&
+			strings.Contains(glErrorResponse.Message, tagExistsMagicString) {
+			return gitprovider.TagInfo{}, validation.NewMultiError(err, gitprovider.ErrAlreadyExists)
+		}
+		return gitprovider.TagInfo{}, handleHTTPError(err)
+	}
+	return tagFromAPI(apiObj), nil
+}
+
+// tagFromAPI converts a GitLab tag. The target of annotated tags is the SHA of the tag
+// object, while the target of lightweight tags is the commit itself.
+func tagFromAPI(apiObj *gitlab.Tag) gitprovider.TagInfo {
+	tag := gitprovider.TagInfo{
+		Name:    apiObj.Name,
+		Message: apiObj.Message,
+	}
+	if apiObj.Commit != nil {
+		tag.SHA = apiObj.Commit.ID
+		tag.Annotated = apiObj.Target != "" && apiObj.Target != apiObj.Commit.ID
+		if apiObj.Commit.CommittedDate != nil {
+			tag.CreatedAt = *apiObj.Commit.CommittedDate
+		}
+	}
+	return tag
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestTagClient(t *testing.T) (*http.ServeMux, *TagClient) {
+	mux, c := setup(t)
+	return mux, &TagClient{
+		clientContext: &clientContext{c: c, domain: "gitlab.com"},
+		ref: gitprovider.UserRepositoryRef{
+			UserRef:        gitprovider.UserRef{Domain: "gitlab.com", UserLogin: "fluxcd"},
+			RepositoryName: "repo",
+		},
+	}
+}
+
+func TestTagClient_Get(t *testing.T) {
+	mux, c := newTestTagClient(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/tags/v1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"name": "v1.0.0", "message": "Release v1.0.0", "target": "t1", "commit": {"id": "c1", "committed_date": "2026-01-01T00:00:00Z"}}`)
+	})
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/tags/v2.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "404 Tag Not Found"}`)
+	})
+
+	got, err := c.Get(context.Background(), "v1.0.0")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	want := gitprovider.TagInfo{
+		Name:      "v1.0.0",
+		SHA:       "c1",
+		Annotated: true,
+		Message:   "Release v1.0.0",
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get() (-want +got):\n%s", diff)
+	}
+
+	if _, err := c.Get(context.Background(), "v2.0.0"); !errors.Is(err, gitprovider.ErrNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, gitprovider.ErrNotFound)
+	}
+}
+
+func TestTagClient_Create(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		exists      bool
+		wantPayload map[string]interface{}
+		wantErr     error
+	}{
+		{
+			name:        "lightweight tag",
+			wantPayload: map[string]interface{}{"tag_name": "v1.0.0", "ref": "c1"},
+		},
+		{
+			name:        "annotated tag",
+			message:     "Release v1.0.0",
+			wantPayload: map[string]interface{}{"tag_name": "v1.0.0", "ref": "c1", "message": "Release v1.0.0"},
+		},
+		{
+			name:    "tag already exists",
+			exists:  true,
+			wantErr: gitprovider.ErrAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := newTestTagClient(t)
+			var payload map[string]interface{}
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/tags", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				if tt.exists {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"message": "Tag v1.0.0 already exists"}`)
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				target := "c1"
+				if tt.message != "" {
+					target = "t1"
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"name": "v1.0.0", "message": %q, "target": %q, "commit": {"id": "c1"}}`, tt.message, target)
+			})
+
+			got, err := c.Create(context.Background(), "v1.0.0", "c1", tt.message)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("Create() payload (-want +got):\n%s", diff)
+			}
+			want := gitprovider.TagInfo{
+				Name:      "v1.0.0",
+				SHA:       "c1",
+				Annotated: tt.message != "",
+				Message:   tt.message,
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Create() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TopicsClient implements the gitprovider.TopicsClient interface.
+var _ gitprovider.TopicsClient = &TopicsClient{}
+
+// TopicsClient operates on the topics of a specific project.
+type TopicsClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the topics of the project.
+func (c *TopicsClient) Get(ctx context.Context) ([]string, error) {
+	apiObj, err := c.c.GetUserProject(ctx, getRepoPath(c.ref))
+	if err != nil {
+		return nil, err
+	}
+	return apiObj.Topics, nil
+}
+
+// Reconcile makes sure the project has exactly the given topics, in any order.
+func (c *TopicsClient) Reconcile(ctx context.Context, topics []string, opts ...gitprovider.TopicsReconcileOption) (bool, error) {
+	return gitprovider.ReconcileTopics(ctx, topics, c.Get, func(ctx context.Context, topics []string) error {
+		_, err := c.c.SetProjectTopics(ctx, getRepoPath(c.ref), topics)
+		return err
+	}, opts...)
+}
@@ -19,7 +19,10 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"gitlab.com/gitlab-org/api/client-go"
@@ -38,15 +41,40 @@ type gitlabClient interface {
 	// GetGroup is a wrapper for "GET /groups/{group}".
 	// This function HTTP error wrapping, and validates the server result.
 	GetGroup(ctx context.Context, groupID interface{}) (*gitlab.Group, error)
+	// GetNamespace is a wrapper for "GET /namespaces/{namespace}", which describes both groups and
+	// users.
+	// This function handles HTTP error wrapping.
+	GetNamespace(ctx context.Context, namespace string) (*gitlab.Namespace, error)
 	// ListGroups is a wrapper for "GET /groups".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListGroups(ctx context.Context) ([]*gitlab.Group, error)
+	// ListGroupsWithMinAccessLevel is a wrapper for "GET /groups?min_access_level={level}".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListGroupsWithMinAccessLevel(ctx context.Context, level gitlab.AccessLevelValue) ([]*gitlab.Group, error)
 	// ListSubgroups is a wrapper for "GET /groups/{group}/subgroups".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListSubgroups(ctx context.Context, groupName string) ([]*gitlab.Group, error)
 	// ListGroupMembers is a wrapper for "GET /groups/{group}/members".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListGroupMembers(ctx context.Context, groupName string) ([]*gitlab.GroupMember, error)
+	// UploadGroupAvatar is a wrapper for "PUT /groups/{group}" with a multipart avatar.
+	// This function handles HTTP error wrapping.
+	UploadGroupAvatar(ctx context.Context, groupName string, avatar io.Reader, filename string) error
+	// UpdateGroup is a wrapper for "PUT /groups/{group}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateGroup(ctx context.Context, groupName string, opts *gitlab.UpdateGroupOptions) (*gitlab.Group, error)
+	// ListGroupHooks is a wrapper for "GET /groups/{group}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListGroupHooks(ctx context.Context, groupName string) ([]*gitlab.GroupHook, error)
+	// AddGroupHook is a wrapper for "POST /groups/{group}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	AddGroupHook(ctx context.Context, groupName string, opts *gitlab.AddGroupHookOptions) (*gitlab.GroupHook, error)
+	// EditGroupHook is a wrapper for "PUT /groups/{group}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditGroupHook(ctx context.Context, groupName string, hookID int, opts *gitlab.EditGroupHookOptions) (*gitlab.GroupHook, error)
+	// DeleteGroupHook is a wrapper for "DELETE /groups/{group}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteGroupHook(ctx context.Context, groupName string, hookID int) error
 
 	// Project methods
 
@@ -56,6 +84,10 @@ type gitlabClient interface {
 	// ListGroupProjects is a wrapper for "GET /groups/{group}/projects".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
 	ListGroupProjects(ctx context.Context, groupName string) ([]*gitlab.Project, error)
+	// IterateGroupProjects is a wrapper for "GET /groups/{group}/projects", calling fn for each
+	// project while fetching one page at a time.
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	IterateGroupProjects(ctx context.Context, groupName string, fn func(*gitlab.Project) error) error
 	// GetProject is a wrapper for "GET /projects/{project}".
 	// This function handles HTTP error wrapping, and validates the server result.
 	GetUserProject(ctx context.Context, projectName string) (*gitlab.Project, error)
@@ -71,19 +103,81 @@ type gitlabClient interface {
 	// UpdateProject is a wrapper for "PUT /projects/{project}".
 	// This function handles HTTP error wrapping, and validates the server result.
 	UpdateProject(ctx context.Context, req *gitlab.Project) (*gitlab.Project, error)
+	// RenameProject is a wrapper for "PUT /projects/{project}", setting both the name and the
+	// path of the project to name.
+	// This function handles HTTP error wrapping, and validates the server result.
+	RenameProject(ctx context.Context, projectName, name string) (*gitlab.Project, error)
 	// DeleteProject is a wrapper for "DELETE /projects/{project}".
 	// This function handles HTTP error wrapping.
 	// DANGEROUS COMMAND: In order to use this, you must set destructiveActions to true.
 	DeleteProject(ctx context.Context, projectName string) error
+	// GetProjectWithLicense is a wrapper for "GET /projects/{project}?license=true".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetProjectWithLicense(ctx context.Context, projectName string) (*gitlab.Project, error)
+	// GetProjectLanguages is a wrapper for "GET /projects/{project}/languages".
+	// This function handles HTTP error wrapping.
+	GetProjectLanguages(ctx context.Context, projectName string) (map[string]float32, error)
+	// SetProjectNotificationLevel is a wrapper for "PUT /projects/{project}/notification_settings".
+	// This function handles HTTP error wrapping.
+	SetProjectNotificationLevel(ctx context.Context, projectName string, level gitlab.NotificationLevelValue) error
+	// UploadProjectAvatar is a wrapper for "PUT /projects/{project}" with a multipart avatar.
+	// This function handles HTTP error wrapping.
+	UploadProjectAvatar(ctx context.Context, projectName string, avatar io.Reader, filename string) error
+	// SetProjectTopics is a wrapper for "PUT /projects/{project}", replacing the topics of the project.
+	// This function handles HTTP error wrapping, and validates the server result.
+	SetProjectTopics(ctx context.Context, projectName string, topics []string) (*gitlab.Project, error)
+	// ListProjectMembers is a wrapper for "GET /projects/{project}/members", or for
+	// "GET /projects/{project}/members/all" when inherited is true.
+	// This function handles pagination and HTTP error wrapping.
+	ListProjectMembers(ctx context.Context, projectName string, inherited bool) ([]*gitlab.ProjectMember, error)
+	// GetProjectApprovalConfiguration is a wrapper for "GET /projects/{project}/approvals".
+	// This function handles HTTP error wrapping.
+	GetProjectApprovalConfiguration(ctx context.Context, projectName string) (*gitlab.ProjectApprovals, error)
+	// ChangeProjectApprovalConfiguration is a wrapper for "POST /projects/{project}/approvals".
+	// This function handles HTTP error wrapping.
+	ChangeProjectApprovalConfiguration(ctx context.Context, projectName string, opts *gitlab.ChangeApprovalConfigurationOptions) (*gitlab.ProjectApprovals, error)
+	// GetProtectedBranch is a wrapper for "GET /projects/{project}/protected_branches/{branch}".
+	// This function handles HTTP error wrapping.
+	GetProtectedBranch(ctx context.Context, projectName, branch string) (*gitlab.ProtectedBranch, error)
+	// CountProjectMergeRequests is a wrapper for "GET /projects/{project}/merge_requests", counting
+	// the merge requests in the given state without listing them all.
+	// This function handles HTTP error wrapping. nil is returned if GitLab doesn't report the count.
+	CountProjectMergeRequests(ctx context.Context, projectName, state string) (*int, error)
+	// CountProjectBranches is a wrapper for "GET /projects/{project}/repository/branches", counting
+	// the branches without listing them all.
+	// This function handles HTTP error wrapping. nil is returned if GitLab doesn't report the count.
+	CountProjectBranches(ctx context.Context, projectName string) (*int, error)
+	// CountProjectTags is a wrapper for "GET /projects/{project}/repository/tags", counting the
+	// tags without listing them all.
+	// This function handles HTTP error wrapping. nil is returned if GitLab doesn't report the count.
+	CountProjectTags(ctx context.Context, projectName string) (*int, error)
 
 	// GetUser is a wrapper for "GET /user"
 	GetUser(ctx context.Context) (*gitlab.User, error)
+	// GetCurrentPersonalAccessToken is a wrapper for "GET /personal_access_tokens/self".
+	// This function handles HTTP error wrapping.
+	GetCurrentPersonalAccessToken(ctx context.Context) (*gitlab.PersonalAccessToken, error)
+
+	// Project hook methods
+
+	// ListProjectHooks is a wrapper for "GET /projects/{project}/hooks".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProjectHooks(ctx context.Context, projectName string) ([]*gitlab.ProjectHook, error)
+	// AddProjectHook is a wrapper for "POST /projects/{project}/hooks".
+	// This function handles HTTP error wrapping, and validates the server result.
+	AddProjectHook(ctx context.Context, projectName string, opts *gitlab.AddProjectHookOptions) (*gitlab.ProjectHook, error)
+	// EditProjectHook is a wrapper for "PUT /projects/{project}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	EditProjectHook(ctx context.Context, projectName string, hookID int, opts *gitlab.EditProjectHookOptions) (*gitlab.ProjectHook, error)
+	// DeleteProjectHook is a wrapper for "DELETE /projects/{project}/hooks/{hook_id}".
+	// This function handles HTTP error wrapping.
+	DeleteProjectHook(ctx context.Context, projectName string, hookID int) error
 
 	// Deploy key methods
 
 	// ListKeys is a wrapper for "GET /projects/{project}/deploy_keys".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
-	ListKeys(projectName string) ([]*gitlab.ProjectDeployKey, error)
+	ListKeys(ctx context.Context, projectName string) ([]*gitlab.ProjectDeployKey, error)
 	// CreateProjectKey is a wrapper for "POST /projects/{project}/deploy_keys".
 	// This function handles HTTP error wrapping, and validates the server result.
 	CreateKey(projectName string, req *gitlab.ProjectDeployKey) (*gitlab.ProjectDeployKey, error)
@@ -95,7 +189,7 @@ type gitlabClient interface {
 
 	// ListTokens is a wrapper for "GET /projects/{project}/deploy_tokens".
 	// This function handles pagination, HTTP error wrapping, and validates the server result.
-	ListTokens(projectName string) ([]*gitlab.DeployToken, error)
+	ListTokens(ctx context.Context, projectName string) ([]*gitlab.DeployToken, error)
 	// CreateProjectKey is a wrapper for "POST /projects/{project}/deploy_tokens".
 	// This function handles HTTP error wrapping, and validates the server result.
 	CreateToken(projectName string, req *gitlab.DeployToken) (*gitlab.DeployToken, error)
@@ -103,6 +197,55 @@ type gitlabClient interface {
 	// This function handles HTTP error wrapping.
 	DeleteToken(projectName string, keyID int) error
 
+	// Protected tag methods
+
+	// ListProtectedTags is a wrapper for "GET /projects/{project}/protected_tags".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListProtectedTags(ctx context.Context, projectName string) ([]*gitlab.ProtectedTag, error)
+	// ProtectTag is a wrapper for "POST /projects/{project}/protected_tags".
+	// This function handles HTTP error wrapping, and validates the server result.
+	ProtectTag(ctx context.Context, projectName string, opts *gitlab.ProtectRepositoryTagsOptions) (*gitlab.ProtectedTag, error)
+	// UnprotectTag is a wrapper for "DELETE /projects/{project}/protected_tags/{name}".
+	// This function handles HTTP error wrapping.
+	UnprotectTag(ctx context.Context, projectName, name string) error
+
+	// Milestone methods
+
+	// ListMilestones is a wrapper for "GET /projects/{project}/milestones".
+	// This function handles pagination, HTTP error wrapping, and validates the server result.
+	ListMilestones(ctx context.Context, projectName string) ([]*gitlab.Milestone, error)
+	// CreateMilestone is a wrapper for "POST /projects/{project}/milestones".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreateMilestone(ctx context.Context, projectName string, opts *gitlab.CreateMilestoneOptions) (*gitlab.Milestone, error)
+	// UpdateMilestone is a wrapper for "PUT /projects/{project}/milestones/{milestone_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	UpdateMilestone(ctx context.Context, projectName string, milestoneID int, opts *gitlab.UpdateMilestoneOptions) (*gitlab.Milestone, error)
+
+	// Label methods
+
+	// CreateLabel is a wrapper for "POST /projects/{project}/labels".
+	// This function handles HTTP error wrapping.
+	CreateLabel(ctx context.Context, projectName string, opts *gitlab.CreateLabelOptions) error
+
+	// Pipelines
+
+	// CreatePipeline is a wrapper for "POST /projects/{project}/pipeline".
+	// This function handles HTTP error wrapping, and validates the server result.
+	CreatePipeline(ctx context.Context, projectName string, opts *gitlab.CreatePipelineOptions) (*gitlab.Pipeline, error)
+	// GetPipeline is a wrapper for "GET /projects/{project}/pipelines/{pipeline_id}".
+	// This function handles HTTP error wrapping, and validates the server result.
+	GetPipeline(ctx context.Context, projectName string, id int) (*gitlab.Pipeline, error)
+	// ListPipelinesPage is a wrapper for "GET /projects/{project}/pipelines".
+	// This function lists the single page given in opts, and handles HTTP error wrapping.
+	ListPipelinesPage(ctx context.Context, projectName string, opts *gitlab.ListProjectPipelinesOptions) ([]*gitlab.Pipeline, error)
+	// ListPipelineJobs is a wrapper for "GET /projects/{project}/pipelines/{pipeline_id}/jobs".
+	// This function handles pagination and HTTP error wrapping.
+	ListPipelineJobs(ctx context.Context, projectName string, pipelineID int) ([]*gitlab.Job, error)
+	// GetJobTrace is a wrapper for "GET /projects/{project}/jobs/{job_id}/trace", writing the log
+	// of the job to w.
+	// This function handles HTTP error wrapping.
+	GetJobTrace(ctx context.Context, projectName string, jobID int, w io.Writer) error
+
 	// Team related methods
 
 	// ShareGroup is a wrapper for ""
@@ -115,8 +258,24 @@ type gitlabClient interface {
 	// Commits
 
 	// ListCommitsPage is a wrapper for "GET /projects/{project}/repository/commits".
-	// This function handles pagination, HTTP error wrapping.
-	ListCommitsPage(projectName, branch string, perPage int, page int) ([]*gitlab.Commit, error)
+	// This function lists the single page given in opts, and handles HTTP error wrapping.
+	ListCommitsPage(ctx context.Context, projectName string, opts *gitlab.ListCommitsOptions) ([]*gitlab.Commit, error)
+	// GetCommit is a wrapper for "GET /projects/{project}/repository/commits/{sha}".
+	// This function handles HTTP error wrapping.
+	GetCommit(ctx context.Context, projectName, sha string) (*gitlab.Commit, error)
+	// GetCommitSignature is a wrapper for "GET /projects/{project}/repository/commits/{sha}/signature".
+	// This function handles HTTP error wrapping.
+	GetCommitSignature(ctx context.Context, projectName, sha string) (*gitlab.GPGSignature, error)
+	// ListCommitBranches is a wrapper for "GET /projects/{project}/repository/commits/{sha}/refs?type=branch".
+	// This function handles pagination and HTTP error wrapping, and returns the names of the branches.
+	ListCommitBranches(ctx context.Context, projectName, sha string) ([]string, error)
+	// GetCommitSHA is a wrapper for "GET /projects/{project}/repository/commits/{sha}".
+	// This function handles HTTP error wrapping, and returns the full SHA of the commit ref points to.
+	GetCommitSHA(ctx context.Context, projectName, ref string) (string, error)
+	// ListCommitDates is a wrapper for "GET /projects/{project}/repository/commits?ref_name={ref}&since={since}".
+	// This function handles pagination and HTTP error wrapping, and returns the dates the commits
+	// were committed at.
+	ListCommitDates(ctx context.Context, projectName, ref string, since time.Time) ([]time.Time, error)
 }
 
 // gitlabClientImpl is a wrapper around *gitlab.Client, which implements higher-level methods,
@@ -147,10 +306,27 @@ func (c *gitlabClientImpl) GetGroup(ctx context.Context, groupID interface{}) (*
 	return apiObj, nil
 }
 
+func (c *gitlabClientImpl) GetNamespace(ctx context.Context, namespace string) (*gitlab.Namespace, error) {
+	// GET /namespaces/{namespace}
+	apiObj, _, err := c.c.Namespaces.GetNamespace(namespace, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
 func (c *gitlabClientImpl) ListGroups(ctx context.Context) ([]*gitlab.Group, error) {
+	return c.listGroups(ctx, &gitlab.ListGroupsOptions{})
+}
+
+func (c *gitlabClientImpl) ListGroupsWithMinAccessLevel(ctx context.Context, level gitlab.AccessLevelValue) ([]*gitlab.Group, error) {
+	return c.listGroups(ctx, &gitlab.ListGroupsOptions{MinAccessLevel: gitlab.Ptr(level)})
+}
+
+// listGroups lists all the groups matching opts, see ListGroups.
+func (c *gitlabClientImpl) listGroups(ctx context.Context, opts *gitlab.ListGroupsOptions) ([]*gitlab.Group, error) {
 	apiObjs := []*gitlab.Group{}
-	opts := &gitlab.ListGroupsOptions{}
-	err := allGroupPages(opts, func() (*gitlab.Response, error) {
+	err := allGroupPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		// GET /groups
 		pageObjs, resp, listErr := c.c.Groups.ListGroups(opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
@@ -171,7 +347,7 @@ func (c *gitlabClientImpl) ListGroups(ctx context.Context) ([]*gitlab.Group, err
 func (c *gitlabClientImpl) ListSubgroups(ctx context.Context, groupName string) ([]*gitlab.Group, error) {
 	var apiObjs []*gitlab.Group
 	opts := &gitlab.ListSubGroupsOptions{}
-	err := allSubgroupPages(opts, func() (*gitlab.Response, error) {
+	err := allSubgroupPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		// GET /groups
 		pageObjs, resp, listErr := c.c.Groups.ListSubGroups(groupName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
@@ -189,6 +365,75 @@ func (c *gitlabClientImpl) ListSubgroups(ctx context.Context, groupName string)
 	return apiObjs, nil
 }
 
+func (c *gitlabClientImpl) UploadGroupAvatar(ctx context.Context, groupName string, avatar io.Reader, filename string) error {
+	// PUT /groups/{group}
+	_, _, err := c.c.Groups.UploadAvatar(groupName, avatar, filename, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) UpdateGroup(ctx context.Context, groupName string, opts *gitlab.UpdateGroupOptions) (*gitlab.Group, error) {
+	// PUT /groups/{group}
+	apiObj, _, err := c.c.Groups.UpdateGroup(groupName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateGroupAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListGroupHooks(ctx context.Context, groupName string) ([]*gitlab.GroupHook, error) {
+	apiObjs := []*gitlab.GroupHook{}
+	opts := &gitlab.ListGroupHooksOptions{}
+	err := allGroupHookPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /groups/{group}/hooks
+		pageObjs, resp, listErr := c.c.Groups.ListGroupHooks(groupName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateGroupHookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) AddGroupHook(ctx context.Context, groupName string, opts *gitlab.AddGroupHookOptions) (*gitlab.GroupHook, error) {
+	// POST /groups/{group}/hooks
+	apiObj, _, err := c.c.Groups.AddGroupHook(groupName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateGroupHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) EditGroupHook(ctx context.Context, groupName string, hookID int, opts *gitlab.EditGroupHookOptions) (*gitlab.GroupHook, error) {
+	// PUT /groups/{group}/hooks/{hook_id}
+	apiObj, _, err := c.c.Groups.EditGroupHook(groupName, hookID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateGroupHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteGroupHook(ctx context.Context, groupName string, hookID int) error {
+	// DELETE /groups/{group}/hooks/{hook_id}
+	_, err := c.c.Groups.DeleteGroupHook(groupName, hookID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
 func (c *gitlabClientImpl) GetGroupProject(ctx context.Context, groupName string, projectName string) (*gitlab.Project, error) {
 	opts := &gitlab.GetProjectOptions{}
 	apiObj, _, err := c.c.Projects.GetProject(fmt.Sprintf("%s/%s", strings.ToLower(groupName), projectName), opts, gitlab.WithContext(ctx))
@@ -198,7 +443,7 @@ func (c *gitlabClientImpl) GetGroupProject(ctx context.Context, groupName string
 func (c *gitlabClientImpl) ListGroupProjects(ctx context.Context, groupName string) ([]*gitlab.Project, error) {
 	var apiObjs []*gitlab.Project
 	opts := &gitlab.ListGroupProjectsOptions{}
-	err := allGroupProjectPages(opts, func() (*gitlab.Response, error) {
+	err := allGroupProjectPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		pageObjs, resp, listErr := c.c.Groups.ListGroupProjects(groupName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
 		return resp, listErr
@@ -209,6 +454,28 @@ func (c *gitlabClientImpl) ListGroupProjects(ctx context.Context, groupName stri
 	return validateProjectObjects(apiObjs)
 }
 
+func (c *gitlabClientImpl) IterateGroupProjects(ctx context.Context, groupName string, fn func(*gitlab.Project) error) error {
+	opts := &gitlab.ListGroupProjectsOptions{}
+	return allGroupProjectPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		pageObjs, resp, listErr := c.c.Groups.ListGroupProjects(groupName, opts, gitlab.WithContext(ctx))
+		if listErr != nil {
+			return resp, listErr
+		}
+		if _, err := validateProjectObjects(pageObjs); err != nil {
+			return nil, err
+		}
+		for _, apiObj := range pageObjs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := fn(apiObj); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	})
+}
+
 func validateProjectObjects(apiObjs []*gitlab.Project) ([]*gitlab.Project, error) {
 	for _, apiObj := range apiObjs {
 		// Make sure apiObj is valid
@@ -222,7 +489,7 @@ func validateProjectObjects(apiObjs []*gitlab.Project) ([]*gitlab.Project, error
 func (c *gitlabClientImpl) ListGroupMembers(ctx context.Context, groupName string) ([]*gitlab.GroupMember, error) {
 	var apiObjs []*gitlab.GroupMember
 	opts := &gitlab.ListGroupMembersOptions{}
-	err := allGroupMemberPages(opts, func() (*gitlab.Response, error) {
+	err := allGroupMemberPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		// GET /groups/{group}/members
 		pageObjs, resp, listErr := c.c.Groups.ListGroupMembers(groupName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
@@ -255,7 +522,7 @@ func validateProjectAPIResp(apiObj *gitlab.Project, err error) (*gitlab.Project,
 func (c *gitlabClientImpl) ListProjects(ctx context.Context) ([]*gitlab.Project, error) {
 	var apiObjs []*gitlab.Project
 	opts := &gitlab.ListProjectsOptions{}
-	err := allProjectPages(opts, func() (*gitlab.Response, error) {
+	err := allProjectPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		// GET /projects
 		pageObjs, resp, listErr := c.c.Projects.ListProjects(opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
@@ -270,7 +537,7 @@ func (c *gitlabClientImpl) ListProjects(ctx context.Context) ([]*gitlab.Project,
 func (c *gitlabClientImpl) ListProjectUsers(ctx context.Context, projectName string) ([]*gitlab.ProjectUser, error) {
 	var apiObjs []*gitlab.ProjectUser
 	opts := &gitlab.ListProjectUserOptions{}
-	err := allProjectUserPages(opts, func() (*gitlab.Response, error) {
+	err := allProjectUserPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		// GET /projects/{project}/users
 		pageObjs, resp, listErr := c.c.Projects.ListProjectsUsers(projectName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
@@ -285,7 +552,7 @@ func (c *gitlabClientImpl) ListProjectUsers(ctx context.Context, projectName str
 func (c *gitlabClientImpl) ListUserProjects(ctx context.Context, username string) ([]*gitlab.Project, error) {
 	var apiObjs []*gitlab.Project
 	opts := &gitlab.ListProjectsOptions{}
-	err := allProjectPages(opts, func() (*gitlab.Response, error) {
+	err := allProjectPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		// GET /projects/{project}/users
 		pageObjs, resp, listErr := c.c.Projects.ListUserProjects(username, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
@@ -315,7 +582,10 @@ func (c *gitlabClientImpl) CreateProject(ctx context.Context, req *gitlab.Projec
 	opts.Name = &req.Name
 	opts.DefaultBranch = &req.DefaultBranch
 	opts.Description = &req.Description
-	opts.Visibility = &req.Visibility
+	// An unset visibility lets GitLab apply the instance or group default
+	if req.Visibility != "" {
+		opts.Visibility = &req.Visibility
+	}
 	if namespaceID != 0 {
 		opts.NamespaceID = &namespaceID
 	}
@@ -328,12 +598,24 @@ func (c *gitlabClientImpl) UpdateProject(ctx context.Context, req *gitlab.Projec
 	opts := &gitlab.EditProjectOptions{
 		Name:        &req.Name,
 		Description: &req.Description,
-		Visibility:  &req.Visibility,
+	}
+	if req.Visibility != "" {
+		opts.Visibility = &req.Visibility
 	}
 	apiObj, _, err := c.c.Projects.EditProject(req.ID, opts, gitlab.WithContext(ctx))
 	return validateProjectAPIResp(apiObj, err)
 }
 
+func (c *gitlabClientImpl) RenameProject(ctx context.Context, projectName, name string) (*gitlab.Project, error) {
+	// PUT /projects/{project}
+	opts := &gitlab.EditProjectOptions{
+		Name: &name,
+		Path: &name,
+	}
+	apiObj, _, err := c.c.Projects.EditProject(projectName, opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
 func (c *gitlabClientImpl) DeleteProject(ctx context.Context, projectName string) error {
 	// Don't allow deleting repositories if the user didn't explicitly allow dangerous API calls.
 	if !c.destructiveActions {
@@ -344,18 +626,204 @@ func (c *gitlabClientImpl) DeleteProject(ctx context.Context, projectName string
 	return err
 }
 
+func (c *gitlabClientImpl) GetProjectWithLicense(ctx context.Context, projectName string) (*gitlab.Project, error) {
+	// GET /projects/{project}?license=true
+	opts := &gitlab.GetProjectOptions{
+		License: gitlab.Ptr(true),
+	}
+	apiObj, _, err := c.c.Projects.GetProject(projectName, opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+func (c *gitlabClientImpl) GetProjectLanguages(ctx context.Context, projectName string) (map[string]float32, error) {
+	// GET /projects/{project}/languages
+	languages, _, err := c.c.Projects.GetProjectLanguages(projectName, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if languages == nil {
+		return map[string]float32{}, nil
+	}
+	return *languages, nil
+}
+
+func (c *gitlabClientImpl) SetProjectNotificationLevel(ctx context.Context, projectName string, level gitlab.NotificationLevelValue) error {
+	// PUT /projects/{project}/notification_settings
+	opts := &gitlab.NotificationSettingsOptions{
+		Level: &level,
+	}
+	_, _, err := c.c.NotificationSettings.UpdateSettingsForProject(projectName, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) UploadProjectAvatar(ctx context.Context, projectName string, avatar io.Reader, filename string) error {
+	// PUT /projects/{project}
+	_, _, err := c.c.Projects.UploadAvatar(projectName, avatar, filename, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) SetProjectTopics(ctx context.Context, projectName string, topics []string) (*gitlab.Project, error) {
+	// PUT /projects/{project}
+	opts := &gitlab.EditProjectOptions{
+		Topics: &topics,
+	}
+	apiObj, _, err := c.c.Projects.EditProject(projectName, opts, gitlab.WithContext(ctx))
+	return validateProjectAPIResp(apiObj, err)
+}
+
+func (c *gitlabClientImpl) ListProjectMembers(ctx context.Context, projectName string, inherited bool) ([]*gitlab.ProjectMember, error) {
+	apiObjs := []*gitlab.ProjectMember{}
+	opts := &gitlab.ListProjectMembersOptions{}
+	err := allProjectMemberPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		var pageObjs []*gitlab.ProjectMember
+		var resp *gitlab.Response
+		var listErr error
+		if inherited {
+			// GET /projects/{project}/members/all
+			pageObjs, resp, listErr = c.c.ProjectMembers.ListAllProjectMembers(projectName, opts, gitlab.WithContext(ctx))
+		} else {
+			// GET /projects/{project}/members
+			pageObjs, resp, listErr = c.c.ProjectMembers.ListProjectMembers(projectName, opts, gitlab.WithContext(ctx))
+		}
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetProjectApprovalConfiguration(ctx context.Context, projectName string) (*gitlab.ProjectApprovals, error) {
+	// GET /projects/{project}/approvals
+	apiObj, _, err := c.c.Projects.GetApprovalConfiguration(projectName, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ChangeProjectApprovalConfiguration(ctx context.Context, projectName string, opts *gitlab.ChangeApprovalConfigurationOptions) (*gitlab.ProjectApprovals, error) {
+	// POST /projects/{project}/approvals
+	apiObj, _, err := c.c.Projects.ChangeApprovalConfiguration(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) GetProtectedBranch(ctx context.Context, projectName, branch string) (*gitlab.ProtectedBranch, error) {
+	// GET /projects/{project}/protected_branches/{branch}
+	apiObj, _, err := c.c.ProtectedBranches.GetProtectedBranch(projectName, branch, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) CountProjectMergeRequests(ctx context.Context, projectName, state string) (*int, error) {
+	// GET /projects/{project}/merge_requests
+	return countItems(func(opts gitlab.ListOptions) (*gitlab.Response, error) {
+		_, resp, err := c.c.MergeRequests.ListProjectMergeRequests(projectName, &gitlab.ListProjectMergeRequestsOptions{
+			ListOptions: opts,
+			State:       &state,
+		}, gitlab.WithContext(ctx))
+		return resp, err
+	})
+}
+
+func (c *gitlabClientImpl) CountProjectBranches(ctx context.Context, projectName string) (*int, error) {
+	// GET /projects/{project}/repository/branches
+	return countItems(func(opts gitlab.ListOptions) (*gitlab.Response, error) {
+		_, resp, err := c.c.Branches.ListBranches(projectName, &gitlab.ListBranchesOptions{
+			ListOptions: opts,
+		}, gitlab.WithContext(ctx))
+		return resp, err
+	})
+}
+
+func (c *gitlabClientImpl) CountProjectTags(ctx context.Context, projectName string) (*int, error) {
+	// GET /projects/{project}/repository/tags
+	return countItems(func(opts gitlab.ListOptions) (*gitlab.Response, error) {
+		_, resp, err := c.c.Tags.ListTags(projectName, &gitlab.ListTagsOptions{
+			ListOptions: opts,
+		}, gitlab.WithContext(ctx))
+		return resp, err
+	})
+}
+
 func (c *gitlabClientImpl) GetUser(ctx context.Context) (*gitlab.User, error) {
 	// GET /user
 	proj, _, err := c.c.Users.CurrentUser(gitlab.WithContext(ctx))
 	return proj, err
 }
 
-func (c *gitlabClientImpl) ListKeys(projectName string) ([]*gitlab.ProjectDeployKey, error) {
+func (c *gitlabClientImpl) GetCurrentPersonalAccessToken(ctx context.Context) (*gitlab.PersonalAccessToken, error) {
+	// GET /personal_access_tokens/self
+	apiObj, _, err := c.c.PersonalAccessTokens.GetSinglePersonalAccessToken(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListProjectHooks(ctx context.Context, projectName string) ([]*gitlab.ProjectHook, error) {
+	apiObjs := []*gitlab.ProjectHook{}
+	opts := &gitlab.ListProjectHooksOptions{}
+	err := allProjectHookPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/hooks
+		pageObjs, resp, listErr := c.c.Projects.ListProjectHooks(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProjectHookAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) AddProjectHook(ctx context.Context, projectName string, opts *gitlab.AddProjectHookOptions) (*gitlab.ProjectHook, error) {
+	// POST /projects/{project}/hooks
+	apiObj, _, err := c.c.Projects.AddProjectHook(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProjectHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) EditProjectHook(ctx context.Context, projectName string, hookID int, opts *gitlab.EditProjectHookOptions) (*gitlab.ProjectHook, error) {
+	// PUT /projects/{project}/hooks/{hook_id}
+	apiObj, _, err := c.c.Projects.EditProjectHook(projectName, hookID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProjectHookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) DeleteProjectHook(ctx context.Context, projectName string, hookID int) error {
+	// DELETE /projects/{project}/hooks/{hook_id}
+	_, err := c.c.Projects.DeleteProjectHook(projectName, hookID, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListKeys(ctx context.Context, projectName string) ([]*gitlab.ProjectDeployKey, error) {
 	apiObjs := []*gitlab.ProjectDeployKey{}
 	opts := &gitlab.ListProjectDeployKeysOptions{}
-	err := allDeployKeyPages(opts, func() (*gitlab.Response, error) {
+	err := allDeployKeyPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		// GET /projects/{project}/deploy_keys
-		pageObjs, resp, listErr := c.c.DeployKeys.ListProjectDeployKeys(projectName, opts)
+		pageObjs, resp, listErr := c.c.DeployKeys.ListProjectDeployKeys(projectName, opts, gitlab.WithContext(ctx))
 		apiObjs = append(apiObjs, pageObjs...)
 		return resp, listErr
 	})
@@ -394,12 +862,12 @@ func (c *gitlabClientImpl) DeleteKey(projectName string, keyID int) error {
 	return handleHTTPError(err)
 }
 
-func (c *gitlabClientImpl) ListTokens(projectName string) ([]*gitlab.DeployToken, error) {
+func (c *gitlabClientImpl) ListTokens(ctx context.Context, projectName string) ([]*gitlab.DeployToken, error) {
 	apiObjs := []*gitlab.DeployToken{}
 	opts := &gitlab.ListProjectDeployTokensOptions{}
-	err := allDeployTokenPages(opts, func() (*gitlab.Response, error) {
+	err := allDeployTokenPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
 		// GET /projects/{project}/deploy_tokens
-		pageObjs, resp, listErr := c.c.DeployTokens.ListProjectDeployTokens(projectName, opts)
+		pageObjs, resp, listErr := c.c.DeployTokens.ListProjectDeployTokens(projectName, opts, gitlab.WithContext(ctx))
 		// filter for active tokens
 		for _, apiObj := range pageObjs {
 			if !apiObj.Expired && !apiObj.Revoked {
@@ -442,6 +910,172 @@ func (c *gitlabClientImpl) DeleteToken(projectName string, keyID int) error {
 	return handleHTTPError(err)
 }
 
+func (c *gitlabClientImpl) ListProtectedTags(ctx context.Context, projectName string) ([]*gitlab.ProtectedTag, error) {
+	apiObjs := []*gitlab.ProtectedTag{}
+	opts := &gitlab.ListProtectedTagsOptions{}
+	err := allProtectedTagPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/protected_tags
+		pageObjs, resp, listErr := c.c.ProtectedTags.ListProtectedTags(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateProtectedTagAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ProtectTag(ctx context.Context, projectName string, opts *gitlab.ProtectRepositoryTagsOptions) (*gitlab.ProtectedTag, error) {
+	// POST /projects/{project}/protected_tags
+	apiObj, _, err := c.c.ProtectedTags.ProtectRepositoryTags(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateProtectedTagAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UnprotectTag(ctx context.Context, projectName, name string) error {
+	// DELETE /projects/{project}/protected_tags/{name}
+	_, err := c.c.ProtectedTags.UnprotectRepositoryTags(projectName, name, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) ListMilestones(ctx context.Context, projectName string) ([]*gitlab.Milestone, error) {
+	apiObjs := []*gitlab.Milestone{}
+	opts := &gitlab.ListMilestonesOptions{}
+	err := allMilestonePages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/milestones
+		pageObjs, resp, listErr := c.c.Milestones.ListMilestones(projectName, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+
+	for _, apiObj := range apiObjs {
+		if err := validateMilestoneAPI(apiObj); err != nil {
+			return nil, err
+		}
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) CreateMilestone(ctx context.Context, projectName string, opts *gitlab.CreateMilestoneOptions) (*gitlab.Milestone, error) {
+	// POST /projects/{project}/milestones
+	apiObj, _, err := c.c.Milestones.CreateMilestone(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateMilestoneAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) UpdateMilestone(ctx context.Context, projectName string, milestoneID int, opts *gitlab.UpdateMilestoneOptions) (*gitlab.Milestone, error) {
+	// PUT /projects/{project}/milestones/{milestone_id}
+	apiObj, _, err := c.c.Milestones.UpdateMilestone(projectName, milestoneID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validateMilestoneAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) CreateLabel(ctx context.Context, projectName string, opts *gitlab.CreateLabelOptions) error {
+	// POST /projects/{project}/labels
+	_, _, err := c.c.Labels.CreateLabel(projectName, opts, gitlab.WithContext(ctx))
+	return handleHTTPError(err)
+}
+
+func (c *gitlabClientImpl) CreatePipeline(ctx context.Context, projectName string, opts *gitlab.CreatePipelineOptions) (*gitlab.Pipeline, error) {
+	// POST /projects/{project}/pipeline
+	apiObj, _, err := c.c.Pipelines.CreatePipeline(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validatePipelineAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) GetPipeline(ctx context.Context, projectName string, id int) (*gitlab.Pipeline, error) {
+	// GET /projects/{project}/pipelines/{pipeline_id}
+	apiObj, _, err := c.c.Pipelines.GetPipeline(projectName, id, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if err := validatePipelineAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListPipelinesPage(ctx context.Context, projectName string, opts *gitlab.ListProjectPipelinesOptions) ([]*gitlab.Pipeline, error) {
+	// GET /projects/{project}/pipelines
+	pageObjs, _, err := c.c.Pipelines.ListProjectPipelines(projectName, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	// The listed pipelines only carry a subset of the fields of a pipeline
+	apiObjs := make([]*gitlab.Pipeline, 0, len(pageObjs))
+	for _, p := range pageObjs {
+		apiObjs = append(apiObjs, &gitlab.Pipeline{
+			ID:        p.ID,
+			IID:       p.IID,
+			ProjectID: p.ProjectID,
+			Status:    p.Status,
+			Source:    p.Source,
+			Ref:       p.Ref,
+			SHA:       p.SHA,
+			WebURL:    p.WebURL,
+			UpdatedAt: p.UpdatedAt,
+			CreatedAt: p.CreatedAt,
+		})
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) ListPipelineJobs(ctx context.Context, projectName string, pipelineID int) ([]*gitlab.Job, error) {
+	apiObjs := []*gitlab.Job{}
+	opts := &gitlab.ListJobsOptions{}
+	err := allJobPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/pipelines/{pipeline_id}/jobs
+		pageObjs, resp, listErr := c.c.Jobs.ListPipelineJobs(projectName, pipelineID, opts, gitlab.WithContext(ctx))
+		apiObjs = append(apiObjs, pageObjs...)
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiObjs, nil
+}
+
+func (c *gitlabClientImpl) GetJobTrace(ctx context.Context, projectName string, jobID int, w io.Writer) error {
+	// GET /projects/{project}/jobs/{job_id}/trace
+	// Jobs.GetTraceFile buffers the whole log, make the request directly to stream it to w instead
+	u := fmt.Sprintf("projects/%s/jobs/%d/trace", gitlab.PathEscape(projectName), jobID)
+	req, err := c.c.NewRequest(http.MethodGet, u, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+	_, err = c.c.Do(req, w)
+	return handleHTTPError(err)
+}
+
 func (c *gitlabClientImpl) ShareProject(projectName string, groupIDObj, groupAccessObj int) error {
 	groupAccess := gitlab.AccessLevel(gitlab.AccessLevelValue(groupAccessObj))
 	groupID := &groupIDObj
@@ -459,19 +1093,11 @@ func (c *gitlabClientImpl) UnshareProject(projectName string, groupID int) error
 	return handleHTTPError(err)
 }
 
-func (c *gitlabClientImpl) ListCommitsPage(projectName string, branch string, perPage int, page int) ([]*gitlab.Commit, error) {
+func (c *gitlabClientImpl) ListCommitsPage(ctx context.Context, projectName string, opts *gitlab.ListCommitsOptions) ([]*gitlab.Commit, error) {
 	apiObjs := make([]*gitlab.Commit, 0)
 
-	opts := gitlab.ListCommitsOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: perPage,
-			Page:    page,
-		},
-		RefName: &branch,
-	}
-
 	// GET /projects/{id}/repository/commits
-	pageObjs, _, listErr := c.c.Commits.ListCommits(projectName, &opts)
+	pageObjs, _, listErr := c.c.Commits.ListCommits(projectName, opts, gitlab.WithContext(ctx))
 	for _, c := range pageObjs {
 		apiObjs = append(apiObjs, &gitlab.Commit{
 			ID:         c.ID,
@@ -479,6 +1105,7 @@ func (c *gitlabClientImpl) ListCommitsPage(projectName string, branch string, pe
 			Message:    c.Message,
 			CreatedAt:  c.CreatedAt,
 			WebURL:     c.WebURL,
+			ParentIDs:  c.ParentIDs,
 		})
 	}
 
@@ -487,3 +1114,69 @@ func (c *gitlabClientImpl) ListCommitsPage(projectName string, branch string, pe
 	}
 	return apiObjs, nil
 }
+
+func (c *gitlabClientImpl) GetCommit(ctx context.Context, projectName, sha string) (*gitlab.Commit, error) {
+	// GET /projects/{project}/repository/commits/{sha}
+	apiObj, _, err := c.c.Commits.GetCommit(projectName, sha, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) GetCommitSignature(ctx context.Context, projectName, sha string) (*gitlab.GPGSignature, error) {
+	// GET /projects/{project}/repository/commits/{sha}/signature
+	apiObj, _, err := c.c.Commits.GetGPGSignature(projectName, sha, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	return apiObj, nil
+}
+
+func (c *gitlabClientImpl) ListCommitBranches(ctx context.Context, projectName, sha string) ([]string, error) {
+	branches := []string{}
+	opts := &gitlab.GetCommitRefsOptions{Type: gitlab.Ptr("branch")}
+	err := allCommitRefPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/repository/commits/{sha}/refs
+		pageObjs, resp, listErr := c.c.Commits.GetCommitRefs(projectName, sha, opts, gitlab.WithContext(ctx))
+		for _, apiObj := range pageObjs {
+			branches = append(branches, apiObj.Name)
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+func (c *gitlabClientImpl) ListCommitDates(ctx context.Context, projectName, ref string, since time.Time) ([]time.Time, error) {
+	dates := []time.Time{}
+	opts := &gitlab.ListCommitsOptions{RefName: &ref, Since: &since}
+	err := allCommitPages(ctx, opts, func(ctx context.Context) (*gitlab.Response, error) {
+		// GET /projects/{project}/repository/commits
+		pageObjs, resp, listErr := c.c.Commits.ListCommits(projectName, opts, gitlab.WithContext(ctx))
+		for _, apiObj := range pageObjs {
+			if apiObj.CommittedDate != nil {
+				dates = append(dates, *apiObj.CommittedDate)
+			}
+		}
+		return resp, listErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
+func (c *gitlabClientImpl) GetCommitSHA(ctx context.Context, projectName, ref string) (string, error) {
+	// GET /projects/{project}/repository/commits/{sha}
+	apiObj, _, err := c.c.Commits.GetCommit(projectName, ref, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", handleHTTPError(err)
+	}
+	if apiObj.ID == "" {
+		return "", fmt.Errorf("didn't expect the commit ID to be empty: %w", gitprovider.ErrInvalidServerData)
+	}
+	return apiObj.ID, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// setup returns a gitlabClient talking to a local HTTP server, and the mux of that
+// server for registering the handlers of the endpoints under test.
+func setup(t *testing.T) (*http.ServeMux, gitlabClient) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := gogitlab.NewClient("", gogitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error while creating the client: %v", err)
+	}
+	return mux, &gitlabClientImpl{c, true}
+}
+
+func TestCreateProject_DefaultVisibility(t *testing.T) {
+	tests := []struct {
+		name           string
+		visibility     gitprovider.RepositoryVisibility
+		wantVisibility interface{}
+	}{
+		{
+			name:           "visibility is omitted for the default sentinel",
+			visibility:     gitprovider.RepositoryVisibilityDefault,
+			wantVisibility: nil,
+		},
+		{
+			name:           "visibility is sent otherwise",
+			visibility:     gitprovider.RepositoryVisibilityPrivate,
+			wantVisibility: "private",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			var payload map[string]interface{}
+			mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id": 1, "name": "repo", "visibility": "internal"}`)
+			})
+
+			ref := gitprovider.UserRepositoryRef{
+				UserRef:        gitprovider.UserRef{Domain: "gitlab.com", UserLogin: "fluxcd"},
+				RepositoryName: "repo",
+			}
+			data := repositoryToAPI(&gitprovider.RepositoryInfo{
+				Visibility: gitprovider.RepositoryVisibilityVar(tt.visibility),
+			}, ref)
+			if _, err := c.CreateProject(context.Background(), &data, nil); err != nil {
+				t.Fatalf("CreateProject() error = %v", err)
+			}
+			if got := payload["visibility"]; got != tt.wantVisibility {
+				t.Errorf("visibility = %v, want %v", got, tt.wantVisibility)
+			}
+		})
+	}
+}
@@ -862,6 +862,46 @@ var _ = Describe("GitLab Provider", func() {
 		validateUserRepo(newRepo, repoRef)
 	})
 
+	It("should be possible to publish a release", func() {
+		userRepoRef := newUserRepoRef(testBaseUrl, testUserName, testRepoName)
+
+		userRepo, err := c.UserRepositories().Get(ctx, userRepoRef)
+		Expect(err).ToNot(HaveOccurred())
+		releases, err := userRepo.Releases()
+		Expect(err).ToNot(HaveOccurred())
+
+		version := "1.0.0\n"
+		release := gitprovider.ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", Body: "First release"}
+		result, err := releases.Publish(ctx, gitprovider.PublishOptions{
+			Files:         []gitprovider.CommitFile{{Path: gitprovider.StringVar("VERSION"), Content: &version}},
+			Branch:        defaultBranchName,
+			CommitMessage: "Release v1.0.0",
+			Release:       release,
+			Rollback:      true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.CommitCreated).To(BeTrue())
+		Expect(result.TagCreated).To(BeTrue())
+		Expect(result.ReleaseCreated).To(BeTrue())
+
+		tag, err := userRepo.Tags().Get(ctx, "v1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tag.SHA).To(Equal(result.CommitSHA))
+		Expect(tag.Annotated).To(BeTrue())
+
+		got, err := releases.Get(ctx, "v1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(got.Name).To(Equal(release.Name))
+		Expect(got.Body).To(Equal(release.Body))
+
+		// Publishing the same tag again fails before creating the release
+		_, err = releases.Publish(ctx, gitprovider.PublishOptions{Ref: result.CommitSHA, Release: release})
+		var publishErr *gitprovider.PublishError
+		Expect(errors.As(err, &publishErr)).To(BeTrue())
+		Expect(publishErr.Step).To(Equal(gitprovider.PublishStepTag))
+		Expect(errors.Is(err, gitprovider.ErrAlreadyExists)).To(BeTrue())
+	})
+
 	It("should be possible to create and edit a pr for a user repository", func() {
 
 		testRepoName = fmt.Sprintf("test-repo2-%03d", rand.Intn(1000))
@@ -895,7 +935,7 @@ var _ = Describe("GitLab Provider", func() {
 		var commits []gitprovider.Commit = []gitprovider.Commit{}
 		Eventually(func() bool {
 			var err error
-			commits, err = userRepo.Commits().ListPage(ctx, defaultBranch, 1, 0)
+			commits, err = userRepo.Commits().ListPage(ctx, defaultBranch, 1, 1)
 			if err == nil && len(commits) == 0 {
 				if retryOp.Counter() == 0 {
 					// This is a known issue with gitlab, see https://gitlab.com/gitlab-org/gitlab/-/issues/372092
@@ -954,7 +994,7 @@ var _ = Describe("GitLab Provider", func() {
 
 		// another pr
 
-		commits, err = userRepo.Commits().ListPage(ctx, defaultBranch, 1, 0)
+		commits, err = userRepo.Commits().ListPage(ctx, defaultBranch, 1, 1)
 		Expect(err).ToNot(HaveOccurred())
 		latestCommit = commits[0]
 		err = userRepo.Branches().Create(ctx, branchName2, latestCommit.Get().Sha)
@@ -51,5 +51,6 @@ func commitFromAPI(apiObj *gitlab.Commit) gitprovider.CommitInfo {
 		Message:   apiObj.Message,
 		CreatedAt: *apiObj.CreatedAt,
 		URL:       apiObj.WebURL,
+		Parents:   apiObj.ParentIDs,
 	}
 }
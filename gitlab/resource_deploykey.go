@@ -103,7 +103,7 @@ func (dk *deployKey) Delete(_ context.Context) error {
 //
 // The internal API object will be overridden with the received server data if actionTaken == true.
 func (dk *deployKey) Reconcile(ctx context.Context) (bool, error) {
-	actual, err := dk.c.get(dk.k.Title)
+	actual, err := dk.c.get(ctx, dk.k.Title)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
@@ -148,10 +148,12 @@ func validateDeployKeyAPI(apiObj *gitlab.ProjectDeployKey) error {
 }
 
 func deployKeyFromAPI(apiObj *gitlab.ProjectDeployKey) gitprovider.DeployKeyInfo {
-	return gitprovider.DeployKeyInfo{
+	info := gitprovider.DeployKeyInfo{
 		Name: apiObj.Title,
 		Key:  []byte(apiObj.Key),
 	}
+	info.ComputeFingerprint()
+	return info
 }
 
 func deployKeyToAPI(info *gitprovider.DeployKeyInfo) *gitlab.ProjectDeployKey {
@@ -0,0 +1,269 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newDeployHook(c *HookClient, hook *gitlab.ProjectHook, secret *string) *deployHook {
+	return &deployHook{
+		h:      *hook,
+		actual: *hook,
+		secret: secret,
+		c:      c,
+	}
+}
+
+var _ gitprovider.DeployHook = &deployHook{}
+
+type deployHook struct {
+	h gitlab.ProjectHook
+	// actual is the hook as last returned by GitLab, which Update compares h to.
+	actual gitlab.ProjectHook
+	// secret is the token of the hook to send with the next update, which GitLab doesn't return.
+	secret *string
+	c      *HookClient
+}
+
+func (dh *deployHook) Get() gitprovider.DeployHookInfo {
+	return deployHookFromAPI(&dh.h)
+}
+
+func (dh *deployHook) Set(info gitprovider.DeployHookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := deployHookInfoToAPIObj(&info, &dh.h); err != nil {
+		return err
+	}
+	dh.secret = info.Secret
+	return nil
+}
+
+func (dh *deployHook) APIObject() interface{} {
+	return &dh.h
+}
+
+func (dh *deployHook) Repository() gitprovider.RepositoryRef {
+	return dh.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only the event flags differing from the server state are sent, along with the secret if set.
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (dh *deployHook) Update(ctx context.Context) error {
+	// PUT /projects/{project}/hooks/{hook_id}
+	apiObj, err := dh.c.c.EditProjectHook(ctx, getRepoPath(dh.c.ref), dh.h.ID, editProjectHookOptions(&dh.actual, &dh.h, dh.secret))
+	if err != nil {
+		return err
+	}
+	*dh = *newDeployHook(dh.c, apiObj, nil)
+	return nil
+}
+
+// Delete deletes the webhook from the project. Deleting a webhook requires destructive
+// API calls to be enabled, ErrDestructiveCallDisallowed is returned otherwise.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dh *deployHook) Delete(ctx context.Context) error {
+	// Don't allow deleting webhooks if the user didn't explicitly allow dangerous API calls.
+	if !dh.c.destructiveActions {
+		return fmt.Errorf("cannot delete webhook: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /projects/{project}/hooks/{hook_id}
+	return dh.c.c.DeleteProjectHook(ctx, getRepoPath(dh.c.ref), dh.h.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (dh *deployHook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := dh.c.get(ctx, dh.h.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			// POST /projects/{project}/hooks
+			apiObj, err := dh.c.c.AddProjectHook(ctx, getRepoPath(dh.c.ref), addProjectHookOptions(&dh.h, dh.secret))
+			if err != nil {
+				return true, err
+			}
+			*dh = *newDeployHook(dh.c, apiObj, nil)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing. GitLab doesn't return the
+	// secret, so a desired one is always sent.
+	desired := dh.Get()
+	desired.Secret = dh.secret
+	if desired.Equals(actual.Get()) {
+		return false, nil
+	}
+	// Otherwise, update the actual webhook with the desired state
+	dh.h.ID = actual.h.ID
+	dh.actual = actual.actual
+	return true, dh.Update(ctx)
+}
+
+func validateProjectHookAPI(apiObj *gitlab.ProjectHook) error {
+	return validateAPIObject("GitLab.ProjectHook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+// projectHookEventFlags returns pointers to the event flags of hook, keyed by the WebhookEvent they
+// stand for. The keys are the same as in webhookEventNames.
+func projectHookEventFlags(hook *gitlab.ProjectHook) map[gitprovider.WebhookEvent]*bool {
+	return map[gitprovider.WebhookEvent]*bool{
+		gitprovider.WebhookEventPush:        &hook.PushEvents,
+		gitprovider.WebhookEventTagPush:     &hook.TagPushEvents,
+		gitprovider.WebhookEventPullRequest: &hook.MergeRequestsEvents,
+		gitprovider.WebhookEventIssues:      &hook.IssuesEvents,
+		gitprovider.WebhookEventComment:     &hook.NoteEvents,
+		gitprovider.WebhookEventRelease:     &hook.ReleasesEvents,
+		gitprovider.WebhookEventDeployment:  &hook.DeploymentEvents,
+		gitprovider.WebhookEventPipeline:    &hook.PipelineEvents,
+		gitprovider.WebhookEventWiki:        &hook.WikiPageEvents,
+	}
+}
+
+// editProjectHookEventOptions returns pointers to the event options of opts, keyed like
+// projectHookEventFlags.
+func editProjectHookEventOptions(opts *gitlab.EditProjectHookOptions) map[gitprovider.WebhookEvent]**bool {
+	return map[gitprovider.WebhookEvent]**bool{
+		gitprovider.WebhookEventPush:        &opts.PushEvents,
+		gitprovider.WebhookEventTagPush:     &opts.TagPushEvents,
+		gitprovider.WebhookEventPullRequest: &opts.MergeRequestsEvents,
+		gitprovider.WebhookEventIssues:      &opts.IssuesEvents,
+		gitprovider.WebhookEventComment:     &opts.NoteEvents,
+		gitprovider.WebhookEventRelease:     &opts.ReleasesEvents,
+		gitprovider.WebhookEventDeployment:  &opts.DeploymentEvents,
+		gitprovider.WebhookEventPipeline:    &opts.PipelineEvents,
+		gitprovider.WebhookEventWiki:        &opts.WikiPageEvents,
+	}
+}
+
+func deployHookFromAPI(apiObj *gitlab.ProjectHook) gitprovider.DeployHookInfo {
+	info := gitprovider.DeployHookInfo{
+		URL: apiObj.URL,
+		// GitLab always delivers the events as JSON, and project hooks can't be paused
+		ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+		Active:      gitprovider.BoolVar(true),
+	}
+	flags := projectHookEventFlags(apiObj)
+	for _, event := range gitprovider.SortedWebhookEvents(webhookEventNames) {
+		if *flags[event] {
+			info.Events = append(info.Events, event)
+		}
+	}
+	return info
+}
+
+func deployHookToAPI(info *gitprovider.DeployHookInfo) (*gitlab.ProjectHook, error) {
+	h := &gitlab.ProjectHook{}
+	if err := deployHookInfoToAPIObj(info, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func deployHookInfoToAPIObj(info *gitprovider.DeployHookInfo, apiObj *gitlab.ProjectHook) error {
+	flags := projectHookEventFlags(apiObj)
+	for _, event := range info.Events {
+		if _, ok := flags[event]; !ok {
+			return fmt.Errorf("GitLab project hooks can't be triggered by %q events: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+	}
+	if info.ContentType != nil && *info.ContentType != gitprovider.WebhookContentTypeJSON {
+		return fmt.Errorf("GitLab project hooks can't deliver events as %q: %w", *info.ContentType, gitprovider.ErrNoProviderSupport)
+	}
+	if info.Active != nil && !*info.Active {
+		return fmt.Errorf("GitLab project hooks can't be deactivated: %w", gitprovider.ErrNoProviderSupport)
+	}
+
+	apiObj.URL = info.URL
+	for _, flag := range flags {
+		*flag = false
+	}
+	for _, event := range info.Events {
+		*flags[event] = true
+	}
+	return nil
+}
+
+// addProjectHookOptions returns the options creating hook, with the given secret token.
+// Only the event flags known to projectHookEventFlags are sent.
+func addProjectHookOptions(hook *gitlab.ProjectHook, secret *string) *gitlab.AddProjectHookOptions {
+	return &gitlab.AddProjectHookOptions{
+		URL:                 gitlab.Ptr(hook.URL),
+		PushEvents:          gitlab.Ptr(hook.PushEvents),
+		TagPushEvents:       gitlab.Ptr(hook.TagPushEvents),
+		MergeRequestsEvents: gitlab.Ptr(hook.MergeRequestsEvents),
+		IssuesEvents:        gitlab.Ptr(hook.IssuesEvents),
+		NoteEvents:          gitlab.Ptr(hook.NoteEvents),
+		ReleasesEvents:      gitlab.Ptr(hook.ReleasesEvents),
+		DeploymentEvents:    gitlab.Ptr(hook.DeploymentEvents),
+		PipelineEvents:      gitlab.Ptr(hook.PipelineEvents),
+		WikiPageEvents:      gitlab.Ptr(hook.WikiPageEvents),
+		Token:               secret,
+	}
+}
+
+// editProjectHookOptions returns the options editing a hook from its actual to its desired state:
+// the URL, which GitLab requires, the event flags differing between both, and the secret token
+// if set. The other settings of the hook are left untouched.
+func editProjectHookOptions(actual, desired *gitlab.ProjectHook, secret *string) *gitlab.EditProjectHookOptions {
+	opts := &gitlab.EditProjectHookOptions{
+		URL:   gitlab.Ptr(desired.URL),
+		Token: secret,
+	}
+	actualFlags := projectHookEventFlags(actual)
+	optFlags := editProjectHookEventOptions(opts)
+	for event, flag := range projectHookEventFlags(desired) {
+		if *flag != *actualFlags[event] {
+			*optFlags[event] = gitlab.Ptr(*flag)
+		}
+	}
+	return opts
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+const (
+	// gitlabMilestoneStateActive is the GitLab equivalent of gitprovider.MilestoneStateOpen.
+	gitlabMilestoneStateActive = "active"
+	// gitlabMilestoneStateClosed is the GitLab equivalent of gitprovider.MilestoneStateClosed.
+	gitlabMilestoneStateClosed = "closed"
+)
+
+func newMilestone(c *MilestoneClient, apiObj *gitlab.Milestone) *milestone {
+	return &milestone{
+		m: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Milestone = &milestone{}
+
+type milestone struct {
+	m gitlab.Milestone
+	c *MilestoneClient
+}
+
+func (m *milestone) Get() gitprovider.MilestoneInfo {
+	return milestoneFromAPI(&m.m)
+}
+
+func (m *milestone) Set(info gitprovider.MilestoneInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	milestoneInfoToAPIObj(&info, &m.m)
+	return nil
+}
+
+func (m *milestone) APIObject() interface{} {
+	return &m.m
+}
+
+func (m *milestone) Repository() gitprovider.RepositoryRef {
+	return m.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (m *milestone) Update(ctx context.Context) error {
+	opts := &gitlab.UpdateMilestoneOptions{
+		Title:       gitlab.Ptr(m.m.Title),
+		Description: gitlab.Ptr(m.m.Description),
+		DueDate:     m.m.DueDate,
+		StateEvent:  gitlab.Ptr("activate"),
+	}
+	if m.m.State == gitlabMilestoneStateClosed {
+		opts.StateEvent = gitlab.Ptr("close")
+	}
+	// PUT /projects/{project}/milestones/{milestone_id}
+	apiObj, err := m.c.c.UpdateMilestone(ctx, getRepoPath(m.c.ref), m.m.ID, opts)
+	if err != nil {
+		return err
+	}
+	m.m = *apiObj
+	return nil
+}
+
+func validateMilestoneAPI(apiObj *gitlab.Milestone) error {
+	return validateAPIObject("GitLab.Milestone", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.Title == "" {
+			validator.Required("Title")
+		}
+	})
+}
+
+func milestoneFromAPI(apiObj *gitlab.Milestone) gitprovider.MilestoneInfo {
+	info := gitprovider.MilestoneInfo{
+		Title:       apiObj.Title,
+		Description: gitprovider.StringVar(apiObj.Description),
+		State:       gitprovider.MilestoneStateVar(gitprovider.MilestoneStateOpen),
+	}
+	if apiObj.State == gitlabMilestoneStateClosed {
+		info.State = gitprovider.MilestoneStateVar(gitprovider.MilestoneStateClosed)
+	}
+	if apiObj.DueDate != nil {
+		info.DueDate = gitprovider.MilestoneDueDate(time.Time(*apiObj.DueDate))
+	}
+	return info
+}
+
+func milestoneToCreateOptions(info *gitprovider.MilestoneInfo) *gitlab.CreateMilestoneOptions {
+	opts := &gitlab.CreateMilestoneOptions{
+		Title:       gitlab.Ptr(info.Title),
+		Description: info.Description,
+	}
+	if info.DueDate != nil {
+		opts.DueDate = gitlab.Ptr(gitlab.ISOTime(*info.DueDate))
+	}
+	return opts
+}
+
+func milestoneInfoToAPIObj(info *gitprovider.MilestoneInfo, apiObj *gitlab.Milestone) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Title = info.Title
+	// optional fields
+	if info.Description != nil {
+		apiObj.Description = *info.Description
+	}
+	if info.DueDate != nil {
+		apiObj.DueDate = gitlab.Ptr(gitlab.ISOTime(*info.DueDate))
+	}
+	if info.State != nil {
+		apiObj.State = gitlabMilestoneStateActive
+		if *info.State == gitprovider.MilestoneStateClosed {
+			apiObj.State = gitlabMilestoneStateClosed
+		}
+	}
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package gitlab
 
 import (
+	"bytes"
+	"context"
 	"gitlab.com/gitlab-org/api/client-go"
+	"io"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
@@ -32,6 +35,14 @@ func newOrganization(ctx *clientContext, apiObj *gitlab.Group, ref gitprovider.O
 			clientContext: ctx,
 			ref:           ref,
 		},
+		settings: &OrganizationSettingsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		webhooks: &OrganizationWebhookClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 	}
 }
 
@@ -43,7 +54,9 @@ type organization struct {
 	g   gitlab.Group
 	ref gitprovider.OrganizationRef
 
-	teams *TeamsClient
+	teams    *TeamsClient
+	settings *OrganizationSettingsClient
+	webhooks *OrganizationWebhookClient
 }
 
 func (o *organization) Get() gitprovider.OrganizationInfo {
@@ -62,10 +75,33 @@ func (o *organization) Teams() gitprovider.TeamsClient {
 	return o.teams
 }
 
+func (o *organization) Settings() gitprovider.OrganizationSettingsClient {
+	return o.settings
+}
+
+func (o *organization) Webhooks() (gitprovider.OrganizationWebhookClient, error) {
+	return o.webhooks, nil
+}
+
+// AuditLog returns an AuditLogClient reading the audit events of the group.
+func (o *organization) AuditLog() (gitprovider.AuditLogClient, error) {
+	return &AuditLogClient{clientContext: o.clientContext, ref: o.ref}, nil
+}
+
+// SetAvatar uploads the given image as the avatar of the group.
+func (o *organization) SetAvatar(ctx context.Context, avatar io.Reader) error {
+	data, filename, err := gitprovider.ReadAvatar(avatar)
+	if err != nil {
+		return err
+	}
+	return o.c.UploadGroupAvatar(ctx, o.ref.Organization, bytes.NewReader(data), filename)
+}
+
 func organizationFromAPI(apiObj *gitlab.Group) gitprovider.OrganizationInfo {
 	return gitprovider.OrganizationInfo{
-		Name:        &apiObj.Name,
-		Description: &apiObj.Description,
+		Name:              &apiObj.Name,
+		Description:       &apiObj.Description,
+		TwoFactorRequired: &apiObj.RequireTwoFactorAuth,
 	}
 }
 
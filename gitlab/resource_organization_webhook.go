@@ -0,0 +1,233 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newOrgWebhook(c *OrganizationWebhookClient, hook *gitlab.GroupHook, secret *string) *orgWebhook {
+	return &orgWebhook{
+		h:      *hook,
+		secret: secret,
+		c:      c,
+	}
+}
+
+var _ gitprovider.OrganizationWebhook = &orgWebhook{}
+
+type orgWebhook struct {
+	h gitlab.GroupHook
+	// secret is the token of the hook, which GitLab doesn't return.
+	secret *string
+	c      *OrganizationWebhookClient
+}
+
+func (w *orgWebhook) Get() gitprovider.OrganizationWebhookInfo {
+	info := orgWebhookFromAPI(&w.h)
+	info.Secret = w.secret
+	return info
+}
+
+func (w *orgWebhook) Set(info gitprovider.OrganizationWebhookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	if err := orgWebhookInfoToAPIObj(&info, &w.h); err != nil {
+		return err
+	}
+	w.secret = info.Secret
+	return nil
+}
+
+func (w *orgWebhook) APIObject() interface{} {
+	return &w.h
+}
+
+func (w *orgWebhook) Organization() gitprovider.OrganizationRef {
+	return w.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (w *orgWebhook) Update(ctx context.Context) error {
+	// The options of adding and editing a hook are the same
+	opts := gitlab.EditGroupHookOptions(*addGroupHookOptions(&w.h, w.secret))
+	// PUT /groups/{group}/hooks/{hook_id}
+	apiObj, err := w.c.c.EditGroupHook(ctx, w.c.ref.Organization, w.h.ID, &opts)
+	if err != nil {
+		return err
+	}
+	w.h = *apiObj
+	return nil
+}
+
+// Delete deletes the webhook from the group. Deleting a webhook requires destructive API calls
+// to be enabled, ErrDestructiveCallDisallowed is returned otherwise.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (w *orgWebhook) Delete(ctx context.Context) error {
+	// Don't allow deleting webhooks if the user didn't explicitly allow dangerous API calls.
+	if !w.c.destructiveActions {
+		return fmt.Errorf("cannot delete webhook: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	// DELETE /groups/{group}/hooks/{hook_id}
+	return w.c.c.DeleteGroupHook(ctx, w.c.ref.Organization, w.h.ID)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (w *orgWebhook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := w.c.get(ctx, w.h.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			// POST /groups/{group}/hooks
+			apiObj, err := w.c.c.AddGroupHook(ctx, w.c.ref.Organization, addGroupHookOptions(&w.h, w.secret))
+			if err != nil {
+				return true, err
+			}
+			w.h = *apiObj
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, do nothing
+	if w.Get().Equals(actual.Get()) {
+		return false, nil
+	}
+	// Otherwise, update the actual webhook with the desired state
+	w.h.ID = actual.h.ID
+	return true, w.Update(ctx)
+}
+
+func validateGroupHookAPI(apiObj *gitlab.GroupHook) error {
+	return validateAPIObject("GitLab.GroupHook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+// groupHookEventFlags returns pointers to the event flags of hook, keyed by the WebhookEvent they
+// stand for. The keys are the same as in webhookEventNames.
+func groupHookEventFlags(hook *gitlab.GroupHook) map[gitprovider.WebhookEvent]*bool {
+	return map[gitprovider.WebhookEvent]*bool{
+		gitprovider.WebhookEventPush:        &hook.PushEvents,
+		gitprovider.WebhookEventTagPush:     &hook.TagPushEvents,
+		gitprovider.WebhookEventPullRequest: &hook.MergeRequestsEvents,
+		gitprovider.WebhookEventIssues:      &hook.IssuesEvents,
+		gitprovider.WebhookEventComment:     &hook.NoteEvents,
+		gitprovider.WebhookEventRelease:     &hook.ReleasesEvents,
+		gitprovider.WebhookEventDeployment:  &hook.DeploymentEvents,
+		gitprovider.WebhookEventPipeline:    &hook.PipelineEvents,
+		gitprovider.WebhookEventWiki:        &hook.WikiPageEvents,
+	}
+}
+
+func orgWebhookFromAPI(apiObj *gitlab.GroupHook) gitprovider.OrganizationWebhookInfo {
+	info := gitprovider.OrganizationWebhookInfo{
+		URL:                apiObj.URL,
+		InsecureSkipVerify: gitprovider.BoolVar(!apiObj.EnableSSLVerification),
+		// GitLab always delivers the events as JSON
+		ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+	}
+	flags := groupHookEventFlags(apiObj)
+	for _, event := range gitprovider.SortedWebhookEvents(webhookEventNames) {
+		if *flags[event] {
+			info.Events = append(info.Events, event)
+		}
+	}
+	return info
+}
+
+func orgWebhookToAPI(info *gitprovider.OrganizationWebhookInfo) (*gitlab.GroupHook, error) {
+	h := &gitlab.GroupHook{}
+	if err := orgWebhookInfoToAPIObj(info, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func orgWebhookInfoToAPIObj(info *gitprovider.OrganizationWebhookInfo, apiObj *gitlab.GroupHook) error {
+	flags := groupHookEventFlags(apiObj)
+	for _, event := range info.Events {
+		if _, ok := flags[event]; !ok {
+			return fmt.Errorf("GitLab group hooks can't be triggered by %q events: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+	}
+	if info.ContentType != nil && *info.ContentType != gitprovider.WebhookContentTypeJSON {
+		return fmt.Errorf("GitLab group hooks can't deliver events as %q: %w", *info.ContentType, gitprovider.ErrNoProviderSupport)
+	}
+
+	apiObj.URL = info.URL
+	for _, flag := range flags {
+		*flag = false
+	}
+	for _, event := range info.Events {
+		*flags[event] = true
+	}
+	if info.InsecureSkipVerify != nil {
+		apiObj.EnableSSLVerification = !*info.InsecureSkipVerify
+	}
+	return nil
+}
+
+// addGroupHookOptions returns the options creating hook, with the given secret token.
+// Only the event flags known to groupHookEventFlags are sent.
+func addGroupHookOptions(hook *gitlab.GroupHook, secret *string) *gitlab.AddGroupHookOptions {
+	return &gitlab.AddGroupHookOptions{
+		URL:                   gitlab.Ptr(hook.URL),
+		PushEvents:            gitlab.Ptr(hook.PushEvents),
+		TagPushEvents:         gitlab.Ptr(hook.TagPushEvents),
+		MergeRequestsEvents:   gitlab.Ptr(hook.MergeRequestsEvents),
+		IssuesEvents:          gitlab.Ptr(hook.IssuesEvents),
+		NoteEvents:            gitlab.Ptr(hook.NoteEvents),
+		ReleasesEvents:        gitlab.Ptr(hook.ReleasesEvents),
+		DeploymentEvents:      gitlab.Ptr(hook.DeploymentEvents),
+		PipelineEvents:        gitlab.Ptr(hook.PipelineEvents),
+		WikiPageEvents:        gitlab.Ptr(hook.WikiPageEvents),
+		EnableSSLVerification: gitlab.Ptr(hook.EnableSSLVerification),
+		Token:                 secret,
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newPipeline(c *PipelineClient, apiObj *gitlab.Pipeline) *pipeline {
+	return &pipeline{
+		p: *apiObj,
+		c: c,
+	}
+}
+
+var _ gitprovider.Pipeline = &pipeline{}
+
+type pipeline struct {
+	p gitlab.Pipeline
+	c *PipelineClient
+}
+
+func (p *pipeline) Get() gitprovider.PipelineInfo {
+	return pipelineFromAPI(&p.p)
+}
+
+func (p *pipeline) APIObject() interface{} {
+	return &p.p
+}
+
+func (p *pipeline) Repository() gitprovider.RepositoryRef {
+	return p.c.ref
+}
+
+func validatePipelineAPI(apiObj *gitlab.Pipeline) error {
+	return validateAPIObject("GitLab.Pipeline", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+	})
+}
+
+func pipelineFromAPI(apiObj *gitlab.Pipeline) gitprovider.PipelineInfo {
+	info := gitprovider.PipelineInfo{
+		ID:     apiObj.ID,
+		Ref:    apiObj.Ref,
+		Sha:    apiObj.SHA,
+		Status: apiObj.Status,
+		URL:    apiObj.WebURL,
+	}
+	if apiObj.CreatedAt != nil {
+		info.CreatedAt = *apiObj.CreatedAt
+	}
+	return info
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newProtectedTag(c *ProtectedTagClient, tag *gitlab.ProtectedTag) *protectedTag {
+	return &protectedTag{
+		t: *tag,
+		c: c,
+	}
+}
+
+var _ gitprovider.ProtectedTag = &protectedTag{}
+
+type protectedTag struct {
+	t gitlab.ProtectedTag
+	c *ProtectedTagClient
+}
+
+func (pt *protectedTag) Get() gitprovider.ProtectedTagInfo {
+	return protectedTagFromAPI(&pt.t)
+}
+
+func (pt *protectedTag) Set(info gitprovider.ProtectedTagInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	protectedTagInfoToAPIObj(&info, &pt.t)
+	return nil
+}
+
+func (pt *protectedTag) APIObject() interface{} {
+	return &pt.t
+}
+
+func (pt *protectedTag) Repository() gitprovider.RepositoryRef {
+	return pt.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (pt *protectedTag) Update(ctx context.Context) error {
+	actual, err := pt.c.get(ctx, pt.t.Name)
+	if err != nil {
+		return err
+	}
+	return pt.update(ctx, &actual.t)
+}
+
+// update applies the desired state over previous, the actual state of the protected tag.
+// GitLab can't update a protected tag, so it's unprotected and protected again. If protecting it
+// with the desired state fails, it's protected again with previous, not to leave it unprotected.
+func (pt *protectedTag) update(ctx context.Context, previous *gitlab.ProtectedTag) error {
+	if err := pt.Delete(ctx); err != nil {
+		return err
+	}
+	err := pt.createIntoSelf(ctx)
+	if err == nil {
+		return nil
+	}
+	// Restore the protection even if ctx is canceled
+	// POST /projects/{project}/protected_tags
+	if _, restoreErr := pt.c.c.ProtectTag(context.WithoutCancel(ctx), getRepoPath(pt.c.ref), protectedTagRestoreOptions(previous)); restoreErr != nil {
+		return fmt.Errorf("%w, and restoring the previous protection failed: %v", err, restoreErr)
+	}
+	return err
+}
+
+// Delete unprotects the tags matching this protected tag.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (pt *protectedTag) Delete(ctx context.Context) error {
+	// DELETE /projects/{project}/protected_tags/{name}
+	return pt.c.c.UnprotectTag(ctx, getRepoPath(pt.c.ref), pt.t.Name)
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (pt *protectedTag) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := pt.c.get(ctx, pt.t.Name)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			return true, pt.createIntoSelf(ctx)
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, err
+	}
+
+	// If the desired matches the actual state, just return the actual state
+	if pt.Get().Equals(actual.Get()) {
+		return false, nil
+	}
+
+	return true, pt.update(ctx, &actual.t)
+}
+
+func (pt *protectedTag) createIntoSelf(ctx context.Context) error {
+	// POST /projects/{project}/protected_tags
+	info := pt.Get()
+	apiObj, err := pt.c.c.ProtectTag(ctx, getRepoPath(pt.c.ref), protectedTagToAPI(&info))
+	if err != nil {
+		return err
+	}
+	pt.t = *apiObj
+	return nil
+}
+
+func validateProtectedTagAPI(apiObj *gitlab.ProtectedTag) error {
+	return validateAPIObject("GitLab.ProtectedTag", func(validator validation.Validator) {
+		if apiObj.Name == "" {
+			validator.Required("Name")
+		}
+	})
+}
+
+func protectedTagFromAPI(apiObj *gitlab.ProtectedTag) gitprovider.ProtectedTagInfo {
+	return gitprovider.ProtectedTagInfo{
+		Name:              apiObj.Name,
+		CreateAccessLevel: gitprovider.PermissionLevelVar(createAccessLevelFromAPI(apiObj.CreateAccessLevels)),
+	}
+}
+
+func protectedTagToAPI(info *gitprovider.ProtectedTagInfo) *gitlab.ProtectRepositoryTagsOptions {
+	opts := &gitlab.ProtectRepositoryTagsOptions{
+		Name: gitlab.Ptr(info.Name),
+	}
+	if info.CreateAccessLevel != nil {
+		opts.CreateAccessLevel = gitlab.Ptr(createAccessLevelToAPI(*info.CreateAccessLevel))
+	}
+	return opts
+}
+
+// protectedTagRestoreOptions returns the options protecting a tag again exactly like apiObj,
+// including the access granted to specific users and groups.
+func protectedTagRestoreOptions(apiObj *gitlab.ProtectedTag) *gitlab.ProtectRepositoryTagsOptions {
+	allowed := make([]*gitlab.TagsPermissionOptions, 0, len(apiObj.CreateAccessLevels))
+	for _, l := range apiObj.CreateAccessLevels {
+		perm := &gitlab.TagsPermissionOptions{}
+		switch {
+		case l.UserID != 0:
+			perm.UserID = gitlab.Ptr(l.UserID)
+		case l.GroupID != 0:
+			perm.GroupID = gitlab.Ptr(l.GroupID)
+		default:
+			perm.AccessLevel = gitlab.Ptr(l.AccessLevel)
+		}
+		allowed = append(allowed, perm)
+	}
+	return &gitlab.ProtectRepositoryTagsOptions{
+		Name:            gitlab.Ptr(apiObj.Name),
+		AllowedToCreate: &allowed,
+	}
+}
+
+func protectedTagInfoToAPIObj(info *gitprovider.ProtectedTagInfo, apiObj *gitlab.ProtectedTag) {
+	// Required fields, we assume info is validated, and hence these are set
+	apiObj.Name = info.Name
+	if info.CreateAccessLevel != nil {
+		apiObj.CreateAccessLevels = []*gitlab.TagAccessDescription{
+			{AccessLevel: createAccessLevelToAPI(*info.CreateAccessLevel)},
+		}
+	}
+}
+
+// createAccessLevelFromAPI returns the most permissive role allowed to create the tags, i.e. the
+// lowest role granted access, regardless of the order of levels.
+// Access granted to specific users or groups is not represented.
+func createAccessLevelFromAPI(levels []*gitlab.TagAccessDescription) gitprovider.PermissionLevel {
+	lowest := gitlab.NoPermissions
+	for _, l := range levels {
+		if l.UserID != 0 || l.GroupID != 0 || l.AccessLevel < gitlab.DeveloperPermissions {
+			continue
+		}
+		if lowest == gitlab.NoPermissions || l.AccessLevel < lowest {
+			lowest = l.AccessLevel
+		}
+	}
+	switch {
+	case lowest == gitlab.NoPermissions:
+		return gitprovider.PermissionLevelNone
+	case lowest < gitlab.MaintainerPermissions:
+		return gitprovider.PermissionLevelWrite
+	default:
+		return gitprovider.PermissionLevelAdmin
+	}
+}
+
+// createAccessLevelToAPI maps a PermissionLevel to the GitLab role allowed to create the tags.
+// PermissionLevelWrite maps to developers, PermissionLevelAdmin to maintainers.
+func createAccessLevelToAPI(level gitprovider.PermissionLevel) gitlab.AccessLevelValue {
+	switch level {
+	case gitprovider.PermissionLevelWrite:
+		return gitlab.DeveloperPermissions
+	case gitprovider.PermissionLevelAdmin:
+		return gitlab.MaintainerPermissions
+	default:
+		return gitlab.NoPermissions
+	}
+}
@@ -55,5 +55,33 @@ func pullrequestFromAPI(apiObj *gitlab.MergeRequest) gitprovider.PullRequestInfo
 		Number:       apiObj.IID,
 		WebURL:       apiObj.WebURL,
 		SourceBranch: apiObj.SourceBranch,
+		Labels:       apiObj.Labels,
 	}
 }
+
+func newPullRequestReview(apiObj interface{}, reviewer string, state gitprovider.ReviewState) *pullRequestReview {
+	return &pullRequestReview{
+		r: apiObj,
+		info: gitprovider.ReviewInfo{
+			Reviewer: reviewer,
+			State:    state,
+		},
+	}
+}
+
+var _ gitprovider.PullRequestReview = &pullRequestReview{}
+
+// pullRequestReview is a review of a merge request, whose API object is either the
+// *gitlab.MergeRequestApprovals of an approval or the *gitlab.Note of a comment.
+type pullRequestReview struct {
+	r    interface{}
+	info gitprovider.ReviewInfo
+}
+
+func (r *pullRequestReview) Get() gitprovider.ReviewInfo {
+	return r.info
+}
+
+func (r *pullRequestReview) APIObject() interface{} {
+	return r.r
+}
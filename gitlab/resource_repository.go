@@ -17,8 +17,14 @@ limitations under the License.
 package gitlab
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	gogitlab "gitlab.com/gitlab-org/api/client-go"
@@ -39,6 +45,14 @@ func newUserProject(ctx *clientContext, apiObj *gogitlab.Project, ref gitprovide
 			clientContext: ctx,
 			ref:           ref,
 		},
+		protectedTags: &ProtectedTagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
+		milestones: &MilestoneClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		commits: &CommitClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -47,6 +61,10 @@ func newUserProject(ctx *clientContext, apiObj *gogitlab.Project, ref gitprovide
 			clientContext: ctx,
 			ref:           ref,
 		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		pullRequests: &PullRequestClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -70,17 +88,20 @@ type userProject struct {
 	p   gogitlab.Project
 	ref gitprovider.RepositoryRef
 
-	deployKeys   *DeployKeyClient
-	deployTokens *DeployTokenClient
-	commits      *CommitClient
-	branches     *BranchClient
-	pullRequests *PullRequestClient
-	files        *FileClient
-	trees        *TreeClient
+	deployKeys    *DeployKeyClient
+	deployTokens  *DeployTokenClient
+	protectedTags *ProtectedTagClient
+	milestones    *MilestoneClient
+	commits       *CommitClient
+	branches      *BranchClient
+	tags          *TagClient
+	pullRequests  *PullRequestClient
+	files         *FileClient
+	trees         *TreeClient
 }
 
 func (p *userProject) Get() gitprovider.RepositoryInfo {
-	return repositoryFromAPI(&p.p)
+	return repositoryFromAPI(&p.p, p.domain)
 }
 
 func (p *userProject) Set(info gitprovider.RepositoryInfo) error {
@@ -103,10 +124,60 @@ func (p *userProject) DeployKeys() gitprovider.DeployKeyClient {
 	return p.deployKeys
 }
 
+// Hooks returns a HookClient operating on the webhooks of the project.
+func (p *userProject) Hooks() (gitprovider.HookClient, error) {
+	return &HookClient{clientContext: p.clientContext, ref: p.ref}, nil
+}
+
 func (p *userProject) DeployTokens() (gitprovider.DeployTokenClient, error) {
 	return p.deployTokens, nil
 }
 
+func (p *userProject) ProtectedTags() (gitprovider.ProtectedTagClient, error) {
+	return p.protectedTags, nil
+}
+
+func (p *userProject) Milestones() (gitprovider.MilestoneClient, error) {
+	return p.milestones, nil
+}
+
+// Releases returns a ReleaseClient operating on the releases of the project.
+func (p *userProject) Releases() (gitprovider.ReleaseClient, error) {
+	return &ReleaseClient{clientContext: p.clientContext, ref: p.ref}, nil
+}
+
+// Pipelines returns a PipelineClient operating on the CI pipelines of the project.
+func (p *userProject) Pipelines() (gitprovider.PipelineClient, error) {
+	return &PipelineClient{clientContext: p.clientContext, ref: p.ref}, nil
+}
+
+// Workflows is not supported, as GitLab CI runs pipelines rather than dispatching workflows; use
+// Pipelines instead. ErrNoProviderSupport is returned.
+func (p *userProject) Workflows() (gitprovider.WorkflowClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Traffic is not supported by GitLab, ErrNoProviderSupport is returned.
+func (p *userProject) Traffic() (gitprovider.TrafficClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Environments is not supported, as GitLab scopes CI/CD variables to environments instead of
+// attaching secrets to them. ErrNoProviderSupport is returned.
+func (p *userProject) Environments() (gitprovider.EnvironmentClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Collaborators returns a CollaboratorClient operating on the members of the project.
+func (p *userProject) Collaborators() (gitprovider.CollaboratorClient, error) {
+	return &CollaboratorClient{clientContext: p.clientContext, ref: p.ref}, nil
+}
+
+// Topics returns a TopicsClient operating on the topics of the project.
+func (p *userProject) Topics() (gitprovider.TopicsClient, error) {
+	return &TopicsClient{clientContext: p.clientContext, ref: p.ref}, nil
+}
+
 func (p *userProject) Commits() gitprovider.CommitClient {
 	return p.commits
 }
@@ -115,6 +186,10 @@ func (p *userProject) Branches() gitprovider.BranchClient {
 	return p.branches
 }
 
+func (p *userProject) Tags() gitprovider.TagClient {
+	return p.tags
+}
+
 func (p *userProject) PullRequests() gitprovider.PullRequestClient {
 	return p.pullRequests
 }
@@ -207,6 +282,260 @@ func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
 	return r.teamAccess
 }
 
+// License returns the license GitLab detected in the project.
+//
+// ErrNotFound is returned if no license is detected.
+func (r *orgRepository) License(ctx context.Context) (gitprovider.LicenseInfo, error) {
+	apiObj, err := r.c.GetProjectWithLicense(ctx, getRepoPath(r.ref))
+	if err != nil {
+		return gitprovider.LicenseInfo{}, err
+	}
+	if apiObj.License == nil {
+		return gitprovider.LicenseInfo{}, fmt.Errorf("no license detected for project %q: %w", getRepoPath(r.ref), gitprovider.ErrNotFound)
+	}
+	return licenseFromAPI(apiObj), nil
+}
+
+// Language returns the language making up the largest share of the project, as detected by GitLab.
+//
+// ErrNotFound is returned if no language is detected.
+func (r *orgRepository) Language(ctx context.Context) (string, error) {
+	languages, err := r.c.GetProjectLanguages(ctx, getRepoPath(r.ref))
+	if err != nil {
+		return "", err
+	}
+	primary := ""
+	for language, share := range languages {
+		// Break ties by name, to be deterministic
+		if primary == "" || share > languages[primary] || (share == languages[primary] && language < primary) {
+			primary = language
+		}
+	}
+	if primary == "" {
+		return "", fmt.Errorf("no language detected for project %q: %w", getRepoPath(r.ref), gitprovider.ErrNotFound)
+	}
+	return primary, nil
+}
+
+// Permissions returns the effective permission level of the authenticated user on the project,
+// taking both project and group membership into account.
+func (r *orgRepository) Permissions(ctx context.Context) (gitprovider.PermissionLevel, error) {
+	apiObj, err := r.c.GetGroupProject(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		return "", err
+	}
+	return permissionLevelFromAPI(apiObj.Permissions), nil
+}
+
+// CreateBackup writes a git bundle of all refs of the repository to w.
+func (r *orgRepository) CreateBackup(ctx context.Context, w io.Writer) error {
+	return gitprovider.WriteBackupBundle(ctx, w, r.p.HTTPURLToRepo, r.gitTransport)
+}
+
+// Wiki returns a WikiClient operating on the wiki repository through the Git protocol.
+func (r *orgRepository) Wiki() (gitprovider.WikiClient, error) {
+	return gitprovider.NewGitWikiClient(gitprovider.WikiCloneURL(r.p.HTTPURLToRepo), r.gitTransport)
+}
+
+// CustomProperties is not supported by GitLab, ErrNoProviderSupport is returned.
+func (r *orgRepository) CustomProperties() (gitprovider.CustomPropertiesClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// InteractionLimits is not supported by GitLab, ErrNoProviderSupport is returned.
+func (r *orgRepository) InteractionLimits() (gitprovider.InteractionLimitsClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ApprovalSettings returns an ApprovalSettingsClient operating on the merge request approval
+// settings of the project.
+func (r *orgRepository) ApprovalSettings() (gitprovider.ApprovalSettingsClient, error) {
+	return &ApprovalSettingsClient{clientContext: r.clientContext, ref: r.ref}, nil
+}
+
+// Pages is not supported, as GitLab Pages sites are published by a CI job of the project rather
+// than configured through the API. ErrNoProviderSupport is returned.
+func (r *orgRepository) Pages() (gitprovider.PagesClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription sets the notification level of the authenticated user on the project.
+// Watching maps to the "watch" level, ignoring to "disabled", and neither to "global".
+func (r *orgRepository) SetSubscription(ctx context.Context, subscription gitprovider.SubscriptionInfo) error {
+	if err := subscription.ValidateInfo(); err != nil {
+		return err
+	}
+	level := gogitlab.GlobalNotificationLevel
+	switch {
+	case subscription.Subscribed:
+		level = gogitlab.WatchNotificationLevel
+	case subscription.Ignored:
+		level = gogitlab.DisabledNotificationLevel
+	}
+	return r.c.SetProjectNotificationLevel(ctx, getRepoPath(r.ref), level)
+}
+
+// Subscribers is not supported, as GitLab doesn't expose the notification levels of other users.
+// ErrNoProviderSupport is returned.
+func (r *orgRepository) Subscribers(_ context.Context) ([]gitprovider.UserRef, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetAvatar uploads the given image as the avatar of the project.
+func (r *orgRepository) SetAvatar(ctx context.Context, avatar io.Reader) error {
+	data, filename, err := gitprovider.ReadAvatar(avatar)
+	if err != nil {
+		return err
+	}
+	return r.c.UploadProjectAvatar(ctx, getRepoPath(r.ref), bytes.NewReader(data), filename)
+}
+
+// ReconcileMergeQueue is not supported, as GitLab merge trains are configured per project rather
+// than per branch. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileMergeQueue(_ context.Context, _ string, _ gitprovider.MergeQueueInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileBranchMergeMethods is not supported, as the merge method and squash option of GitLab
+// projects apply to all the protected branches alike. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileBranchMergeMethods(_ context.Context, _ string, _ gitprovider.BranchMergeMethodsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileMergeMethods is not supported, as GitLab projects have a single merge method, and a
+// squash option, rather than a set of allowed methods. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileMergeMethods(_ context.Context, _ gitprovider.MergeMethodsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileEnvironmentProtection is not supported, as GitLab protects environments by the access
+// level of the deployers rather than by ref patterns. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileEnvironmentProtection(_ context.Context, _ string, _ gitprovider.EnvironmentProtectionInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// IsDefaultBranchProtected returns whether the default branch of the project is protected, from a
+// single request to its protection. Only protections named after the branch are found, not the
+// wildcard ones.
+func (r *orgRepository) IsDefaultBranchProtected(ctx context.Context) (bool, error) {
+	_, err := r.c.GetProtectedBranch(ctx, getRepoPath(r.ref), r.p.DefaultBranch)
+	if errors.Is(err, gitprovider.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReconcileWorkflowPermissions is not supported, as the permissions of the GitLab CI job token
+// aren't configured as a default level. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileWorkflowPermissions(_ context.Context, _ gitprovider.WorkflowPermissionsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileCodeScanningDefaultSetup is not supported, as GitLab configures its security scanners
+// in the CI configuration of the project. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileCodeScanningDefaultSetup(_ context.Context, _ gitprovider.CodeScanningDefaultSetupInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// Counts returns the number of open merge requests, open issues, branches and tags of the
+// project. The open issues are read from the project object, while the other counts are read
+// from the X-Total header of single-item list requests.
+func (r *orgRepository) Counts(ctx context.Context) (gitprovider.RepoCounts, error) {
+	projectName := getRepoPath(r.ref)
+	apiObj, err := r.c.GetGroupProject(ctx, r.ref.GetIdentity(), r.ref.GetRepository())
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	mergeRequests, err := r.c.CountProjectMergeRequests(ctx, projectName, "opened")
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	branches, err := r.c.CountProjectBranches(ctx, projectName)
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	tags, err := r.c.CountProjectTags(ctx, projectName)
+	if err != nil {
+		return gitprovider.RepoCounts{}, err
+	}
+	return repoCountsFromAPI(apiObj, mergeRequests, branches, tags), nil
+}
+
+// CommitActivity returns the weekly commit activity of the default branch. GitLab has no weekly
+// commit statistics, so the activity is computed from the commits of the last year.
+func (r *orgRepository) CommitActivity(ctx context.Context) ([]gitprovider.WeeklyActivity, error) {
+	now := time.Now()
+	dates, err := r.c.ListCommitDates(ctx, getRepoPath(r.ref), r.p.DefaultBranch, gitprovider.CommitActivitySince(now))
+	if err != nil {
+		return nil, err
+	}
+	return gitprovider.WeeklyActivityFromCommits(now, dates), nil
+}
+
+// SetTemplates commits the given pull request and issue templates to their conventional
+// locations on the default branch, in a single commit.
+func (r *orgRepository) SetTemplates(ctx context.Context, templates gitprovider.TemplatesInfo) (gitprovider.Commit, error) {
+	return gitprovider.CommitTemplates(ctx, r.commits, r.p.DefaultBranch, gitlabTemplateLayout, templates)
+}
+
+// GetTemplates reads the pull request and issue templates from their conventional
+// locations on the default branch.
+func (r *orgRepository) GetTemplates(ctx context.Context) (gitprovider.TemplatesInfo, error) {
+	return gitprovider.ReadTemplates(ctx, r.files, r.p.DefaultBranch, gitlabTemplateLayout)
+}
+
+// DiscoverTemplates returns the paths of the description templates found in the .gitlab
+// directory, at the given ref or on the default branch if ref is empty.
+func (r *orgRepository) DiscoverTemplates(ctx context.Context, ref string) (gitprovider.TemplateLocations, error) {
+	if ref == "" {
+		ref = r.p.DefaultBranch
+	}
+	return gitprovider.DiscoverTemplates(ctx, r.trees, ref, gitlabTemplateConventions)
+}
+
+// SetDependabotConfig is not supported, as GitLab has no Dependabot. ErrNoProviderSupport is returned.
+func (r *orgRepository) SetDependabotConfig(_ context.Context, _ []byte) (gitprovider.Commit, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetDependabotConfig is not supported, as GitLab has no Dependabot. ErrNoProviderSupport is returned.
+func (r *orgRepository) GetDependabotConfig(_ context.Context) ([]byte, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetSecurityPolicy is not supported, as GitLab has no security policy convention.
+// ErrNoProviderSupport is returned.
+func (r *orgRepository) SetSecurityPolicy(_ context.Context, _ []byte) (gitprovider.Commit, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetSecurityPolicy is not supported, as GitLab has no security policy convention.
+// ErrNoProviderSupport is returned.
+func (r *orgRepository) GetSecurityPolicy(_ context.Context) ([]byte, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// gitlabTemplateLayout describes where GitLab expects the templates, see https://docs.gitlab.com/ee/user/project/description_templates.html
+//
+//nolint:gochecknoglobals
+var gitlabTemplateLayout = gitprovider.TemplateLayout{
+	PullRequestTemplate: ".gitlab/merge_request_templates/Default.md",
+	IssueTemplatesDir:   ".gitlab/issue_templates",
+}
+
+// gitlabTemplateConventions describes where GitLab looks for the description templates. Only the
+// .gitlab directory is listed, as GitLab ignores templates elsewhere.
+//
+//nolint:gochecknoglobals
+var gitlabTemplateConventions = gitprovider.TemplateConventions{
+	SearchDir:               ".gitlab",
+	PullRequestTemplateDirs: []string{".gitlab/merge_request_templates"},
+	IssueTemplateDirs:       []string{".gitlab/issue_templates"},
+}
+
 func (r *orgRepository) Commits() gitprovider.CommitClient {
 	return r.commits
 }
@@ -251,15 +580,106 @@ func (r *orgRepository) Reconcile(ctx context.Context) (bool, error) {
 	return true, r.Update(ctx)
 }
 
-func repositoryFromAPI(apiObj *gogitlab.Project) gitprovider.RepositoryInfo {
+func repositoryFromAPI(apiObj *gogitlab.Project, domain string) gitprovider.RepositoryInfo {
 	repo := gitprovider.RepositoryInfo{
 		Description:   &apiObj.Description,
 		DefaultBranch: &apiObj.DefaultBranch,
 	}
 	repo.Visibility = gitprovider.RepositoryVisibilityVar(gitprovider.RepositoryVisibility(apiObj.Visibility))
+	if apiObj.ForkedFromProject != nil {
+		repo.Parent = forkParentRefFromAPI(apiObj.ForkedFromProject, domain)
+	}
 	return repo
 }
 
+// forkParentRefFromAPI returns a reference to the project a fork was created from. GitLab doesn't
+// report whether the parent lives in a user or a group namespace, so the namespace is always
+// mapped to an organization (with its subgroups), which yields the same URLs for both kinds.
+func forkParentRefFromAPI(apiObj *gogitlab.ForkParent, domain string) gitprovider.RepositoryRef {
+	namespace, name := path.Split(apiObj.PathWithNamespace)
+	groups := strings.Split(strings.TrimSuffix(namespace, "/"), "/")
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: domain, Organization: groups[0]},
+		RepositoryName:  name,
+	}
+	if len(groups) > 1 {
+		ref.SubOrganizations = groups[1:]
+	}
+	return ref
+}
+
+// gitlabLicenseSPDXMap maps the license keys reported by GitLab to their SPDX identifiers.
+// Keys not in this map are reported as-is.
+//
+//nolint:gochecknoglobals
+var gitlabLicenseSPDXMap = map[string]string{
+	"agpl-3.0":     "AGPL-3.0",
+	"apache-2.0":   "Apache-2.0",
+	"bsd-2-clause": "BSD-2-Clause",
+	"bsd-3-clause": "BSD-3-Clause",
+	"bsl-1.0":      "BSL-1.0",
+	"cc0-1.0":      "CC0-1.0",
+	"epl-2.0":      "EPL-2.0",
+	"gpl-2.0":      "GPL-2.0",
+	"gpl-3.0":      "GPL-3.0",
+	"isc":          "ISC",
+	"lgpl-2.1":     "LGPL-2.1",
+	"lgpl-3.0":     "LGPL-3.0",
+	"mit":          "MIT",
+	"mpl-2.0":      "MPL-2.0",
+	"unlicense":    "Unlicense",
+}
+
+// repoCountsFromAPI maps the counts of a project. A nil count means GitLab didn't report it. The
+// open issues count of the project object is cached by GitLab, hence approximate.
+func repoCountsFromAPI(apiObj *gogitlab.Project, mergeRequests, branches, tags *int) gitprovider.RepoCounts {
+	return gitprovider.RepoCounts{
+		OpenPullRequests: gitprovider.ItemCount{Count: mergeRequests, Exact: mergeRequests != nil},
+		OpenIssues:       gitprovider.ItemCount{Count: gitprovider.IntVar(apiObj.OpenIssuesCount)},
+		Branches:         gitprovider.ItemCount{Count: branches, Exact: branches != nil},
+		Tags:             gitprovider.ItemCount{Count: tags, Exact: tags != nil},
+	}
+}
+
+func licenseFromAPI(apiObj *gogitlab.Project) gitprovider.LicenseInfo {
+	license := gitprovider.LicenseInfo{
+		Name:   apiObj.License.Name,
+		SPDXID: apiObj.License.Key,
+	}
+	if spdxID, ok := gitlabLicenseSPDXMap[apiObj.License.Key]; ok {
+		license.SPDXID = spdxID
+	}
+	// The license URL is of the form https://<domain>/<group>/<project>/-/blob/<branch>/<path>
+	if _, blobPath, ok := strings.Cut(apiObj.LicenseURL, "/-/blob/"); ok {
+		license.Path = strings.TrimPrefix(blobPath, apiObj.DefaultBranch+"/")
+	}
+	return license
+}
+
+// permissionLevelFromAPI maps the highest of the project and group access levels of the
+// authenticated user to a PermissionLevel. Maintainers are allowed to manage deploy keys
+// and sharing, hence they map to PermissionLevelAdmin.
+func permissionLevelFromAPI(permissions *gogitlab.Permissions) gitprovider.PermissionLevel {
+	accessLevel := gogitlab.NoPermissions
+	if permissions != nil {
+		if permissions.ProjectAccess != nil && permissions.ProjectAccess.AccessLevel > accessLevel {
+			accessLevel = permissions.ProjectAccess.AccessLevel
+		}
+		if permissions.GroupAccess != nil && permissions.GroupAccess.AccessLevel > accessLevel {
+			accessLevel = permissions.GroupAccess.AccessLevel
+		}
+	}
+	switch {
+	case accessLevel >= gogitlab.MaintainerPermissions:
+		return gitprovider.PermissionLevelAdmin
+	case accessLevel >= gogitlab.DeveloperPermissions:
+		return gitprovider.PermissionLevelWrite
+	case accessLevel >= gogitlab.GuestPermissions:
+		return gitprovider.PermissionLevelRead
+	}
+	return gitprovider.PermissionLevelNone
+}
+
 func repositoryToAPI(repo *gitprovider.RepositoryInfo, ref gitprovider.RepositoryRef) gogitlab.Project {
 	apiObj := gogitlab.Project{
 		Name: *gitprovider.StringVar(ref.GetRepository()),
@@ -275,7 +695,7 @@ func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *gogitlab.P
 	if repo.DefaultBranch != nil {
 		apiObj.DefaultBranch = *repo.DefaultBranch
 	}
-	if repo.Visibility != nil {
+	if repo.Visibility != nil && *repo.Visibility != gitprovider.RepositoryVisibilityDefault {
 		apiObj.Visibility = gitlabVisibilityMap[*repo.Visibility]
 	}
 }
@@ -311,3 +731,15 @@ var gitlabVisibilityMap = map[gitprovider.RepositoryVisibility]gogitlab.Visibili
 	gitprovider.RepositoryVisibilityPrivate:  gogitlab.PrivateVisibility,
 	gitprovider.RepositoryVisibilityPublic:   gogitlab.PublicVisibility,
 }
+
+// Rename renames the project. Both the name and the path are changed, as the path is what
+// the project is referenced by.
+func (r *orgRepository) Rename(ctx context.Context, newName string) (gitprovider.OrgRepository, error) {
+	apiObj, err := r.c.RenameProject(ctx, getRepoPath(r.ref), newName)
+	if err != nil {
+		return nil, err
+	}
+	ref := r.ref.(gitprovider.OrgRepositoryRef)
+	ref.RepositoryName = apiObj.Path
+	return newGroupProject(r.clientContext, apiObj, ref), nil
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	gogitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func Test_licenseFromAPI(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiObj *gogitlab.Project
+		want   gitprovider.LicenseInfo
+	}{
+		{
+			name: "known license key",
+			apiObj: &gogitlab.Project{
+				DefaultBranch: "main",
+				LicenseURL:    "https://gitlab.com/group/project/-/blob/main/LICENSE",
+				License: &gogitlab.ProjectLicense{
+					Key:  "apache-2.0",
+					Name: "Apache License 2.0",
+				},
+			},
+			want: gitprovider.LicenseInfo{
+				SPDXID: "Apache-2.0",
+				Name:   "Apache License 2.0",
+				Path:   "LICENSE",
+			},
+		},
+		{
+			name: "unknown license key in a nested path",
+			apiObj: &gogitlab.Project{
+				DefaultBranch: "dev",
+				LicenseURL:    "https://gitlab.com/group/sub/project/-/blob/dev/docs/LICENSE.md",
+				License: &gogitlab.ProjectLicense{
+					Key:  "other",
+					Name: "Other",
+				},
+			},
+			want: gitprovider.LicenseInfo{
+				SPDXID: "other",
+				Name:   "Other",
+				Path:   "docs/LICENSE.md",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := licenseFromAPI(tt.apiObj)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("licenseFromAPI() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_forkParentRefFromAPI(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiObj *gogitlab.ForkParent
+		want   gitprovider.RepositoryRef
+	}{
+		{
+			name:   "top-level namespace",
+			apiObj: &gogitlab.ForkParent{PathWithNamespace: "fluxcd/flux2"},
+			want: gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+				RepositoryName:  "flux2",
+			},
+		},
+		{
+			name:   "subgroup",
+			apiObj: &gogitlab.ForkParent{PathWithNamespace: "fluxcd/dev/team/flux2"},
+			want: gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{
+					Domain:           "gitlab.com",
+					Organization:     "fluxcd",
+					SubOrganizations: []string{"dev", "team"},
+				},
+				RepositoryName: "flux2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := forkParentRefFromAPI(tt.apiObj, "gitlab.com"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("forkParentRefFromAPI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_permissionLevelFromAPI(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions *gogitlab.Permissions
+		want        gitprovider.PermissionLevel
+	}{
+		{
+			name: "no permissions",
+			want: gitprovider.PermissionLevelNone,
+		},
+		{
+			name: "project developer",
+			permissions: &gogitlab.Permissions{
+				ProjectAccess: &gogitlab.ProjectAccess{AccessLevel: gogitlab.DeveloperPermissions},
+			},
+			want: gitprovider.PermissionLevelWrite,
+		},
+		{
+			name: "group maintainer overrides project reporter",
+			permissions: &gogitlab.Permissions{
+				ProjectAccess: &gogitlab.ProjectAccess{AccessLevel: gogitlab.ReporterPermissions},
+				GroupAccess:   &gogitlab.GroupAccess{AccessLevel: gogitlab.MaintainerPermissions},
+			},
+			want: gitprovider.PermissionLevelAdmin,
+		},
+		{
+			name: "group guest",
+			permissions: &gogitlab.Permissions{
+				GroupAccess: &gogitlab.GroupAccess{AccessLevel: gogitlab.GuestPermissions},
+			},
+			want: gitprovider.PermissionLevelRead,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := permissionLevelFromAPI(tt.permissions); got != tt.want {
+				t.Errorf("permissionLevelFromAPI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_SetSubscription(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription gitprovider.SubscriptionInfo
+		wantLevel    string
+	}{
+		{
+			name:         "watch",
+			subscription: gitprovider.SubscriptionInfo{Subscribed: true},
+			wantLevel:    "watch",
+		},
+		{
+			name:         "ignore",
+			subscription: gitprovider.SubscriptionInfo{Ignored: true},
+			wantLevel:    "disabled",
+		},
+		{
+			name:      "reset",
+			wantLevel: "global",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, c := setup(t)
+			var payload map[string]interface{}
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/notification_settings", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					t.Errorf("method = %s, want %s", r.Method, http.MethodPut)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				fmt.Fprintf(w, `{"level": %q}`, tt.wantLevel)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newGroupProject(&clientContext{c: c, domain: "gitlab.com"}, &gogitlab.Project{Name: "repo"}, ref)
+			if err := repo.SetSubscription(context.Background(), tt.subscription); err != nil {
+				t.Fatalf("SetSubscription() error = %v", err)
+			}
+			if diff := cmp.Diff(map[string]interface{}{"level": tt.wantLevel}, payload); diff != "" {
+				t.Errorf("payload (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_SetAvatar(t *testing.T) {
+	var avatar bytes.Buffer
+	if err := png.Encode(&avatar, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want %s", r.Method, http.MethodPut)
+		}
+		f, header, err := r.FormFile("avatar")
+		if err != nil {
+			t.Fatalf("failed to read the avatar part of the multipart body: %v", err)
+		}
+		defer f.Close()
+		if header.Filename != "avatar.png" {
+			t.Errorf("filename = %q, want %q", header.Filename, "avatar.png")
+		}
+		got, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, avatar.Bytes()) {
+			t.Error("uploaded avatar doesn't match the image")
+		}
+		fmt.Fprint(w, `{"id": 1, "name": "repo"}`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newGroupProject(&clientContext{c: c, domain: "gitlab.com"}, &gogitlab.Project{Name: "repo"}, ref)
+	if err := repo.SetAvatar(context.Background(), bytes.NewReader(avatar.Bytes())); err != nil {
+		t.Fatalf("SetAvatar() error = %v", err)
+	}
+}
+
+func TestOrgRepository_Counts(t *testing.T) {
+	mux, c := setup(t)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "repo", "open_issues_count": 4}`)
+	})
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != "opened" {
+			t.Errorf("state = %q, want %q", got, "opened")
+		}
+		w.Header().Set("X-Total", "3")
+		fmt.Fprint(w, `[{"iid": 1}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/branches", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total", "2")
+		fmt.Fprint(w, `[{"name": "main"}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/tags", func(w http.ResponseWriter, r *http.Request) {
+		// GitLab omits X-Total for large lists
+		fmt.Fprint(w, `[{"name": "v1.0.0"}]`)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newGroupProject(&clientContext{c: c, domain: "gitlab.com"}, &gogitlab.Project{Name: "repo"}, ref)
+	got, err := repo.Counts(context.Background())
+	if err != nil {
+		t.Fatalf("Counts() error = %v", err)
+	}
+	want := gitprovider.RepoCounts{
+		OpenPullRequests: gitprovider.ItemCount{Count: gitprovider.IntVar(3), Exact: true},
+		OpenIssues:       gitprovider.ItemCount{Count: gitprovider.IntVar(4)},
+		Branches:         gitprovider.ItemCount{Count: gitprovider.IntVar(2), Exact: true},
+		Tags:             gitprovider.ItemCount{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Counts() (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrgRepository_CommitActivity(t *testing.T) {
+	mux, c := setup(t)
+	committed := time.Now().UTC().Format(time.RFC3339)
+	mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ref_name"); got != "main" {
+			t.Errorf("ref_name = %q, want %q", got, "main")
+		}
+		if r.URL.Query().Get("since") == "" {
+			t.Error("since isn't set")
+		}
+		fmt.Fprintf(w, `[{"id": "a1", "committed_date": %q}, {"id": "b2", "committed_date": %q}]`, committed, committed)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+		RepositoryName:  "repo",
+	}
+	repo := newGroupProject(&clientContext{c: c, domain: "gitlab.com"}, &gogitlab.Project{Name: "repo", DefaultBranch: "main"}, ref)
+	got, err := repo.CommitActivity(context.Background())
+	if err != nil {
+		t.Fatalf("CommitActivity() error = %v", err)
+	}
+	if len(got) != gitprovider.CommitActivityWeeks {
+		t.Fatalf("CommitActivity() returned %d weeks, want %d", len(got), gitprovider.CommitActivityWeeks)
+	}
+	if total := got[len(got)-1].Total; total != 2 {
+		t.Errorf("CommitActivity() current week total = %d, want 2", total)
+	}
+}
+
+func TestOrgRepository_IsDefaultBranchProtected(t *testing.T) {
+	for _, protected := range []bool{true, false} {
+		t.Run(fmt.Sprintf("protected=%t", protected), func(t *testing.T) {
+			mux, c := setup(t)
+			mux.HandleFunc("/api/v4/projects/fluxcd%2Frepo/protected_branches/main", func(w http.ResponseWriter, r *http.Request) {
+				if !protected {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message": "404 Not found"}`)
+					return
+				}
+				fmt.Fprint(w, `{"id": 1, "name": "main"}`)
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "gitlab.com", Organization: "fluxcd"},
+				RepositoryName:  "repo",
+			}
+			repo := newGroupProject(&clientContext{c: c, domain: "gitlab.com"}, &gogitlab.Project{Name: "repo", DefaultBranch: "main"}, ref)
+			got, err := repo.IsDefaultBranchProtected(context.Background())
+			if err != nil {
+				t.Fatalf("IsDefaultBranchProtected() error = %v", err)
+			}
+			if got != protected {
+				t.Errorf("IsDefaultBranchProtected() = %t, want %t", got, protected)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 const (
 	alreadyExistsMagicString = "name: [has already been taken]"
 	alreadySharedWithGroup   = "already shared with this group"
+	tagExistsMagicString     = "already exists"
 	defaultBranchName        = "main"
 )
 
@@ -25,10 +27,233 @@ func getRepoPath(ref gitprovider.RepositoryRef) string {
 // allPages runs fn for each page, expecting a HTTP request to be made and returned during that call.
 // allPages expects that the data is saved in fn to an outer variable.
 // allPages calls fn as many times as needed to get all pages, and modifies opts for each call.
+// Each call gets a context bounded by the page timeout of the client, if any, and the error of ctx
+// is returned without fetching further pages once ctx is done.
 // There is no need to wrap the resulting error in handleHTTPError(err), as that's already done.
-func allGroupPages(opts *gitlab.ListGroupsOptions, fn func() (*gitlab.Response, error)) error {
+func allGroupPages(ctx context.Context, opts *gitlab.ListGroupsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allSubgroupPages(ctx context.Context, opts *gitlab.ListSubGroupsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allGroupHookPages(ctx context.Context, opts *gitlab.ListGroupHooksOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProjectHookPages(ctx context.Context, opts *gitlab.ListProjectHooksOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allGroupProjectPages(ctx context.Context, opts *gitlab.ListGroupProjectsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allGroupMemberPages(ctx context.Context, opts *gitlab.ListGroupMembersOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProjectPages(ctx context.Context, opts *gitlab.ListProjectsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProjectUserPages(ctx context.Context, opts *gitlab.ListProjectUserOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allDeployKeyPages(ctx context.Context, opts *gitlab.ListProjectDeployKeysOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allDeployTokenPages(ctx context.Context, opts *gitlab.ListProjectDeployTokensOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allProtectedTagPages(ctx context.Context, opts *gitlab.ListProtectedTagsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allMilestonePages(ctx context.Context, opts *gitlab.ListMilestonesOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allMergeRequestDiffPages(ctx context.Context, opts *gitlab.ListMergeRequestDiffsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
 		if err != nil {
 			return handleHTTPError(err)
 		}
@@ -39,12 +264,17 @@ func allGroupPages(opts *gitlab.ListGroupsOptions, fn func() (*gitlab.Response,
 	}
 }
 
-func allSubgroupPages(opts *gitlab.ListSubGroupsOptions, fn func() (*gitlab.Response, error)) error {
+func allMergeRequestCommitPages(ctx context.Context, opts *gitlab.GetMergeRequestCommitsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
 		if err != nil {
 			return err
 		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
 		if resp.NextPage == 0 {
 			return nil
 		}
@@ -52,12 +282,17 @@ func allSubgroupPages(opts *gitlab.ListSubGroupsOptions, fn func() (*gitlab.Resp
 	}
 }
 
-func allGroupProjectPages(opts *gitlab.ListGroupProjectsOptions, fn func() (*gitlab.Response, error)) error {
+func allMergeRequestNotePages(ctx context.Context, opts *gitlab.ListMergeRequestNotesOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
 		if err != nil {
 			return err
 		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
 		if resp.NextPage == 0 {
 			return nil
 		}
@@ -65,12 +300,35 @@ func allGroupProjectPages(opts *gitlab.ListGroupProjectsOptions, fn func() (*git
 	}
 }
 
-func allGroupMemberPages(opts *gitlab.ListGroupMembersOptions, fn func() (*gitlab.Response, error)) error {
+func allTagPages(ctx context.Context, opts *gitlab.ListTagsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
 		if err != nil {
 			return err
 		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allReleasePages(ctx context.Context, opts *gitlab.ListReleasesOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
 		if resp.NextPage == 0 {
 			return nil
 		}
@@ -78,12 +336,17 @@ func allGroupMemberPages(opts *gitlab.ListGroupMembersOptions, fn func() (*gitla
 	}
 }
 
-func allProjectPages(opts *gitlab.ListProjectsOptions, fn func() (*gitlab.Response, error)) error {
+func allAuditEventPages(ctx context.Context, opts *gitlab.ListAuditEventsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
 		if err != nil {
 			return err
 		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
 		if resp.NextPage == 0 {
 			return nil
 		}
@@ -91,12 +354,17 @@ func allProjectPages(opts *gitlab.ListProjectsOptions, fn func() (*gitlab.Respon
 	}
 }
 
-func allProjectUserPages(opts *gitlab.ListProjectUserOptions, fn func() (*gitlab.Response, error)) error {
+func allProjectMemberPages(ctx context.Context, opts *gitlab.ListProjectMembersOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
 		if err != nil {
 			return err
 		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
 		if resp.NextPage == 0 {
 			return nil
 		}
@@ -104,12 +372,17 @@ func allProjectUserPages(opts *gitlab.ListProjectUserOptions, fn func() (*gitlab
 	}
 }
 
-func allDeployKeyPages(opts *gitlab.ListProjectDeployKeysOptions, fn func() (*gitlab.Response, error)) error {
+func allCommitRefPages(ctx context.Context, opts *gitlab.GetCommitRefsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
 		if err != nil {
 			return err
 		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
 		if resp.NextPage == 0 {
 			return nil
 		}
@@ -117,12 +390,17 @@ func allDeployKeyPages(opts *gitlab.ListProjectDeployKeysOptions, fn func() (*gi
 	}
 }
 
-func allDeployTokenPages(opts *gitlab.ListProjectDeployTokensOptions, fn func() (*gitlab.Response, error)) error {
+func allCommitPages(ctx context.Context, opts *gitlab.ListCommitsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
 		if err != nil {
 			return err
 		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
 		if resp.NextPage == 0 {
 			return nil
 		}
@@ -130,6 +408,56 @@ func allDeployTokenPages(opts *gitlab.ListProjectDeployTokensOptions, fn func()
 	}
 }
 
+func allStatusCheckPages(ctx context.Context, opts *gitlab.ListOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func allJobPages(ctx context.Context, opts *gitlab.ListJobsOptions, fn func(ctx context.Context) (*gitlab.Response, error)) error {
+	for {
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
+		if err != nil {
+			return handleHTTPError(err)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// countItems counts the items of a paginated list without fetching them all, by reading the
+// X-Total header of a single-item page. GitLab omits the header for large lists, in which case
+// nil is returned.
+func countItems(fn func(opts gitlab.ListOptions) (*gitlab.Response, error)) (*int, error) {
+	resp, err := fn(gitlab.ListOptions{PerPage: 1})
+	if err != nil {
+		return nil, handleHTTPError(err)
+	}
+	if resp.Header.Get("X-Total") == "" {
+		return nil, nil
+	}
+	return gitprovider.IntVar(resp.TotalItems), nil
+}
+
 // validateUserRepositoryRef makes sure the UserRepositoryRef is valid for GitLab's usage.
 func validateUserRepositoryRef(ref gitprovider.UserRepositoryRef, expectedDomain string) error {
 	// Make sure the RepositoryRef fields are valid
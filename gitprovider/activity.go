@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import "time"
+
+// CommitActivityWeeks is the number of weeks covered by the commit activity of a repository.
+const CommitActivityWeeks = 52
+
+// CommitActivityMaxCommits bounds the number of commits listed to compute the commit activity, for
+// providers without commit statistics. The commits are listed in topological order rather than by
+// date, hence an older commit doesn't end the listing: the commits of a long-lived branch merged
+// recently may come after it.
+const CommitActivityMaxCommits = 10000
+
+// CommitActivitySince returns the start of the oldest week of the commit activity ending with the
+// week of now. Weeks start on Sunday at midnight UTC.
+func CommitActivitySince(now time.Time) time.Time {
+	now = now.UTC()
+	week := time.Date(now.Year(), now.Month(), now.Day()-int(now.Weekday()), 0, 0, 0, 0, time.UTC)
+	return week.AddDate(0, 0, -7*(CommitActivityWeeks-1))
+}
+
+// WeeklyActivityFromCommits computes the commit activity ending with the week of now from the
+// dates of the commits, for providers without commit statistics. Dates outside of the covered
+// weeks are ignored.
+func WeeklyActivityFromCommits(now time.Time, dates []time.Time) []WeeklyActivity {
+	since := CommitActivitySince(now)
+	activity := make([]WeeklyActivity, CommitActivityWeeks)
+	for i := range activity {
+		activity[i].Week = since.AddDate(0, 0, 7*i)
+	}
+	for _, date := range dates {
+		day := int(date.Sub(since) / (24 * time.Hour))
+		if date.Before(since) || day >= 7*CommitActivityWeeks {
+			continue
+		}
+		activity[day/7].Total++
+		activity[day/7].Days[day%7]++
+	}
+	return activity
+}
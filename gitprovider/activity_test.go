@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeeklyActivityFromCommits(t *testing.T) {
+	// Wednesday
+	now := time.Date(2023, 6, 7, 15, 0, 0, 0, time.UTC)
+	dates := []time.Time{
+		time.Date(2023, 6, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 7, 9, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 7, 10, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 3, 23, 59, 0, 0, time.UTC),
+		// Before the oldest week
+		time.Date(2022, 6, 11, 23, 59, 0, 0, time.UTC),
+	}
+
+	activity := WeeklyActivityFromCommits(now, dates)
+	if len(activity) != CommitActivityWeeks {
+		t.Fatalf("WeeklyActivityFromCommits() returned %d weeks, want %d", len(activity), CommitActivityWeeks)
+	}
+	if want := time.Date(2022, 6, 12, 0, 0, 0, 0, time.UTC); !activity[0].Week.Equal(want) {
+		t.Errorf("oldest week = %v, want %v", activity[0].Week, want)
+	}
+	current := activity[CommitActivityWeeks-1]
+	if want := time.Date(2023, 6, 4, 0, 0, 0, 0, time.UTC); !current.Week.Equal(want) {
+		t.Errorf("current week = %v, want %v", current.Week, want)
+	}
+	if want := [7]int{1, 0, 0, 2, 0, 0, 0}; current.Total != 3 || current.Days != want {
+		t.Errorf("current week = %+v, want a total of 3 and days %v", current, want)
+	}
+	previous := activity[CommitActivityWeeks-2]
+	if want := [7]int{0, 0, 0, 0, 0, 0, 1}; previous.Total != 1 || previous.Days != want {
+		t.Errorf("previous week = %+v, want a total of 1 and days %v", previous, want)
+	}
+	total := 0
+	for _, week := range activity {
+		total += week.Total
+	}
+	if total != 4 {
+		t.Errorf("total = %d, want 4", total)
+	}
+}
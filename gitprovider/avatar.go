@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// avatarExtensions maps the content types of the images accepted as avatars to the file
+// extension providers derive the image type from.
+//
+//nolint:gochecknoglobals
+var avatarExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ReadAvatar reads an avatar image from r, and detects its content type. It returns the image
+// and a file name with the extension matching its content type, e.g. "avatar.png".
+//
+// ErrInvalidArgument is returned if the content isn't a PNG, JPEG, GIF or WebP image.
+func ReadAvatar(r io.Reader) (data []byte, filename string, err error) {
+	data, err = io.ReadAll(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read the avatar: %w", err)
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := avatarExtensions[contentType]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported avatar content type %q: %w", contentType, ErrInvalidArgument)
+	}
+	return data, "avatar" + ext, nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestReadAvatar(t *testing.T) {
+	var pngImage bytes.Buffer
+	if err := png.Encode(&pngImage, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+
+	data, filename, err := ReadAvatar(bytes.NewReader(pngImage.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadAvatar() error = %v", err)
+	}
+	if filename != "avatar.png" {
+		t.Errorf("ReadAvatar() filename = %q, want %q", filename, "avatar.png")
+	}
+	if !bytes.Equal(data, pngImage.Bytes()) {
+		t.Error("ReadAvatar() didn't return the image as read")
+	}
+
+	if _, _, err := ReadAvatar(strings.NewReader("not an image")); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("ReadAvatar() error = %v, want %v", err, ErrInvalidArgument)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/sideband"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// bundleSignature is the first line of a version 2 git bundle.
+const bundleSignature = "# v2 git bundle\n"
+
+// GitTransportOptions specifies how to talk to the Git endpoints of a provider,
+// e.g. to fetch a repository over HTTPS.
+type GitTransportOptions struct {
+	// HTTPClient is the client used for the requests.
+	// Default: nil, which means a client using http.DefaultTransport.
+	HTTPClient *http.Client
+
+	// Auth is the authentication added to the requests, in case it isn't
+	// already handled by the transport of HTTPClient.
+	// Default: nil.
+	Auth transport.AuthMethod
+
+	// CABundle is the CA bundle used to verify the TLS certificate of the server.
+	// Default: nil, which means the system CA bundle.
+	CABundle []byte
+}
+
+// WriteBackupBundle fetches all refs of the repository at cloneURL, and writes them to w as a git
+// bundle, like "git bundle create --all" of a mirror clone would. As the whole history of every
+// branch, tag and provider-specific ref (e.g. pull request heads) is fetched, the bundle may be large.
+// The bundle is streamed to w as it is fetched, without storing the repository locally.
+func WriteBackupBundle(ctx context.Context, w io.Writer, cloneURL string, opts GitTransportOptions) error {
+	t, ep, err := opts.newEndpoint(cloneURL)
+	if err != nil {
+		return err
+	}
+	return writeBundle(ctx, w, t, ep, opts.Auth)
+}
+
+// newEndpoint parses cloneURL, and returns the transport to use for talking to it.
+func (opts GitTransportOptions) newEndpoint(cloneURL string) (transport.Transport, *transport.Endpoint, error) {
+	ep, err := transport.NewEndpoint(cloneURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid clone URL %q: %w", cloneURL, err)
+	}
+	ep.CaBundle = opts.CABundle
+	return githttp.NewClient(opts.HTTPClient), ep, nil
+}
+
+// writeBundle writes all refs of the repository at ep to w as a git bundle, fetching them using t.
+func writeBundle(ctx context.Context, w io.Writer, t transport.Transport, ep *transport.Endpoint, auth transport.AuthMethod) (err error) {
+	sess, err := t.NewUploadPackSession(ep, auth)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", ep.String(), err)
+	}
+	defer closeAndKeepError(sess, &err)
+
+	ar, err := sess.AdvertisedReferencesContext(ctx)
+	if err != nil {
+		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+			return fmt.Errorf("cannot create a bundle of an empty repository: %w", ErrInvalidArgument)
+		}
+		return fmt.Errorf("failed to list the refs of %s: %w", ep.String(), err)
+	}
+
+	// Collect the refs to list in the bundle header, and the unique commits to fetch
+	refs := make(map[string]plumbing.Hash, len(ar.References)+1)
+	for name, hash := range ar.References {
+		refs[name] = hash
+	}
+	if ar.Head != nil {
+		refs[plumbing.HEAD.String()] = *ar.Head
+	}
+	if len(refs) == 0 {
+		return fmt.Errorf("cannot create a bundle of an empty repository: %w", ErrInvalidArgument)
+	}
+	names := make([]string, 0, len(refs))
+	wanted := map[plumbing.Hash]struct{}{}
+	req := packp.NewUploadPackRequestFromCapabilities(ar.Capabilities)
+	for name, hash := range refs {
+		names = append(names, name)
+		if _, ok := wanted[hash]; !ok {
+			wanted[hash] = struct{}{}
+			req.Wants = append(req.Wants, hash)
+		}
+	}
+	sort.Strings(names)
+	if ar.Capabilities.Supports(capability.NoProgress) {
+		if err := req.Capabilities.Set(capability.NoProgress); err != nil {
+			return err
+		}
+	}
+
+	res, err := sess.UploadPack(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", ep.String(), err)
+	}
+	defer closeAndKeepError(res, &err)
+
+	// Write the bundle header, followed by the packfile
+	if _, err := io.WriteString(w, bundleSignature); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, err := fmt.Fprintf(w, "%s %s\n", refs[name], name); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, packfileReader(req, res)); err != nil {
+		return fmt.Errorf("failed to write the packfile of %s: %w", ep.String(), err)
+	}
+	return nil
+}
+
+// packfileReader returns a reader of the packfile in res, demultiplexing it if req
+// requested a side-band.
+func packfileReader(req *packp.UploadPackRequest, res io.Reader) io.Reader {
+	switch {
+	case req.Capabilities.Supports(capability.Sideband64k):
+		return sideband.NewDemuxer(sideband.Sideband64k, res)
+	case req.Capabilities.Supports(capability.Sideband):
+		return sideband.NewDemuxer(sideband.Sideband, res)
+	default:
+		return res
+	}
+}
+
+// closeAndKeepError closes c, and sets *err to the resulting error unless it is already set.
+func closeAndKeepError(c io.Closer, err *error) {
+	if cerr := c.Close(); cerr != nil && *err == nil {
+		*err = cerr
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// newFakeRepository returns an in-memory repository with a commit on the main and feature
+// branches, and a tag.
+func newFakeRepository(t *testing.T) *git.Repository {
+	t.Helper()
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))); err != nil {
+		t.Fatal(err)
+	}
+	wt, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := func(name, content string) plumbing.Hash {
+		f, err := wt.Filesystem.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		if _, err := wt.Add(name); err != nil {
+			t.Fatal(err)
+		}
+		hash, err := wt.Commit("Add "+name, &git.CommitOptions{
+			Author: &object.Signature{Name: "Flux", Email: "flux@example.com", When: time.Unix(1672531200, 0)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+
+	main := commit("README.md", "# Fake\n")
+	if _, err := r.CreateTag("v1.0.0", main, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}); err != nil {
+		t.Fatal(err)
+	}
+	commit("feature.txt", "feature\n")
+	return r
+}
+
+func TestWriteBundle(t *testing.T) {
+	repo := newFakeRepository(t)
+	ep, err := transport.NewEndpoint("https://example.com/fluxcd/repo.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	tr := server.NewClient(server.MapLoader{ep.String(): repo.Storer})
+	if err := writeBundle(context.Background(), &buf, tr, ep, nil); err != nil {
+		t.Fatalf("writeBundle() error = %v", err)
+	}
+
+	// Read the header and check it lists all refs of the repository
+	br := bufio.NewReader(bytes.NewReader(buf.Bytes()))
+	signature, err := br.ReadString('\n')
+	if err != nil || signature != bundleSignature {
+		t.Fatalf("bundle signature = %q, %v, want %q", signature, err, bundleSignature)
+	}
+	gotRefs := map[string]string{}
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read bundle header: %v", err)
+		}
+		if line == "\n" {
+			break
+		}
+		hash, name, _ := strings.Cut(strings.TrimSuffix(line, "\n"), " ")
+		gotRefs[name] = hash
+	}
+	wantRefs := map[string]string{}
+	for _, name := range []string{"HEAD", "refs/heads/main", "refs/heads/feature", "refs/tags/v1.0.0"} {
+		ref, err := repo.Reference(plumbing.ReferenceName(name), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantRefs[name] = ref.Hash().String()
+	}
+	if !reflect.DeepEqual(gotRefs, wantRefs) {
+		t.Errorf("bundle refs = %v, want %v", gotRefs, wantRefs)
+	}
+
+	// The rest of the bundle must be a packfile containing the referenced commits
+	pack, err := io.ReadAll(br)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := memory.NewStorage()
+	if err := packfile.UpdateObjectStorage(s, bytes.NewReader(pack)); err != nil {
+		t.Fatalf("bundle packfile is invalid: %v", err)
+	}
+	for name, hash := range gotRefs {
+		if _, err := object.GetCommit(s, plumbing.NewHash(hash)); err != nil {
+			t.Errorf("commit of %s missing from bundle: %v", name, err)
+		}
+	}
+
+	// Let git verify the bundle too, if available
+	gitBin, err := exec.LookPath("git")
+	if err != nil {
+		return
+	}
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "repo.bundle")
+	if err := os.WriteFile(bundle, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command(gitBin, "clone", "--mirror", bundle, filepath.Join(dir, "clone")).CombinedOutput(); err != nil {
+		t.Errorf("git clone of bundle failed: %v: %s", err, out)
+	}
+}
+
+func TestWriteBundle_EmptyRepository(t *testing.T) {
+	ep, err := transport.NewEndpoint("https://example.com/fluxcd/empty.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := server.NewClient(server.MapLoader{ep.String(): memory.NewStorage()})
+	if err := writeBundle(context.Background(), io.Discard, tr, ep, nil); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("writeBundle() error = %v, want %v", err, ErrInvalidArgument)
+	}
+}
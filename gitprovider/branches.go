@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBranchesContainingConcurrency is the number of branches BranchesContaining checks
+// at once, kept as low as for tags and files.
+const defaultBranchesContainingConcurrency = 4
+
+// BranchesContaining calls contains for each of the given branches, with at most 4 calls in
+// flight, and returns the branches it reported true for, in the order of branches. It's used by
+// providers without an endpoint listing the branches containing a commit, which compare the
+// commit with the head of each branch instead.
+//
+// The first error cancels the context passed to the other calls, and is returned.
+func BranchesContaining(ctx context.Context, branches []string, contains func(ctx context.Context, branch string) (bool, error)) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found := make([]bool, len(branches))
+	sem := make(chan struct{}, defaultBranchesContainingConcurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for i := range branches {
+		// Don't check any more branches once an error occurred or ctx is done
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			ok, err := contains(ctx, branches[i])
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			found[i] = ok
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for i, branch := range branches {
+		if found[i] {
+			result = append(result, branch)
+		}
+	}
+	return result, nil
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package gitprovider
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // Client is an interface that allows talking to a Git provider.
 type Client interface {
@@ -37,6 +40,20 @@ type Client interface {
 	// permission. Permissions should be coarse-grained and applicable to *all* providers.
 	HasTokenPermission(ctx context.Context, permission TokenPermission) (bool, error)
 
+	// TokenScopes returns the raw scopes granted to the supplied token, as named by the provider.
+	// It's informational, e.g. to diagnose insufficient permission errors.
+	// Returns "ErrNoProviderSupport" if the provider can't introspect the token.
+	TokenScopes(ctx context.Context) ([]string, error)
+
+	// Repo gets the repository with the given name, owned by the organization or user given
+	// through WithDefaultOwner. It's a shorthand for OrgRepositories().Get or UserRepositories().Get.
+	// ErrNoDefaultOwner is returned if no default owner is configured.
+	Repo(ctx context.Context, name string) (UserRepository, error)
+
+	// SupportedWebhookEvents returns the webhook events supported by the provider, mapped to the
+	// provider-neutral WebhookEvent enum and sorted. The catalog is static per provider.
+	SupportedWebhookEvents() []WebhookEvent
+
 	// Raw returns the Go client used under the hood to access the Git provider.
 	Raw() interface{}
 }
@@ -70,6 +87,13 @@ type OrganizationsClient interface {
 	// List returns all available organizations, using multiple paginated requests if needed.
 	List(ctx context.Context) ([]Organization, error)
 
+	// ListWithRole lists the top-level organizations where the authenticated user has at least
+	// the given role, e.g. OrganizationRoleAdmin also matches the organizations the user owns.
+	//
+	// ListWithRole returns all matching organizations, using multiple paginated requests if needed.
+	// Returns "ErrNoProviderSupport" if the provider can't report the given role.
+	ListWithRole(ctx context.Context, role OrganizationRole) ([]Organization, error)
+
 	// Children returns the immediate child-organizations for the specific OrganizationRef o.
 	// The OrganizationRef may point to any existing sub-organization.
 	//
@@ -81,6 +105,65 @@ type OrganizationsClient interface {
 	// Possibly add Create/Update/Delete methods later
 }
 
+// OrganizationSettingsClient operates on the org-wide defaults of a specific organization.
+// This client can be accessed through Organization.Settings().
+type OrganizationSettingsClient interface {
+	// Get returns the org-wide defaults of the organization.
+	// The fields the provider doesn't support are left nil.
+	Get(ctx context.Context) (OrganizationSettingsInfo, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// Only the fields set in req are reconciled, the others are left as-is.
+	//
+	// ErrNoProviderSupport is returned if a field set in req isn't supported by the provider,
+	// in which case no changes are made.
+	Reconcile(ctx context.Context, req OrganizationSettingsInfo) (actionTaken bool, err error)
+}
+
+// OrganizationWebhookClient operates on the webhooks of a specific organization.
+// This client can be accessed through Organization.Webhooks().
+type OrganizationWebhookClient interface {
+	// Get a webhook by its URL.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, url string) (OrganizationWebhook, error)
+
+	// List all webhooks of the organization.
+	//
+	// List returns all available webhooks, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]OrganizationWebhook, error)
+
+	// Create a webhook with the given specifications.
+	// ErrNoProviderSupport is returned if the provider can't be triggered by one of the events.
+	//
+	// ErrAlreadyExists will be returned if a webhook with the same URL already exists.
+	Create(ctx context.Context, req OrganizationWebhookInfo) (OrganizationWebhook, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// The webhook is looked up by its URL.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req OrganizationWebhookInfo) (resp OrganizationWebhook, actionTaken bool, err error)
+
+	// SetActive pauses or resumes the delivery of events by the webhook with the given URL,
+	// keeping its configuration.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	// Returns "ErrNoProviderSupport" if the provider's webhooks can't be paused.
+	SetActive(ctx context.Context, url string, active bool) error
+}
+
+// AuditLogClient reads the audit log of a specific organization.
+// This client can be accessed through Organization.AuditLog().
+type AuditLogClient interface {
+	// List lists the entries of the audit log passing the filters of opts, newest first.
+	//
+	// List returns all matching entries, using multiple paginated requests if needed.
+	List(ctx context.Context, opts AuditLogOptions) ([]AuditLogEntry, error)
+}
+
 // OrgRepositoriesClient operates on repositories for organizations.
 type OrgRepositoriesClient interface {
 	// Get returns the repository for the given reference.
@@ -93,6 +176,13 @@ type OrgRepositoriesClient interface {
 	// List returns all available repositories, using multiple paginated requests if needed.
 	List(ctx context.Context, o OrganizationRef) ([]OrgRepository, error)
 
+	// Iterate calls fn for each repository in the given organization. The pages are fetched
+	// lazily, so only one page of repositories is held in memory at a time.
+	//
+	// Iterate stops at the first error returned by fn, or once ctx is done, and returns that
+	// error as-is. To stop early on purpose, return a sentinel error from fn and check for it.
+	Iterate(ctx context.Context, o OrganizationRef, fn func(OrgRepository) error) error
+
 	// Create creates a repository for the given organization, with the data and options.
 	//
 	// ErrAlreadyExists will be returned if the resource already exists.
@@ -103,6 +193,8 @@ type OrgRepositoriesClient interface {
 	// If req doesn't exist under the hood, it is created (actionTaken == true).
 	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
 	// If req is already the actual state, this is a no-op (actionTaken == false).
+	// If RepositoryReconcileOptions.Expected is set and the actual state doesn't match it anymore,
+	// ErrPreconditionFailed is returned. This check is best-effort, see RepositoryReconcileOptions.
 	Reconcile(ctx context.Context, r OrgRepositoryRef, req RepositoryInfo, opts ...RepositoryReconcileOption) (resp OrgRepository, actionTaken bool, err error)
 }
 
@@ -131,6 +223,8 @@ type UserRepositoriesClient interface {
 	// If req doesn't exist under the hood, it is created (actionTaken == true).
 	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
 	// If req is already the actual state, this is a no-op (actionTaken == false).
+	// If RepositoryReconcileOptions.Expected is set and the actual state doesn't match it anymore,
+	// ErrPreconditionFailed is returned. This check is best-effort, see RepositoryReconcileOptions.
 	Reconcile(ctx context.Context, r UserRepositoryRef, req RepositoryInfo, opts ...RepositoryReconcileOption) (resp UserRepository, actionTaken bool, err error)
 }
 
@@ -181,6 +275,12 @@ type TeamAccessClient interface {
 	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
 	// If req is already the actual state, this is a no-op (actionTaken == false).
 	Reconcile(ctx context.Context, req TeamAccessInfo) (resp TeamAccess, actionTaken bool, err error)
+
+	// ReconcileAll makes sure exactly the given teams (reqs) have access to the repository, with the
+	// given permissions, listing the actual team access only once. The access of the teams not in
+	// reqs is only removed if prune is true, which requires destructive API calls to be enabled.
+	// See ReconcileAllTeamAccess for the returned per-team results.
+	ReconcileAll(ctx context.Context, reqs []TeamAccessInfo, prune bool) ([]TeamAccessBatchResult, error)
 }
 
 // DeployKeyClient operates on the access credential list for a specific repository.
@@ -208,6 +308,38 @@ type DeployKeyClient interface {
 	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
 	// If req is already the actual state, this is a no-op (actionTaken == false).
 	Reconcile(ctx context.Context, req DeployKeyInfo) (resp DeployKey, actionTaken bool, err error)
+
+	// ReconcileBatch makes sure all the given desired deploy keys (reqs) become the actual state in the
+	// backing Git provider, listing the existing deploy keys only once. See ReconcileDeployKeys for the
+	// deduplication and ordering of the returned per-key results.
+	ReconcileBatch(ctx context.Context, reqs []DeployKeyInfo) ([]DeployKeyBatchResult, error)
+}
+
+// HookClient operates on the webhooks of a specific repository.
+// This client can be accessed through Repository.Hooks().
+type HookClient interface {
+	// Get a webhook by its URL.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, url string) (DeployHook, error)
+
+	// List all webhooks of the repository.
+	//
+	// List returns all available webhooks, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]DeployHook, error)
+
+	// Create a webhook with the given specifications.
+	//
+	// ErrAlreadyExists will be returned if a webhook with the same URL already exists.
+	Create(ctx context.Context, req DeployHookInfo) (DeployHook, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// The webhook is looked up by its URL.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req DeployHookInfo) (resp DeployHook, actionTaken bool, err error)
 }
 
 // DeployTokenClient operates on the deploy token list of a specific repository.
@@ -237,30 +369,354 @@ type DeployTokenClient interface {
 	Reconcile(ctx context.Context, req DeployTokenInfo) (resp DeployToken, actionTaken bool, err error)
 }
 
+// ProtectedTagClient operates on the protected tags of a specific repository.
+// This client can be accessed through Repository.ProtectedTags().
+type ProtectedTagClient interface {
+	// Get a ProtectedTag by its name or pattern.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, name string) (ProtectedTag, error)
+
+	// List all protected tags for the given repository.
+	//
+	// List returns all available protected tags, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]ProtectedTag, error)
+
+	// Create protects the tags matching the given specifications.
+	//
+	// ErrAlreadyExists will be returned if the resource already exists.
+	Create(ctx context.Context, req ProtectedTagInfo) (ProtectedTag, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req ProtectedTagInfo) (resp ProtectedTag, actionTaken bool, err error)
+}
+
+// MilestoneClient operates on the milestones of a specific repository.
+// This client can be accessed through Repository.Milestones().
+type MilestoneClient interface {
+	// Get a Milestone by its title.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, title string) (Milestone, error)
+
+	// List all milestones, open and closed, of the given repository.
+	//
+	// List returns all available milestones, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]Milestone, error)
+
+	// Create a milestone with the given specifications.
+	//
+	// ErrAlreadyExists will be returned if the resource already exists.
+	Create(ctx context.Context, req MilestoneInfo) (Milestone, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	//
+	// If req doesn't exist under the hood, it is created (actionTaken == true).
+	// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+	// If req is already the actual state, this is a no-op (actionTaken == false).
+	Reconcile(ctx context.Context, req MilestoneInfo) (resp Milestone, actionTaken bool, err error)
+
+	// Close closes the milestone with the given title. Closing a closed milestone is a no-op.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Close(ctx context.Context, title string) (Milestone, error)
+}
+
+// ReleaseClient operates on the releases of a specific repository. Releases are identified by
+// the name of their tag. This client can be accessed through Repository.Releases().
+type ReleaseClient interface {
+	// List lists all the releases of the repository, drafts included.
+	//
+	// List returns all available releases, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]ReleaseInfo, error)
+
+	// Get returns the release of the given tag.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, tagName string) (ReleaseInfo, error)
+
+	// Create creates a release with the given specifications. If the tag doesn't exist, it's
+	// created from req.TargetCommitish first.
+	//
+	// ErrNotFound is returned if the tag doesn't exist and req.TargetCommitish is empty.
+	// ErrAlreadyExists is returned if the tag already has a release.
+	Create(ctx context.Context, req ReleaseInfo) (ReleaseInfo, error)
+
+	// Edit updates the release of req.TagName to match req. req.TargetCommitish is ignored.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Edit(ctx context.Context, req ReleaseInfo) (ReleaseInfo, error)
+
+	// Delete deletes the release of the given tag. The tag itself is kept.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Delete(ctx context.Context, tagName string) error
+
+	// Publish creates a commit (or resolves an existing ref), an annotated tag pointing to it,
+	// and the release of that tag, as described by opts. See PublishRelease for how a failing
+	// step is reported. Rolling back requires destructive API calls to be enabled,
+	// ErrDestructiveCallDisallowed is returned otherwise.
+	Publish(ctx context.Context, opts PublishOptions) (PublishResult, error)
+}
+
+// PipelineClient operates on the CI pipelines of a specific repository.
+// This client can be accessed through Repository.Pipelines().
+type PipelineClient interface {
+	// Trigger starts a pipeline for the given branch or tag, passing it the given variables.
+	Trigger(ctx context.Context, ref string, variables map[string]string) (Pipeline, error)
+
+	// Get a Pipeline by its ID.
+	//
+	// ErrNotFound is returned if the resource does not exist.
+	Get(ctx context.Context, id int) (Pipeline, error)
+
+	// List lists a page of the pipelines of the repository, most recent first.
+	List(ctx context.Context, opts PipelineListOptions) ([]Pipeline, error)
+
+	// GetRunLogs returns the logs of the jobs of the pipeline with the given ID, one job after
+	// the other. The logs are streamed as they are read, the caller must close the returned reader.
+	//
+	// ErrNotFound is returned if the pipeline does not exist.
+	GetRunLogs(ctx context.Context, id int) (io.ReadCloser, error)
+}
+
+// WorkflowClient operates on the CI workflows of a specific repository.
+// This client can be accessed through Repository.Workflows().
+type WorkflowClient interface {
+	// Dispatch runs the given workflow, identified by its file name or ID, on the given branch or
+	// tag with the given inputs.
+	//
+	// ErrNotFound is returned if the workflow does not exist.
+	Dispatch(ctx context.Context, workflow, ref string, inputs map[string]string) error
+
+	// ListRuns lists the most recent runs of the given workflow, identified by its file name or ID,
+	// most recent first.
+	//
+	// ErrNotFound is returned if the workflow does not exist.
+	ListRuns(ctx context.Context, workflow string) ([]WorkflowRun, error)
+
+	// GetRunLogs returns the logs of the jobs of the workflow run with the given ID, one job after
+	// the other. The logs are streamed as they are read, the caller must close the returned reader.
+	//
+	// ErrNotFound is returned if the workflow run does not exist.
+	GetRunLogs(ctx context.Context, runID int64) (io.ReadCloser, error)
+}
+
+// TrafficClient reads the traffic statistics of a specific repository, over the window reported by
+// the provider. This client can be accessed through Repository.Traffic().
+type TrafficClient interface {
+	// Clones returns the number of clones of the repository, in total and per day.
+	Clones(ctx context.Context) (TrafficInfo, error)
+
+	// Views returns the number of views of the repository, in total and per day.
+	Views(ctx context.Context) (TrafficInfo, error)
+
+	// PopularPaths returns the most viewed paths of the repository, most viewed first.
+	PopularPaths(ctx context.Context) ([]PopularPathInfo, error)
+}
+
+// EnvironmentClient operates on the deployment environments of a specific repository.
+// This client can be accessed through Repository.Environments().
+type EnvironmentClient interface {
+	// Secrets returns a client operating on the secrets of the given environment.
+	Secrets(environment string) EnvironmentSecretClient
+}
+
+// EnvironmentSecretClient reads the secrets of a deployment environment. The values of the
+// secrets are never returned.
+// This client can be accessed through EnvironmentClient.Secrets().
+type EnvironmentSecretClient interface {
+	// List returns the names of the secrets along with when they were last updated.
+	//
+	// ErrNotFound is returned if the environment doesn't exist.
+	List(ctx context.Context) ([]SecretMetadataInfo, error)
+
+	// ListNames returns the names of the secrets, sorted.
+	//
+	// ErrNotFound is returned if the environment doesn't exist.
+	ListNames(ctx context.Context) ([]string, error)
+}
+
 // CommitClient operates on the commits list for a specific repository.
 // This client can be accessed through Repository.Commits().
 type CommitClient interface {
 
 	// ListPage lists repository commits of the given page and page size.
+	// It is a shorthand for List with only the branch and paging options set.
+	// Pages are 1-based; ErrInvalidArgument is returned if perPage or page is less than 1.
 	ListPage(ctx context.Context, branch string, perPage int, page int) ([]Commit, error)
+	// Get returns the commit with the given SHA, which allows walking the history through the
+	// parents of the commits. ErrNotFound is returned if the commit doesn't exist.
+	Get(ctx context.Context, sha string) (Commit, error)
+	// List lists the repository commits matching the given options.
+	List(ctx context.Context, opts CommitListOptions) ([]Commit, error)
 	// Create creates a commit with the given specifications.
-	Create(ctx context.Context, branch string, message string, files []CommitFile) (Commit, error)
+	// ErrPreconditionFailed is returned if the branch doesn't point to the commit expected
+	// through CommitCreateOptions.ExpectedHeadSHA.
+	Create(ctx context.Context, branch string, message string, files []CommitFile, opts ...CommitCreateOption) (Commit, error)
+	// SignatureKey returns the ID of the key that signed the commit with the given SHA, see SignatureKeyID.
+	// ErrNotFound is returned if the commit is not signed, and ErrNoProviderSupport if the provider
+	// doesn't expose commit signatures.
+	SignatureKey(ctx context.Context, sha string) (string, error)
+	// ResolveRef resolves a branch, a tag or an abbreviated commit SHA to the full SHA of the commit
+	// it points to. ErrNotFound is returned if ref doesn't match any commit, and ErrAmbiguousReference
+	// if ref is an abbreviated SHA matching several commits. Providers unable to tell these cases
+	// apart return ErrNotFound for both.
+	ResolveRef(ctx context.Context, ref string) (string, error)
+	// CheckAnnotations lists the annotations reported by the check runs of the commit with the
+	// given SHA, using multiple paginated requests if needed.
+	// ErrNoProviderSupport is returned if the provider doesn't have check annotations.
+	CheckAnnotations(ctx context.Context, sha string) ([]Annotation, error)
+	// ContainedInBranches returns the names of the branches whose history includes the commit
+	// with the given SHA, sorted. ErrNotFound is returned if the commit doesn't exist.
+	ContainedInBranches(ctx context.Context, sha string) ([]string, error)
+}
+
+// CommitContentEncoder is implemented by the CommitClients whose Create takes the content of the
+// files encoded, e.g. base64-encoded on Gitea. The helpers committing rendered text, such as
+// CreateWithSettings and CommitTemplates, encode the content through it.
+type CommitContentEncoder interface {
+	// EncodeCommitContent encodes the raw content of a file the way Create expects it.
+	EncodeCommitContent(content string) string
+}
+
+// CollaboratorClient operates on the users having access to a specific repository.
+// This client can be accessed through Repository.Collaborators().
+type CollaboratorClient interface {
+	// List lists the users having access to the repository, sorted by login. Only the users
+	// granted access on the repository itself are listed, unless CollaboratorListOptions.Effective
+	// is set. List returns all available users, using multiple paginated requests if needed.
+	List(ctx context.Context, opts ...CollaboratorListOption) ([]CollaboratorInfo, error)
+}
+
+// TagClient operates on the tags of a specific repository.
+// This client can be accessed through Repository.Tags().
+type TagClient interface {
+	// List lists all the tags of the repository, with the commit they point to and their
+	// creation date. List returns all available tags, using multiple paginated requests if needed.
+	List(ctx context.Context) ([]TagInfo, error)
+
+	// Get returns the tag with the given name.
+	//
+	// ErrNotFound is returned if the tag doesn't exist.
+	Get(ctx context.Context, name string) (TagInfo, error)
+
+	// Create creates a tag with the given name pointing to commitSHA. An annotated tag is
+	// created if message is set, and a lightweight tag otherwise.
+	//
+	// ErrAlreadyExists is returned if a tag with the same name already exists.
+	Create(ctx context.Context, name, commitSHA, message string) (TagInfo, error)
+}
+
+// WikiClient operates on the pages of the wiki of a specific repository, which is stored in a
+// separate Git repository. This client can be accessed through OrgRepository.Wiki().
+type WikiClient interface {
+	// Get returns the pages in the given directory of the wiki, "" being the root.
+	// ErrNotFound is returned if the wiki is empty, or the directory doesn't exist.
+	Get(ctx context.Context, dir string) ([]*CommitFile, error)
+	// Create commits the given pages to the wiki in a single commit.
+	// Pages with a nil Content are deleted.
+	Create(ctx context.Context, message string, files []CommitFile) (Commit, error)
+}
+
+// CustomPropertiesClient operates on the custom properties of a specific repository, which
+// classify it according to properties defined by its organization.
+// This client can be accessed through OrgRepository.CustomProperties().
+type CustomPropertiesClient interface {
+	// Get returns the values of the custom properties set on the repository, by property name.
+	// The values of multi-select properties are comma-separated.
+	Get(ctx context.Context) (map[string]string, error)
+	// Reconcile makes sure the properties in req have the given values, an empty value unsetting
+	// the property. Properties not in req are left untouched.
+	// ErrInvalidArgument is returned if a property or value isn't allowed by the organization.
+	Reconcile(ctx context.Context, req map[string]string) (actionTaken bool, err error)
+}
+
+// TopicsClient operates on the topics of a specific repository, which classify it when browsing
+// and searching the provider. This client can be accessed through Repository.Topics().
+type TopicsClient interface {
+	// Get returns the topics of the repository.
+	Get(ctx context.Context) ([]string, error)
+	// Reconcile makes sure the repository has exactly the given topics, in any order.
+	// ErrInvalidArgument is returned if a topic isn't allowed, see WithAllowedTopics.
+	Reconcile(ctx context.Context, topics []string, opts ...TopicsReconcileOption) (actionTaken bool, err error)
+}
+
+// ApprovalSettingsClient operates on the settings governing the approval of the pull requests of
+// a specific repository. This client can be accessed through OrgRepository.ApprovalSettings().
+type ApprovalSettingsClient interface {
+	// Get returns the approval settings of the repository.
+	Get(ctx context.Context) (ApprovalSettingsInfo, error)
+
+	// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+	// Only the fields set in req are reconciled, the others are left as-is.
+	Reconcile(ctx context.Context, req ApprovalSettingsInfo) (actionTaken bool, err error)
+}
+
+// PagesClient operates on the static website published from a specific repository, e.g. GitHub
+// Pages. This client can be accessed through OrgRepository.Pages().
+type PagesClient interface {
+	// Get returns how the website of the repository is published.
+	// ErrNotFound is returned if no website is published from the repository.
+	Get(ctx context.Context) (PagesInfo, error)
+
+	// Reconcile makes sure the website of the repository is published as described by the desired
+	// state (req), publishing it first if needed.
+	Reconcile(ctx context.Context, req PagesInfo) (actionTaken bool, err error)
+}
+
+// InteractionLimitsClient operates on the temporary limits on which users may interact with a
+// specific repository. This client can be accessed through OrgRepository.InteractionLimits().
+type InteractionLimitsClient interface {
+	// Get returns the interaction limits in effect.
+	// ErrNotFound is returned if interactions aren't limited.
+	Get(ctx context.Context) (InteractionLimitsInfo, error)
+	// Set limits the interactions with the repository, replacing the limits in effect, and returns
+	// the resulting limits.
+	Set(ctx context.Context, req InteractionLimitsInfo) (InteractionLimitsInfo, error)
+	// Remove lifts the interaction limits. It's a no-op if interactions aren't limited.
+	Remove(ctx context.Context) error
 }
 
 // BranchClient operates on the branches for a specific repository.
 // This client can be accessed through Repository.Branches().
 type BranchClient interface {
 	// Create creates a branch with the given specifications.
+	// sha may be abbreviated, in which case it's resolved to the full SHA first.
 	Create(ctx context.Context, branch, sha string) error
+
+	// RequiredChecks returns the names of the status checks which must pass before changes can
+	// be merged into the given protected branch.
+	// ErrNotFound is returned if the branch isn't protected.
+	// Returns "ErrNoProviderSupport" if the provider can't express required status checks.
+	RequiredChecks(ctx context.Context, branch string) ([]string, error)
+
+	// RequiresSignedCommits returns whether the protection of the given branch only accepts signed
+	// commits, without fetching its whole protection. Unprotected branches don't require signed commits.
+	// Returns "ErrNoProviderSupport" if the provider can't require signed commits.
+	RequiresSignedCommits(ctx context.Context, branch string) (bool, error)
+
+	// DefaultReviewers returns the logins of the users added as reviewers to new pull requests
+	// targeting the given branch, by default.
+	// Returns "ErrNoProviderSupport" if the provider has no default reviewers.
+	DefaultReviewers(ctx context.Context, branch string) ([]string, error)
 }
 
 // PullRequestClient operates on the pull requests for a specific repository.
 // This client can be accessed through Repository.PullRequests().
 type PullRequestClient interface {
 	// List lists all pull requests in the repository
-	List(ctx context.Context) ([]PullRequest, error)
+	// The list can be narrowed down with PullRequestListOptions, e.g. to the pull requests
+	// carrying given labels.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support a filter set in the options.
+	List(ctx context.Context, opts ...PullRequestListOption) ([]PullRequest, error)
 	// Create creates a pull request with the given specifications.
-	Create(ctx context.Context, title, branch, baseBranch, description string) (PullRequest, error)
+	// The pull request can be opened as a draft through PullRequestCreateOptions.Draft.
+	Create(ctx context.Context, title, branch, baseBranch, description string, opts ...PullRequestCreateOption) (PullRequest, error)
 	// Edit allows for changing an existing pull request using the given options. Please refer to "EditOptions" for details on which data can be
 	// edited.
 	Edit(ctx context.Context, number int, opts EditOptions) (PullRequest, error)
@@ -268,6 +724,44 @@ type PullRequestClient interface {
 	Get(ctx context.Context, number int) (PullRequest, error)
 	// Merge merges a pull request with via either the "Squash" or "Merge" method
 	Merge(ctx context.Context, number int, mergeMethod MergeMethod, message string) error
+	// MergeabilityStatus returns whether the pull request can be merged, and why not.
+	// Providers compute this asynchronously, so it's polled until known or until ctx is done.
+	MergeabilityStatus(ctx context.Context, number int) (MergeableInfo, error)
+	// Diff returns the changes of the pull request as a unified diff, like "git diff" prints it.
+	// ErrNotFound is returned if the pull request doesn't exist.
+	Diff(ctx context.Context, number int) ([]byte, error)
+	// ListCommits lists the commits of the pull request, i.e. those between its base and its head.
+	// An empty slice is returned for a pull request without commits.
+	// ListCommits returns all available commits, using multiple paginated requests if needed.
+	ListCommits(ctx context.Context, number int) ([]Commit, error)
+	// PreviewRebase returns whether rebasing the pull request onto its base branch would conflict,
+	// without rebasing it. Providers compute this asynchronously, so it's polled until known or
+	// until ctx is done.
+	// Returns "ErrNoProviderSupport" if the provider can't tell whether a rebase would conflict.
+	PreviewRebase(ctx context.Context, number int) (RebasePreview, error)
+	// ReviewSummary returns the number of reviewers currently approving the pull request or
+	// requesting changes to it, and who approved it. Only the latest verdict of every reviewer
+	// counts. ErrNotFound is returned if the pull request doesn't exist.
+	ReviewSummary(ctx context.Context, number int) (ReviewSummary, error)
+	// CreateComment posts a comment with the given body on the conversation of the pull request.
+	// ErrNotFound is returned if the pull request doesn't exist.
+	CreateComment(ctx context.Context, number int, body string) (PullRequestComment, error)
+	// ListComments lists the comments on the conversation of the pull request, from the oldest
+	// to the newest. Comments on the lines of the diff aren't included.
+	// ListComments returns all available comments, using multiple paginated requests if needed.
+	ListComments(ctx context.Context, number int) ([]PullRequestComment, error)
+	// Review submits a review of the pull request as the authenticated user, with the given
+	// verdict and body. The body may only be empty when approving, see ValidateReview.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support the action.
+	Review(ctx context.Context, number int, action ReviewAction, body string) (PullRequestReview, error)
+	// AddLabels adds the labels with the given names to the pull request, keeping the labels it
+	// already carries. Providers creating labels on the fly create the missing ones, others
+	// return ErrNotFound if a label doesn't exist in the repository.
+	// Returns "ErrNoProviderSupport" if the provider has no pull request labels.
+	AddLabels(ctx context.Context, number int, labels []string) error
+	// ListLabels lists the names of the labels of the pull request.
+	// Returns "ErrNoProviderSupport" if the provider has no pull request labels.
+	ListLabels(ctx context.Context, number int) ([]string, error)
 }
 
 // EditOptions is provided to a PullRequestClient's "Edit" method for updating an existing pull request.
@@ -280,6 +774,8 @@ type EditOptions struct {
 // This client can be accessed through Repository.Branches().
 type FileClient interface {
 	// GetFiles fetch files content from specific path and branch
+	// The files of a directory are downloaded concurrently, see FilesGetOptions.Concurrency,
+	// and returned in the order they are listed by the provider.
 	Get(ctx context.Context, path, branch string, optFns ...FilesGetOption) ([]*CommitFile, error)
 }
 
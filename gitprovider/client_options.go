@@ -21,8 +21,10 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider/cache"
+	"github.com/fluxcd/go-git-providers/gitprovider/replay"
 	"github.com/go-logr/logr"
 	"golang.org/x/oauth2"
 )
@@ -64,6 +66,33 @@ type CommonClientOptions struct {
 
 	// CABundle is a []byte containing the CA bundle to use for the client.
 	CABundle []byte
+
+	// DefaultOwner is the organization or user the repository names given to Client.Repo
+	// are resolved against. Its existence is only checked when it's first used.
+	DefaultOwner IdentityRef
+
+	// RetryPolicy specifies how failed requests are retried. It replaces the built-in retries of
+	// the providers which have some. Default: nil, which means the behaviour of the provider.
+	RetryPolicy *RetryPolicy
+
+	// PageTimeout bounds the time spent in the requests fetching a single page of a paginated list,
+	// excluding the waits between retries. Default: nil, which means only the context given to
+	// the call applies.
+	PageTimeout *time.Duration
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections the transport keeps per host.
+	// Default: nil, which means the default of the transport.
+	MaxIdleConnsPerHost *int
+
+	// DisableHTTP2 specifies whether the transport is restricted to HTTP/1.1. Default: nil, which
+	// means the default of the transport.
+	DisableHTTP2 *bool
+
+	// RecordReplayTransportHook records the HTTP interactions with the Git provider API, or replays
+	// recorded ones, see WithRecorder and WithReplayer. It sits right above the "Post Chain" and
+	// below the retry, authentication and cache transports, which hence behave as they would
+	// against the live API.
+	RecordReplayTransportHook ChainableRoundTripperFunc
 }
 
 // ApplyToCommonClientOptions applies the currently set fields in opts to target. If both opts and
@@ -119,6 +148,49 @@ func (opts *CommonClientOptions) ApplyToCommonClientOptions(target *CommonClient
 		target.CABundle = opts.CABundle
 	}
 
+	if opts.DefaultOwner != nil {
+		if target.DefaultOwner != nil {
+			return fmt.Errorf("option DefaultOwner already configured: %w", ErrInvalidClientOptions)
+		}
+		target.DefaultOwner = opts.DefaultOwner
+	}
+
+	if opts.RetryPolicy != nil {
+		if target.RetryPolicy != nil {
+			return fmt.Errorf("option RetryPolicy already configured: %w", ErrInvalidClientOptions)
+		}
+		target.RetryPolicy = opts.RetryPolicy
+	}
+
+	if opts.PageTimeout != nil {
+		if target.PageTimeout != nil {
+			return fmt.Errorf("option PageTimeout already configured: %w", ErrInvalidClientOptions)
+		}
+		target.PageTimeout = opts.PageTimeout
+	}
+
+	if opts.MaxIdleConnsPerHost != nil {
+		if target.MaxIdleConnsPerHost != nil {
+			return fmt.Errorf("option MaxIdleConnsPerHost already configured: %w", ErrInvalidClientOptions)
+		}
+		target.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+
+	if opts.DisableHTTP2 != nil {
+		if target.DisableHTTP2 != nil {
+			return fmt.Errorf("option DisableHTTP2 already configured: %w", ErrInvalidClientOptions)
+		}
+		target.DisableHTTP2 = opts.DisableHTTP2
+	}
+
+	if opts.RecordReplayTransportHook != nil {
+		// Make sure the user didn't ask both for recording and replaying, or twice
+		if target.RecordReplayTransportHook != nil {
+			return fmt.Errorf("option RecordReplayTransportHook already configured: %w", ErrInvalidClientOptions)
+		}
+		target.RecordReplayTransportHook = opts.RecordReplayTransportHook
+	}
+
 	return nil
 }
 
@@ -184,11 +256,35 @@ func (opts *ClientOptions) ApplyToClientOptions(target *ClientOptions) error {
 
 // GetTransportChain builds the full chain of transports (from left to right,
 // as per gitprovider.BuildClientFromTransportChain) of the form described in NewClient.
-func (opts *ClientOptions) GetTransportChain() (chain []ChainableRoundTripperFunc) {
+func (opts *ClientOptions) GetTransportChain() []ChainableRoundTripperFunc {
+	return opts.transportChain(true)
+}
+
+// GetUnauthenticatedTransportChain builds the chain of transports of GetTransportChain, without
+// the authentication transport. It's meant for requests which mustn't carry the credentials of
+// the client, e.g. to pre-signed URLs, while still using e.g. the custom CA and the recorder.
+func (opts *ClientOptions) GetUnauthenticatedTransportChain() []ChainableRoundTripperFunc {
+	return opts.transportChain(false)
+}
+
+// transportChain builds the chain of transports, with the authentication transport if withAuth.
+func (opts *ClientOptions) transportChain(withAuth bool) (chain []ChainableRoundTripperFunc) {
 	if opts.PostChainTransportHook != nil {
 		chain = append(chain, opts.PostChainTransportHook)
 	}
-	if opts.authTransport != nil {
+	if opts.MaxIdleConnsPerHost != nil || opts.DisableHTTP2 != nil {
+		chain = append(chain, tunedTransport(opts.MaxIdleConnsPerHost, opts.DisableHTTP2))
+	}
+	if opts.RecordReplayTransportHook != nil {
+		chain = append(chain, opts.RecordReplayTransportHook)
+	}
+	if opts.PageTimeout != nil {
+		chain = append(chain, pageTimeoutTransport(*opts.PageTimeout))
+	}
+	if opts.RetryPolicy != nil {
+		chain = append(chain, retryTransport(*opts.RetryPolicy))
+	}
+	if withAuth && opts.authTransport != nil {
 		chain = append(chain, opts.authTransport)
 	}
 	if opts.enableConditionalRequests != nil && *opts.enableConditionalRequests {
@@ -232,6 +328,60 @@ func WithDomain(domain string) ClientOption {
 	return buildCommonOption(CommonClientOptions{Domain: &domain})
 }
 
+// WithDefaultOwner makes Client.Repo resolve repository names against the given organization
+// or user. ref must be an OrganizationRef or a UserRef, methods taking explicit references are
+// not affected.
+func WithDefaultOwner(ref IdentityRef) ClientOption {
+	// Don't allow an empty value
+	if ref == nil {
+		return optionError(fmt.Errorf("ref cannot be nil: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{DefaultOwner: ref})
+}
+
+// WithRetryPolicy makes the client retry the requests failing with a network error, a rate limit
+// or a server error according to policy, instead of the default behaviour of the provider. Only
+// idempotent requests are retried on network and server errors, see RetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	if err := policy.ValidateOptions(); err != nil {
+		return optionError(fmt.Errorf("%w: %w", err, ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{RetryPolicy: &policy})
+}
+
+// WithPageTimeout bounds the time spent fetching each page of the paginated lists, so that a single
+// slow page fails early with context.DeadlineExceeded instead of silently consuming the budget of
+// the whole listing, which the deadline of the context given to the call applies to. The waits
+// between retries don't count against timeout. timeout must be positive.
+func WithPageTimeout(timeout time.Duration) ClientOption {
+	// Don't allow a value failing every page
+	if timeout <= 0 {
+		return optionError(fmt.Errorf("timeout must be positive: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{PageTimeout: &timeout})
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle connections kept per host by the
+// transport of the client, e.g. to reuse more connections when doing many concurrent calls.
+// n must be positive.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	// Don't allow a value disabling the pool
+	if n <= 0 {
+		return optionError(fmt.Errorf("n must be positive: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{MaxIdleConnsPerHost: &n})
+}
+
+// WithDisableHTTP2 tells the client whether to restrict its transport to HTTP/1.1, instead of
+// negotiating HTTP/2 with the servers supporting it.
+func WithDisableHTTP2(disableHTTP2 bool) ClientOption {
+	return buildCommonOption(CommonClientOptions{DisableHTTP2: &disableHTTP2})
+}
+
 // WithLogger initializes a Client for a custom Stash instance with a logger.
 func WithLogger(log *logr.Logger) ClientOption {
 	return buildCommonOption(CommonClientOptions{Logger: log})
@@ -265,6 +415,29 @@ func WithPostChainTransportHook(postRoundTripperFunc ChainableRoundTripperFunc)
 	return buildCommonOption(CommonClientOptions{PostChainTransportHook: postRoundTripperFunc})
 }
 
+// WithRecorder records the HTTP interactions of the client with the Git provider API in r, which
+// redacts the credentials. Save the recorded cassette with r.Save, and replay it with WithReplayer.
+func WithRecorder(r *replay.Recorder) ClientOption {
+	// Don't allow an empty value
+	if r == nil {
+		return optionError(fmt.Errorf("r cannot be nil: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{RecordReplayTransportHook: r.Transport})
+}
+
+// WithReplayer makes the client replay the HTTP interactions recorded in the cassette of p instead
+// of talking to the Git provider API, e.g. for deterministic tests. Requests without a matching
+// recorded interaction fail with replay.ErrInteractionNotFound.
+func WithReplayer(p *replay.Replayer) ClientOption {
+	// Don't allow an empty value
+	if p == nil {
+		return optionError(fmt.Errorf("p cannot be nil: %w", ErrInvalidClientOptions))
+	}
+
+	return buildCommonOption(CommonClientOptions{RecordReplayTransportHook: p.Transport})
+}
+
 // WithOAuth2Token initializes a Client which authenticates with Stash through an OAuth2 token.
 // oauth2Token must not be an empty string.
 func WithOAuth2Token(oauth2Token string) ClientOption {
@@ -334,3 +507,30 @@ func caCustomTransport(caBundle []byte) ChainableRoundTripperFunc {
 		}
 	}
 }
+
+// tunedTransport returns a ChainableRoundTripperFunc tuning a copy of "in", or of
+// http.DefaultTransport if "in" is nil. Only *http.Transport can be tuned, other RoundTrippers
+// are returned as is.
+func tunedTransport(maxIdleConnsPerHost *int, disableHTTP2 *bool) ChainableRoundTripperFunc {
+	return func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		t, ok := in.(*http.Transport)
+		if !ok {
+			return in
+		}
+		t = t.Clone()
+		if maxIdleConnsPerHost != nil {
+			t.MaxIdleConnsPerHost = *maxIdleConnsPerHost
+		}
+		if disableHTTP2 != nil {
+			t.ForceAttemptHTTP2 = !*disableHTTP2
+			if *disableHTTP2 {
+				// A non-nil empty map disables the HTTP/2 upgrade of TLS connections
+				t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			}
+		}
+		return t
+	}
+}
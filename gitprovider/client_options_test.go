@@ -17,11 +17,16 @@ limitations under the License.
 package gitprovider
 
 import (
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/fluxcd/go-git-providers/gitprovider/replay"
 	"github.com/fluxcd/go-git-providers/validation"
 )
 
@@ -29,6 +34,8 @@ func dummyRoundTripper1(http.RoundTripper) http.RoundTripper { return nil }
 func dummyRoundTripper2(http.RoundTripper) http.RoundTripper { return nil }
 func dummyRoundTripper3(http.RoundTripper) http.RoundTripper { return nil }
 
+func durationVar(d time.Duration) *time.Duration { return &d }
+
 func roundTrippersEqual(a, b ChainableRoundTripperFunc) bool {
 	if a == nil && b == nil {
 		return true
@@ -212,6 +219,32 @@ func Test_clientOptions_getTransportChain(t *testing.T) {
 	}
 }
 
+func Test_clientOptions_getUnauthenticatedTransportChain(t *testing.T) {
+	dummy := "dummy"
+	opts := &ClientOptions{
+		CommonClientOptions: CommonClientOptions{
+			Domain:                 &dummy,
+			PreChainTransportHook:  dummyRoundTripper1,
+			PostChainTransportHook: dummyRoundTripper2,
+		},
+		authTransport: dummyRoundTripper3,
+	}
+	// expect: "post chain" <-> "pre chain"
+	wantChain := []ChainableRoundTripperFunc{
+		dummyRoundTripper2,
+		dummyRoundTripper1,
+	}
+	gotChain := opts.GetUnauthenticatedTransportChain()
+	if len(gotChain) != len(wantChain) {
+		t.Fatalf("clientOptions.GetUnauthenticatedTransportChain() = %v, want %v", gotChain, wantChain)
+	}
+	for i := range wantChain {
+		if !roundTrippersEqual(wantChain[i], gotChain[i]) {
+			t.Fatalf("clientOptions.GetUnauthenticatedTransportChain() = %v, want %v", gotChain, wantChain)
+		}
+	}
+}
+
 func Test_makeCientOptions(t *testing.T) {
 	ca, err := os.ReadFile("./testdata/ca.pem")
 	if err != nil {
@@ -282,6 +315,56 @@ func Test_makeCientOptions(t *testing.T) {
 			opts:         []ClientOption{WithOAuth2Token("")},
 			expectedErrs: []error{ErrInvalidClientOptions},
 		},
+		{
+			name: "WithPageTimeout",
+			opts: []ClientOption{WithPageTimeout(time.Minute)},
+			want: buildCommonOption(CommonClientOptions{PageTimeout: durationVar(time.Minute)}),
+		},
+		{
+			name:         "WithPageTimeout, zero",
+			opts:         []ClientOption{WithPageTimeout(0)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithPageTimeout, duplicate",
+			opts:         []ClientOption{WithPageTimeout(time.Minute), WithPageTimeout(time.Second)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name: "WithMaxIdleConnsPerHost",
+			opts: []ClientOption{WithMaxIdleConnsPerHost(50)},
+			want: buildCommonOption(CommonClientOptions{MaxIdleConnsPerHost: IntVar(50)}),
+		},
+		{
+			name:         "WithMaxIdleConnsPerHost, zero",
+			opts:         []ClientOption{WithMaxIdleConnsPerHost(0)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithMaxIdleConnsPerHost, duplicate",
+			opts:         []ClientOption{WithMaxIdleConnsPerHost(50), WithMaxIdleConnsPerHost(100)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name: "WithDisableHTTP2",
+			opts: []ClientOption{WithDisableHTTP2(true)},
+			want: buildCommonOption(CommonClientOptions{DisableHTTP2: BoolVar(true)}),
+		},
+		{
+			name:         "WithRecorder, nil",
+			opts:         []ClientOption{WithRecorder(nil)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithReplayer, nil",
+			opts:         []ClientOption{WithReplayer(nil)},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
+		{
+			name:         "WithRecorder and WithReplayer, exclusive",
+			opts:         []ClientOption{WithRecorder(replay.NewRecorder()), WithReplayer(replay.NewReplayer(&replay.Cassette{}))},
+			expectedErrs: []error{ErrInvalidClientOptions},
+		},
 		{
 			name: "WithConditionalRequests",
 			opts: []ClientOption{WithConditionalRequests(true)},
@@ -319,3 +402,105 @@ func Test_makeCientOptions(t *testing.T) {
 		})
 	}
 }
+
+func Test_clientOptions_tunedTransport(t *testing.T) {
+	opts, err := MakeClientOptions(WithMaxIdleConnsPerHost(50), WithDisableHTTP2(true), WithConditionalRequests(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Build the chain step by step, to find the tuned transport under the cache
+	var tuned *http.Transport
+	var rt http.RoundTripper
+	for _, rtFunc := range opts.GetTransportChain() {
+		rt = rtFunc(rt)
+		if tr, ok := rt.(*http.Transport); ok {
+			tuned = tr
+		}
+	}
+	if tuned == nil {
+		t.Fatal("no *http.Transport in the transport chain")
+	}
+	if tuned == http.DefaultTransport {
+		t.Error("http.DefaultTransport was modified instead of a copy")
+	}
+	if tuned.MaxIdleConnsPerHost != 50 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 50", tuned.MaxIdleConnsPerHost)
+	}
+	if tuned.ForceAttemptHTTP2 || tuned.TLSNextProto == nil {
+		t.Errorf("HTTP/2 isn't disabled, ForceAttemptHTTP2 = %t, TLSNextProto = %v", tuned.ForceAttemptHTTP2, tuned.TLSNextProto)
+	}
+
+	// The cache is on top of the tuned transport
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: rt}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != "ok" {
+			t.Errorf("request %d: got %d %q, want 200 %q", i, resp.StatusCode, body, "ok")
+		}
+	}
+	if requests != 2 {
+		t.Errorf("server got %d requests, want 2", requests)
+	}
+}
+
+func Test_clientOptions_replayWithConditionalRequests(t *testing.T) {
+	// The second request is revalidated by the cache, and answered with the cached body
+	cassette := &replay.Cassette{Interactions: []replay.Interaction{
+		{
+			Request: replay.Request{Method: http.MethodGet, URL: "https://example.com/repos"},
+			Response: replay.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"v1"`}},
+				Body:       "[]",
+			},
+		},
+		{
+			Request:  replay.Request{Method: http.MethodGet, URL: "https://example.com/repos"},
+			Response: replay.Response{StatusCode: http.StatusNotModified, Header: http.Header{"Etag": {`"v1"`}}},
+		},
+	}}
+	replayer := replay.NewReplayer(cassette)
+	opts, err := MakeClientOptions(WithReplayer(replayer), WithConditionalRequests(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := BuildClientFromTransportChain(opts.GetTransportChain())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get("https://example.com/repos")
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK || string(body) != "[]" {
+			t.Errorf("request %d got %d %q, want 200 \"[]\"", i, resp.StatusCode, body)
+		}
+	}
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("%d interactions weren't replayed", len(unused))
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// initialFilesCommitMessage is the commit message used when committing the initial files.
+const initialFilesCommitMessage = "Add initial files"
+
+// RepositorySettings specifies the settings CreateWithSettings applies to a repository right
+// after creating it. Unset fields are left at the defaults of the provider, and cost no API call.
+type RepositorySettings struct {
+	// Topics are the topics of the repository.
+	// +optional
+	Topics []string
+
+	// ApprovalSettings are the settings governing the approval of pull requests.
+	// +optional
+	ApprovalSettings *ApprovalSettingsInfo
+
+	// WorkflowPermissions are the default permissions of the token handed to CI workflows.
+	// +optional
+	WorkflowPermissions *WorkflowPermissionsInfo
+
+	// MergeQueues maps the names of the protected branches to the merge queue of each.
+	// +optional
+	MergeQueues map[string]MergeQueueInfo
+
+	// InitialFiles are committed to the default branch in a single commit, before the other
+	// settings are applied. The repository must be created with AutoInit for the default branch
+	// to exist.
+	// +optional
+	InitialFiles []CommitFile
+
+	// TemplateData is the data the content of each of InitialFiles is rendered with, as a
+	// text/template, e.g. to substitute "{{ .RepoName }}". If nil, the files are committed as-is.
+	// +optional
+	TemplateData map[string]any
+}
+
+// ValidateInfo validates the settings, so that CreateWithSettings fails before creating anything.
+func (s RepositorySettings) ValidateInfo() error {
+	validator := validation.New("RepositorySettings")
+	for _, topic := range s.Topics {
+		if len(strings.TrimSpace(topic)) == 0 {
+			validator.Required("Topics")
+			break
+		}
+	}
+	if s.ApprovalSettings != nil {
+		validator.Append(s.ApprovalSettings.ValidateInfo(), *s.ApprovalSettings, "ApprovalSettings")
+	}
+	if s.WorkflowPermissions != nil {
+		validator.Append(s.WorkflowPermissions.ValidateInfo(), *s.WorkflowPermissions, "WorkflowPermissions")
+	}
+	for branch, mq := range s.MergeQueues {
+		if branch == "" {
+			validator.Required("MergeQueues")
+			continue
+		}
+		mq.Default()
+		validator.Append(mq.ValidateInfo(), mq, "MergeQueues")
+	}
+	for _, f := range s.InitialFiles {
+		if f.Path == nil || *f.Path == "" || f.Content == nil {
+			validator.Required("InitialFiles")
+			break
+		}
+	}
+	return validator.Error()
+}
+
+// renderInitialFiles returns the initial files with their content rendered with TemplateData.
+// ErrInvalidArgument is returned if a file isn't a valid template, or fails to render.
+func (s RepositorySettings) renderInitialFiles() ([]CommitFile, error) {
+	if s.TemplateData == nil {
+		return s.InitialFiles, nil
+	}
+	files := make([]CommitFile, 0, len(s.InitialFiles))
+	for _, f := range s.InitialFiles {
+		// Fail on missing keys, as they're most likely typos in the placeholders
+		tmpl, err := template.New(*f.Path).Option("missingkey=error").Parse(*f.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse initial file %q: %v: %w", *f.Path, err, ErrInvalidArgument)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, s.TemplateData); err != nil {
+			return nil, fmt.Errorf("failed to render initial file %q: %v: %w", *f.Path, err, ErrInvalidArgument)
+		}
+		files = append(files, CommitFile{Path: f.Path, Content: StringVar(buf.String())})
+	}
+	return files, nil
+}
+
+// CreateWithSettings creates the repository ref with c, and applies settings to it straight away.
+// Each kind of setting takes at most one reconcile against the fresh repository, and settings
+// which are unset aren't touched, keeping the number of API calls low when provisioning many
+// repositories at once.
+//
+// The settings are validated, and the initial files rendered, before the repository is created.
+// ErrNoProviderSupport is returned if the provider doesn't support a setting which is set. If
+// applying a setting fails, the created repository is returned along with the error, so that the
+// caller can retry or delete it.
+func CreateWithSettings(ctx context.Context, c OrgRepositoriesClient, ref OrgRepositoryRef, req RepositoryInfo, settings RepositorySettings, opts ...RepositoryCreateOption) (OrgRepository, error) {
+	if err := settings.ValidateInfo(); err != nil {
+		return nil, err
+	}
+	files, err := settings.renderInitialFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := c.Create(ctx, ref, req, opts...)
+	if err != nil {
+		// The repository may have been created despite the error, see RepositoryCreateOptions
+		return repo, err
+	}
+	if err := applyRepositorySettings(ctx, repo, files, settings); err != nil {
+		return repo, fmt.Errorf("failed to apply the settings of repository %s: %w", ref.String(), err)
+	}
+	return repo, nil
+}
+
+// applyRepositorySettings commits the rendered initial files to repo, and applies the set fields
+// of settings to it.
+func applyRepositorySettings(ctx context.Context, repo OrgRepository, files []CommitFile, settings RepositorySettings) error {
+	if len(files) != 0 {
+		branch := ""
+		if b := repo.Get().DefaultBranch; b != nil {
+			branch = *b
+		}
+		commits := repo.Commits()
+		if _, err := commits.Create(ctx, branch, initialFilesCommitMessage, encodeCommitFiles(commits, files)); err != nil {
+			return fmt.Errorf("initial files: %w", err)
+		}
+	}
+	if len(settings.Topics) != 0 {
+		topics, err := repo.Topics()
+		if err != nil {
+			return fmt.Errorf("topics: %w", err)
+		}
+		if _, err := topics.Reconcile(ctx, settings.Topics); err != nil {
+			return fmt.Errorf("topics: %w", err)
+		}
+	}
+	if settings.ApprovalSettings != nil {
+		approvals, err := repo.ApprovalSettings()
+		if err != nil {
+			return fmt.Errorf("approval settings: %w", err)
+		}
+		if _, err := approvals.Reconcile(ctx, *settings.ApprovalSettings); err != nil {
+			return fmt.Errorf("approval settings: %w", err)
+		}
+	}
+	if settings.WorkflowPermissions != nil {
+		if _, err := repo.ReconcileWorkflowPermissions(ctx, *settings.WorkflowPermissions); err != nil {
+			return fmt.Errorf("workflow permissions: %w", err)
+		}
+	}
+	// Sort the branches to configure the merge queues in a deterministic order
+	branches := make([]string, 0, len(settings.MergeQueues))
+	for branch := range settings.MergeQueues {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+	for _, branch := range branches {
+		if _, err := repo.ReconcileMergeQueue(ctx, branch, settings.MergeQueues[branch]); err != nil {
+			return fmt.Errorf("merge queue of branch %q: %w", branch, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// fakeSettingsRepository is an OrgRepository recording the settings applied to it.
+// Only the methods used by CreateWithSettings are implemented.
+type fakeSettingsRepository struct {
+	OrgRepository
+	topics              fakeTopics
+	workflowPermissions *WorkflowPermissionsInfo
+	mergeQueues         []string
+	commits             memoryRepo
+}
+
+func (r *fakeSettingsRepository) Get() RepositoryInfo {
+	return RepositoryInfo{DefaultBranch: StringVar("main")}
+}
+
+func (r *fakeSettingsRepository) Commits() CommitClient { return memoryCommits{&r.commits} }
+
+func (r *fakeSettingsRepository) Topics() (TopicsClient, error) { return &r.topics, nil }
+
+func (r *fakeSettingsRepository) ApprovalSettings() (ApprovalSettingsClient, error) {
+	return nil, ErrNoProviderSupport
+}
+
+func (r *fakeSettingsRepository) ReconcileWorkflowPermissions(_ context.Context, req WorkflowPermissionsInfo) (bool, error) {
+	r.workflowPermissions = &req
+	return true, nil
+}
+
+func (r *fakeSettingsRepository) ReconcileMergeQueue(_ context.Context, branch string, _ MergeQueueInfo) (bool, error) {
+	r.mergeQueues = append(r.mergeQueues, branch)
+	return true, nil
+}
+
+// fakeTopics is a TopicsClient storing the topics in memory.
+type fakeTopics struct {
+	topics []string
+}
+
+func (t *fakeTopics) Get(_ context.Context) ([]string, error) { return t.topics, nil }
+
+func (t *fakeTopics) Reconcile(_ context.Context, topics []string, _ ...TopicsReconcileOption) (bool, error) {
+	t.topics = topics
+	return true, nil
+}
+
+// fakeSettingsClient is an OrgRepositoriesClient creating fakeSettingsRepositories.
+type fakeSettingsClient struct {
+	OrgRepositoriesClient
+	repo *fakeSettingsRepository
+}
+
+func (c *fakeSettingsClient) Create(_ context.Context, _ OrgRepositoryRef, _ RepositoryInfo, _ ...RepositoryCreateOption) (OrgRepository, error) {
+	c.repo = &fakeSettingsRepository{}
+	return c.repo, nil
+}
+
+func TestCreateWithSettings(t *testing.T) {
+	ref := OrgRepositoryRef{OrganizationRef: OrganizationRef{Domain: "example.com", Organization: "flux"}, RepositoryName: "repo"}
+	tests := []struct {
+		name          string
+		settings      RepositorySettings
+		wantCreated   bool
+		wantErr       error
+		wantTopics    []string
+		wantQueues    []string
+		wantWorkflows bool
+	}{
+		{
+			name:        "no settings",
+			wantCreated: true,
+		},
+		{
+			name: "all supported settings",
+			settings: RepositorySettings{
+				Topics:              []string{"flux", "gitops"},
+				WorkflowPermissions: &WorkflowPermissionsInfo{DefaultPermission: WorkflowPermissionVar(WorkflowPermissionRead)},
+				MergeQueues: map[string]MergeQueueInfo{
+					"release": {Enabled: true},
+					"main":    {Enabled: true},
+				},
+			},
+			wantCreated:   true,
+			wantTopics:    []string{"flux", "gitops"},
+			wantQueues:    []string{"main", "release"},
+			wantWorkflows: true,
+		},
+		{
+			name:        "unsupported setting",
+			settings:    RepositorySettings{ApprovalSettings: &ApprovalSettingsInfo{ResetApprovalsOnPush: BoolVar(true)}},
+			wantCreated: true,
+			wantErr:     ErrNoProviderSupport,
+		},
+		{
+			name:     "invalid setting",
+			settings: RepositorySettings{Topics: []string{" "}},
+			wantErr:  validation.ErrFieldRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeSettingsClient{}
+			repo, err := CreateWithSettings(context.Background(), c, ref, RepositoryInfo{}, tt.settings)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("CreateWithSettings() expected an error")
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateWithSettings() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("CreateWithSettings() error = %v", err)
+			}
+			if gotCreated := c.repo != nil; gotCreated != tt.wantCreated {
+				t.Fatalf("CreateWithSettings() created = %v, want %v", gotCreated, tt.wantCreated)
+			}
+			if !tt.wantCreated {
+				return
+			}
+			if repo == nil {
+				t.Fatal("CreateWithSettings() didn't return the created repository")
+			}
+			if !reflect.DeepEqual(c.repo.topics.topics, tt.wantTopics) {
+				t.Errorf("topics = %v, want %v", c.repo.topics.topics, tt.wantTopics)
+			}
+			if !reflect.DeepEqual(c.repo.mergeQueues, tt.wantQueues) {
+				t.Errorf("merge queues = %v, want %v", c.repo.mergeQueues, tt.wantQueues)
+			}
+			if gotWorkflows := c.repo.workflowPermissions != nil; gotWorkflows != tt.wantWorkflows {
+				t.Errorf("workflow permissions set = %v, want %v", gotWorkflows, tt.wantWorkflows)
+			}
+		})
+	}
+}
+
+func TestCreateWithSettings_InitialFiles(t *testing.T) {
+	ref := OrgRepositoryRef{OrganizationRef: OrganizationRef{Domain: "example.com", Organization: "flux"}, RepositoryName: "repo"}
+	tests := []struct {
+		name      string
+		content   string
+		data      map[string]any
+		wantFile  string
+		wantErr   error
+		wantInErr string
+	}{
+		{
+			name:     "substituted",
+			content:  "# {{ .RepoName }}\n",
+			data:     map[string]any{"RepoName": "repo"},
+			wantFile: "# repo\n",
+		},
+		{
+			name:     "no template data",
+			content:  "# {{ .RepoName }}\n",
+			wantFile: "# {{ .RepoName }}\n",
+		},
+		{
+			name:      "parse error",
+			content:   "# {{ .RepoName }\n",
+			data:      map[string]any{"RepoName": "repo"},
+			wantErr:   ErrInvalidArgument,
+			wantInErr: "README.md",
+		},
+		{
+			name:      "missing key",
+			content:   "# {{ .Name }}\n",
+			data:      map[string]any{"RepoName": "repo"},
+			wantErr:   ErrInvalidArgument,
+			wantInErr: "README.md",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeSettingsClient{}
+			_, err := CreateWithSettings(context.Background(), c, ref, RepositoryInfo{}, RepositorySettings{
+				InitialFiles: []CommitFile{{Path: StringVar("README.md"), Content: StringVar(tt.content)}},
+				TemplateData: tt.data,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), tt.wantInErr) {
+					t.Fatalf("CreateWithSettings() error = %v, want %v naming %q", err, tt.wantErr, tt.wantInErr)
+				}
+				if c.repo != nil {
+					t.Error("CreateWithSettings() created the repository despite the invalid template")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateWithSettings() error = %v", err)
+			}
+			if len(c.repo.commits.commits) != 1 {
+				t.Fatalf("CreateWithSettings() made %d commits, want 1", len(c.repo.commits.commits))
+			}
+			if got := c.repo.commits.files["README.md"]; got != tt.wantFile {
+				t.Errorf("README.md = %q, want %q", got, tt.wantFile)
+			}
+		})
+	}
+}
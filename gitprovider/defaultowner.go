@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultOwner resolves repository names against the owner configured through WithDefaultOwner.
+// Providers use it to implement Client.Repo. The zero value, or a nil DefaultOwner, resolves
+// nothing and returns ErrNoDefaultOwner.
+type DefaultOwner struct {
+	ref IdentityRef
+
+	// mu guards validated, which is set once the organization is known to exist
+	mu        sync.Mutex
+	validated bool
+}
+
+// NewDefaultOwner returns a DefaultOwner resolving repository names against ref, which may be nil.
+func NewDefaultOwner(ref IdentityRef) *DefaultOwner {
+	return &DefaultOwner{ref: ref}
+}
+
+// RepositoryRef returns the reference of the repository with the given name, owned by the
+// default owner.
+func (o *DefaultOwner) RepositoryRef(name string) (RepositoryRef, error) {
+	if o == nil || o.ref == nil {
+		return nil, ErrNoDefaultOwner
+	}
+	switch ref := o.ref.(type) {
+	case OrganizationRef:
+		return OrgRepositoryRef{OrganizationRef: ref, RepositoryName: name}, nil
+	case *OrganizationRef:
+		return OrgRepositoryRef{OrganizationRef: *ref, RepositoryName: name}, nil
+	case UserRef:
+		return UserRepositoryRef{UserRef: ref, RepositoryName: name}, nil
+	case *UserRef:
+		return UserRepositoryRef{UserRef: *ref, RepositoryName: name}, nil
+	}
+	return nil, fmt.Errorf("default owner %s is neither an OrganizationRef nor a UserRef: %w", o.ref, ErrInvalidArgument)
+}
+
+// Repository gets the repository with the given name, owned by the default owner.
+//
+// An organization is checked to exist on the first call, so that a misconfigured owner isn't
+// reported as a missing repository. Users can't be looked up in a provider-neutral way, a
+// missing user results in ErrNotFound as for a missing repository.
+func (o *DefaultOwner) Repository(ctx context.Context, c ResourceClient, name string) (UserRepository, error) {
+	ref, err := o.RepositoryRef(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ref := ref.(type) {
+	case OrgRepositoryRef:
+		if err := o.validateOrganization(ctx, c, ref.OrganizationRef); err != nil {
+			return nil, err
+		}
+		return c.OrgRepositories().Get(ctx, ref)
+	case UserRepositoryRef:
+		return c.UserRepositories().Get(ctx, ref)
+	}
+	return nil, fmt.Errorf("unexpected repository reference %s: %w", ref, ErrInvalidArgument)
+}
+
+// validateOrganization makes sure the organization exists, once. Failures are not remembered,
+// so that transient errors are retried on the next call.
+func (o *DefaultOwner) validateOrganization(ctx context.Context, c ResourceClient, ref OrganizationRef) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.validated {
+		return nil
+	}
+	if _, err := c.Organizations().Get(ctx, ref); err != nil {
+		return fmt.Errorf("failed to get default owner %s: %w", ref, err)
+	}
+	o.validated = true
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDefaultOwner_RepositoryRef(t *testing.T) {
+	org := OrganizationRef{Domain: "github.com", Organization: "fluxcd"}
+	user := UserRef{Domain: "github.com", UserLogin: "alice"}
+	tests := []struct {
+		name    string
+		owner   *DefaultOwner
+		want    RepositoryRef
+		wantErr error
+	}{
+		{
+			name:  "organization",
+			owner: NewDefaultOwner(org),
+			want:  OrgRepositoryRef{OrganizationRef: org, RepositoryName: "repo"},
+		},
+		{
+			name:  "user",
+			owner: NewDefaultOwner(user),
+			want:  UserRepositoryRef{UserRef: user, RepositoryName: "repo"},
+		},
+		{
+			name:  "pointer to a user",
+			owner: NewDefaultOwner(&user),
+			want:  UserRepositoryRef{UserRef: user, RepositoryName: "repo"},
+		},
+		{
+			name:    "unset",
+			owner:   NewDefaultOwner(nil),
+			wantErr: ErrNoDefaultOwner,
+		},
+		{
+			name:    "nil",
+			wantErr: ErrNoDefaultOwner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.owner.RepositoryRef("repo")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RepositoryRef() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RepositoryRef() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// deployKeyBatchConcurrency bounds the number of deploy keys ReconcileDeployKeys creates or
+// updates concurrently, to stay well within the rate limits of the Git providers.
+const deployKeyBatchConcurrency = 4
+
+// DeployKeyBatchResult is the result of reconciling a single deploy key of a batch.
+type DeployKeyBatchResult struct {
+	// DeployKey is the reconciled deploy key, unset if Err is set.
+	DeployKey DeployKey
+
+	// ActionTaken is true if the deploy key was created or updated.
+	ActionTaken bool
+
+	// Err is the error that occurred while reconciling this deploy key, if any.
+	Err error
+}
+
+// ReconcileDeployKeys makes sure the given desired deploy keys (reqs) become the actual state of the
+// repository c operates on, listing its deploy keys only once.
+//
+// Requests are deduplicated by normalized key content, i.e. the key type and data without comment,
+// and existing deploy keys are matched by key content before name, so that re-runs are no-ops.
+// At most deployKeyBatchConcurrency deploy keys are created or updated concurrently.
+//
+// The returned results are in the order of reqs, a duplicate request gets the result of the first
+// request with the same key content, with ActionTaken == false. An error is returned if any request
+// is invalid or the deploy keys can't be listed; errors of single deploy keys are set in their result.
+func ReconcileDeployKeys(ctx context.Context, c DeployKeyClient, reqs []DeployKeyInfo) ([]DeployKeyBatchResult, error) {
+	// Validate and default copies of the requests, to not modify the caller's slice
+	desired := make([]DeployKeyInfo, len(reqs))
+	copy(desired, reqs)
+	for i := range desired {
+		if err := ValidateAndDefaultInfo(&desired[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	actuals, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actualsByKey := make(map[string]DeployKey, len(actuals))
+	actualsByName := make(map[string]DeployKey, len(actuals))
+	for _, actual := range actuals {
+		info := actual.Get()
+		actualsByKey[normalizeDeployKey(info.Key)] = actual
+		actualsByName[info.Name] = actual
+	}
+
+	results := make([]DeployKeyBatchResult, len(desired))
+	firstByKey := make(map[string]int, len(desired))
+	claimed := make(map[DeployKey]struct{}, len(desired))
+	sem := make(chan struct{}, deployKeyBatchConcurrency)
+	var wg sync.WaitGroup
+	for i := range desired {
+		key := normalizeDeployKey(desired[i].Key)
+		if _, ok := firstByKey[key]; ok {
+			continue
+		}
+		firstByKey[key] = i
+
+		actual, ok := actualsByKey[key]
+		if !ok {
+			actual = actualsByName[desired[i].Name]
+		}
+		// Don't let two requests reconcile the same deploy key
+		if actual != nil {
+			if _, ok := claimed[actual]; ok {
+				actual = nil
+			} else {
+				claimed[actual] = struct{}{}
+			}
+		}
+
+		wg.Add(1)
+		go func(i int, actual DeployKey) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = reconcileDeployKey(ctx, c, desired[i], actual)
+		}(i, actual)
+	}
+	wg.Wait()
+
+	for i := range desired {
+		if first := firstByKey[normalizeDeployKey(desired[i].Key)]; first != i {
+			results[i] = DeployKeyBatchResult{DeployKey: results[first].DeployKey, Err: results[first].Err}
+		}
+	}
+	return results, nil
+}
+
+// reconcileDeployKey creates req if actual is nil, or updates actual if it doesn't match req.
+func reconcileDeployKey(ctx context.Context, c DeployKeyClient, req DeployKeyInfo, actual DeployKey) DeployKeyBatchResult {
+	if actual == nil {
+		resp, err := c.Create(ctx, req)
+		if err != nil {
+			return DeployKeyBatchResult{Err: err}
+		}
+		return DeployKeyBatchResult{DeployKey: resp, ActionTaken: true}
+	}
+
+	// Providers may strip the key comment, compare the normalized keys instead
+	actualInfo := actual.Get()
+	if normalizeDeployKey(req.Key) == normalizeDeployKey(actualInfo.Key) {
+		req.Key = actualInfo.Key
+	}
+	if req.Equals(actualInfo) {
+		return DeployKeyBatchResult{DeployKey: actual}
+	}
+
+	if err := actual.Set(req); err != nil {
+		return DeployKeyBatchResult{Err: err}
+	}
+	if err := actual.Update(ctx); err != nil {
+		return DeployKeyBatchResult{Err: err}
+	}
+	return DeployKeyBatchResult{DeployKey: actual, ActionTaken: true}
+}
+
+// normalizeDeployKey returns the type and data of an authorized_keys formatted public key,
+// without the comment and surrounding whitespace.
+func normalizeDeployKey(key []byte) string {
+	fields := strings.Fields(string(key))
+	if len(fields) < 2 {
+		return strings.TrimSpace(string(key))
+	}
+	return fields[0] + " " + fields[1]
+}
+
+// DeployKeyFingerprint returns the SHA256 fingerprint of an authorized_keys formatted public key,
+// as printed by ssh-keygen -l, e.g. "SHA256:KMfTVsNiAiJWpmRtthaD2VhAOv1H7u/9uER9xBnI+j8".
+// The comment and the surrounding whitespace of key, including line endings, are ignored.
+func DeployKeyFingerprint(key []byte) (string, error) {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(normalizeDeployKey(key)))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse SSH public key: %v: %w", err, ErrInvalidArgument)
+	}
+	return ssh.FingerprintSHA256(pub), nil
+}
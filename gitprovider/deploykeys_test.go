@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// memoryDeployKeys is an in-memory DeployKeyClient counting the calls made to it.
+type memoryDeployKeys struct {
+	mu      sync.Mutex
+	keys    []*memoryDeployKey
+	lists   int
+	creates int
+	updates int
+}
+
+func (c *memoryDeployKeys) Get(_ context.Context, name string) (DeployKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range c.keys {
+		if k.info.Name == name {
+			return k, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (c *memoryDeployKeys) List(_ context.Context) ([]DeployKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lists++
+	keys := make([]DeployKey, 0, len(c.keys))
+	for _, k := range c.keys {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (c *memoryDeployKeys) Create(_ context.Context, req DeployKeyInfo) (DeployKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.creates++
+	k := &memoryDeployKey{c: c, info: req}
+	c.keys = append(c.keys, k)
+	return k, nil
+}
+
+func (c *memoryDeployKeys) Reconcile(_ context.Context, _ DeployKeyInfo) (DeployKey, bool, error) {
+	return nil, false, ErrNoProviderSupport
+}
+
+func (c *memoryDeployKeys) ReconcileBatch(ctx context.Context, reqs []DeployKeyInfo) ([]DeployKeyBatchResult, error) {
+	return ReconcileDeployKeys(ctx, c, reqs)
+}
+
+type memoryDeployKey struct {
+	c    *memoryDeployKeys
+	info DeployKeyInfo
+}
+
+func (k *memoryDeployKey) APIObject() interface{}                    { return &k.info }
+func (k *memoryDeployKey) Repository() RepositoryRef                 { return nil }
+func (k *memoryDeployKey) Get() DeployKeyInfo                        { return k.info }
+func (k *memoryDeployKey) Delete(_ context.Context) error            { return nil }
+func (k *memoryDeployKey) Reconcile(_ context.Context) (bool, error) { return false, nil }
+
+func (k *memoryDeployKey) Set(info DeployKeyInfo) error {
+	k.info = info
+	return nil
+}
+
+func (k *memoryDeployKey) Update(_ context.Context) error {
+	k.c.mu.Lock()
+	defer k.c.mu.Unlock()
+	k.c.updates++
+	return nil
+}
+
+func TestReconcileDeployKeys(t *testing.T) {
+	c := &memoryDeployKeys{}
+	c.keys = []*memoryDeployKey{
+		// Providers may strip the comment of the key
+		{c: c, info: DeployKeyInfo{Name: "existing", Key: []byte("ssh-ed25519 AAAAexisting"), ReadOnly: BoolVar(true)}},
+		{c: c, info: DeployKeyInfo{Name: "writable", Key: []byte("ssh-ed25519 AAAAwritable"), ReadOnly: BoolVar(true)}},
+	}
+	reqs := []DeployKeyInfo{
+		{Name: "existing", Key: []byte("ssh-ed25519 AAAAexisting flux@cluster\n")},
+		{Name: "new", Key: []byte("ssh-ed25519 AAAAnew flux@cluster")},
+		{Name: "new-duplicate", Key: []byte("  ssh-ed25519 AAAAnew other@cluster")},
+		{Name: "writable", Key: []byte("ssh-ed25519 AAAAwritable"), ReadOnly: BoolVar(false)},
+	}
+
+	results, err := c.ReconcileBatch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("ReconcileBatch() error = %v", err)
+	}
+	gotActions := []bool{}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Errorf("ReconcileBatch() result %d error = %v", i, r.Err)
+		}
+		gotActions = append(gotActions, r.ActionTaken)
+	}
+	if diff := cmp.Diff([]bool{false, true, false, true}, gotActions); diff != "" {
+		t.Errorf("ReconcileBatch() actions (-want +got):\n%s", diff)
+	}
+	if results[2].DeployKey != results[1].DeployKey {
+		t.Errorf("ReconcileBatch() expected the duplicate to get the deploy key of the first request")
+	}
+	if c.lists != 1 || c.creates != 1 || c.updates != 1 {
+		t.Errorf("ReconcileBatch() lists = %d, creates = %d, updates = %d, want 1, 1, 1", c.lists, c.creates, c.updates)
+	}
+	if reqs[0].ReadOnly != nil {
+		t.Errorf("ReconcileBatch() modified the requests")
+	}
+
+	// Re-running the same batch is a no-op
+	results, err = c.ReconcileBatch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("ReconcileBatch() error = %v", err)
+	}
+	for i, r := range results {
+		if r.ActionTaken {
+			t.Errorf("ReconcileBatch() re-run took action for request %d", i)
+		}
+	}
+	if c.creates != 1 || c.updates != 1 {
+		t.Errorf("ReconcileBatch() re-run creates = %d, updates = %d, want 1, 1", c.creates, c.updates)
+	}
+}
+
+func TestReconcileDeployKeysValidation(t *testing.T) {
+	c := &memoryDeployKeys{}
+	if _, err := c.ReconcileBatch(context.Background(), []DeployKeyInfo{{Name: "no-key"}}); err == nil {
+		t.Error("ReconcileBatch() expected an error")
+	}
+	if c.lists != 0 {
+		t.Errorf("ReconcileBatch() listed the deploy keys of an invalid batch")
+	}
+}
+
+func TestDeployKeyFingerprint(t *testing.T) {
+	// The fingerprint printed by ssh-keygen -lf for the key
+	const want = "SHA256:KMfTVsNiAiJWpmRtthaD2VhAOv1H7u/9uER9xBnI+j8"
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{
+			name: "with comment",
+			key:  "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHjOPER7YZeZ4xWXO3stnnb0HFDmD/vk+IiVhEQGiA6Y flux@example.com",
+		},
+		{
+			name: "without comment",
+			key:  "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHjOPER7YZeZ4xWXO3stnnb0HFDmD/vk+IiVhEQGiA6Y",
+		},
+		{
+			name: "trailing newline",
+			key:  "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHjOPER7YZeZ4xWXO3stnnb0HFDmD/vk+IiVhEQGiA6Y flux@example.com\n",
+		},
+		{
+			name: "CRLF line ending",
+			key:  "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHjOPER7YZeZ4xWXO3stnnb0HFDmD/vk+IiVhEQGiA6Y\r\n",
+		},
+		{
+			name:    "not a public key",
+			key:     "ssh-ed25519 AAAAnot-base64",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeployKeyFingerprint([]byte(tt.key))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeployKeyFingerprint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != want {
+				t.Errorf("DeployKeyFingerprint() = %q, want %q", got, want)
+			}
+		})
+	}
+}
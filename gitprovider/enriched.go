@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// EnrichedRepository is an organization repository, along with the details which take extra API
+// calls to read. Details the provider doesn't support or has no value for are left nil.
+type EnrichedRepository struct {
+	// Repository is the repository, as returned by Get.
+	Repository OrgRepository
+
+	// Topics are the topics of the repository.
+	Topics []string
+
+	// Language is the primary language of the repository.
+	Language *string
+
+	// License is the license detected in the repository.
+	License *LicenseInfo
+
+	// Counts are the number of open pull requests, open issues, branches and tags.
+	Counts *RepoCounts
+}
+
+// GetEnriched gets the repository ref with c, and reads its topics, primary language, license and
+// counts concurrently. It's meant for building catalogs of repositories, where reading each detail
+// separately would be cumbersome; use Get when the details aren't needed.
+//
+// On top of Get, this costs one API call per detail on most providers, and several for the counts,
+// see OrgRepository.Counts. All are in flight at once. Details the provider doesn't support or
+// doesn't find are left nil, other errors are returned.
+func GetEnriched(ctx context.Context, c OrgRepositoriesClient, ref OrgRepositoryRef) (EnrichedRepository, error) {
+	repo, err := c.Get(ctx, ref)
+	if err != nil {
+		return EnrichedRepository{}, err
+	}
+	enriched := EnrichedRepository{Repository: repo}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	fetch := func(detail string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := fn()
+			if err == nil || errors.Is(err, ErrNotFound) || errors.Is(err, ErrNoProviderSupport) {
+				return
+			}
+			errOnce.Do(func() {
+				firstErr = fmt.Errorf("failed to get the %s of repository %s: %w", detail, ref.String(), err)
+				cancel()
+			})
+		}()
+	}
+
+	fetch("topics", func() error {
+		topics, err := repo.Topics()
+		if err != nil {
+			return err
+		}
+		enriched.Topics, err = topics.Get(ctx)
+		return err
+	})
+	fetch("language", func() error {
+		language, err := repo.Language(ctx)
+		if err == nil {
+			enriched.Language = &language
+		}
+		return err
+	})
+	fetch("license", func() error {
+		license, err := repo.License(ctx)
+		if err == nil {
+			enriched.License = &license
+		}
+		return err
+	})
+	fetch("counts", func() error {
+		counts, err := repo.Counts(ctx)
+		if err == nil {
+			enriched.Counts = &counts
+		}
+		return err
+	})
+	wg.Wait()
+
+	if firstErr != nil {
+		return EnrichedRepository{}, firstErr
+	}
+	return enriched, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeEnrichedRepository is an OrgRepository reporting fixed details.
+// Only the methods used by GetEnriched are implemented.
+type fakeEnrichedRepository struct {
+	OrgRepository
+	info       RepositoryInfo
+	topics     fakeTopics
+	language   string
+	licenseErr error
+	countsErr  error
+}
+
+func (r *fakeEnrichedRepository) Get() RepositoryInfo           { return r.info }
+func (r *fakeEnrichedRepository) Topics() (TopicsClient, error) { return &r.topics, nil }
+func (r *fakeEnrichedRepository) Language(context.Context) (string, error) {
+	return r.language, nil
+}
+
+func (r *fakeEnrichedRepository) License(context.Context) (LicenseInfo, error) {
+	if r.licenseErr != nil {
+		return LicenseInfo{}, r.licenseErr
+	}
+	return LicenseInfo{SPDXID: "Apache-2.0"}, nil
+}
+
+func (r *fakeEnrichedRepository) Counts(context.Context) (RepoCounts, error) {
+	if r.countsErr != nil {
+		return RepoCounts{}, r.countsErr
+	}
+	return RepoCounts{Branches: ItemCount{Count: IntVar(2), Exact: true}}, nil
+}
+
+// fakeEnrichedClient is an OrgRepositoriesClient holding a single fakeEnrichedRepository.
+type fakeEnrichedClient struct {
+	OrgRepositoriesClient
+	repo *fakeEnrichedRepository
+}
+
+func (c *fakeEnrichedClient) Get(context.Context, OrgRepositoryRef) (OrgRepository, error) {
+	return c.repo, nil
+}
+
+func TestGetEnriched(t *testing.T) {
+	ref := OrgRepositoryRef{OrganizationRef: OrganizationRef{Domain: "example.com", Organization: "flux"}, RepositoryName: "repo"}
+	info := RepositoryInfo{
+		Description:   StringVar("A repository"),
+		DefaultBranch: StringVar("main"),
+		Visibility:    RepositoryVisibilityVar(RepositoryVisibilityPublic),
+	}
+	tests := []struct {
+		name       string
+		licenseErr error
+		countsErr  error
+		wantErr    error
+		want       EnrichedRepository
+	}{
+		{
+			name: "all details",
+			want: EnrichedRepository{
+				Topics:   []string{"flux"},
+				Language: StringVar("Go"),
+				License:  &LicenseInfo{SPDXID: "Apache-2.0"},
+				Counts:   &RepoCounts{Branches: ItemCount{Count: IntVar(2), Exact: true}},
+			},
+		},
+		{
+			name:       "missing and unsupported details",
+			licenseErr: ErrNotFound,
+			countsErr:  ErrNoProviderSupport,
+			want: EnrichedRepository{
+				Topics:   []string{"flux"},
+				Language: StringVar("Go"),
+			},
+		},
+		{
+			name:      "failing detail",
+			countsErr: ErrInvalidArgument,
+			wantErr:   ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeEnrichedRepository{
+				info:       info,
+				topics:     fakeTopics{topics: []string{"flux"}},
+				language:   "Go",
+				licenseErr: tt.licenseErr,
+				countsErr:  tt.countsErr,
+			}
+			got, err := GetEnriched(context.Background(), &fakeEnrichedClient{repo: repo}, ref)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetEnriched() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetEnriched() error = %v", err)
+			}
+			if !got.Repository.Get().Equals(info) {
+				t.Errorf("GetEnriched() repository = %+v, want %+v", got.Repository.Get(), info)
+			}
+			tt.want.Repository = repo
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetEnriched() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -46,6 +46,10 @@ const (
 	// RepositoryVisibilityPrivate specifies that the repository should only be accessible by
 	// specifically added team members.
 	RepositoryVisibilityPrivate = RepositoryVisibility("private")
+	// RepositoryVisibilityDefault specifies that the visibility of the repository is not managed.
+	// At creation time the visibility is omitted, so that the provider (or organization) default
+	// applies, and reconciliation leaves the actual visibility untouched.
+	RepositoryVisibilityDefault = RepositoryVisibility("default")
 )
 
 // knownRepositoryVisibilityValues is a map of known RepositoryVisibility values, used for validation.
@@ -55,6 +59,7 @@ var knownRepositoryVisibilityValues = map[RepositoryVisibility]struct{}{
 	RepositoryVisibilityPublic:   {},
 	RepositoryVisibilityInternal: {},
 	RepositoryVisibilityPrivate:  {},
+	RepositoryVisibilityDefault:  {},
 }
 
 // ValidateRepositoryVisibility validates a given RepositoryVisibility.
@@ -165,6 +170,250 @@ func LicenseTemplateVar(t LicenseTemplate) *LicenseTemplate {
 	return &t
 }
 
+// PermissionLevel is an enum specifying the effective permission level of the authenticated
+// user on a repository.
+type PermissionLevel string
+
+const (
+	// PermissionLevelNone specifies that the user has no explicit access to the repository.
+	PermissionLevelNone = PermissionLevel("none")
+	// PermissionLevelRead specifies that the user can read, but not write to the repository.
+	PermissionLevelRead = PermissionLevel("read")
+	// PermissionLevelWrite specifies that the user can read and write to the repository, but not
+	// administer it.
+	PermissionLevelWrite = PermissionLevel("write")
+	// PermissionLevelAdmin specifies that the user can administer the repository, e.g. manage its
+	// deploy keys and team access.
+	PermissionLevelAdmin = PermissionLevel("admin")
+)
+
+// knownPermissionLevelValues is a map of known PermissionLevel values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownPermissionLevelValues = map[PermissionLevel]struct{}{
+	PermissionLevelNone:  {},
+	PermissionLevelRead:  {},
+	PermissionLevelWrite: {},
+	PermissionLevelAdmin: {},
+}
+
+// ValidatePermissionLevel validates a given PermissionLevel.
+// Use as errs.Append(ValidatePermissionLevel(level), level, "FieldName").
+func ValidatePermissionLevel(l PermissionLevel) error {
+	_, ok := knownPermissionLevelValues[l]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// PermissionLevelVar returns a pointer to a PermissionLevel.
+func PermissionLevelVar(l PermissionLevel) *PermissionLevel {
+	return &l
+}
+
+// MilestoneState is an enum specifying the state of a milestone.
+type MilestoneState string
+
+const (
+	// MilestoneStateOpen specifies that the milestone is open.
+	MilestoneStateOpen = MilestoneState("open")
+	// MilestoneStateClosed specifies that the milestone is closed.
+	MilestoneStateClosed = MilestoneState("closed")
+)
+
+// knownMilestoneStateValues is a map of known MilestoneState values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownMilestoneStateValues = map[MilestoneState]struct{}{
+	MilestoneStateOpen:   {},
+	MilestoneStateClosed: {},
+}
+
+// ValidateMilestoneState validates a given MilestoneState.
+// Use as errs.Append(ValidateMilestoneState(state), state, "FieldName").
+func ValidateMilestoneState(s MilestoneState) error {
+	_, ok := knownMilestoneStateValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// MilestoneStateVar returns a pointer to a MilestoneState.
+func MilestoneStateVar(s MilestoneState) *MilestoneState {
+	return &s
+}
+
+// ReviewState is an enum specifying the state of a pull request review.
+type ReviewState string
+
+const (
+	// ReviewStateApproved specifies that the reviewer approved the pull request.
+	ReviewStateApproved = ReviewState("approved")
+	// ReviewStateChangesRequested specifies that the reviewer requested changes to the pull request.
+	ReviewStateChangesRequested = ReviewState("changes_requested")
+	// ReviewStateCommented specifies that the reviewer only commented on the pull request.
+	ReviewStateCommented = ReviewState("commented")
+	// ReviewStateDismissed specifies that the verdict of the reviewer was dismissed.
+	ReviewStateDismissed = ReviewState("dismissed")
+)
+
+// knownReviewStateValues is a map of known ReviewState values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownReviewStateValues = map[ReviewState]struct{}{
+	ReviewStateApproved:         {},
+	ReviewStateChangesRequested: {},
+	ReviewStateCommented:        {},
+	ReviewStateDismissed:        {},
+}
+
+// ValidateReviewState validates a given ReviewState.
+// Use as errs.Append(ValidateReviewState(state), state, "FieldName").
+func ValidateReviewState(s ReviewState) error {
+	_, ok := knownReviewStateValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// ReviewStateVar returns a pointer to a ReviewState.
+func ReviewStateVar(s ReviewState) *ReviewState {
+	return &s
+}
+
+// ReviewAction is an enum specifying the verdict submitted when reviewing a pull request.
+type ReviewAction string
+
+const (
+	// ReviewApprove approves the pull request.
+	ReviewApprove = ReviewAction("approve")
+	// ReviewRequestChanges requests changes to the pull request before it's merged.
+	ReviewRequestChanges = ReviewAction("request_changes")
+	// ReviewComment comments on the pull request, without a verdict.
+	ReviewComment = ReviewAction("comment")
+)
+
+// knownReviewActionValues is a map of known ReviewAction values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownReviewActionValues = map[ReviewAction]struct{}{
+	ReviewApprove:        {},
+	ReviewRequestChanges: {},
+	ReviewComment:        {},
+}
+
+// ValidateReviewAction validates a given ReviewAction.
+// Use as errs.Append(ValidateReviewAction(action), action, "FieldName").
+func ValidateReviewAction(a ReviewAction) error {
+	_, ok := knownReviewActionValues[a]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// ReviewActionVar returns a pointer to a ReviewAction.
+func ReviewActionVar(a ReviewAction) *ReviewAction {
+	return &a
+}
+
+// WebhookEvent is an enum specifying a provider-neutral type of event a webhook can be
+// triggered by.
+type WebhookEvent string
+
+const (
+	// WebhookEventPush specifies that the webhook is triggered by pushes to branches.
+	WebhookEventPush = WebhookEvent("push")
+	// WebhookEventTagPush specifies that the webhook is triggered by created or deleted tags.
+	WebhookEventTagPush = WebhookEvent("tag_push")
+	// WebhookEventPullRequest specifies that the webhook is triggered by pull request activity.
+	// This is called "merge request" in GitLab.
+	WebhookEventPullRequest = WebhookEvent("pull_request")
+	// WebhookEventPullRequestReview specifies that the webhook is triggered by pull request reviews.
+	WebhookEventPullRequestReview = WebhookEvent("pull_request_review")
+	// WebhookEventIssues specifies that the webhook is triggered by issue activity.
+	WebhookEventIssues = WebhookEvent("issues")
+	// WebhookEventComment specifies that the webhook is triggered by comments on issues,
+	// pull requests or commits.
+	WebhookEventComment = WebhookEvent("comment")
+	// WebhookEventRelease specifies that the webhook is triggered by release activity.
+	WebhookEventRelease = WebhookEvent("release")
+	// WebhookEventDeployment specifies that the webhook is triggered by deployments.
+	WebhookEventDeployment = WebhookEvent("deployment")
+	// WebhookEventPipeline specifies that the webhook is triggered by CI pipelines,
+	// e.g. GitHub workflow runs or GitLab pipelines.
+	WebhookEventPipeline = WebhookEvent("pipeline")
+	// WebhookEventWiki specifies that the webhook is triggered by wiki page changes.
+	WebhookEventWiki = WebhookEvent("wiki")
+	// WebhookEventRepository specifies that the webhook is triggered by changes to the
+	// repository itself, e.g. when it's renamed or archived.
+	WebhookEventRepository = WebhookEvent("repository")
+)
+
+// knownWebhookEventValues is a map of known WebhookEvent values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownWebhookEventValues = map[WebhookEvent]struct{}{
+	WebhookEventPush:              {},
+	WebhookEventTagPush:           {},
+	WebhookEventPullRequest:       {},
+	WebhookEventPullRequestReview: {},
+	WebhookEventIssues:            {},
+	WebhookEventComment:           {},
+	WebhookEventRelease:           {},
+	WebhookEventDeployment:        {},
+	WebhookEventPipeline:          {},
+	WebhookEventWiki:              {},
+	WebhookEventRepository:        {},
+}
+
+// ValidateWebhookEvent validates a given WebhookEvent.
+// Use as errs.Append(ValidateWebhookEvent(event), event, "FieldName").
+func ValidateWebhookEvent(e WebhookEvent) error {
+	_, ok := knownWebhookEventValues[e]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// WebhookContentType is an enum specifying the format the events are delivered in by a webhook.
+type WebhookContentType string
+
+const (
+	// WebhookContentTypeJSON delivers the events as a JSON body.
+	WebhookContentTypeJSON = WebhookContentType("json")
+	// WebhookContentTypeForm delivers the events as a form-encoded body, holding the JSON event in
+	// its "payload" field.
+	WebhookContentTypeForm = WebhookContentType("form")
+)
+
+// knownWebhookContentTypeValues is a map of known WebhookContentType values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownWebhookContentTypeValues = map[WebhookContentType]struct{}{
+	WebhookContentTypeJSON: {},
+	WebhookContentTypeForm: {},
+}
+
+// ValidateWebhookContentType validates a given WebhookContentType.
+// Use as errs.Append(ValidateWebhookContentType(contentType), contentType, "FieldName").
+func ValidateWebhookContentType(t WebhookContentType) error {
+	_, ok := knownWebhookContentTypeValues[t]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// WebhookContentTypeVar returns a pointer to a WebhookContentType.
+func WebhookContentTypeVar(t WebhookContentType) *WebhookContentType {
+	return &t
+}
+
 // TokenPermission is an enum specifying the permissions for a token.
 type TokenPermission int
 
@@ -183,3 +432,263 @@ const (
 	// MergeMethodSquash causes a pull request merge to first squash commits
 	MergeMethodSquash = MergeMethod("squash")
 )
+
+// knownMergeMethodValues is a map of known MergeMethod values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownMergeMethodValues = map[MergeMethod]struct{}{
+	MergeMethodMerge:  {},
+	MergeMethodSquash: {},
+}
+
+// ValidateMergeMethod validates a given MergeMethod.
+// Use as errs.Append(ValidateMergeMethod(method), method, "FieldName").
+func ValidateMergeMethod(m MergeMethod) error {
+	_, ok := knownMergeMethodValues[m]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// MergeMethodVar returns a pointer to a MergeMethod.
+func MergeMethodVar(m MergeMethod) *MergeMethod {
+	return &m
+}
+
+// CommitFileAction is an enum specifying what a commit does with a file.
+type CommitFileAction string
+
+const (
+	// CommitFileActionWrite creates the file, or overwrites it with the given content.
+	CommitFileActionWrite = CommitFileAction("write")
+
+	// CommitFileActionDelete deletes the file, which must exist.
+	CommitFileActionDelete = CommitFileAction("delete")
+)
+
+// knownCommitFileActionValues is a map of known CommitFileAction values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownCommitFileActionValues = map[CommitFileAction]struct{}{
+	CommitFileActionWrite:  {},
+	CommitFileActionDelete: {},
+}
+
+// ValidateCommitFileAction validates a given CommitFileAction.
+// Use as errs.Append(ValidateCommitFileAction(action), action, "FieldName").
+func ValidateCommitFileAction(a CommitFileAction) error {
+	_, ok := knownCommitFileActionValues[a]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// WorkflowPermission is an enum specifying the default permissions granted to the token of the
+// CI workflows of a repository.
+type WorkflowPermission string
+
+const (
+	// WorkflowPermissionRead grants the workflows read access to the repository contents.
+	WorkflowPermissionRead = WorkflowPermission("read")
+
+	// WorkflowPermissionWrite grants the workflows read and write access to the repository.
+	WorkflowPermissionWrite = WorkflowPermission("write")
+)
+
+// knownWorkflowPermissionValues is a map of known WorkflowPermission values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownWorkflowPermissionValues = map[WorkflowPermission]struct{}{
+	WorkflowPermissionRead:  {},
+	WorkflowPermissionWrite: {},
+}
+
+// ValidateWorkflowPermission validates a given WorkflowPermission.
+// Use as errs.Append(ValidateWorkflowPermission(permission), permission, "FieldName").
+func ValidateWorkflowPermission(p WorkflowPermission) error {
+	_, ok := knownWorkflowPermissionValues[p]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// WorkflowPermissionVar returns a pointer to a WorkflowPermission.
+func WorkflowPermissionVar(p WorkflowPermission) *WorkflowPermission {
+	return &p
+}
+
+// CodeScanningQuerySuite is an enum specifying the set of queries run by the code scanning
+// default setup of a repository.
+type CodeScanningQuerySuite string
+
+const (
+	// CodeScanningQuerySuiteDefault runs the queries with a low false positive rate.
+	CodeScanningQuerySuiteDefault = CodeScanningQuerySuite("default")
+
+	// CodeScanningQuerySuiteExtended runs the default queries and lower severity, lower
+	// precision ones.
+	CodeScanningQuerySuiteExtended = CodeScanningQuerySuite("extended")
+)
+
+// knownCodeScanningQuerySuiteValues is a map of known CodeScanningQuerySuite values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownCodeScanningQuerySuiteValues = map[CodeScanningQuerySuite]struct{}{
+	CodeScanningQuerySuiteDefault:  {},
+	CodeScanningQuerySuiteExtended: {},
+}
+
+// ValidateCodeScanningQuerySuite validates a given CodeScanningQuerySuite.
+// Use as errs.Append(ValidateCodeScanningQuerySuite(suite), suite, "FieldName").
+func ValidateCodeScanningQuerySuite(s CodeScanningQuerySuite) error {
+	_, ok := knownCodeScanningQuerySuiteValues[s]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// CodeScanningQuerySuiteVar returns a pointer to a CodeScanningQuerySuite.
+func CodeScanningQuerySuiteVar(s CodeScanningQuerySuite) *CodeScanningQuerySuite {
+	return &s
+}
+
+// InteractionLimit is an enum specifying which users may interact with a repository, e.g. comment,
+// open issues or create pull requests, while interactions are limited.
+type InteractionLimit string
+
+const (
+	// InteractionLimitExistingUsers limits interactions to users whose account isn't new.
+	InteractionLimitExistingUsers = InteractionLimit("existing_users")
+
+	// InteractionLimitContributorsOnly limits interactions to users who contributed to the
+	// repository before.
+	InteractionLimitContributorsOnly = InteractionLimit("contributors_only")
+
+	// InteractionLimitCollaboratorsOnly limits interactions to the collaborators of the repository.
+	InteractionLimitCollaboratorsOnly = InteractionLimit("collaborators_only")
+)
+
+// knownInteractionLimitValues is a map of known InteractionLimit values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownInteractionLimitValues = map[InteractionLimit]struct{}{
+	InteractionLimitExistingUsers:     {},
+	InteractionLimitContributorsOnly:  {},
+	InteractionLimitCollaboratorsOnly: {},
+}
+
+// ValidateInteractionLimit validates a given InteractionLimit.
+// Use as errs.Append(ValidateInteractionLimit(limit), limit, "FieldName").
+func ValidateInteractionLimit(l InteractionLimit) error {
+	_, ok := knownInteractionLimitValues[l]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// InteractionLimitVar returns a pointer to an InteractionLimit.
+func InteractionLimitVar(l InteractionLimit) *InteractionLimit {
+	return &l
+}
+
+// InteractionLimitExpiry is an enum specifying how long interactions with a repository stay limited.
+type InteractionLimitExpiry string
+
+const (
+	// InteractionLimitExpiryOneDay lifts the limit after a day.
+	InteractionLimitExpiryOneDay = InteractionLimitExpiry("one_day")
+
+	// InteractionLimitExpiryThreeDays lifts the limit after three days.
+	InteractionLimitExpiryThreeDays = InteractionLimitExpiry("three_days")
+
+	// InteractionLimitExpiryOneWeek lifts the limit after a week.
+	InteractionLimitExpiryOneWeek = InteractionLimitExpiry("one_week")
+
+	// InteractionLimitExpiryOneMonth lifts the limit after a month.
+	InteractionLimitExpiryOneMonth = InteractionLimitExpiry("one_month")
+
+	// InteractionLimitExpirySixMonths lifts the limit after six months.
+	InteractionLimitExpirySixMonths = InteractionLimitExpiry("six_months")
+)
+
+// knownInteractionLimitExpiryValues is a map of known InteractionLimitExpiry values, used for validation.
+//
+//nolint:gochecknoglobals
+var knownInteractionLimitExpiryValues = map[InteractionLimitExpiry]struct{}{
+	InteractionLimitExpiryOneDay:    {},
+	InteractionLimitExpiryThreeDays: {},
+	InteractionLimitExpiryOneWeek:   {},
+	InteractionLimitExpiryOneMonth:  {},
+	InteractionLimitExpirySixMonths: {},
+}
+
+// ValidateInteractionLimitExpiry validates a given InteractionLimitExpiry.
+// Use as errs.Append(ValidateInteractionLimitExpiry(expiry), expiry, "FieldName").
+func ValidateInteractionLimitExpiry(e InteractionLimitExpiry) error {
+	_, ok := knownInteractionLimitExpiryValues[e]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// InteractionLimitExpiryVar returns a pointer to an InteractionLimitExpiry.
+func InteractionLimitExpiryVar(e InteractionLimitExpiry) *InteractionLimitExpiry {
+	return &e
+}
+
+// AnnotationLevel is an enum specifying the severity of a check annotation.
+type AnnotationLevel string
+
+const (
+	// AnnotationLevelNotice specifies an informational annotation.
+	AnnotationLevelNotice = AnnotationLevel("notice")
+	// AnnotationLevelWarning specifies a warning annotation.
+	AnnotationLevelWarning = AnnotationLevel("warning")
+	// AnnotationLevelFailure specifies an annotation that made the check fail.
+	AnnotationLevelFailure = AnnotationLevel("failure")
+)
+
+// OrganizationRole is an enum specifying the role of a user in an organization.
+type OrganizationRole string
+
+const (
+	// OrganizationRoleMember is a plain member of the organization.
+	OrganizationRoleMember = OrganizationRole("member")
+	// OrganizationRoleAdmin administrates the repositories and teams of the organization.
+	OrganizationRoleAdmin = OrganizationRole("admin")
+	// OrganizationRoleOwner has full control over the organization, including its settings.
+	OrganizationRoleOwner = OrganizationRole("owner")
+)
+
+// knownOrganizationRoleValues is a map of known OrganizationRole values to their rank, used for
+// validation and for comparing roles.
+//
+//nolint:gochecknoglobals
+var knownOrganizationRoleValues = map[OrganizationRole]int{
+	OrganizationRoleMember: 1,
+	OrganizationRoleAdmin:  2,
+	OrganizationRoleOwner:  3,
+}
+
+// ValidateOrganizationRole validates a given OrganizationRole.
+// Use as errs.Append(ValidateOrganizationRole(role), role, "FieldName").
+func ValidateOrganizationRole(r OrganizationRole) error {
+	_, ok := knownOrganizationRoleValues[r]
+	if !ok {
+		return validation.ErrFieldEnumInvalid
+	}
+	return nil
+}
+
+// AtLeast returns true if the role r grants at least the privileges of role other, e.g. an owner
+// is at least an admin.
+func (r OrganizationRole) AtLeast(other OrganizationRole) bool {
+	rank, ok := knownOrganizationRoleValues[r]
+	return ok && rank >= knownOrganizationRoleValues[other]
+}
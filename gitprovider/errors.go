@@ -43,6 +43,16 @@ var (
 	ErrAlreadyExists = errors.New("resource already exists, cannot create object. Use Reconcile() to create it idempotently")
 	// ErrNotFound is returned by .Get() and .Update() calls if the given resource doesn't exist.
 	ErrNotFound = errors.New("the requested resource was not found")
+	// ErrFileNotFound is returned when committing the deletion of a file which doesn't exist.
+	ErrFileNotFound = errors.New("the file to delete was not found")
+	// ErrAmbiguousReference is returned when an abbreviated commit SHA matches several commits.
+	ErrAmbiguousReference = errors.New("the abbreviated commit SHA matches several commits")
+	// ErrPreconditionFailed is returned by conditional operations when the state on the server
+	// changed since it was last observed, e.g. because of a concurrent change.
+	ErrPreconditionFailed = errors.New("the resource changed since it was last observed")
+	// ErrNotReady is returned when the server is still computing the requested data. The request
+	// is retryable, the data is usually available after a while.
+	ErrNotReady = errors.New("the requested data is still being computed by the server, retry later")
 	// ErrInvalidServerData is returned when the server returned invalid data, e.g. missing required fields in the response.
 	ErrInvalidServerData = errors.New("got invalid data from server, don't know how to handle")
 
@@ -67,10 +77,19 @@ var (
 	// ErrInvalidPermissionLevel is the error returned when there is no mapping
 	// from the given level to the gitprovider levels.
 	ErrInvalidPermissionLevel = errors.New("invalid permission level")
+	// ErrNoDefaultOwner is returned when resolving a repository name without an owner configured
+	// through WithDefaultOwner.
+	ErrNoDefaultOwner = errors.New("no default owner configured")
+	// ErrPermissionNotSupported is returned when the provider can't express the requested
+	// permission level, e.g. the triage permission on a provider without such a role.
+	ErrPermissionNotSupported = errors.New("permission level not supported by the provider")
 	// ErrMissingHeader is returned when an expected header is missing from the HTTP response.
 	ErrMissingHeader = errors.New("header is missing")
 	// ErrGroupNotFound is returned when the gitlab group does not exist
 	ErrGroupNotFound = errors.New("404 Group Not Found")
+	// ErrIdentityTypeMismatch is returned when the identity of a reference is of another type than
+	// expected, e.g. when creating a user repository under the login of an organization.
+	ErrIdentityTypeMismatch = errors.New("the identity is an organization where a user is expected, or vice versa")
 )
 
 // HTTPError is an error that contains context about the HTTP request/response that failed.
@@ -103,6 +122,18 @@ type RateLimitError struct {
 	Reset time.Time `json:"reset"`
 }
 
+// SecondaryRateLimitError is an error, extending HTTPError, returned when a secondary rate limit
+// (also called abuse rate limit) is hit, e.g. by making too many concurrent requests. Unlike the
+// primary rate limit, it isn't tracked by request counts, and usually requires waiting longer.
+type SecondaryRateLimitError struct {
+	// SecondaryRateLimitError extends HTTPError.
+	HTTPError `json:",inline"`
+
+	// RetryAfter is how long the server asks to wait before retrying. It's zero if the server
+	// didn't suggest any wait.
+	RetryAfter time.Duration `json:"retryAfter"`
+}
+
 // ValidationError is an error, extending HTTPError, that contains context about failed server-side validation.
 type ValidationError struct {
 	// RateLimitError extends HTTPError.
@@ -149,3 +180,12 @@ func NewErrIncorrectUser(user string) *ErrIncorrectUser {
 func (e *ErrIncorrectUser) Error() string {
 	return fmt.Sprintf("incorrect user '%s' provided", e.user)
 }
+
+// NewErrIdentityTypeMismatch returns an error wrapping ErrIdentityTypeMismatch, telling that the
+// given identity is an organization if isOrganization is true, and a user otherwise.
+func NewErrIdentityTypeMismatch(identity string, isOrganization bool) error {
+	if isOrganization {
+		return fmt.Errorf("%q is an organization, use OrgRepositories() for its repositories: %w", identity, ErrIdentityTypeMismatch)
+	}
+	return fmt.Errorf("%q is a user, use UserRepositories() for its repositories: %w", identity, ErrIdentityTypeMismatch)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultFilesGetConcurrency is the number of files FetchFiles downloads at once by default. It's
+// kept low to stay well within the rate limits of the Git providers.
+const defaultFilesGetConcurrency = 4
+
+// FetchFiles calls fetch for each of the given paths, with at most concurrency calls in flight, and
+// returns the fetched files in the order of paths. A concurrency of 0 or less means the default of 4.
+//
+// The first error cancels the context passed to the other calls, and is returned.
+func FetchFiles(ctx context.Context, paths []string, concurrency int, fetch func(ctx context.Context, path string) (*CommitFile, error)) ([]*CommitFile, error) {
+	if concurrency <= 0 {
+		concurrency = defaultFilesGetConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	files := make([]*CommitFile, len(paths))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for i, path := range paths {
+		// Don't start any more downloads once an error occurred or ctx is done
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			file, err := fetch(ctx, path)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			files[i] = file
+		}(i, path)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// encodeCommitFiles encodes the raw content of files the way the Create method of c expects it,
+// if c is a CommitContentEncoder. files is returned as-is otherwise.
+func encodeCommitFiles(c CommitClient, files []CommitFile) []CommitFile {
+	encoder, ok := c.(CommitContentEncoder)
+	if !ok {
+		return files
+	}
+	encoded := make([]CommitFile, 0, len(files))
+	for _, file := range files {
+		if file.Content != nil {
+			file.Content = StringVar(encoder.EncodeCommitContent(*file.Content))
+		}
+		encoded = append(encoded, file)
+	}
+	return encoded
+}
+
+// ValidateCommitFiles makes sure the paths of the given files can be committed as-is, the same way
+// by all the providers. Paths are relative to the root of the repository, use "/" as separator, and
+// may point into directories which don't exist yet, these are created along with the files.
+//
+// As Git doesn't track directories, a path can't denote one, e.g. "a/b/": an empty directory is
+// committed as a placeholder file in it instead, e.g. "a/b/.gitkeep".
+//
+// The action of every file must be consistent with its content, i.e. deleted files have no content.
+func ValidateCommitFiles(files []CommitFile) error {
+	for _, file := range files {
+		if file.Path == nil {
+			return fmt.Errorf("file without path: %w", ErrInvalidArgument)
+		}
+		path := *file.Path
+		action := file.GetAction()
+		if err := ValidateCommitFileAction(action); err != nil {
+			return fmt.Errorf("invalid action %q for path %q: %w", action, path, ErrInvalidArgument)
+		}
+		if (action == CommitFileActionDelete) != (file.Content == nil) {
+			return fmt.Errorf("action %q doesn't match the content of path %q: %w", action, path, ErrInvalidArgument)
+		}
+		if strings.HasSuffix(path, "/") {
+			return fmt.Errorf("path %q is a directory, which Git can't commit without files: %w", path, ErrInvalidArgument)
+		}
+		for _, segment := range strings.Split(path, "/") {
+			switch segment {
+			case "", ".", "..":
+				return fmt.Errorf("path %q isn't a clean path relative to the repository root: %w", path, ErrInvalidArgument)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchFiles(t *testing.T) {
+	paths := make([]string, 50)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("file-%02d", i)
+	}
+
+	var inFlight, maxInFlight int32
+	files, err := FetchFiles(context.Background(), paths, 5, func(_ context.Context, path string) (*CommitFile, error) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			old := atomic.LoadInt32(&maxInFlight)
+			if cur <= old || atomic.CompareAndSwapInt32(&maxInFlight, old, cur) {
+				break
+			}
+		}
+		return &CommitFile{Path: StringVar(path)}, nil
+	})
+	if err != nil {
+		t.Fatalf("FetchFiles() error = %v", err)
+	}
+	for i, f := range files {
+		if *f.Path != paths[i] {
+			t.Errorf("files[%d].Path = %q, want %q", i, *f.Path, paths[i])
+		}
+	}
+	if maxInFlight > 5 {
+		t.Errorf("%d fetches were in flight at once, want at most 5", maxInFlight)
+	}
+}
+
+func TestFetchFiles_Error(t *testing.T) {
+	paths := make([]string, 50)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("file-%02d", i)
+	}
+
+	rateLimited := &RateLimitError{}
+	var calls int32
+	_, err := FetchFiles(context.Background(), paths, 2, func(ctx context.Context, path string) (*CommitFile, error) {
+		atomic.AddInt32(&calls, 1)
+		switch {
+		case path < "file-03":
+			return &CommitFile{Path: StringVar(path)}, nil
+		case path == "file-03":
+			return nil, rateLimited
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, rateLimited) {
+		t.Errorf("FetchFiles() error = %v, want %v", err, rateLimited)
+	}
+	if calls == int32(len(paths)) {
+		t.Error("FetchFiles() kept fetching after an error")
+	}
+}
+
+func TestFetchFiles_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := FetchFiles(ctx, []string{"a", "b"}, 0, func(_ context.Context, path string) (*CommitFile, error) {
+		return &CommitFile{Path: StringVar(path)}, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("FetchFiles() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestValidateCommitFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    *string
+		wantErr bool
+	}{
+		{name: "top-level file", path: StringVar("README.md")},
+		{name: "multi-level path", path: StringVar("a/b/c/file.txt")},
+		{name: "placeholder of empty directory", path: StringVar("a/b/.gitkeep")},
+		{name: "no path", wantErr: true},
+		{name: "empty path", path: StringVar(""), wantErr: true},
+		{name: "directory", path: StringVar("a/b/"), wantErr: true},
+		{name: "absolute path", path: StringVar("/a/file.txt"), wantErr: true},
+		{name: "empty segment", path: StringVar("a//file.txt"), wantErr: true},
+		{name: "dot segment", path: StringVar("./a/file.txt"), wantErr: true},
+		{name: "parent segment", path: StringVar("a/../file.txt"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommitFiles([]CommitFile{{Path: tt.path, Content: StringVar("content")}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCommitFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("ValidateCommitFiles() error = %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestValidateCommitFiles_Action(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    CommitFile
+		wantErr bool
+	}{
+		{name: "write", file: CommitFile{Path: StringVar("a.txt"), Content: StringVar("content")}},
+		{name: "explicit write", file: CommitFile{Path: StringVar("a.txt"), Action: CommitFileActionWrite, Content: StringVar("")}},
+		{name: "implicit delete", file: CommitFile{Path: StringVar("a.txt")}},
+		{name: "explicit delete", file: CommitFile{Path: StringVar("a.txt"), Action: CommitFileActionDelete}},
+		{name: "delete with content", file: CommitFile{Path: StringVar("a.txt"), Action: CommitFileActionDelete, Content: StringVar("content")}, wantErr: true},
+		{name: "write without content", file: CommitFile{Path: StringVar("a.txt"), Action: CommitFileActionWrite}, wantErr: true},
+		{name: "unknown action", file: CommitFile{Path: StringVar("a.txt"), Action: "rename", Content: StringVar("content")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommitFiles([]CommitFile{tt.file})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCommitFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("ValidateCommitFiles() error = %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+}
+
+// upperEncoder is a CommitClient taking the content of the files upper-cased.
+type upperEncoder struct {
+	CommitClient
+}
+
+func (upperEncoder) EncodeCommitContent(content string) string { return strings.ToUpper(content) }
+
+func TestEncodeCommitFiles(t *testing.T) {
+	files := []CommitFile{
+		{Path: StringVar("a.txt"), Content: StringVar("hello")},
+		{Path: StringVar("b.txt"), Action: CommitFileActionDelete},
+	}
+
+	if got := encodeCommitFiles(memoryCommits{&memoryRepo{}}, files); *got[0].Content != "hello" {
+		t.Errorf("content without encoder = %q, want %q", *got[0].Content, "hello")
+	}
+	got := encodeCommitFiles(upperEncoder{}, files)
+	if *got[0].Content != "HELLO" {
+		t.Errorf("encoded content = %q, want %q", *got[0].Content, "HELLO")
+	}
+	if got[1].Content != nil {
+		t.Errorf("deleted file content = %q, want none", *got[1].Content)
+	}
+	if *files[0].Content != "hello" {
+		t.Error("encodeCommitFiles() modified the given files")
+	}
+}
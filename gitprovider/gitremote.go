@@ -0,0 +1,639 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+	// defaultGitBranch is the branch committed to in empty repositories, if none is given.
+	defaultGitBranch = "master"
+	// gitCommitAuthor is the author name of the commits made through the Git protocol.
+	gitCommitAuthor = "go-git-providers"
+	// gitPackWindow is the delta window used when encoding the objects pushed to a remote.
+	gitPackWindow = 10
+)
+
+var (
+	// initialCommitAttempts is the number of times the default branch is fetched while waiting
+	// for the initial commit of a repository just initialized by a provider.
+	initialCommitAttempts = 5
+	// initialCommitInterval is the delay between these fetches.
+	initialCommitInterval = time.Second
+)
+
+// PushInitialCommit commits the given files to branch of the empty repository at cloneURL,
+// and pushes it. This allows controlling the first commit of repositories where the
+// provider doesn't.
+func PushInitialCommit(ctx context.Context, cloneURL, branch, message string, files []CommitFile, opts GitTransportOptions) (Commit, error) {
+	r, err := opts.newRemote(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	return r.commitFiles(ctx, plumbing.NewBranchReferenceName(branch), message, files)
+}
+
+// CommitOnParents commits the given files to branch of the repository at cloneURL, in a commit
+// with the parents and dates given through opts, for providers whose API always commits on top of
+// the branch head. See CommitCreateOptions.Parents. The history of all branches and tags is
+// fetched to find the parents, which must be reachable from them. The branch is only moved if it
+// still points to opts.ExpectedHeadSHA, which the remote checks atomically when pushing.
+func CommitOnParents(ctx context.Context, cloneURL, branch, message string, files []CommitFile, opts CommitCreateOptions, transportOpts GitTransportOptions) (Commit, error) {
+	r, err := transportOpts.newRemote(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	return r.commitOnParents(ctx, plumbing.NewBranchReferenceName(branch), message, files, opts)
+}
+
+// RewordInitialCommit replaces the message of the commit the default branch of the repository
+// at cloneURL points to, and force-pushes it. This is meant for repositories just initialized
+// by the provider, hence ErrInvalidArgument is returned if the commit has parents. As providers
+// may create the initial commit asynchronously, an empty repository is fetched again a few times
+// before giving up. The signature of the commit, if any, is dropped as it doesn't match anymore.
+func RewordInitialCommit(ctx context.Context, cloneURL, message string, opts GitTransportOptions) error {
+	r, err := opts.newRemote(cloneURL)
+	if err != nil {
+		return err
+	}
+	return r.rewordRoot(ctx, message)
+}
+
+// MirrorRepository pushes all branches and tags of the repository at srcCloneURL to the repository
+// at dstCloneURL, e.g. to migrate a repository between providers. Provider-specific refs, like
+// pull request heads, aren't pushed. Refs already existing in the destination are updated, which
+// the destination rejects unless it's a fast-forward.
+func MirrorRepository(ctx context.Context, srcCloneURL string, srcOpts GitTransportOptions, dstCloneURL string, dstOpts GitTransportOptions) error {
+	src, err := srcOpts.newRemote(srcCloneURL)
+	if err != nil {
+		return err
+	}
+	dst, err := dstOpts.newRemote(dstCloneURL)
+	if err != nil {
+		return err
+	}
+	_, err = src.mirrorTo(ctx, dst)
+	return err
+}
+
+// ResolveShortSHA resolves the abbreviated commit SHA to the full SHA of the single commit of the
+// repository at cloneURL starting with it, looking at the history of all branches and tags. This
+// allows telling missing commits from ambiguous SHAs on providers reporting both as not found, at
+// the cost of fetching the whole repository. ErrNotFound is returned if no commit matches sha, and
+// ErrAmbiguousReference if several do.
+func ResolveShortSHA(ctx context.Context, cloneURL, sha string, opts GitTransportOptions) (string, error) {
+	r, err := opts.newRemote(cloneURL)
+	if err != nil {
+		return "", err
+	}
+	return r.resolveShortSHA(ctx, sha)
+}
+
+// newRemote returns a gitRemote operating on the repository at cloneURL.
+func (opts GitTransportOptions) newRemote(cloneURL string) (*gitRemote, error) {
+	t, ep, err := opts.newEndpoint(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	return &gitRemote{t: t, ep: ep, auth: opts.Auth}, nil
+}
+
+// gitRemote operates on a remote repository through the Git protocol, working on a single
+// branch fetched into memory.
+type gitRemote struct {
+	t    transport.Transport
+	ep   *transport.Endpoint
+	auth transport.AuthMethod
+}
+
+// commitFiles commits the given files to branch in a single commit, and pushes it. Files are
+// written or deleted as their action says. If branch is empty, the default branch is used, falling back
+// to defaultGitBranch in empty repositories.
+func (r *gitRemote) commitFiles(ctx context.Context, branch plumbing.ReferenceName, message string, files []CommitFile) (Commit, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files added: %w", ErrInvalidArgument)
+	}
+	if err := ValidateCommitFiles(files); err != nil {
+		return nil, err
+	}
+	repo, head, err := r.fetch(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if branch == "" {
+		branch = plumbing.NewBranchReferenceName(defaultGitBranch)
+	}
+	oldHash := plumbing.ZeroHash
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	if head != nil {
+		branch, oldHash = head.Name(), head.Hash()
+		if err := wt.Checkout(&git.CheckoutOptions{Branch: branch}); err != nil {
+			return nil, err
+		}
+	} else if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branch)); err != nil {
+		return nil, err
+	}
+
+	if err := writeFiles(wt, files); err != nil {
+		return nil, err
+	}
+	newHash, err := wt.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: gitCommitAuthor, When: time.Now()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.push(ctx, repo, branch, oldHash, newHash); err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(newHash)
+	if err != nil {
+		return nil, err
+	}
+	return &gitCommit{c: commit}, nil
+}
+
+// commitOnParents commits the given files to branch in a commit with the parents given through
+// opts, and pushes it if branch still points to opts.ExpectedHeadSHA.
+func (r *gitRemote) commitOnParents(ctx context.Context, branch plumbing.ReferenceName, message string, files []CommitFile, opts CommitCreateOptions) (Commit, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files added: %w", ErrInvalidArgument)
+	}
+	if err := ValidateCommitFiles(files); err != nil {
+		return nil, err
+	}
+	if len(opts.Parents) == 0 {
+		return nil, fmt.Errorf("no parents given: %w", ErrInvalidArgument)
+	}
+	if err := opts.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	bare, refs, err := r.fetchRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	oldHash := plumbing.ZeroHash
+	for _, ref := range refs {
+		if ref.Name() == branch {
+			oldHash = ref.Hash()
+		}
+	}
+	if oldHash.IsZero() {
+		return nil, fmt.Errorf("branch %s doesn't exist: %w", branch.Short(), ErrPreconditionFailed)
+	}
+	if err := opts.CheckHead(oldHash.String()); err != nil {
+		return nil, err
+	}
+
+	parents := make([]plumbing.Hash, 0, len(opts.Parents))
+	for _, sha := range opts.Parents {
+		parent := plumbing.NewHash(sha)
+		if _, err := bare.CommitObject(parent); errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil, fmt.Errorf("parent commit %s doesn't exist: %w", sha, ErrInvalidArgument)
+		} else if err != nil {
+			return nil, err
+		}
+		parents = append(parents, parent)
+	}
+
+	// Apply the files on top of the tree of the first parent
+	repo, err := git.Open(bare.Storer, memfs.New())
+	if err != nil {
+		return nil, err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Hash: parents[0]}); err != nil {
+		return nil, err
+	}
+	if err := writeFiles(wt, files); err != nil {
+		return nil, err
+	}
+	author := &object.Signature{Name: gitCommitAuthor, When: time.Now()}
+	if opts.AuthorName != "" {
+		author.Name, author.Email = opts.AuthorName, opts.AuthorEmail
+	}
+	if opts.AuthorDate != nil {
+		author.When = *opts.AuthorDate
+	}
+	committer := *author
+	if opts.CommitterDate != nil {
+		committer.When = *opts.CommitterDate
+	}
+	newHash, err := wt.Commit(message, &git.CommitOptions{
+		Author:    author,
+		Committer: &committer,
+		Parents:   parents,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The remote rejects the update if the branch doesn't point to oldHash anymore
+	if err := r.push(ctx, repo, branch, oldHash, newHash); err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(newHash)
+	if err != nil {
+		return nil, err
+	}
+	return &gitCommit{c: commit}, nil
+}
+
+// writeFiles writes or deletes the given files in wt, as their action says, and stages them.
+func writeFiles(wt *git.Worktree, files []CommitFile) error {
+	for _, file := range files {
+		if file.Path == nil {
+			return fmt.Errorf("file without path: %w", ErrInvalidArgument)
+		}
+		if file.GetAction() == CommitFileActionDelete {
+			if _, err := wt.Remove(*file.Path); err != nil {
+				if errors.Is(err, index.ErrEntryNotFound) {
+					return fmt.Errorf("%q: %w", *file.Path, ErrFileNotFound)
+				}
+				return fmt.Errorf("failed to delete file %q: %w", *file.Path, err)
+			}
+			continue
+		}
+		if err := util.WriteFile(wt.Filesystem, *file.Path, []byte(*file.Content), 0o644); err != nil {
+			return err
+		}
+		if _, err := wt.Add(*file.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewordRoot replaces the message of the root commit the default branch points to.
+func (r *gitRemote) rewordRoot(ctx context.Context, message string) error {
+	repo, head, err := r.fetchInitialCommit(ctx)
+	if err != nil {
+		return err
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+	if commit.NumParents() != 0 {
+		return fmt.Errorf("commit %s of %s isn't an initial commit: %w", head.Hash(), head.Name(), ErrInvalidArgument)
+	}
+
+	commit.Message = message
+	commit.PGPSignature = ""
+	obj := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		return err
+	}
+	newHash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return err
+	}
+	return r.push(ctx, repo, head.Name(), head.Hash(), newHash)
+}
+
+// resolveShortSHA returns the full SHA of the single commit of the remote starting with sha.
+func (r *gitRemote) resolveShortSHA(ctx context.Context, sha string) (string, error) {
+	repo, _, err := r.fetchRefs(ctx)
+	if err != nil {
+		return "", err
+	}
+	return ResolveShortSHAInRepository(repo, sha)
+}
+
+// ResolveShortSHAInRepository resolves the abbreviated commit SHA to the full SHA of the single
+// commit of repo starting with it. ErrNotFound is returned if no commit matches sha, and
+// ErrAmbiguousReference if several do.
+func ResolveShortSHAInRepository(repo *git.Repository, sha string) (string, error) {
+	iter, err := repo.CommitObjects()
+	if err != nil {
+		return "", err
+	}
+	defer iter.Close()
+
+	prefix := strings.ToLower(sha)
+	matches := []string{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		if strings.HasPrefix(c.Hash.String(), prefix) {
+			matches = append(matches, c.Hash.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("commit %s not found: %w", sha, ErrNotFound)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("commit %s: %w", sha, ErrAmbiguousReference)
+	}
+}
+
+// fetchInitialCommit fetches the default branch, fetching it again every initialCommitInterval
+// while the repository is empty, up to initialCommitAttempts times.
+func (r *gitRemote) fetchInitialCommit(ctx context.Context) (*git.Repository, *plumbing.Reference, error) {
+	for attempt := 1; ; attempt++ {
+		repo, head, err := r.fetch(ctx, "")
+		if err != nil || head != nil {
+			return repo, head, err
+		}
+		if attempt >= initialCommitAttempts {
+			return nil, nil, fmt.Errorf("cannot reword the initial commit of an empty repository: %w", ErrInvalidArgument)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(initialCommitInterval):
+		}
+	}
+}
+
+// fetch fetches branch into a new in-memory repository, or the default branch if branch is
+// empty, falling back to defaultGitBranch if the remote doesn't advertise its HEAD. The
+// returned reference of the branch is nil if the branch doesn't exist yet.
+func (r *gitRemote) fetch(ctx context.Context, branch plumbing.ReferenceName) (repo *git.Repository, head *plumbing.Reference, err error) {
+	repo, err = git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sess, err := r.t.NewUploadPackSession(r.ep, r.auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", r.ep.String(), err)
+	}
+	defer closeAndKeepError(sess, &err)
+
+	ar, err := sess.AdvertisedReferencesContext(ctx)
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return repo, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the refs of %s: %w", r.ep.String(), err)
+	}
+	refs, err := ar.AllReferences()
+	if err != nil {
+		return nil, nil, err
+	}
+	if branch == "" {
+		branch = plumbing.NewBranchReferenceName(defaultGitBranch)
+		if symref, ok := refs[plumbing.HEAD]; ok && symref.Type() == plumbing.SymbolicReference {
+			branch = symref.Target()
+		} else if _, ok := refs[branch]; !ok && len(refs) > 0 {
+			return nil, nil, fmt.Errorf("cannot determine the default branch of %s: %w", r.ep.String(), ErrInvalidServerData)
+		}
+	}
+	head, ok := refs[branch]
+	if !ok {
+		return repo, nil, nil
+	}
+
+	req := packp.NewUploadPackRequestFromCapabilities(ar.Capabilities)
+	req.Wants = []plumbing.Hash{head.Hash()}
+	if ar.Capabilities.Supports(capability.NoProgress) {
+		if err := req.Capabilities.Set(capability.NoProgress); err != nil {
+			return nil, nil, err
+		}
+	}
+	res, err := sess.UploadPack(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch %s: %w", r.ep.String(), err)
+	}
+	defer closeAndKeepError(res, &err)
+
+	if err := packfile.UpdateObjectStorage(repo.Storer, packfileReader(req, res)); err != nil {
+		return nil, nil, err
+	}
+	if err := repo.Storer.SetReference(head); err != nil {
+		return nil, nil, err
+	}
+	return repo, head, nil
+}
+
+// mirrorTo pushes all branches and tags of the remote to dst, and returns whether any ref of dst
+// was created or updated.
+func (r *gitRemote) mirrorTo(ctx context.Context, dst *gitRemote) (updated bool, err error) {
+	repo, refs, err := r.fetchRefs(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(refs) == 0 {
+		return false, fmt.Errorf("cannot mirror the empty repository %s: %w", r.ep.String(), ErrInvalidArgument)
+	}
+	err = dst.receivePack(ctx, repo, func(ar *packp.AdvRefs) ([]*packp.Command, error) {
+		existing, err := ar.AllReferences()
+		if err != nil {
+			return nil, err
+		}
+		commands := []*packp.Command{}
+		for _, ref := range refs {
+			old := plumbing.ZeroHash
+			if e, ok := existing[ref.Name()]; ok {
+				old = e.Hash()
+			}
+			if old != ref.Hash() {
+				commands = append(commands, &packp.Command{Name: ref.Name(), Old: old, New: ref.Hash()})
+			}
+		}
+		updated = len(commands) > 0
+		return commands, nil
+	})
+	return updated && err == nil, err
+}
+
+// fetchRefs fetches all branches and tags of the remote into a new in-memory repository, and
+// returns their references sorted by name.
+func (r *gitRemote) fetchRefs(ctx context.Context) (repo *git.Repository, refs []*plumbing.Reference, err error) {
+	repo, err = git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sess, err := r.t.NewUploadPackSession(r.ep, r.auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", r.ep.String(), err)
+	}
+	defer closeAndKeepError(sess, &err)
+
+	ar, err := sess.AdvertisedReferencesContext(ctx)
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return repo, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the refs of %s: %w", r.ep.String(), err)
+	}
+	all, err := ar.AllReferences()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := packp.NewUploadPackRequestFromCapabilities(ar.Capabilities)
+	wanted := map[plumbing.Hash]struct{}{}
+	for _, ref := range all {
+		if ref.Type() != plumbing.HashReference || !(ref.Name().IsBranch() || ref.Name().IsTag()) {
+			continue
+		}
+		refs = append(refs, ref)
+		if _, ok := wanted[ref.Hash()]; !ok {
+			wanted[ref.Hash()] = struct{}{}
+			req.Wants = append(req.Wants, ref.Hash())
+		}
+	}
+	if len(refs) == 0 {
+		return repo, nil, nil
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Name() < refs[j].Name() })
+	if ar.Capabilities.Supports(capability.NoProgress) {
+		if err := req.Capabilities.Set(capability.NoProgress); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	res, err := sess.UploadPack(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch %s: %w", r.ep.String(), err)
+	}
+	defer closeAndKeepError(res, &err)
+
+	if err := packfile.UpdateObjectStorage(repo.Storer, packfileReader(req, res)); err != nil {
+		return nil, nil, err
+	}
+	return repo, refs, nil
+}
+
+// push updates branch of the remote from oldHash to newHash, sending the missing objects from repo.
+func (r *gitRemote) push(ctx context.Context, repo *git.Repository, branch plumbing.ReferenceName, oldHash, newHash plumbing.Hash) error {
+	return r.receivePack(ctx, repo, func(_ *packp.AdvRefs) ([]*packp.Command, error) {
+		return []*packp.Command{{Name: branch, Old: oldHash, New: newHash}}, nil
+	})
+}
+
+// receivePack updates the refs of the remote with the commands returned by commands, given the
+// refs advertised by the remote, and sends the objects from repo the remote is missing.
+func (r *gitRemote) receivePack(ctx context.Context, repo *git.Repository, commands func(ar *packp.AdvRefs) ([]*packp.Command, error)) (err error) {
+	sess, err := r.t.NewReceivePackSession(r.ep, r.auth)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", r.ep.String(), err)
+	}
+	defer closeAndKeepError(sess, &err)
+
+	ar, err := sess.AdvertisedReferencesContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list the refs of %s: %w", r.ep.String(), err)
+	}
+	req := packp.NewReferenceUpdateRequestFromCapabilities(ar.Capabilities)
+	req.Commands, err = commands(ar)
+	if err != nil {
+		return err
+	}
+	if len(req.Commands) == 0 {
+		return nil
+	}
+	wants := make([]plumbing.Hash, 0, len(req.Commands))
+	ignore := []plumbing.Hash{}
+	for _, cmd := range req.Commands {
+		wants = append(wants, cmd.New)
+		// The remote already has the objects reachable from the old values we know of
+		if !cmd.Old.IsZero() && repo.Storer.HasEncodedObject(cmd.Old) == nil {
+			ignore = append(ignore, cmd.Old)
+		}
+	}
+	hashes, err := revlist.Objects(repo.Storer, wants, ignore)
+	if err != nil {
+		return err
+	}
+
+	rd, wr := io.Pipe()
+	req.Packfile = rd
+	// Buffered, so the encoder doesn't block if ReceivePack fails early
+	done := make(chan error, 1)
+	go func() {
+		e := packfile.NewEncoder(wr, repo.Storer, !ar.Capabilities.Supports(capability.OFSDelta))
+		_, err := e.Encode(hashes, gitPackWindow)
+		done <- wr.CloseWithError(err)
+	}()
+
+	rs, err := sess.ReceivePack(ctx, req)
+	if err != nil {
+		_ = rd.Close()
+		return fmt.Errorf("failed to push to %s: %w", r.ep.String(), err)
+	}
+	if err := <-done; err != nil {
+		return err
+	}
+	if rs != nil {
+		return rs.Error()
+	}
+	return nil
+}
+
+// gitCommit implements the Commit interface.
+var _ Commit = &gitCommit{}
+
+// gitCommit is a commit created through the Git protocol.
+type gitCommit struct {
+	c *object.Commit
+}
+
+// APIObject returns the underlying go-git commit.
+func (c *gitCommit) APIObject() interface{} {
+	return c.c
+}
+
+// Get returns high-level information about the commit.
+func (c *gitCommit) Get() CommitInfo {
+	parents := make([]string, 0, len(c.c.ParentHashes))
+	for _, parent := range c.c.ParentHashes {
+		parents = append(parents, parent.String())
+	}
+	return CommitInfo{
+		Sha:       c.c.Hash.String(),
+		TreeSha:   c.c.TreeHash.String(),
+		Author:    c.c.Author.Name,
+		Message:   c.c.Message,
+		CreatedAt: c.c.Author.When,
+		Parents:   parents,
+	}
+}
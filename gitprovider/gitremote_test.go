@@ -0,0 +1,440 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestRemote returns a gitRemote operating on the given storage through an in-process
+// Git server.
+func newTestRemote(t *testing.T, cloneURL string, s storage.Storer) *gitRemote {
+	t.Helper()
+	ep, err := transport.NewEndpoint(cloneURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &gitRemote{t: server.NewClient(server.MapLoader{ep.String(): s}), ep: ep}
+}
+
+// newInitializedRepository returns a repository with a single commit on main, like the ones
+// initialized by providers.
+func newInitializedRepository(t *testing.T) *git.Repository {
+	t.Helper()
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))); err != nil {
+		t.Fatal(err)
+	}
+	wt, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := wt.Filesystem.Create("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("# repo\n")); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if _, err := wt.Add("README.md"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Commit("Initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "Flux", Email: "flux@example.com", When: time.Unix(1672531200, 0)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestGitRemote_RewordRoot(t *testing.T) {
+	repo := newInitializedRepository(t)
+	oldHead, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldCommit, err := repo.CommitObject(oldHead.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := newTestRemote(t, "https://example.com/fluxcd/repo.git", repo.Storer)
+	if err := r.rewordRoot(context.Background(), "chore: initialize repository"); err != nil {
+		t.Fatalf("rewordRoot() error = %v", err)
+	}
+
+	head, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Message != "chore: initialize repository" {
+		t.Errorf("message = %q, want %q", commit.Message, "chore: initialize repository")
+	}
+	if commit.NumParents() != 0 {
+		t.Errorf("commit has %d parents, want 0", commit.NumParents())
+	}
+	if commit.TreeHash != oldCommit.TreeHash {
+		t.Errorf("tree = %s, want the initial tree %s", commit.TreeHash, oldCommit.TreeHash)
+	}
+	if !commit.Author.When.Equal(oldCommit.Author.When) || commit.Author.Name != oldCommit.Author.Name {
+		t.Errorf("author = %v, want the initial author %v", commit.Author, oldCommit.Author)
+	}
+}
+
+// delayedLoader serves an empty repository first, and then the given storage, like repositories
+// whose initial commit is created asynchronously.
+type delayedLoader struct {
+	s     storer.Storer
+	loads int
+}
+
+func (l *delayedLoader) Load(*transport.Endpoint) (storer.Storer, error) {
+	l.loads++
+	if l.loads == 1 {
+		return memory.NewStorage(), nil
+	}
+	return l.s, nil
+}
+
+func TestGitRemote_RewordRoot_WaitsForSignedCommit(t *testing.T) {
+	interval := initialCommitInterval
+	initialCommitInterval = 0
+	t.Cleanup(func() { initialCommitInterval = interval })
+
+	repo := newInitializedRepository(t)
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed.PGPSignature = "-----BEGIN PGP SIGNATURE-----\n\nwsBcBAABCAAQ\n-----END PGP SIGNATURE-----\n"
+	obj := repo.Storer.NewEncodedObject()
+	if err := signed.Encode(obj); err != nil {
+		t.Fatal(err)
+	}
+	signedHash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(head.Name(), signedHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	ep, err := transport.NewEndpoint("https://example.com/fluxcd/repo.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader := &delayedLoader{s: repo.Storer}
+	r := &gitRemote{t: server.NewClient(loader), ep: ep}
+	if err := r.rewordRoot(context.Background(), "chore: initialize repository"); err != nil {
+		t.Fatalf("rewordRoot() error = %v", err)
+	}
+	if loader.loads < 2 {
+		t.Errorf("the repository was loaded %d times, want it fetched again", loader.loads)
+	}
+
+	head, err = repo.Reference(head.Name(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Message != "chore: initialize repository" {
+		t.Errorf("message = %q, want %q", commit.Message, "chore: initialize repository")
+	}
+	if commit.PGPSignature != "" {
+		t.Errorf("signature = %q, want it dropped", commit.PGPSignature)
+	}
+}
+
+func TestGitRemote_RewordRoot_NotInitial(t *testing.T) {
+	interval := initialCommitInterval
+	initialCommitInterval = 0
+	t.Cleanup(func() { initialCommitInterval = interval })
+
+	tests := []struct {
+		name    string
+		storage func(t *testing.T) storage.Storer
+	}{
+		{
+			name:    "empty repository",
+			storage: func(*testing.T) storage.Storer { return memory.NewStorage() },
+		},
+		{
+			// The feature branch of the fake repository has two commits
+			name:    "commit with parents",
+			storage: func(t *testing.T) storage.Storer { return newFakeRepository(t).Storer },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRemote(t, "https://example.com/fluxcd/repo.git", tt.storage(t))
+			if err := r.rewordRoot(context.Background(), "chore: initialize repository"); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("rewordRoot() error = %v, want %v", err, ErrInvalidArgument)
+			}
+		})
+	}
+}
+
+func TestGitRemote_CommitFiles_EmptyRepository(t *testing.T) {
+	s := memory.NewStorage()
+	r := newTestRemote(t, "https://example.com/fluxcd/repo.git", s)
+
+	commit, err := r.commitFiles(context.Background(), plumbing.NewBranchReferenceName("main"), "chore: initialize repository", []CommitFile{
+		{Path: StringVar("README.md"), Content: StringVar("# repo\n")},
+	})
+	if err != nil {
+		t.Fatalf("commitFiles() error = %v", err)
+	}
+	if got := commit.Get().Message; got != "chore: initialize repository" {
+		t.Errorf("message = %q, want %q", got, "chore: initialize repository")
+	}
+
+	ref, err := s.Reference(plumbing.NewBranchReferenceName("main"))
+	if err != nil {
+		t.Fatalf("failed to get main: %v", err)
+	}
+	if got := ref.Hash().String(); got != commit.Get().Sha {
+		t.Errorf("main = %s, want %s", got, commit.Get().Sha)
+	}
+}
+
+func TestGitRemote_CommitFiles_DeleteMissingFile(t *testing.T) {
+	repo := newInitializedRepository(t)
+	oldHead, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newTestRemote(t, "https://example.com/fluxcd/repo.git", repo.Storer)
+
+	_, err = r.commitFiles(context.Background(), plumbing.NewBranchReferenceName("main"), "remove files", []CommitFile{
+		{Path: StringVar("README.md"), Action: CommitFileActionDelete},
+		{Path: StringVar("missing.txt"), Action: CommitFileActionDelete},
+	})
+	if !errors.Is(err, ErrFileNotFound) {
+		t.Fatalf("commitFiles() error = %v, want %v", err, ErrFileNotFound)
+	}
+
+	head, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head.Hash() != oldHead.Hash() {
+		t.Errorf("main = %s, want it unchanged at %s", head.Hash(), oldHead.Hash())
+	}
+}
+
+func TestGitRemote_CommitFiles_InvalidFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []CommitFile
+	}{
+		{
+			name:  "write without content",
+			files: []CommitFile{{Path: StringVar("README.md"), Action: CommitFileActionWrite}},
+		},
+		{
+			name:  "path outside the repository",
+			files: []CommitFile{{Path: StringVar("../README.md"), Content: StringVar("# repo\n")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newInitializedRepository(t)
+			r := newTestRemote(t, "https://example.com/fluxcd/repo.git", repo.Storer)
+
+			_, err := r.commitFiles(context.Background(), plumbing.NewBranchReferenceName("main"), "update files", tt.files)
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("commitFiles() error = %v, want %v", err, ErrInvalidArgument)
+			}
+			_, err = r.commitOnParents(context.Background(), plumbing.NewBranchReferenceName("main"), "update files", tt.files, CommitCreateOptions{Parents: []string{"a1"}})
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("commitOnParents() error = %v, want %v", err, ErrInvalidArgument)
+			}
+		})
+	}
+}
+
+func TestGitRemote_ResolveShortSHA(t *testing.T) {
+	repo := newFakeRepository(t)
+	mainRef, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	featureRef, err := repo.Reference(plumbing.NewBranchReferenceName("feature"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The longest prefix shared by the two commits matches both
+	main, feature := mainRef.Hash().String(), featureRef.Hash().String()
+	shared := 0
+	for shared < len(main) && main[shared] == feature[shared] {
+		shared++
+	}
+
+	tests := []struct {
+		name    string
+		sha     string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "single match",
+			sha:  strings.ToUpper(feature[:shared+1]),
+			want: feature,
+		},
+		{
+			name:    "several matches",
+			sha:     main[:shared],
+			wantErr: ErrAmbiguousReference,
+		},
+		{
+			name:    "no match",
+			sha:     main[:shared+1] + strings.Repeat("0", 39-shared),
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRemote(t, "https://example.com/fluxcd/repo.git", repo.Storer)
+			got, err := r.resolveShortSHA(context.Background(), tt.sha)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolveShortSHA() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveShortSHA() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitRemote_CommitOnParents(t *testing.T) {
+	authored := time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		parents      func(main string) []string
+		expectedHead func(feature string) string
+		wantErr      error
+	}{
+		{
+			name:         "graft onto a historical commit",
+			parents:      func(main string) []string { return []string{main} },
+			expectedHead: func(feature string) string { return feature },
+		},
+		{
+			name:         "branch moved",
+			parents:      func(main string) []string { return []string{main} },
+			expectedHead: func(string) string { return strings.Repeat("a", 40) },
+			wantErr:      ErrPreconditionFailed,
+		},
+		{
+			name:         "unknown parent",
+			parents:      func(main string) []string { return []string{main, strings.Repeat("b", 40)} },
+			expectedHead: func(feature string) string { return feature },
+			wantErr:      ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository(t)
+			mainRef, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			featureRef, err := repo.Reference(plumbing.NewBranchReferenceName("feature"), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := newTestRemote(t, "https://example.com/fluxcd/repo.git", repo.Storer)
+			commit, err := r.commitOnParents(context.Background(), featureRef.Name(), "graft", []CommitFile{
+				{Path: StringVar("graft.txt"), Content: StringVar("graft\n")},
+			}, CommitCreateOptions{
+				Parents:         tt.parents(mainRef.Hash().String()),
+				ExpectedHeadSHA: tt.expectedHead(featureRef.Hash().String()),
+				AuthorDate:      &authored,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("commitOnParents() error = %v, want %v", err, tt.wantErr)
+			}
+
+			head, err := repo.Reference(featureRef.Name(), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != nil {
+				if head.Hash() != featureRef.Hash() {
+					t.Errorf("feature was moved to %s despite the error", head.Hash())
+				}
+				return
+			}
+			if head.Hash().String() != commit.Get().Sha {
+				t.Errorf("feature points to %s, want the new commit %s", head.Hash(), commit.Get().Sha)
+			}
+			if diff := cmp.Diff([]string{mainRef.Hash().String()}, commit.Get().Parents); diff != "" {
+				t.Errorf("parents (-want +got):\n%s", diff)
+			}
+			if got := commit.Get().CreatedAt; !got.Equal(authored) {
+				t.Errorf("author date = %v, want %v", got, authored)
+			}
+			pushed, err := repo.CommitObject(head.Hash())
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree, err := pushed.Tree()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, entry := range tree.Entries {
+				got = append(got, entry.Name)
+			}
+			// The files of the commits grafted over aren't kept
+			if diff := cmp.Diff([]string{"README.md", "graft.txt"}, got); diff != "" {
+				t.Errorf("files (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PollMergeability calls get until it reports a known mergeable state, waiting interval between
+// the calls. If ctx is done first, the last, unknown, state is returned along with the error of ctx.
+func PollMergeability(ctx context.Context, interval time.Duration, get func(ctx context.Context) (MergeableInfo, error)) (MergeableInfo, error) {
+	for {
+		info, err := get(ctx)
+		if err != nil || info.Mergeable != nil {
+			return info, err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return info, fmt.Errorf("mergeable state is still unknown: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
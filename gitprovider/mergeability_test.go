@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollMergeability(t *testing.T) {
+	calls := 0
+	info, err := PollMergeability(context.Background(), time.Millisecond, func(_ context.Context) (MergeableInfo, error) {
+		calls++
+		if calls < 3 {
+			return MergeableInfo{}, nil
+		}
+		return MergeableInfo{Mergeable: BoolVar(false), Conflicts: true}, nil
+	})
+	if err != nil {
+		t.Fatalf("PollMergeability() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("PollMergeability() made %d calls, want 3", calls)
+	}
+	if info.Mergeable == nil || *info.Mergeable || !info.Conflicts {
+		t.Errorf("PollMergeability() = %+v, want a conflicting pull request", info)
+	}
+}
+
+func TestPollMergeability_Deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	info, err := PollMergeability(ctx, time.Millisecond, func(_ context.Context) (MergeableInfo, error) {
+		return MergeableInfo{}, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PollMergeability() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if info.Mergeable != nil {
+		t.Errorf("PollMergeability() mergeable = %v, want unknown", *info.Mergeable)
+	}
+}
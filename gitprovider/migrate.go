@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// MigrateItem identifies a part of a repository migrated by Migrate.
+type MigrateItem string
+
+const (
+	// MigrateItemRepository is the creation of the destination repository.
+	MigrateItemRepository = MigrateItem("repository")
+	// MigrateItemRefs are the branches and tags of the repository.
+	MigrateItemRefs = MigrateItem("refs")
+	// MigrateItemRepositoryInfo is the default branch of the repository, and its description and
+	// visibility if metadata is migrated.
+	MigrateItemRepositoryInfo = MigrateItem("repository-info")
+	// MigrateItemDeployKey is a deploy key of the repository.
+	MigrateItemDeployKey = MigrateItem("deploy-key")
+	// MigrateItemTeamAccess is the access of a team to the repository.
+	MigrateItemTeamAccess = MigrateItem("team-access")
+)
+
+// MigrateOptions specifies the repository Migrate migrates, and where to.
+type MigrateOptions struct {
+	// Source is the repository to migrate.
+	// +required
+	Source OrgRepository
+
+	// SourceCloneURL is the URL the branches and tags of Source are fetched from.
+	// Default: the HTTPS clone URL of the reference of Source.
+	// +optional
+	SourceCloneURL string
+
+	// SourceGit specifies how to fetch the branches and tags of Source.
+	// +optional
+	SourceGit GitTransportOptions
+
+	// Destination is the client of the provider the repository is migrated to.
+	// +required
+	Destination Client
+
+	// DestinationRef is the reference of the repository created by Migrate in Destination.
+	// +required
+	DestinationRef OrgRepositoryRef
+
+	// DestinationCloneURL is the URL the branches and tags are pushed to.
+	// Default: the HTTPS clone URL of DestinationRef.
+	// +optional
+	DestinationCloneURL string
+
+	// DestinationGit specifies how to push the branches and tags to the destination.
+	// +optional
+	DestinationGit GitTransportOptions
+
+	// Metadata determines if the description, visibility, deploy keys and team access of Source
+	// are migrated as well.
+	// Default: false, which means only the branches, tags and default branch are migrated.
+	// +optional
+	Metadata bool
+}
+
+// MigrateResult is the result of migrating a single item of a repository.
+type MigrateResult struct {
+	// Item is the migrated item.
+	Item MigrateItem
+
+	// Name identifies the item among the items of its kind, e.g. the name of a deploy key.
+	// It's empty for the items existing once per repository.
+	Name string
+
+	// ActionTaken is true if the item was created or updated in the destination.
+	ActionTaken bool
+
+	// Skipped is true if the source or destination provider doesn't support the item.
+	Skipped bool
+
+	// Err is the error that occurred while migrating this item, if any.
+	Err error
+}
+
+// Migrate migrates a repository between providers: it creates the destination repository, mirrors
+// all branches and tags of the source to it, and sets the default branch. If opts.Metadata is set,
+// the description, visibility, deploy keys and team access are read from the source and reconciled
+// into the destination too. Webhooks aren't migrated, as there are no provider-neutral repository
+// webhooks. Teams must already exist in the destination organization for their access to migrate.
+//
+// An existing destination repository is reused, so an interrupted migration can be run again.
+// Items the source or destination provider doesn't support are skipped.
+//
+// The returned results are in the order the items were migrated. An error is returned, along with
+// the results so far, if the destination repository can't be created or the refs can't be
+// mirrored; errors of single items are set in their result.
+func Migrate(ctx context.Context, opts MigrateOptions) ([]MigrateResult, error) {
+	if opts.Source == nil || opts.Destination == nil || opts.DestinationRef.RepositoryName == "" {
+		return nil, fmt.Errorf("source, destination and destination repository name are required: %w", ErrInvalidArgument)
+	}
+	if opts.SourceCloneURL == "" {
+		opts.SourceCloneURL = opts.Source.Repository().GetCloneURL(TransportTypeHTTPS)
+	}
+	if opts.DestinationCloneURL == "" {
+		opts.DestinationCloneURL = opts.DestinationRef.GetCloneURL(TransportTypeHTTPS)
+	}
+	src, err := opts.SourceGit.newRemote(opts.SourceCloneURL)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := opts.DestinationGit.newRemote(opts.DestinationCloneURL)
+	if err != nil {
+		return nil, err
+	}
+	return migrate(ctx, opts, src, dst)
+}
+
+// migrate runs Migrate, mirroring the refs from src to dst.
+func migrate(ctx context.Context, opts MigrateOptions, src, dst *gitRemote) ([]MigrateResult, error) {
+	srcInfo := opts.Source.Get()
+	results := []MigrateResult{}
+
+	// Create the destination repository empty, as the mirrored refs bring the history
+	createInfo := RepositoryInfo{}
+	if opts.Metadata {
+		createInfo.Description = srcInfo.Description
+		createInfo.Visibility = srcInfo.Visibility
+	}
+	repo, err := opts.Destination.OrgRepositories().Create(ctx, opts.DestinationRef, createInfo)
+	result := MigrateResult{Item: MigrateItemRepository, ActionTaken: err == nil}
+	if errors.Is(err, ErrAlreadyExists) {
+		repo, err = opts.Destination.OrgRepositories().Get(ctx, opts.DestinationRef)
+	}
+	if err != nil {
+		result.Err = err
+		return append(results, result), err
+	}
+	results = append(results, result)
+
+	updated, err := src.mirrorTo(ctx, dst)
+	if err != nil {
+		return append(results, MigrateResult{Item: MigrateItemRefs, Err: err}), err
+	}
+	results = append(results, MigrateResult{Item: MigrateItemRefs, ActionTaken: updated})
+
+	results = append(results, migrateRepositoryInfo(ctx, repo, srcInfo, opts.Metadata))
+	if opts.Metadata {
+		results = append(results, migrateDeployKeys(ctx, opts.Source, repo)...)
+		results = append(results, migrateTeamAccess(ctx, opts.Source, repo)...)
+	}
+	return results, nil
+}
+
+// migrateRepositoryInfo sets the default branch of dst to the one of the source, and the
+// description and visibility if metadata is set.
+func migrateRepositoryInfo(ctx context.Context, dst OrgRepository, srcInfo RepositoryInfo, metadata bool) MigrateResult {
+	result := MigrateResult{Item: MigrateItemRepositoryInfo}
+	info := dst.Get()
+	if srcInfo.DefaultBranch != nil {
+		info.DefaultBranch = srcInfo.DefaultBranch
+	}
+	if metadata {
+		info.Description = srcInfo.Description
+		info.Visibility = srcInfo.Visibility
+	}
+	if err := dst.Set(info); err != nil {
+		result.Err = err
+		return result
+	}
+	result.ActionTaken, result.Err = dst.Reconcile(ctx)
+	return result
+}
+
+// migrateDeployKeys reconciles the deploy keys of src into dst.
+func migrateDeployKeys(ctx context.Context, src, dst OrgRepository) []MigrateResult {
+	keys, err := src.DeployKeys().List(ctx)
+	if err != nil {
+		return []MigrateResult{skippedOrFailed(MigrateItemDeployKey, "", err)}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	reqs := make([]DeployKeyInfo, 0, len(keys))
+	for _, key := range keys {
+		reqs = append(reqs, key.Get())
+	}
+	batch, err := dst.DeployKeys().ReconcileBatch(ctx, reqs)
+	if err != nil {
+		return []MigrateResult{skippedOrFailed(MigrateItemDeployKey, "", err)}
+	}
+	results := make([]MigrateResult, 0, len(batch))
+	for i, r := range batch {
+		result := skippedOrFailed(MigrateItemDeployKey, reqs[i].Name, r.Err)
+		result.ActionTaken = r.ActionTaken
+		results = append(results, result)
+	}
+	return results
+}
+
+// migrateTeamAccess reconciles the access of the teams to src into dst.
+func migrateTeamAccess(ctx context.Context, src, dst OrgRepository) []MigrateResult {
+	teams, err := src.TeamAccess().List(ctx)
+	if err != nil {
+		return []MigrateResult{skippedOrFailed(MigrateItemTeamAccess, "", err)}
+	}
+	results := make([]MigrateResult, 0, len(teams))
+	for _, team := range teams {
+		info := team.Get()
+		_, actionTaken, err := dst.TeamAccess().Reconcile(ctx, info)
+		result := skippedOrFailed(MigrateItemTeamAccess, info.Name, err)
+		result.ActionTaken = actionTaken
+		results = append(results, result)
+	}
+	return results
+}
+
+// skippedOrFailed returns the result of an item which failed with err, or was skipped if err
+// is ErrNoProviderSupport.
+func skippedOrFailed(item MigrateItem, name string, err error) MigrateResult {
+	if errors.Is(err, ErrNoProviderSupport) {
+		return MigrateResult{Item: item, Name: name, Skipped: true}
+	}
+	return MigrateResult{Item: item, Name: name, Err: err}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// fakeMigrateClient is a Client of a fake provider holding a single organization repository.
+// Only the methods used by Migrate are implemented.
+type fakeMigrateClient struct {
+	Client
+	OrgRepositoriesClient
+	repo *fakeMigrateRepository
+}
+
+func (c *fakeMigrateClient) OrgRepositories() OrgRepositoriesClient { return c }
+
+func (c *fakeMigrateClient) Get(_ context.Context, ref OrgRepositoryRef) (OrgRepository, error) {
+	if c.repo == nil || c.repo.ref.RepositoryName != ref.RepositoryName {
+		return nil, ErrNotFound
+	}
+	return c.repo, nil
+}
+
+func (c *fakeMigrateClient) Create(_ context.Context, ref OrgRepositoryRef, req RepositoryInfo, _ ...RepositoryCreateOption) (OrgRepository, error) {
+	if c.repo != nil {
+		return nil, ErrAlreadyExists
+	}
+	c.repo = &fakeMigrateRepository{ref: ref, info: req, keys: &memoryDeployKeys{}}
+	return c.repo, nil
+}
+
+// fakeMigrateRepository is an OrgRepository of a fake provider without team access support.
+type fakeMigrateRepository struct {
+	OrgRepository
+	ref        OrgRepositoryRef
+	info       RepositoryInfo
+	actual     RepositoryInfo
+	keys       *memoryDeployKeys
+	reconciles int
+}
+
+func (r *fakeMigrateRepository) Repository() RepositoryRef     { return r.ref }
+func (r *fakeMigrateRepository) Get() RepositoryInfo           { return r.info }
+func (r *fakeMigrateRepository) DeployKeys() DeployKeyClient   { return r.keys }
+func (r *fakeMigrateRepository) TeamAccess() TeamAccessClient  { return noTeamAccess{} }
+func (r *fakeMigrateRepository) Set(info RepositoryInfo) error { r.info = info; return nil }
+
+func (r *fakeMigrateRepository) Reconcile(_ context.Context) (bool, error) {
+	if r.info.Equals(r.actual) {
+		return false, nil
+	}
+	r.actual = r.info
+	r.reconciles++
+	return true, nil
+}
+
+// noTeamAccess is the TeamAccessClient of a provider without teams.
+type noTeamAccess struct {
+	TeamAccessClient
+}
+
+func (noTeamAccess) List(_ context.Context) ([]TeamAccess, error) {
+	return nil, ErrNoProviderSupport
+}
+
+func TestMigrate(t *testing.T) {
+	srcRepo := newFakeRepository(t)
+	src := &fakeMigrateRepository{
+		ref: OrgRepositoryRef{OrganizationRef: OrganizationRef{Domain: "source.example.com", Organization: "flux"}, RepositoryName: "repo"},
+		info: RepositoryInfo{
+			Description:   StringVar("A repository"),
+			DefaultBranch: StringVar("main"),
+			Visibility:    RepositoryVisibilityVar(RepositoryVisibilityPrivate),
+		},
+		keys: &memoryDeployKeys{},
+	}
+	src.keys.keys = []*memoryDeployKey{
+		{c: src.keys, info: DeployKeyInfo{Name: "ci", Key: []byte("ssh-ed25519 AAAA ci")}},
+	}
+	dstClient := &fakeMigrateClient{}
+	dstStorage := memory.NewStorage()
+
+	opts := MigrateOptions{
+		Source:         src,
+		Destination:    dstClient,
+		DestinationRef: OrgRepositoryRef{OrganizationRef: OrganizationRef{Domain: "destination.example.com", Organization: "flux"}, RepositoryName: "repo"},
+		Metadata:       true,
+	}
+	srcRemote := newTestRemote(t, "https://source.example.com/flux/repo.git", srcRepo.Storer)
+	dstRemote := newTestRemote(t, "https://destination.example.com/flux/repo.git", dstStorage)
+
+	results, err := migrate(context.Background(), opts, srcRemote, dstRemote)
+	if err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	want := []MigrateResult{
+		{Item: MigrateItemRepository, ActionTaken: true},
+		{Item: MigrateItemRefs, ActionTaken: true},
+		{Item: MigrateItemRepositoryInfo, ActionTaken: true},
+		{Item: MigrateItemDeployKey, Name: "ci", ActionTaken: true},
+		{Item: MigrateItemTeamAccess, Skipped: true},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("migrate() results = %+v, want %+v", results, want)
+	}
+
+	for _, name := range []plumbing.ReferenceName{"refs/heads/main", "refs/heads/feature", "refs/tags/v1.0.0"} {
+		srcRef, err := srcRepo.Reference(name, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dstRef, err := dstStorage.Reference(name)
+		if err != nil {
+			t.Fatalf("ref %s wasn't mirrored: %v", name, err)
+		}
+		if dstRef.Hash() != srcRef.Hash() {
+			t.Errorf("ref %s = %s, want %s", name, dstRef.Hash(), srcRef.Hash())
+		}
+	}
+	if dst := dstClient.repo; !dst.actual.Equals(src.info) {
+		t.Errorf("destination repository info = %+v, want %+v", dst.actual, src.info)
+	}
+	if keys := dstClient.repo.keys.keys; len(keys) != 1 || keys[0].info.Name != "ci" {
+		t.Errorf("destination deploy keys = %+v, want the ci key", keys)
+	}
+
+	// Migrating again reuses the destination repository, and is a no-op
+	results, err = migrate(context.Background(), opts, srcRemote, dstRemote)
+	if err != nil {
+		t.Fatalf("migrate() again error = %v", err)
+	}
+	want = []MigrateResult{
+		{Item: MigrateItemRepository},
+		{Item: MigrateItemRefs},
+		{Item: MigrateItemRepositoryInfo},
+		{Item: MigrateItemDeployKey, Name: "ci"},
+		{Item: MigrateItemTeamAccess, Skipped: true},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("migrate() again results = %+v, want %+v", results, want)
+	}
+}
+
+func TestMigrateValidation(t *testing.T) {
+	_, err := Migrate(context.Background(), MigrateOptions{Destination: &fakeMigrateClient{}})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Migrate() error = %v, want %v", err, ErrInvalidArgument)
+	}
+}
@@ -17,9 +17,18 @@ limitations under the License.
 package gitprovider
 
 import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/fluxcd/go-git-providers/validation"
 )
 
+// maxCommitDateSkew is how far in the future the dates of a created commit may be, to allow for
+// clock skew between the client and the provider.
+const maxCommitDateSkew = 24 * time.Hour
+
 // MakeRepositoryCreateOptions returns a RepositoryCreateOptions based off the mutator functions
 // given to e.g. RepositoriesClient.Create(). The returned validation error may be ignored in the
 // case that the client allows e.g. other license templates than those that are common.
@@ -38,6 +47,27 @@ type RepositoryReconcileOption interface {
 	RepositoryCreateOption
 }
 
+// MakeRepositoryReconcileOptions returns a RepositoryReconcileOptions based off the mutator functions
+// given to e.g. RepositoriesClient.Reconcile(). Options only implementing RepositoryCreateOption
+// apply to the embedded RepositoryCreateOptions.
+func MakeRepositoryReconcileOptions(opts ...RepositoryReconcileOption) RepositoryReconcileOptions {
+	o := &RepositoryReconcileOptions{}
+	for _, opt := range opts {
+		if ro, ok := opt.(repositoryReconcileOptionApplier); ok {
+			ro.ApplyToRepositoryReconcileOptions(o)
+			continue
+		}
+		opt.ApplyToRepositoryCreateOptions(&o.RepositoryCreateOptions)
+	}
+	return *o
+}
+
+// repositoryReconcileOptionApplier is implemented by the options which apply to reconciling
+// repositories only, in addition to RepositoryCreateOption.
+type repositoryReconcileOptionApplier interface {
+	ApplyToRepositoryReconcileOptions(target *RepositoryReconcileOptions)
+}
+
 // RepositoryCreateOption is an interface for applying options to when creating repositories.
 type RepositoryCreateOption interface {
 	// ApplyToRepositoryCreateOptions should apply relevant options to the target.
@@ -45,6 +75,11 @@ type RepositoryCreateOption interface {
 }
 
 // RepositoryCreateOptions specifies optional options when creating a repository.
+//
+// Some options are applied in further steps once the repository has been created, e.g. rewording
+// the initial commit or creating the labels. If one of these steps fails, the created repository
+// is returned along with the error, so that the caller can retry the step or delete it: creating
+// it again returns ErrAlreadyExists.
 type RepositoryCreateOptions struct {
 	// AutoInit can be set to true in order to automatically initialize the Git repo with a
 	// README.md and optionally a license in the first commit.
@@ -55,6 +90,23 @@ type RepositoryCreateOptions struct {
 	// Default: nil.
 	// Available options: See the LicenseTemplate enum.
 	LicenseTemplate *LicenseTemplate
+
+	// InitialCommitMessage lets the user specify the message of the first commit when AutoInit
+	// is true. Providers not supporting it natively rewrite the first commit through Git.
+	// Default: nil, which means the provider's default message.
+	InitialCommitMessage *string
+
+	// Labels lets the user specify the issue labels to provision right after the repository
+	// has been created, in addition to the provider's default labels, if any. Bitbucket Server
+	// doesn't support labels.
+	// Default: nil.
+	Labels []LabelInfo
+
+	// MaxDescriptionLength overrides the maximum length of the repository description, in
+	// characters, the provider accepts. A longer description is rejected with ErrInvalidArgument
+	// before reaching the provider. 0 disables the check.
+	// Default: nil, which means the limit of the provider, if known.
+	MaxDescriptionLength *int
 }
 
 // ApplyToRepositoryCreateOptions applies the options defined in the options struct to the
@@ -67,6 +119,15 @@ func (opts *RepositoryCreateOptions) ApplyToRepositoryCreateOptions(target *Repo
 	if opts.LicenseTemplate != nil {
 		target.LicenseTemplate = opts.LicenseTemplate
 	}
+	if opts.InitialCommitMessage != nil {
+		target.InitialCommitMessage = opts.InitialCommitMessage
+	}
+	if opts.Labels != nil {
+		target.Labels = opts.Labels
+	}
+	if opts.MaxDescriptionLength != nil {
+		target.MaxDescriptionLength = opts.MaxDescriptionLength
+	}
 }
 
 // ValidateOptions validates that the options are valid.
@@ -75,12 +136,384 @@ func (opts *RepositoryCreateOptions) ValidateOptions() error {
 	if opts.LicenseTemplate != nil {
 		errs.Append(ValidateLicenseTemplate(*opts.LicenseTemplate), *opts.LicenseTemplate, "LicenseTemplate")
 	}
+	if opts.InitialCommitMessage != nil && len(strings.TrimSpace(*opts.InitialCommitMessage)) == 0 {
+		errs.Required("InitialCommitMessage")
+	}
+	names := make(map[string]bool, len(opts.Labels))
+	for _, label := range opts.Labels {
+		errs.Append(label.ValidateInfo(), label, "Labels")
+		if names[label.Name] {
+			errs.Invalid(label.Name, "Labels")
+		}
+		names[label.Name] = true
+	}
+	if opts.MaxDescriptionLength != nil && *opts.MaxDescriptionLength < 0 {
+		errs.Invalid(*opts.MaxDescriptionLength, "MaxDescriptionLength")
+	}
 	return errs.Error()
 }
 
+// ValidateDescription returns ErrInvalidArgument if the description of req is longer than
+// MaxDescriptionLength, or than providerMax if MaxDescriptionLength isn't set. A limit of 0
+// disables the check.
+func (opts *RepositoryCreateOptions) ValidateDescription(req RepositoryInfo, providerMax int) error {
+	limit := providerMax
+	if opts.MaxDescriptionLength != nil {
+		limit = *opts.MaxDescriptionLength
+	}
+	if limit <= 0 || req.Description == nil {
+		return nil
+	}
+	if length := utf8.RuneCountInString(*req.Description); length > limit {
+		return fmt.Errorf("repository description is %d characters long, the limit is %d: %w", length, limit, ErrInvalidArgument)
+	}
+	return nil
+}
+
+// RepositoryReconcileOptions specifies optional options when reconciling a repository.
+type RepositoryReconcileOptions struct {
+	// RepositoryCreateOptions are used if the repository doesn't exist and is created.
+	RepositoryCreateOptions
+
+	// Expected is the state of the repository the desired state is based on, e.g. as returned by
+	// Get(). If the actual state differs from it, or the repository doesn't exist anymore,
+	// ErrPreconditionFailed is returned instead of overwriting the concurrent change.
+	//
+	// This is a best-effort check, not an atomic compare-and-swap: the actual state is read and
+	// compared client-side before the update is sent, as none of the providers support conditional
+	// requests (e.g. If-Match) when updating repositories. A change made between the read and the
+	// update is still overwritten.
+	// Default: nil, which means the repository is reconciled unconditionally.
+	Expected *RepositoryInfo
+}
+
+// ApplyToRepositoryReconcileOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *RepositoryReconcileOptions) ApplyToRepositoryReconcileOptions(target *RepositoryReconcileOptions) {
+	opts.ApplyToRepositoryCreateOptions(&target.RepositoryCreateOptions)
+	if opts.Expected != nil {
+		target.Expected = opts.Expected
+	}
+}
+
+// CheckPrecondition returns ErrPreconditionFailed if Expected is set and doesn't match the actual
+// state of the repository. A nil actual means that the repository doesn't exist.
+// As actual is read before updating the repository, this doesn't make the update atomic.
+func (opts *RepositoryReconcileOptions) CheckPrecondition(actual *RepositoryInfo) error {
+	if opts.Expected == nil {
+		return nil
+	}
+	if actual == nil {
+		return fmt.Errorf("repository was deleted: %w", ErrPreconditionFailed)
+	}
+	if !opts.Expected.Equals(*actual) {
+		return fmt.Errorf("repository was changed: %w", ErrPreconditionFailed)
+	}
+	return nil
+}
+
+// CommitCreateOption is an interface for applying options when creating commits.
+type CommitCreateOption interface {
+	// ApplyToCommitCreateOptions should apply relevant options to the target.
+	ApplyToCommitCreateOptions(target *CommitCreateOptions)
+}
+
+// MakeCommitCreateOptions returns a CommitCreateOptions based off the mutator functions given
+// to CommitClient.Create().
+func MakeCommitCreateOptions(opts ...CommitCreateOption) CommitCreateOptions {
+	o := &CommitCreateOptions{}
+	for _, opt := range opts {
+		opt.ApplyToCommitCreateOptions(o)
+	}
+	return *o
+}
+
+// CommitCreateOptions specifies optional options when creating a commit.
+type CommitCreateOptions struct {
+	// ExpectedHeadSHA is the full SHA of the commit the branch is expected to point to. If the
+	// branch moved since, e.g. because of a concurrent push, ErrPreconditionFailed is returned
+	// instead of committing on top of it.
+	// Default: "", which means the commit is created on top of whatever the branch points to.
+	ExpectedHeadSHA string
+
+	// Parents are the full SHAs of the parents of the commit, e.g. to graft it onto a historical
+	// commit. The files are applied on top of the tree of the first parent, and the branch is
+	// moved to the commit even if it doesn't descend from the branch head. Hence ExpectedHeadSHA
+	// is required along with Parents, so that concurrent changes aren't discarded.
+	// ErrInvalidArgument is returned if a parent doesn't exist. Providers whose API can't create
+	// such commits push them through Git instead, see CommitOnParents.
+	// Default: nil, which means the only parent is the commit the branch points to.
+	Parents []string
+
+	// AuthorName and AuthorEmail identify the author of the commit, who also commits it. They're
+	// given together, and some providers require them along with the commit dates, as their API
+	// doesn't take a date without an identity, e.g. GitHub.
+	// Default: "", which means the identity the provider attributes commits to, e.g. the
+	// authenticated user.
+	AuthorName  string
+	AuthorEmail string
+
+	// AuthorDate is the date the changes were authored at, e.g. to create reproducible commits
+	// or to import changes made earlier. ErrNoProviderSupport is returned if the provider can't
+	// set the dates of the commits it creates.
+	// Default: nil, which means the time the commit is created.
+	AuthorDate *time.Time
+
+	// CommitterDate is the date the commit is recorded at.
+	// Default: nil, which means AuthorDate if set, and the time the commit is created otherwise.
+	CommitterDate *time.Time
+}
+
+// ApplyToCommitCreateOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *CommitCreateOptions) ApplyToCommitCreateOptions(target *CommitCreateOptions) {
+	if opts.ExpectedHeadSHA != "" {
+		target.ExpectedHeadSHA = opts.ExpectedHeadSHA
+	}
+	if opts.Parents != nil {
+		target.Parents = opts.Parents
+	}
+	if opts.AuthorName != "" {
+		target.AuthorName = opts.AuthorName
+	}
+	if opts.AuthorEmail != "" {
+		target.AuthorEmail = opts.AuthorEmail
+	}
+	if opts.AuthorDate != nil {
+		target.AuthorDate = opts.AuthorDate
+	}
+	if opts.CommitterDate != nil {
+		target.CommitterDate = opts.CommitterDate
+	}
+}
+
+// ValidateOptions validates that the options are valid. ExpectedHeadSHA is required along with
+// Parents, AuthorName and AuthorEmail are required together, and the commit dates must lie
+// between the Unix epoch and a day from now, as dates outside this range are most likely a
+// mistake.
+func (opts *CommitCreateOptions) ValidateOptions() error {
+	errs := validation.New("CommitCreateOptions")
+	if len(opts.Parents) > 0 && opts.ExpectedHeadSHA == "" {
+		errs.Required("ExpectedHeadSHA")
+	}
+	if opts.AuthorName != "" && opts.AuthorEmail == "" {
+		errs.Required("AuthorEmail")
+	}
+	if opts.AuthorEmail != "" && opts.AuthorName == "" {
+		errs.Required("AuthorName")
+	}
+	if opts.AuthorDate != nil && !isValidCommitDate(*opts.AuthorDate) {
+		errs.Invalid(*opts.AuthorDate, "AuthorDate")
+	}
+	if opts.CommitterDate != nil && !isValidCommitDate(*opts.CommitterDate) {
+		errs.Invalid(*opts.CommitterDate, "CommitterDate")
+	}
+	return errs.Error()
+}
+
+func isValidCommitDate(date time.Time) bool {
+	return !date.Before(time.Unix(0, 0)) && !date.After(time.Now().Add(maxCommitDateSkew))
+}
+
+// CheckHead returns ErrPreconditionFailed if ExpectedHeadSHA is set and doesn't match head, the
+// SHA of the commit the branch points to.
+func (opts *CommitCreateOptions) CheckHead(head string) error {
+	if opts.ExpectedHeadSHA == "" || opts.ExpectedHeadSHA == head {
+		return nil
+	}
+	return fmt.Errorf("branch points to %s instead of %s: %w", head, opts.ExpectedHeadSHA, ErrPreconditionFailed)
+}
+
+// PullRequestListOption is an interface for applying options when listing pull requests.
+type PullRequestListOption interface {
+	// ApplyToPullRequestListOptions should apply relevant options to the target.
+	ApplyToPullRequestListOptions(target *PullRequestListOptions)
+}
+
+// MakePullRequestListOptions returns a PullRequestListOptions based off the mutator functions
+// given to PullRequestClient.List().
+func MakePullRequestListOptions(opts ...PullRequestListOption) PullRequestListOptions {
+	o := &PullRequestListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToPullRequestListOptions(o)
+	}
+	return *o
+}
+
+// PullRequestListOptions specifies optional options when listing pull requests.
+type PullRequestListOptions struct {
+	// Labels restricts the list to the pull requests carrying all of the given labels.
+	// Providers which can't filter by label server-side filter the listed pull requests instead.
+	// Default: nil, which means the pull requests aren't filtered by label.
+	Labels []string
+}
+
+// ApplyToPullRequestListOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *PullRequestListOptions) ApplyToPullRequestListOptions(target *PullRequestListOptions) {
+	if opts.Labels != nil {
+		target.Labels = opts.Labels
+	}
+}
+
+// FilterByLabels returns the pull requests of prs carrying all of the Labels. This is the
+// client-side fallback for providers which can't filter by label server-side.
+func (opts *PullRequestListOptions) FilterByLabels(prs []PullRequest) []PullRequest {
+	if len(opts.Labels) == 0 {
+		return prs
+	}
+	filtered := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if hasAllLabels(pr.Get().Labels, opts.Labels) {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered
+}
+
+// hasAllLabels returns whether labels contains every label of want.
+func hasAllLabels(labels, want []string) bool {
+	set := make(map[string]struct{}, len(labels))
+	for _, l := range labels {
+		set[l] = struct{}{}
+	}
+	for _, l := range want {
+		if _, ok := set[l]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// PullRequestCreateOption is an interface for applying options when creating pull requests.
+type PullRequestCreateOption interface {
+	// ApplyToPullRequestCreateOptions should apply relevant options to the target.
+	ApplyToPullRequestCreateOptions(target *PullRequestCreateOptions)
+}
+
+// MakePullRequestCreateOptions returns a PullRequestCreateOptions based off the mutator functions
+// given to PullRequestClient.Create().
+func MakePullRequestCreateOptions(opts ...PullRequestCreateOption) PullRequestCreateOptions {
+	o := &PullRequestCreateOptions{}
+	for _, opt := range opts {
+		opt.ApplyToPullRequestCreateOptions(o)
+	}
+	return *o
+}
+
+// PullRequestCreateOptions specifies optional options when creating a pull request.
+type PullRequestCreateOptions struct {
+	// Draft opens the pull request as a draft, which can't be merged until it's marked as ready.
+	// Providers without native drafts follow their title convention, e.g. a "Draft:" prefix.
+	// ErrNoProviderSupport is returned if the provider has no notion of drafts.
+	// Default: false.
+	Draft bool
+}
+
+// ApplyToPullRequestCreateOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *PullRequestCreateOptions) ApplyToPullRequestCreateOptions(target *PullRequestCreateOptions) {
+	if opts.Draft {
+		target.Draft = opts.Draft
+	}
+}
+
+// CollaboratorListOption is an interface for applying options when listing collaborators.
+type CollaboratorListOption interface {
+	// ApplyToCollaboratorListOptions should apply relevant options to the target.
+	ApplyToCollaboratorListOptions(target *CollaboratorListOptions)
+}
+
+// MakeCollaboratorListOptions returns a CollaboratorListOptions based off the mutator functions
+// given to CollaboratorClient.List().
+func MakeCollaboratorListOptions(opts ...CollaboratorListOption) CollaboratorListOptions {
+	o := &CollaboratorListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToCollaboratorListOptions(o)
+	}
+	return *o
+}
+
+// CollaboratorListOptions specifies optional options when listing collaborators.
+type CollaboratorListOptions struct {
+	// Effective lists the effective permission of every user having access to the repository,
+	// including the access inherited from the organization and granted through teams. The
+	// highest of the permissions granted to a user wins.
+	// Default: false, which means only the users granted access on the repository are listed.
+	Effective bool
+}
+
+// ApplyToCollaboratorListOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *CollaboratorListOptions) ApplyToCollaboratorListOptions(target *CollaboratorListOptions) {
+	if opts.Effective {
+		target.Effective = true
+	}
+}
+
+// TopicsReconcileOption is an interface for applying options when reconciling repository topics.
+type TopicsReconcileOption interface {
+	// ApplyToTopicsReconcileOptions should apply relevant options to the target.
+	ApplyToTopicsReconcileOptions(target *TopicsReconcileOptions)
+}
+
+// MakeTopicsReconcileOptions returns a TopicsReconcileOptions based off the mutator functions
+// given to TopicsClient.Reconcile().
+func MakeTopicsReconcileOptions(opts ...TopicsReconcileOption) TopicsReconcileOptions {
+	o := &TopicsReconcileOptions{}
+	for _, opt := range opts {
+		opt.ApplyToTopicsReconcileOptions(o)
+	}
+	return *o
+}
+
+// WithAllowedTopics restricts the topics which may be set on a repository to the given
+// vocabulary, see TopicsReconcileOptions.AllowedTopics.
+func WithAllowedTopics(allowed []string) TopicsReconcileOption {
+	return &TopicsReconcileOptions{AllowedTopics: allowed}
+}
+
+// TopicsReconcileOptions specifies optional options when reconciling repository topics.
+type TopicsReconcileOptions struct {
+	// AllowedTopics is the vocabulary the topics must be part of. Reconciling any other topic
+	// fails with ErrInvalidArgument, before anything is sent to the provider.
+	// Default: nil, which means all topics are allowed.
+	AllowedTopics []string
+}
+
+// ApplyToTopicsReconcileOptions applies the options defined in the options struct to the
+// target struct that is being completed.
+func (opts *TopicsReconcileOptions) ApplyToTopicsReconcileOptions(target *TopicsReconcileOptions) {
+	if opts.AllowedTopics != nil {
+		target.AllowedTopics = opts.AllowedTopics
+	}
+}
+
+// CheckTopics returns ErrInvalidArgument, naming the first offending topic, if AllowedTopics is
+// set and topics contains a topic which isn't part of it.
+func (opts *TopicsReconcileOptions) CheckTopics(topics []string) error {
+	if opts.AllowedTopics == nil {
+		return nil
+	}
+	allowed := make(map[string]struct{}, len(opts.AllowedTopics))
+	for _, t := range opts.AllowedTopics {
+		allowed[t] = struct{}{}
+	}
+	for _, t := range topics {
+		if _, ok := allowed[t]; !ok {
+			return fmt.Errorf("topic %q isn't in the allowed topics: %w", t, ErrInvalidArgument)
+		}
+	}
+	return nil
+}
+
 // FilesGetOptions specifies optional options when fetcing files.
 type FilesGetOptions struct {
 	Recursive bool
+
+	// Concurrency is the maximum number of files downloaded at once when getting a directory.
+	// Default: 0, which means 4.
+	Concurrency int
 }
 
 // FilesGetOption is an interface for applying options when fetching/getting files
@@ -92,5 +525,152 @@ type FilesGetOption interface {
 func (opts *FilesGetOptions) ApplyFilesGetOptions(target *FilesGetOptions) {
 	// Go through each field in opts, and apply it to target if set
 	target.Recursive = opts.Recursive
+	target.Concurrency = opts.Concurrency
+
+}
+
+// CommitListOptions specifies which commits to list with CommitClient.List.
+type CommitListOptions struct {
+	// Branch is the branch to list the commits of.
+	// Default: "", which means the default branch of the repository.
+	Branch string
+
+	// Author only lists the commits of the given author. Depending on the provider, this is
+	// matched against the name, email address and/or login of the commit author.
+	// Default: "", which means commits of all authors.
+	Author string
+
+	// Since only lists the commits created at or after the given time.
+	// Default: nil.
+	Since *time.Time
 
+	// Until only lists the commits created at or before the given time.
+	// Default: nil.
+	Until *time.Time
+
+	// Path only lists the commits touching the given file or directory.
+	// Default: "", which means the whole repository.
+	Path string
+
+	// PerPage is the number of commits to list per page.
+	// Default: 0, which means the provider's default page size.
+	PerPage int
+
+	// Page is the 1-based page of commits to list.
+	// Default: 0, which means the first page.
+	Page int
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *CommitListOptions) ValidateOptions() error {
+	errs := validation.New("CommitListOptions")
+	if opts.Since != nil && opts.Until != nil && opts.Since.After(*opts.Until) {
+		errs.Invalid(*opts.Since, "Since")
+	}
+	if opts.PerPage < 0 {
+		errs.Invalid(opts.PerPage, "PerPage")
+	}
+	if opts.Page < 0 {
+		errs.Invalid(opts.Page, "Page")
+	}
+	return errs.Error()
+}
+
+// AuditLogOptions specifies which entries to list with AuditLogClient.List.
+type AuditLogOptions struct {
+	// Since only lists the events that happened at or after the given time.
+	// Default: nil.
+	Since *time.Time
+
+	// Until only lists the events that happened at or before the given time.
+	// Default: nil.
+	Until *time.Time
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *AuditLogOptions) ValidateOptions() error {
+	errs := validation.New("AuditLogOptions")
+	if opts.Since != nil && opts.Until != nil && opts.Since.After(*opts.Until) {
+		errs.Invalid(*opts.Since, "Since")
+	}
+	return errs.Error()
+}
+
+// HasFilters returns true if any of the Author, Since and Until filters is set.
+func (opts *CommitListOptions) HasFilters() bool {
+	return opts.Author != "" || opts.Since != nil || opts.Until != nil
+}
+
+// Before returns true if a commit created at createdAt precedes Since. As commits are listed
+// newest first, providers filtering client-side stop listing at such a commit, like
+// "git log --since" does.
+func (opts *CommitListOptions) Before(createdAt time.Time) bool {
+	return opts.Since != nil && createdAt.Before(*opts.Since)
+}
+
+// PageBounds returns the bounds of the page selected by PerPage and Page among n commits, using
+// defaultPerPage if PerPage isn't set. It is meant for providers filtering the commits client-side,
+// which need to list all matching commits before cutting the requested page out of them.
+func (opts *CommitListOptions) PageBounds(n, defaultPerPage int) (start, end int) {
+	perPage, page := opts.PerPage, opts.Page
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	if page == 0 {
+		page = 1
+	}
+	start = min(perPage*(page-1), n)
+	return start, min(start+perPage, n)
+}
+
+// Matches returns true if a commit with the given author and creation time passes the Author,
+// Since and Until filters. It is meant for providers that can't apply these filters server-side;
+// any of authors matching Author is sufficient.
+func (opts *CommitListOptions) Matches(createdAt time.Time, authors ...string) bool {
+	if opts.Since != nil && createdAt.Before(*opts.Since) {
+		return false
+	}
+	if opts.Until != nil && createdAt.After(*opts.Until) {
+		return false
+	}
+	if opts.Author == "" {
+		return true
+	}
+	for _, author := range authors {
+		if strings.EqualFold(author, opts.Author) {
+			return true
+		}
+	}
+	return false
+}
+
+// PipelineListOptions specifies which pipelines to list with PipelineClient.List.
+type PipelineListOptions struct {
+	// Ref only lists the pipelines of the given branch or tag.
+	// Default: "", which means the pipelines of all refs.
+	Ref string
+
+	// Status only lists the pipelines with the given status, as reported by the provider.
+	// Default: "", which means the pipelines of any status.
+	Status string
+
+	// PerPage is the number of pipelines to list per page.
+	// Default: 0, which means the provider's default page size.
+	PerPage int
+
+	// Page is the 1-based page of pipelines to list.
+	// Default: 0, which means the first page.
+	Page int
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *PipelineListOptions) ValidateOptions() error {
+	errs := validation.New("PipelineListOptions")
+	if opts.PerPage < 0 {
+		errs.Invalid(opts.PerPage, "PerPage")
+	}
+	if opts.Page < 0 {
+		errs.Invalid(opts.Page, "Page")
+	}
+	return errs.Error()
 }
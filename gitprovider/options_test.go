@@ -20,6 +20,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/validation"
 )
@@ -64,6 +65,59 @@ func TestMakeRepositoryCreateOptions(t *testing.T) {
 			want:        *invalidRepoCreateOpts,
 			expectedErr: validation.ErrFieldEnumInvalid,
 		},
+		{
+			name: "initial commit message",
+			opts: []RepositoryCreateOption{
+				repoCreateOpts1,
+				&RepositoryCreateOptions{InitialCommitMessage: StringVar("chore: initialize repository")},
+			},
+			want: RepositoryCreateOptions{
+				AutoInit:             BoolVar(true),
+				LicenseTemplate:      LicenseTemplateVar(LicenseTemplateMIT),
+				InitialCommitMessage: StringVar("chore: initialize repository"),
+			},
+		},
+		{
+			name:        "empty initial commit message",
+			opts:        []RepositoryCreateOption{&RepositoryCreateOptions{InitialCommitMessage: StringVar(" ")}},
+			want:        RepositoryCreateOptions{InitialCommitMessage: StringVar(" ")},
+			expectedErr: validation.ErrFieldRequired,
+		},
+		{
+			name: "labels",
+			opts: []RepositoryCreateOption{&RepositoryCreateOptions{Labels: []LabelInfo{
+				{Name: "bug", Color: "#D73A4A"},
+				{Name: "enhancement", Color: "a2eeef", Description: StringVar("New feature or request")},
+			}}},
+			want: RepositoryCreateOptions{Labels: []LabelInfo{
+				{Name: "bug", Color: "#D73A4A"},
+				{Name: "enhancement", Color: "a2eeef", Description: StringVar("New feature or request")},
+			}},
+		},
+		{
+			name:        "invalid label color",
+			opts:        []RepositoryCreateOption{&RepositoryCreateOptions{Labels: []LabelInfo{{Name: "bug", Color: "red"}}}},
+			want:        RepositoryCreateOptions{Labels: []LabelInfo{{Name: "bug", Color: "red"}}},
+			expectedErr: validation.ErrFieldInvalid,
+		},
+		{
+			name:        "missing label color",
+			opts:        []RepositoryCreateOption{&RepositoryCreateOptions{Labels: []LabelInfo{{Name: "bug"}}}},
+			want:        RepositoryCreateOptions{Labels: []LabelInfo{{Name: "bug"}}},
+			expectedErr: validation.ErrFieldRequired,
+		},
+		{
+			name: "duplicate label names",
+			opts: []RepositoryCreateOption{&RepositoryCreateOptions{Labels: []LabelInfo{
+				{Name: "bug", Color: "d73a4a"},
+				{Name: "bug", Color: "a2eeef"},
+			}}},
+			want: RepositoryCreateOptions{Labels: []LabelInfo{
+				{Name: "bug", Color: "d73a4a"},
+				{Name: "bug", Color: "a2eeef"},
+			}},
+			expectedErr: validation.ErrFieldInvalid,
+		},
 		{
 			name: "partial options can form an unit",
 			opts: []RepositoryCreateOption{
@@ -93,3 +147,175 @@ func TestMakeRepositoryCreateOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeRepositoryReconcileOptions(t *testing.T) {
+	expected := &RepositoryInfo{Description: StringVar("observed")}
+	got := MakeRepositoryReconcileOptions(
+		repoCreateOpts1,
+		&RepositoryReconcileOptions{Expected: expected},
+		partialCreateOpts1,
+	)
+	want := RepositoryReconcileOptions{
+		RepositoryCreateOptions: RepositoryCreateOptions{AutoInit: BoolVar(false), LicenseTemplate: LicenseTemplateVar(LicenseTemplateMIT)},
+		Expected:                expected,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MakeRepositoryReconcileOptions() = %v, want %v", got, want)
+	}
+}
+
+func TestRepositoryReconcileOptions_CheckPrecondition(t *testing.T) {
+	observed := RepositoryInfo{Description: StringVar("observed")}
+	changed := RepositoryInfo{Description: StringVar("changed")}
+	tests := []struct {
+		name     string
+		expected *RepositoryInfo
+		actual   *RepositoryInfo
+		wantErr  error
+	}{
+		{name: "unconditional", actual: &changed},
+		{name: "unchanged", expected: &observed, actual: &observed},
+		{name: "changed", expected: &observed, actual: &changed, wantErr: ErrPreconditionFailed},
+		{name: "deleted", expected: &observed, wantErr: ErrPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &RepositoryReconcileOptions{Expected: tt.expected}
+			if err := opts.CheckPrecondition(tt.actual); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RepositoryReconcileOptions.CheckPrecondition() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepositoryCreateOptions_ValidateDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        RepositoryCreateOptions
+		description *string
+		providerMax int
+		wantErr     error
+	}{
+		{name: "no description", providerMax: 3},
+		{name: "within the provider limit", description: StringVar("abc"), providerMax: 3},
+		{name: "characters are counted, not bytes", description: StringVar("äöü"), providerMax: 3},
+		{name: "over the provider limit", description: StringVar("abcd"), providerMax: 3, wantErr: ErrInvalidArgument},
+		{name: "no provider limit", description: StringVar("abcd")},
+		{name: "limit raised", opts: RepositoryCreateOptions{MaxDescriptionLength: IntVar(4)}, description: StringVar("abcd"), providerMax: 3},
+		{name: "limit lowered", opts: RepositoryCreateOptions{MaxDescriptionLength: IntVar(2)}, description: StringVar("abc"), providerMax: 3, wantErr: ErrInvalidArgument},
+		{name: "limit disabled", opts: RepositoryCreateOptions{MaxDescriptionLength: IntVar(0)}, description: StringVar("abcd"), providerMax: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.ValidateDescription(RepositoryInfo{Description: tt.description}, tt.providerMax)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RepositoryCreateOptions.ValidateDescription() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommitListOptions_Matches(t *testing.T) {
+	since := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	opts := &CommitListOptions{Author: "alice@example.com", Since: &since, Until: &until}
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		authors   []string
+		want      bool
+	}{
+		{
+			name:      "matching email",
+			createdAt: since.Add(time.Hour),
+			authors:   []string{"Alice", "Alice@Example.com"},
+			want:      true,
+		},
+		{
+			name:      "other author",
+			createdAt: since.Add(time.Hour),
+			authors:   []string{"Bob", "bob@example.com"},
+		},
+		{
+			name:      "before since",
+			createdAt: since.Add(-time.Hour),
+			authors:   []string{"alice@example.com"},
+		},
+		{
+			name:      "after until",
+			createdAt: until.Add(time.Hour),
+			authors:   []string{"alice@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := opts.Matches(tt.createdAt, tt.authors...); got != tt.want {
+				t.Errorf("CommitListOptions.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitListOptions_ValidateOptions(t *testing.T) {
+	since := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	opts := &CommitListOptions{Since: &since, Until: &until}
+	validation.TestExpectErrors(t, "CommitListOptions.ValidateOptions", opts.ValidateOptions(), validation.ErrFieldInvalid)
+}
+
+func TestCommitCreateOptions_ValidateOptions(t *testing.T) {
+	authored := time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC)
+	committed := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	beforeEpoch := time.Date(1969, time.December, 31, 0, 0, 0, 0, time.UTC)
+	nextYear := time.Now().AddDate(1, 0, 0)
+	tests := []struct {
+		name     string
+		opts     CommitCreateOptions
+		expected error
+	}{
+		{
+			name: "no dates",
+		},
+		{
+			name: "backdated",
+			opts: CommitCreateOptions{
+				AuthorDate:    &authored,
+				CommitterDate: &committed,
+			},
+		},
+		{
+			name:     "before the Unix epoch",
+			opts:     CommitCreateOptions{AuthorDate: &beforeEpoch},
+			expected: validation.ErrFieldInvalid,
+		},
+		{
+			name:     "in the future",
+			opts:     CommitCreateOptions{CommitterDate: &nextYear},
+			expected: validation.ErrFieldInvalid,
+		},
+		{
+			name: "parents with the expected head",
+			opts: CommitCreateOptions{Parents: []string{"p1"}, ExpectedHeadSHA: "c2"},
+		},
+		{
+			name:     "parents without the expected head",
+			opts:     CommitCreateOptions{Parents: []string{"p1"}},
+			expected: validation.ErrFieldRequired,
+		},
+		{
+			name: "author",
+			opts: CommitCreateOptions{AuthorName: "Flux", AuthorEmail: "flux@example.com", AuthorDate: &authored},
+		},
+		{
+			name:     "author without email",
+			opts:     CommitCreateOptions{AuthorName: "Flux"},
+			expected: validation.ErrFieldRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation.TestExpectErrors(t, "CommitCreateOptions.ValidateOptions", tt.opts.ValidateOptions(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// pageBudgetKey is the context key of the pageBudget of a page, set by PageContext.
+type pageBudgetKey struct{}
+
+// pageBudget tracks the time spent in the requests fetching a page of a paginated list.
+type pageBudget struct {
+	mu    sync.Mutex
+	spent time.Duration
+}
+
+// remaining returns the time left out of timeout.
+func (b *pageBudget) remaining(timeout time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return timeout - b.spent
+}
+
+// add records d as spent.
+func (b *pageBudget) add(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent += d
+}
+
+// pageBudgetFrom returns the budget of the page ctx is fetching, or nil if ctx isn't a page context.
+func pageBudgetFrom(ctx context.Context) *pageBudget {
+	b, _ := ctx.Value(pageBudgetKey{}).(*pageBudget)
+	return b
+}
+
+// PageContext returns the context to fetch the next page of a paginated list with. The requests
+// made with it are bounded by the page timeout of the client, if any, see WithPageTimeout. The
+// error of ctx is returned if it's done already, so that listings stop between pages once their
+// context is cancelled. The returned cancel function must be called once the page is fetched.
+func PageContext(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	pageCtx, cancel := context.WithCancel(context.WithValue(ctx, pageBudgetKey{}, &pageBudget{}))
+	return pageCtx, cancel, nil
+}
+
+// pageTimeoutTransport returns a ChainableRoundTripperFunc bounding the requests made with a
+// PageContext, until their response body is closed, to timeout in total per page. It sits below
+// the retry transport, so that the waits between retries don't count against the timeout.
+func pageTimeoutTransport(timeout time.Duration) ChainableRoundTripperFunc {
+	return func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		return &pageTimeoutRoundTripper{next: in, timeout: timeout}
+	}
+}
+
+// pageTimeoutRoundTripper is a http.RoundTripper bounding the requests fetching pages.
+type pageTimeoutRoundTripper struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *pageTimeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	budget := pageBudgetFrom(req.Context())
+	if budget == nil {
+		return rt.next.RoundTrip(req)
+	}
+	remaining := budget.remaining(rt.timeout)
+	if remaining <= 0 {
+		return nil, context.DeadlineExceeded
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), remaining)
+	start := time.Now()
+	resp, err := rt.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		budget.add(time.Since(start))
+		if ctx.Err() != nil && req.Context().Err() == nil {
+			// The page ran out of time, rather than the caller's context
+			return nil, context.DeadlineExceeded
+		}
+		return nil, err
+	}
+	resp.Body = &pageBody{ReadCloser: resp.Body, done: func() {
+		cancel()
+		budget.add(time.Since(start))
+	}}
+	return resp, nil
+}
+
+// pageBody releases the timeout of a request once its body is closed.
+type pageBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+// Close implements io.Closer.
+func (b *pageBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPageTimeoutTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       bool
+		delay      time.Duration
+		failures   int
+		wantErr    error
+		wantStatus int
+	}{
+		{name: "fast page", page: true, wantStatus: http.StatusOK},
+		{name: "slow page", page: true, delay: 200 * time.Millisecond, wantErr: context.DeadlineExceeded},
+		// The requests not fetching a page aren't bounded
+		{name: "slow request", delay: 200 * time.Millisecond, wantStatus: http.StatusOK},
+		// The waits between the retries are longer than the timeout, but don't count against it
+		{name: "retried page", page: true, failures: 2, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= int32(tt.failures) {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client, err := BuildClientFromTransportChain([]ChainableRoundTripperFunc{
+				pageTimeoutTransport(50 * time.Millisecond),
+				retryTransport(RetryPolicy{
+					InitialInterval: 40 * time.Millisecond,
+					Multiplier:      1,
+					MaxInterval:     40 * time.Millisecond,
+					MaxElapsedTime:  time.Second,
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			if tt.page {
+				pageCtx, cancel, err := PageContext(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer cancel()
+				ctx = pageCtx
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+				}
+				if got := requests.Load(); got != 1 {
+					t.Errorf("server got %d requests, want 1", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Do() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPageContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := PageContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("PageContext() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// ReleasePublisher provides the primitives PublishRelease is built on, for a specific repository.
+// The providers implement it to back ReleaseClient.Publish.
+type ReleasePublisher interface {
+	// Commits returns the client creating the released commit, or resolving the released ref.
+	Commits() CommitClient
+	// CreateTag creates an annotated tag named name, pointing to the commit with the given SHA.
+	// ErrAlreadyExists is returned if the tag already exists.
+	CreateTag(ctx context.Context, name, sha, message string) error
+	// DeleteTag deletes the tag named name.
+	DeleteTag(ctx context.Context, name string) error
+	// CreateRelease creates the release of the existing tag named req.TagName.
+	CreateRelease(ctx context.Context, req ReleaseInfo) error
+}
+
+// PublishOptions specifies the release published by PublishRelease.
+type PublishOptions struct {
+	// Files are committed on Branch first, the tag pointing to that commit. Files with a nil
+	// Content are deleted. If no files are given, the tag points to Ref instead.
+	// +optional
+	Files []CommitFile
+
+	// Branch is the branch the files are committed on. Required if Files are given.
+	// +optional
+	Branch string
+
+	// CommitMessage is the message of the commit. Required if Files are given.
+	// +optional
+	CommitMessage string
+
+	// Ref is the branch, tag or commit SHA the tag points to. Required if no Files are given.
+	// +optional
+	Ref string
+
+	// TagMessage is the message of the annotated tag.
+	// Default value: the name of the release, or the name of the tag if the release has none.
+	// +optional
+	TagMessage string
+
+	// Release is the release created, Release.TagName naming the created tag.
+	// +required
+	Release ReleaseInfo
+
+	// Rollback deletes the created tag if creating the release fails, so that publishing can be
+	// retried. This is a destructive action, refused with ErrDestructiveCallDisallowed unless
+	// destructive API calls are enabled. The commit is never reverted, as it may have been built
+	// upon already.
+	// +optional
+	Rollback bool
+}
+
+// ValidateOptions validates that the options are valid.
+func (opts *PublishOptions) ValidateOptions() error {
+	errs := validation.New("PublishOptions")
+	if len(opts.Files) != 0 {
+		if opts.Branch == "" {
+			errs.Required("Branch")
+		}
+		if opts.CommitMessage == "" {
+			errs.Required("CommitMessage")
+		}
+	} else if opts.Ref == "" {
+		errs.Required("Ref")
+	}
+	errs.Append(opts.Release.ValidateInfo(), opts.Release, "Release")
+	return errs.Error()
+}
+
+// PublishStep is a step of publishing a release.
+type PublishStep string
+
+const (
+	// PublishStepCommit creates the commit, or resolves the ref, to tag.
+	PublishStepCommit = PublishStep("commit")
+
+	// PublishStepTag creates the tag.
+	PublishStepTag = PublishStep("tag")
+
+	// PublishStepRelease creates the release.
+	PublishStepRelease = PublishStep("release")
+)
+
+// PublishResult tells what PublishRelease left in the repository.
+type PublishResult struct {
+	// CommitSHA is the SHA of the tagged commit, empty if it couldn't be created or resolved.
+	CommitSHA string
+
+	// CommitCreated is true if a commit was created.
+	CommitCreated bool
+
+	// TagCreated is true if the tag was created, and wasn't rolled back.
+	TagCreated bool
+
+	// ReleaseCreated is true if the release was created.
+	ReleaseCreated bool
+}
+
+// PublishError is returned by PublishRelease when a step fails. Result tells which of the
+// earlier steps made changes that are left in the repository.
+type PublishError struct {
+	// Step is the step that failed.
+	Step PublishStep
+
+	// Err is the error of the failed step.
+	Err error
+
+	// RollbackErr is the error that occurred while rolling back the earlier steps, if any.
+	RollbackErr error
+
+	// Result tells what was left in the repository.
+	Result PublishResult
+}
+
+// Error implements the error interface.
+func (e *PublishError) Error() string {
+	msg := fmt.Sprintf("failed to publish the release, %s step failed: %v", e.Step, e.Err)
+	if e.RollbackErr != nil {
+		msg += fmt.Sprintf(", and rolling back failed: %v", e.RollbackErr)
+	}
+	return msg
+}
+
+// Unwrap returns the errors of the failed step and of the rollback, for errors.Is and errors.As.
+func (e *PublishError) Unwrap() []error {
+	if e.RollbackErr == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Err, e.RollbackErr}
+}
+
+// PublishRelease creates a commit (or resolves an existing ref), an annotated tag pointing to it,
+// and the release of that tag, as described by opts.
+//
+// These are separate API calls, hence a step may fail after the earlier ones made changes. A
+// *PublishError is returned in that case, telling what was left in the repository. If
+// opts.Rollback is set, the tag is deleted when creating the release fails; as this is a
+// destructive action, ErrDestructiveCallDisallowed is returned before any change is made unless
+// destructiveActions is true.
+func PublishRelease(ctx context.Context, p ReleasePublisher, opts PublishOptions, destructiveActions bool) (PublishResult, error) {
+	result := PublishResult{}
+	if err := opts.ValidateOptions(); err != nil {
+		return result, err
+	}
+	if opts.Rollback && !destructiveActions {
+		return result, fmt.Errorf("cannot roll back the release: %w", ErrDestructiveCallDisallowed)
+	}
+
+	if len(opts.Files) != 0 {
+		commit, err := p.Commits().Create(ctx, opts.Branch, opts.CommitMessage, opts.Files)
+		if err != nil {
+			return result, &PublishError{Step: PublishStepCommit, Err: err, Result: result}
+		}
+		result.CommitSHA, result.CommitCreated = commit.Get().Sha, true
+	} else {
+		sha, err := p.Commits().ResolveRef(ctx, opts.Ref)
+		if err != nil {
+			return result, &PublishError{Step: PublishStepCommit, Err: err, Result: result}
+		}
+		result.CommitSHA = sha
+	}
+
+	tagMessage := opts.TagMessage
+	if tagMessage == "" {
+		tagMessage = opts.Release.Name
+	}
+	if tagMessage == "" {
+		tagMessage = opts.Release.TagName
+	}
+	if err := p.CreateTag(ctx, opts.Release.TagName, result.CommitSHA, tagMessage); err != nil {
+		return result, &PublishError{Step: PublishStepTag, Err: err, Result: result}
+	}
+	result.TagCreated = true
+
+	if err := p.CreateRelease(ctx, opts.Release); err != nil {
+		publishErr := &PublishError{Step: PublishStepRelease, Err: err}
+		if opts.Rollback {
+			// Delete the tag, even if ctx is done, as it's unused without its release
+			if rollbackErr := p.DeleteTag(context.WithoutCancel(ctx), opts.Release.TagName); rollbackErr != nil && !errors.Is(rollbackErr, ErrNotFound) {
+				publishErr.RollbackErr = rollbackErr
+			} else {
+				result.TagCreated = false
+			}
+		}
+		publishErr.Result = result
+		return result, publishErr
+	}
+	result.ReleaseCreated = true
+	return result, nil
+}
+
+// EnsureReleaseTag makes sure the tag of the given release exists before the release is created,
+// as providers would otherwise tag the default branch implicitly. A missing tag is created as a
+// lightweight tag on req.TargetCommitish, which is resolved to a commit SHA with commits.
+//
+// ErrNotFound is returned if the tag doesn't exist and req.TargetCommitish is empty.
+func EnsureReleaseTag(ctx context.Context, tags TagClient, commits CommitClient, req ReleaseInfo) error {
+	_, err := tags.Get(ctx, req.TagName)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if req.TargetCommitish == "" {
+		return fmt.Errorf("tag %q doesn't exist, and no target commitish is given: %w", req.TagName, ErrNotFound)
+	}
+
+	sha, err := commits.ResolveRef(ctx, req.TargetCommitish)
+	if err != nil {
+		return fmt.Errorf("failed to resolve target commitish %q: %w", req.TargetCommitish, err)
+	}
+	// The tag may have been created concurrently, which is just as good
+	if _, err := tags.Create(ctx, req.TagName, sha, ""); err != nil && !errors.Is(err, ErrAlreadyExists) {
+		return fmt.Errorf("failed to create tag %q: %w", req.TagName, err)
+	}
+	return nil
+}
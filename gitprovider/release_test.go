@@ -0,0 +1,265 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// memoryCommit is a Commit with the given information.
+type memoryCommit CommitInfo
+
+func (c memoryCommit) APIObject() interface{} { return CommitInfo(c) }
+func (c memoryCommit) Get() CommitInfo        { return CommitInfo(c) }
+
+// publishedCommits is the CommitClient of a memoryPublisher, the branch "main" pointing to mainSHA.
+type publishedCommits struct {
+	memoryCommits
+}
+
+const mainSHA = "8b5e6a6ab5c2b5e2e0a7cd6b9b1fd7a7c1e4a2d0"
+
+func (c publishedCommits) Create(ctx context.Context, branch, message string, files []CommitFile, opts ...CommitCreateOption) (Commit, error) {
+	if _, err := c.memoryRepo.Create(ctx, branch, message, files, opts...); err != nil {
+		return nil, err
+	}
+	return memoryCommit{Sha: "0d2a4e1c7a7df1b9b6dc7a0e2e5b2c5ba6a6e5b8"}, nil
+}
+
+func (c publishedCommits) ResolveRef(_ context.Context, ref string) (string, error) {
+	if ref != "main" {
+		return "", ErrNotFound
+	}
+	return mainSHA, nil
+}
+
+// memoryPublisher is an in-memory ReleasePublisher, failing to create releases with releaseErr.
+type memoryPublisher struct {
+	repo       memoryRepo
+	tags       map[string]string
+	releases   []ReleaseInfo
+	releaseErr error
+}
+
+func (p *memoryPublisher) Commits() CommitClient {
+	return publishedCommits{memoryCommits{&p.repo}}
+}
+
+func (p *memoryPublisher) CreateTag(_ context.Context, name, sha, _ string) error {
+	if _, ok := p.tags[name]; ok {
+		return ErrAlreadyExists
+	}
+	p.tags[name] = sha
+	return nil
+}
+
+func (p *memoryPublisher) DeleteTag(_ context.Context, name string) error {
+	if _, ok := p.tags[name]; !ok {
+		return ErrNotFound
+	}
+	delete(p.tags, name)
+	return nil
+}
+
+func (p *memoryPublisher) CreateRelease(_ context.Context, req ReleaseInfo) error {
+	if p.releaseErr != nil {
+		return p.releaseErr
+	}
+	p.releases = append(p.releases, req)
+	return nil
+}
+
+func TestPublishRelease(t *testing.T) {
+	release := ReleaseInfo{TagName: "v1.0.0", Name: "v1.0.0", Body: "First release"}
+	files := []CommitFile{{Path: StringVar("VERSION"), Content: StringVar("1.0.0\n")}}
+	errServer := errors.New("internal server error")
+	tests := []struct {
+		name               string
+		opts               PublishOptions
+		existingTags       map[string]string
+		releaseErr         error
+		destructiveActions bool
+		wantResult         PublishResult
+		wantErr            error
+		wantStep           PublishStep
+		wantTags           map[string]string
+		wantReleases       []ReleaseInfo
+	}{
+		{
+			name:         "commit, tag and release",
+			opts:         PublishOptions{Files: files, Branch: "main", CommitMessage: "Release v1.0.0", Release: release},
+			wantResult:   PublishResult{CommitSHA: "0d2a4e1c7a7df1b9b6dc7a0e2e5b2c5ba6a6e5b8", CommitCreated: true, TagCreated: true, ReleaseCreated: true},
+			wantTags:     map[string]string{"v1.0.0": "0d2a4e1c7a7df1b9b6dc7a0e2e5b2c5ba6a6e5b8"},
+			wantReleases: []ReleaseInfo{release},
+		},
+		{
+			name:         "tag an existing ref",
+			opts:         PublishOptions{Ref: "main", Release: release},
+			wantResult:   PublishResult{CommitSHA: mainSHA, TagCreated: true, ReleaseCreated: true},
+			wantTags:     map[string]string{"v1.0.0": mainSHA},
+			wantReleases: []ReleaseInfo{release},
+		},
+		{
+			name:     "missing ref",
+			opts:     PublishOptions{Ref: "missing", Release: release},
+			wantErr:  ErrNotFound,
+			wantStep: PublishStepCommit,
+			wantTags: map[string]string{},
+		},
+		{
+			name:         "existing tag",
+			opts:         PublishOptions{Ref: "main", Release: release},
+			existingTags: map[string]string{"v1.0.0": "4c1e"},
+			wantResult:   PublishResult{CommitSHA: mainSHA},
+			wantErr:      ErrAlreadyExists,
+			wantStep:     PublishStepTag,
+			wantTags:     map[string]string{"v1.0.0": "4c1e"},
+		},
+		{
+			name:       "release fails, tag is left",
+			opts:       PublishOptions{Files: files, Branch: "main", CommitMessage: "Release v1.0.0", Release: release},
+			releaseErr: errServer,
+			wantResult: PublishResult{CommitSHA: "0d2a4e1c7a7df1b9b6dc7a0e2e5b2c5ba6a6e5b8", CommitCreated: true, TagCreated: true},
+			wantErr:    errServer,
+			wantStep:   PublishStepRelease,
+			wantTags:   map[string]string{"v1.0.0": "0d2a4e1c7a7df1b9b6dc7a0e2e5b2c5ba6a6e5b8"},
+		},
+		{
+			name:               "release fails, tag is rolled back",
+			opts:               PublishOptions{Ref: "main", Release: release, Rollback: true},
+			releaseErr:         errServer,
+			destructiveActions: true,
+			wantResult:         PublishResult{CommitSHA: mainSHA},
+			wantErr:            errServer,
+			wantStep:           PublishStepRelease,
+			wantTags:           map[string]string{},
+		},
+		{
+			name:     "rollback without destructive actions",
+			opts:     PublishOptions{Ref: "main", Release: release, Rollback: true},
+			wantErr:  ErrDestructiveCallDisallowed,
+			wantTags: map[string]string{},
+		},
+		{
+			name:     "files without a branch",
+			opts:     PublishOptions{Files: files, CommitMessage: "Release v1.0.0", Release: release},
+			wantErr:  validation.ErrFieldRequired,
+			wantTags: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &memoryPublisher{tags: map[string]string{}, releaseErr: tt.releaseErr}
+			for name, sha := range tt.existingTags {
+				p.tags[name] = sha
+			}
+
+			result, err := PublishRelease(context.Background(), p, tt.opts, tt.destructiveActions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PublishRelease() error = %v, want %v", err, tt.wantErr)
+			}
+			var publishErr *PublishError
+			if errors.As(err, &publishErr) != (tt.wantStep != "") {
+				t.Errorf("PublishRelease() error = %v, want a PublishError: %v", err, tt.wantStep != "")
+			} else if publishErr != nil && publishErr.Step != tt.wantStep {
+				t.Errorf("PublishRelease() failed at step %s, want %s", publishErr.Step, tt.wantStep)
+			}
+			if result != tt.wantResult {
+				t.Errorf("PublishRelease() = %+v, want %+v", result, tt.wantResult)
+			}
+			if !reflect.DeepEqual(p.tags, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", p.tags, tt.wantTags)
+			}
+			if !reflect.DeepEqual(p.releases, tt.wantReleases) {
+				t.Errorf("releases = %v, want %v", p.releases, tt.wantReleases)
+			}
+		})
+	}
+}
+
+// memoryTags is an in-memory TagClient, mapping the names of the tags to their commit.
+type memoryTags map[string]string
+
+func (t memoryTags) List(_ context.Context) ([]TagInfo, error) {
+	return nil, ErrNoProviderSupport
+}
+
+func (t memoryTags) Get(_ context.Context, name string) (TagInfo, error) {
+	sha, ok := t[name]
+	if !ok {
+		return TagInfo{}, ErrNotFound
+	}
+	return TagInfo{Name: name, SHA: sha}, nil
+}
+
+func (t memoryTags) Create(_ context.Context, name, commitSHA, _ string) (TagInfo, error) {
+	if _, ok := t[name]; ok {
+		return TagInfo{}, ErrAlreadyExists
+	}
+	t[name] = commitSHA
+	return TagInfo{Name: name, SHA: commitSHA}, nil
+}
+
+func TestEnsureReleaseTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      ReleaseInfo
+		wantErr  error
+		wantTags memoryTags
+	}{
+		{
+			name:     "existing tag",
+			req:      ReleaseInfo{TagName: "v0.1.0"},
+			wantTags: memoryTags{"v0.1.0": "c1"},
+		},
+		{
+			name:     "missing tag without target commitish",
+			req:      ReleaseInfo{TagName: "v1.0.0"},
+			wantErr:  ErrNotFound,
+			wantTags: memoryTags{"v0.1.0": "c1"},
+		},
+		{
+			name:     "missing tag created from target commitish",
+			req:      ReleaseInfo{TagName: "v1.0.0", TargetCommitish: "main"},
+			wantTags: memoryTags{"v0.1.0": "c1", "v1.0.0": mainSHA},
+		},
+		{
+			name:     "unknown target commitish",
+			req:      ReleaseInfo{TagName: "v1.0.0", TargetCommitish: "develop"},
+			wantErr:  ErrNotFound,
+			wantTags: memoryTags{"v0.1.0": "c1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := memoryTags{"v0.1.0": "c1"}
+			commits := publishedCommits{memoryCommits{&memoryRepo{}}}
+			err := EnsureReleaseTag(context.Background(), tags, commits, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EnsureReleaseTag() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tags, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", tags, tt.wantTags)
+			}
+		})
+	}
+}
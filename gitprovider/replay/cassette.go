@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replay records the HTTP interactions of a Git provider client with its API to a
+// cassette, and replays them later without a live provider, e.g. for deterministic tests.
+package replay
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+)
+
+// Cassette contains recorded HTTP interactions, in the order they happened.
+type Cassette struct {
+	// Interactions are the recorded request and response pairs.
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request, along with the response of the server.
+type Interaction struct {
+	// Request is the recorded request.
+	Request Request `json:"request"`
+
+	// Response is the response of the server to Request.
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	// Method is the HTTP method of the request, e.g. "GET".
+	Method string `json:"method"`
+
+	// URL is the full URL of the request, including the query.
+	URL string `json:"url"`
+
+	// Header contains the headers of the request.
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the body of the request.
+	Body string `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	// StatusCode is the HTTP status code of the response, e.g. 200.
+	StatusCode int `json:"statusCode"`
+
+	// Header contains the headers of the response.
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the body of the response.
+	Body string `json:"body,omitempty"`
+}
+
+// Load reads the cassette stored in the file at path.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Save stores the cassette as JSON in the file at path, which is only readable by its owner.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fluxcd/go-git-providers/github"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/gitprovider/replay"
+)
+
+// fakeGitHub stands in for the GitHub API in this example, so it runs offline.
+func fakeGitHub(http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		w := httptest.NewRecorder()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"login": "fluxcd", "name": "Flux project"}`)
+		return w.Result(), nil
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func getOrganizationName(c gitprovider.Client) string {
+	org, err := c.Organizations().Get(context.Background(), gitprovider.OrganizationRef{
+		Domain:       github.DefaultDomain,
+		Organization: "fluxcd",
+	})
+	if err != nil {
+		log.Fatalf("failed to get the organization: %v", err)
+	}
+	return *org.Get().Name
+}
+
+func Example_recordAndReplay() {
+	dir, err := os.MkdirTemp("", "cassettes")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "organization.json")
+
+	// Record the interactions with the API once, redacting the token
+	recorder := replay.NewRecorder("my-token")
+	c, err := github.NewClient(
+		gitprovider.WithOAuth2Token("my-token"),
+		gitprovider.WithPostChainTransportHook(fakeGitHub),
+		gitprovider.WithRecorder(recorder),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("recorded:", getOrganizationName(c))
+	if err := recorder.Save(path); err != nil {
+		log.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("token in cassette:", strings.Contains(string(data), "my-token"))
+
+	// Replay them, without any token or network access
+	cassette, err := replay.Load(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c, err = github.NewClient(gitprovider.WithReplayer(replay.NewReplayer(cassette)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("replayed:", getOrganizationName(c))
+	// Output:
+	// recorded: Flux project
+	// token in cassette: false
+	// replayed: Flux project
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// redacted replaces the secrets found in the recorded interactions.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are the headers carrying credentials, whose values are never recorded.
+//
+//nolint:gochecknoglobals
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Private-Token",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// Recorder records the HTTP interactions going through its transport. It's safe for concurrent use.
+type Recorder struct {
+	secrets []string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder redacting the given secrets, e.g. access tokens, wherever they
+// appear in the recorded URLs, headers and bodies. The values of the headers carrying credentials,
+// like "Authorization", are always redacted.
+func NewRecorder(secrets ...string) *Recorder {
+	return &Recorder{secrets: secrets}
+}
+
+// Transport is a gitprovider.ChainableRoundTripperFunc sending the requests through "in", or
+// http.DefaultTransport if "in" is nil, and recording them along with their responses.
+func (r *Recorder) Transport(in http.RoundTripper) http.RoundTripper {
+	if in == nil {
+		in = http.DefaultTransport
+	}
+	return &recordingTransport{recorder: r, next: in}
+}
+
+// Cassette returns a copy of the interactions recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save stores the interactions recorded so far in the file at path, see Cassette.Save.
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+func (r *Recorder) record(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) {
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.redact(req.URL.String()),
+			Header: r.redactHeader(req.Header),
+			Body:   r.redact(string(reqBody)),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.redactHeader(resp.Header),
+			Body:       r.redact(string(respBody)),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+}
+
+// redact replaces the secrets of the recorder in s.
+func (r *Recorder) redact(s string) string {
+	for _, secret := range r.secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
+
+// redactHeader returns a copy of header, with the secrets and the credentials redacted.
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			out[name] = append(out[name], r.redact(value))
+		}
+	}
+	for _, name := range sensitiveHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+// recordingTransport is the http.RoundTripper returned by Recorder.Transport.
+type recordingTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = body
+		// Send a copy of the request, as RoundTrippers mustn't modify the request
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.recorder.record(req, reqBody, resp, respBody)
+	return resp, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_Redaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	recorder := NewRecorder("s3cr3t")
+	client := &http.Client{Transport: recorder.Transport(nil)}
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/keys?token=s3cr3t", strings.NewReader(`{"key": "s3cr3t"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer other-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	// The caller still gets the unredacted response
+	if string(body) != `{"key": "s3cr3t"}` {
+		t.Errorf("response body = %q, want the request body", body)
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	cassette, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 1 {
+		t.Fatalf("recorded %d interactions, want 1", len(cassette.Interactions))
+	}
+	got := cassette.Interactions[0]
+	if want := server.URL + "/keys?token=REDACTED"; got.Request.URL != want {
+		t.Errorf("recorded URL = %q, want %q", got.Request.URL, want)
+	}
+	if got.Request.Body != `{"key": "REDACTED"}` || got.Response.Body != `{"key": "REDACTED"}` {
+		t.Errorf("recorded bodies = %q and %q, want the secret redacted", got.Request.Body, got.Response.Body)
+	}
+	if got.Request.Header.Get("Authorization") != redacted {
+		t.Errorf("recorded Authorization header = %q, want it redacted", got.Request.Header.Get("Authorization"))
+	}
+	if got.Response.Header.Get("Set-Cookie") != redacted {
+		t.Errorf("recorded Set-Cookie header = %q, want it redacted", got.Response.Header.Get("Set-Cookie"))
+	}
+}
+
+func TestReplayer(t *testing.T) {
+	replayer := NewReplayer(&Cassette{Interactions: []Interaction{
+		{
+			Request:  Request{Method: http.MethodGet, URL: "https://example.com/a"},
+			Response: Response{StatusCode: http.StatusOK, Body: "first"},
+		},
+		{
+			Request:  Request{Method: http.MethodPost, URL: "https://example.com/a"},
+			Response: Response{StatusCode: http.StatusCreated, Body: "created"},
+		},
+		{
+			Request:  Request{Method: http.MethodGet, URL: "https://example.com/a"},
+			Response: Response{StatusCode: http.StatusOK, Body: "second"},
+		},
+	}})
+	client := &http.Client{Transport: replayer.Transport(nil)}
+
+	for _, want := range []string{"first", "second"} {
+		resp, err := client.Get("https://example.com/a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != want {
+			t.Errorf("response body = %q, want %q", body, want)
+		}
+	}
+	if _, err := client.Get("https://example.com/a"); !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("replaying more requests than recorded returned error %v, want %v", err, ErrInteractionNotFound)
+	}
+	if unused := replayer.Unused(); len(unused) != 1 || unused[0].Request.Method != http.MethodPost {
+		t.Errorf("Unused() = %v, want the POST interaction", unused)
+	}
+}
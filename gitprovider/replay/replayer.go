@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrInteractionNotFound is returned by the transport of a Replayer when no recorded interaction
+// matches a request.
+var ErrInteractionNotFound = errors.New("no recorded interaction matches the request")
+
+// Replayer replays the interactions of a cassette, without ever contacting the server. It's safe
+// for concurrent use.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer returns a Replayer for the interactions of the given cassette.
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{cassette: c, used: make([]bool, len(c.Interactions))}
+}
+
+// Transport is a gitprovider.ChainableRoundTripperFunc answering the requests with the recorded
+// responses. "in" is never called.
+//
+// Every interaction is replayed once, the first one not replayed yet with the same method and URL
+// as the request answers it. Redacted secrets are part of the recorded URLs, hence requests
+// carrying secrets in their URL don't match. ErrInteractionNotFound is returned if no recorded
+// interaction is left for a request.
+func (p *Replayer) Transport(_ http.RoundTripper) http.RoundTripper {
+	return p
+}
+
+// Unused returns the recorded interactions which weren't replayed yet.
+func (p *Replayer) Unused() []Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	unused := []Interaction{}
+	for i, interaction := range p.cassette.Interactions {
+		if !p.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// RoundTrip implements http.RoundTripper.
+func (p *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	interaction, ok := p.next(req.Method, req.URL.String())
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, req.Method, req.URL)
+	}
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// next marks the first interaction not replayed yet with the given method and URL as replayed,
+// and returns it.
+func (p *Replayer) next(method, url string) (Interaction, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, interaction := range p.cassette.Interactions {
+		if !p.used[i] && interaction.Request.Method == method && interaction.Request.URL == url {
+			p.used[i] = true
+			return interaction, true
+		}
+	}
+	return Interaction{}, false
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CommitRepoFile commits content to filePath on the given branch, in a single commit.
+// This is used to provision files at the conventional paths of a Git provider.
+func CommitRepoFile(ctx context.Context, c CommitClient, branch, filePath, message string, content []byte) (Commit, error) {
+	if len(content) == 0 {
+		return nil, fmt.Errorf("content of %q must not be empty: %w", filePath, ErrInvalidArgument)
+	}
+	return c.Create(ctx, branch, message, encodeCommitFiles(c, []CommitFile{{
+		Path:    StringVar(filePath),
+		Content: StringVar(string(content)),
+	}}))
+}
+
+// ReadRepoFile reads the file at filePath on the given branch, fetching only that file.
+//
+// ErrNotFound is returned if the file doesn't exist.
+func ReadRepoFile(ctx context.Context, c FileClient, branch, filePath string) ([]byte, error) {
+	files, err := c.Get(ctx, filePath, branch)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	for _, f := range files {
+		if f.Path != nil && *f.Path == filePath && f.Content != nil {
+			return []byte(*f.Content), nil
+		}
+	}
+	return nil, fmt.Errorf("file %q not found on branch %q: %w", filePath, branch, ErrNotFound)
+}
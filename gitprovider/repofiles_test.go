@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRepoFileRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+	}{
+		{
+			name:     "nested file",
+			filePath: ".github/dependabot.yml",
+			content:  "version: 2\nupdates:\n  - package-ecosystem: gomod\n    directory: /\n",
+		},
+		{
+			name:     "root file",
+			filePath: "SECURITY.md",
+			content:  "# Security Policy\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepo{files: map[string]string{"README.md": "# Readme\n"}}
+			if _, err := ReadRepoFile(context.Background(), repo, "main", tt.filePath); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("ReadRepoFile() before commit error = %v, want %v", err, ErrNotFound)
+			}
+
+			if _, err := CommitRepoFile(context.Background(), memoryCommits{repo}, "main", tt.filePath, "Set file", []byte(tt.content)); err != nil {
+				t.Fatalf("CommitRepoFile() error = %v", err)
+			}
+			if len(repo.commits) != 1 {
+				t.Errorf("CommitRepoFile() made %d commits, want 1", len(repo.commits))
+			}
+
+			got, err := ReadRepoFile(context.Background(), repo, "main", tt.filePath)
+			if err != nil {
+				t.Fatalf("ReadRepoFile() error = %v", err)
+			}
+			if string(got) != tt.content {
+				t.Errorf("ReadRepoFile() = %q, want %q", got, tt.content)
+			}
+			// Only the file is fetched, not the whole directory containing it
+			if last := repo.gets[len(repo.gets)-1]; last != tt.filePath {
+				t.Errorf("ReadRepoFile() fetched %q, want %q", last, tt.filePath)
+			}
+		})
+	}
+}
+
+func TestCommitRepoFile_Empty(t *testing.T) {
+	repo := &memoryRepo{}
+	if _, err := CommitRepoFile(context.Background(), memoryCommits{repo}, "main", "SECURITY.md", "Set file", nil); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("CommitRepoFile() error = %v, want %v", err, ErrInvalidArgument)
+	}
+	if len(repo.commits) != 0 {
+		t.Errorf("CommitRepoFile() made %d commits, want 0", len(repo.commits))
+	}
+}
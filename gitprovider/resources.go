@@ -16,6 +16,11 @@ limitations under the License.
 
 package gitprovider
 
+import (
+	"context"
+	"io"
+)
+
 // Organization represents an organization in a Git provider.
 // For now, the organization is read-only, i.e. there aren't set/update methods.
 type Organization interface {
@@ -30,6 +35,43 @@ type Organization interface {
 
 	// Teams gives access to the TeamsClient for this specific organization
 	Teams() TeamsClient
+
+	// Settings gives access to the org-wide defaults of this specific organization.
+	Settings() OrganizationSettingsClient
+
+	// Webhooks gives access to the webhooks triggered by all repositories of this specific organization.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support organization webhooks.
+	Webhooks() (OrganizationWebhookClient, error)
+
+	// AuditLog gives access to the audit log of this specific organization.
+	// Returns "ErrNoProviderSupport" if the provider doesn't expose an audit log.
+	AuditLog() (AuditLogClient, error)
+
+	// SetAvatar uploads the given PNG, JPEG, GIF or WebP image as the avatar of the organization.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support setting organization avatars.
+	SetAvatar(ctx context.Context, avatar io.Reader) error
+}
+
+// OrganizationWebhook represents a webhook triggered by the events of all repositories of an
+// organization.
+type OrganizationWebhook interface {
+	// OrganizationWebhook implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The webhook can be updated.
+	Updatable
+	// The webhook can be reconciled.
+	Reconcilable
+	// The webhook can be deleted.
+	Deletable
+	// OrganizationBound returns organization reference details.
+	OrganizationBound
+
+	// Get returns high-level information about this webhook.
+	Get() OrganizationWebhookInfo
+	// Set sets high-level desired state for this webhook. In order to apply these changes in
+	// the Git provider, run .Update() or .Reconcile().
+	Set(OrganizationWebhookInfo) error
 }
 
 // Team represents a team in an organization in a Git provider.
@@ -68,16 +110,59 @@ type UserRepository interface {
 	// DeployKeys gives access to manipulating deploy keys to access this specific repository.
 	DeployKeys() DeployKeyClient
 
+	// Hooks gives access to manipulating the webhooks of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support repository webhooks.
+	Hooks() (HookClient, error)
+
 	// DeployTokens gives access to manipulating deploy tokens to access this specific repository.
 	// Returns "ErrNoProviderSupport" if the provider doesn't support deploy tokens.
 	DeployTokens() (DeployTokenClient, error)
 
+	// ProtectedTags gives access to manipulating the protected tags of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support protected tags.
+	ProtectedTags() (ProtectedTagClient, error)
+
+	// Milestones gives access to manipulating the milestones of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support milestones.
+	Milestones() (MilestoneClient, error)
+
+	// Releases gives access to manipulating the releases of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support releases.
+	Releases() (ReleaseClient, error)
+
+	// Pipelines gives access to the CI pipelines of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider has no pipelines API.
+	Pipelines() (PipelineClient, error)
+
+	// Workflows gives access to the CI workflows of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider has no workflows API.
+	Workflows() (WorkflowClient, error)
+
+	// Traffic gives access to the clone and view statistics of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider doesn't report traffic statistics.
+	Traffic() (TrafficClient, error)
+
+	// Environments gives access to the deployment environments of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider has no deployment environments API.
+	Environments() (EnvironmentClient, error)
+
+	// Collaborators gives access to the users having access to this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider can't list the users of a repository.
+	Collaborators() (CollaboratorClient, error)
+
+	// Topics gives access to manipulating the topics of this specific repository.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support topics.
+	Topics() (TopicsClient, error)
+
 	// Commits gives access to this specific repository commits
 	Commits() CommitClient
 
 	// Branches gives access to this specific repository branches
 	Branches() BranchClient
 
+	// Tags gives access to this specific repository tags
+	Tags() TagClient
+
 	// PullRequests gives access to this specific repository pull requests
 	PullRequests() PullRequestClient
 
@@ -95,6 +180,158 @@ type OrgRepository interface {
 
 	// TeamAccess returns a TeamsAccessClient for operating on teams' access to this specific repository.
 	TeamAccess() TeamAccessClient
+
+	// License returns the license detected in the repository.
+	//
+	// ErrNotFound is returned if no license is detected.
+	License(ctx context.Context) (LicenseInfo, error)
+
+	// Language returns the primary programming language of the repository, as detected by the
+	// provider.
+	//
+	// ErrNotFound is returned if no language is detected.
+	// Returns "ErrNoProviderSupport" if the provider doesn't detect languages.
+	Language(ctx context.Context) (string, error)
+
+	// SetTemplates commits the given pull request and issue templates to their conventional
+	// locations on the default branch, in a single commit.
+	// Returns "ErrNoProviderSupport" if the provider has no template conventions.
+	SetTemplates(ctx context.Context, templates TemplatesInfo) (Commit, error)
+
+	// GetTemplates reads the pull request and issue templates from their conventional
+	// locations on the default branch.
+	// Returns "ErrNoProviderSupport" if the provider has no template conventions.
+	GetTemplates(ctx context.Context) (TemplatesInfo, error)
+
+	// DiscoverTemplates returns the paths of the pull request and issue templates found at the
+	// locations the provider recognizes, at the given branch, tag or commit, or on the default
+	// branch if ref is empty.
+	// Returns "ErrNoProviderSupport" if the provider has no template conventions.
+	DiscoverTemplates(ctx context.Context, ref string) (TemplateLocations, error)
+
+	// SetDependabotConfig commits the given Dependabot configuration to its conventional location
+	// on the default branch, in a single commit.
+	// Returns "ErrNoProviderSupport" if the provider has no Dependabot convention.
+	SetDependabotConfig(ctx context.Context, config []byte) (Commit, error)
+
+	// GetDependabotConfig reads the Dependabot configuration from its conventional location on
+	// the default branch.
+	// ErrNotFound is returned if the repository has no Dependabot configuration.
+	// Returns "ErrNoProviderSupport" if the provider has no Dependabot convention.
+	GetDependabotConfig(ctx context.Context) ([]byte, error)
+
+	// SetSecurityPolicy commits the given security policy to its conventional location on the
+	// default branch, in a single commit.
+	// Returns "ErrNoProviderSupport" if the provider has no security policy convention.
+	SetSecurityPolicy(ctx context.Context, policy []byte) (Commit, error)
+
+	// GetSecurityPolicy reads the security policy from its conventional location on the default
+	// branch.
+	// ErrNotFound is returned if the repository has no security policy.
+	// Returns "ErrNoProviderSupport" if the provider has no security policy convention.
+	GetSecurityPolicy(ctx context.Context) ([]byte, error)
+
+	// Permissions returns the effective permission level of the authenticated user on the
+	// repository. This allows skipping operations the user is not allowed to perform.
+	Permissions(ctx context.Context) (PermissionLevel, error)
+
+	// CreateBackup writes a git bundle of all refs of the repository to w, for disaster recovery.
+	// The full history of every ref is fetched, so the bundle may be large.
+	CreateBackup(ctx context.Context, w io.Writer) error
+
+	// Wiki returns a WikiClient for operating on the pages of the repository's wiki.
+	// Returns "ErrNoProviderSupport" if the provider doesn't expose wikis as Git repositories.
+	Wiki() (WikiClient, error)
+
+	// CustomProperties returns a CustomPropertiesClient for operating on the custom properties
+	// classifying the repository.
+	// Returns "ErrNoProviderSupport" if the provider has no repository custom properties.
+	CustomProperties() (CustomPropertiesClient, error)
+
+	// InteractionLimits returns an InteractionLimitsClient for temporarily limiting which users
+	// may interact with the repository.
+	// Returns "ErrNoProviderSupport" if the provider can't limit interactions.
+	InteractionLimits() (InteractionLimitsClient, error)
+
+	// ApprovalSettings returns an ApprovalSettingsClient for operating on the repository-wide
+	// settings governing the approval of pull requests.
+	// Returns "ErrNoProviderSupport" if the provider has no such settings.
+	ApprovalSettings() (ApprovalSettingsClient, error)
+
+	// Pages returns a PagesClient for operating on the static website published from the
+	// repository.
+	// Returns "ErrNoProviderSupport" if the provider can't publish websites through its API.
+	Pages() (PagesClient, error)
+
+	// SetSubscription sets the notification subscription of the authenticated user to the
+	// repository, e.g. to watch it or ignore it.
+	// Returns "ErrNoProviderSupport" if the provider has no per-repository subscriptions.
+	SetSubscription(ctx context.Context, subscription SubscriptionInfo) error
+
+	// Subscribers lists the users watching the repository, i.e. notified of all its activity.
+	// Returns "ErrNoProviderSupport" if the provider doesn't expose the watchers of a repository.
+	Subscribers(ctx context.Context) ([]UserRef, error)
+
+	// SetAvatar uploads the given PNG, JPEG, GIF or WebP image as the avatar of the repository.
+	// Returns "ErrNoProviderSupport" if the provider doesn't support setting repository avatars.
+	SetAvatar(ctx context.Context, avatar io.Reader) error
+
+	// ReconcileMergeQueue makes sure the merge queue of the given protected branch matches the
+	// desired state (req). A disabled req removes the merge queue of the branch.
+	// Returns "ErrNoProviderSupport" if the provider has no merge queues.
+	ReconcileMergeQueue(ctx context.Context, branch string, req MergeQueueInfo) (actionTaken bool, err error)
+
+	// ReconcileBranchMergeMethods makes sure the merge methods allowed for the pull requests
+	// targeting the given branch match the desired state (req), e.g. to only allow squash merges
+	// into the default branch. An empty req removes the merge methods of the branch.
+	// Returns "ErrNoProviderSupport" if the provider only has repository-wide merge methods, in
+	// which case callers may fall back to these.
+	ReconcileBranchMergeMethods(ctx context.Context, branch string, req BranchMergeMethodsInfo) (actionTaken bool, err error)
+
+	// ReconcileMergeMethods makes sure the merge methods allowed for all the pull requests of the
+	// repository, and the preselected one, match the desired state (req).
+	// Returns "ErrNoProviderSupport" if the provider has no dedicated repository-wide merge method
+	// settings.
+	ReconcileMergeMethods(ctx context.Context, req MergeMethodsInfo) (actionTaken bool, err error)
+
+	// ReconcileEnvironmentProtection makes sure the refs allowed to deploy to the given deployment
+	// environment, and its custom protection rules, match the desired state (req). Patterns and
+	// rules are compared regardless of their order. An empty req lets any ref deploy.
+	// Returns "ErrNoProviderSupport" if the provider has no deployment environments.
+	ReconcileEnvironmentProtection(ctx context.Context, environment string, req EnvironmentProtectionInfo) (actionTaken bool, err error)
+
+	// IsDefaultBranchProtected returns whether the default branch of the repository is protected,
+	// without fetching its protection configuration.
+	// Returns "ErrNoProviderSupport" if the provider can't tell whether a branch is protected.
+	IsDefaultBranchProtected(ctx context.Context) (bool, error)
+
+	// ReconcileWorkflowPermissions makes sure the default permissions of the token handed to the
+	// CI workflows of the repository match the desired state (req).
+	// Returns "ErrNoProviderSupport" if the provider has no configurable workflow token.
+	ReconcileWorkflowPermissions(ctx context.Context, req WorkflowPermissionsInfo) (actionTaken bool, err error)
+
+	// ReconcileCodeScanningDefaultSetup makes sure the code scanning default setup of the
+	// repository matches the desired state (req), enabling or disabling it as needed.
+	// Returns "ErrNoProviderSupport" if the provider has no code scanning default setup.
+	ReconcileCodeScanningDefaultSetup(ctx context.Context, req CodeScanningDefaultSetupInfo) (actionTaken bool, err error)
+
+	// Counts returns the number of open pull requests, open issues, branches and tags of the
+	// repository, without paginating through the full lists. Counts the provider can't report
+	// cheaply are left unset.
+	// Returns "ErrNoProviderSupport" if the provider can't report any of the counts cheaply.
+	Counts(ctx context.Context) (RepoCounts, error)
+
+	// CommitActivity returns the number of commits made to the default branch of the repository
+	// every week of the last year, from the oldest week to the current one.
+	// Returns "ErrNotReady" if the provider is still computing the activity, the call can then be
+	// retried later.
+	CommitActivity(ctx context.Context) ([]WeeklyActivity, error)
+
+	// Rename renames the repository to newName in place, keeping its history, and returns it
+	// under the new reference. The receiver keeps referring to the old name, so it shouldn't be
+	// used anymore after a successful rename.
+	// ErrAlreadyExists is returned if a repository named newName already exists.
+	Rename(ctx context.Context, newName string) (OrgRepository, error)
 }
 
 // CloneableURL returns the HTTPS URL to clone the repository.
@@ -123,6 +360,27 @@ type DeployKey interface {
 	Set(DeployKeyInfo) error
 }
 
+// DeployHook represents a webhook triggered by the events of a repository.
+type DeployHook interface {
+	// DeployHook implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The webhook can be updated.
+	Updatable
+	// The webhook can be reconciled.
+	Reconcilable
+	// The webhook can be deleted.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this webhook.
+	Get() DeployHookInfo
+	// Set sets high-level desired state for this webhook. In order to apply these changes in
+	// the Git provider, run .Update() or .Reconcile().
+	Set(DeployHookInfo) error
+}
+
 // DeployToken represents a short-lived credential used to access a repository.
 type DeployToken interface {
 	// DeployToken implements the Object interface,
@@ -144,6 +402,44 @@ type DeployToken interface {
 	Set(DeployTokenInfo) error
 }
 
+// ProtectedTag represents a tag name or pattern for which tag creation is restricted.
+type ProtectedTag interface {
+	// ProtectedTag implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The protected tag can be updated.
+	Updatable
+	// The protected tag can be reconciled.
+	Reconcilable
+	// The protected tag can be deleted.
+	Deletable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this protected tag.
+	Get() ProtectedTagInfo
+	// Set sets high-level desired state for this protected tag. In order to apply these changes in
+	// the Git provider, run .Update() or .Reconcile().
+	Set(ProtectedTagInfo) error
+}
+
+// Milestone represents a milestone, used to track the progress of a set of issues and pull requests.
+type Milestone interface {
+	// Milestone implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// The milestone can be updated.
+	Updatable
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this milestone.
+	Get() MilestoneInfo
+	// Set sets high-level desired state for this milestone. In order to apply these changes in
+	// the Git provider, run .Update().
+	Set(MilestoneInfo) error
+}
+
 // TeamAccess describes a binding between a repository and a team.
 type TeamAccess interface {
 	// TeamAccess implements the Object interface,
@@ -175,6 +471,30 @@ type Commit interface {
 	Get() CommitInfo
 }
 
+// Pipeline represents a run of the CI pipeline of a repository.
+type Pipeline interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this pipeline.
+	Get() PipelineInfo
+}
+
+// WorkflowRun represents a run of a CI workflow of a repository.
+type WorkflowRun interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+	// RepositoryBound returns repository reference details.
+	RepositoryBound
+
+	// Get returns high-level information about this workflow run.
+	Get() WorkflowRunInfo
+}
+
 // PullRequest represents a pull request.
 type PullRequest interface {
 	// Object implements the Object interface,
@@ -185,6 +505,16 @@ type PullRequest interface {
 	Get() PullRequestInfo
 }
 
+// PullRequestReview represents a review submitted on a pull request.
+type PullRequestReview interface {
+	// Object implements the Object interface,
+	// allowing access to the underlying object returned from the API.
+	Object
+
+	// Get returns high-level information about this review.
+	Get() ReviewInfo
+}
+
 // Tree represents a git tree which is the hierarchical structure of your git data.
 type Tree interface {
 	// Object implements the Object interface,
@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+// RetryPolicy specifies how requests failing with a network error, a rate limit (429, or 403 with a
+// Retry-After header) or a server error (5xx) are retried, with exponential backoff. A longer wait
+// asked by the server through the Retry-After header is honored. It's configured with WithRetryPolicy.
+//
+// Requests with a method that isn't idempotent, e.g. POST and PATCH, may have been applied when
+// they fail with a network or server error, and are only retried on rate limits or when the
+// server asks for it through the Retry-After header.
+type RetryPolicy struct {
+	// InitialInterval is the time waited before the first retry.
+	// +required
+	InitialInterval time.Duration
+
+	// Multiplier is the factor the interval grows by after each retry. 1 means a constant interval.
+	// +required
+	Multiplier float64
+
+	// MaxInterval caps the interval between two retries, before jitter is applied.
+	// +required
+	MaxInterval time.Duration
+
+	// MaxElapsedTime is the time after which a request isn't retried anymore, counted from the
+	// first attempt. The last response or error is then returned.
+	// +required
+	MaxElapsedTime time.Duration
+
+	// Jitter is the randomization factor applied to each interval, in the range [0, 1]: an
+	// interval i becomes a random duration between i*(1-Jitter) and i*(1+Jitter).
+	// +optional
+	Jitter float64
+}
+
+// ValidateOptions validates that the policy is valid.
+func (p RetryPolicy) ValidateOptions() error {
+	errs := validation.New("RetryPolicy")
+	if p.InitialInterval <= 0 {
+		errs.Invalid(p.InitialInterval, "InitialInterval")
+	}
+	if p.Multiplier < 1 {
+		errs.Invalid(p.Multiplier, "Multiplier")
+	}
+	if p.MaxInterval < p.InitialInterval {
+		errs.Invalid(p.MaxInterval, "MaxInterval")
+	}
+	if p.MaxElapsedTime <= 0 {
+		errs.Invalid(p.MaxElapsedTime, "MaxElapsedTime")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		errs.Invalid(p.Jitter, "Jitter")
+	}
+	return errs.Error()
+}
+
+// Interval returns the interval before the given retry, starting at 0, without jitter.
+func (p RetryPolicy) Interval(retry int) time.Duration {
+	interval := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(retry))
+	if interval > float64(p.MaxInterval) {
+		return p.MaxInterval
+	}
+	return time.Duration(interval)
+}
+
+// jitter randomizes interval by the Jitter factor of the policy, given a random number in [0, 1).
+func (p RetryPolicy) jitter(interval time.Duration, random float64) time.Duration {
+	return time.Duration(float64(interval) * (1 + p.Jitter*(2*random-1)))
+}
+
+// retryTransport returns a ChainableRoundTripperFunc retrying the requests according to policy.
+func retryTransport(policy RetryPolicy) ChainableRoundTripperFunc {
+	return func(in http.RoundTripper) http.RoundTripper {
+		if in == nil {
+			in = http.DefaultTransport
+		}
+		return &retryRoundTripper{
+			next:   in,
+			policy: policy,
+			random: rand.New(rand.NewSource(time.Now().UnixNano())).Float64,
+			now:    time.Now,
+			sleep:  sleepContext,
+		}
+	}
+}
+
+// retryRoundTripper is a http.RoundTripper retrying the failed requests of the next RoundTripper.
+type retryRoundTripper struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+	random func() float64
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// RoundTrip implements http.RoundTripper. Requests whose body can't be replayed aren't retried.
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := rt.now()
+	for retry := 0; ; retry++ {
+		attempt := req
+		if retry > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt = req.Clone(req.Context())
+			attempt.Body = body
+		}
+
+		resp, err := rt.next.RoundTrip(attempt)
+		if !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// Honor the wait asked by the server if it's longer than the backoff
+		wait := rt.policy.jitter(rt.policy.Interval(retry), rt.random())
+		if serverWait := retryAfter(resp); serverWait > wait {
+			wait = serverWait
+		}
+		if rt.now().Add(wait).Sub(start) > rt.policy.MaxElapsedTime {
+			return resp, err
+		}
+		if resp != nil {
+			// Drain the body, so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := rt.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleepContext waits for d, returning early with the error of ctx if it's done before.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// shouldRetry returns whether the outcome of the request is worth retrying.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		// Don't retry canceled requests, nor the pages running out of time. Requests that
+		// aren't idempotent may have been applied, and aren't replayed
+		return isIdempotent(req) && req.Context().Err() == nil && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusForbidden:
+		// Secondary rate limits are reported with 403 Forbidden by e.g. GitHub, along with
+		// the wait, unlike permission errors
+		return retryAfter(resp) > 0
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return isIdempotent(req) || retryAfter(resp) > 0
+	}
+	return false
+}
+
+// isIdempotent returns whether sending req several times has the same effect as sending it once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the wait requested by the Retry-After header of resp, given either in
+// seconds or as an HTTP date, or 0.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	value := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
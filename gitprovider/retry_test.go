@@ -0,0 +1,245 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRetryPolicy_Interval(t *testing.T) {
+	policy := RetryPolicy{
+		InitialInterval: 100 * time.Millisecond,
+		Multiplier:      2,
+		MaxInterval:     time.Second,
+		MaxElapsedTime:  time.Minute,
+		Jitter:          0.5,
+	}
+	got := []time.Duration{}
+	for retry := 0; retry < 6; retry++ {
+		got = append(got, policy.Interval(retry))
+	}
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Interval() = %v, want %v", got, want)
+	}
+
+	// The jitter spreads the interval evenly around it
+	for random, want := range map[float64]time.Duration{
+		0:    50 * time.Millisecond,
+		0.5:  100 * time.Millisecond,
+		0.75: 125 * time.Millisecond,
+	} {
+		if got := policy.jitter(100*time.Millisecond, random); got != want {
+			t.Errorf("jitter(100ms, %v) = %v, want %v", random, got, want)
+		}
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	valid := RetryPolicy{InitialInterval: time.Second, Multiplier: 1.5, MaxInterval: time.Minute, MaxElapsedTime: time.Hour}
+	tests := []struct {
+		name    string
+		mutate  func(p *RetryPolicy)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(p *RetryPolicy) {}},
+		{name: "no initial interval", mutate: func(p *RetryPolicy) { p.InitialInterval = 0 }, wantErr: true},
+		{name: "shrinking", mutate: func(p *RetryPolicy) { p.Multiplier = 0.5 }, wantErr: true},
+		{name: "max interval below initial", mutate: func(p *RetryPolicy) { p.MaxInterval = time.Millisecond }, wantErr: true},
+		{name: "no max elapsed time", mutate: func(p *RetryPolicy) { p.MaxElapsedTime = 0 }, wantErr: true},
+		{name: "jitter out of range", mutate: func(p *RetryPolicy) { p.Jitter = 1.5 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := valid
+			tt.mutate(&policy)
+			_, err := MakeClientOptions(WithRetryPolicy(policy))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MakeClientOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidClientOptions) {
+				t.Errorf("MakeClientOptions() error = %v, want %v", err, ErrInvalidClientOptions)
+			}
+		})
+	}
+}
+
+// fakeClock is a clock which only advances when sleeping, for testing the retries independently
+// of the time they take.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(_ context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		failures     int
+		failStatus   int
+		retryAfter   string
+		wantStatus   int
+		wantRequests int
+		wantSleeps   []time.Duration
+	}{
+		{
+			name: "recovers", failures: 2, failStatus: http.StatusServiceUnavailable,
+			wantStatus: http.StatusOK, wantRequests: 3, wantSleeps: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		{
+			name: "gives up", failures: 10, failStatus: http.StatusServiceUnavailable,
+			wantStatus: http.StatusServiceUnavailable, wantRequests: 4, wantSleeps: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond},
+		},
+		{name: "forbidden", failures: 1, failStatus: http.StatusForbidden, wantStatus: http.StatusForbidden, wantRequests: 1},
+		// The server asks for a longer wait than the max elapsed time
+		{name: "long retry after", failures: 1, failStatus: http.StatusTooManyRequests, retryAfter: "60", wantStatus: http.StatusTooManyRequests, wantRequests: 1},
+		// A POST failing with a server error may have been applied
+		{name: "post server error", method: http.MethodPost, failures: 1, failStatus: http.StatusServiceUnavailable, wantStatus: http.StatusServiceUnavailable, wantRequests: 1},
+		{
+			name: "post rate limit", method: http.MethodPost, failures: 1, failStatus: http.StatusTooManyRequests,
+			wantStatus: http.StatusOK, wantRequests: 2, wantSleeps: []time.Duration{10 * time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			bodies := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				if requests <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			// Waits of 10ms, 20ms and 40ms fit in the max elapsed time, the next one doesn't
+			clock := &fakeClock{now: time.Now()}
+			rt := retryTransport(RetryPolicy{
+				InitialInterval: 10 * time.Millisecond,
+				Multiplier:      2,
+				MaxInterval:     time.Second,
+				MaxElapsedTime:  120 * time.Millisecond,
+			})(nil).(*retryRoundTripper)
+			rt.now, rt.sleep = clock.Now, clock.Sleep
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req, err := http.NewRequest(method, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := (&http.Client{Transport: rt}).Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Do() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("server got %d requests, want %d", requests, tt.wantRequests)
+			}
+			for _, body := range bodies {
+				if body != "payload" {
+					t.Errorf("server got body %q, want %q", body, "payload")
+				}
+			}
+			if diff := cmp.Diff(tt.wantSleeps, clock.sleeps); diff != "" {
+				t.Errorf("sleeps (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		status     int
+		retryAfter string
+		err        error
+		want       bool
+	}{
+		{name: "ok", status: http.StatusOK, want: false},
+		{name: "not found", status: http.StatusNotFound, want: false},
+		{name: "forbidden", status: http.StatusForbidden, want: false},
+		{name: "secondary rate limit", status: http.StatusForbidden, retryAfter: "60", want: true},
+		{name: "rate limit", status: http.StatusTooManyRequests, want: true},
+		{name: "server error", status: http.StatusBadGateway, want: true},
+		{name: "post rate limit", method: http.MethodPost, status: http.StatusTooManyRequests, want: true},
+		{name: "post server error", method: http.MethodPost, status: http.StatusBadGateway, want: false},
+		{name: "patch server error with retry after", method: http.MethodPatch, status: http.StatusServiceUnavailable, retryAfter: "1", want: true},
+		{name: "put server error", method: http.MethodPut, status: http.StatusBadGateway, want: true},
+		{name: "get network error", err: errors.New("connection reset"), want: true},
+		{name: "post network error", method: http.MethodPost, err: errors.New("connection reset"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "https://example.com", nil)
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status, Header: http.Header{}}
+				if tt.retryAfter != "" {
+					resp.Header.Set("Retry-After", tt.retryAfter)
+				}
+			}
+			if got := shouldRetry(req, resp, tt.err); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
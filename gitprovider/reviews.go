@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SummarizeReviews summarizes the given reviews of a pull request, ordered from the oldest to the
+// newest. The latest approval, change request or dismissal of a reviewer replaces their previous
+// verdict, while comments leave it unchanged.
+func SummarizeReviews(reviews []ReviewInfo) ReviewSummary {
+	verdicts := map[string]ReviewState{}
+	for _, review := range reviews {
+		switch review.State {
+		case ReviewStateApproved, ReviewStateChangesRequested:
+			verdicts[review.Reviewer] = review.State
+		case ReviewStateDismissed:
+			delete(verdicts, review.Reviewer)
+		}
+	}
+
+	summary := ReviewSummary{}
+	for reviewer, state := range verdicts {
+		if state == ReviewStateApproved {
+			summary.Approvals++
+			summary.Approvers = append(summary.Approvers, reviewer)
+		} else {
+			summary.ChangesRequested++
+		}
+	}
+	sort.Strings(summary.Approvers)
+	return summary
+}
+
+// ValidateReview validates the arguments of PullRequestClient.Review. A comment or a change
+// request must explain itself, hence have a body, while an approval may not.
+func ValidateReview(action ReviewAction, body string) error {
+	if err := ValidateReviewAction(action); err != nil {
+		return fmt.Errorf("invalid review action %q: %w", action, ErrInvalidArgument)
+	}
+	if action != ReviewApprove && body == "" {
+		return fmt.Errorf("review action %q requires a body: %w", action, ErrInvalidArgument)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeReviews(t *testing.T) {
+	tests := []struct {
+		name    string
+		reviews []ReviewInfo
+		want    ReviewSummary
+	}{
+		{
+			name: "no reviews",
+			want: ReviewSummary{},
+		},
+		{
+			name: "mixed review states",
+			reviews: []ReviewInfo{
+				{Reviewer: "stefan", State: ReviewStateChangesRequested},
+				{Reviewer: "hidde", State: ReviewStateApproved},
+				{Reviewer: "max", State: ReviewStateCommented},
+				{Reviewer: "stefan", State: ReviewStateApproved},
+				{Reviewer: "hidde", State: ReviewStateCommented},
+				{Reviewer: "sanskar", State: ReviewStateChangesRequested},
+			},
+			want: ReviewSummary{
+				Approvals:        2,
+				ChangesRequested: 1,
+				Approvers:        []string{"hidde", "stefan"},
+			},
+		},
+		{
+			name: "approval withdrawn",
+			reviews: []ReviewInfo{
+				{Reviewer: "stefan", State: ReviewStateApproved},
+				{Reviewer: "hidde", State: ReviewStateApproved},
+				{Reviewer: "hidde", State: ReviewStateChangesRequested},
+			},
+			want: ReviewSummary{
+				Approvals:        1,
+				ChangesRequested: 1,
+				Approvers:        []string{"stefan"},
+			},
+		},
+		{
+			name: "dismissed review",
+			reviews: []ReviewInfo{
+				{Reviewer: "stefan", State: ReviewStateChangesRequested},
+				{Reviewer: "stefan", State: ReviewStateDismissed},
+				{Reviewer: "max", State: ReviewStateCommented},
+			},
+			want: ReviewSummary{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummarizeReviews(tt.reviews); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SummarizeReviews() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReview(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  ReviewAction
+		body    string
+		wantErr bool
+	}{
+		{name: "approval without body", action: ReviewApprove},
+		{name: "change request with body", action: ReviewRequestChanges, body: "Please add a test"},
+		{name: "change request without body", action: ReviewRequestChanges, wantErr: true},
+		{name: "comment without body", action: ReviewComment, wantErr: true},
+		{name: "unknown action", action: ReviewAction("merge"), body: "LGTM", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReview(tt.action, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateReview() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("ValidateReview() error = %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+}
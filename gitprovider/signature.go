@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	pgpSignatureArmorType = "PGP SIGNATURE"
+	sshSignatureBegin     = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureEnd       = "-----END SSH SIGNATURE-----"
+	sshSignatureMagic     = "SSHSIG"
+)
+
+// SignatureKeyID returns the ID of the key that created the given armored commit signature.
+// For PGP signatures this is the 16-character hexadecimal issuer key ID, for SSH signatures
+// the SHA256 fingerprint of the signing public key. ErrNoProviderSupport is returned for
+// other signature formats (e.g. X.509), and ErrInvalidServerData if the signature can't be parsed.
+func SignatureKeyID(signature string) (string, error) {
+	signature = strings.TrimSpace(signature)
+	switch {
+	case strings.HasPrefix(signature, sshSignatureBegin):
+		return sshSignatureKeyID(signature)
+	case strings.HasPrefix(signature, "-----BEGIN "+pgpSignatureArmorType+"-----"):
+		return pgpSignatureKeyID(signature)
+	default:
+		return "", fmt.Errorf("unsupported commit signature format: %w", ErrNoProviderSupport)
+	}
+}
+
+func pgpSignatureKeyID(signature string) (string, error) {
+	block, err := armor.Decode(strings.NewReader(signature))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode PGP signature: %v: %w", err, ErrInvalidServerData)
+	}
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PGP signature: %v: %w", err, ErrInvalidServerData)
+	}
+	sig, ok := p.(*packet.Signature)
+	if !ok || sig.IssuerKeyId == nil {
+		return "", fmt.Errorf("PGP signature has no issuer key ID: %w", ErrInvalidServerData)
+	}
+	return fmt.Sprintf("%016X", *sig.IssuerKeyId), nil
+}
+
+// sshSignature is the wire format of an SSH signature blob, following the "SSHSIG" magic preamble.
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+func sshSignatureKeyID(signature string) (string, error) {
+	body := strings.TrimSuffix(strings.TrimPrefix(signature, sshSignatureBegin), sshSignatureEnd)
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode SSH signature: %v: %w", err, ErrInvalidServerData)
+	}
+	if !bytes.HasPrefix(blob, []byte(sshSignatureMagic)) {
+		return "", fmt.Errorf("SSH signature is missing the %q preamble: %w", sshSignatureMagic, ErrInvalidServerData)
+	}
+	var sig sshSignature
+	if err := ssh.Unmarshal(blob[len(sshSignatureMagic):], &sig); err != nil {
+		return "", fmt.Errorf("failed to read SSH signature: %v: %w", err, ErrInvalidServerData)
+	}
+	pub, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse SSH signature public key: %v: %w", err, ErrInvalidServerData)
+	}
+	return ssh.FingerprintSHA256(pub), nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+// armoredPGPSignature signs data with a new PGP entity and returns the armored signature
+// together with the ID of the signing key.
+func armoredPGPSignature(t *testing.T, data string) (string, string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Flux", "", "flux@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, entity, strings.NewReader(data), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String(), fmt.Sprintf("%016X", entity.PrimaryKey.KeyId)
+}
+
+// armoredSSHSignature builds an SSH signature of data with a new ed25519 key and returns it
+// together with the fingerprint of the signing key.
+func armoredSSHSignature(t *testing.T, data string) (string, string) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := signer.Sign(rand.Reader, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignature{
+		Version:       1,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     "git",
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(sig),
+	})...)
+	armored := sshSignatureBegin + "\n" + base64.StdEncoding.EncodeToString(blob) + "\n" + sshSignatureEnd + "\n"
+	return armored, ssh.FingerprintSHA256(signer.PublicKey())
+}
+
+func TestSignatureKeyID(t *testing.T) {
+	pgpSig, pgpKeyID := armoredPGPSignature(t, "tree 1234\n")
+	sshSig, sshKeyID := armoredSSHSignature(t, "tree 1234\n")
+
+	tests := []struct {
+		name      string
+		signature string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "pgp signature",
+			signature: pgpSig,
+			want:      pgpKeyID,
+		},
+		{
+			name:      "ssh signature",
+			signature: sshSig,
+			want:      sshKeyID,
+		},
+		{
+			name:      "x509 signature",
+			signature: "-----BEGIN SIGNED MESSAGE-----\nMIAGCSqGSIb3DQEHAqCAMIACAQExDTALBglghkgBZQMEAgEwgAYJKoZIhvcNAQcB\n-----END SIGNED MESSAGE-----\n",
+			wantErr:   ErrNoProviderSupport,
+		},
+		{
+			name:      "corrupt ssh signature",
+			signature: sshSignatureBegin + "\nbm90IGEgc2lnbmF0dXJl\n" + sshSignatureEnd,
+			wantErr:   ErrInvalidServerData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SignatureKeyID(tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SignatureKeyID() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SignatureKeyID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
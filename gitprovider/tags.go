@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultTagsResolveConcurrency is the number of tags ResolveTags resolves at once. Like for
+// files, it's kept low to stay within the rate limits of the Git providers.
+const defaultTagsResolveConcurrency = 4
+
+// ResolveTags calls resolve for each of the given tags, with at most 4 calls in flight. It's
+// used by providers needing an extra request per tag to fill in the details of TagInfo, e.g.
+// the tagger and date of annotated tags. resolve updates the tag it's given in place.
+//
+// The first error cancels the context passed to the other calls, and is returned.
+func ResolveTags(ctx context.Context, tags []TagInfo, resolve func(ctx context.Context, tag *TagInfo) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, defaultTagsResolveConcurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for i := range tags {
+		// Don't resolve any more tags once an error occurred or ctx is done
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(tag *TagInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := resolve(ctx, tag); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(&tags[i])
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
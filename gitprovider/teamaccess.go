@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// TeamAccessAction is the action taken on the access of a single team by ReconcileAllTeamAccess.
+type TeamAccessAction string
+
+const (
+	// TeamAccessActionNone means the access of the team already matched the desired state.
+	TeamAccessActionNone = TeamAccessAction("none")
+
+	// TeamAccessActionCreated means the team was granted access to the repository.
+	TeamAccessActionCreated = TeamAccessAction("created")
+
+	// TeamAccessActionUpdated means the permission of the team was changed.
+	TeamAccessActionUpdated = TeamAccessAction("updated")
+
+	// TeamAccessActionRemoved means the access of the team was removed, as it wasn't desired.
+	TeamAccessActionRemoved = TeamAccessAction("removed")
+)
+
+// TeamAccessBatchResult is the result of reconciling the access of a single team of a batch.
+type TeamAccessBatchResult struct {
+	// Name is the name of the team.
+	Name string
+
+	// Action is the action taken on the access of the team, unset if Err is set.
+	Action TeamAccessAction
+
+	// Err is the error that occurred while reconciling the access of this team, if any.
+	Err error
+}
+
+// ReconcileAllTeamAccess makes sure the teams having access to the repository c operates on, and
+// their permissions, are the given desired ones (reqs), listing the actual team access only once.
+//
+// If prune is true, the access of the teams not in reqs is removed. This is a destructive action,
+// which is refused with ErrDestructiveCallDisallowed unless destructiveActions is true, before any
+// change is made.
+//
+// The returned results are in the order of reqs, followed by the removed teams sorted by name.
+// An error is returned if any request is invalid, a team is requested more than once, or the
+// actual team access can't be listed; errors of single teams are set in their result.
+func ReconcileAllTeamAccess(ctx context.Context, c TeamAccessClient, reqs []TeamAccessInfo, prune, destructiveActions bool) ([]TeamAccessBatchResult, error) {
+	// Validate and default copies of the requests, to not modify the caller's slice
+	desired := make([]TeamAccessInfo, len(reqs))
+	copy(desired, reqs)
+	requested := make(map[string]struct{}, len(desired))
+	for i := range desired {
+		if err := ValidateAndDefaultInfo(&desired[i]); err != nil {
+			return nil, err
+		}
+		if _, ok := requested[desired[i].Name]; ok {
+			return nil, fmt.Errorf("team %q is requested more than once: %w", desired[i].Name, ErrInvalidArgument)
+		}
+		requested[desired[i].Name] = struct{}{}
+	}
+
+	actuals, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actualsByName := make(map[string]TeamAccess, len(actuals))
+	undesired := []string{}
+	for _, actual := range actuals {
+		name := actual.Get().Name
+		actualsByName[name] = actual
+		if _, ok := requested[name]; !ok {
+			undesired = append(undesired, name)
+		}
+	}
+	sort.Strings(undesired)
+	if prune && len(undesired) != 0 && !destructiveActions {
+		return nil, fmt.Errorf("cannot remove the access of teams %v: %w", undesired, ErrDestructiveCallDisallowed)
+	}
+
+	results := make([]TeamAccessBatchResult, 0, len(desired)+len(undesired))
+	for _, req := range desired {
+		action, err := reconcileTeamAccess(ctx, c, req, actualsByName[req.Name])
+		results = append(results, TeamAccessBatchResult{Name: req.Name, Action: action, Err: err})
+	}
+	if !prune {
+		return results, nil
+	}
+	for _, name := range undesired {
+		result := TeamAccessBatchResult{Name: name}
+		if result.Err = actualsByName[name].Delete(ctx); result.Err == nil {
+			result.Action = TeamAccessActionRemoved
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// reconcileTeamAccess grants req if actual is nil, or updates actual if it doesn't match req.
+func reconcileTeamAccess(ctx context.Context, c TeamAccessClient, req TeamAccessInfo, actual TeamAccess) (TeamAccessAction, error) {
+	if actual == nil {
+		if _, err := c.Create(ctx, req); err != nil {
+			return "", err
+		}
+		return TeamAccessActionCreated, nil
+	}
+	if req.Equals(actual.Get()) {
+		return TeamAccessActionNone, nil
+	}
+	if err := actual.Set(req); err != nil {
+		return "", err
+	}
+	if err := actual.Update(ctx); err != nil {
+		return "", err
+	}
+	return TeamAccessActionUpdated, nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// memoryTeamAccess is an in-memory TeamAccessClient.
+type memoryTeamAccess struct {
+	teams map[string]RepositoryPermission
+	lists int
+}
+
+func (c *memoryTeamAccess) Get(_ context.Context, name string) (TeamAccess, error) {
+	permission, ok := c.teams[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &memoryTeam{c: c, info: TeamAccessInfo{Name: name, Permission: &permission}}, nil
+}
+
+func (c *memoryTeamAccess) List(ctx context.Context) ([]TeamAccess, error) {
+	c.lists++
+	teams := make([]TeamAccess, 0, len(c.teams))
+	for name := range c.teams {
+		ta, _ := c.Get(ctx, name)
+		teams = append(teams, ta)
+	}
+	return teams, nil
+}
+
+func (c *memoryTeamAccess) Create(_ context.Context, req TeamAccessInfo) (TeamAccess, error) {
+	c.teams[req.Name] = *req.Permission
+	return &memoryTeam{c: c, info: req}, nil
+}
+
+func (c *memoryTeamAccess) Reconcile(_ context.Context, _ TeamAccessInfo) (TeamAccess, bool, error) {
+	return nil, false, ErrNoProviderSupport
+}
+
+func (c *memoryTeamAccess) ReconcileAll(ctx context.Context, reqs []TeamAccessInfo, prune bool) ([]TeamAccessBatchResult, error) {
+	return ReconcileAllTeamAccess(ctx, c, reqs, prune, true)
+}
+
+type memoryTeam struct {
+	c    *memoryTeamAccess
+	info TeamAccessInfo
+}
+
+func (t *memoryTeam) APIObject() interface{}                    { return &t.info }
+func (t *memoryTeam) Repository() RepositoryRef                 { return nil }
+func (t *memoryTeam) Get() TeamAccessInfo                       { return t.info }
+func (t *memoryTeam) Reconcile(_ context.Context) (bool, error) { return false, nil }
+
+func (t *memoryTeam) Set(info TeamAccessInfo) error {
+	t.info = info
+	return nil
+}
+
+func (t *memoryTeam) Update(_ context.Context) error {
+	t.c.teams[t.info.Name] = *t.info.Permission
+	return nil
+}
+
+func (t *memoryTeam) Delete(_ context.Context) error {
+	delete(t.c.teams, t.info.Name)
+	return nil
+}
+
+func TestReconcileAllTeamAccess(t *testing.T) {
+	c := &memoryTeamAccess{teams: map[string]RepositoryPermission{
+		"maintainers": RepositoryPermissionMaintain,
+		"developers":  RepositoryPermissionPull,
+		"contractors": RepositoryPermissionPush,
+		"auditors":    RepositoryPermissionPull,
+	}}
+	reqs := []TeamAccessInfo{
+		{Name: "maintainers", Permission: RepositoryPermissionVar(RepositoryPermissionMaintain)},
+		{Name: "developers", Permission: RepositoryPermissionVar(RepositoryPermissionPush)},
+		{Name: "release"},
+	}
+
+	results, err := c.ReconcileAll(context.Background(), reqs, true)
+	if err != nil {
+		t.Fatalf("ReconcileAll() error = %v", err)
+	}
+	wantResults := []TeamAccessBatchResult{
+		{Name: "maintainers", Action: TeamAccessActionNone},
+		{Name: "developers", Action: TeamAccessActionUpdated},
+		{Name: "release", Action: TeamAccessActionCreated},
+		{Name: "auditors", Action: TeamAccessActionRemoved},
+		{Name: "contractors", Action: TeamAccessActionRemoved},
+	}
+	if diff := cmp.Diff(wantResults, results); diff != "" {
+		t.Errorf("ReconcileAll() results (-want +got):\n%s", diff)
+	}
+	wantTeams := map[string]RepositoryPermission{
+		"maintainers": RepositoryPermissionMaintain,
+		"developers":  RepositoryPermissionPush,
+		"release":     defaultRepoPermission,
+	}
+	if diff := cmp.Diff(wantTeams, c.teams); diff != "" {
+		t.Errorf("ReconcileAll() teams (-want +got):\n%s", diff)
+	}
+	if c.lists != 1 {
+		t.Errorf("ReconcileAll() listed the teams %d times, want 1", c.lists)
+	}
+	if reqs[2].Permission != nil {
+		t.Error("ReconcileAll() modified the requests")
+	}
+}
+
+func TestReconcileAllTeamAccess_PruneDisallowed(t *testing.T) {
+	c := &memoryTeamAccess{teams: map[string]RepositoryPermission{
+		"developers":  RepositoryPermissionPull,
+		"contractors": RepositoryPermissionPush,
+	}}
+	reqs := []TeamAccessInfo{
+		{Name: "developers", Permission: RepositoryPermissionVar(RepositoryPermissionPush)},
+	}
+
+	_, err := ReconcileAllTeamAccess(context.Background(), c, reqs, true, false)
+	if !errors.Is(err, ErrDestructiveCallDisallowed) {
+		t.Fatalf("ReconcileAllTeamAccess() error = %v, want %v", err, ErrDestructiveCallDisallowed)
+	}
+	wantTeams := map[string]RepositoryPermission{
+		"developers":  RepositoryPermissionPull,
+		"contractors": RepositoryPermissionPush,
+	}
+	if diff := cmp.Diff(wantTeams, c.teams); diff != "" {
+		t.Errorf("ReconcileAllTeamAccess() changed the teams (-want +got):\n%s", diff)
+	}
+
+	// Without pruning, the other teams are left as-is
+	if _, err := ReconcileAllTeamAccess(context.Background(), c, reqs, false, false); err != nil {
+		t.Fatalf("ReconcileAllTeamAccess() error = %v", err)
+	}
+	if got := c.teams["contractors"]; got != RepositoryPermissionPush {
+		t.Errorf("ReconcileAllTeamAccess() changed the contractors permission to %q", got)
+	}
+}
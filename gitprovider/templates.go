@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+)
+
+// templatesCommitMessage is the commit message used when committing templates.
+const templatesCommitMessage = "Add pull request and issue templates"
+
+// TemplateLayout describes the conventional locations of the pull request and issue
+// templates of a Git provider.
+type TemplateLayout struct {
+	// PullRequestTemplate is the path of the default pull request template,
+	// e.g. ".github/PULL_REQUEST_TEMPLATE.md".
+	PullRequestTemplate string
+
+	// IssueTemplatesDir is the directory holding the issue templates,
+	// e.g. ".github/ISSUE_TEMPLATE".
+	IssueTemplatesDir string
+}
+
+// TemplateConventions describes where a Git provider looks for pull request and issue templates.
+// Paths are relative to the root of the repository, and matched case-insensitively.
+type TemplateConventions struct {
+	// SearchDir is the directory listed to discover the templates, "" for the whole repository.
+	SearchDir string
+
+	// PullRequestTemplates are the paths of the single pull request template files,
+	// e.g. ".github/pull_request_template.md".
+	PullRequestTemplates []string
+
+	// PullRequestTemplateDirs are the directories holding several pull request templates,
+	// e.g. ".gitlab/merge_request_templates".
+	PullRequestTemplateDirs []string
+
+	// IssueTemplates are the paths of the single issue template files,
+	// e.g. ".github/issue_template.md".
+	IssueTemplates []string
+
+	// IssueTemplateDirs are the directories holding several issue templates,
+	// e.g. ".github/ISSUE_TEMPLATE".
+	IssueTemplateDirs []string
+}
+
+// templateConfigFiles are the files configuring the template chooser, which may be found next to
+// the templates but aren't templates themselves.
+//
+//nolint:gochecknoglobals
+var templateConfigFiles = map[string]bool{
+	"config.yml":  true,
+	"config.yaml": true,
+}
+
+// DiscoverTemplates returns the paths of the pull request and issue templates found at the
+// locations described by conventions, at the given ref. Only the files directly in the template
+// directories are templates.
+func DiscoverTemplates(ctx context.Context, c TreeClient, ref string, conventions TemplateConventions) (TemplateLocations, error) {
+	locations := TemplateLocations{PullRequestTemplates: []string{}, IssueTemplates: []string{}}
+	entries, err := c.List(ctx, ref, conventions.SearchDir, true)
+	if err != nil {
+		return locations, err
+	}
+
+	prFiles, prDirs := lowerSet(conventions.PullRequestTemplates), lowerSet(conventions.PullRequestTemplateDirs)
+	issueFiles, issueDirs := lowerSet(conventions.IssueTemplates), lowerSet(conventions.IssueTemplateDirs)
+	for _, entry := range entries {
+		p := strings.ToLower(entry.Path)
+		dir, name := path.Split(p)
+		dir = strings.TrimSuffix(dir, "/")
+		inDir := func(dirs map[string]bool) bool { return dirs[dir] && !templateConfigFiles[name] }
+		switch {
+		case prFiles[p] || inDir(prDirs):
+			locations.PullRequestTemplates = append(locations.PullRequestTemplates, entry.Path)
+		case issueFiles[p] || inDir(issueDirs):
+			locations.IssueTemplates = append(locations.IssueTemplates, entry.Path)
+		}
+	}
+	sort.Strings(locations.PullRequestTemplates)
+	sort.Strings(locations.IssueTemplates)
+	return locations, nil
+}
+
+// lowerSet returns the set of the given strings, lowercased.
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToLower(v)] = true
+	}
+	return set
+}
+
+// CommitTemplates commits the given templates to their locations in layout, on the given
+// branch and in a single commit.
+func CommitTemplates(ctx context.Context, c CommitClient, branch string, layout TemplateLayout, templates TemplatesInfo) (Commit, error) {
+	if err := templates.ValidateInfo(); err != nil {
+		return nil, err
+	}
+
+	files := []CommitFile{}
+	if templates.PullRequest != nil {
+		files = append(files, CommitFile{
+			Path:    StringVar(layout.PullRequestTemplate),
+			Content: templates.PullRequest,
+		})
+	}
+	// Sort the issue templates to get a deterministic commit
+	names := make([]string, 0, len(templates.Issues))
+	for name := range templates.Issues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		files = append(files, CommitFile{
+			Path:    StringVar(path.Join(layout.IssueTemplatesDir, name)),
+			Content: StringVar(templates.Issues[name]),
+		})
+	}
+
+	return c.Create(ctx, branch, templatesCommitMessage, encodeCommitFiles(c, files))
+}
+
+// ReadTemplates reads the templates found at the locations in layout, on the given branch.
+// Missing templates are left unset.
+func ReadTemplates(ctx context.Context, c FileClient, branch string, layout TemplateLayout) (TemplatesInfo, error) {
+	templates := TemplatesInfo{}
+
+	prFiles, err := getFilesIfExist(ctx, c, path.Dir(layout.PullRequestTemplate), branch)
+	if err != nil {
+		return templates, err
+	}
+	for _, f := range prFiles {
+		if f.Path != nil && *f.Path == layout.PullRequestTemplate {
+			templates.PullRequest = f.Content
+		}
+	}
+
+	issueFiles, err := getFilesIfExist(ctx, c, layout.IssueTemplatesDir, branch)
+	if err != nil {
+		return templates, err
+	}
+	for _, f := range issueFiles {
+		if f.Path == nil || f.Content == nil {
+			continue
+		}
+		if templates.Issues == nil {
+			templates.Issues = map[string]string{}
+		}
+		templates.Issues[path.Base(*f.Path)] = *f.Content
+	}
+
+	return templates, nil
+}
+
+// getFilesIfExist returns the files in the given directory, or no files if the directory doesn't exist.
+func getFilesIfExist(ctx context.Context, c FileClient, dir, branch string) ([]*CommitFile, error) {
+	files, err := c.Get(ctx, dir, branch)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return files, err
+}
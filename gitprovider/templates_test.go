@@ -0,0 +1,277 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// memoryRepo is an in-memory repository implementing FileClient, and CommitClient through
+// memoryCommits, storing the files of a single branch. The paths given to Get are recorded.
+type memoryRepo struct {
+	files   map[string]string
+	commits []string
+	gets    []string
+}
+
+// memoryCommits is the CommitClient of a memoryRepo.
+type memoryCommits struct {
+	*memoryRepo
+}
+
+func (c memoryCommits) Get(_ context.Context, _ string) (Commit, error) {
+	return nil, ErrNotFound
+}
+
+func (r *memoryRepo) ListPage(_ context.Context, _ string, _ int, _ int) ([]Commit, error) {
+	return nil, nil
+}
+
+func (r *memoryRepo) List(_ context.Context, _ CommitListOptions) ([]Commit, error) {
+	return nil, nil
+}
+
+func (r *memoryRepo) Create(_ context.Context, _ string, message string, files []CommitFile, _ ...CommitCreateOption) (Commit, error) {
+	if r.files == nil {
+		r.files = map[string]string{}
+	}
+	for _, f := range files {
+		r.files[*f.Path] = *f.Content
+	}
+	r.commits = append(r.commits, message)
+	return nil, nil
+}
+
+func (r *memoryRepo) SignatureKey(_ context.Context, _ string) (string, error) {
+	return "", ErrNoProviderSupport
+}
+
+func (r *memoryRepo) ResolveRef(_ context.Context, _ string) (string, error) {
+	return "", ErrNoProviderSupport
+}
+
+func (r *memoryRepo) CheckAnnotations(_ context.Context, _ string) ([]Annotation, error) {
+	return nil, ErrNoProviderSupport
+}
+
+func (r *memoryRepo) ContainedInBranches(_ context.Context, _ string) ([]string, error) {
+	return nil, ErrNoProviderSupport
+}
+
+func (r *memoryRepo) Get(_ context.Context, dir, _ string, _ ...FilesGetOption) ([]*CommitFile, error) {
+	r.gets = append(r.gets, dir)
+	if content, ok := r.files[dir]; ok {
+		return []*CommitFile{{Path: StringVar(dir), Content: StringVar(content)}}, nil
+	}
+	if dir == "" {
+		dir = "."
+	}
+	paths := []string{}
+	for p := range r.files {
+		if path.Dir(p) == dir {
+			paths = append(paths, p)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, ErrNotFound
+	}
+	sort.Strings(paths)
+	files := make([]*CommitFile, 0, len(paths))
+	for _, p := range paths {
+		files = append(files, &CommitFile{Path: StringVar(p), Content: StringVar(r.files[p])})
+	}
+	return files, nil
+}
+
+func TestTemplatesRoundTrip(t *testing.T) {
+	layout := TemplateLayout{
+		PullRequestTemplate: ".github/PULL_REQUEST_TEMPLATE.md",
+		IssueTemplatesDir:   ".github/ISSUE_TEMPLATE",
+	}
+	tests := []struct {
+		name      string
+		templates TemplatesInfo
+		wantFiles []string
+	}{
+		{
+			name: "pull request template",
+			templates: TemplatesInfo{
+				PullRequest: StringVar("## Description\n"),
+			},
+			wantFiles: []string{".github/PULL_REQUEST_TEMPLATE.md"},
+		},
+		{
+			name: "pull request and issue templates",
+			templates: TemplatesInfo{
+				PullRequest: StringVar("## Description\n"),
+				Issues: map[string]string{
+					"bug_report.md":      "## Bug\n",
+					"feature_request.md": "## Feature\n",
+				},
+			},
+			wantFiles: []string{
+				".github/ISSUE_TEMPLATE/bug_report.md",
+				".github/ISSUE_TEMPLATE/feature_request.md",
+				".github/PULL_REQUEST_TEMPLATE.md",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepo{}
+			if _, err := CommitTemplates(context.Background(), memoryCommits{repo}, "main", layout, tt.templates); err != nil {
+				t.Fatalf("CommitTemplates() error = %v", err)
+			}
+			if len(repo.commits) != 1 {
+				t.Errorf("CommitTemplates() made %d commits, want 1", len(repo.commits))
+			}
+			gotFiles := []string{}
+			for p := range repo.files {
+				gotFiles = append(gotFiles, p)
+			}
+			sort.Strings(gotFiles)
+			if !reflect.DeepEqual(gotFiles, tt.wantFiles) {
+				t.Errorf("CommitTemplates() files = %v, want %v", gotFiles, tt.wantFiles)
+			}
+
+			got, err := ReadTemplates(context.Background(), repo, "main", layout)
+			if err != nil {
+				t.Fatalf("ReadTemplates() error = %v", err)
+			}
+			if !got.Equals(tt.templates) {
+				t.Errorf("ReadTemplates() = %+v, want %+v", got, tt.templates)
+			}
+		})
+	}
+}
+
+func TestCommitTemplatesValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates TemplatesInfo
+	}{
+		{
+			name:      "no templates",
+			templates: TemplatesInfo{},
+		},
+		{
+			name: "nested issue template name",
+			templates: TemplatesInfo{
+				Issues: map[string]string{"sub/bug.md": ""},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRepo{}
+			if _, err := CommitTemplates(context.Background(), memoryCommits{repo}, "main", TemplateLayout{}, tt.templates); err == nil {
+				t.Error("CommitTemplates() expected an error")
+			}
+			if len(repo.commits) != 0 {
+				t.Errorf("CommitTemplates() made %d commits, want 0", len(repo.commits))
+			}
+		})
+	}
+}
+
+// memoryTree is an in-memory TreeClient listing the given file paths.
+type memoryTree []string
+
+func (t memoryTree) Get(_ context.Context, _ string, _ bool) (*TreeInfo, error) {
+	return nil, ErrNoProviderSupport
+}
+
+func (t memoryTree) List(_ context.Context, _ string, dir string, _ bool) ([]*TreeEntry, error) {
+	entries := []*TreeEntry{}
+	for _, p := range t {
+		if dir == "" || strings.HasPrefix(p, dir+"/") {
+			entries = append(entries, &TreeEntry{Path: p, Type: "blob"})
+		}
+	}
+	return entries, nil
+}
+
+func TestDiscoverTemplates(t *testing.T) {
+	tree := memoryTree{
+		"README.md",
+		".github/PULL_REQUEST_TEMPLATE.md",
+		".github/ISSUE_TEMPLATE/bug_report.md",
+		".github/ISSUE_TEMPLATE/feature_request.yml",
+		".github/ISSUE_TEMPLATE/config.yml",
+		".github/ISSUE_TEMPLATE/nested/ignored.md",
+		"docs/Issue_Template.md",
+		".gitlab/merge_request_templates/Default.md",
+		".gitlab/issue_templates/Bug.md",
+		".gitlab/ci/build.yml",
+	}
+	tests := []struct {
+		name        string
+		conventions TemplateConventions
+		want        TemplateLocations
+	}{
+		{
+			name: "GitHub layout",
+			conventions: TemplateConventions{
+				PullRequestTemplates:    []string{".github/pull_request_template.md"},
+				PullRequestTemplateDirs: []string{".github/PULL_REQUEST_TEMPLATE"},
+				IssueTemplates:          []string{"docs/issue_template.md"},
+				IssueTemplateDirs:       []string{".github/ISSUE_TEMPLATE"},
+			},
+			want: TemplateLocations{
+				PullRequestTemplates: []string{".github/PULL_REQUEST_TEMPLATE.md"},
+				IssueTemplates: []string{
+					".github/ISSUE_TEMPLATE/bug_report.md",
+					".github/ISSUE_TEMPLATE/feature_request.yml",
+					"docs/Issue_Template.md",
+				},
+			},
+		},
+		{
+			name: "GitLab layout",
+			conventions: TemplateConventions{
+				SearchDir:               ".gitlab",
+				PullRequestTemplateDirs: []string{".gitlab/merge_request_templates"},
+				IssueTemplateDirs:       []string{".gitlab/issue_templates"},
+			},
+			want: TemplateLocations{
+				PullRequestTemplates: []string{".gitlab/merge_request_templates/Default.md"},
+				IssueTemplates:       []string{".gitlab/issue_templates/Bug.md"},
+			},
+		},
+		{
+			name:        "no templates",
+			conventions: TemplateConventions{IssueTemplateDirs: []string{".gitea/ISSUE_TEMPLATE"}},
+			want:        TemplateLocations{PullRequestTemplates: []string{}, IssueTemplates: []string{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiscoverTemplates(context.Background(), tree, "main", tt.conventions)
+			if err != nil {
+				t.Fatalf("DiscoverTemplates() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiscoverTemplates() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
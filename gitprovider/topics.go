@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"sort"
+)
+
+// ReconcileTopics makes sure a repository has exactly the given topics, using get to read the
+// actual topics and set to replace them. The topics are checked against the options before get
+// is called, and set is only called if the topics differ, regardless of their order.
+func ReconcileTopics(ctx context.Context, topics []string, get func(ctx context.Context) ([]string, error), set func(ctx context.Context, topics []string) error, opts ...TopicsReconcileOption) (bool, error) {
+	o := MakeTopicsReconcileOptions(opts...)
+	if err := o.CheckTopics(topics); err != nil {
+		return false, err
+	}
+
+	actual, err := get(ctx)
+	if err != nil {
+		return false, err
+	}
+	if sameTopics(actual, topics) {
+		return false, nil
+	}
+	return true, set(ctx, topics)
+}
+
+// sameTopics returns whether a and b contain the same topics, in any order.
+func sameTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
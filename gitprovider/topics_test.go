@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReconcileTopics(t *testing.T) {
+	allowed := WithAllowedTopics([]string{"flux", "gitops", "kubernetes"})
+	tests := []struct {
+		name       string
+		topics     []string
+		opts       []TopicsReconcileOption
+		wantErr    error
+		wantAction bool
+		wantSet    []string
+	}{
+		{
+			name:       "no allowed topics",
+			topics:     []string{"flx"},
+			wantAction: true,
+			wantSet:    []string{"flx"},
+		},
+		{
+			name:       "allowed topics",
+			topics:     []string{"gitops", "kubernetes"},
+			opts:       []TopicsReconcileOption{allowed},
+			wantAction: true,
+			wantSet:    []string{"gitops", "kubernetes"},
+		},
+		{
+			name:    "disallowed topic",
+			topics:  []string{"gitops", "flx"},
+			opts:    []TopicsReconcileOption{allowed},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:   "same topics in another order",
+			topics: []string{"gitops", "flux"},
+			opts:   []TopicsReconcileOption{allowed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var set []string
+			getCalled := false
+			get := func(_ context.Context) ([]string, error) {
+				getCalled = true
+				return []string{"flux", "gitops"}, nil
+			}
+			setFn := func(_ context.Context, topics []string) error {
+				set = topics
+				return nil
+			}
+			gotAction, err := ReconcileTopics(context.Background(), tt.topics, get, setFn, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReconcileTopics() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), `"flx"`) {
+				t.Errorf("ReconcileTopics() error = %v, want it to name the disallowed topic", err)
+			}
+			if err != nil && getCalled {
+				t.Error("ReconcileTopics() called the provider for a disallowed topic")
+			}
+			if gotAction != tt.wantAction {
+				t.Errorf("ReconcileTopics() actionTaken = %v, want %v", gotAction, tt.wantAction)
+			}
+			if !reflect.DeepEqual(set, tt.wantSet) {
+				t.Errorf("ReconcileTopics() set %v, want %v", set, tt.wantSet)
+			}
+		})
+	}
+}
@@ -19,8 +19,13 @@ package gitprovider
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
+func timeVar(t time.Time) *time.Time {
+	return &t
+}
+
 func TestDefaulting(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -89,6 +94,17 @@ func TestDefaulting(t *testing.T) {
 				DefaultBranch: StringVar("main"),
 			},
 		},
+		{
+			name:       "Repository: keep the default visibility sentinel",
+			structName: "Repository",
+			object: &RepositoryInfo{
+				Visibility: RepositoryVisibilityVar(RepositoryVisibilityDefault),
+			},
+			expected: &RepositoryInfo{
+				Visibility:    RepositoryVisibilityVar(RepositoryVisibilityDefault),
+				DefaultBranch: StringVar("main"),
+			},
+		},
 		{
 			name:       "TeamAccess: empty",
 			structName: "TeamAccess",
@@ -117,6 +133,26 @@ func TestDefaulting(t *testing.T) {
 				Permission: RepositoryPermissionVar(RepositoryPermissionPush),
 			},
 		},
+		{
+			name:       "Milestone: empty",
+			structName: "Milestone",
+			object:     &MilestoneInfo{},
+			expected: &MilestoneInfo{
+				State: MilestoneStateVar(MilestoneStateOpen),
+			},
+		},
+		{
+			name:       "Milestone: truncate the due date to midnight UTC of its day",
+			structName: "Milestone",
+			object: &MilestoneInfo{
+				DueDate: timeVar(time.Date(2023, time.March, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))),
+				State:   MilestoneStateVar(MilestoneStateClosed),
+			},
+			expected: &MilestoneInfo{
+				DueDate: timeVar(time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC)),
+				State:   MilestoneStateVar(MilestoneStateClosed),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
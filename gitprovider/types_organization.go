@@ -16,6 +16,15 @@ limitations under the License.
 
 package gitprovider
 
+import (
+	"net/url"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
 // OrganizationInfo represents an (top-level- or sub-) organization.
 type OrganizationInfo struct {
 	// Name is the human-friendly name of this organization, e.g. "Flux" or "Kubernetes SIGs".
@@ -23,6 +32,29 @@ type OrganizationInfo struct {
 
 	// Description returns a description for the organization.
 	Description *string `json:"description"`
+
+	// TwoFactorRequired specifies whether the members of the organization must enable two-factor
+	// authentication. This field is read-only, and nil if the provider doesn't report it.
+	// +optional
+	TwoFactorRequired *bool `json:"twoFactorRequired,omitempty"`
+}
+
+// AuditLogEntry is an event recorded in the audit log of an organization.
+type AuditLogEntry struct {
+	// Actor is the login or name of the user who performed the action.
+	Actor string `json:"actor"`
+
+	// Action is the name of the action that was performed, in the vocabulary of the provider,
+	// e.g. "repo.create" on GitHub.
+	Action string `json:"action"`
+
+	// CreatedAt is the time the event happened.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Target is the name of the resource the action was performed on, e.g. a repository or a
+	// user. It's empty if the provider doesn't report it for the event.
+	// +optional
+	Target string `json:"target,omitempty"`
 }
 
 // TeamInfo is a representation for a team of users inside of an organization.
@@ -33,3 +65,136 @@ type TeamInfo struct {
 	// Members points to a set of user names (logins) of the members of this team.
 	Members []string `json:"members"`
 }
+
+// OrganizationSettingsInfo implements InfoRequest.
+var _ InfoRequest = OrganizationSettingsInfo{}
+
+// OrganizationSettingsInfo contains the org-wide defaults governing the repositories of an
+// organization. Unset fields are left as-is when reconciling.
+type OrganizationSettingsInfo struct {
+	// DefaultRepositoryPermission is the permission level every member of the organization
+	// has on all its repositories.
+	// +optional
+	DefaultRepositoryPermission *PermissionLevel `json:"defaultRepositoryPermission,omitempty"`
+
+	// MembersCanCreateRepositories specifies whether members who aren't administrators of the
+	// organization can create repositories in it.
+	// +optional
+	MembersCanCreateRepositories *bool `json:"membersCanCreateRepositories,omitempty"`
+
+	// AllowAutoMerge specifies whether new repositories allow pull requests to be merged
+	// automatically once their requirements are met.
+	// +optional
+	AllowAutoMerge *bool `json:"allowAutoMerge,omitempty"`
+
+	// AllowSquashMerge specifies whether new repositories allow squashing pull requests
+	// when merging them.
+	// +optional
+	AllowSquashMerge *bool `json:"allowSquashMerge,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (s OrganizationSettingsInfo) ValidateInfo() error {
+	validator := validation.New("OrganizationSettings")
+	if s.DefaultRepositoryPermission != nil {
+		validator.Append(ValidatePermissionLevel(*s.DefaultRepositoryPermission), *s.DefaultRepositoryPermission, "DefaultRepositoryPermission")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (s OrganizationSettingsInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(s, actual)
+}
+
+// OrganizationWebhookInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = OrganizationWebhookInfo{}
+var _ DefaultedInfoRequest = &OrganizationWebhookInfo{}
+
+// OrganizationWebhookInfo contains high-level information about a webhook triggered by the
+// events of all repositories of an organization.
+type OrganizationWebhookInfo struct {
+	// URL is the address the events are delivered to. It identifies the webhook in the
+	// organization.
+	// +required
+	URL string `json:"url"`
+
+	// Events are the events triggering the webhook. Events not expressible as a WebhookEvent
+	// are left out when reading webhooks, and left as-is when updating them.
+	// Default value at POST-time: [push].
+	// +optional
+	Events []WebhookEvent `json:"events,omitempty"`
+
+	// Secret is used by the receiver to verify that the deliveries come from the provider.
+	// This field is write-only, and ignored when comparing webhooks. As it can't be read back,
+	// it has to be set again when updating a webhook in order to keep it.
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the TLS certificate of URL.
+	// Default value at POST-time: false.
+	// +optional
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+
+	// ContentType is the format the events are delivered in.
+	// Default value at POST-time: WebhookContentTypeJSON.
+	// +optional
+	ContentType *WebhookContentType `json:"contentType,omitempty"`
+}
+
+// Default defaults the OrganizationWebhook fields.
+func (w *OrganizationWebhookInfo) Default() {
+	if len(w.Events) == 0 {
+		w.Events = []WebhookEvent{defaultWebhookEvent}
+	}
+	if w.InsecureSkipVerify == nil {
+		w.InsecureSkipVerify = BoolVar(defaultWebhookInsecureSkipVerify)
+	}
+	if w.ContentType == nil {
+		w.ContentType = WebhookContentTypeVar(defaultWebhookContentType)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (w OrganizationWebhookInfo) ValidateInfo() error {
+	validator := validation.New("OrganizationWebhook")
+	if len(w.URL) == 0 {
+		validator.Required("URL")
+	} else if !isValidWebhookURL(w.URL) {
+		validator.Invalid(w.URL, "URL")
+	}
+	for _, e := range w.Events {
+		validator.Append(ValidateWebhookEvent(e), e, "Events")
+	}
+	if w.ContentType != nil {
+		validator.Append(ValidateWebhookContentType(*w.ContentType), *w.ContentType, "ContentType")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The order of the events doesn't matter, and a desired Secret is
+// always a difference, as the actual one isn't returned by the providers.
+func (w OrganizationWebhookInfo) Equals(actual InfoRequest) bool {
+	actualHook, ok := actual.(OrganizationWebhookInfo)
+	if !ok || w.Secret != nil {
+		return false
+	}
+	actualHook.Secret = nil
+	w.Events, actualHook.Events = sortedWebhookEvents(w.Events), sortedWebhookEvents(actualHook.Events)
+	return reflect.DeepEqual(w, actualHook)
+}
+
+// isValidWebhookURL returns true if events can be delivered to rawURL, an absolute HTTP(S) URL.
+func isValidWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// sortedWebhookEvents returns a sorted copy of events.
+func sortedWebhookEvents(events []WebhookEvent) []WebhookEvent {
+	sorted := append([]WebhookEvent{}, events...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
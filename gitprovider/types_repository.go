@@ -17,7 +17,10 @@ limitations under the License.
 package gitprovider
 
 import (
+	"encoding/hex"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/fluxcd/go-git-providers/validation"
@@ -34,6 +37,34 @@ const (
 	defaultBranchName = "main"
 	// by default, deploy keys are read-only.
 	defaultDeployKeyReadOnly = true
+	// by default, only administrators can create protected tags.
+	defaultProtectedTagCreateAccessLevel = PermissionLevelAdmin
+	// by default, milestones are open.
+	defaultMilestoneState = MilestoneStateOpen
+	// by default, merge queues create merge commits.
+	defaultMergeQueueMergeMethod = MergeMethodMerge
+	// by default, merge queues merge at most 5 pull requests at once.
+	defaultMergeQueueMaxEntries = 5
+	// by default, merge queues wait 5 minutes for more pull requests to merge together.
+	defaultMergeQueueMinWait = 5 * time.Minute
+	// by default, merge queues wait an hour for the required status checks.
+	defaultMergeQueueMaxWait = time.Hour
+	// defaultWorkflowPermission is the least privileged default workflow permission.
+	defaultWorkflowPermission = WorkflowPermissionRead
+	// by default, code scanning runs the queries with a low false positive rate.
+	defaultCodeScanningQuerySuite = CodeScanningQuerySuiteDefault
+	// by default, interaction limits are lifted after a day.
+	defaultInteractionLimitExpiry = InteractionLimitExpiryOneDay
+	// by default, webhooks are triggered by pushes.
+	defaultWebhookEvent = WebhookEventPush
+	// by default, webhooks verify the TLS certificate of their URL.
+	defaultWebhookInsecureSkipVerify = false
+	// by default, webhooks deliver the events as JSON.
+	defaultWebhookContentType = WebhookContentTypeJSON
+	// by default, repository webhooks deliver events.
+	defaultDeployHookActive = true
+	// by default, websites are published from the root of their source branch.
+	defaultPagesSourcePath = "/"
 )
 
 // RepositoryInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
@@ -55,9 +86,23 @@ type RepositoryInfo struct {
 	DefaultBranch *string `json:"defaultBranch"`
 
 	// Visibility returns the desired visibility for the repository.
+	// Set it to RepositoryVisibilityDefault to let the provider apply its default visibility
+	// at POST-time, and to leave the visibility unmanaged when reconciling.
 	// Default value at POST-time: RepositoryVisibilityPrivate.
 	// +optional
 	Visibility *RepositoryVisibility `json:"visibility"`
+
+	// Parent is the repository this repository was forked from, nil if it isn't a fork.
+	// This field is read-only, and ignored when comparing repositories.
+	// +optional
+	Parent RepositoryRef `json:"parent,omitempty"`
+
+	// Source is the root repository of the fork network this repository belongs to, nil if
+	// it isn't a fork or the provider doesn't report it. It equals Parent unless the parent
+	// is itself a fork.
+	// This field is read-only, and ignored when comparing repositories.
+	// +optional
+	Source RepositoryRef `json:"source,omitempty"`
 }
 
 // Default defaults the Repository, implementing the InfoRequest interface.
@@ -82,8 +127,19 @@ func (r RepositoryInfo) ValidateInfo() error {
 
 // Equals can be used to check if this *Info request (the desired state) matches the actual
 // passed in as the argument.
+// If the desired visibility is RepositoryVisibilityDefault, the visibility is not compared.
 func (r RepositoryInfo) Equals(actual InfoRequest) bool {
-	return reflect.DeepEqual(r, actual)
+	actualRepo, ok := actual.(RepositoryInfo)
+	if !ok {
+		return false
+	}
+	if r.Visibility != nil && *r.Visibility == RepositoryVisibilityDefault {
+		r.Visibility = actualRepo.Visibility
+	}
+	// The fork network is reported by the provider, and can't be changed
+	r.Parent, r.Source = nil, nil
+	actualRepo.Parent, actualRepo.Source = nil, nil
+	return reflect.DeepEqual(r, actualRepo)
 }
 
 // TeamAccessInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
@@ -148,6 +204,31 @@ type DeployKeyInfo struct {
 	// Default value at POST-time: true.
 	// +optional
 	ReadOnly *bool `json:"readOnly,omitempty"`
+
+	// LastUsed is the time the key was last used, if reported by the provider.
+	// This field is read-only, and ignored when comparing deploy keys.
+	// +optional
+	LastUsed *time.Time `json:"lastUsed,omitempty"`
+
+	// LastUsedFrom is the address the key was last used from, if reported by the provider.
+	// This field is read-only, and ignored when comparing deploy keys.
+	// +optional
+	LastUsedFrom *string `json:"lastUsedFrom,omitempty"`
+
+	// Fingerprint is the SHA256 fingerprint of Key, in the "SHA256:..." format of ssh-keygen -l.
+	// It's computed client-side, see ComputeFingerprint.
+	// This field is read-only, and ignored when comparing deploy keys.
+	// +optional
+	Fingerprint *string `json:"fingerprint,omitempty"`
+}
+
+// ComputeFingerprint sets Fingerprint from Key. Fingerprint is left unset if Key isn't a valid SSH
+// public key.
+func (dk *DeployKeyInfo) ComputeFingerprint() {
+	dk.Fingerprint = nil
+	if fingerprint, err := DeployKeyFingerprint(dk.Key); err == nil {
+		dk.Fingerprint = &fingerprint
+	}
 }
 
 // Default defaults the DeployKey fields.
@@ -176,7 +257,14 @@ func (dk DeployKeyInfo) ValidateInfo() error {
 // Equals can be used to check if this *Info request (the desired state) matches the actual
 // passed in as the argument.
 func (dk DeployKeyInfo) Equals(actual InfoRequest) bool {
-	return reflect.DeepEqual(dk, actual)
+	actualKey, ok := actual.(DeployKeyInfo)
+	if !ok {
+		return false
+	}
+	// The usage fields are reported by the provider, and the fingerprint is derived from the key
+	dk.LastUsed, dk.LastUsedFrom, dk.Fingerprint = nil, nil, nil
+	actualKey.LastUsed, actualKey.LastUsedFrom, actualKey.Fingerprint = nil, nil, nil
+	return reflect.DeepEqual(dk, actualKey)
 }
 
 // DeployTokenInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
@@ -220,6 +308,256 @@ func (dk DeployTokenInfo) Equals(actual InfoRequest) bool {
 	return reflect.DeepEqual(dk, actual)
 }
 
+// DeployHookInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = DeployHookInfo{}
+var _ DefaultedInfoRequest = &DeployHookInfo{}
+
+// DeployHookInfo contains high-level information about a webhook triggered by the events of a
+// repository.
+type DeployHookInfo struct {
+	// URL is the address the events are delivered to. It identifies the webhook in the repository.
+	// +required
+	URL string `json:"url"`
+
+	// Events are the events triggering the webhook. Events not expressible as a WebhookEvent
+	// are left out when reading webhooks, and left as-is when updating them.
+	// Default value at POST-time: [push].
+	// +optional
+	Events []WebhookEvent `json:"events,omitempty"`
+
+	// Secret is used by the receiver to verify that the deliveries come from the provider.
+	// This field is write-only: it's never returned by Get, hence it has to be set again when
+	// updating a webhook in order to keep it. As the actual secret can't be compared, a desired
+	// secret is always considered a difference, so that rotated secrets are applied.
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+
+	// ContentType is the format the events are delivered in.
+	// Default value at POST-time: WebhookContentTypeJSON.
+	// +optional
+	ContentType *WebhookContentType `json:"contentType,omitempty"`
+
+	// Active specifies whether events are delivered. Inactive webhooks keep their configuration.
+	// Default value at POST-time: true.
+	// +optional
+	Active *bool `json:"active,omitempty"`
+}
+
+// Default defaults the DeployHook fields.
+func (h *DeployHookInfo) Default() {
+	if len(h.Events) == 0 {
+		h.Events = []WebhookEvent{defaultWebhookEvent}
+	}
+	if h.ContentType == nil {
+		h.ContentType = WebhookContentTypeVar(defaultWebhookContentType)
+	}
+	if h.Active == nil {
+		h.Active = BoolVar(defaultDeployHookActive)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (h DeployHookInfo) ValidateInfo() error {
+	validator := validation.New("DeployHook")
+	if len(h.URL) == 0 {
+		validator.Required("URL")
+	} else if !isValidWebhookURL(h.URL) {
+		validator.Invalid(h.URL, "URL")
+	}
+	for _, e := range h.Events {
+		validator.Append(ValidateWebhookEvent(e), e, "Events")
+	}
+	if h.ContentType != nil {
+		validator.Append(ValidateWebhookContentType(*h.ContentType), *h.ContentType, "ContentType")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The order of the events doesn't matter, and a desired Secret is
+// always a difference, as the actual one isn't returned by the providers.
+func (h DeployHookInfo) Equals(actual InfoRequest) bool {
+	actualHook, ok := actual.(DeployHookInfo)
+	if !ok || h.Secret != nil {
+		return false
+	}
+	actualHook.Secret = nil
+	h.Events, actualHook.Events = sortedWebhookEvents(h.Events), sortedWebhookEvents(actualHook.Events)
+	return reflect.DeepEqual(h, actualHook)
+}
+
+// ProtectedTagInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = ProtectedTagInfo{}
+var _ DefaultedInfoRequest = &ProtectedTagInfo{}
+
+// ProtectedTagInfo contains high-level information about a protected tag.
+type ProtectedTagInfo struct {
+	// Name is the name of the tag to protect, or a wildcard pattern matching the tags
+	// to protect, e.g. "v*".
+	// +required
+	Name string `json:"name"`
+
+	// CreateAccessLevel is the minimum permission level required to create the tags matching Name.
+	// PermissionLevelNone prevents anyone from creating them, PermissionLevelRead is invalid.
+	// Default value at POST-time: PermissionLevelAdmin.
+	// +optional
+	CreateAccessLevel *PermissionLevel `json:"createAccessLevel,omitempty"`
+}
+
+// Default defaults the ProtectedTag fields.
+func (pt *ProtectedTagInfo) Default() {
+	if pt.CreateAccessLevel == nil {
+		pt.CreateAccessLevel = PermissionLevelVar(defaultProtectedTagCreateAccessLevel)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (pt ProtectedTagInfo) ValidateInfo() error {
+	validator := validation.New("ProtectedTag")
+	// Make sure we've set the name or pattern of the protected tag
+	if len(pt.Name) == 0 {
+		validator.Required("Name")
+	}
+	// Make sure the access level is valid if set. Read access doesn't allow creating tags.
+	if pt.CreateAccessLevel != nil {
+		if *pt.CreateAccessLevel == PermissionLevelRead {
+			validator.Invalid(*pt.CreateAccessLevel, "CreateAccessLevel")
+		} else {
+			validator.Append(ValidatePermissionLevel(*pt.CreateAccessLevel), *pt.CreateAccessLevel, "CreateAccessLevel")
+		}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (pt ProtectedTagInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(pt, actual)
+}
+
+// MilestoneInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = MilestoneInfo{}
+var _ DefaultedInfoRequest = &MilestoneInfo{}
+
+// MilestoneInfo contains high-level information about a milestone.
+type MilestoneInfo struct {
+	// Title is the title of the milestone, which is unique within the repository.
+	// +required
+	Title string `json:"title"`
+
+	// Description describes the milestone.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// DueDate is the day the milestone is due. Not all providers store the time of the day,
+	// hence DueDate is defaulted to midnight UTC of its day.
+	// +optional
+	DueDate *time.Time `json:"dueDate,omitempty"`
+
+	// State is the state of the milestone.
+	// Default value at POST-time: MilestoneStateOpen.
+	// +optional
+	State *MilestoneState `json:"state,omitempty"`
+}
+
+// Default defaults the Milestone fields.
+func (m *MilestoneInfo) Default() {
+	if m.State == nil {
+		m.State = MilestoneStateVar(defaultMilestoneState)
+	}
+	if m.DueDate != nil {
+		m.DueDate = MilestoneDueDate(*m.DueDate)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (m MilestoneInfo) ValidateInfo() error {
+	validator := validation.New("Milestone")
+	// Make sure we've set the title of the milestone
+	if len(m.Title) == 0 {
+		validator.Required("Title")
+	}
+	// The zero time is most likely an unset time.Time and not a due date
+	if m.DueDate != nil && m.DueDate.IsZero() {
+		validator.Invalid(*m.DueDate, "DueDate")
+	}
+	// Make sure the state is valid if set
+	if m.State != nil {
+		validator.Append(ValidateMilestoneState(*m.State), *m.State, "State")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. Unset optional fields of the desired state match any actual value.
+func (m MilestoneInfo) Equals(actual InfoRequest) bool {
+	if a, ok := actual.(MilestoneInfo); ok {
+		if m.Description == nil {
+			m.Description = a.Description
+		}
+		if m.DueDate == nil {
+			m.DueDate = a.DueDate
+		}
+	}
+	return reflect.DeepEqual(m, actual)
+}
+
+// LabelInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = LabelInfo{}
+var _ DefaultedInfoRequest = &LabelInfo{}
+
+// LabelInfo contains high-level information about an issue label.
+type LabelInfo struct {
+	// Name is the name of the label, which is unique within the repository.
+	// +required
+	Name string `json:"name"`
+
+	// Color is the color of the label, as six hexadecimal digits, e.g. "d73a4a". A leading "#"
+	// is accepted, and removed by Default().
+	// +required
+	Color string `json:"color"`
+
+	// Description describes the label.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// Default defaults the Label fields.
+func (l *LabelInfo) Default() {
+	l.Color = strings.ToLower(strings.TrimPrefix(l.Color, "#"))
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (l LabelInfo) ValidateInfo() error {
+	validator := validation.New("Label")
+	// Make sure we've set the name of the label
+	if len(l.Name) == 0 {
+		validator.Required("Name")
+	}
+	// Make sure the color is given as six hexadecimal digits
+	color := strings.TrimPrefix(l.Color, "#")
+	if len(l.Color) == 0 {
+		validator.Required("Color")
+	} else if _, err := hex.DecodeString(color); err != nil || len(color) != 6 {
+		validator.Invalid(l.Color, "Color")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (l LabelInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(l, actual)
+}
+
+// MilestoneDueDate returns a pointer to midnight UTC of the day of t, as stored in
+// MilestoneInfo.DueDate. The day is taken in the location of t, so that e.g. midnight in a
+// timezone ahead of UTC stays on the same day.
+func MilestoneDueDate(t time.Time) *time.Time {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
 // CommitInfo contains high-level information about a deploy key.
 type CommitInfo struct {
 	// Sha is the git sha for this commit.
@@ -241,6 +579,213 @@ type CommitInfo struct {
 
 	// URL is the link for the commit
 	URL string `json:"url"`
+
+	// Parents are the SHAs of the parents of the commit, the first parent first.
+	// A merge commit has several parents, and a root commit none.
+	Parents []string `json:"parents,omitempty"`
+}
+
+// PipelineInfo contains high-level information about a pipeline.
+type PipelineInfo struct {
+	// ID is the identifier of the pipeline.
+	ID int `json:"id"`
+
+	// Ref is the branch or tag the pipeline runs for.
+	Ref string `json:"ref"`
+
+	// Sha is the commit the pipeline runs for.
+	Sha string `json:"sha"`
+
+	// Status is the status of the pipeline as reported by the provider, e.g. "running" or "success".
+	Status string `json:"status"`
+
+	// URL is the link for the pipeline.
+	URL string `json:"url"`
+
+	// CreatedAt is the time the pipeline was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkflowRunInfo contains high-level information about a workflow run.
+type WorkflowRunInfo struct {
+	// ID is the identifier of the workflow run.
+	ID int64 `json:"id"`
+
+	// Ref is the branch or tag the workflow runs for.
+	Ref string `json:"ref"`
+
+	// Sha is the commit the workflow runs for.
+	Sha string `json:"sha"`
+
+	// Event is the event which triggered the run, e.g. "workflow_dispatch" or "push".
+	Event string `json:"event"`
+
+	// Status is the status of the run as reported by the provider, e.g. "queued" or "completed".
+	Status string `json:"status"`
+
+	// Conclusion is the outcome of a completed run as reported by the provider, e.g. "success".
+	// It is empty until the run completes.
+	Conclusion string `json:"conclusion"`
+
+	// URL is the link for the workflow run.
+	URL string `json:"url"`
+
+	// CreatedAt is the time the workflow run was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TrafficInfo contains the number of clones or views of a repository over the window reported by
+// the provider, e.g. the last 14 days for GitHub.
+type TrafficInfo struct {
+	// Count is the total number of clones or views over the window.
+	Count int `json:"count"`
+
+	// Uniques is the number of unique cloners or visitors over the window.
+	Uniques int `json:"uniques"`
+
+	// Daily holds the counts of each day of the window, oldest first.
+	Daily []TrafficCountInfo `json:"daily"`
+}
+
+// TrafficCountInfo contains the number of clones or views of a repository over a period.
+type TrafficCountInfo struct {
+	// Timestamp is the start of the period.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Count is the number of clones or views over the period.
+	Count int `json:"count"`
+
+	// Uniques is the number of unique cloners or visitors over the period.
+	Uniques int `json:"uniques"`
+}
+
+// PopularPathInfo contains the number of views of a path of a repository.
+type PopularPathInfo struct {
+	// Path is the path that was viewed, e.g. "/fluxcd/flux2/releases".
+	Path string `json:"path"`
+
+	// Title is the title of the viewed page.
+	Title string `json:"title"`
+
+	// Count is the number of views of the path.
+	Count int `json:"count"`
+
+	// Uniques is the number of unique visitors of the path.
+	Uniques int `json:"uniques"`
+}
+
+// SecretMetadataInfo describes a secret without its value, which providers never return.
+type SecretMetadataInfo struct {
+	// Name is the name of the secret.
+	Name string `json:"name"`
+
+	// UpdatedAt is when the value of the secret was last set.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Annotation is a message attached to a range of lines of a file by a check run on a commit.
+type Annotation struct {
+	// CheckName is the name of the check which reported the annotation.
+	CheckName string `json:"check_name"`
+
+	// Path is the path of the annotated file, relative to the root of the repository.
+	Path string `json:"path"`
+
+	// StartLine is the first annotated line.
+	StartLine int `json:"start_line"`
+
+	// EndLine is the last annotated line, which equals StartLine for single line annotations.
+	EndLine int `json:"end_line"`
+
+	// Level is the severity of the annotation.
+	Level AnnotationLevel `json:"level"`
+
+	// Title is the optional title of the annotation.
+	Title string `json:"title,omitempty"`
+
+	// Message is the message of the annotation.
+	Message string `json:"message"`
+}
+
+// CollaboratorInfo contains high-level information about a user's access to a repository.
+type CollaboratorInfo struct {
+	// Login is the login of the user.
+	Login string `json:"login"`
+
+	// Permission is the permission level of the user on the repository. When several grants
+	// apply to the user, this is the highest of them.
+	Permission RepositoryPermission `json:"permission"`
+}
+
+// TagInfo contains high-level information about a tag.
+type TagInfo struct {
+	// Name is the name of the tag, e.g. "v1.0.0".
+	Name string `json:"name"`
+
+	// SHA is the git sha of the commit the tag points to. For annotated tags, this is the
+	// commit the tag object refers to, not the sha of the tag object itself.
+	SHA string `json:"sha"`
+
+	// Annotated is true if the tag is an annotated tag, and false for lightweight tags.
+	Annotated bool `json:"annotated"`
+
+	// Message is the message of an annotated tag. It's empty for lightweight tags, and for
+	// providers not exposing it.
+	Message string `json:"message,omitempty"`
+
+	// Tagger is the name of the person who created an annotated tag. It's empty for
+	// lightweight tags, and for providers not exposing it.
+	Tagger string `json:"tagger,omitempty"`
+
+	// CreatedAt is the time the tag was created for annotated tags, and the time the commit
+	// was created for lightweight tags.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReleaseInfo implements InfoRequest.
+var _ InfoRequest = ReleaseInfo{}
+
+// ReleaseInfo contains high-level information about a release of a repository.
+type ReleaseInfo struct {
+	// TagName is the name of the tag the release is made of, e.g. "v1.0.0".
+	// +required
+	TagName string `json:"tagName"`
+
+	// Name is the title of the release.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Body is the description of the release, e.g. its changelog.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Draft is true if the release is unpublished.
+	// +optional
+	Draft bool `json:"draft,omitempty"`
+
+	// Prerelease is true if the release isn't ready for production.
+	// +optional
+	Prerelease bool `json:"prerelease,omitempty"`
+
+	// TargetCommitish is the branch or commit SHA the tag is created from when it doesn't exist
+	// yet. It's only used when creating the release.
+	// +optional
+	TargetCommitish string `json:"targetCommitish,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (r ReleaseInfo) ValidateInfo() error {
+	validator := validation.New("Release")
+	if len(r.TagName) == 0 {
+		validator.Required("TagName")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (r ReleaseInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(r, actual)
 }
 
 // CommitFile contains high-level information about a file added to a commit.
@@ -249,9 +794,26 @@ type CommitFile struct {
 	// +required
 	Path *string `json:"path"`
 
-	// Content is the content of the file.
+	// Content is the content of the file. It must be nil when deleting the file.
 	// +required
 	Content *string `json:"content"`
+
+	// Action is what the commit does with the file. When empty, the file is written if Content
+	// is set, and deleted otherwise.
+	// +optional
+	Action CommitFileAction `json:"action,omitempty"`
+}
+
+// GetAction returns what the commit does with the file, inferring it from Content if Action is
+// empty.
+func (f CommitFile) GetAction() CommitFileAction {
+	if f.Action != "" {
+		return f.Action
+	}
+	if f.Content == nil {
+		return CommitFileActionDelete
+	}
+	return CommitFileActionWrite
 }
 
 // PullRequestInfo contains high-level information about a pull request.
@@ -274,6 +836,681 @@ type PullRequestInfo struct {
 
 	// SourceBranch is the branch from which the pull request has been created.
 	SourceBranch string `json:"source_branch"`
+
+	// Labels are the names of the labels of the pull request.
+	Labels []string `json:"labels,omitempty"`
+}
+
+// MergeableInfo contains information about whether a pull request can be merged, and why not.
+type MergeableInfo struct {
+	// Mergeable specifies whether the pull request can be merged. It is nil while the provider
+	// is still computing it.
+	Mergeable *bool `json:"mergeable"`
+
+	// Conflicts specifies whether the pull request conflicts with its base branch.
+	Conflicts bool `json:"conflicts"`
+
+	// FailingChecks lists the names of the required checks that failed for the pull request.
+	FailingChecks []string `json:"failing_checks,omitempty"`
+}
+
+// RebasePreview contains information about whether rebasing a pull request onto its base branch
+// would conflict.
+type RebasePreview struct {
+	// Conflicts specifies whether rebasing the pull request would conflict.
+	Conflicts bool `json:"conflicts"`
+
+	// ConflictingFiles lists the paths of the files which may conflict. It is empty if there are
+	// no conflicts, or if the provider doesn't report them.
+	ConflictingFiles []string `json:"conflicting_files,omitempty"`
+}
+
+// ReviewInfo contains a review of a pull request, as input to SummarizeReviews.
+type ReviewInfo struct {
+	// Reviewer is the login of the user who submitted the review.
+	Reviewer string `json:"reviewer"`
+
+	// State is the state of the review.
+	State ReviewState `json:"state"`
+}
+
+// ReviewSummary contains the current verdicts of the reviewers of a pull request.
+type ReviewSummary struct {
+	// Approvals is the number of reviewers currently approving the pull request.
+	Approvals int `json:"approvals"`
+
+	// ChangesRequested is the number of reviewers currently requesting changes to the pull request.
+	ChangesRequested int `json:"changes_requested"`
+
+	// Approvers lists the sorted logins of the reviewers currently approving the pull request.
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+// PullRequestComment is a comment on the conversation of a pull request.
+type PullRequestComment struct {
+	// ID is the provider-specific identifier of the comment.
+	ID int64 `json:"id"`
+
+	// Author is the login of the user who posted the comment.
+	Author string `json:"author"`
+
+	// Body is the text of the comment.
+	Body string `json:"body"`
+
+	// CreatedAt is the time the comment was posted.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LicenseInfo contains high-level information about the license detected in a repository.
+// This reports what is actually in the repository, as opposed to the LicenseTemplate used at
+// creation time.
+type LicenseInfo struct {
+	// SPDXID is the SPDX identifier of the detected license, e.g. "Apache-2.0".
+	// It is empty if the provider doesn't report an SPDX identifier.
+	SPDXID string `json:"spdx_id"`
+
+	// Name is the human-friendly name of the detected license.
+	Name string `json:"name"`
+
+	// Path is the path of the license file in the repository, e.g. "LICENSE".
+	Path string `json:"path"`
+}
+
+// RepoCounts contains the number of open pull requests, open issues, branches and tags of a
+// repository. The counts are best-effort, as they are read from the cheapest endpoints the
+// provider offers rather than by listing all items.
+type RepoCounts struct {
+	// OpenPullRequests is the number of open pull requests.
+	OpenPullRequests ItemCount `json:"openPullRequests"`
+
+	// OpenIssues is the number of open issues.
+	OpenIssues ItemCount `json:"openIssues"`
+
+	// Branches is the number of branches.
+	Branches ItemCount `json:"branches"`
+
+	// Tags is the number of tags.
+	Tags ItemCount `json:"tags"`
+}
+
+// ItemCount is a best-effort number of items.
+type ItemCount struct {
+	// Count is the number of items. It is nil if the provider can't report it cheaply.
+	Count *int `json:"count"`
+
+	// Exact specifies whether Count is exact. An approximate count is e.g. derived from another
+	// count, or capped by the provider for large lists.
+	Exact bool `json:"exact"`
+}
+
+// WeeklyActivity contains the number of commits made to the default branch of a repository
+// during a week.
+type WeeklyActivity struct {
+	// Week is the start of the week, on Sunday at midnight UTC.
+	Week time.Time `json:"week"`
+
+	// Total is the number of commits made during the week.
+	Total int `json:"total"`
+
+	// Days are the number of commits made on each day of the week, starting on Sunday.
+	Days [7]int `json:"days"`
+}
+
+// TemplatesInfo implements InfoRequest.
+var _ InfoRequest = TemplatesInfo{}
+
+// TemplatesInfo contains the pull request and issue templates of a repository.
+type TemplatesInfo struct {
+	// PullRequest is the content of the default pull request template.
+	// +optional
+	PullRequest *string `json:"pullRequest,omitempty"`
+
+	// Issues maps issue template file names (e.g. "bug_report.md") to their content.
+	// +optional
+	Issues map[string]string `json:"issues,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (t TemplatesInfo) ValidateInfo() error {
+	validator := validation.New("Templates")
+	// At least one template must be given
+	if t.PullRequest == nil && len(t.Issues) == 0 {
+		validator.Required("PullRequest")
+	}
+	// Issue template names must be plain file names
+	for name := range t.Issues {
+		if len(name) == 0 || strings.Contains(name, "/") {
+			validator.Invalid(name, "Issues")
+		}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (t TemplatesInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(t, actual)
+}
+
+// TemplateLocations contains the paths of the pull request and issue templates found in a
+// repository, relative to its root and sorted.
+type TemplateLocations struct {
+	// PullRequestTemplates are the paths of the pull request templates.
+	PullRequestTemplates []string `json:"pullRequestTemplates"`
+
+	// IssueTemplates are the paths of the issue templates.
+	IssueTemplates []string `json:"issueTemplates"`
+}
+
+// SubscriptionInfo implements InfoRequest.
+var _ InfoRequest = SubscriptionInfo{}
+
+// SubscriptionInfo describes the notification subscription of the authenticated user to a
+// repository. Leaving both fields false resets the subscription to the user's defaults.
+type SubscriptionInfo struct {
+	// Subscribed determines if notifications are received for all activity in the repository,
+	// i.e. the repository is watched.
+	// +optional
+	Subscribed bool `json:"subscribed"`
+
+	// Ignored determines if all notifications from the repository are blocked.
+	// +optional
+	Ignored bool `json:"ignored"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (s SubscriptionInfo) ValidateInfo() error {
+	validator := validation.New("Subscription")
+	// A repository can't be both watched and ignored
+	if s.Subscribed && s.Ignored {
+		validator.Invalid(s.Ignored, "Ignored")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (s SubscriptionInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(s, actual)
+}
+
+// MergeQueueInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = MergeQueueInfo{}
+var _ DefaultedInfoRequest = &MergeQueueInfo{}
+
+// MergeQueueInfo contains high-level information about the merge queue of a protected branch.
+type MergeQueueInfo struct {
+	// Enabled determines if pull requests targeting the branch are merged through a merge queue.
+	// When false, the other fields are ignored.
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// MergeMethod is the method used to merge the pull requests in the queue.
+	// Default value at POST-time: MergeMethodMerge.
+	// +optional
+	MergeMethod *MergeMethod `json:"mergeMethod,omitempty"`
+
+	// MaxEntries is the maximum number of pull requests merged together in a single group.
+	// Default value at POST-time: 5.
+	// +optional
+	MaxEntries *int `json:"maxEntries,omitempty"`
+
+	// MinWait is how long the queue waits for more pull requests to merge together, before
+	// merging the pull requests already in the queue. It must be a whole number of minutes.
+	// Default value at POST-time: 5 minutes.
+	// +optional
+	MinWait *time.Duration `json:"minWait,omitempty"`
+
+	// MaxWait is how long the queue waits for the required status checks to report, before
+	// removing a pull request from the queue. It must be a whole, positive number of minutes.
+	// Default value at POST-time: 1 hour.
+	// +optional
+	MaxWait *time.Duration `json:"maxWait,omitempty"`
+}
+
+// Default defaults the MergeQueue fields.
+func (mq *MergeQueueInfo) Default() {
+	if mq.MergeMethod == nil {
+		mq.MergeMethod = MergeMethodVar(defaultMergeQueueMergeMethod)
+	}
+	if mq.MaxEntries == nil {
+		maxEntries := defaultMergeQueueMaxEntries
+		mq.MaxEntries = &maxEntries
+	}
+	if mq.MinWait == nil {
+		minWait := defaultMergeQueueMinWait
+		mq.MinWait = &minWait
+	}
+	if mq.MaxWait == nil {
+		maxWait := defaultMergeQueueMaxWait
+		mq.MaxWait = &maxWait
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (mq MergeQueueInfo) ValidateInfo() error {
+	validator := validation.New("MergeQueue")
+	if mq.MergeMethod != nil {
+		validator.Append(ValidateMergeMethod(*mq.MergeMethod), *mq.MergeMethod, "MergeMethod")
+	}
+	if mq.MaxEntries != nil && *mq.MaxEntries < 1 {
+		validator.Invalid(*mq.MaxEntries, "MaxEntries")
+	}
+	// Providers configure the waiting times in minutes
+	if mq.MinWait != nil && (*mq.MinWait < 0 || *mq.MinWait%time.Minute != 0) {
+		validator.Invalid(*mq.MinWait, "MinWait")
+	}
+	if mq.MaxWait != nil && (*mq.MaxWait < time.Minute || *mq.MaxWait%time.Minute != 0) {
+		validator.Invalid(*mq.MaxWait, "MaxWait")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (mq MergeQueueInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(mq, actual)
+}
+
+// BranchMergeMethodsInfo implements InfoRequest.
+var _ InfoRequest = BranchMergeMethodsInfo{}
+
+// BranchMergeMethodsInfo contains high-level information about the merge methods allowed for the
+// pull requests targeting a specific branch.
+type BranchMergeMethodsInfo struct {
+	// AllowedMethods are the merge methods allowed for the pull requests targeting the branch,
+	// e.g. only MergeMethodSquash. When empty, the branch has no merge methods of its own, and the
+	// repository-wide settings apply.
+	// +optional
+	AllowedMethods []MergeMethod `json:"allowedMethods,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (bm BranchMergeMethodsInfo) ValidateInfo() error {
+	validator := validation.New("BranchMergeMethods")
+	for _, method := range bm.AllowedMethods {
+		validator.Append(ValidateMergeMethod(method), method, "AllowedMethods")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The order of the allowed methods doesn't matter.
+func (bm BranchMergeMethodsInfo) Equals(actual InfoRequest) bool {
+	a, ok := actual.(BranchMergeMethodsInfo)
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(sortedMergeMethods(bm.AllowedMethods), sortedMergeMethods(a.AllowedMethods))
+}
+
+// sortedMergeMethods returns a sorted copy of methods, treating nil as empty.
+func sortedMergeMethods(methods []MergeMethod) []MergeMethod {
+	sorted := append([]MergeMethod{}, methods...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// MergeMethodsInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = MergeMethodsInfo{}
+var _ DefaultedInfoRequest = &MergeMethodsInfo{}
+
+// MergeMethodsInfo contains high-level information about the merge methods allowed for all the
+// pull requests of a repository.
+type MergeMethodsInfo struct {
+	// AllowedMethods are the merge methods allowed for the pull requests of the repository.
+	// +required
+	AllowedMethods []MergeMethod `json:"allowedMethods"`
+
+	// DefaultMethod is the merge method preselected when merging a pull request. It must be one
+	// of AllowedMethods.
+	// Default value at POST-time: the first of AllowedMethods.
+	// +optional
+	DefaultMethod *MergeMethod `json:"defaultMethod,omitempty"`
+}
+
+// Default defaults the MergeMethods fields.
+func (mm *MergeMethodsInfo) Default() {
+	if mm.DefaultMethod == nil && len(mm.AllowedMethods) != 0 {
+		mm.DefaultMethod = MergeMethodVar(mm.AllowedMethods[0])
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (mm MergeMethodsInfo) ValidateInfo() error {
+	validator := validation.New("MergeMethods")
+	if len(mm.AllowedMethods) == 0 {
+		validator.Required("AllowedMethods")
+	}
+	allowed := false
+	for _, method := range mm.AllowedMethods {
+		validator.Append(ValidateMergeMethod(method), method, "AllowedMethods")
+		allowed = allowed || (mm.DefaultMethod != nil && method == *mm.DefaultMethod)
+	}
+	if mm.DefaultMethod != nil && !allowed {
+		validator.Invalid(*mm.DefaultMethod, "DefaultMethod")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The order of the allowed methods doesn't matter.
+func (mm MergeMethodsInfo) Equals(actual InfoRequest) bool {
+	a, ok := actual.(MergeMethodsInfo)
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(sortedMergeMethods(mm.AllowedMethods), sortedMergeMethods(a.AllowedMethods)) &&
+		reflect.DeepEqual(mm.DefaultMethod, a.DefaultMethod)
+}
+
+// EnvironmentProtectionInfo implements InfoRequest.
+var _ InfoRequest = EnvironmentProtectionInfo{}
+
+// EnvironmentProtectionInfo contains high-level information about the refs allowed to deploy to a
+// deployment environment, and the custom rules protecting it.
+type EnvironmentProtectionInfo struct {
+	// BranchPatterns are the name patterns of the branches allowed to deploy to the environment,
+	// e.g. "main" or "release/*".
+	// +optional
+	BranchPatterns []string `json:"branchPatterns,omitempty"`
+
+	// TagPatterns are the name patterns of the tags allowed to deploy to the environment, e.g. "v*".
+	// +optional
+	TagPatterns []string `json:"tagPatterns,omitempty"`
+
+	// CustomRuleAppIDs are the IDs of the apps deciding whether deployments to the environment
+	// may proceed, through custom protection rules.
+	// +optional
+	CustomRuleAppIDs []int64 `json:"customRuleAppIDs,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (ep EnvironmentProtectionInfo) ValidateInfo() error {
+	validator := validation.New("EnvironmentProtection")
+	for _, pattern := range ep.BranchPatterns {
+		if pattern == "" {
+			validator.Invalid(pattern, "BranchPatterns")
+		}
+	}
+	for _, pattern := range ep.TagPatterns {
+		if pattern == "" {
+			validator.Invalid(pattern, "TagPatterns")
+		}
+	}
+	for _, id := range ep.CustomRuleAppIDs {
+		if id < 1 {
+			validator.Invalid(id, "CustomRuleAppIDs")
+		}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The order of the patterns and apps doesn't matter.
+func (ep EnvironmentProtectionInfo) Equals(actual InfoRequest) bool {
+	a, ok := actual.(EnvironmentProtectionInfo)
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(sortedStrings(ep.BranchPatterns), sortedStrings(a.BranchPatterns)) &&
+		reflect.DeepEqual(sortedStrings(ep.TagPatterns), sortedStrings(a.TagPatterns)) &&
+		reflect.DeepEqual(sortedIDs(ep.CustomRuleAppIDs), sortedIDs(a.CustomRuleAppIDs))
+}
+
+// sortedStrings returns a sorted copy of s, treating nil as empty.
+func sortedStrings(s []string) []string {
+	sorted := append([]string{}, s...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// sortedIDs returns a sorted copy of ids, treating nil as empty.
+func sortedIDs(ids []int64) []int64 {
+	sorted := append([]int64{}, ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// WorkflowPermissionsInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = WorkflowPermissionsInfo{}
+var _ DefaultedInfoRequest = &WorkflowPermissionsInfo{}
+
+// WorkflowPermissionsInfo contains high-level information about the default permissions of the
+// token handed to the CI workflows of a repository.
+type WorkflowPermissionsInfo struct {
+	// DefaultPermission is the permission granted to the workflow token, unless a workflow
+	// asks for other permissions explicitly.
+	// Default value at POST-time: WorkflowPermissionRead.
+	// +optional
+	DefaultPermission *WorkflowPermission `json:"defaultPermission,omitempty"`
+
+	// CanApprovePullRequestReviews determines if workflows may approve pull requests.
+	// Default value at POST-time: false.
+	// +optional
+	CanApprovePullRequestReviews *bool `json:"canApprovePullRequestReviews,omitempty"`
+}
+
+// Default defaults the WorkflowPermissions fields, to the least privileged configuration.
+func (wp *WorkflowPermissionsInfo) Default() {
+	if wp.DefaultPermission == nil {
+		wp.DefaultPermission = WorkflowPermissionVar(defaultWorkflowPermission)
+	}
+	if wp.CanApprovePullRequestReviews == nil {
+		wp.CanApprovePullRequestReviews = BoolVar(false)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (wp WorkflowPermissionsInfo) ValidateInfo() error {
+	validator := validation.New("WorkflowPermissions")
+	if wp.DefaultPermission != nil {
+		validator.Append(ValidateWorkflowPermission(*wp.DefaultPermission), *wp.DefaultPermission, "DefaultPermission")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (wp WorkflowPermissionsInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(wp, actual)
+}
+
+// CodeScanningDefaultSetupInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = CodeScanningDefaultSetupInfo{}
+var _ DefaultedInfoRequest = &CodeScanningDefaultSetupInfo{}
+
+// CodeScanningDefaultSetupInfo contains high-level information about the code scanning default
+// setup of a repository, which analyzes the code without a workflow committed to the repository.
+type CodeScanningDefaultSetupInfo struct {
+	// Enabled determines if the default setup is configured.
+	// When false, the other fields are ignored.
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// Languages are the languages analyzed, e.g. "go" or "javascript-typescript". The order
+	// doesn't matter. When empty, the provider analyzes the languages it detects in the repository.
+	// +optional
+	Languages []string `json:"languages,omitempty"`
+
+	// QuerySuite is the set of queries run on the code.
+	// Default value at POST-time: CodeScanningQuerySuiteDefault.
+	// +optional
+	QuerySuite *CodeScanningQuerySuite `json:"querySuite,omitempty"`
+}
+
+// Default defaults the CodeScanningDefaultSetup fields.
+func (cs *CodeScanningDefaultSetupInfo) Default() {
+	if cs.QuerySuite == nil {
+		cs.QuerySuite = CodeScanningQuerySuiteVar(defaultCodeScanningQuerySuite)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (cs CodeScanningDefaultSetupInfo) ValidateInfo() error {
+	validator := validation.New("CodeScanningDefaultSetup")
+	if cs.QuerySuite != nil {
+		validator.Append(ValidateCodeScanningQuerySuite(*cs.QuerySuite), *cs.QuerySuite, "QuerySuite")
+	}
+	for _, language := range cs.Languages {
+		if language == "" {
+			validator.Invalid(language, "Languages")
+		}
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument. The other fields are only compared when the default setup is
+// enabled, and the languages only when they're given in the desired state.
+func (cs CodeScanningDefaultSetupInfo) Equals(actual InfoRequest) bool {
+	a, ok := actual.(CodeScanningDefaultSetupInfo)
+	if !ok {
+		return false
+	}
+	if !cs.Enabled || !a.Enabled {
+		return cs.Enabled == a.Enabled
+	}
+	if len(cs.Languages) != 0 && !reflect.DeepEqual(sortedStrings(cs.Languages), sortedStrings(a.Languages)) {
+		return false
+	}
+	return reflect.DeepEqual(cs.QuerySuite, a.QuerySuite)
+}
+
+// InteractionLimitsInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = InteractionLimitsInfo{}
+var _ DefaultedInfoRequest = &InteractionLimitsInfo{}
+
+// InteractionLimitsInfo contains high-level information about the temporary limits on which users
+// may interact with a repository.
+type InteractionLimitsInfo struct {
+	// Limit specifies which users may still interact with the repository.
+	// +required
+	Limit InteractionLimit `json:"limit"`
+
+	// Expiry specifies how long the limit applies from the time it's set.
+	// This field is write-only, the time the limit is lifted is set in ExpiresAt.
+	// Default value at POST-time: InteractionLimitExpiryOneDay.
+	// +optional
+	Expiry *InteractionLimitExpiry `json:"expiry,omitempty"`
+
+	// ExpiresAt is the time the limit is lifted.
+	// This field is read-only and set by the provider.
+	// +optional
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// Default defaults the InteractionLimits fields.
+func (il *InteractionLimitsInfo) Default() {
+	if il.Expiry == nil {
+		il.Expiry = InteractionLimitExpiryVar(defaultInteractionLimitExpiry)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (il InteractionLimitsInfo) ValidateInfo() error {
+	validator := validation.New("InteractionLimits")
+	if len(il.Limit) == 0 {
+		validator.Required("Limit")
+	} else {
+		validator.Append(ValidateInteractionLimit(il.Limit), il.Limit, "Limit")
+	}
+	if il.Expiry != nil {
+		validator.Append(ValidateInteractionLimitExpiry(*il.Expiry), *il.Expiry, "Expiry")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (il InteractionLimitsInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(il, actual)
+}
+
+// ApprovalSettingsInfo implements InfoRequest.
+var _ InfoRequest = ApprovalSettingsInfo{}
+
+// ApprovalSettingsInfo contains the repository-wide settings governing the approval of pull
+// requests. Unset fields are left as-is when reconciling.
+type ApprovalSettingsInfo struct {
+	// ResetApprovalsOnPush removes the approvals of a pull request when commits are pushed to it.
+	// +optional
+	ResetApprovalsOnPush *bool `json:"resetApprovalsOnPush,omitempty"`
+
+	// PreventAuthorApproval prevents the author of a pull request from approving it.
+	// +optional
+	PreventAuthorApproval *bool `json:"preventAuthorApproval,omitempty"`
+
+	// PreventCommitterApproval prevents the users who pushed commits to a pull request from
+	// approving it.
+	// +optional
+	PreventCommitterApproval *bool `json:"preventCommitterApproval,omitempty"`
+
+	// RequirePasswordToApprove requires users to enter their password when approving a pull request.
+	// +optional
+	RequirePasswordToApprove *bool `json:"requirePasswordToApprove,omitempty"`
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (as ApprovalSettingsInfo) ValidateInfo() error {
+	return nil
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (as ApprovalSettingsInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(as, actual)
+}
+
+// PagesInfo implements InfoRequest and DefaultedInfoRequest (with a pointer receiver).
+var _ InfoRequest = PagesInfo{}
+var _ DefaultedInfoRequest = &PagesInfo{}
+
+// PagesInfo contains high-level information about the static website published from a repository.
+type PagesInfo struct {
+	// SourceBranch is the branch the website is published from.
+	// +required
+	SourceBranch string `json:"sourceBranch"`
+
+	// SourcePath is the directory of SourceBranch the website is published from, e.g. "/docs".
+	// Default value at POST-time: "/".
+	// +optional
+	SourcePath *string `json:"sourcePath,omitempty"`
+
+	// CustomDomain is the custom domain the website is served at. When reconciling, an empty
+	// string removes the custom domain, while leaving it unset keeps the actual one.
+	// +optional
+	CustomDomain *string `json:"customDomain,omitempty"`
+
+	// URL is the address the website is served at.
+	// This field is read-only and set by the provider.
+	// +optional
+	URL *string `json:"url,omitempty"`
+}
+
+// Default defaults the Pages fields.
+func (p *PagesInfo) Default() {
+	if p.SourcePath == nil {
+		p.SourcePath = StringVar(defaultPagesSourcePath)
+	}
+}
+
+// ValidateInfo validates the object at {Object}.Set() and POST-time.
+func (p PagesInfo) ValidateInfo() error {
+	validator := validation.New("Pages")
+	if p.SourceBranch == "" {
+		validator.Required("SourceBranch")
+	}
+	if p.SourcePath != nil && !strings.HasPrefix(*p.SourcePath, "/") {
+		validator.Invalid(*p.SourcePath, "SourcePath")
+	}
+	return validator.Error()
+}
+
+// Equals can be used to check if this *Info request (the desired state) matches the actual
+// passed in as the argument.
+func (p PagesInfo) Equals(actual InfoRequest) bool {
+	return reflect.DeepEqual(p, actual)
 }
 
 // TreeEntry contains info about each tree object's structure in TreeInfo whether it is a file or tree
@@ -19,6 +19,7 @@ package gitprovider
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/validation"
 )
@@ -104,6 +105,302 @@ func TestDeployToken_Validate(t *testing.T) {
 	}
 }
 
+func TestProtectedTag_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		tag          ProtectedTagInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid create",
+			tag: ProtectedTagInfo{
+				Name: "v*",
+			},
+		},
+		{
+			name: "valid create, no one can create",
+			tag: ProtectedTagInfo{
+				Name:              "v*",
+				CreateAccessLevel: PermissionLevelVar(PermissionLevelNone),
+			},
+		},
+		{
+			name:         "invalid create, missing name",
+			tag:          ProtectedTagInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name: "invalid create, read access can't create tags",
+			tag: ProtectedTagInfo{
+				Name:              "v*",
+				CreateAccessLevel: PermissionLevelVar(PermissionLevelRead),
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name: "invalid create, unknown access level",
+			tag: ProtectedTagInfo{
+				Name:              "v*",
+				CreateAccessLevel: PermissionLevelVar("owner"),
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "ProtectedTag", tt.tag.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestMilestone_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		milestone    MilestoneInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid create",
+			milestone: MilestoneInfo{
+				Title: "v1.0",
+			},
+		},
+		{
+			name: "valid create, with all fields populated",
+			milestone: MilestoneInfo{
+				Title:       "v1.0",
+				Description: StringVar("First stable release"),
+				DueDate:     MilestoneDueDate(time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)),
+				State:       MilestoneStateVar(MilestoneStateClosed),
+			},
+		},
+		{
+			name:         "invalid create, missing title",
+			milestone:    MilestoneInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name: "invalid create, zero due date",
+			milestone: MilestoneInfo{
+				Title:   "v1.0",
+				DueDate: &time.Time{},
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name: "invalid create, unknown state",
+			milestone: MilestoneInfo{
+				Title: "v1.0",
+				State: MilestoneStateVar("active"),
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "Milestone", tt.milestone.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestMilestoneDueDate(t *testing.T) {
+	want := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	for _, loc := range []*time.Location{time.UTC, time.FixedZone("UTC+9", 9*3600), time.FixedZone("UTC-7", -7*3600)} {
+		t.Run(loc.String(), func(t *testing.T) {
+			for _, hour := range []int{0, 12, 23} {
+				if got := MilestoneDueDate(time.Date(2023, time.April, 1, hour, 30, 0, 0, loc)); !got.Equal(want) {
+					t.Errorf("MilestoneDueDate() at %02d:30 = %v, want %v", hour, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeQueue_Validate(t *testing.T) {
+	minute, halfMinute, negative := time.Minute, 30*time.Second, -time.Minute
+	zero, ten := 0, 10
+	tests := []struct {
+		name         string
+		mergeQueue   MergeQueueInfo
+		expectedErrs []error
+	}{
+		{
+			name:       "valid, disabled",
+			mergeQueue: MergeQueueInfo{},
+		},
+		{
+			name: "valid, with all fields populated",
+			mergeQueue: MergeQueueInfo{
+				Enabled:     true,
+				MergeMethod: MergeMethodVar(MergeMethodSquash),
+				MaxEntries:  &ten,
+				MinWait:     &minute,
+				MaxWait:     &minute,
+			},
+		},
+		{
+			name: "invalid, unknown merge method",
+			mergeQueue: MergeQueueInfo{
+				Enabled:     true,
+				MergeMethod: MergeMethodVar("fast-forward"),
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+		{
+			name: "invalid, no entries",
+			mergeQueue: MergeQueueInfo{
+				Enabled:    true,
+				MaxEntries: &zero,
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name: "invalid, partial minute",
+			mergeQueue: MergeQueueInfo{
+				Enabled: true,
+				MinWait: &halfMinute,
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name: "invalid, negative max wait",
+			mergeQueue: MergeQueueInfo{
+				Enabled: true,
+				MaxWait: &negative,
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "MergeQueue", tt.mergeQueue.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestMergeMethods_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		mergeMethods MergeMethodsInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid, with a default method",
+			mergeMethods: MergeMethodsInfo{
+				AllowedMethods: []MergeMethod{MergeMethodMerge, MergeMethodSquash},
+				DefaultMethod:  MergeMethodVar(MergeMethodSquash),
+			},
+		},
+		{
+			name:         "invalid, no allowed methods",
+			mergeMethods: MergeMethodsInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name: "invalid, unknown merge method",
+			mergeMethods: MergeMethodsInfo{
+				AllowedMethods: []MergeMethod{"fast-forward"},
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+		{
+			name: "invalid, default method not allowed",
+			mergeMethods: MergeMethodsInfo{
+				AllowedMethods: []MergeMethod{MergeMethodMerge},
+				DefaultMethod:  MergeMethodVar(MergeMethodSquash),
+			},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "MergeMethods", tt.mergeMethods.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestOrganizationWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		webhook      OrganizationWebhookInfo
+		expectedErrs []error
+	}{
+		{
+			name: "valid, with all fields populated",
+			webhook: OrganizationWebhookInfo{
+				URL:                "https://audit.example.com/hook",
+				Events:             []WebhookEvent{WebhookEventPush, WebhookEventRepository},
+				Secret:             StringVar("s3cr3t"),
+				InsecureSkipVerify: BoolVar(true),
+			},
+		},
+		{
+			name:         "invalid, no URL",
+			webhook:      OrganizationWebhookInfo{},
+			expectedErrs: []error{validation.ErrFieldRequired},
+		},
+		{
+			name:         "invalid, relative URL",
+			webhook:      OrganizationWebhookInfo{URL: "/hook"},
+			expectedErrs: []error{validation.ErrFieldInvalid},
+		},
+		{
+			name: "invalid, unknown event",
+			webhook: OrganizationWebhookInfo{
+				URL:    "https://audit.example.com/hook",
+				Events: []WebhookEvent{"star"},
+			},
+			expectedErrs: []error{validation.ErrFieldEnumInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidation(t, "OrganizationWebhook", tt.webhook.ValidateInfo, tt.expectedErrs)
+		})
+	}
+}
+
+func TestOrganizationWebhook_Equals(t *testing.T) {
+	desired := OrganizationWebhookInfo{
+		URL:    "https://audit.example.com/hook",
+		Events: []WebhookEvent{WebhookEventPush, WebhookEventRelease},
+	}
+	actual := OrganizationWebhookInfo{
+		URL:    "https://audit.example.com/hook",
+		Events: []WebhookEvent{WebhookEventRelease, WebhookEventPush},
+	}
+	if !desired.Equals(actual) {
+		t.Error("webhooks differing in the order of the events should be equal")
+	}
+	desired.Secret = StringVar("r0tated")
+	if desired.Equals(actual) {
+		t.Error("a desired secret should be a difference, as the actual one isn't known")
+	}
+	desired.Secret = nil
+	actual.Events = actual.Events[:1]
+	if desired.Equals(actual) {
+		t.Error("webhooks differing in their events shouldn't be equal")
+	}
+}
+
+func TestDeployHook_Equals(t *testing.T) {
+	desired := DeployHookInfo{
+		URL:    "https://ci.example.com/hook",
+		Events: []WebhookEvent{WebhookEventPush, WebhookEventTagPush},
+	}
+	actual := DeployHookInfo{
+		URL:    "https://ci.example.com/hook",
+		Events: []WebhookEvent{WebhookEventTagPush, WebhookEventPush},
+	}
+	if !desired.Equals(actual) {
+		t.Error("webhooks differing in the order of the events should be equal")
+	}
+	desired.Secret = StringVar("r0tated")
+	if desired.Equals(actual) {
+		t.Error("a desired secret should be a difference, as the actual one isn't known")
+	}
+}
+
 func TestRepository_Validate(t *testing.T) {
 	unknownRepositoryVisibility := RepositoryVisibility("unknown")
 	tests := []struct {
@@ -133,6 +430,63 @@ func TestRepository_Validate(t *testing.T) {
 	}
 }
 
+func TestRepository_Equals(t *testing.T) {
+	actual := RepositoryInfo{
+		Description:   StringVar("foo-description"),
+		DefaultBranch: StringVar("main"),
+		Visibility:    RepositoryVisibilityVar(RepositoryVisibilityInternal),
+	}
+	tests := []struct {
+		name    string
+		desired RepositoryInfo
+		want    bool
+	}{
+		{
+			name: "same visibility",
+			desired: RepositoryInfo{
+				Description:   StringVar("foo-description"),
+				DefaultBranch: StringVar("main"),
+				Visibility:    RepositoryVisibilityVar(RepositoryVisibilityInternal),
+			},
+			want: true,
+		},
+		{
+			name: "different visibility",
+			desired: RepositoryInfo{
+				Description:   StringVar("foo-description"),
+				DefaultBranch: StringVar("main"),
+				Visibility:    RepositoryVisibilityVar(RepositoryVisibilityPrivate),
+			},
+			want: false,
+		},
+		{
+			name: "default visibility isn't compared",
+			desired: RepositoryInfo{
+				Description:   StringVar("foo-description"),
+				DefaultBranch: StringVar("main"),
+				Visibility:    RepositoryVisibilityVar(RepositoryVisibilityDefault),
+			},
+			want: true,
+		},
+		{
+			name: "default visibility with a different description",
+			desired: RepositoryInfo{
+				Description:   StringVar("bar-description"),
+				DefaultBranch: StringVar("main"),
+				Visibility:    RepositoryVisibilityVar(RepositoryVisibilityDefault),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.desired.Equals(actual); got != tt.want {
+				t.Errorf("Equals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTeamAccess_Validate(t *testing.T) {
 	invalidPermission := RepositoryPermission("unknown")
 	tests := []struct {
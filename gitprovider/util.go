@@ -17,8 +17,18 @@ limitations under the License.
 package gitprovider
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// fullSHALength is the length of a full, hex-encoded, SHA-1 commit SHA.
+	fullSHALength = 40
+	// minShortSHALength is the minimum length of an abbreviated commit SHA, as accepted by Git.
+	minShortSHALength = 4
 )
 
 // BoolVar returns a pointer to the given bool.
@@ -26,6 +36,11 @@ func BoolVar(b bool) *bool {
 	return &b
 }
 
+// IntVar returns a pointer to the given int.
+func IntVar(i int) *int {
+	return &i
+}
+
 // StringVar returns a pointer to the given string.
 func StringVar(s string) *string {
 	return &s
@@ -39,3 +54,53 @@ func GetDomainURL(d string) string {
 	}
 	return d
 }
+
+// SortedWebhookEvents returns the sorted keys of a map from WebhookEvent to the
+// provider-specific name of the event.
+func SortedWebhookEvents(events map[WebhookEvent]string) []WebhookEvent {
+	sorted := make([]WebhookEvent, 0, len(events))
+	for e := range events {
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// ValidatePage validates the paging arguments of a ListPage call. Pages are 1-based across all
+// providers, and hold at least one item; ErrInvalidArgument is returned otherwise.
+func ValidatePage(perPage, page int) error {
+	if perPage < 1 {
+		return fmt.Errorf("perPage must be at least 1, got %d: %w", perPage, ErrInvalidArgument)
+	}
+	if page < 1 {
+		return fmt.Errorf("page must be at least 1, got %d: %w", page, ErrInvalidArgument)
+	}
+	return nil
+}
+
+// IsShortSHA returns whether s looks like an abbreviated commit SHA, i.e. is a hex string shorter
+// than a full SHA.
+func IsShortSHA(s string) bool {
+	if len(s) < minShortSHALength || len(s) >= fullSHALength {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ExpandShortSHA resolves sha to the full commit SHA using c, if it's abbreviated.
+// Other values are returned as-is, to be handled by the provider.
+func ExpandShortSHA(ctx context.Context, c CommitClient, sha string) (string, error) {
+	if !IsShortSHA(sha) {
+		return sha, nil
+	}
+	fullSHA, err := c.ResolveRef(ctx, sha)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve commit %q: %w", sha, err)
+	}
+	return fullSHA, nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/github"
+	"github.com/fluxcd/go-git-providers/gitlab"
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestSupportedWebhookEvents(t *testing.T) {
+	githubClient, err := github.NewClient()
+	if err != nil {
+		t.Fatalf("github.NewClient() error = %v", err)
+	}
+	gitlabClient, err := gitlab.NewClient("", "")
+	if err != nil {
+		t.Fatalf("gitlab.NewClient() error = %v", err)
+	}
+
+	catalogs := map[string][]gitprovider.WebhookEvent{
+		"github": githubClient.SupportedWebhookEvents(),
+		"gitlab": gitlabClient.SupportedWebhookEvents(),
+	}
+	for provider, events := range catalogs {
+		if len(events) == 0 {
+			t.Errorf("%s: SupportedWebhookEvents() is empty", provider)
+		}
+		for _, e := range events {
+			if err := gitprovider.ValidateWebhookEvent(e); err != nil {
+				t.Errorf("%s: SupportedWebhookEvents() returned unknown event %q", provider, e)
+			}
+		}
+	}
+	if cmp.Equal(catalogs["github"], catalogs["gitlab"]) {
+		t.Errorf("expected the github and gitlab catalogs to differ, both are %v", catalogs["github"])
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// WikiCloneURL returns the clone URL of the wiki of the repository with the given clone URL,
+// following the "<repository>.wiki.git" convention of GitHub, GitLab and Gitea.
+func WikiCloneURL(cloneURL string) string {
+	return strings.TrimSuffix(cloneURL, ".git") + ".wiki.git"
+}
+
+// NewGitWikiClient returns a WikiClient operating on the wiki Git repository at cloneURL.
+// Each call fetches the current state of the wiki into memory, which suits seeding and
+// updating a handful of pages.
+func NewGitWikiClient(cloneURL string, opts GitTransportOptions) (WikiClient, error) {
+	r, err := opts.newRemote(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	return &gitWikiClient{gitRemote: *r}, nil
+}
+
+// gitWikiClient implements the WikiClient interface.
+var _ WikiClient = &gitWikiClient{}
+
+// gitWikiClient operates on a wiki through the Git protocol.
+type gitWikiClient struct {
+	gitRemote
+}
+
+// Get returns the pages in the given directory of the wiki, "" being the root.
+//
+// ErrNotFound is returned if the wiki is empty, or the directory doesn't exist.
+func (c *gitWikiClient) Get(ctx context.Context, dir string) ([]*CommitFile, error) {
+	repo, head, err := c.fetch(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, ErrNotFound
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	dir = path.Clean(dir)
+	if dir != "." {
+		tree, err = tree.Tree(dir)
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return nil, ErrNotFound
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	files := []*CommitFile{}
+	for i := range tree.Entries {
+		entry := &tree.Entries[i]
+		if !entry.Mode.IsFile() {
+			continue
+		}
+		f, err := tree.TreeEntryFile(entry)
+		if err != nil {
+			return nil, err
+		}
+		content, err := f.Contents()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &CommitFile{
+			Path:    StringVar(path.Join(dir, entry.Name)),
+			Content: StringVar(content),
+		})
+	}
+	return files, nil
+}
+
+// Create commits the given pages to the default branch of the wiki in a single commit,
+// and pushes it. Pages with a nil Content are deleted.
+func (c *gitWikiClient) Create(ctx context.Context, message string, files []CommitFile) (Commit, error) {
+	return c.commitFiles(ctx, "", message, files)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestWikiClient returns a gitWikiClient operating on the given storage through an
+// in-process Git server.
+func newTestWikiClient(t *testing.T, s storage.Storer) *gitWikiClient {
+	t.Helper()
+	return &gitWikiClient{gitRemote: *newTestRemote(t, "https://example.com/fluxcd/repo.wiki.git", s)}
+}
+
+func TestGitWikiClient_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		storage    func(t *testing.T) storage.Storer
+		wantBranch string
+		wantFiles  []string
+	}{
+		{
+			name:       "empty wiki",
+			storage:    func(*testing.T) storage.Storer { return memory.NewStorage() },
+			wantBranch: "refs/heads/master",
+			wantFiles:  []string{"Home.md"},
+		},
+		{
+			// HEAD of the fake repository points to its feature branch
+			name:       "existing wiki",
+			storage:    func(t *testing.T) storage.Storer { return newFakeRepository(t).Storer },
+			wantBranch: "refs/heads/feature",
+			wantFiles:  []string{"Home.md", "README.md", "feature.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.storage(t)
+			c := newTestWikiClient(t, s)
+
+			commit, err := c.Create(context.Background(), "Add home page", []CommitFile{
+				{Path: StringVar("Home.md"), Content: StringVar("# Welcome\n")},
+			})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			// The commit must have been pushed to the default branch of the wiki
+			ref, err := s.Reference(plumbing.ReferenceName(tt.wantBranch))
+			if err != nil {
+				t.Fatalf("failed to get %s: %v", tt.wantBranch, err)
+			}
+			if got := ref.Hash().String(); got != commit.Get().Sha {
+				t.Errorf("%s = %s, want %s", tt.wantBranch, got, commit.Get().Sha)
+			}
+
+			pages, err := c.Get(context.Background(), "")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			gotFiles := []string{}
+			for _, page := range pages {
+				gotFiles = append(gotFiles, *page.Path)
+				if *page.Path == "Home.md" && *page.Content != "# Welcome\n" {
+					t.Errorf("Home.md content = %q, want %q", *page.Content, "# Welcome\n")
+				}
+			}
+			if diff := cmp.Diff(tt.wantFiles, gotFiles); diff != "" {
+				t.Errorf("Get() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitWikiClient_Get_Empty(t *testing.T) {
+	c := newTestWikiClient(t, memory.NewStorage())
+	if _, err := c.Get(context.Background(), ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+	}
+}
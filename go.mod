@@ -23,6 +23,7 @@ require (
 	golang.org/x/crypto v0.30.0
 	golang.org/x/oauth2 v0.24.0
 	golang.org/x/time v0.8.0
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/utils v0.0.0-20241104163129-6fe5fd82f078
 )
 
@@ -54,5 +55,4 @@ require (
 	golang.org/x/text v0.21.0 // indirect
 	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
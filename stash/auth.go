@@ -37,8 +37,14 @@ func NewStashClient(username, token string, optFns ...gitprovider.ClientOption)
 		return nil, fmt.Errorf("failed making client options: %w", err)
 	}
 
+	chain := opts.GetTransportChain()
+	if opts.PostChainTransportHook == nil && (opts.MaxIdleConnsPerHost != nil || opts.DisableHTTP2 != nil) {
+		// Tune the pooled transport of the Stash client rather than http.DefaultTransport
+		chain = append([]gitprovider.ChainableRoundTripperFunc{pooledTransport}, chain...)
+	}
+
 	// Create a *http.Client using the transport chain
-	client, err := gitprovider.BuildClientFromTransportChain(opts.GetTransportChain())
+	client, err := gitprovider.BuildClientFromTransportChain(chain)
 	if err != nil {
 		return nil, fmt.Errorf("failed building client: %w", err)
 	}
@@ -70,11 +76,18 @@ func NewStashClient(username, token string, optFns ...gitprovider.ClientOption)
 		return nil, fmt.Errorf("failed creating client: %w", err)
 	}
 
+	if opts.RetryPolicy != nil {
+		// The retry policy is applied by the transport chain, replacing the built-in retries
+		stashClient.Client.RetryMax = 0
+	}
+
 	// By default, turn destructive actions off. But allow overrides.
 	destructiveActions := false
 	if opts.EnableDestructiveAPICalls != nil {
 		destructiveActions = *opts.EnableDestructiveAPICalls
 	}
 
-	return newClient(stashClient, host, token, destructiveActions, logger), nil
+	c := newClient(stashClient, host, token, destructiveActions, logger)
+	c.defaultOwner = gitprovider.NewDefaultOwner(opts.DefaultOwner)
+	return c, nil
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package stash
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -60,3 +61,24 @@ func Test_DomainVariations(t *testing.T) {
 		})
 	}
 }
+
+func Test_TransportTuning(t *testing.T) {
+	c, err := NewStashClient("user1", "token", gitprovider.WithDomain("stash.testserver.link"),
+		gitprovider.WithMaxIdleConnsPerHost(50), gitprovider.WithDisableHTTP2(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := c.client.Client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", c.client.Client.HTTPClient.Transport)
+	}
+	if tr == defaultTransport || tr == http.DefaultTransport {
+		t.Error("a shared transport was modified instead of a copy")
+	}
+	if tr.MaxIdleConnsPerHost != 50 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 50", tr.MaxIdleConnsPerHost)
+	}
+	if tr.ForceAttemptHTTP2 {
+		t.Error("ForceAttemptHTTP2 = true, want false")
+	}
+}
@@ -22,11 +22,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const (
-	branchesURI      = "branches"
-	defaultBranchURI = "default"
+	stashURIbranchUtils = "/rest/branch-utils/1.0"
+	branchesURI         = "branches"
+	defaultBranchURI    = "default"
+	branchesInfoURI     = "branches/info"
 )
 
 // Branches interface defines the methods that can be used to
@@ -37,6 +40,8 @@ type Branches interface {
 	Create(ctx context.Context, projectKey, repositorySlug, branchID, startPoint string) (*Branch, error)
 	Default(ctx context.Context, projectKey, repositorySlug string) (*Branch, error)
 	SetDefault(ctx context.Context, projectKey, repositorySlug, branchID string) error
+	ListContaining(ctx context.Context, projectKey, repositorySlug, commitID string, opts *PagingOptions) (*BranchList, error)
+	AllContaining(ctx context.Context, projectKey, repositorySlug, commitID string) ([]*Branch, error)
 }
 
 // BranchesService is a client for communicating with stash branches endpoint
@@ -236,3 +241,60 @@ func (s *BranchesService) Create(ctx context.Context, projectKey, repositorySlug
 	b.Session.set(resp)
 	return b, nil
 }
+
+// ListContaining returns the list of branches containing the given commit.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a BranchList struct is returned to retrieve the next page of results.
+// ListContaining uses the endpoint
+// "GET /rest/branch-utils/1.0/projects/{projectKey}/repos/{repositorySlug}/branches/info/{commitId}".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-branch-rest.html
+func (s *BranchesService) ListContaining(ctx context.Context, projectKey, repositorySlug, commitID string, opts *PagingOptions) (*BranchList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newBranchUtilsURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, branchesInfoURI, commitID), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list branches containing commit request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list branches containing commit failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	b := &BranchList{}
+	if err := json.Unmarshal(res, b); err != nil {
+		return nil, fmt.Errorf("list branches containing commit failed, unable to unmarshall branches json: %w", err)
+	}
+
+	for _, branch := range b.GetBranches() {
+		branch.Session.set(resp)
+	}
+
+	return b, nil
+}
+
+// AllContaining retrieves all the branches containing the given commit.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *BranchesService) AllContaining(ctx context.Context, projectKey, repositorySlug, commitID string) ([]*Branch, error) {
+	b := []*Branch{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.ListContaining(ctx, projectKey, repositorySlug, commitID, opts)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, list.GetBranches()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func newBranchUtilsURI(elements ...string) string {
+	return strings.Join(append([]string{stashURIbranchUtils}, elements...), "/")
+}
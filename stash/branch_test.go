@@ -24,6 +24,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 func TestGetBranch(t *testing.T) {
@@ -117,6 +119,38 @@ func TestListBranches(t *testing.T) {
 
 }
 
+func TestAllContaining(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/c1", stashURIbranchUtils, projectsURI, RepositoriesURI, branchesInfoURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		// Serve one branch per page
+		if r.URL.Query().Get("start") == "" {
+			json.NewEncoder(w).Encode(&BranchList{
+				Paging:   Paging{NextPageStart: 1},
+				Branches: []*Branch{{DisplayID: "main"}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(&BranchList{
+			Paging:   Paging{IsLastPage: true},
+			Branches: []*Branch{{DisplayID: "release"}},
+		})
+	})
+
+	branches, err := client.Branches.AllContaining(context.Background(), "prj1", "repo1", "c1")
+	if err != nil {
+		t.Fatalf("Branches.AllContaining returned error: %v", err)
+	}
+	got := []string{}
+	for _, b := range branches {
+		got = append(got, b.DisplayID)
+	}
+	if diff := cmp.Diff([]string{"main", "release"}, got); diff != "" {
+		t.Errorf("Branches.AllContaining returned diff (want -> got):\n%s", diff)
+	}
+}
+
 func TestDefaultBranch(t *testing.T) {
 	d := struct {
 		ID        string `json:"id"`
@@ -153,3 +187,45 @@ func TestDefaultBranch(t *testing.T) {
 		t.Errorf("Branches.Default returned branch:\n%s, want:\n %s", b.ID, d.ID)
 	}
 }
+
+func TestBranchClient_RequiredChecks(t *testing.T) {
+	mux, client := setup(t)
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIrequiredBuilds, projectsURI, RepositoriesURI, conditionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&RequiredBuildConditionList{
+			Paging: Paging{IsLastPage: true},
+			Conditions: []*RequiredBuildCondition{
+				{
+					ID:              1,
+					BuildParentKeys: []string{"build", "test"},
+					RefMatcher:      RefMatcher{ID: "refs/heads/main", DisplayID: "main", Type: RefMatcherType{ID: RefMatcherTypeBranch}},
+				},
+				{
+					ID:              2,
+					BuildParentKeys: []string{"lint", "build"},
+					RefMatcher:      RefMatcher{ID: "ANY_REF_MATCHER_ID", Type: RefMatcherType{ID: RefMatcherTypeAnyRef}},
+				},
+				{
+					ID:              3,
+					BuildParentKeys: []string{"release"},
+					RefMatcher:      RefMatcher{ID: "release/*", Type: RefMatcherType{ID: "PATTERN"}},
+				},
+			},
+		})
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"},
+		RepositoryName:  "repo1",
+	}
+	ref.SetKey("prj1")
+	ref.SetSlug("repo1")
+	c := &BranchClient{clientContext: &clientContext{client: client}, ref: ref}
+	got, err := c.RequiredChecks(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("RequiredChecks() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"build", "test", "lint"}, got); diff != "" {
+		t.Errorf("RequiredChecks() (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	stashURIbuildStatus = "/rest/build-status/1.0"
+)
+
+const (
+	// BuildStatusStateSuccessful is the state of a successful build.
+	BuildStatusStateSuccessful = "SUCCESSFUL"
+	// BuildStatusStateFailed is the state of a failed build.
+	BuildStatusStateFailed = "FAILED"
+	// BuildStatusStateInProgress is the state of a build in progress.
+	BuildStatusStateInProgress = "INPROGRESS"
+)
+
+// BuildStatuses interface defines the methods for working with
+// the build statuses of commits.
+type BuildStatuses interface {
+	List(ctx context.Context, commitID string, opts *PagingOptions) (*BuildStatusList, error)
+	All(ctx context.Context, commitID string) ([]*BuildStatus, error)
+	Set(ctx context.Context, commitID string, status *BuildStatus) error
+}
+
+// BuildStatusService is a client for communicating with stash build status endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-build-rest.html
+type BuildStatusService service
+
+// BuildStatus is the status of a build of a commit.
+// A commit has one build status per Key, setting a status with an existing Key replaces it.
+type BuildStatus struct {
+	// Session is the session object for the build status.
+	Session `json:"sessionInfo,omitempty"`
+	// State is the state of the build, one of SUCCESSFUL, FAILED or INPROGRESS.
+	State string `json:"state,omitempty"`
+	// Key identifies the build, e.g. the name of the CI job.
+	Key string `json:"key,omitempty"`
+	// Name is the human-friendly name of the build.
+	Name string `json:"name,omitempty"`
+	// URL links to the build.
+	URL string `json:"url,omitempty"`
+	// Description describes the build result.
+	Description string `json:"description,omitempty"`
+	// DateAdded is the time the status was set, in milliseconds since the epoch.
+	DateAdded int64 `json:"dateAdded,omitempty"`
+}
+
+// BuildStatusList is a list of build statuses.
+type BuildStatusList struct {
+	// Paging is the paging information.
+	Paging
+	// BuildStatuses is the list of build statuses.
+	BuildStatuses []*BuildStatus `json:"values,omitempty"`
+}
+
+// GetBuildStatuses returns the list of build statuses.
+func (b *BuildStatusList) GetBuildStatuses() []*BuildStatus {
+	return b.BuildStatuses
+}
+
+// List returns the list of build statuses of the commit.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a BuildStatusList struct is returned to retrieve the next page of results.
+// List uses the endpoint "GET /rest/build-status/1.0/commits/{commitId}".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-build-rest.html
+func (s *BuildStatusService) List(ctx context.Context, commitID string, opts *PagingOptions) (*BuildStatusList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newBuildStatusURI(commitsURI, commitID), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list build statuses request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list build statuses failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("list build statuses failed: %s: %w", resp.Status, ErrBadRequest)
+	}
+
+	b := &BuildStatusList{}
+	if err := json.Unmarshal(res, b); err != nil {
+		return nil, fmt.Errorf("list build statuses failed, unable to unmarshall json: %w", err)
+	}
+
+	for _, status := range b.GetBuildStatuses() {
+		status.Session.set(resp)
+	}
+
+	return b, nil
+}
+
+// All retrieves all build statuses of the commit.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *BuildStatusService) All(ctx context.Context, commitID string) ([]*BuildStatus, error) {
+	b := []*BuildStatus{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.List(ctx, commitID, opts)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, list.GetBuildStatuses()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Set sets the build status of the commit, replacing the status with the same key if any.
+// State, Key and URL are required by Bitbucket Server.
+// Set uses the endpoint "POST /rest/build-status/1.0/commits/{commitId}".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-build-rest.html
+func (s *BuildStatusService) Set(ctx context.Context, commitID string, status *BuildStatus) error {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshall build status: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newBuildStatusURI(commitsURI, commitID), WithBody(body), WithHeader(header))
+	if err != nil {
+		return fmt.Errorf("set build status request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("set build status failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("set build status failed: %s: %w", resp.Status, ErrBadRequest)
+	}
+
+	return nil
+}
+
+func newBuildStatusURI(elements ...string) string {
+	return strings.Join(append([]string{stashURIbuildStatus}, elements...), "/")
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSetBuildStatus(t *testing.T) {
+	commitID := "abcdef0123abcdef4567abcdef8987abcdef6543"
+	status := &BuildStatus{
+		State:       BuildStatusStateSuccessful,
+		Key:         "ci",
+		Name:        "CI build",
+		URL:         "https://ci.example.com/builds/1",
+		Description: "All tests passed",
+	}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/%s", stashURIbuildStatus, commitsURI, commitID)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want %s", r.Method, http.MethodPost)
+		}
+		got := &BuildStatus{}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		if diff := cmp.Diff(status, got); diff != "" {
+			t.Errorf("request body (-want +got):\n%s", diff)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.BuildStatus.Set(context.Background(), commitID, status); err != nil {
+		t.Fatalf("BuildStatus.Set returned error: %v", err)
+	}
+}
+
+func TestSetBuildStatus_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    int
+		wantErr error
+	}{
+		{
+			name:    "unknown commit",
+			code:    http.StatusNotFound,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "invalid status",
+			code:    http.StatusBadRequest,
+			wantErr: ErrBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc(fmt.Sprintf("%s/%s/%s", stashURIbuildStatus, commitsURI, "abcdef"), func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.code)
+			})
+
+			err := client.BuildStatus.Set(context.Background(), "abcdef", &BuildStatus{State: BuildStatusStateFailed})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("BuildStatus.Set error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListBuildStatuses(t *testing.T) {
+	commitID := "abcdef0123abcdef4567abcdef8987abcdef6543"
+	statuses := []*BuildStatus{
+		{State: BuildStatusStateSuccessful, Key: "ci", URL: "https://ci.example.com/builds/1", DateAdded: 1700000000000},
+		{State: BuildStatusStateInProgress, Key: "e2e", URL: "https://ci.example.com/builds/2", DateAdded: 1700000001000},
+		{State: BuildStatusStateFailed, Key: "lint", URL: "https://ci.example.com/builds/3", DateAdded: 1700000002000},
+	}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/%s", stashURIbuildStatus, commitsURI, commitID)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		// Serve the statuses one per page to exercise the pagination
+		start := 0
+		fmt.Sscanf(r.URL.Query().Get("start"), "%d", &start)
+		b := struct {
+			Paging
+			BuildStatuses []*BuildStatus `json:"values"`
+		}{
+			Paging: Paging{
+				IsLastPage:    start == len(statuses)-1,
+				NextPageStart: int64(start + 1),
+			},
+			BuildStatuses: statuses[start : start+1],
+		}
+		json.NewEncoder(w).Encode(b)
+	})
+
+	got, err := client.BuildStatus.All(context.Background(), commitID)
+	if err != nil {
+		t.Fatalf("BuildStatus.All returned error: %v", err)
+	}
+	if diff := cmp.Diff(statuses, got); diff != "" {
+		t.Errorf("BuildStatus.All returned diff (want -> got):\n%s", diff)
+	}
+}
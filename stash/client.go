@@ -29,10 +29,13 @@ import (
 	"sync"
 	"time"
 
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-cleanhttp"
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
 	"golang.org/x/time/rate"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 const (
@@ -59,6 +62,12 @@ var (
 	defaultTransport = cleanhttp.DefaultPooledTransport()
 )
 
+// pooledTransport is a gitprovider.ChainableRoundTripperFunc returning a new pooled transport, to
+// be used as the base of a transport chain.
+func pooledTransport(http.RoundTripper) http.RoundTripper {
+	return cleanhttp.DefaultPooledTransport()
+}
+
 // Doer is the interface that wraps the basic Do method.
 //
 // Do makes an http request for req.
@@ -104,15 +113,20 @@ type Client struct {
 	caBundle []byte
 
 	// Services are used to communicate with the different stash endpoints.
-	Users        Users
-	Groups       Groups
-	Projects     Projects
-	Git          Git
-	Repositories Repositories
-	Branches     Branches
-	Commits      Commits
-	PullRequests PullRequests
-	DeployKeys   DeployKeys
+	Users            Users
+	Groups           Groups
+	Projects         Projects
+	Git              Git
+	Repositories     Repositories
+	Branches         Branches
+	Tags             Tags
+	Commits          Commits
+	PullRequests     PullRequests
+	DeployKeys       DeployKeys
+	BuildStatus      BuildStatuses
+	RequiredBuilds   RequiredBuilds
+	DefaultReviewers DefaultReviewers
+	Webhooks         Webhooks
 }
 
 // RateLimiter is the interface that wraps the basic Wait method.
@@ -223,9 +237,14 @@ func NewClient(httpClient *http.Client, host string, header *http.Header, logger
 	c.Git = &GitService{Client: c}
 	c.Repositories = &RepositoriesService{Client: c}
 	c.Branches = &BranchesService{Client: c}
+	c.Tags = &TagsService{Client: c}
 	c.Commits = &CommitsService{Client: c}
 	c.PullRequests = &PullRequestsService{Client: c}
 	c.DeployKeys = &DeployKeysService{Client: c}
+	c.BuildStatus = &BuildStatusService{Client: c}
+	c.RequiredBuilds = &RequiredBuildsService{Client: c}
+	c.DefaultReviewers = &DefaultReviewersService{Client: c}
+	c.Webhooks = &WebhooksService{Client: c}
 
 	return c, nil
 }
@@ -251,6 +270,16 @@ func (c *Client) Raw() *Client {
 	return c
 }
 
+// gitTransport returns the options for talking to the Git endpoints of the server, with the
+// credentials and CA bundle of the client.
+func (c *Client) gitTransport() gitprovider.GitTransportOptions {
+	return gitprovider.GitTransportOptions{
+		HTTPClient: c.Client.HTTPClient,
+		Auth:       &githttp.BasicAuth{Username: c.username, Password: c.token},
+		CABundle:   c.caBundle,
+	}
+}
+
 // retryHTTPCheck provides a callback for Client.CheckRetry which
 // will retry both rate limit (429) and server (>= 500) errors as well as other recoverable errors.
 func (c *Client) retryHTTPCheck(ctx context.Context, resp *http.Response, err error) (bool, error) {
@@ -497,7 +526,7 @@ func (c *Client) Do(request *http.Request) ([]byte, *http.Response, error) {
 		return nil, resp, err
 	}
 
-	if resp.StatusCode == http.StatusOK || (resp.StatusCode == http.StatusCreated && request.Method == http.MethodPost) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodDelete) ||
+	if resp.StatusCode == http.StatusOK || (resp.StatusCode == http.StatusCreated && request.Method == http.MethodPost) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodPost) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodDelete) ||
 		(resp.StatusCode == http.StatusAccepted && request.Method == http.MethodDelete) || (resp.StatusCode == http.StatusNoContent && request.Method == http.MethodPut) || resp.StatusCode == http.StatusBadRequest {
 		return resBytes, resp, nil
 	}
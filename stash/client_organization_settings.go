@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// OrganizationSettingsClient implements the gitprovider.OrganizationSettingsClient interface.
+var _ gitprovider.OrganizationSettingsClient = &OrganizationSettingsClient{}
+
+// OrganizationSettingsClient operates on the org-wide defaults of a specific organization.
+// Bitbucket Server projects have no org-wide repository defaults, so no field is supported.
+type OrganizationSettingsClient struct {
+	*clientContext
+	ref gitprovider.OrganizationRef
+}
+
+// Get returns no org-wide defaults, as Bitbucket Server doesn't support any.
+func (c *OrganizationSettingsClient) Get(_ context.Context) (gitprovider.OrganizationSettingsInfo, error) {
+	return gitprovider.OrganizationSettingsInfo{}, nil
+}
+
+// Reconcile returns ErrNoProviderSupport if any field is set in req, and is a no-op otherwise.
+func (c *OrganizationSettingsClient) Reconcile(_ context.Context, req gitprovider.OrganizationSettingsInfo) (bool, error) {
+	if !req.Equals(gitprovider.OrganizationSettingsInfo{}) {
+		return false, gitprovider.ErrNoProviderSupport
+	}
+	return false, nil
+}
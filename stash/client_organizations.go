@@ -65,6 +65,43 @@ func (c *OrganizationsClient) List(ctx context.Context) ([]gitprovider.Organizat
 		return nil, fmt.Errorf("failed to list organizations: %w", err)
 	}
 
+	return c.projectsToOrganizations(apiObjs)
+}
+
+// ListWithRole lists the projects where the authenticated user has at least the given role.
+// Projects are filtered server-side through the permission of the user on them, members
+// have PROJECT_READ and admins PROJECT_ADMIN. Projects have no owners, so ErrNoProviderSupport
+// is returned for OrganizationRoleOwner.
+//
+// ListWithRole returns all matching organizations, using multiple paginated requests if needed.
+func (c *OrganizationsClient) ListWithRole(ctx context.Context, role gitprovider.OrganizationRole) ([]gitprovider.Organization, error) {
+	if err := gitprovider.ValidateOrganizationRole(role); err != nil {
+		return nil, fmt.Errorf("invalid role %q: %w", role, gitprovider.ErrInvalidArgument)
+	}
+	permission, ok := organizationRolePermissions[role]
+	if !ok {
+		return nil, fmt.Errorf("role %q isn't reported by stash: %w", role, gitprovider.ErrNoProviderSupport)
+	}
+
+	apiObjs, err := c.client.Projects.AllWithPermission(ctx, permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations with role %q: %w", role, err)
+	}
+
+	return c.projectsToOrganizations(apiObjs)
+}
+
+// organizationRolePermissions maps the organization roles to the project permissions
+// granting them.
+//
+//nolint:gochecknoglobals
+var organizationRolePermissions = map[gitprovider.OrganizationRole]string{
+	gitprovider.OrganizationRoleMember: stashPermissionProjectRead,
+	gitprovider.OrganizationRoleAdmin:  stashPermissionProjectAdmin,
+}
+
+// projectsToOrganizations validates the given projects and wraps them as organizations.
+func (c *OrganizationsClient) projectsToOrganizations(apiObjs []*Project) ([]gitprovider.Organization, error) {
 	// Validate the API objects
 	var errs error
 	for _, apiObj := range apiObjs {
@@ -97,6 +134,39 @@ func (c *OrganizationsClient) Children(_ context.Context, _ gitprovider.Organiza
 	return nil, gitprovider.ErrNoProviderSupport
 }
 
+// Reconcile makes sure the metadata of the project behind ref matches the desired state (req).
+// Only the fields set in req are reconciled, the others are left as-is. Projects aren't created,
+// ErrNotFound is returned if the project doesn't exist.
+//
+// Reconcile is specific to Bitbucket Server, and is reached by asserting the client returned by
+// Organizations() to *OrganizationsClient.
+func (c *OrganizationsClient) Reconcile(ctx context.Context, ref gitprovider.OrganizationRef, req ProjectInfo) (gitprovider.Organization, bool, error) {
+	if err := validateOrganizationRef(ref, c.host); err != nil {
+		return nil, false, err
+	}
+	apiObj, err := c.client.Projects.Get(ctx, ref.Organization)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get organization %q: %w", ref.Organization, err)
+	}
+	if err := validateProjectAPI(apiObj); err != nil {
+		return nil, false, err
+	}
+	ref.SetKey(apiObj.Key)
+
+	if req.Equals(projectInfoFromAPI(apiObj)) {
+		return newOrganization(c.clientContext, apiObj, ref), false, nil
+	}
+
+	apiObj, err = c.client.Projects.Update(ctx, apiObj.Key, projectInfoToAPI(&req, apiObj))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to update organization %q: %w", ref.Organization, err)
+	}
+	if err := validateProjectAPI(apiObj); err != nil {
+		return nil, false, err
+	}
+	return newOrganization(c.clientContext, apiObj, ref), true, nil
+}
+
 // validateOrganizationRef makes sure the OrganizationRef is valid for stash usage.
 func validateOrganizationRef(ref gitprovider.OrganizationRef, expectedDomain string) error {
 	// Make sure the OrganizationRef fields are valid
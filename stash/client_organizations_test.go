@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrganizationsClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         ProjectInfo
+		wantUpdate  *ProjectUpdate
+		wantChanged bool
+	}{
+		{
+			name: "up to date",
+			req:  ProjectInfo{Description: gitprovider.StringVar("my project")},
+		},
+		{
+			name:        "make public",
+			req:         ProjectInfo{Public: gitprovider.BoolVar(true)},
+			wantUpdate:  &ProjectUpdate{Key: "PRJ1", Name: "project1", Description: "my project", Public: true},
+			wantChanged: true,
+		},
+		{
+			name:        "change description",
+			req:         ProjectInfo{Description: gitprovider.StringVar("")},
+			wantUpdate:  &ProjectUpdate{Key: "PRJ1", Name: "project1"},
+			wantChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			actual := &Project{Key: "PRJ1", Name: "project1", Description: "my project"}
+			var gotUpdate *ProjectUpdate
+			mux.HandleFunc(fmt.Sprintf("%s/%s", stashURIprefix, projectsURI), func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(&ProjectsList{Projects: []*Project{actual}})
+			})
+			mux.HandleFunc(fmt.Sprintf("%s/%s/PRJ1", stashURIprefix, projectsURI), func(w http.ResponseWriter, r *http.Request) {
+				gotUpdate = &ProjectUpdate{}
+				if err := json.NewDecoder(r.Body).Decode(gotUpdate); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				json.NewEncoder(w).Encode(&Project{
+					Key:         gotUpdate.Key,
+					Name:        gotUpdate.Name,
+					Description: gotUpdate.Description,
+					Public:      gotUpdate.Public,
+				})
+			})
+
+			c := &OrganizationsClient{clientContext: &clientContext{client: client, host: "stash.example.com", log: logr.Discard()}}
+			ref := gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"}
+			org, changed, err := c.Reconcile(context.Background(), ref, tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", changed, tt.wantChanged)
+			}
+			if tt.wantUpdate == nil && gotUpdate != nil {
+				t.Errorf("Reconcile() updated the project with %+v", gotUpdate)
+			}
+			if tt.wantUpdate != nil && (gotUpdate == nil || *gotUpdate != *tt.wantUpdate) {
+				t.Errorf("Reconcile() update = %+v, want %+v", gotUpdate, tt.wantUpdate)
+			}
+			if got := org.APIObject().(*Project); !tt.req.Equals(projectInfoFromAPI(got)) {
+				t.Errorf("Reconcile() returned %+v, want %+v", got, tt.req)
+			}
+		})
+	}
+}
+
+func TestOrganizationsClient_ListWithRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           gitprovider.OrganizationRole
+		wantPermission string
+		wantErr        error
+	}{
+		{
+			name:           "member",
+			role:           gitprovider.OrganizationRoleMember,
+			wantPermission: "PROJECT_READ",
+		},
+		{
+			name:           "admin",
+			role:           gitprovider.OrganizationRoleAdmin,
+			wantPermission: "PROJECT_ADMIN",
+		},
+		{
+			name:    "owner",
+			role:    gitprovider.OrganizationRoleOwner,
+			wantErr: gitprovider.ErrNoProviderSupport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			mux.HandleFunc(fmt.Sprintf("%s/%s", stashURIprefix, projectsURI), func(w http.ResponseWriter, r *http.Request) {
+				if permission := r.URL.Query().Get("permission"); permission != tt.wantPermission {
+					t.Errorf("unexpected permission query: %q, want %q", permission, tt.wantPermission)
+				}
+				json.NewEncoder(w).Encode(&ProjectsList{
+					Paging:   Paging{IsLastPage: true},
+					Projects: []*Project{{Key: "PRJ1", Name: "project1"}},
+				})
+			})
+
+			c := &OrganizationsClient{clientContext: &clientContext{client: client, host: "stash.example.com", log: logr.Discard()}}
+			orgs, err := c.ListWithRole(context.Background(), tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListWithRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(orgs) != 1 || orgs[0].Organization().Organization != "project1" {
+				t.Errorf("ListWithRole() = %v, want project1 only", orgs)
+			}
+		})
+	}
+}
@@ -114,6 +114,26 @@ func (c *OrgRepositoriesClient) List(ctx context.Context, ref gitprovider.Organi
 	return repos, nil
 }
 
+// Iterate calls fn for each repository in the given organization, fetching the pages lazily.
+func (c *OrgRepositoriesClient) Iterate(ctx context.Context, ref gitprovider.OrganizationRef, fn func(gitprovider.OrgRepository) error) error {
+	// Make sure the OrganizationRef is valid
+	if err := validateOrganizationRef(ref, c.host); err != nil {
+		return err
+	}
+
+	return c.client.Repositories.Iterate(ctx, ref.Key(), func(apiObj *Repository) error {
+		if err := validateRepositoryAPI(apiObj); err != nil {
+			return err
+		}
+		repoRef := gitprovider.OrgRepositoryRef{
+			OrganizationRef: ref,
+			RepositoryName:  apiObj.Name,
+		}
+		repoRef.SetSlug(apiObj.Slug)
+		return fn(newOrgRepository(c.clientContext, apiObj, repoRef))
+	})
+}
+
 // Create creates a repository for the given organization, with the data and options.
 // ErrAlreadyExists will be returned if the resource already exists.
 func (c *OrgRepositoriesClient) Create(ctx context.Context,
@@ -130,6 +150,10 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context,
 		if errors.Is(err, ErrAlreadyExists) {
 			return nil, gitprovider.ErrAlreadyExists
 		}
+		// The repositories of a user live in their personal project, not in a project named after them
+		if isUser(ctx, c.client, ref.Organization) {
+			return nil, gitprovider.NewErrIdentityTypeMismatch(ref.Organization, false)
+		}
 		return nil, fmt.Errorf("failed to create repository %s/%s: %w", ref.Key(), ref.RepositoryName, err)
 	}
 
@@ -138,15 +162,31 @@ func (c *OrgRepositoriesClient) Create(ctx context.Context,
 	return newOrgRepository(c.clientContext, apiObj, ref), nil
 }
 
+// isUser returns whether name is the name of a user, and not of a project.
+func isUser(ctx context.Context, c *Client, name string) bool {
+	if _, err := c.Projects.Get(ctx, name); !errors.Is(err, ErrNotFound) {
+		return false
+	}
+	_, err := c.Users.Get(ctx, name)
+	return err == nil
+}
+
 // Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
 // If req doesn't exist under the hood, it is created (actionTaken == true).
 // If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
 // If req is already the actual state, this is a no-op (actionTaken == false).
 func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.OrgRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.OrgRepository, bool, error) {
+	o := gitprovider.MakeRepositoryReconcileOptions(opts...)
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, false, err
+	}
 	actual, err := c.Get(ctx, ref)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
+			if err := o.CheckPrecondition(nil); err != nil {
+				return nil, false, err
+			}
 			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
 			return resp, true, err
 		}
@@ -155,6 +195,11 @@ func (c *OrgRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.O
 		return nil, false, fmt.Errorf("unexpected error when reconciling repository: %w", err)
 	}
 
+	actualInfo := actual.Get()
+	if err := o.CheckPrecondition(&actualInfo); err != nil {
+		return nil, false, err
+	}
+
 	actionTaken, err := c.reconcileRepository(ctx, actual, req)
 
 	return actual, actionTaken, err
@@ -191,6 +236,10 @@ func deleteRepository(ctx context.Context, c *Client, orgKey, repoSlug string) e
 	return nil
 }
 
+// maxDescriptionLength is the maximum length of the repository descriptions checked before
+// reaching Bitbucket Server, which doesn't document a limit. 0 disables the check.
+const maxDescriptionLength = 0
+
 func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprovider.RepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryCreateOption) (*Repository, error) {
 	// First thing, validate and default the request to ensure a valid and fully-populated object
 	// (to minimize any possible diffs between desired and actual state)
@@ -203,6 +252,13 @@ func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprov
 	if err != nil {
 		return nil, err
 	}
+	if err := opt.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, err
+	}
+	// Bitbucket Server doesn't have issues, hence no labels
+	if len(opt.Labels) != 0 {
+		return nil, fmt.Errorf("labels aren't supported by Bitbucket Server: %w", gitprovider.ErrNoProviderSupport)
+	}
 
 	// Convert to the API object and apply the options
 	data := repositoryToAPI(&req, ref)
@@ -243,12 +299,16 @@ func createRepository(ctx context.Context, c *Client, orgKey string, ref gitprov
 			}
 		}
 
+		message := "initial commit"
+		if opt.InitialCommitMessage != nil {
+			message = *opt.InitialCommitMessage
+		}
 		initCommit, err = NewCommit(
 			WithAuthor(&CommitAuthor{
 				Name:  user.Name,
 				Email: user.EmailAddress,
 			}),
-			WithMessage("initial commit"),
+			WithMessage(message),
 			WithURL(getRepoHTTPref(repo.Links.Clone)),
 			WithFiles(files))
 
@@ -137,6 +137,14 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 		return nil, err
 	}
 
+	// The repository is created in the personal project of the authenticated user, make sure the
+	// reference doesn't name a project instead
+	if ref.UserLogin != c.client.username {
+		if _, err := c.client.Projects.Get(ctx, ref.UserLogin); err == nil {
+			return nil, gitprovider.NewErrIdentityTypeMismatch(ref.UserLogin, true)
+		}
+	}
+
 	apiObj, err := createRepository(ctx, c.client, addTilde(c.client.username), ref, req, opts...)
 	if err != nil {
 		if errors.Is(err, ErrAlreadyExists) {
@@ -157,10 +165,17 @@ func (c *UserRepositoriesClient) Create(ctx context.Context,
 // If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
 // If req is already the actual state, this is a no-op (actionTaken == false).
 func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.UserRepositoryRef, req gitprovider.RepositoryInfo, opts ...gitprovider.RepositoryReconcileOption) (gitprovider.UserRepository, bool, error) {
+	o := gitprovider.MakeRepositoryReconcileOptions(opts...)
+	if err := o.ValidateDescription(req, maxDescriptionLength); err != nil {
+		return nil, false, err
+	}
 	actual, err := c.Get(ctx, ref)
 	if err != nil {
 		// Create if not found
 		if errors.Is(err, gitprovider.ErrNotFound) {
+			if err := o.CheckPrecondition(nil); err != nil {
+				return nil, false, err
+			}
 			resp, err := c.Create(ctx, ref, req, toCreateOpts(opts...)...)
 			return resp, true, err
 		}
@@ -169,6 +184,11 @@ func (c *UserRepositoriesClient) Reconcile(ctx context.Context, ref gitprovider.
 		return nil, false, fmt.Errorf("failed to reconcile repository %s/%s: %w", addTilde(ref.UserLogin), ref.RepositoryName, err)
 	}
 
+	actualInfo := actual.Get()
+	if err := o.CheckPrecondition(&actualInfo); err != nil {
+		return nil, false, err
+	}
+
 	actionTaken, err := c.reconcileRepository(ctx, actual, req)
 
 	return actual, actionTaken, err
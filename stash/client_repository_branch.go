@@ -18,6 +18,7 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
@@ -107,3 +108,79 @@ func (c *BranchClient) getDefault(ctx context.Context) (string, error) {
 	return b.DisplayID, nil
 
 }
+
+// RequiredChecks returns the keys of the builds required by the required builds merge checks
+// targeting the branch. Merge checks matching branches by pattern or branching model aren't
+// taken into account.
+func (c *BranchClient) RequiredChecks(ctx context.Context, branch string) ([]string, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	conditions, err := c.client.RequiredBuilds.All(ctx, projectKey, repoSlug)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list required builds: %w", err)
+	}
+
+	protected := false
+	names := []string{}
+	seen := map[string]struct{}{}
+	for _, condition := range conditions {
+		if !condition.RefMatcher.Matches(branch) {
+			continue
+		}
+		protected = true
+		for _, key := range condition.BuildParentKeys {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				names = append(names, key)
+			}
+		}
+	}
+	if !protected {
+		return nil, gitprovider.ErrNotFound
+	}
+	return names, nil
+}
+
+// RequiresSignedCommits is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (c *BranchClient) RequiresSignedCommits(_ context.Context, _ string) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// DefaultReviewers returns the logins of the reviewers of the default reviewers conditions
+// targeting the branch, whatever their source branch. Conditions matching branches by pattern or
+// branching model aren't taken into account.
+func (c *BranchClient) DefaultReviewers(ctx context.Context, branch string) ([]string, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	conditions, err := c.client.DefaultReviewers.List(ctx, projectKey, repoSlug)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list default reviewers: %w", err)
+	}
+
+	logins := []string{}
+	seen := map[string]struct{}{}
+	for _, condition := range conditions {
+		if !condition.TargetRefMatcher.Matches(branch) {
+			continue
+		}
+		for _, reviewer := range condition.Reviewers {
+			if _, ok := seen[reviewer.Name]; !ok {
+				seen[reviewer.Name] = struct{}{}
+				logins = append(logins, reviewer.Name)
+			}
+		}
+	}
+	return logins, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// CollaboratorClient implements the gitprovider.CollaboratorClient interface.
+var _ gitprovider.CollaboratorClient = &CollaboratorClient{}
+
+// CollaboratorClient operates on the users having access to a specific repository.
+type CollaboratorClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists the users having access to the repository.
+//
+// By default, only the users granted a permission on the repository itself are listed.
+// With CollaboratorListOptions.Effective, List resolves the effective permission of each
+// user, which Bitbucket Server computes from four kinds of grants:
+//   - the user's repository permission,
+//   - the user's project permission,
+//   - the repository permissions of the groups the user is a member of,
+//   - the project permissions of the groups the user is a member of.
+//
+// Project permissions are mapped to their repository counterpart (PROJECT_READ to REPO_READ
+// and so on) and the highest of all the grants wins, as grants never restrict access.
+// Global permissions and public access are not taken into account.
+func (c *CollaboratorClient) List(ctx context.Context, opts ...gitprovider.CollaboratorListOption) ([]gitprovider.CollaboratorInfo, error) {
+	o := gitprovider.MakeCollaboratorListOptions(opts...)
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	// Init a set of permissions per user
+	userPermissions := make(map[string]map[string]bool)
+	grant := func(login, permission string) {
+		if userPermissions[login] == nil {
+			userPermissions[login] = make(map[string]bool)
+		}
+		userPermissions[login][permission] = true
+	}
+
+	repoUsers, err := c.client.Repositories.AllUsersPermission(ctx, projectKey, repoSlug)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list repository users: %w", err)
+	}
+	for _, perm := range repoUsers {
+		grant(perm.User.Name, perm.Permission)
+	}
+
+	if o.Effective {
+		if err := c.addInheritedPermissions(ctx, projectKey, repoSlug, grant); err != nil {
+			return nil, err
+		}
+	}
+
+	collaborators := make([]gitprovider.CollaboratorInfo, 0, len(userPermissions))
+	for login, perms := range userPermissions {
+		permission, err := getGitProviderPermission(getStashPermissionFromMap(perms))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the permission of user %s: %w", login, err)
+		}
+		collaborators = append(collaborators, gitprovider.CollaboratorInfo{
+			Login:      login,
+			Permission: *permission,
+		})
+	}
+	sort.Slice(collaborators, func(i, j int) bool {
+		return collaborators[i].Login < collaborators[j].Login
+	})
+
+	return collaborators, nil
+}
+
+// addInheritedPermissions grants the users their project permissions, and the members of
+// the groups their groups repository and project permissions.
+// Personal projects don't have project or group permissions, so these are ignored when not found.
+func (c *CollaboratorClient) addInheritedPermissions(ctx context.Context, projectKey, repoSlug string, grant func(login, permission string)) error {
+	projectUsers, err := c.client.Projects.AllUsersPermission(ctx, projectKey)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to list project users: %w", err)
+	}
+	for _, perm := range projectUsers {
+		grant(perm.User.Name, getRepoPermissionFromProject(perm.Permission))
+	}
+
+	// Group name to the permissions granted to the group
+	groupPermissions := make(map[string][]string)
+	repoGroups, err := c.client.Repositories.AllGroupsPermission(ctx, projectKey, repoSlug)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to list repository groups: %w", err)
+	}
+	for _, perm := range repoGroups {
+		groupPermissions[perm.Group.Name] = append(groupPermissions[perm.Group.Name], perm.Permission)
+	}
+	projectGroups, err := c.client.Projects.AllGroupsPermission(ctx, projectKey)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to list project groups: %w", err)
+	}
+	for _, perm := range projectGroups {
+		groupPermissions[perm.Group.Name] = append(groupPermissions[perm.Group.Name], getRepoPermissionFromProject(perm.Permission))
+	}
+
+	for group, perms := range groupPermissions {
+		members, err := c.client.Groups.AllGroupMembers(ctx, group)
+		if err != nil {
+			return fmt.Errorf("failed to list the members of group %s: %w", group, err)
+		}
+		for _, member := range members {
+			for _, perm := range perms {
+				grant(member.Name, perm)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getRepoPermissionFromProject returns the repository permission matching a project permission.
+func getRepoPermissionFromProject(permission string) string {
+	switch permission {
+	case stashPermissionProjectRead:
+		return stashPermissionRead
+	case stashPermissionProjectWrite:
+		return stashPermissionWrite
+	case stashPermissionProjectAdmin:
+		return stashPermissionAdmin
+	}
+	return permission
+}
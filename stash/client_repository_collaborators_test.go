@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestCollaboratorClient_List(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []gitprovider.CollaboratorListOption
+		want []gitprovider.CollaboratorInfo
+	}{
+		{
+			name: "direct",
+			want: []gitprovider.CollaboratorInfo{
+				{Login: "alice", Permission: gitprovider.RepositoryPermissionPull},
+			},
+		},
+		{
+			name: "effective",
+			opts: []gitprovider.CollaboratorListOption{&gitprovider.CollaboratorListOptions{Effective: true}},
+			want: []gitprovider.CollaboratorInfo{
+				// alice is granted read on the repository but write through her group
+				{Login: "alice", Permission: gitprovider.RepositoryPermissionPush},
+				// bob is only granted admin on the project
+				{Login: "bob", Permission: gitprovider.RepositoryPermissionAdmin},
+				// carol is a member of a group granted read on the project
+				{Login: "carol", Permission: gitprovider.RepositoryPermissionPull},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			repoURI := fmt.Sprintf("%s/%s/PRJ1/%s/repo1", stashURIprefix, projectsURI, RepositoriesURI)
+			projectURI := fmt.Sprintf("%s/%s/PRJ1", stashURIprefix, projectsURI)
+			mux.HandleFunc(fmt.Sprintf("%s/%s", repoURI, userPermisionsURI), func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(&RepositoryUsers{
+					Paging: Paging{IsLastPage: true},
+					Users: []*RepositoryUserPermission{
+						{User: User{Name: "alice"}, Permission: stashPermissionRead},
+					},
+				})
+			})
+			mux.HandleFunc(fmt.Sprintf("%s/%s", projectURI, userPermisionsURI), func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(&ProjectUsers{
+					Paging: Paging{IsLastPage: true},
+					Users: []*ProjectUserPermission{
+						{User: User{Name: "bob"}, Permission: stashPermissionProjectAdmin},
+					},
+				})
+			})
+			mux.HandleFunc(fmt.Sprintf("%s/%s", repoURI, groupPermisionsURI), func(w http.ResponseWriter, r *http.Request) {
+				perm := &RepositoryGroupPermission{Permission: stashPermissionWrite}
+				perm.Group.Name = "developers"
+				json.NewEncoder(w).Encode(&RepositoryGroups{
+					Paging: Paging{IsLastPage: true},
+					Groups: []*RepositoryGroupPermission{perm},
+				})
+			})
+			mux.HandleFunc(fmt.Sprintf("%s/%s", projectURI, groupPermisionsURI), func(w http.ResponseWriter, r *http.Request) {
+				perm := &ProjectGroupPermission{Permission: stashPermissionProjectRead}
+				perm.Group.Name = "readers"
+				json.NewEncoder(w).Encode(&ProjectGroups{
+					Paging: Paging{IsLastPage: true},
+					Groups: []*ProjectGroupPermission{perm},
+				})
+			})
+			members := map[string][]*User{
+				"developers": {{Name: "alice"}},
+				"readers":    {{Name: "carol"}},
+			}
+			mux.HandleFunc(fmt.Sprintf("%s/%s", stashURIprefix, groupMembersURI), func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(&GroupMembers{
+					Paging: Paging{IsLastPage: true},
+					Users:  members[r.URL.Query().Get(contextKey)],
+				})
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"},
+				RepositoryName:  "repo1",
+			}
+			ref.SetKey("PRJ1")
+			ref.SetSlug("repo1")
+			c := &CollaboratorClient{
+				clientContext: &clientContext{client: client, host: "stash.example.com", log: logr.Discard()},
+				ref:           ref,
+			}
+			got, err := c.List(context.Background(), tt.opts...)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("List() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
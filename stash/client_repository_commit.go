@@ -18,11 +18,22 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
+const (
+	// commitFilterPageSize is the number of commits listed per request when filtering them
+	// client-side.
+	commitFilterPageSize = 100
+	// commitDefaultPageSize is the default number of commits per page of Bitbucket Server.
+	commitDefaultPageSize = 25
+)
+
 // CommitClient implements the gitprovider.CommitClient interface.
 var _ gitprovider.CommitClient = &CommitClient{}
 
@@ -34,7 +45,51 @@ type CommitClient struct {
 
 // ListPage lists repository commits of the given page and page size.
 func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, page int) ([]gitprovider.Commit, error) {
-	commitList, err := c.listPage(ctx, branch, perPage, page)
+	if err := gitprovider.ValidatePage(perPage, page); err != nil {
+		return nil, err
+	}
+	return c.List(ctx, gitprovider.CommitListOptions{
+		Branch:  branch,
+		PerPage: perPage,
+		Page:    page,
+	})
+}
+
+// Get returns the commit with the given SHA.
+//
+// ErrNotFound is returned if the commit does not exist.
+func (c *CommitClient) Get(ctx context.Context, sha string) (gitprovider.Commit, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	apiObj, err := c.client.Commits.Get(ctx, projectKey, repoSlug, sha)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get commit %s: %w", sha, err)
+	}
+	return newCommit(apiObj), nil
+}
+
+// List lists the repository commits matching the given options.
+// Bitbucket Server doesn't support the author and time filters, so when any of them is set, all
+// commits are listed up to the first one preceding Since, or the whole history without Since, and
+// the requested page is cut from the matching commits. This costs a request per
+// commitFilterPageSize commits. Filtering by path is not supported.
+func (c *CommitClient) List(ctx context.Context, opts gitprovider.CommitListOptions) ([]gitprovider.Commit, error) {
+	if err := opts.ValidateOptions(); err != nil {
+		return nil, err
+	}
+	if opts.Path != "" {
+		return nil, fmt.Errorf("listing commits by path: %w", gitprovider.ErrNoProviderSupport)
+	}
+	commitList, err := c.list(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list commits: %w", err)
 	}
@@ -46,7 +101,7 @@ func (c *CommitClient) ListPage(ctx context.Context, branch string, perPage, pag
 	return commits, nil
 }
 
-func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, page int) ([]*commitType, error) {
+func (c *CommitClient) list(ctx context.Context, opts gitprovider.CommitListOptions) ([]*commitType, error) {
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -55,7 +110,13 @@ func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, pag
 		projectKey = addTilde(r.UserLogin)
 	}
 
-	apiObjs, err := c.client.Commits.ListPage(ctx, projectKey, repoSlug, branch, perPage, page)
+	var apiObjs []*CommitObject
+	var err error
+	if opts.HasFilters() {
+		apiObjs, err = c.listMatching(ctx, projectKey, repoSlug, opts)
+	} else {
+		apiObjs, err = c.client.Commits.ListPage(ctx, projectKey, repoSlug, opts.Branch, opts.PerPage, opts.Page)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +129,78 @@ func (c *CommitClient) listPage(ctx context.Context, branch string, perPage, pag
 	return commits, nil
 }
 
+// listMatching lists all commits passing the author and time filters of opts, and returns the
+// page of them selected by opts.
+func (c *CommitClient) listMatching(ctx context.Context, projectKey, repoSlug string, opts gitprovider.CommitListOptions) ([]*CommitObject, error) {
+	matching := []*CommitObject{}
+	paging := &PagingOptions{Limit: commitFilterPageSize}
+	err := allPages(ctx, paging, func(ctx context.Context) (*Paging, error) {
+		list, err := c.client.Commits.List(ctx, projectKey, repoSlug, opts.Branch, paging)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiObj := range list.Commits {
+			createdAt := commitFromAPI(*apiObj).CreatedAt
+			if opts.Before(createdAt) {
+				// Stop listing at the first commit preceding Since
+				return &Paging{IsLastPage: true}, nil
+			}
+			if opts.Matches(createdAt, apiObj.Author.Name, apiObj.Author.EmailAddress, apiObj.Author.Slug) {
+				matching = append(matching, apiObj)
+			}
+		}
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	start, end := opts.PageBounds(len(matching), commitDefaultPageSize)
+	return matching[start:end], nil
+}
+
+// listCommitDates returns the creation dates of the commits of branch created at or after since.
+// As Bitbucket Server lists the commits in topological order, the older commits are filtered out
+// across all pages, listing at most gitprovider.CommitActivityMaxCommits commits.
+func (c *CommitClient) listCommitDates(ctx context.Context, branch string, since time.Time) ([]time.Time, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	dates := []time.Time{}
+	listed := 0
+	paging := &PagingOptions{Limit: commitFilterPageSize}
+	err := allPages(ctx, paging, func(ctx context.Context) (*Paging, error) {
+		list, err := c.client.Commits.List(ctx, projectKey, repoSlug, branch, paging)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiObj := range list.Commits {
+			if createdAt := commitFromAPI(*apiObj).CreatedAt; !createdAt.Before(since) {
+				dates = append(dates, createdAt)
+			}
+		}
+		if listed += len(list.Commits); listed >= gitprovider.CommitActivityMaxCommits {
+			return &Paging{IsLastPage: true}, nil
+		}
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
 // Create creates a commit with the given specifications.
-func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile) (gitprovider.Commit, error) {
+func (c *CommitClient) Create(ctx context.Context, branch string, message string, files []gitprovider.CommitFile, opts ...gitprovider.CommitCreateOption) (gitprovider.Commit, error) {
+	if err := gitprovider.ValidateCommitFiles(files); err != nil {
+		return nil, err
+	}
+	o := gitprovider.MakeCommitCreateOptions(opts...)
+	if err := o.ValidateOptions(); err != nil {
+		return nil, err
+	}
+
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -82,12 +213,27 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repository %s/%s: %w", projectKey, repoSlug, err)
 	}
+	if len(o.Parents) > 0 {
+		// The clone below commits on top of the branch, hence push the commit through Git
+		return gitprovider.CommitOnParents(ctx, getRepoHTTPref(repo.Links.Clone), branch, message, files, o, c.client.gitTransport())
+	}
 
 	user, err := c.client.Users.Get(ctx, repo.Session.UserName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user %s: %w", repo.Session.UserName, err)
 	}
 
+	// The push below isn't forced, hence it also fails if the branch moved after this check
+	if o.ExpectedHeadSHA != "" {
+		head, err := c.ResolveRef(ctx, branch)
+		if err != nil {
+			return nil, err
+		}
+		if err := o.CheckHead(head); err != nil {
+			return nil, err
+		}
+	}
+
 	url := getRepoHTTPref(repo.Links.Clone)
 	r, dir, err := c.client.Git.CloneRepository(ctx, url)
 	if err != nil {
@@ -98,14 +244,30 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 	for _, file := range files {
 		f = append(f, CommitFile{Path: file.Path, Content: file.Content})
 	}
-	commit, err := NewCommit(
-		WithAuthor(&CommitAuthor{
-			Name:  user.Name,
-			Email: user.EmailAddress,
-		}),
+	author := &CommitAuthor{
+		Name:  user.Name,
+		Email: user.EmailAddress,
+	}
+	if o.AuthorName != "" {
+		author.Name, author.Email = o.AuthorName, o.AuthorEmail
+	}
+	if o.AuthorDate != nil {
+		author.Date = o.AuthorDate.Unix()
+	}
+	commitOpts := []GitCommitOptionsFunc{
+		WithAuthor(author),
 		WithMessage(message),
 		WithURL(url),
-		WithFiles(f))
+		WithFiles(f),
+	}
+	if o.CommitterDate != nil {
+		commitOpts = append(commitOpts, WithCommitter(&CommitAuthor{
+			Name:  author.Name,
+			Email: author.Email,
+			Date:  o.CommitterDate.Unix(),
+		}))
+	}
+	commit, err := NewCommit(commitOpts...)
 
 	result, err := c.client.Git.CreateCommit(dir, r, branch, commit)
 	if err != nil {
@@ -129,3 +291,67 @@ func (c *CommitClient) Create(ctx context.Context, branch string, message string
 
 	return newCommit(sha), nil
 }
+
+// SignatureKey returns the ID of the key that signed the commit with the given SHA.
+// Bitbucket Server doesn't expose commit signatures, so this returns ErrNoProviderSupport.
+func (c *CommitClient) SignatureKey(_ context.Context, _ string) (string, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
+// ResolveRef resolves a branch, a tag or an abbreviated commit SHA to the full SHA of its commit.
+func (c *CommitClient) ResolveRef(ctx context.Context, ref string) (string, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	commit, err := c.client.Commits.Get(ctx, projectKey, repoSlug, ref)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return "", gitprovider.ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get commit %s: %w", ref, err)
+	}
+	return commit.ID, nil
+}
+
+// ContainedInBranches returns the names of the branches containing the commit with the given SHA.
+func (c *CommitClient) ContainedInBranches(ctx context.Context, sha string) ([]string, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	// Make sure the commit exists, and expand abbreviated SHAs
+	sha, err := c.ResolveRef(ctx, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	apiObjs, err := c.client.Branches.AllContaining(ctx, projectKey, repoSlug, sha)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list the branches containing commit %s: %w", sha, err)
+	}
+
+	branches := make([]string, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		branches = append(branches, apiObj.DisplayID)
+	}
+	sort.Strings(branches)
+	return branches, nil
+}
+
+// CheckAnnotations lists the check annotations of the commit with the given SHA.
+// Bitbucket Server doesn't have check annotations, so this returns ErrNoProviderSupport.
+func (c *CommitClient) CheckAnnotations(_ context.Context, _ string) ([]gitprovider.Annotation, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestCommitClient_List_FilterAcrossPages(t *testing.T) {
+	mux, client := setup(t)
+
+	now := time.Now()
+	commit := func(id, author string, age time.Duration) *CommitObject {
+		return &CommitObject{ID: id, Author: User{Name: author}, AuthorTimestamp: now.Add(-age).UnixMilli()}
+	}
+	pages := map[string]*CommitList{
+		"": {
+			Paging:  Paging{NextPageStart: 3},
+			Commits: []*CommitObject{commit("c1", "alice", time.Hour), commit("c2", "bob", 2*time.Hour), commit("c3", "alice", 3*time.Hour)},
+		},
+		"3": {
+			Paging:  Paging{NextPageStart: 6},
+			Commits: []*CommitObject{commit("c4", "alice", 4*time.Hour), commit("c5", "alice", 6*time.Hour), commit("c6", "alice", 7*time.Hour)},
+		},
+	}
+	var starts []string
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, commitsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		start := r.URL.Query().Get("start")
+		starts = append(starts, start)
+		if got := r.URL.Query().Get("limit"); got != "100" {
+			t.Errorf("limit = %q, want %q", got, "100")
+		}
+		page, ok := pages[start]
+		if !ok {
+			http.Error(w, "unexpected page", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "prj1"},
+		RepositoryName:  "repo1",
+	}
+	ref.SetKey("prj1")
+	ref.SetSlug("repo1")
+	c := &CommitClient{clientContext: &clientContext{client: client, host: "stash.example.com", log: logr.Discard()}, ref: ref}
+	since := now.Add(-5 * time.Hour)
+	commits, err := c.List(context.Background(), gitprovider.CommitListOptions{
+		Author:  "alice",
+		Since:   &since,
+		PerPage: 2,
+		Page:    2,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	got := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		got = append(got, commit.Get().Sha)
+	}
+	if diff := cmp.Diff([]string{"c4"}, got); diff != "" {
+		t.Errorf("List() (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"", "3"}, starts); diff != "" {
+		t.Errorf("listed pages (-want +got):\n%s", diff)
+	}
+}
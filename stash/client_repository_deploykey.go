@@ -177,6 +177,13 @@ func (c *DeployKeyClient) Reconcile(ctx context.Context, req gitprovider.DeployK
 	return actual, true, nil
 }
 
+// ReconcileBatch makes sure all the given desired deploy keys (reqs) become the actual state in the
+// backing Git provider, listing the existing deploy keys only once.
+// See gitprovider.ReconcileDeployKeys for details.
+func (c *DeployKeyClient) ReconcileBatch(ctx context.Context, reqs []gitprovider.DeployKeyInfo) ([]gitprovider.DeployKeyBatchResult, error) {
+	return gitprovider.ReconcileDeployKeys(ctx, c, reqs)
+}
+
 // update will apply the desired state in this object to the server.
 // ErrNotFound is returned if the resource does not exist.
 func (c *DeployKeyClient) update(ctx context.Context, req gitprovider.DeployKeyInfo) (*DeployKey, error) {
@@ -262,9 +269,11 @@ func validateDeployKeyAPI(apiObj *DeployKey) error {
 
 func deployKeyFromAPI(apiObj *DeployKey) gitprovider.DeployKeyInfo {
 	deRefBool := apiObj.Permission == stashPermissionRead
-	return gitprovider.DeployKeyInfo{
+	info := gitprovider.DeployKeyInfo{
 		Name:     apiObj.Key.Label,
 		Key:      []byte(apiObj.Key.Text),
 		ReadOnly: &deRefBool,
 	}
+	info.ComputeFingerprint()
+	return info
 }
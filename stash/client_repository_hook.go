@@ -0,0 +1,209 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/hashicorp/go-multierror"
+)
+
+// HookClient implements the gitprovider.HookClient interface.
+var _ gitprovider.HookClient = &HookClient{}
+
+// HookClient operates on the webhooks of a specific repository.
+type HookClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// Get returns the webhook with the given URL.
+// ErrNotFound is returned if the resource does not exist.
+func (c *HookClient) Get(ctx context.Context, url string) (gitprovider.DeployHook, error) {
+	webhook, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook %q: %w", url, err)
+	}
+	return newDeployHook(c, webhook), nil
+}
+
+func (c *HookClient) get(ctx context.Context, url string) (*Webhook, error) {
+	webhooks, err := c.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Loop through the webhooks until we find one with the right URL
+	for _, w := range webhooks {
+		if w.URL == url {
+			return w, nil
+		}
+	}
+	return nil, gitprovider.ErrNotFound
+}
+
+// List lists all webhooks of the repository.
+// List returns all available webhooks, using multiple paginated requests if needed.
+func (c *HookClient) List(ctx context.Context) ([]gitprovider.DeployHook, error) {
+	apiObjs, err := c.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	// Cast to the generic []gitprovider.DeployHook
+	hooks := make([]gitprovider.DeployHook, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		hooks = append(hooks, newDeployHook(c, apiObj))
+	}
+	return hooks, nil
+}
+
+func (c *HookClient) list(ctx context.Context) ([]*Webhook, error) {
+	projectKey, repoSlug := c.stashRefs()
+	apiObjs, err := c.client.Webhooks.All(ctx, projectKey, repoSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	for _, apiObj := range apiObjs {
+		if err := validateWebhookAPI(apiObj); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+	return apiObjs, nil
+}
+
+// Create creates a webhook with the given specifications.
+//
+// ErrAlreadyExists will be returned if a webhook with the same URL already exists.
+func (c *HookClient) Create(ctx context.Context, req gitprovider.DeployHookInfo) (gitprovider.DeployHook, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.get(ctx, req.URL); err == nil {
+		return nil, gitprovider.ErrAlreadyExists
+	} else if !errors.Is(err, gitprovider.ErrNotFound) {
+		return nil, fmt.Errorf("failed to create webhook %q: %w", req.URL, err)
+	}
+
+	webhook := &Webhook{}
+	if err := deployHookInfoToAPIObj(&req, webhook); err != nil {
+		return nil, err
+	}
+	apiObj, err := c.create(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook %q: %w", req.URL, err)
+	}
+	return newDeployHook(c, apiObj), nil
+}
+
+// Reconcile makes sure the given desired state (req) becomes the actual state in the backing Git provider.
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+func (c *HookClient) Reconcile(ctx context.Context, req gitprovider.DeployHookInfo) (gitprovider.DeployHook, bool, error) {
+	// First thing, validate and default the request to ensure a valid and fully-populated object
+	// (to minimize any possible diffs between desired and actual state)
+	if err := gitprovider.ValidateAndDefaultInfo(&req); err != nil {
+		return nil, false, err
+	}
+
+	actual, err := c.Get(ctx, req.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			resp, err := c.Create(ctx, req)
+			return resp, true, err
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return nil, false, fmt.Errorf("failed to reconcile webhook %q: %w", req.URL, err)
+	}
+
+	desired, err := desiredDeployHookInfo(req)
+	if err != nil {
+		return nil, false, err
+	}
+	// If the desired matches the actual state, just return the actual state
+	if desired.Equals(actual.Get()) {
+		return actual, false, nil
+	}
+
+	// Populate the desired state to the current-actual object
+	if err := actual.Set(req); err != nil {
+		return actual, false, err
+	}
+	// Apply the desired state by running Update
+	return actual, true, actual.Update(ctx)
+}
+
+func (c *HookClient) create(ctx context.Context, webhook *Webhook) (*Webhook, error) {
+	projectKey, repoSlug := c.stashRefs()
+	apiObj, err := c.client.Webhooks.Create(ctx, projectKey, repoSlug, webhook)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *HookClient) update(ctx context.Context, webhook *Webhook) (*Webhook, error) {
+	projectKey, repoSlug := c.stashRefs()
+	apiObj, err := c.client.Webhooks.Update(ctx, projectKey, repoSlug, webhook.ID, webhook)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, err
+	}
+	if err := validateWebhookAPI(apiObj); err != nil {
+		return nil, err
+	}
+	return apiObj, nil
+}
+
+func (c *HookClient) delete(ctx context.Context, webhookID int) error {
+	projectKey, repoSlug := c.stashRefs()
+	if err := c.client.Webhooks.Delete(ctx, projectKey, repoSlug, webhookID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// stashRefs returns the project key and the slug of the repository,
+// the project key of user repositories being the user login prefixed with a tilde.
+func (c *HookClient) stashRefs() (string, string) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+	return projectKey, repoSlug
+}
@@ -18,12 +18,22 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 	"github.com/fluxcd/go-git-providers/validation"
 )
 
+const (
+	// mergeabilityPollInterval is the interval at which the merge status of a pull request is
+	// polled while Bitbucket Server computes it.
+	mergeabilityPollInterval = 2 * time.Second
+	// mergeOutcomeUnknown is the merge outcome of pull requests whose merge status isn't computed yet.
+	mergeOutcomeUnknown = "UNKNOWN"
+)
+
 // PullRequestClient implements the gitprovider.PullRequestClient interface.
 var _ gitprovider.PullRequestClient = &PullRequestClient{}
 
@@ -52,7 +62,11 @@ func (c *PullRequestClient) Get(ctx context.Context, number int) (gitprovider.Pu
 }
 
 // List returns all pull requests for the given repository.
-func (c *PullRequestClient) List(ctx context.Context) ([]gitprovider.PullRequest, error) {
+// Bitbucket Server pull requests have no labels, ErrNoProviderSupport is returned if a labels filter is set.
+func (c *PullRequestClient) List(ctx context.Context, opts ...gitprovider.PullRequestListOption) ([]gitprovider.PullRequest, error) {
+	if o := gitprovider.MakePullRequestListOptions(opts...); len(o.Labels) > 0 {
+		return nil, gitprovider.ErrNoProviderSupport
+	}
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -103,8 +117,243 @@ func (c *PullRequestClient) Merge(ctx context.Context, number int, _ gitprovider
 
 }
 
+// MergeabilityStatus returns whether the pull request can be merged, and why not.
+// The merge checks vetoing the merge, e.g. missing approvals or failed builds, are reported as the
+// failing checks. The pull request is polled while Bitbucket Server computes the merge outcome.
+func (c *PullRequestClient) MergeabilityStatus(ctx context.Context, number int) (gitprovider.MergeableInfo, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	return gitprovider.PollMergeability(ctx, mergeabilityPollInterval, func(ctx context.Context) (gitprovider.MergeableInfo, error) {
+		status, err := c.client.PullRequests.CanMerge(ctx, projectKey, repoSlug, number)
+		if err != nil {
+			return gitprovider.MergeableInfo{}, fmt.Errorf("failed to get merge status: %w", err)
+		}
+		if status.Outcome == mergeOutcomeUnknown {
+			return gitprovider.MergeableInfo{}, nil
+		}
+
+		info := gitprovider.MergeableInfo{
+			Mergeable: gitprovider.BoolVar(status.CanMerge),
+			Conflicts: status.Conflicted,
+		}
+		for _, veto := range status.Vetoes {
+			info.FailingChecks = append(info.FailingChecks, veto.SummaryMessage)
+		}
+		return info, nil
+	})
+}
+
+// PreviewRebase returns whether rebasing the pull request would conflict, from the merge outcome
+// Bitbucket Server computes for it. Bitbucket Server doesn't report the conflicting files.
+func (c *PullRequestClient) PreviewRebase(ctx context.Context, number int) (gitprovider.RebasePreview, error) {
+	info, err := c.MergeabilityStatus(ctx, number)
+	if err != nil {
+		return gitprovider.RebasePreview{}, err
+	}
+	return gitprovider.RebasePreview{Conflicts: info.Conflicts}, nil
+}
+
+// ReviewSummary returns the current verdicts of the reviewers of the pull request.
+// Bitbucket Server only keeps the current status of every reviewer, which is either approved,
+// needs work or unapproved.
+func (c *PullRequestClient) ReviewSummary(ctx context.Context, number int) (gitprovider.ReviewSummary, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	pr, err := c.client.PullRequests.Get(ctx, projectKey, repoSlug, number)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.ReviewSummary{}, gitprovider.ErrNotFound
+		}
+		return gitprovider.ReviewSummary{}, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	reviews := make([]gitprovider.ReviewInfo, 0, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		switch reviewer.Status {
+		case "APPROVED":
+			reviews = append(reviews, gitprovider.ReviewInfo{Reviewer: reviewer.Name, State: gitprovider.ReviewStateApproved})
+		case "NEEDS_WORK":
+			reviews = append(reviews, gitprovider.ReviewInfo{Reviewer: reviewer.Name, State: gitprovider.ReviewStateChangesRequested})
+		}
+	}
+	return gitprovider.SummarizeReviews(reviews), nil
+}
+
+// Diff returns the changes of the pull request as a unified diff.
+func (c *PullRequestClient) Diff(ctx context.Context, number int) ([]byte, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	diff, err := c.client.PullRequests.Diff(ctx, projectKey, repoSlug, number)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get pull request diff: %w", err)
+	}
+	return diff, nil
+}
+
+// ListCommits lists the commits of the pull request.
+func (c *PullRequestClient) ListCommits(ctx context.Context, number int) ([]gitprovider.Commit, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	apiObjs, err := c.client.PullRequests.AllCommits(ctx, projectKey, repoSlug, number)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+	}
+
+	commits := make([]gitprovider.Commit, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		commits = append(commits, newCommit(apiObj))
+	}
+	return commits, nil
+}
+
+// CreateComment posts a comment on the overview of the pull request.
+func (c *PullRequestClient) CreateComment(ctx context.Context, number int, body string) (gitprovider.PullRequestComment, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	apiObj, err := c.client.PullRequests.CreateComment(ctx, projectKey, repoSlug, number, body)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.PullRequestComment{}, gitprovider.ErrNotFound
+		}
+		return gitprovider.PullRequestComment{}, fmt.Errorf("failed to create pull request comment: %w", err)
+	}
+	return pullRequestCommentFromAPI(apiObj), nil
+}
+
+// ListComments lists the comments on the overview of the pull request. Replies to the comments
+// are nested in them, hence not listed.
+func (c *PullRequestClient) ListComments(ctx context.Context, number int) ([]gitprovider.PullRequestComment, error) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	apiObjs, err := c.client.PullRequests.AllComments(ctx, projectKey, repoSlug, number)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list pull request comments: %w", err)
+	}
+
+	comments := make([]gitprovider.PullRequestComment, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		comments = append(comments, pullRequestCommentFromAPI(apiObj))
+	}
+	return comments, nil
+}
+
+// Review submits a review of the pull request as the authenticated user. Bitbucket Server has no
+// reviews as such: approving or requesting changes sets the status of the user as a participant,
+// and the body is posted as a comment, while commenting only posts the comment. The API object of
+// the review is the *Participant, or the *Comment.
+func (c *PullRequestClient) Review(ctx context.Context, number int, action gitprovider.ReviewAction, body string) (gitprovider.PullRequestReview, error) {
+	if err := gitprovider.ValidateReview(action, body); err != nil {
+		return nil, err
+	}
+	projectKey, repoSlug := getStashRefs(c.ref)
+
+	// check if it is a user repository
+	// if yes, we need to add a tilde to the user login and use it as the project key
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+
+	var comment *Comment
+	if body != "" {
+		var err error
+		comment, err = c.client.PullRequests.CreateComment(ctx, projectKey, repoSlug, number, body)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return nil, gitprovider.ErrNotFound
+			}
+			return nil, fmt.Errorf("failed to create pull request comment: %w", err)
+		}
+	}
+	if action == gitprovider.ReviewComment {
+		return newPullRequestReview(comment, comment.Author.Name, gitprovider.ReviewStateCommented), nil
+	}
+
+	status, state := participantApproved, gitprovider.ReviewStateApproved
+	if action == gitprovider.ReviewRequestChanges {
+		status, state = participantNeedsWork, gitprovider.ReviewStateChangesRequested
+	}
+	participant, err := c.client.PullRequests.UpdateParticipantStatus(ctx, projectKey, repoSlug, number, c.client.username, status)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to review pull request: %w", err)
+	}
+	return newPullRequestReview(participant, participant.Name, state), nil
+}
+
+// AddLabels is not supported, as Bitbucket Server pull requests have no labels.
+// ErrNoProviderSupport is returned.
+func (c *PullRequestClient) AddLabels(_ context.Context, _ int, _ []string) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// ListLabels is not supported, as Bitbucket Server pull requests have no labels.
+// ErrNoProviderSupport is returned.
+func (c *PullRequestClient) ListLabels(_ context.Context, _ int) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+func pullRequestCommentFromAPI(apiObj *Comment) gitprovider.PullRequestComment {
+	return gitprovider.PullRequestComment{
+		ID:        apiObj.ID,
+		Author:    apiObj.Author.Name,
+		Body:      apiObj.Text,
+		CreatedAt: time.UnixMilli(apiObj.CreatedDate),
+	}
+}
+
 // Create creates a pull request with the given specifications.
-func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string) (gitprovider.PullRequest, error) {
+// Bitbucket Server has no draft pull requests, ErrNoProviderSupport is returned if one is requested.
+func (c *PullRequestClient) Create(ctx context.Context, title, branch, baseBranch, description string, opts ...gitprovider.PullRequestCreateOption) (gitprovider.PullRequest, error) {
+	if o := gitprovider.MakePullRequestCreateOptions(opts...); o.Draft {
+		return nil, gitprovider.ErrNoProviderSupport
+	}
 	projectKey, repoSlug := getStashRefs(c.ref)
 
 	// check if it is a user repository
@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// TagClient implements the gitprovider.TagClient interface.
+var _ gitprovider.TagClient = &TagClient{}
+
+// TagClient operates on the tags of a specific repository.
+type TagClient struct {
+	*clientContext
+	ref gitprovider.RepositoryRef
+}
+
+// List lists all the tags of the repository.
+// Bitbucket Server doesn't expose the tagger of annotated tags, so the creation date of all
+// tags is the date of the commit they point to, which is fetched for each tag.
+func (c *TagClient) List(ctx context.Context) ([]gitprovider.TagInfo, error) {
+	projectKey, repoSlug := c.stashRefs()
+	apiObjs, err := c.client.Tags.All(ctx, projectKey, repoSlug)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, gitprovider.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := make([]gitprovider.TagInfo, 0, len(apiObjs))
+	for _, apiObj := range apiObjs {
+		tags = append(tags, tagFromAPI(apiObj))
+	}
+
+	if err := gitprovider.ResolveTags(ctx, tags, c.resolveCreatedAt); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Get returns the tag with the given name.
+// Bitbucket Server doesn't expose the message of annotated tags.
+func (c *TagClient) Get(ctx context.Context, name string) (gitprovider.TagInfo, error) {
+	projectKey, repoSlug := c.stashRefs()
+	apiObj, err := c.client.Tags.Get(ctx, projectKey, repoSlug, name)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.TagInfo{}, gitprovider.ErrNotFound
+		}
+		return gitprovider.TagInfo{}, fmt.Errorf("failed to get tag %s: %w", name, err)
+	}
+
+	tag := tagFromAPI(apiObj)
+	if err := c.resolveCreatedAt(ctx, &tag); err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	return tag, nil
+}
+
+// Create creates a tag pointing to commitSHA. Bitbucket Server creates an annotated tag if a
+// message is given, and a lightweight tag otherwise.
+func (c *TagClient) Create(ctx context.Context, name, commitSHA, message string) (gitprovider.TagInfo, error) {
+	projectKey, repoSlug := c.stashRefs()
+	apiObj, err := c.client.Tags.Create(ctx, projectKey, repoSlug, name, commitSHA, message)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return gitprovider.TagInfo{}, gitprovider.ErrAlreadyExists
+		}
+		if errors.Is(err, ErrNotFound) {
+			return gitprovider.TagInfo{}, gitprovider.ErrNotFound
+		}
+		return gitprovider.TagInfo{}, fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+
+	tag := tagFromAPI(apiObj)
+	if tag.Annotated {
+		tag.Message = message
+	}
+	if err := c.resolveCreatedAt(ctx, &tag); err != nil {
+		return gitprovider.TagInfo{}, err
+	}
+	return tag, nil
+}
+
+// resolveCreatedAt sets the creation date of the tag to the date of the commit it points to.
+func (c *TagClient) resolveCreatedAt(ctx context.Context, tag *gitprovider.TagInfo) error {
+	projectKey, repoSlug := c.stashRefs()
+	commit, err := c.client.Commits.Get(ctx, projectKey, repoSlug, tag.SHA)
+	if err != nil {
+		return fmt.Errorf("failed to get commit %s of tag %s: %w", tag.SHA, tag.Name, err)
+	}
+	tag.CreatedAt = time.UnixMilli(commit.CommitterTimestamp)
+	return nil
+}
+
+// stashRefs returns the project key and slug of the repository. The project key of a user
+// repository is the user login prefixed with a tilde.
+func (c *TagClient) stashRefs() (string, string) {
+	projectKey, repoSlug := getStashRefs(c.ref)
+	if r, ok := c.ref.(gitprovider.UserRepositoryRef); ok {
+		projectKey = addTilde(r.UserLogin)
+	}
+	return projectKey, repoSlug
+}
+
+// tagFromAPI converts a Bitbucket Server tag, whose hash is only set for annotated tags.
+func tagFromAPI(apiObj *Tag) gitprovider.TagInfo {
+	return gitprovider.TagInfo{
+		Name:      apiObj.DisplayID,
+		SHA:       apiObj.LatestCommit,
+		Annotated: apiObj.Hash != "",
+	}
+}
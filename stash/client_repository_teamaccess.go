@@ -258,3 +258,12 @@ func (c *TeamAccessClient) Reconcile(ctx context.Context,
 
 	return actual, true, nil
 }
+
+// ReconcileAll makes sure exactly the given teams (reqs) have access to the repository, with the
+// given permissions. The access of the other teams is removed if prune is true, which requires
+// the client to allow destructive API calls. Bitbucket Server can't remove the access of a
+// team through its API, so pruned teams get ErrNoProviderSupport in their result.
+// See gitprovider.ReconcileAllTeamAccess for details.
+func (c *TeamAccessClient) ReconcileAll(ctx context.Context, reqs []gitprovider.TeamAccessInfo, prune bool) ([]gitprovider.TeamAccessBatchResult, error) {
+	return gitprovider.ReconcileAllTeamAccess(ctx, c, reqs, prune, c.destructiveActions)
+}
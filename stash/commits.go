@@ -122,12 +122,12 @@ func (s *CommitsService) List(ctx context.Context, projectKey, repositorySlug, b
 	return c, nil
 }
 
-// ListPage retrieves all commits for a given page.
+// ListPage retrieves all commits for a given 1-based page.
 // This function handles pagination, HTTP error wrapping, and validates the server result.
 func (s *CommitsService) ListPage(ctx context.Context, projectKey, repositorySlug, branch string, perPage, page int) ([]*CommitObject, error) {
 	start := 0
-	if page > 0 {
-		start = (perPage * page) + 1
+	if page > 1 {
+		start = perPage * (page - 1)
 	}
 
 	opts := &PagingOptions{Limit: int64(perPage), Start: int64(start)}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	stashURIdefaultReviewers = "/rest/default-reviewers/1.0"
+)
+
+// DefaultReviewers interface defines the methods for working with
+// the default reviewers conditions of a repository.
+type DefaultReviewers interface {
+	List(ctx context.Context, projectKey, repositorySlug string) ([]*DefaultReviewersCondition, error)
+}
+
+// DefaultReviewersService is a client for communicating with stash default reviewers endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/7.14.0/bitbucket-default-reviewers-rest.html
+type DefaultReviewersService service
+
+// DefaultReviewersCondition adds reviewers to the pull requests from and to the refs it matches.
+type DefaultReviewersCondition struct {
+	// Session is the session object for the condition.
+	Session `json:"sessionInfo,omitempty"`
+	// ID is the unique ID of the condition.
+	ID int64 `json:"id,omitempty"`
+	// SourceRefMatcher matches the source refs of the pull requests.
+	SourceRefMatcher RefMatcher `json:"sourceRefMatcher,omitempty"`
+	// TargetRefMatcher matches the target refs of the pull requests.
+	TargetRefMatcher RefMatcher `json:"targetRefMatcher,omitempty"`
+	// Reviewers are the users added as reviewers.
+	Reviewers []User `json:"reviewers,omitempty"`
+	// RequiredApprovals is the number of reviewers which must approve the pull requests.
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+}
+
+// List returns the default reviewers conditions of the repository, including the conditions
+// inherited from its project. The endpoint isn't paginated, all conditions are returned at once.
+// List uses the endpoint "GET /rest/default-reviewers/1.0/projects/{projectKey}/repos/{repositorySlug}/conditions".
+// https://docs.atlassian.com/bitbucket-server/rest/7.14.0/bitbucket-default-reviewers-rest.html
+func (s *DefaultReviewersService) List(ctx context.Context, projectKey, repositorySlug string) ([]*DefaultReviewersCondition, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newDefaultReviewersURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, conditionsURI))
+	if err != nil {
+		return nil, fmt.Errorf("list default reviewers request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list default reviewers failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("list default reviewers failed: %s: %w", resp.Status, ErrBadRequest)
+	}
+
+	conditions := []*DefaultReviewersCondition{}
+	if err := json.Unmarshal(res, &conditions); err != nil {
+		return nil, fmt.Errorf("list default reviewers failed, unable to unmarshall json: %w", err)
+	}
+
+	for _, condition := range conditions {
+		condition.Session.set(resp)
+	}
+
+	return conditions, nil
+}
+
+func newDefaultReviewersURI(elements ...string) string {
+	return strings.Join(append([]string{stashURIdefaultReviewers}, elements...), "/")
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func newTestDefaultReviewersConditions() []*DefaultReviewersCondition {
+	return []*DefaultReviewersCondition{
+		{
+			ID:                1,
+			SourceRefMatcher:  RefMatcher{ID: "ANY_REF_MATCHER_ID", Type: RefMatcherType{ID: RefMatcherTypeAnyRef}},
+			TargetRefMatcher:  RefMatcher{ID: "refs/heads/main", DisplayID: "main", Type: RefMatcherType{ID: RefMatcherTypeBranch}},
+			Reviewers:         []User{{Name: "alice"}, {Name: "bob"}},
+			RequiredApprovals: 1,
+		},
+		{
+			ID:               2,
+			SourceRefMatcher: RefMatcher{ID: "ANY_REF_MATCHER_ID", Type: RefMatcherType{ID: RefMatcherTypeAnyRef}},
+			TargetRefMatcher: RefMatcher{ID: "ANY_REF_MATCHER_ID", Type: RefMatcherType{ID: RefMatcherTypeAnyRef}},
+			Reviewers:        []User{{Name: "bob"}, {Name: "carol"}},
+		},
+	}
+}
+
+func TestListDefaultReviewers(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/PRJ1/%s/repo1/%s", stashURIdefaultReviewers, projectsURI, RepositoriesURI, conditionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(newTestDefaultReviewersConditions())
+	})
+
+	got, err := client.DefaultReviewers.List(context.Background(), "PRJ1", "repo1")
+	if err != nil {
+		t.Fatalf("DefaultReviewers.List returned error: %v", err)
+	}
+	if diff := cmp.Diff(newTestDefaultReviewersConditions(), got); diff != "" {
+		t.Errorf("DefaultReviewers.List returned diff (want -> got):\n%s", diff)
+	}
+
+	if _, err := client.DefaultReviewers.List(context.Background(), "PRJ1", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DefaultReviewers.List returned error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestBranchClient_DefaultReviewers(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/PRJ1/%s/repo1/%s", stashURIdefaultReviewers, projectsURI, RepositoriesURI, conditionsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(newTestDefaultReviewersConditions())
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"},
+		RepositoryName:  "repo1",
+	}
+	ref.SetKey("PRJ1")
+	ref.SetSlug("repo1")
+	c := &BranchClient{
+		clientContext: &clientContext{client: client, host: "stash.example.com", log: logr.Discard()},
+		ref:           ref,
+	}
+
+	tests := []struct {
+		branch string
+		want   []string
+	}{
+		{branch: "main", want: []string{"alice", "bob", "carol"}},
+		{branch: "feature", want: []string{"bob", "carol"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			got, err := c.DefaultReviewers(context.Background(), tt.branch)
+			if err != nil {
+				t.Fatalf("DefaultReviewers() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DefaultReviewers() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -127,7 +127,7 @@ func (s *DeployKeysService) List(ctx context.Context, projectKey, repositorySlug
 func (s *DeployKeysService) All(ctx context.Context, projectKey, repositorySlug string) ([]*DeployKey, error) {
 	k := []*DeployKey{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
 		list, err := s.List(ctx, projectKey, repositorySlug, opts)
 		if err != nil {
 			return nil, err
@@ -33,6 +33,7 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/filesystem"
@@ -145,7 +146,7 @@ type CreateCommit struct {
 type CommitFile struct {
 	// The path of the file relative to the repository root.
 	Path *string `json:"path"`
-	// The contents of the file.
+	// The contents of the file. A nil content deletes the file.
 	Content *string `json:"content"`
 }
 
@@ -245,6 +246,7 @@ func NewCommit(opts ...GitCommitOptionsFunc) (*CreateCommit, error) {
 // CreateCommit creates a commit for the given CommitFiles. The commit is not pushed.
 // The commit is signed with the given SignKey when provided.
 // When committer is nil, author is used as the committer.
+// The author and committer are dated now unless their Date is set.
 // An optional branch name can be provided to checkout the branch before committing.
 func (s *GitService) CreateCommit(rPath string, r *git.Repository, branchName string, c *CreateCommit) (*Commit, error) {
 	if c == nil {
@@ -268,10 +270,12 @@ func (s *GitService) CreateCommit(rPath string, r *git.Repository, branchName st
 		return nil, err
 	}
 
-	// Set the committer & author DATE
+	// Date the commit now, unless dates were provided
 	now := time.Now().Unix()
-	c.Author.Date = now
-	if c.Committer != nil {
+	if c.Author.Date == 0 {
+		c.Author.Date = now
+	}
+	if c.Committer != nil && c.Committer.Date == 0 {
 		c.Committer.Date = now
 	}
 
@@ -281,7 +285,7 @@ func (s *GitService) CreateCommit(rPath string, r *git.Repository, branchName st
 	}
 
 	var parents []string
-	if obj.ParentHashes == nil {
+	if obj.ParentHashes != nil {
 		for _, parent := range obj.ParentHashes {
 			parents = append(parents, parent.String())
 		}
@@ -340,6 +344,16 @@ func (s *GitService) CloneRepository(ctx context.Context, URL string) (r *git.Re
 
 func (s *GitService) addCommitFiles(w *git.Worktree, dir string, files []CommitFile) error {
 	for _, file := range files {
+		if file.Content == nil {
+			// Removes the file from the worktree and the staging area.
+			if _, err := w.Remove(*file.Path); err != nil {
+				if errors.Is(err, index.ErrEntryNotFound) {
+					return fmt.Errorf("%q: %w", *file.Path, gitprovider.ErrFileNotFound)
+				}
+				return err
+			}
+			continue
+		}
 		err := writeCommitFile(file, dir)
 		if err != nil {
 			return err
@@ -393,6 +407,13 @@ func (s *GitService) CreateBranch(branchName string, r *git.Repository, commitID
 
 	if commitID != "" {
 		commitHash := plumbing.NewHash(commitID)
+		if gitprovider.IsShortSHA(commitID) {
+			commitID, err = gitprovider.ResolveShortSHAInRepository(r, commitID)
+			if err != nil {
+				return err
+			}
+			commitHash = plumbing.NewHash(commitID)
+		}
 		err = w.Checkout(&git.CheckoutOptions{
 			Hash: commitHash,
 		})
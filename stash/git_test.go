@@ -218,3 +218,81 @@ func TestCreateCommit(t *testing.T) {
 		t.Errorf("Message mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestCreateCommit_Date(t *testing.T) {
+	readmePath, readmeContent := "README.md", "# GO GIT REPO"
+	path, content := "testpath", "test content"
+	date := time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	initCommit := CreateCommit{
+		Author:  &CommitAuthor{Name: "user1", Email: "user1@users.com"},
+		Message: "init",
+		URL:     "https://github.com/fluxcd/go-git-providers.git",
+		Files:   []CommitFile{{Path: &readmePath, Content: &readmeContent}},
+	}
+	testCommit := CreateCommit{
+		Author:  &CommitAuthor{Name: "user1", Email: "user1@users.com", Date: date},
+		Message: "import",
+		URL:     "https://github.com/fluxcd/go-git-providers.git",
+		Files:   []CommitFile{{Path: &path, Content: &content}},
+	}
+
+	c, err := NewClient(nil, defaultHost, nil, initLogger(t))
+	if err != nil {
+		t.Fatalf("unexpected error while declaring a client: %v", err)
+	}
+	r, dir, err := c.Git.InitRepository(&initCommit, false)
+	if err != nil {
+		t.Fatalf("unexpected error while init repo: %v", err)
+	}
+	defer c.Git.Cleanup(dir)
+
+	obj, err := c.Git.CreateCommit(dir, r, "testbranch", &testCommit)
+	if err != nil {
+		t.Fatalf("unexpected error while creating a commit: %v", err)
+	}
+	// The committer defaults to the author, date included
+	if obj.Author.Date != date || obj.Committer.Date != date {
+		t.Errorf("commit dated %d (author) and %d (committer), want %d", obj.Author.Date, obj.Committer.Date, date)
+	}
+}
+
+func TestCreateCommit_Parents(t *testing.T) {
+	readmePath, readmeContent := "README.md", "# GO GIT REPO"
+	path, content := "testpath", "test content"
+
+	initCommit := CreateCommit{
+		Author:  &CommitAuthor{Name: "user1", Email: "user1@users.com"},
+		Message: "init",
+		URL:     "https://github.com/fluxcd/go-git-providers.git",
+		Files:   []CommitFile{{Path: &readmePath, Content: &readmeContent}},
+	}
+	testCommit := CreateCommit{
+		Author:  &CommitAuthor{Name: "user1", Email: "user1@users.com"},
+		Message: "add testpath",
+		URL:     "https://github.com/fluxcd/go-git-providers.git",
+		Files:   []CommitFile{{Path: &path, Content: &content}},
+	}
+
+	c, err := NewClient(nil, defaultHost, nil, initLogger(t))
+	if err != nil {
+		t.Fatalf("unexpected error while declaring a client: %v", err)
+	}
+	r, dir, err := c.Git.InitRepository(&initCommit, false)
+	if err != nil {
+		t.Fatalf("unexpected error while init repo: %v", err)
+	}
+	defer c.Git.Cleanup(dir)
+	head, err := r.Head()
+	if err != nil {
+		t.Fatalf("unexpected error while getting the head: %v", err)
+	}
+
+	obj, err := c.Git.CreateCommit(dir, r, "testbranch", &testCommit)
+	if err != nil {
+		t.Fatalf("unexpected error while creating a commit: %v", err)
+	}
+	if diff := cmp.Diff([]string{head.Hash().String()}, obj.Parents); diff != "" {
+		t.Errorf("commit parents (-want +got):\n%s", diff)
+	}
+}
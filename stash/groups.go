@@ -194,7 +194,7 @@ func (s *GroupsService) ListGroupMembers(ctx context.Context, groupName string,
 func (s *GroupsService) AllGroupMembers(ctx context.Context, groupName string) ([]*User, error) {
 	p := []*User{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
 		list, err := s.ListGroupMembers(ctx, groupName, opts)
 		if err != nil {
 			return nil, err
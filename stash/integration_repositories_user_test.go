@@ -201,7 +201,7 @@ var _ = Describe("Stash Provider", func() {
 		retryOp := testutils.NewRetry()
 		Eventually(func() bool {
 			var err error
-			commits, err = userRepo.Commits().ListPage(ctx, defaultBranch, 1, 0)
+			commits, err = userRepo.Commits().ListPage(ctx, defaultBranch, 1, 1)
 			if err == nil && len(commits) == 0 {
 				err = errors.New("empty commits list")
 			}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	pullRequestSettingsURI = "settings/pull-requests"
+	// mergeConfigTypeRepository is the type of the merge configurations set on the repository,
+	// rather than inherited from its project or the server.
+	mergeConfigTypeRepository = "REPOSITORY"
+)
+
+// MergeConfig is the merge strategy configuration of the pull requests of a repository
+type MergeConfig struct {
+	// DefaultStrategy is the strategy preselected when merging a pull request
+	DefaultStrategy *MergeStrategy `json:"defaultStrategy,omitempty"`
+	// Strategies are the merge strategies, which are all returned with their Enabled flag.
+	// The strategies sent when updating the configuration are the enabled ones.
+	Strategies []MergeStrategy `json:"strategies,omitempty"`
+	// Type tells where the configuration is set, one of DEFAULT, PROJECT or REPOSITORY
+	Type string `json:"type,omitempty"`
+}
+
+// MergeStrategy is a strategy merging pull requests, e.g. "no-ff" or "squash"
+type MergeStrategy struct {
+	// ID is the id of the strategy, one of "no-ff", "ff", "ff-only", "rebase-no-ff",
+	// "rebase-ff-only", "squash" or "squash-ff-only"
+	ID string `json:"id"`
+	// Enabled indicates if the strategy may be used to merge pull requests
+	Enabled bool `json:"enabled,omitempty"`
+	// Name is the human-friendly name of the strategy
+	Name string `json:"name,omitempty"`
+}
+
+// pullRequestSettings are the pull request settings of a repository, which include the merge configuration
+type pullRequestSettings struct {
+	// MergeConfig is the merge strategy configuration
+	MergeConfig *MergeConfig `json:"mergeConfig,omitempty"`
+}
+
+// GetMergeConfig retrieves the merge strategy configuration of the pull requests of the repository,
+// which is inherited from its project or the server unless it's set on the repository.
+// GetMergeConfig uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/settings/pull-requests".
+// https://docs.atlassian.com/bitbucket-server/rest/7.14.0/bitbucket-rest.html
+func (s *RepositoriesService) GetMergeConfig(ctx context.Context, projectKey, repositorySlug string) (*MergeConfig, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestSettingsURI))
+	if err != nil {
+		return nil, fmt.Errorf("get merge config request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get merge config failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	return unmarshalMergeConfig(res, "get")
+}
+
+// UpdateMergeConfig sets the merge strategy configuration of the pull requests of the repository,
+// enabling the given strategies only, and returns the updated configuration.
+// UpdateMergeConfig uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/settings/pull-requests".
+// https://docs.atlassian.com/bitbucket-server/rest/7.14.0/bitbucket-rest.html
+func (s *RepositoriesService) UpdateMergeConfig(ctx context.Context, projectKey, repositorySlug string, config *MergeConfig) (*MergeConfig, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(&pullRequestSettings{MergeConfig: config})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall merge config: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestSettingsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update merge config request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update merge config failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("update merge config failed: %s: %w", resp.Status, ErrBadRequest)
+	}
+
+	return unmarshalMergeConfig(res, "update")
+}
+
+// unmarshalMergeConfig returns the merge configuration of the given pull request settings json.
+func unmarshalMergeConfig(res []byte, op string) (*MergeConfig, error) {
+	settings := &pullRequestSettings{}
+	if err := json.Unmarshal(res, settings); err != nil {
+		return nil, fmt.Errorf("%s merge config failed, unable to unmarshall json: %w", op, err)
+	}
+	if settings.MergeConfig == nil {
+		return &MergeConfig{}, nil
+	}
+	return settings.MergeConfig, nil
+}
@@ -34,12 +34,16 @@ const (
 // retrieve projects and related permissions.
 type Projects interface {
 	List(ctx context.Context, opts *PagingOptions) (*ProjectsList, error)
+	ListWithPermission(ctx context.Context, permission string, opts *PagingOptions) (*ProjectsList, error)
 	Get(ctx context.Context, projectName string) (*Project, error)
 	All(ctx context.Context) ([]*Project, error)
+	AllWithPermission(ctx context.Context, permission string) ([]*Project, error)
+	Update(ctx context.Context, projectKey string, project *ProjectUpdate) (*Project, error)
 	GetProjectGroupPermission(ctx context.Context, projectKey, groupName string) (*ProjectGroupPermission, error)
 	ListProjectGroupsPermission(ctx context.Context, projectKey string, opts *PagingOptions) (*ProjectGroups, error)
 	AllGroupsPermission(ctx context.Context, projectKey string) ([]*ProjectGroupPermission, error)
 	ListProjectUsersPermission(ctx context.Context, projectKey string, opts *PagingOptions) (*ProjectUsers, error)
+	AllUsersPermission(ctx context.Context, projectKey string) ([]*ProjectUserPermission, error)
 }
 
 // ProjectsService is a client for communicating with stash projects endpoint
@@ -88,7 +92,18 @@ func (p *ProjectsList) GetProjects() []*Project {
 // List uses the endpoint "GET /rest/api/1.0/projects".
 // bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
 func (s *ProjectsService) List(ctx context.Context, opts *PagingOptions) (*ProjectsList, error) {
+	return s.ListWithPermission(ctx, "", opts)
+}
+
+// ListWithPermission retrieves a list of the projects the authenticated user has the given
+// permission on, e.g. PROJECT_ADMIN. An empty permission lists all the projects, like List.
+// ListWithPermission uses the endpoint "GET /rest/api/1.0/projects?permission".
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *ProjectsService) ListWithPermission(ctx context.Context, permission string, opts *PagingOptions) (*ProjectsList, error) {
 	query := addPaging(url.Values{}, opts)
+	if permission != "" {
+		query.Set("permission", permission)
+	}
 	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI), WithQuery(query))
 	if err != nil {
 		return nil, fmt.Errorf("get projects request creation failed: %w", err)
@@ -123,10 +138,16 @@ func (s *ProjectsService) List(ctx context.Context, opts *PagingOptions) (*Proje
 // All retrieves all projects.
 // This function handles pagination, HTTP error wrapping, and validates the server result.
 func (s *ProjectsService) All(ctx context.Context) ([]*Project, error) {
+	return s.AllWithPermission(ctx, "")
+}
+
+// AllWithPermission retrieves all projects the authenticated user has the given permission on.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *ProjectsService) AllWithPermission(ctx context.Context, permission string) ([]*Project, error) {
 	p := []*Project{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
-		list, err := s.List(ctx, opts)
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.ListWithPermission(ctx, permission, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -185,6 +206,56 @@ func (s *ProjectsService) Get(ctx context.Context, projectName string) (*Project
 
 }
 
+// ProjectUpdate is the body of a project update.
+// Unlike Project, the description and public flag are always sent, so they can be cleared.
+type ProjectUpdate struct {
+	// Key is the project key. Changing it moves the project.
+	Key string `json:"key,omitempty"`
+	// Name is the project name.
+	Name string `json:"name,omitempty"`
+	// Description is the project description.
+	Description string `json:"description"`
+	// Public is the project public flag.
+	Public bool `json:"public"`
+}
+
+// Update updates the project with the given key.
+// Update uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}".
+// The authenticated user must have PROJECT_ADMIN permission for the specified project to call this resource.
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *ProjectsService) Update(ctx context.Context, projectKey string, project *ProjectUpdate) (*Project, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall project: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update project request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update project failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("update project failed: %s", resp.Status)
+	}
+
+	p := &Project{}
+	if err := json.Unmarshal(res, p); err != nil {
+		return nil, fmt.Errorf("update project failed, unable to unmarshall project json: %w", err)
+	}
+
+	p.Session.set(resp)
+
+	return p, nil
+}
+
 // ProjectGroupPermission is a permission for a given group.
 // The permission is tied to a project.
 // The permission can be either read, write, or admin.
@@ -292,7 +363,7 @@ func (s *ProjectsService) ListProjectGroupsPermission(ctx context.Context, proje
 func (s *ProjectsService) AllGroupsPermission(ctx context.Context, projectKey string) ([]*ProjectGroupPermission, error) {
 	p := []*ProjectGroupPermission{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
 		list, err := s.ListProjectGroupsPermission(ctx, projectKey, opts)
 		if err != nil {
 			return nil, err
@@ -373,3 +444,23 @@ func (s *ProjectsService) ListProjectUsersPermission(ctx context.Context, projec
 
 	return up, nil
 }
+
+// AllUsersPermission retrieves all projects users permission.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *ProjectsService) AllUsersPermission(ctx context.Context, projectKey string) ([]*ProjectUserPermission, error) {
+	p := []*ProjectUserPermission{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.ListProjectUsersPermission(ctx, projectKey, opts)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, list.GetUsers()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -227,3 +227,63 @@ func TestListProjectUsersPermission(t *testing.T) {
 	}
 
 }
+
+func TestUpdateProject(t *testing.T) {
+	tests := []struct {
+		name        string
+		update      *ProjectUpdate
+		wantPayload map[string]interface{}
+	}{
+		{
+			name:   "make public",
+			update: &ProjectUpdate{Key: "PRJ1", Name: "project 1", Description: "my project", Public: true},
+			wantPayload: map[string]interface{}{
+				"key":         "PRJ1",
+				"name":        "project 1",
+				"description": "my project",
+				"public":      true,
+			},
+		},
+		{
+			name:   "clear description and make private",
+			update: &ProjectUpdate{Key: "PRJ1", Name: "project 1"},
+			wantPayload: map[string]interface{}{
+				"key":         "PRJ1",
+				"name":        "project 1",
+				"description": "",
+				"public":      false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var payload map[string]interface{}
+			mux.HandleFunc(fmt.Sprintf("%s/%s/PRJ1", stashURIprefix, projectsURI), func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					t.Errorf("method = %s, want %s", r.Method, http.MethodPut)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				json.NewEncoder(w).Encode(&Project{
+					Key:         tt.update.Key,
+					Name:        tt.update.Name,
+					Description: tt.update.Description,
+					Public:      tt.update.Public,
+				})
+			})
+
+			p, err := client.Projects.Update(context.Background(), "PRJ1", tt.update)
+			if err != nil {
+				t.Fatalf("Projects.Update returned error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantPayload, payload); diff != "" {
+				t.Errorf("payload (-want +got):\n%s", diff)
+			}
+			if p.Public != tt.update.Public || p.Description != tt.update.Description {
+				t.Errorf("Projects.Update returned %+v, want %+v", p, tt.update)
+			}
+		})
+	}
+}
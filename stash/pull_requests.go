@@ -28,6 +28,18 @@ import (
 const (
 	pullRequestsURI = "pull-requests"
 	mergeURI        = "merge"
+	diffSuffix      = ".diff"
+	commentsURI     = "comments"
+	activitiesURI   = "activities"
+	// activityCommented is the action of the activities adding, editing or deleting comments.
+	activityCommented = "COMMENTED"
+	// commentActionAdded is the comment action of the activities adding comments.
+	commentActionAdded = "ADDED"
+	participantsURI    = "participants"
+	// participantApproved is the status of the participants approving a pull request.
+	participantApproved = "APPROVED"
+	// participantNeedsWork is the status of the participants requesting changes to a pull request.
+	participantNeedsWork = "NEEDS_WORK"
 )
 
 // PullRequests interface defines the methods that can be used to
@@ -39,7 +51,15 @@ type PullRequests interface {
 	Create(ctx context.Context, projectKey, repositorySlug string, pr *CreatePullRequest) (*PullRequest, error)
 	Update(ctx context.Context, projectKey, repositorySlug string, pr *PullRequest) (*PullRequest, error)
 	Merge(ctx context.Context, projectKey, repositorySlug string, prID int, version int) (*PullRequest, error)
+	CanMerge(ctx context.Context, projectKey, repositorySlug string, prID int) (*MergeStatus, error)
+	Diff(ctx context.Context, projectKey, repositorySlug string, prID int) ([]byte, error)
+	ListCommits(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*CommitList, error)
+	AllCommits(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*CommitObject, error)
 	Delete(ctx context.Context, projectKey, repositorySlug string, IDVersion IDVersion) error
+	CreateComment(ctx context.Context, projectKey, repositorySlug string, prID int, text string) (*Comment, error)
+	ListActivities(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*ActivityList, error)
+	AllComments(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*Comment, error)
+	UpdateParticipantStatus(ctx context.Context, projectKey, repositorySlug string, prID int, userSlug, status string) (*Participant, error)
 }
 
 // PullRequestsService is a client for communicating with stash pull requests endpoint
@@ -158,6 +178,26 @@ type MergeResult struct {
 	Outcome string `json:"outcome,omitempty"`
 }
 
+// MergeStatus tells whether a pull request can be merged
+type MergeStatus struct {
+	// CanMerge indicates if the pull request can be merged
+	CanMerge bool `json:"canMerge"`
+	// Conflicted indicates if the pull request conflicts with its target branch
+	Conflicted bool `json:"conflicted"`
+	// Outcome is the outcome of the merge, one of CLEAN, CONFLICTED or UNKNOWN
+	Outcome string `json:"outcome,omitempty"`
+	// Vetoes are the merge checks preventing the pull request from being merged
+	Vetoes []MergeVeto `json:"vetoes,omitempty"`
+}
+
+// MergeVeto is a merge check preventing a pull request from being merged
+type MergeVeto struct {
+	// SummaryMessage is a short description of the veto
+	SummaryMessage string `json:"summaryMessage,omitempty"`
+	// DetailedMessage is a detailed description of the veto
+	DetailedMessage string `json:"detailedMessage,omitempty"`
+}
+
 // PullRequestList is a list of pull requests
 type PullRequestList struct {
 	// Paging is the paging information
@@ -171,6 +211,52 @@ func (p *PullRequestList) GetPullRequests() []*PullRequest {
 	return p.PullRequests
 }
 
+// Comment is a comment on a pull request
+type Comment struct {
+	// Author is the user who posted the comment
+	Author User `json:"author,omitempty"`
+	// CreatedDate is the creation date of the comment
+	CreatedDate int64 `json:"createdDate,omitempty"`
+	// ID is the id of the comment
+	ID int64 `json:"id,omitempty"`
+	// Text is the text of the comment
+	Text string `json:"text,omitempty"`
+	// UpdatedDate is the update date of the comment
+	UpdatedDate int64 `json:"updatedDate,omitempty"`
+	// Version is the version of the comment
+	Version int `json:"version,omitempty"`
+}
+
+// Activity is an event on a pull request, e.g. a comment being added
+type Activity struct {
+	// Action is the kind of activity, e.g. COMMENTED, APPROVED or MERGED
+	Action string `json:"action,omitempty"`
+	// Comment is the comment of COMMENTED activities
+	Comment *Comment `json:"comment,omitempty"`
+	// CommentAction is the action on the comment of COMMENTED activities, one of ADDED, EDITED
+	// or DELETED
+	CommentAction string `json:"commentAction,omitempty"`
+	// CommentAnchor is set for the comments on the lines of the diff
+	CommentAnchor interface{} `json:"commentAnchor,omitempty"`
+	// CreatedDate is the creation date of the activity
+	CreatedDate int64 `json:"createdDate,omitempty"`
+	// ID is the id of the activity
+	ID int64 `json:"id,omitempty"`
+}
+
+// ActivityList is a list of pull request activities
+type ActivityList struct {
+	// Paging is the paging information
+	Paging
+	// Activities are the activities
+	Activities []*Activity `json:"values,omitempty"`
+}
+
+// GetActivities returns a list of activities
+func (a *ActivityList) GetActivities() []*Activity {
+	return a.Activities
+}
+
 // List returns the list of pull requests.
 // Paging is optional and is enabled by providing a PagingOptions struct.
 // A pointer to a PullRequestsList struct is returned to retrieve the next page of results.
@@ -208,7 +294,7 @@ func (s *PullRequestsService) List(ctx context.Context, projectKey, repositorySl
 func (s *PullRequestsService) All(ctx context.Context, projectKey, repositorySlug string) ([]*PullRequest, error) {
 	pr := []*PullRequest{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
 		list, err := s.List(ctx, projectKey, repositorySlug, opts)
 		if err != nil {
 			return nil, err
@@ -348,6 +434,100 @@ func (s *PullRequestsService) Merge(ctx context.Context, projectKey, repositoryS
 	return p, nil
 }
 
+// CanMerge tests whether the pull request with the given ID can be merged.
+// CanMerge uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/merge".
+func (s *PullRequestsService) CanMerge(ctx context.Context, projectKey, repositorySlug string, prID int) (*MergeStatus, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), mergeURI))
+	if err != nil {
+		return nil, fmt.Errorf("get merge status request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get merge status failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	m := &MergeStatus{}
+	if err := json.Unmarshal(res, m); err != nil {
+		return nil, fmt.Errorf("get merge status failed, unable to unmarshal merge status json: %w", err)
+	}
+
+	return m, nil
+}
+
+// Diff retrieves the raw unified diff of the pull request with the given ID.
+// Diff uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}.diff".
+func (s *PullRequestsService) Diff(ctx context.Context, projectKey, repositorySlug string, prID int) ([]byte, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID)+diffSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("get pull request diff request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get pull request diff failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	return res, nil
+}
+
+// ListCommits returns the commits of the pull request with the given ID.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a CommitList struct is returned to retrieve the next page of results.
+// ListCommits uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/commits".
+// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-rest.html
+func (s *PullRequestsService) ListCommits(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*CommitList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commitsURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list pull request commits request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list pull request commits failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	c := &CommitList{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("list pull request commits failed, unable to unmarshal commit list json: %w", err)
+	}
+
+	for _, commit := range c.GetCommits() {
+		commit.Session.set(resp)
+	}
+	return c, nil
+}
+
+// AllCommits retrieves all commits of the pull request with the given ID.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *PullRequestsService) AllCommits(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*CommitObject, error) {
+	commits := []*CommitObject{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.ListCommits(ctx, projectKey, repositorySlug, prID, opts)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, list.GetCommits()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
 // Delete deletes the pull request with the given ID
 // Delete uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}".
 // To call this resource, users must:
@@ -377,3 +557,122 @@ func (s *PullRequestsService) Delete(ctx context.Context, projectKey, repository
 
 	return nil
 }
+
+// CreateComment posts a comment with the given text on the pull request with the given ID.
+// CreateComment uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/comments".
+func (s *PullRequestsService) CreateComment(ctx context.Context, projectKey, repositorySlug string, prID int, text string) (*Comment, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(&Comment{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall comment: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), commentsURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create pull request comment request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create pull request comment failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	c := &Comment{}
+	if err := json.Unmarshal(res, c); err != nil {
+		return nil, fmt.Errorf("create pull request comment failed, unable to unmarshal comment json: %w", err)
+	}
+	return c, nil
+}
+
+// ListActivities returns the activities of the pull request with the given ID, from the newest
+// to the oldest.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to an ActivityList struct is returned to retrieve the next page of results.
+// ListActivities uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/activities".
+func (s *PullRequestsService) ListActivities(ctx context.Context, projectKey, repositorySlug string, prID int, opts *PagingOptions) (*ActivityList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), activitiesURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list pull request activities request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list pull request activities failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	a := &ActivityList{}
+	if err := json.Unmarshal(res, a); err != nil {
+		return nil, fmt.Errorf("list pull request activities failed, unable to unmarshal activity list json: %w", err)
+	}
+	return a, nil
+}
+
+// AllComments retrieves the comments on the overview of the pull request with the given ID, from
+// the oldest to the newest. Listing the comments endpoint requires the path of a file, so the
+// comments are read from the activities adding them instead, skipping those on the lines of the diff.
+// This function handles pagination and HTTP error wrapping.
+func (s *PullRequestsService) AllComments(ctx context.Context, projectKey, repositorySlug string, prID int) ([]*Comment, error) {
+	comments := []*Comment{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.ListActivities(ctx, projectKey, repositorySlug, prID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, activity := range list.GetActivities() {
+			if activity.Action == activityCommented && activity.CommentAction == commentActionAdded &&
+				activity.CommentAnchor == nil && activity.Comment != nil {
+				comments = append(comments, activity.Comment)
+			}
+		}
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The activities are listed from the newest to the oldest
+	for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+		comments[i], comments[j] = comments[j], comments[i]
+	}
+	return comments, nil
+}
+
+// UpdateParticipantStatus sets the status of the given user on the pull request with the given ID,
+// one of APPROVED, NEEDS_WORK or UNAPPROVED. The user is added as a participant if needed.
+// UpdateParticipantStatus uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/pull-requests/{pullRequestId}/participants/{userSlug}".
+func (s *PullRequestsService) UpdateParticipantStatus(ctx context.Context, projectKey, repositorySlug string, prID int, userSlug, status string) (*Participant, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(&Participant{
+		User:     User{Name: userSlug},
+		Approved: status == participantApproved,
+		Status:   status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall participant: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, pullRequestsURI, strconv.Itoa(prID), participantsURI, userSlug), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update pull request participant request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update pull request participant failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	p := &Participant{}
+	if err := json.Unmarshal(res, p); err != nil {
+		return nil, fmt.Errorf("update pull request participant failed, unable to unmarshal participant json: %w", err)
+	}
+	return p, nil
+}
@@ -490,3 +490,125 @@ func TestDeletePR(t *testing.T) {
 		})
 	}
 }
+
+func TestListPRCommits(t *testing.T) {
+	commits := []*CommitObject{
+		{ID: "abc", Message: "first", Author: User{Name: "alice"}},
+		{ID: "def", Message: "second", Author: User{Name: "bob"}},
+	}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/1/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI, commitsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		// Serve a commit per page
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		json.NewEncoder(w).Encode(&CommitList{
+			Paging:  Paging{Start: int64(start), IsLastPage: start == len(commits)-1, NextPageStart: int64(start + 1)},
+			Commits: []*CommitObject{commits[start]},
+		})
+	})
+	ctx := context.Background()
+	got, err := client.PullRequests.AllCommits(ctx, "prj1", "repo1", 1)
+	if err != nil {
+		t.Fatalf("PullRequests.AllCommits returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(commits, got); diff != "" {
+		t.Errorf("PullRequests.AllCommits returned diff (want -> got):\n%s", diff)
+	}
+}
+
+func TestCreatePRComment(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/1/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI, commentsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		c := &Comment{}
+		if err := json.NewDecoder(r.Body).Decode(c); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		c.ID, c.Author, c.CreatedDate = 10, User{Name: "flux-bot"}, 1685700000000
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(c)
+	})
+	ctx := context.Background()
+	got, err := client.PullRequests.CreateComment(ctx, "prj1", "repo1", 1, "Reconciliation succeeded")
+	if err != nil {
+		t.Fatalf("PullRequests.CreateComment returned error: %v", err)
+	}
+
+	want := &Comment{ID: 10, Author: User{Name: "flux-bot"}, Text: "Reconciliation succeeded", CreatedDate: 1685700000000}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PullRequests.CreateComment returned diff (want -> got):\n%s", diff)
+	}
+}
+
+func TestAllPRComments(t *testing.T) {
+	first := &Comment{ID: 1, Text: "Please reconcile", Author: User{Name: "alice"}}
+	edited := &Comment{ID: 2, Text: "Reconciliation succeeded", Author: User{Name: "flux-bot"}}
+	inline := &Comment{ID: 3, Text: "nit", Author: User{Name: "bob"}}
+	// Activities are listed from the newest to the oldest
+	activities := []*Activity{
+		{ID: 6, Action: activityCommented, CommentAction: "EDITED", Comment: edited},
+		{ID: 5, Action: activityCommented, CommentAction: commentActionAdded, Comment: inline, CommentAnchor: map[string]interface{}{"path": "main.go"}},
+		{ID: 4, Action: activityCommented, CommentAction: commentActionAdded, Comment: edited},
+		{ID: 3, Action: "APPROVED"},
+		{ID: 2, Action: activityCommented, CommentAction: commentActionAdded, Comment: first},
+		{ID: 1, Action: "OPENED"},
+	}
+
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/1/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI, activitiesURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		// Serve three activities per page
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		json.NewEncoder(w).Encode(&ActivityList{
+			Paging:     Paging{Start: int64(start), IsLastPage: start+3 >= len(activities), NextPageStart: int64(start + 3)},
+			Activities: activities[start : start+3],
+		})
+	})
+	ctx := context.Background()
+	got, err := client.PullRequests.AllComments(ctx, "prj1", "repo1", 1)
+	if err != nil {
+		t.Fatalf("PullRequests.AllComments returned error: %v", err)
+	}
+
+	if diff := cmp.Diff([]*Comment{first, edited}, got); diff != "" {
+		t.Errorf("PullRequests.AllComments returned diff (want -> got):\n%s", diff)
+	}
+}
+
+func TestUpdatePRParticipantStatus(t *testing.T) {
+	mux, client := setup(t)
+
+	path := fmt.Sprintf("%s/%s/prj1/%s/repo1/%s/1/%s/flux-bot", stashURIprefix, projectsURI, RepositoriesURI, pullRequestsURI, participantsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		p := &Participant{}
+		if err := json.NewDecoder(r.Body).Decode(p); err != nil {
+			t.Fatalf("failed to decode the request body: %v", err)
+		}
+		if p.Name != "flux-bot" || p.Status != participantNeedsWork || p.Approved {
+			t.Errorf("participant = %+v, want flux-bot needing work", p)
+		}
+		p.Role = "REVIEWER"
+		json.NewEncoder(w).Encode(p)
+	})
+	ctx := context.Background()
+	got, err := client.PullRequests.UpdateParticipantStatus(ctx, "prj1", "repo1", 1, "flux-bot", participantNeedsWork)
+	if err != nil {
+		t.Fatalf("PullRequests.UpdateParticipantStatus returned error: %v", err)
+	}
+
+	want := &Participant{User: User{Name: "flux-bot"}, Role: "REVIEWER", Status: participantNeedsWork}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PullRequests.UpdateParticipantStatus returned diff (want -> got):\n%s", diff)
+	}
+}
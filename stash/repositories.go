@@ -43,12 +43,14 @@ const (
 type Repositories interface {
 	RepositoryManager
 	RepositoryPermissionManager
+	RepositoryMergeConfigManager
 }
 
 // RepositoryManager interface defines the CRUD operations for repositories.
 type RepositoryManager interface {
 	List(ctx context.Context, projectKey string, opts *PagingOptions) (*RepositoryList, error)
 	All(ctx context.Context, projectKey string) ([]*Repository, error)
+	Iterate(ctx context.Context, projectKey string, fn func(*Repository) error) error
 	Get(ctx context.Context, projectKey, repoSlug string) (*Repository, error)
 	Create(ctx context.Context, projectKey string, repository *Repository) (*Repository, error)
 	Update(ctx context.Context, projectKey, repositorySlug string, repository *Repository) (*Repository, error)
@@ -62,6 +64,14 @@ type RepositoryPermissionManager interface {
 	AllGroupsPermission(ctx context.Context, projectKey, repositorySlug string) ([]*RepositoryGroupPermission, error)
 	UpdateRepositoryGroupPermission(ctx context.Context, projectKey, repositorySlug string, permission *RepositoryGroupPermission) error
 	ListRepositoryUsersPermission(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*RepositoryUsers, error)
+	AllUsersPermission(ctx context.Context, projectKey, repositorySlug string) ([]*RepositoryUserPermission, error)
+	HasPermission(ctx context.Context, projectKey, repositorySlug, permission string) (bool, error)
+}
+
+// RepositoryMergeConfigManager interface defines the operations for working with the merge strategies of repositories.
+type RepositoryMergeConfigManager interface {
+	GetMergeConfig(ctx context.Context, projectKey, repositorySlug string) (*MergeConfig, error)
+	UpdateMergeConfig(ctx context.Context, projectKey, repositorySlug string, config *MergeConfig) (*MergeConfig, error)
 }
 
 // RepositoriesService is a client for communicating with stash repositories endpoints
@@ -155,7 +165,7 @@ func (s *RepositoriesService) List(ctx context.Context, projectKey string, opts
 func (s *RepositoriesService) All(ctx context.Context, projectKey string) ([]*Repository, error) {
 	r := []*Repository{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
 		list, err := s.List(ctx, projectKey, opts)
 		if err != nil {
 			return nil, err
@@ -170,6 +180,27 @@ func (s *RepositoriesService) All(ctx context.Context, projectKey string) ([]*Re
 	return r, nil
 }
 
+// Iterate calls fn for each repository of a given project, fetching one page at a time.
+// Iterate stops at the first error returned by fn, or once ctx is done, and returns that error.
+func (s *RepositoriesService) Iterate(ctx context.Context, projectKey string, fn func(*Repository) error) error {
+	opts := &PagingOptions{Limit: perPageLimit}
+	return allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.List(ctx, projectKey, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range list.GetRepositories() {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := fn(r); err != nil {
+				return nil, err
+			}
+		}
+		return &list.Paging, nil
+	})
+}
+
 // Get returns the repository with the given slug
 // Accessing personal repositories via REST is achieved through the normal project-centric REST URLs using
 // the user's slug prefixed by tilde as the project key.
@@ -258,6 +289,9 @@ func (s *RepositoriesService) Update(ctx context.Context, projectKey, repository
 	}
 	res, resp, err := s.Client.Do(req)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return nil, ErrAlreadyExists
+		}
 		return nil, fmt.Errorf("update repository failed: %w", err)
 	}
 
@@ -398,7 +432,7 @@ func (s *RepositoriesService) ListRepositoryGroupsPermission(ctx context.Context
 func (s *RepositoriesService) AllGroupsPermission(ctx context.Context, projectKey, repositorySlug string) ([]*RepositoryGroupPermission, error) {
 	p := []*RepositoryGroupPermission{}
 	opts := &PagingOptions{Limit: perPageLimit}
-	err := allPages(opts, func() (*Paging, error) {
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
 		list, err := s.ListRepositoryGroupsPermission(ctx, projectKey, repositorySlug, opts)
 		if err != nil {
 			return nil, err
@@ -495,3 +529,65 @@ func (s *RepositoriesService) ListRepositoryUsersPermission(ctx context.Context,
 
 	return users, nil
 }
+
+// AllUsersPermission retrieves all repository users permission.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *RepositoriesService) AllUsersPermission(ctx context.Context, projectKey, repositorySlug string) ([]*RepositoryUserPermission, error) {
+	p := []*RepositoryUserPermission{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.ListRepositoryUsersPermission(ctx, projectKey, repositorySlug, opts)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, list.GetUsers()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// HasPermission returns true if the authenticated user has the given permission (REPO_READ, REPO_WRITE or REPO_ADMIN)
+// on the specified repository.
+// HasPermission uses the endpoint "GET /rest/api/1.0/repos?projectkey&name&permission", which only returns
+// the repositories the authenticated user has the given permission on. As the name filter matches
+// on substrings, the results are paged through until the repository is found.
+func (s *RepositoriesService) HasPermission(ctx context.Context, projectKey, repositorySlug, permission string) (bool, error) {
+	found := false
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		query := url.Values{}
+		query.Add("projectkey", projectKey)
+		query.Add("name", repositorySlug)
+		query.Add("permission", permission)
+		req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(RepositoriesURI), WithQuery(addPaging(query, opts)))
+		if err != nil {
+			return nil, fmt.Errorf("search repositories request creation failed: %w", err)
+		}
+		res, _, err := s.Client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("search repositories failed: %w", err)
+		}
+
+		repos := &RepositoryList{}
+		if err := json.Unmarshal(res, repos); err != nil {
+			return nil, fmt.Errorf("search repositories failed, unable to unmarshal repository list json: %w", err)
+		}
+
+		// The name filter matches on substrings of the name, so compare the slugs
+		for _, r := range repos.GetRepositories() {
+			if r.Slug == repositorySlug {
+				found = true
+				return &Paging{IsLastPage: true}, nil
+			}
+		}
+		return &repos.Paging, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return found, nil
+}
@@ -660,3 +660,66 @@ func TestListRepositoryUsersPermission(t *testing.T) {
 	}
 
 }
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		want       bool
+	}{
+		{
+			name:       "user has write permission",
+			permission: "REPO_WRITE",
+			want:       true,
+		},
+		{
+			name:       "user lacks admin permission",
+			permission: "REPO_ADMIN",
+			want:       false,
+		},
+	}
+
+	mux, client := setup(t)
+
+	// /rest/api/1.0/repos?projectkey&name&permission
+	path := fmt.Sprintf("%s/%s", stashURIprefix, RepositoriesURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("projectkey") != "prj" || query.Get("name") != "repo1" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		// the name filter matches on substrings, the repository is on the second page
+		repos := &RepositoryList{}
+		switch query.Get("start") {
+		case "":
+			repos.Paging = Paging{NextPageStart: 2}
+			repos.Repositories = []*Repository{
+				{Slug: "repo10"},
+				{Slug: "repo11"},
+			}
+		case "2":
+			repos.Paging = Paging{IsLastPage: true}
+			repos.Repositories = []*Repository{
+				{Slug: "repo12"},
+			}
+			if query.Get("permission") != "REPO_ADMIN" {
+				repos.Repositories = append(repos.Repositories, &Repository{Slug: "repo1"})
+			}
+		default:
+			t.Errorf("unexpected start: %s", query.Get("start"))
+		}
+		json.NewEncoder(w).Encode(repos)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Repositories.HasPermission(context.Background(), "prj", "repo1", tt.permission)
+			if err != nil {
+				t.Fatalf("Repositories.HasPermission returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Repositories.HasPermission = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	stashURIrequiredBuilds = "/rest/required-builds/latest"
+	conditionsURI          = "conditions"
+)
+
+const (
+	// RefMatcherTypeBranch matches a single branch by its ref.
+	RefMatcherTypeBranch = "BRANCH"
+	// RefMatcherTypeAnyRef matches all refs.
+	RefMatcherTypeAnyRef = "ANY_REF"
+)
+
+// RequiredBuilds interface defines the methods for working with
+// the required builds merge checks of a repository.
+type RequiredBuilds interface {
+	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*RequiredBuildConditionList, error)
+	All(ctx context.Context, projectKey, repositorySlug string) ([]*RequiredBuildCondition, error)
+}
+
+// RequiredBuildsService is a client for communicating with stash required builds endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/7.14.0/bitbucket-required-builds-rest.html
+type RequiredBuildsService service
+
+// RequiredBuildCondition requires successful builds for the pull requests targeting the refs
+// it matches.
+type RequiredBuildCondition struct {
+	// Session is the session object for the condition.
+	Session `json:"sessionInfo,omitempty"`
+	// ID is the unique ID of the condition.
+	ID int64 `json:"id,omitempty"`
+	// BuildParentKeys are the keys of the builds which must be successful.
+	BuildParentKeys []string `json:"buildParentKeys,omitempty"`
+	// RefMatcher matches the target refs the condition applies to.
+	RefMatcher RefMatcher `json:"refMatcher,omitempty"`
+	// ExemptRefMatcher matches the source refs exempted from the condition.
+	ExemptRefMatcher *RefMatcher `json:"exemptRefMatcher,omitempty"`
+}
+
+// RefMatcher matches refs, e.g. a single branch or branches by pattern.
+type RefMatcher struct {
+	// ID is the matched value, e.g. the ref of a branch.
+	ID string `json:"id,omitempty"`
+	// DisplayID is the human-friendly matched value, e.g. the name of a branch.
+	DisplayID string `json:"displayId,omitempty"`
+	// Type is the kind of matcher.
+	Type RefMatcherType `json:"type,omitempty"`
+}
+
+// RefMatcherType is the kind of a RefMatcher, e.g. BRANCH, PATTERN or ANY_REF.
+type RefMatcherType struct {
+	// ID is the kind of matcher.
+	ID string `json:"id,omitempty"`
+	// Name is the human-friendly kind of matcher.
+	Name string `json:"name,omitempty"`
+}
+
+// RequiredBuildConditionList is a list of required builds conditions.
+type RequiredBuildConditionList struct {
+	// Paging is the paging information.
+	Paging
+	// Conditions is the list of conditions.
+	Conditions []*RequiredBuildCondition `json:"values,omitempty"`
+}
+
+// GetConditions returns the list of conditions.
+func (r *RequiredBuildConditionList) GetConditions() []*RequiredBuildCondition {
+	return r.Conditions
+}
+
+// List returns the list of required builds conditions of the repository.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a RequiredBuildConditionList struct is returned to retrieve the next page of results.
+// List uses the endpoint "GET /rest/required-builds/latest/projects/{projectKey}/repos/{repositorySlug}/conditions".
+// https://docs.atlassian.com/bitbucket-server/rest/7.14.0/bitbucket-required-builds-rest.html
+func (s *RequiredBuildsService) List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*RequiredBuildConditionList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newRequiredBuildsURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, conditionsURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list required builds request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list required builds failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("list required builds failed: %s: %w", resp.Status, ErrBadRequest)
+	}
+
+	r := &RequiredBuildConditionList{}
+	if err := json.Unmarshal(res, r); err != nil {
+		return nil, fmt.Errorf("list required builds failed, unable to unmarshall json: %w", err)
+	}
+
+	for _, condition := range r.GetConditions() {
+		condition.Session.set(resp)
+	}
+
+	return r, nil
+}
+
+// All retrieves all required builds conditions of the repository.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *RequiredBuildsService) All(ctx context.Context, projectKey, repositorySlug string) ([]*RequiredBuildCondition, error) {
+	r := []*RequiredBuildCondition{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, opts)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, list.GetConditions()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Matches returns whether the matcher matches the given branch. Only branch and any-ref
+// matchers are evaluated, matchers by pattern or branching model never match.
+func (m RefMatcher) Matches(branch string) bool {
+	switch m.Type.ID {
+	case RefMatcherTypeAnyRef:
+		return true
+	case RefMatcherTypeBranch:
+		return m.ID == "refs/heads/"+branch || m.DisplayID == branch
+	default:
+		return false
+	}
+}
+
+func newRequiredBuildsURI(elements ...string) string {
+	return strings.Join(append([]string{stashURIrequiredBuilds}, elements...), "/")
+}
@@ -43,11 +43,16 @@ func (c *commitType) APIObject() interface{} {
 }
 
 func commitFromAPI(commit CommitObject) gitprovider.CommitInfo {
-	t := time.Unix(commit.AuthorTimestamp, 0)
-	return gitprovider.CommitInfo{
+	// Bitbucket Server timestamps are in milliseconds
+	t := time.UnixMilli(commit.AuthorTimestamp)
+	info := gitprovider.CommitInfo{
 		Sha:       commit.ID,
 		Author:    commit.Author.Name,
 		Message:   commit.Message,
 		CreatedAt: t,
 	}
+	for _, parent := range commit.Parents {
+		info.Parents = append(info.Parents, parent.ID)
+	}
+	return info
 }
@@ -0,0 +1,235 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+	"github.com/fluxcd/go-git-providers/validation"
+)
+
+func newDeployHook(c *HookClient, webhook *Webhook) *deployHook {
+	h := &deployHook{
+		w: *webhook,
+		c: c,
+	}
+	// The secret isn't returned by Bitbucket Server, it's only sent when set again
+	h.w.Configuration.Secret = ""
+	return h
+}
+
+var _ gitprovider.DeployHook = &deployHook{}
+
+type deployHook struct {
+	w Webhook
+	c *HookClient
+}
+
+func (dh *deployHook) Get() gitprovider.DeployHookInfo {
+	return deployHookFromAPI(&dh.w)
+}
+
+func (dh *deployHook) Set(info gitprovider.DeployHookInfo) error {
+	if err := info.ValidateInfo(); err != nil {
+		return err
+	}
+	return deployHookInfoToAPIObj(&info, &dh.w)
+}
+
+func (dh *deployHook) APIObject() interface{} {
+	return &dh.w
+}
+
+func (dh *deployHook) Repository() gitprovider.RepositoryRef {
+	return dh.c.ref
+}
+
+// Update will apply the desired state in this object to the server.
+// Only set fields will be respected (i.e. PATCH behaviour).
+// In order to apply changes to this object, use the .Set({Resource}Info) error
+// function, or cast .APIObject() to a pointer to the provider-specific type
+// and set custom fields there.
+//
+// ErrNotFound is returned if the resource does not exist.
+//
+// The internal API object will be overridden with the received server data.
+func (dh *deployHook) Update(ctx context.Context) error {
+	apiObj, err := dh.c.update(ctx, &dh.w)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook %q: %w", dh.w.URL, err)
+	}
+	*dh = *newDeployHook(dh.c, apiObj)
+	return nil
+}
+
+// Delete deletes the webhook from the repository. Deleting a webhook requires destructive
+// API calls to be enabled, ErrDestructiveCallDisallowed is returned otherwise.
+//
+// ErrNotFound is returned if the resource does not exist.
+func (dh *deployHook) Delete(ctx context.Context) error {
+	// Don't allow deleting webhooks if the user didn't explicitly allow dangerous API calls.
+	if !dh.c.destructiveActions {
+		return fmt.Errorf("cannot delete webhook: %w", gitprovider.ErrDestructiveCallDisallowed)
+	}
+	if err := dh.c.delete(ctx, dh.w.ID); err != nil {
+		return fmt.Errorf("failed to delete webhook %q: %w", dh.w.URL, err)
+	}
+	return nil
+}
+
+// Reconcile makes sure the desired state in this object (called "req" here) becomes
+// the actual state in the backing Git provider.
+//
+// If req doesn't exist under the hood, it is created (actionTaken == true).
+// If req doesn't equal the actual state, the resource will be updated (actionTaken == true).
+// If req is already the actual state, this is a no-op (actionTaken == false).
+//
+// The internal API object will be overridden with the received server data if actionTaken == true.
+func (dh *deployHook) Reconcile(ctx context.Context) (bool, error) {
+	actual, err := dh.c.get(ctx, dh.w.URL)
+	if err != nil {
+		// Create if not found
+		if errors.Is(err, gitprovider.ErrNotFound) {
+			apiObj, err := dh.c.create(ctx, &dh.w)
+			if err != nil {
+				return true, fmt.Errorf("failed to create webhook %q: %w", dh.w.URL, err)
+			}
+			*dh = *newDeployHook(dh.c, apiObj)
+			return true, nil
+		}
+
+		// Unexpected path, Get should succeed or return NotFound
+		return false, fmt.Errorf("failed to reconcile webhook %q: %w", dh.w.URL, err)
+	}
+
+	// If the desired matches the actual state, do nothing. Bitbucket Server doesn't return the
+	// secret, so a desired one is always sent.
+	desired := dh.Get()
+	if secret := dh.w.Configuration.Secret; secret != "" {
+		desired.Secret = &secret
+	}
+	if desired.Equals(deployHookFromAPI(actual)) {
+		return false, nil
+	}
+	// Otherwise, update the actual webhook with the desired state
+	dh.w.ID = actual.ID
+	return true, dh.Update(ctx)
+}
+
+func validateWebhookAPI(apiObj *Webhook) error {
+	return validateAPIObject("Stash.Webhook", func(validator validation.Validator) {
+		if apiObj.ID == 0 {
+			validator.Required("ID")
+		}
+		if apiObj.URL == "" {
+			validator.Required("URL")
+		}
+	})
+}
+
+func deployHookFromAPI(apiObj *Webhook) gitprovider.DeployHookInfo {
+	return gitprovider.DeployHookInfo{
+		URL:    apiObj.URL,
+		Events: webhookEventsFromAPI(apiObj.Events),
+		// Bitbucket Server always delivers the events as JSON
+		ContentType: gitprovider.WebhookContentTypeVar(gitprovider.WebhookContentTypeJSON),
+		Active:      gitprovider.BoolVar(apiObj.Active),
+	}
+}
+
+// desiredDeployHookInfo returns info as it would be read back from Bitbucket Server,
+// for comparing it with the actual state. As pushes to branches and tags are the same
+// event, desiring either one reads back as both.
+func desiredDeployHookInfo(info gitprovider.DeployHookInfo) (gitprovider.DeployHookInfo, error) {
+	events, err := webhookEventsToAPI(info.Events, nil)
+	if err != nil {
+		return info, err
+	}
+	desired := deployHookFromAPI(&Webhook{URL: info.URL, Events: events})
+	desired.ContentType, desired.Active, desired.Secret = info.ContentType, info.Active, info.Secret
+	return desired, nil
+}
+
+// webhookEventsFromAPI maps Bitbucket Server event keys to WebhookEvents, dropping the keys
+// which can't be expressed as a WebhookEvent.
+func webhookEventsFromAPI(names []string) []gitprovider.WebhookEvent {
+	var events []gitprovider.WebhookEvent
+	for _, event := range gitprovider.SortedWebhookEvents(webhookEventNames) {
+		for _, name := range names {
+			if name == webhookEventNames[event] {
+				events = append(events, event)
+				break
+			}
+		}
+	}
+	return events
+}
+
+// webhookEventsToAPI maps events to Bitbucket Server event keys, keeping the keys in current
+// which can't be expressed as a WebhookEvent, as these can't be desired. Events sharing a key
+// are sent once.
+func webhookEventsToAPI(events []gitprovider.WebhookEvent, current []string) ([]string, error) {
+	known := make(map[string]struct{}, len(webhookEventNames))
+	for _, name := range webhookEventNames {
+		known[name] = struct{}{}
+	}
+	names := make([]string, 0, len(events))
+	for _, name := range current {
+		if _, ok := known[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	added := make(map[string]struct{}, len(events))
+	for _, event := range events {
+		name, ok := webhookEventNames[event]
+		if !ok {
+			return nil, fmt.Errorf("Bitbucket Server webhooks can't be triggered by %q events: %w", event, gitprovider.ErrNoProviderSupport)
+		}
+		if _, ok := added[name]; !ok {
+			added[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func deployHookInfoToAPIObj(info *gitprovider.DeployHookInfo, apiObj *Webhook) error {
+	if info.ContentType != nil && *info.ContentType != gitprovider.WebhookContentTypeJSON {
+		return fmt.Errorf("webhooks deliver the events as JSON: %w", gitprovider.ErrNoProviderSupport)
+	}
+	events, err := webhookEventsToAPI(info.Events, apiObj.Events)
+	if err != nil {
+		return err
+	}
+	// Bitbucket Server requires a name, default it to the URL
+	if apiObj.Name == "" {
+		apiObj.Name = info.URL
+	}
+	apiObj.URL = info.URL
+	apiObj.Events = events
+	if info.Active != nil {
+		apiObj.Active = *info.Active
+	}
+	apiObj.Configuration.Secret = ""
+	if info.Secret != nil {
+		apiObj.Configuration.Secret = *info.Secret
+	}
+	return nil
+}
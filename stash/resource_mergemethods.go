@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// mergeStrategyIDs maps the provider-neutral merge methods to the Bitbucket Server merge strategies.
+//
+//nolint:gochecknoglobals
+var mergeStrategyIDs = map[gitprovider.MergeMethod]string{
+	gitprovider.MergeMethodMerge:  "no-ff",
+	gitprovider.MergeMethodSquash: "squash",
+}
+
+// mergeMethodsFromAPI returns the merge methods of the enabled merge strategies. It also returns
+// whether all the enabled strategies, and the default one, map to a merge method.
+func mergeMethodsFromAPI(apiObj *MergeConfig) (gitprovider.MergeMethodsInfo, bool) {
+	methods := map[string]gitprovider.MergeMethod{}
+	for method, id := range mergeStrategyIDs {
+		methods[id] = method
+	}
+
+	info := gitprovider.MergeMethodsInfo{AllowedMethods: []gitprovider.MergeMethod{}}
+	exact := true
+	for _, strategy := range apiObj.Strategies {
+		if !strategy.Enabled {
+			continue
+		}
+		method, ok := methods[strategy.ID]
+		if !ok {
+			exact = false
+			continue
+		}
+		info.AllowedMethods = append(info.AllowedMethods, method)
+	}
+	if apiObj.DefaultStrategy != nil {
+		if method, ok := methods[apiObj.DefaultStrategy.ID]; ok {
+			info.DefaultMethod = gitprovider.MergeMethodVar(method)
+		} else {
+			exact = false
+		}
+	}
+	return info, exact
+}
+
+// mergeMethodsToAPI converts the defaulted req to the merge configuration enabling its strategies only.
+func mergeMethodsToAPI(req gitprovider.MergeMethodsInfo) *MergeConfig {
+	config := &MergeConfig{
+		DefaultStrategy: &MergeStrategy{ID: mergeStrategyIDs[*req.DefaultMethod]},
+		Strategies:      make([]MergeStrategy, 0, len(req.AllowedMethods)),
+	}
+	for _, method := range req.AllowedMethods {
+		config.Strategies = append(config.Strategies, MergeStrategy{ID: mergeStrategyIDs[method], Enabled: true})
+	}
+	return config
+}
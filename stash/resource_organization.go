@@ -17,7 +17,9 @@ limitations under the License.
 package stash
 
 import (
+	"context"
 	"github.com/fluxcd/go-git-providers/gitprovider"
+	"io"
 )
 
 // Organization implements the gitprovider.Organization interface.
@@ -25,9 +27,10 @@ var _ gitprovider.Organization = &Organization{}
 
 // Organization represents a project in the Stash provider.
 type Organization struct {
-	p     Project
-	ref   gitprovider.OrganizationRef
-	teams *TeamsClient
+	p        Project
+	ref      gitprovider.OrganizationRef
+	teams    *TeamsClient
+	settings *OrganizationSettingsClient
 }
 
 // Get returns the organization's information, Name and description.
@@ -50,6 +53,26 @@ func (o *Organization) Teams() gitprovider.TeamsClient {
 	return o.teams
 }
 
+// Settings gives access to the org-wide defaults of this specific organization
+func (o *Organization) Settings() gitprovider.OrganizationSettingsClient {
+	return o.settings
+}
+
+// Webhooks isn't implemented for Bitbucket Server projects, ErrNoProviderSupport is returned.
+func (o *Organization) Webhooks() (gitprovider.OrganizationWebhookClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// AuditLog isn't implemented for Bitbucket Server projects, ErrNoProviderSupport is returned.
+func (o *Organization) AuditLog() (gitprovider.AuditLogClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetAvatar is not supported, ErrNoProviderSupport is returned.
+func (o *Organization) SetAvatar(_ context.Context, _ io.Reader) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
 func organizationFromAPI(apiObj *Project) gitprovider.OrganizationInfo {
 	return gitprovider.OrganizationInfo{
 		Name:        &apiObj.Name,
@@ -57,6 +80,55 @@ func organizationFromAPI(apiObj *Project) gitprovider.OrganizationInfo {
 	}
 }
 
+// ProjectInfo contains the metadata of a Bitbucket Server project that can be reconciled,
+// going beyond the provider-agnostic gitprovider.OrganizationInfo.
+type ProjectInfo struct {
+	// Description is the project description.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Public specifies whether the project is readable by anonymous users.
+	// +optional
+	Public *bool `json:"public,omitempty"`
+}
+
+// Equals can be used to check if this ProjectInfo's desired state matches the desired state of
+// another ProjectInfo object. Fields unset in p are ignored.
+func (p ProjectInfo) Equals(actual ProjectInfo) bool {
+	if p.Description != nil && (actual.Description == nil || *p.Description != *actual.Description) {
+		return false
+	}
+	if p.Public != nil && (actual.Public == nil || *p.Public != *actual.Public) {
+		return false
+	}
+	return true
+}
+
+func projectInfoFromAPI(apiObj *Project) ProjectInfo {
+	return ProjectInfo{
+		Description: gitprovider.StringVar(apiObj.Description),
+		Public:      gitprovider.BoolVar(apiObj.Public),
+	}
+}
+
+// projectInfoToAPI returns the update of the actual project apiObj to the desired state info.
+// The fields unset in info keep their actual values.
+func projectInfoToAPI(info *ProjectInfo, apiObj *Project) *ProjectUpdate {
+	update := &ProjectUpdate{
+		Key:         apiObj.Key,
+		Name:        apiObj.Name,
+		Description: apiObj.Description,
+		Public:      apiObj.Public,
+	}
+	if info.Description != nil {
+		update.Description = *info.Description
+	}
+	if info.Public != nil {
+		update.Public = *info.Public
+	}
+	return update
+}
+
 func newOrganization(ctx *clientContext, apiObj *Project, ref gitprovider.OrganizationRef) *Organization {
 	return &Organization{
 		p:   *apiObj,
@@ -65,5 +137,9 @@ func newOrganization(ctx *clientContext, apiObj *Project, ref gitprovider.Organi
 			clientContext: ctx,
 			ref:           ref,
 		},
+		settings: &OrganizationSettingsClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 	}
 }
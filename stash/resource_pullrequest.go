@@ -54,6 +54,33 @@ func pullrequestFromAPI(apiObj *PullRequest) gitprovider.PullRequestInfo {
 	}
 }
 
+func newPullRequestReview(apiObj interface{}, reviewer string, state gitprovider.ReviewState) *pullRequestReview {
+	return &pullRequestReview{
+		r: apiObj,
+		info: gitprovider.ReviewInfo{
+			Reviewer: reviewer,
+			State:    state,
+		},
+	}
+}
+
+var _ gitprovider.PullRequestReview = &pullRequestReview{}
+
+// pullRequestReview is a review of a pull request, whose API object is either the *Participant
+// of an approval or a change request, or the *Comment of a comment.
+type pullRequestReview struct {
+	r    interface{}
+	info gitprovider.ReviewInfo
+}
+
+func (r *pullRequestReview) Get() gitprovider.ReviewInfo {
+	return r.info
+}
+
+func (r *pullRequestReview) APIObject() interface{} {
+	return r.r
+}
+
 func getSelfref(selves []Self) string {
 	if len(selves) == 0 {
 		return "no http ref found"
@@ -18,7 +18,10 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/fluxcd/go-git-providers/gitprovider"
 )
@@ -44,6 +47,10 @@ func newUserRepository(ctx *clientContext, apiObj *Repository, ref gitprovider.R
 			clientContext: ctx,
 			ref:           ref,
 		},
+		tags: &TagClient{
+			clientContext: ctx,
+			ref:           ref,
+		},
 		pullRequests: &PullRequestClient{
 			clientContext: ctx,
 			ref:           ref,
@@ -67,6 +74,7 @@ type userRepository struct {
 	c            *UserRepositoriesClient
 	deployKeys   *DeployKeyClient
 	branches     *BranchClient
+	tags         *TagClient
 	pullRequests *PullRequestClient
 	commits      *CommitClient
 	files        *FileClient
@@ -77,6 +85,10 @@ func (r *userRepository) Branches() gitprovider.BranchClient {
 	return r.branches
 }
 
+func (r *userRepository) Tags() gitprovider.TagClient {
+	return r.tags
+}
+
 func (r *userRepository) Commits() gitprovider.CommitClient {
 	return r.commits
 }
@@ -117,10 +129,58 @@ func (r *userRepository) DeployKeys() gitprovider.DeployKeyClient {
 	return r.deployKeys
 }
 
+// Hooks returns the webhook client.
+func (r *userRepository) Hooks() (gitprovider.HookClient, error) {
+	return &HookClient{clientContext: r.c.clientContext, ref: r.ref}, nil
+}
+
 func (r *userRepository) DeployTokens() (gitprovider.DeployTokenClient, error) {
 	return nil, gitprovider.ErrNoProviderSupport
 }
 
+func (r *userRepository) ProtectedTags() (gitprovider.ProtectedTagClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+func (r *userRepository) Milestones() (gitprovider.MilestoneClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Releases is not supported, as Bitbucket Server has no releases. ErrNoProviderSupport is returned.
+func (r *userRepository) Releases() (gitprovider.ReleaseClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Pipelines is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *userRepository) Pipelines() (gitprovider.PipelineClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Workflows is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *userRepository) Workflows() (gitprovider.WorkflowClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Traffic is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *userRepository) Traffic() (gitprovider.TrafficClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Environments is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *userRepository) Environments() (gitprovider.EnvironmentClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Collaborators returns a CollaboratorClient operating on the users having access to the repository.
+func (r *userRepository) Collaborators() (gitprovider.CollaboratorClient, error) {
+	return &CollaboratorClient{clientContext: r.c.clientContext, ref: r.ref}, nil
+}
+
+// Topics is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *userRepository) Topics() (gitprovider.TopicsClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
 // The internal API object will be overridden with the received server data.
 func (r *userRepository) Update(ctx context.Context) error {
 	// update by calling client
@@ -139,6 +199,11 @@ func (r *userRepository) Update(ctx context.Context) error {
 	return nil
 }
 
+// Language is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) Language(_ context.Context) (string, error) {
+	return "", gitprovider.ErrNoProviderSupport
+}
+
 // Reconcile makes sure the desired state in this object (called "req" here) becomes
 // the actual state in the backing Git provider.
 
@@ -213,6 +278,194 @@ func (r *orgRepository) TeamAccess() gitprovider.TeamAccessClient {
 	return r.teamAccess
 }
 
+// Permissions returns the effective permission level of the authenticated user on the repository.
+func (r *orgRepository) Permissions(ctx context.Context) (gitprovider.PermissionLevel, error) {
+	ref := r.ref.(gitprovider.OrgRepositoryRef)
+	levels := []struct {
+		permission string
+		level      gitprovider.PermissionLevel
+	}{
+		{stashPermissionAdmin, gitprovider.PermissionLevelAdmin},
+		{stashPermissionWrite, gitprovider.PermissionLevelWrite},
+		{stashPermissionRead, gitprovider.PermissionLevelRead},
+	}
+	for _, l := range levels {
+		ok, err := r.c.client.Repositories.HasPermission(ctx, ref.Key(), ref.Slug(), l.permission)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return l.level, nil
+		}
+	}
+	return gitprovider.PermissionLevelNone, nil
+}
+
+// SetTemplates is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) SetTemplates(_ context.Context, _ gitprovider.TemplatesInfo) (gitprovider.Commit, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// CreateBackup writes a git bundle of all refs of the repository to w.
+// The repository is fetched with the credentials and CA bundle of the client.
+func (r *orgRepository) CreateBackup(ctx context.Context, w io.Writer) error {
+	return gitprovider.WriteBackupBundle(ctx, w, getRepoHTTPref(r.repository.Links.Clone), r.c.client.gitTransport())
+}
+
+// Wiki is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) Wiki() (gitprovider.WikiClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// CustomProperties is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) CustomProperties() (gitprovider.CustomPropertiesClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// InteractionLimits is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) InteractionLimits() (gitprovider.InteractionLimitsClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// ApprovalSettings is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) ApprovalSettings() (gitprovider.ApprovalSettingsClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// Pages is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) Pages() (gitprovider.PagesClient, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetSubscription is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) SetSubscription(_ context.Context, _ gitprovider.SubscriptionInfo) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Subscribers is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) Subscribers(_ context.Context) ([]gitprovider.UserRef, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetAvatar is not supported, ErrNoProviderSupport is returned.
+func (r *orgRepository) SetAvatar(_ context.Context, _ io.Reader) error {
+	return gitprovider.ErrNoProviderSupport
+}
+
+// Counts is not supported, as Bitbucket Server has no issues and its paged lists don't report
+// their total size. ErrNoProviderSupport is returned.
+func (r *orgRepository) Counts(_ context.Context) (gitprovider.RepoCounts, error) {
+	return gitprovider.RepoCounts{}, gitprovider.ErrNoProviderSupport
+}
+
+// CommitActivity returns the weekly commit activity of the default branch. Bitbucket Server has
+// no weekly commit statistics, so the activity is computed from the commits of the last year.
+func (r *orgRepository) CommitActivity(ctx context.Context) ([]gitprovider.WeeklyActivity, error) {
+	now := time.Now()
+	dates, err := r.commits.listCommitDates(ctx, r.repository.DefaultBranch, gitprovider.CommitActivitySince(now))
+	if err != nil {
+		return nil, err
+	}
+	return gitprovider.WeeklyActivityFromCommits(now, dates), nil
+}
+
+// ReconcileMergeQueue is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileMergeQueue(_ context.Context, _ string, _ gitprovider.MergeQueueInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileBranchMergeMethods is not supported, as the merge strategies of Bitbucket Server are
+// configured per repository. ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileBranchMergeMethods(_ context.Context, _ string, _ gitprovider.BranchMergeMethodsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileMergeMethods makes sure the merge strategies enabled for the pull requests of the
+// repository match req. Merge commits map to the "no-ff" strategy and squashing to "squash", while
+// the other strategies, e.g. fast-forwarding, are disabled. A configuration inherited from the
+// project which matches req is left as is, else it's overridden on the repository.
+func (r *orgRepository) ReconcileMergeMethods(ctx context.Context, req gitprovider.MergeMethodsInfo) (bool, error) {
+	req.Default()
+	if err := req.ValidateInfo(); err != nil {
+		return false, err
+	}
+
+	ref := r.ref.(gitprovider.OrgRepositoryRef)
+	apiObj, err := r.c.client.Repositories.GetMergeConfig(ctx, ref.Key(), ref.Slug())
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, gitprovider.ErrNotFound
+		}
+		return false, fmt.Errorf("failed to get merge config: %w", err)
+	}
+	if actual, exact := mergeMethodsFromAPI(apiObj); exact && req.Equals(actual) {
+		return false, nil
+	}
+
+	if _, err := r.c.client.Repositories.UpdateMergeConfig(ctx, ref.Key(), ref.Slug(), mergeMethodsToAPI(req)); err != nil {
+		return true, fmt.Errorf("failed to update merge config: %w", err)
+	}
+	return true, nil
+}
+
+// ReconcileEnvironmentProtection is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileEnvironmentProtection(_ context.Context, _ string, _ gitprovider.EnvironmentProtectionInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// IsDefaultBranchProtected is not supported, as the branch permissions of Bitbucket Server aren't
+// exposed by this client. ErrNoProviderSupport is returned.
+func (r *orgRepository) IsDefaultBranchProtected(_ context.Context) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileWorkflowPermissions is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileWorkflowPermissions(_ context.Context, _ gitprovider.WorkflowPermissionsInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// ReconcileCodeScanningDefaultSetup is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) ReconcileCodeScanningDefaultSetup(_ context.Context, _ gitprovider.CodeScanningDefaultSetupInfo) (bool, error) {
+	return false, gitprovider.ErrNoProviderSupport
+}
+
+// GetTemplates is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) GetTemplates(_ context.Context) (gitprovider.TemplatesInfo, error) {
+	return gitprovider.TemplatesInfo{}, gitprovider.ErrNoProviderSupport
+}
+
+// DiscoverTemplates is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) DiscoverTemplates(_ context.Context, _ string) (gitprovider.TemplateLocations, error) {
+	return gitprovider.TemplateLocations{}, gitprovider.ErrNoProviderSupport
+}
+
+// SetDependabotConfig is not supported, as Bitbucket Server has no Dependabot. ErrNoProviderSupport is returned.
+func (r *orgRepository) SetDependabotConfig(_ context.Context, _ []byte) (gitprovider.Commit, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetDependabotConfig is not supported, as Bitbucket Server has no Dependabot. ErrNoProviderSupport is returned.
+func (r *orgRepository) GetDependabotConfig(_ context.Context) ([]byte, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// SetSecurityPolicy is not supported, as Bitbucket Server has no security policy convention.
+// ErrNoProviderSupport is returned.
+func (r *orgRepository) SetSecurityPolicy(_ context.Context, _ []byte) (gitprovider.Commit, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// GetSecurityPolicy is not supported, as Bitbucket Server has no security policy convention.
+// ErrNoProviderSupport is returned.
+func (r *orgRepository) GetSecurityPolicy(_ context.Context) ([]byte, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// License is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (r *orgRepository) License(_ context.Context) (gitprovider.LicenseInfo, error) {
+	return gitprovider.LicenseInfo{}, gitprovider.ErrNoProviderSupport
+}
+
 // Reconcile makes sure the desired state in this object (called "req" here) becomes
 // the actual state in the backing Git provider.
 //
@@ -288,7 +541,7 @@ func repositoryInfoToAPIObj(repo *gitprovider.RepositoryInfo, apiObj *Repository
 	if repo.Description != nil {
 		apiObj.Description = *repo.Description
 	}
-	if repo.Visibility != nil {
+	if repo.Visibility != nil && *repo.Visibility != gitprovider.RepositoryVisibilityDefault {
 		apiObj.Public = *gitprovider.StringVar(string(*repo.Visibility)) == "true"
 	}
 
@@ -315,3 +568,19 @@ func (r *orgRepository) GetCloneURL(prefix string, transport gitprovider.Transpo
 		return ""
 	}
 }
+
+// Rename renames the repository. Bitbucket Server derives the slug from the name, so the
+// returned repository is referenced by the new slug.
+func (r *orgRepository) Rename(ctx context.Context, newName string) (gitprovider.OrgRepository, error) {
+	ref := r.ref.(gitprovider.OrgRepositoryRef)
+	apiObj, err := r.c.client.Repositories.Update(ctx, ref.Key(), ref.Slug(), &Repository{Name: newName})
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return nil, gitprovider.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to rename repository: %w", err)
+	}
+	ref.RepositoryName = apiObj.Name
+	ref.SetSlug(apiObj.Slug)
+	return newOrgRepository(r.c.clientContext, apiObj, ref), nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+func TestOrgRepository_Rename(t *testing.T) {
+	tests := []struct {
+		name     string
+		newName  string
+		conflict bool
+		wantSlug string
+		wantErr  error
+	}{
+		{
+			name:     "new slug",
+			newName:  "My Renamed Repo",
+			wantSlug: "my-renamed-repo",
+		},
+		{
+			name:     "name taken",
+			newName:  "taken",
+			conflict: true,
+			wantErr:  gitprovider.ErrAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			path := fmt.Sprintf("%s/%s/PRJ1/%s/repo1", stashURIprefix, projectsURI, RepositoriesURI)
+			mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					t.Errorf("unexpected %s request", r.Method)
+				}
+				if tt.conflict {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				req := &Repository{}
+				if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+					t.Fatalf("failed to decode the request body: %v", err)
+				}
+				json.NewEncoder(w).Encode(&Repository{Name: req.Name, Slug: tt.wantSlug})
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"},
+				RepositoryName:  "repo1",
+			}
+			ref.SetKey("PRJ1")
+			ref.SetSlug("repo1")
+			ctx := &clientContext{client: client, host: "stash.example.com", log: logr.Discard()}
+			repo := newOrgRepository(ctx, &Repository{Name: "repo1", Slug: "repo1"}, ref)
+
+			renamed, err := repo.Rename(context.Background(), tt.newName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rename() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			gotRef := renamed.Repository().(gitprovider.OrgRepositoryRef)
+			if gotRef.RepositoryName != tt.newName || gotRef.Slug() != tt.wantSlug || gotRef.Key() != "PRJ1" {
+				t.Errorf("Rename() ref = %+v (slug %q), want name %q and slug %q", gotRef, gotRef.Slug(), tt.newName, tt.wantSlug)
+			}
+		})
+	}
+}
+
+func TestOrgRepository_CommitActivity(t *testing.T) {
+	mux, client := setup(t)
+
+	now := time.Now()
+	commit := func(id string, age time.Duration) *CommitObject {
+		return &CommitObject{ID: id, AuthorTimestamp: now.Add(-age).UnixMilli()}
+	}
+	// Bitbucket Server lists the commits in topological order, the recent commits of a merged
+	// branch may come after a commit older than a year
+	pages := map[string]*CommitList{
+		"": {
+			Paging:  Paging{NextPageStart: 2},
+			Commits: []*CommitObject{commit("c1", time.Minute), commit("c2", 2*time.Minute)},
+		},
+		"2": {
+			Paging:  Paging{IsLastPage: true},
+			Commits: []*CommitObject{commit("c3", 3*time.Minute), commit("c4", 2*365*24*time.Hour), commit("c5", 4*time.Minute)},
+		},
+	}
+	var starts []string
+	path := fmt.Sprintf("%s/%s/PRJ1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, commitsURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("until"); got != "main" {
+			t.Errorf("until = %q, want %q", got, "main")
+		}
+		start := r.URL.Query().Get("start")
+		starts = append(starts, start)
+		page, ok := pages[start]
+		if !ok {
+			http.Error(w, "unexpected page", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	ref := gitprovider.OrgRepositoryRef{
+		OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"},
+		RepositoryName:  "repo1",
+	}
+	ref.SetKey("PRJ1")
+	ref.SetSlug("repo1")
+	ctx := &clientContext{client: client, host: "stash.example.com", log: logr.Discard()}
+	repo := newOrgRepository(ctx, &Repository{Name: "repo1", Slug: "repo1", DefaultBranch: "main"}, ref)
+
+	got, err := repo.CommitActivity(context.Background())
+	if err != nil {
+		t.Fatalf("CommitActivity() error = %v", err)
+	}
+	if len(got) != gitprovider.CommitActivityWeeks {
+		t.Fatalf("CommitActivity() returned %d weeks, want %d", len(got), gitprovider.CommitActivityWeeks)
+	}
+	total := 0
+	for _, week := range got {
+		total += week.Total
+	}
+	if total != 4 {
+		t.Errorf("CommitActivity() total = %d, want 4", total)
+	}
+	if len(starts) != 2 {
+		t.Errorf("CommitActivity() listed %d pages, want 2", len(starts))
+	}
+}
+
+func TestOrgRepository_ReconcileMergeMethods(t *testing.T) {
+	tests := []struct {
+		name            string
+		actual          string
+		req             gitprovider.MergeMethodsInfo
+		wantActionTaken bool
+		wantPayload     string
+	}{
+		{
+			name: "inherited configuration matches",
+			actual: `{"mergeConfig": {"type": "PROJECT", "defaultStrategy": {"id": "squash"}, "strategies": [
+				{"id": "no-ff", "enabled": true}, {"id": "ff", "enabled": false}, {"id": "squash", "enabled": true}]}}`,
+			req: gitprovider.MergeMethodsInfo{
+				AllowedMethods: []gitprovider.MergeMethod{gitprovider.MergeMethodSquash, gitprovider.MergeMethodMerge},
+			},
+		},
+		{
+			name: "fast-forward disabled",
+			actual: `{"mergeConfig": {"type": "DEFAULT", "defaultStrategy": {"id": "no-ff"}, "strategies": [
+				{"id": "no-ff", "enabled": true}, {"id": "ff", "enabled": true}, {"id": "squash", "enabled": false}]}}`,
+			req: gitprovider.MergeMethodsInfo{
+				AllowedMethods: []gitprovider.MergeMethod{gitprovider.MergeMethodMerge},
+			},
+			wantActionTaken: true,
+			wantPayload:     `{"mergeConfig":{"defaultStrategy":{"id":"no-ff"},"strategies":[{"id":"no-ff","enabled":true}]}}`,
+		},
+		{
+			name: "default strategy changed",
+			actual: `{"mergeConfig": {"type": "REPOSITORY", "defaultStrategy": {"id": "no-ff"}, "strategies": [
+				{"id": "no-ff", "enabled": true}, {"id": "squash", "enabled": true}]}}`,
+			req: gitprovider.MergeMethodsInfo{
+				AllowedMethods: []gitprovider.MergeMethod{gitprovider.MergeMethodMerge, gitprovider.MergeMethodSquash},
+				DefaultMethod:  gitprovider.MergeMethodVar(gitprovider.MergeMethodSquash),
+			},
+			wantActionTaken: true,
+			wantPayload:     `{"mergeConfig":{"defaultStrategy":{"id":"squash"},"strategies":[{"id":"no-ff","enabled":true},{"id":"squash","enabled":true}]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			var gotPayload string
+			path := fmt.Sprintf("%s/%s/PRJ1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, pullRequestSettingsURI)
+			mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					fmt.Fprint(w, tt.actual)
+				case http.MethodPost:
+					body, err := io.ReadAll(r.Body)
+					if err != nil {
+						t.Fatalf("failed to read the request body: %v", err)
+					}
+					gotPayload = strings.TrimSpace(string(body))
+					w.Write(body)
+				default:
+					t.Errorf("unexpected %s request", r.Method)
+				}
+			})
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"},
+				RepositoryName:  "repo1",
+			}
+			ref.SetKey("PRJ1")
+			ref.SetSlug("repo1")
+			ctx := &clientContext{client: client, host: "stash.example.com", log: logr.Discard()}
+			repo := newOrgRepository(ctx, &Repository{Name: "repo1", Slug: "repo1"}, ref)
+
+			actionTaken, err := repo.ReconcileMergeMethods(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("ReconcileMergeMethods() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("ReconcileMergeMethods() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if gotPayload != tt.wantPayload {
+				t.Errorf("merge config payload = %s, want %s", gotPayload, tt.wantPayload)
+			}
+		})
+	}
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package stash
 
 import (
+	"context"
 	"net/http"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
 )
 
 const (
@@ -104,9 +107,17 @@ type Links struct {
 	Clone []Clone `json:"clone,omitempty"`
 }
 
-func allPages(opts *PagingOptions, fn func() (*Paging, error)) error {
+// allPages calls fn for each page, as many times as needed to get all pages, and modifies opts
+// for each call. Each call gets a context bounded by the page timeout of the client, if any, and
+// the error of ctx is returned without fetching further pages once ctx is done.
+func allPages(ctx context.Context, opts *PagingOptions, fn func(ctx context.Context) (*Paging, error)) error {
 	for {
-		resp, err := fn()
+		pageCtx, cancel, err := gitprovider.PageContext(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := fn(pageCtx)
+		cancel()
 		if err != nil {
 			return err
 		}
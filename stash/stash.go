@@ -71,6 +71,9 @@ type ProviderClient struct {
 	orgs      *OrganizationsClient
 	orgRepos  *OrgRepositoriesClient
 	userRepos *UserRepositoriesClient
+
+	// defaultOwner resolves the repository names given to Repo.
+	defaultOwner *gitprovider.DefaultOwner
 }
 
 // SupportedDomain returns the host endpoint for this client, e.g. "mystash.com:7990"
@@ -112,6 +115,34 @@ func (p *ProviderClient) HasTokenPermission(_ context.Context, _ gitprovider.Tok
 	return false, gitprovider.ErrNoProviderSupport
 }
 
+// TokenScopes is not supported by Bitbucket Server, ErrNoProviderSupport is returned.
+func (p *ProviderClient) TokenScopes(_ context.Context) ([]string, error) {
+	return nil, gitprovider.ErrNoProviderSupport
+}
+
+// webhookEventNames maps the supported WebhookEvents to Bitbucket Server event keys.
+// Pushes to branches and tags are both reported as refs changes.
+//
+//nolint:gochecknoglobals
+var webhookEventNames = map[gitprovider.WebhookEvent]string{
+	gitprovider.WebhookEventPush:              "repo:refs_changed",
+	gitprovider.WebhookEventTagPush:           "repo:refs_changed",
+	gitprovider.WebhookEventPullRequest:       "pr:opened",
+	gitprovider.WebhookEventPullRequestReview: "pr:reviewer:updated",
+	gitprovider.WebhookEventComment:           "pr:comment:added",
+	gitprovider.WebhookEventRepository:        "repo:modified",
+}
+
+// Repo gets the repository with the given name, owned by the owner given through WithDefaultOwner.
+func (p *ProviderClient) Repo(ctx context.Context, name string) (gitprovider.UserRepository, error) {
+	return p.defaultOwner.Repository(ctx, p, name)
+}
+
+// SupportedWebhookEvents returns the webhook events Bitbucket Server can trigger webhooks for.
+func (p *ProviderClient) SupportedWebhookEvents() []gitprovider.WebhookEvent {
+	return gitprovider.SortedWebhookEvents(webhookEventNames)
+}
+
 // validateAPIObject creates a Validatior with the specified name, gives it to fn, and
 // depending on if any error was registered with it; either returns nil, or a MultiError
 // with both the validation error and ErrInvalidServerData, to mark that the server data
@@ -0,0 +1,242 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	webhooksURI = "webhooks"
+)
+
+// Webhooks interface defines the methods for working with the webhooks of a repository.
+type Webhooks interface {
+	List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*WebhookList, error)
+	All(ctx context.Context, projectKey, repositorySlug string) ([]*Webhook, error)
+	Get(ctx context.Context, projectKey, repositorySlug string, webhookID int) (*Webhook, error)
+	Create(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error)
+	Update(ctx context.Context, projectKey, repositorySlug string, webhookID int, webhook *Webhook) (*Webhook, error)
+	Delete(ctx context.Context, projectKey, repositorySlug string, webhookID int) error
+}
+
+// WebhooksService is a client for communicating with stash webhooks endpoint
+// bitbucket-server API docs: https://docs.atlassian.com/bitbucket-server/rest/7.14.0/bitbucket-rest.html
+type WebhooksService service
+
+// Webhook delivers the events of a repository to a URL.
+type Webhook struct {
+	// Session is the session object
+	Session `json:"sessionInfo,omitempty"`
+	// ID is the webhook id
+	ID int `json:"id,omitempty"`
+	// Name is the webhook name
+	Name string `json:"name,omitempty"`
+	// URL is the address the events are delivered to
+	URL string `json:"url,omitempty"`
+	// Events are the events triggering the webhook
+	// For example "repo:refs_changed" or "pr:opened"
+	Events []string `json:"events,omitempty"`
+	// Active indicates whether the events are delivered
+	Active bool `json:"active"`
+	// Configuration holds the shared secret of the webhook
+	Configuration WebhookConfiguration `json:"configuration,omitempty"`
+}
+
+// WebhookConfiguration is the configuration of a webhook.
+type WebhookConfiguration struct {
+	// Secret is used to sign the deliveries, it isn't returned by the server
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookList is a list of webhooks
+type WebhookList struct {
+	Paging
+	Webhooks []*Webhook `json:"values,omitempty"`
+}
+
+// GetWebhooks returns the list of webhooks
+func (w *WebhookList) GetWebhooks() []*Webhook {
+	return w.Webhooks
+}
+
+// List returns the list of webhooks of the repository.
+// Paging is optional and is enabled by providing a PagingOptions struct.
+// A pointer to a WebhookList struct is returned to retrieve the next page of results.
+// List uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks".
+func (s *WebhooksService) List(ctx context.Context, projectKey, repositorySlug string, opts *PagingOptions) (*WebhookList, error) {
+	query := addPaging(url.Values{}, opts)
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI), WithQuery(query))
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	webhooks := &WebhookList{}
+	if err := json.Unmarshal(res, webhooks); err != nil {
+		return nil, fmt.Errorf("list webhooks failed, unable to unmarshall json: %w", err)
+	}
+
+	for _, w := range webhooks.GetWebhooks() {
+		w.Session.set(resp)
+	}
+
+	return webhooks, nil
+}
+
+// All retrieves all webhooks of the repository.
+// This function handles pagination, HTTP error wrapping, and validates the server result.
+func (s *WebhooksService) All(ctx context.Context, projectKey, repositorySlug string) ([]*Webhook, error) {
+	w := []*Webhook{}
+	opts := &PagingOptions{Limit: perPageLimit}
+	err := allPages(ctx, opts, func(ctx context.Context) (*Paging, error) {
+		list, err := s.List(ctx, projectKey, repositorySlug, opts)
+		if err != nil {
+			return nil, err
+		}
+		w = append(w, list.GetWebhooks()...)
+		return &list.Paging, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Get retrieves a webhook given its ID.
+// Get uses the endpoint "GET /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks/{webhookId}".
+func (s *WebhooksService) Get(ctx context.Context, projectKey, repositorySlug string, webhookID int) (*Webhook, error) {
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI, strconv.Itoa(webhookID)))
+	if err != nil {
+		return nil, fmt.Errorf("get webhook request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	webhook := &Webhook{}
+	if err := json.Unmarshal(res, webhook); err != nil {
+		return nil, fmt.Errorf("get webhook failed, unable to unmarshall webhook json: %w", err)
+	}
+
+	webhook.Session.set(resp)
+
+	return webhook, nil
+}
+
+// Create creates a webhook.
+// Create uses the endpoint "POST /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks".
+func (s *WebhooksService) Create(ctx context.Context, projectKey, repositorySlug string, webhook *Webhook) (*Webhook, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall webhook: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("create webhook request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("create webhook failed: %s: %w", resp.Status, ErrBadRequest)
+	}
+
+	created := &Webhook{}
+	if err := json.Unmarshal(res, created); err != nil {
+		return nil, fmt.Errorf("create webhook failed, unable to unmarshall webhook json: %w", err)
+	}
+
+	created.Session.set(resp)
+
+	return created, nil
+}
+
+// Update updates the webhook with the given ID.
+// Update uses the endpoint "PUT /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks/{webhookId}".
+func (s *WebhooksService) Update(ctx context.Context, projectKey, repositorySlug string, webhookID int, webhook *Webhook) (*Webhook, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	body, err := marshallBody(webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall webhook: %v", err)
+	}
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI, strconv.Itoa(webhookID)), WithBody(body), WithHeader(header))
+	if err != nil {
+		return nil, fmt.Errorf("update webhook request creation failed: %w", err)
+	}
+	res, resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("update webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("update webhook failed: %s: %w", resp.Status, ErrBadRequest)
+	}
+
+	updated := &Webhook{}
+	if err := json.Unmarshal(res, updated); err != nil {
+		return nil, fmt.Errorf("update webhook failed, unable to unmarshall webhook json: %w", err)
+	}
+
+	updated.Session.set(resp)
+
+	return updated, nil
+}
+
+// Delete deletes the webhook with the given ID.
+// Delete uses the endpoint "DELETE /rest/api/1.0/projects/{projectKey}/repos/{repositorySlug}/webhooks/{webhookId}".
+func (s *WebhooksService) Delete(ctx context.Context, projectKey, repositorySlug string, webhookID int) error {
+	req, err := s.Client.NewRequest(ctx, http.MethodDelete, newURI(projectsURI, projectKey, RepositoriesURI, repositorySlug, webhooksURI, strconv.Itoa(webhookID)))
+	if err != nil {
+		return fmt.Errorf("delete webhook request creation failed: %w", err)
+	}
+	_, resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete webhook failed: %w", err)
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2023 The Flux CD contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// serveWebhooks serves the given webhooks of the PRJ1/repo1 repository from memory.
+func serveWebhooks(t *testing.T, mux *http.ServeMux, webhooks map[int]*Webhook) {
+	path := fmt.Sprintf("%s/%s/PRJ1/%s/repo1/%s", stashURIprefix, projectsURI, RepositoriesURI, webhooksURI)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			list := &WebhookList{Paging: Paging{IsLastPage: true}}
+			for id := 1; id <= len(webhooks)+1; id++ {
+				if webhook, ok := webhooks[id]; ok {
+					list.Webhooks = append(list.Webhooks, webhook)
+				}
+			}
+			json.NewEncoder(w).Encode(list)
+		case http.MethodPost:
+			webhook := &Webhook{}
+			if err := json.NewDecoder(r.Body).Decode(webhook); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			webhook.ID = len(webhooks) + 1
+			webhooks[webhook.ID] = webhook
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(webhook)
+		}
+	})
+	mux.HandleFunc(path+"/", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, path+"/"))
+		if _, ok := webhooks[id]; !ok {
+			http.Error(w, "The specified webhook does not exist", http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			webhook := &Webhook{}
+			if err := json.NewDecoder(r.Body).Decode(webhook); err != nil {
+				t.Fatalf("failed to decode the request body: %v", err)
+			}
+			webhook.ID = id
+			webhooks[id] = webhook
+			json.NewEncoder(w).Encode(webhook)
+		case http.MethodDelete:
+			delete(webhooks, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
+
+func TestListWebhooks(t *testing.T) {
+	webhooks := map[int]*Webhook{
+		1: {ID: 1, Name: "ci", URL: "https://ci.example.com/hook", Events: []string{"repo:refs_changed"}, Active: true},
+		2: {ID: 2, Name: "chat", URL: "https://chat.example.com/hook", Events: []string{"pr:opened", "pr:merged"}},
+	}
+	mux, client := setup(t)
+	serveWebhooks(t, mux, webhooks)
+
+	got, err := client.Webhooks.All(context.Background(), "PRJ1", "repo1")
+	if err != nil {
+		t.Fatalf("Webhooks.All returned error: %v", err)
+	}
+	if diff := cmp.Diff([]*Webhook{webhooks[1], webhooks[2]}, got); diff != "" {
+		t.Errorf("Webhooks.All returned diff (want -> got):\n%s", diff)
+	}
+
+	if err := client.Webhooks.Delete(context.Background(), "PRJ1", "repo1", 3); err != ErrNotFound {
+		t.Errorf("Webhooks.Delete returned error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestHookClient_Reconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		webhooks        map[int]*Webhook
+		req             gitprovider.DeployHookInfo
+		wantActionTaken bool
+		want            *Webhook
+	}{
+		{
+			name: "create",
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Secret: gitprovider.StringVar("s3cr3t"),
+			},
+			wantActionTaken: true,
+			want: &Webhook{
+				ID:            1,
+				Name:          "https://ci.example.com/hook",
+				URL:           "https://ci.example.com/hook",
+				Events:        []string{"repo:refs_changed"},
+				Active:        true,
+				Configuration: WebhookConfiguration{Secret: "s3cr3t"},
+			},
+		},
+		{
+			name: "up to date",
+			webhooks: map[int]*Webhook{
+				1: {ID: 1, Name: "ci", URL: "https://ci.example.com/hook", Events: []string{"pr:opened", "repo:refs_changed"}, Active: true},
+			},
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPush, gitprovider.WebhookEventPullRequest},
+			},
+			want: &Webhook{ID: 1, Name: "ci", URL: "https://ci.example.com/hook", Events: []string{"pr:opened", "repo:refs_changed"}, Active: true},
+		},
+		{
+			name: "rotated secret",
+			webhooks: map[int]*Webhook{
+				1: {ID: 1, Name: "ci", URL: "https://ci.example.com/hook", Events: []string{"repo:refs_changed"}, Active: true},
+			},
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Secret: gitprovider.StringVar("r0tated"),
+			},
+			wantActionTaken: true,
+			want: &Webhook{
+				ID:            1,
+				Name:          "ci",
+				URL:           "https://ci.example.com/hook",
+				Events:        []string{"repo:refs_changed"},
+				Active:        true,
+				Configuration: WebhookConfiguration{Secret: "r0tated"},
+			},
+		},
+		{
+			name: "update",
+			webhooks: map[int]*Webhook{
+				1: {ID: 1, Name: "ci", URL: "https://ci.example.com/hook", Events: []string{"repo:refs_changed", "pr:merged"}, Active: true},
+			},
+			req: gitprovider.DeployHookInfo{
+				URL:    "https://ci.example.com/hook",
+				Events: []gitprovider.WebhookEvent{gitprovider.WebhookEventPullRequest},
+				Active: gitprovider.BoolVar(false),
+			},
+			wantActionTaken: true,
+			want:            &Webhook{ID: 1, Name: "ci", URL: "https://ci.example.com/hook", Events: []string{"pr:merged", "pr:opened"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			if tt.webhooks == nil {
+				tt.webhooks = map[int]*Webhook{}
+			}
+			serveWebhooks(t, mux, tt.webhooks)
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"},
+				RepositoryName:  "repo1",
+			}
+			ref.SetKey("PRJ1")
+			ref.SetSlug("repo1")
+			c := &HookClient{
+				clientContext: &clientContext{client: client, host: "stash.example.com", log: logr.Discard()},
+				ref:           ref,
+			}
+
+			_, actionTaken, err := c.Reconcile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if actionTaken != tt.wantActionTaken {
+				t.Errorf("Reconcile() actionTaken = %v, want %v", actionTaken, tt.wantActionTaken)
+			}
+			if diff := cmp.Diff(tt.want, tt.webhooks[1]); diff != "" {
+				t.Errorf("Reconcile() webhook mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeployHook_Delete(t *testing.T) {
+	tests := []struct {
+		name               string
+		destructiveActions bool
+		wantErr            error
+		wantWebhooks       int
+	}{
+		{
+			name:               "destructive actions enabled",
+			destructiveActions: true,
+		},
+		{
+			name:         "destructive actions disabled",
+			wantErr:      gitprovider.ErrDestructiveCallDisallowed,
+			wantWebhooks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, client := setup(t)
+			webhooks := map[int]*Webhook{
+				1: {ID: 1, Name: "ci", URL: "https://ci.example.com/hook", Events: []string{"repo:refs_changed"}, Active: true},
+			}
+			serveWebhooks(t, mux, webhooks)
+
+			ref := gitprovider.OrgRepositoryRef{
+				OrganizationRef: gitprovider.OrganizationRef{Domain: "stash.example.com", Organization: "project1"},
+				RepositoryName:  "repo1",
+			}
+			ref.SetKey("PRJ1")
+			ref.SetSlug("repo1")
+			c := &HookClient{
+				clientContext: &clientContext{client: client, host: "stash.example.com", destructiveActions: tt.destructiveActions, log: logr.Discard()},
+				ref:           ref,
+			}
+
+			hook := newDeployHook(c, webhooks[1])
+			if err := hook.Delete(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(webhooks) != tt.wantWebhooks {
+				t.Errorf("got %d webhooks, want %d", len(webhooks), tt.wantWebhooks)
+			}
+		})
+	}
+}